@@ -2,25 +2,47 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
 	"path"
 	"runtime"
 	"time"
+	_ "time/tzdata" // A imagem de produção não tem a base de fusos; os fusos das contas dependem dela
 
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/captcha"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/exchange"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/geocoding"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/metaclient"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/ssoticaclient"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/storage"
 	"github.com/vfg2006/traffic-manager-api/internal/api"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/scheduler"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/account"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/annotating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/dashboarding"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/goaling"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/masking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/monitoring"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/privacy"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/profile"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/searching"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
 )
 
 func main() {
@@ -49,13 +71,56 @@ func main() {
 
 	accountRepo := repository.NewAccountRepository(pgConn)
 	userRepo := repository.NewUserRepository(pgConn)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(pgConn)
+	revokedTokenRepo := repository.NewRevokedTokenRepository(pgConn)
+	passwordResetRepo := repository.NewPasswordResetRepository(pgConn)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(pgConn)
+	apiKeyRepo := repository.NewAPIKeyRepository(pgConn)
+	auditLogRepo := repository.NewAuditLogRepository(pgConn)
 	adInsightRepo := repository.NewAdInsightRepository(pgConn)
+	adInsightBreakdownRepo := repository.NewAdInsightBreakdownRepository(pgConn)
 	salesInsightRepo := repository.NewSalesInsightRepository(pgConn)
+	intradayInsightRepo := repository.NewIntradayInsightRepository(pgConn)
 	monthlyAdInsightRepo := repository.NewMonthlyAdInsightRepository(pgConn)
 	monthlySalesInsightRepo := repository.NewMonthlySalesInsightRepository(pgConn)
 	storeRankingRepo := repository.NewStoreRankingRepository(pgConn)
+	webhookRepo := repository.NewWebhookRepository(pgConn)
+	privacyRepo := repository.NewPrivacyRepository(pgConn)
+	policyRepo := repository.NewPolicyRepository(pgConn)
+	roleRepo := repository.NewRoleRepository(pgConn)
+	preferencesRepo := repository.NewPreferencesRepository(pgConn)
+	savedReportRepo := repository.NewSavedReportRepository(pgConn)
+	reportScheduleRepo := repository.NewReportScheduleRepository(pgConn)
+	accountActivityRepo := repository.NewAccountActivityRepository(pgConn)
+	searchRepo := repository.NewSearchRepository(pgConn)
+	annotationRepo := repository.NewAnnotationRepository(pgConn)
+	goalRepo := repository.NewGoalRepository(pgConn)
+	anomalyRepo := repository.NewAnomalyRepository(pgConn)
+	schedulerRunRepo := repository.NewSchedulerRunRepository(pgConn)
+	schedulerLockRepo := repository.NewSchedulerLockRepository(pgConn)
+	schedulerPauseRepo := repository.NewSchedulerPauseRepository(pgConn)
+	syncScheduleRepo := repository.NewSyncScheduleRepository(pgConn)
+	rollupInsightRepo := repository.NewRollupInsightRepository(pgConn)
+	backfillChunkRepo := repository.NewBackfillChunkRepository(pgConn)
+	syncFailureRepo := repository.NewSyncFailureRepository(pgConn)
+	jobRepo := repository.NewJobRepository(pgConn)
 
-	authenticator := authenticating.NewService(userRepo, accountRepo, cfg)
+	var mailer notifying.Mailer
+	smtpMailer, err := notifying.NewSMTPMailer(cfg.Email)
+	switch {
+	case err == nil:
+		mailer = smtpMailer
+	case errors.Is(err, notifying.ErrEmailNotConfigured):
+		logrus.Warn("SMTP não configurado, relatórios agendados e links de redefinição de senha não serão enviados")
+	default:
+		logrus.WithError(err).Fatal("Erro ao configurar envio de e-mail")
+	}
+
+	auditService := auditing.NewService(auditLogRepo)
+	authenticator := authenticating.NewService(userRepo, accountRepo, refreshTokenRepo, revokedTokenRepo, passwordResetRepo, apiKeyRepo, mailer, cfg,
+		authenticating.WithAuditRecorder(auditService),
+		authenticating.WithEmailVerification(emailVerificationRepo),
+		authenticating.WithRoleRepository(roleRepo))
 
 	renderClient := config.NewRenderClient(cfg)
 
@@ -69,33 +134,179 @@ func main() {
 	ssoticaClient := ssoticaclient.NewClient(cfg)
 	ssoticaIntegrator := ssotica.New(cfg, ssoticaClient)
 
-	accountService := account.NewService(accountRepo, metaIntegrator, renderClient, ssoticaIntegrator, cfg)
+	var captchaClient captcha.Client
+	if cfg.Security.CaptchaEnabled {
+		captchaClient, err = captcha.NewClient(cfg)
+		if err != nil {
+			logrus.WithError(err).Fatal("Erro ao configurar verificação de captcha")
+		}
+	}
+
+	ratesProvider, err := exchange.NewProvider(cfg)
+	if err != nil {
+		logrus.WithError(err).Fatal("Erro ao configurar cotações de moedas")
+	}
+
+	accountService := account.NewService(accountRepo, metaIntegrator, renderClient, ssoticaIntegrator, geocoding.NewClient(cfg), cfg,
+		account.WithAuditRecorder(auditService))
+	activityService := activity.NewService(accountActivityRepo)
 
 	// Inicializa o serviço de insights com suporte a cache
-	insightService := insighting.NewService(cfg, metaIntegrator, ssoticaIntegrator, accountRepo)
-	cachedInsightService := insightService.(*insighting.Service).WithCache(
-		adInsightRepo,
-		salesInsightRepo,
-		monthlyAdInsightRepo,
-		monthlySalesInsightRepo,
+	insightService := insighting.NewService(
+		cfg,
+		metaIntegrator,
+		ssoticaIntegrator,
+		accountRepo,
+		insighting.WithMonthlyInsights(monthlyAdInsightRepo, monthlySalesInsightRepo),
+		insighting.WithAnnotations(annotationRepo),
+		insighting.WithGoals(goalRepo),
+		insighting.WithCurrencyConverter(ratesProvider),
 	)
+	var cachedOptions []insighting.CachedOption
+	if cfg.IntradaySync.Enabled {
+		cachedOptions = append(cachedOptions, insighting.WithIntradayInsights(intradayInsightRepo, cfg.IntradaySync.MaxAge))
+	}
+	cachedInsightService := insighting.NewCachedService(insightService, adInsightRepo, salesInsightRepo, cachedOptions...)
+
+	annotationService := annotating.NewService(annotationRepo)
+	goalService := goaling.NewService(goalRepo)
+
+	rollupService := insighting.NewRollupService(rollupInsightRepo, monthlyAdInsightRepo, monthlySalesInsightRepo, accountRepo, ratesProvider, cfg.ResultMetrics.GrossMargin)
+
+	catalogService := insighting.NewCatalogService(metaIntegrator, accountRepo, cfg.CampaignCatalog.CacheTTL)
+
+	breakdownService := insighting.NewBreakdownService(metaIntegrator, accountRepo, adInsightBreakdownRepo, cfg.InsightBreakdowns.CacheTTL)
+
+	businessManagerInsightService := insighting.NewBusinessManagerInsightService(cachedInsightService, accountRepo, ratesProvider, cfg.ResultMetrics.GrossMargin)
+
+	anomalyService := insighting.NewAnomalyService(adInsightRepo, anomalyRepo, cfg.AnomalyDetection.Threshold)
+
+	forecastService := insighting.NewForecastService(accountRepo, adInsightRepo, salesInsightRepo, goalRepo, storeRankingRepo)
+
+	reachOverviewService := insighting.NewReachOverviewService(insightService, cfg.ReachOverview.CacheTTL, cfg.ReachOverview.MaxConcurrency)
 
 	rankingService := ranking.NewStoreRankingService(storeRankingRepo)
 
+	dashboardService := dashboarding.NewService(cachedInsightService, rankingService, accountRepo, adInsightRepo, salesInsightRepo)
+
+	deckService := reporting.NewDeckService(accountRepo, monthlyAdInsightRepo, monthlySalesInsightRepo)
+	workbookService := reporting.NewWorkbookService(cachedInsightService)
+	pdfReportService := reporting.NewPDFReportService(
+		cachedInsightService,
+		reporting.PDFBrand{Name: cfg.PDFReport.BrandName, Color: cfg.PDFReport.BrandColor},
+		cfg.PDFReport.JobTTL,
+		cfg.PDFReport.MaxConcurrentJobs,
+	)
+	dailyExportService := reporting.NewDailyExportService(accountRepo, adInsightRepo, salesInsightRepo)
+
+	savedReportService := reporting.NewSavedReportService(savedReportRepo, accountRepo, userRepo, cachedInsightService, breakdownService)
+	reportScheduleService := reporting.NewReportScheduleService(reportScheduleRepo, savedReportRepo)
+
+	webhookService := notifying.NewService(webhookRepo, cfg)
+
+	erasureService := privacy.NewService(privacyRepo)
+	policyService := privacy.NewPolicyService(policyRepo, cfg)
+
+	fieldMasker := masking.NewService(roleRepo, cfg.Security.FieldMaskingCacheTTL)
+	permissionPolicy := authorizing.NewService(roleRepo, cfg.Security.PermissionsCacheTTL)
+	roleService := authorizing.NewRoleService(roleRepo, permissionPolicy, auditService)
+
+	var (
+		fileStorage   storage.Storage
+		uploadedFiles http.Handler
+	)
+	switch cfg.Storage.Backend {
+	case "local":
+		localStorage, err := storage.NewLocalStorage(cfg.Storage)
+		switch {
+		case err == nil:
+			fileStorage = localStorage
+			uploadedFiles = localStorage.Handler()
+		case errors.Is(err, storage.ErrNotConfigured):
+			logrus.Warn("Storage local sem STORAGE_LOCAL_DIR, upload de avatar desabilitado")
+		default:
+			logrus.WithError(err).Fatal("Erro ao configurar storage local")
+		}
+	case "s3":
+		s3Storage, err := storage.NewS3Storage(cfg.Storage)
+		switch {
+		case err == nil:
+			fileStorage = s3Storage
+		case errors.Is(err, storage.ErrNotConfigured):
+			logrus.Warn("Storage S3 não configurado, upload de avatar desabilitado")
+		default:
+			logrus.WithError(err).Fatal("Erro ao configurar storage S3")
+		}
+	default:
+		logrus.Fatalf("STORAGE_BACKEND inválido: %q (use s3 ou local)", cfg.Storage.Backend)
+	}
+
+	avatarService := profile.NewAvatarService(userRepo, fileStorage, cfg.Storage.AvatarSize)
+	preferencesService := profile.NewPreferencesService(preferencesRepo, userRepo)
+
+	searchService := searching.NewService(searchRepo)
+
+	syncScheduleService := syncing.NewScheduleService(syncScheduleRepo)
+	syncRunService := syncing.NewRunService(jobRepo)
+	syncPauseService := syncing.NewPauseService(schedulerPauseRepo, auditService)
+
+	// Alterações de status e credenciais das contas chegam aos agendadores em execução via LISTEN/NOTIFY
+	accountChanges := scheduler.NewAccountChangeTracker(accountRepo)
+	if cfg.AccountChanges.ListenEnabled {
+		go func() {
+			if err := postgres.Listen(ctx, cfg.Database.DSN, scheduler.AccountChangesChannel, accountChanges.HandleChange, accountChanges.Reset); err != nil {
+				logrus.WithError(err).Error("Erro ao assinar as alterações das contas")
+			}
+		}()
+	}
+
+	// Limites de requisições por integrador, compartilhados entre os agendadores
+	metaRateLimiter := scheduler.NewRateLimiter(domain.SyncSourceMeta, cfg.SyncRateLimit.MetaRequestsPerSecond, cfg.SyncRateLimit.MetaBurst, cfg.SyncRateLimit.Cooldown)
+	ssoticaRateLimiter := scheduler.NewRateLimiter(domain.SyncSourceSSOtica, cfg.SyncRateLimit.SSOticaRequestsPerSecond, cfg.SyncRateLimit.SSOticaBurst, cfg.SyncRateLimit.Cooldown)
+
+	// Histórico, lock entre instâncias, pausa e registro na tabela jobs, comuns aos agendadores de sincronização
+	jobOptions := []scheduler.JobOption{
+		scheduler.WithRunHistory(schedulerRunRepo, cfg.SchedulerCatchUp),
+		scheduler.WithJobRepository(jobRepo),
+		scheduler.WithInstanceLock(schedulerLockRepo),
+		scheduler.WithPauses(schedulerPauseRepo),
+	}
+
 	// Inicializa os agendadores de sincronização separados
 	metaInsightSyncService := scheduler.NewMetaInsightSyncService(
 		accountRepo,
 		adInsightRepo,
 		cachedInsightService, // Implementa MetaInsighter
 		cfg,
-	)
+		jobOptions...,
+	).WithActivityRecorder(activityService).
+		WithPrioritizer(activityService).
+		WithDispatcher(webhookService).
+		WithAccountSchedules(syncScheduleRepo, cfg.AccountSyncSchedule.CheckInterval).
+		WithBackfill(backfillChunkRepo).
+		WithSyncFailures(syncFailureRepo).
+		WithRateLimiter(metaRateLimiter).
+		WithAccountChanges(accountChanges)
 
 	ssoticaInsightSyncService := scheduler.NewSSOticaInsightSyncService(
 		accountRepo,
 		salesInsightRepo,
 		cachedInsightService, // Implementa SSOticaInsighter
 		cfg,
-	)
+		jobOptions...,
+	).WithActivityRecorder(activityService).
+		WithPrioritizer(activityService).
+		WithDispatcher(webhookService).
+		WithAccountSchedules(syncScheduleRepo, cfg.AccountSyncSchedule.CheckInterval).
+		WithBackfill(backfillChunkRepo).
+		WithSyncFailures(syncFailureRepo).
+		WithRateLimiter(ssoticaRateLimiter).
+		WithAccountChanges(accountChanges)
+
+	syncFailureService := syncing.NewFailureService(syncFailureRepo, map[string]syncing.FailureRequeuer{
+		domain.SyncSourceMeta:    metaInsightSyncService,
+		domain.SyncSourceSSOtica: ssoticaInsightSyncService,
+	}, auditService)
 
 	// Inicializa o agendador de sincronização mensal
 	monthlyInsightsSyncService := scheduler.NewMonthlyInsightsSyncService(
@@ -105,51 +316,188 @@ func main() {
 		cachedInsightService, // Implementa MetaInsighter
 		cachedInsightService, // Implementa SSOticaInsighter
 		cfg,
+		jobOptions...,
+	).WithDispatcher(webhookService).
+		WithRateLimiters(metaRateLimiter, ssoticaRateLimiter).
+		WithAccountChanges(accountChanges)
+
+	// Inicializa a sincronização do dia em andamento, combinada aos insights diários nos dashboards
+	intradaySyncService := scheduler.NewIntradaySyncService(
+		accountRepo,
+		intradayInsightRepo,
+		cachedInsightService, // Implementa MetaInsighter
+		cachedInsightService, // Implementa SSOticaInsighter
+		cfg,
+		scheduler.WithInstanceLock(schedulerLockRepo),
+	).WithPrioritizer(activityService).
+		WithRateLimiters(metaRateLimiter, ssoticaRateLimiter)
+
+	// Inicializa o agendador de consolidação trimestral e anual
+	rollupInsightsSyncService := scheduler.NewRollupInsightsSyncService(
+		accountRepo,
+		rollupService,
+		cfg,
+		scheduler.WithRunHistory(schedulerRunRepo, cfg.SchedulerCatchUp),
+		scheduler.WithInstanceLock(schedulerLockRepo),
+	)
+
+	// Inicializa a detecção diária de anomalias de investimento e custo por resultado
+	anomalyDetectionService := scheduler.NewAnomalyDetectionService(
+		accountRepo,
+		anomalyService,
+		cfg,
+		scheduler.WithRunHistory(schedulerRunRepo, cfg.SchedulerCatchUp),
+		scheduler.WithInstanceLock(schedulerLockRepo),
 	)
 
+	// Inicializa a verificação diária do ritmo de investimento das contas com orçamento
+	budgetPacingService := scheduler.NewBudgetPacingService(
+		accountRepo,
+		insightService,
+		cfg,
+		scheduler.WithRunHistory(schedulerRunRepo, cfg.SchedulerCatchUp),
+		scheduler.WithInstanceLock(schedulerLockRepo),
+	).WithDispatcher(webhookService)
+
 	topRankingAccountsSyncService := scheduler.NewTopRankingAccountsService(
 		accountRepo,
 		storeRankingRepo,
 		salesInsightRepo,
 		ssoticaIntegrator,
 		cfg,
+		jobOptions...,
+	).WithDispatcher(webhookService).
+		WithRateLimiter(ssoticaRateLimiter)
+
+	reportScheduleRunner := scheduler.NewReportScheduleService(
+		reportScheduleRepo,
+		savedReportRepo,
+		userRepo,
+		savedReportService,
+		fieldMasker,
+		permissionPolicy,
+		mailer,
+		webhookService,
+		cfg,
+		scheduler.WithInstanceLock(schedulerLockRepo),
 	)
 
-	// Inicia os agendadores em background
-	if err := metaInsightSyncService.Start(ctx); err != nil {
+	// Os serviços registram as suas rotinas no agendador único da aplicação, iniciado depois de todos os registros
+	// As sincronizações usam o contexto do agendador, cancelado no encerramento depois da espera pelas execuções
+	appScheduler := scheduler.NewScheduler(ctx)
+	syncCtx := appScheduler.Context()
+	if err := metaInsightSyncService.Start(syncCtx, appScheduler); err != nil {
 		logrus.WithError(err).Error("Erro ao iniciar o agendador de sincronização de insights do Meta")
 	} else {
 		logrus.Info("Agendador de sincronização de insights do Meta iniciado com sucesso")
 	}
 
-	if err := ssoticaInsightSyncService.Start(ctx); err != nil {
+	if err := ssoticaInsightSyncService.Start(syncCtx, appScheduler); err != nil {
 		logrus.WithError(err).Error("Erro ao iniciar o agendador de sincronização de insights do SSOtica")
 	} else {
 		logrus.Info("Agendador de sincronização de insights do SSOtica iniciado com sucesso")
 	}
 
-	if err := monthlyInsightsSyncService.Start(ctx); err != nil {
+	if err := monthlyInsightsSyncService.Start(syncCtx, appScheduler); err != nil {
 		logrus.WithError(err).Error("Erro ao iniciar o agendador de sincronização mensal de insights")
 	} else {
 		logrus.Info("Agendador de sincronização mensal de insights iniciado com sucesso")
 	}
 
-	if err := topRankingAccountsSyncService.Start(ctx); err != nil {
+	if err := intradaySyncService.Start(syncCtx, appScheduler); err != nil {
+		logrus.WithError(err).Error("Erro ao iniciar o agendador de sincronização intradiária")
+	} else {
+		logrus.Info("Agendador de sincronização intradiária iniciado com sucesso")
+	}
+
+	if err := rollupInsightsSyncService.Start(syncCtx, appScheduler); err != nil {
+		logrus.WithError(err).Error("Erro ao iniciar o agendador de insights trimestrais e anuais")
+	} else {
+		logrus.Info("Agendador de insights trimestrais e anuais iniciado com sucesso")
+	}
+
+	if err := anomalyDetectionService.Start(syncCtx, appScheduler); err != nil {
+		logrus.WithError(err).Error("Erro ao iniciar o agendador de detecção de anomalias")
+	} else {
+		logrus.Info("Agendador de detecção de anomalias iniciado com sucesso")
+	}
+
+	if err := budgetPacingService.Start(syncCtx, appScheduler); err != nil {
+		logrus.WithError(err).Error("Erro ao iniciar o agendador de verificação de ritmo de investimento")
+	} else {
+		logrus.Info("Agendador de verificação de ritmo de investimento iniciado com sucesso")
+	}
+
+	if err := topRankingAccountsSyncService.Start(syncCtx, appScheduler); err != nil {
 		logrus.WithError(err).Error("Erro ao iniciar o agendador de sincronização de top ranking de contas")
 	} else {
 		logrus.Info("Agendador de sincronização de top ranking de contas iniciado com sucesso")
 	}
 
+	if err := reportScheduleRunner.Start(syncCtx, appScheduler); err != nil {
+		logrus.WithError(err).Error("Erro ao iniciar o agendador de relatórios")
+	} else {
+		logrus.Info("Agendador de relatórios iniciado com sucesso")
+	}
+
+	appScheduler.Start()
+
+	// Verificações ativas das dependências externas, expostas em /readyz e /v1/admin/dependencies
+	dependencyMonitor := monitoring.NewMonitor(
+		cfg.HealthProbes.Interval,
+		cfg.HealthProbes.Timeout,
+		monitoring.Probe{Name: monitoring.DependencyDatabase, Critical: true, Check: pgConn.Ping},
+		monitoring.Probe{Name: monitoring.DependencyMeta, Check: metaIntegrator.Ping},
+		monitoring.SSOticaProbe(accountRepo, ssoticaIntegrator),
+	)
+	dependencyMonitor.Start(ctx)
+
 	server, err := api.New(
 		cfg,
 		cachedInsightService,
+		annotationService,
+		goalService,
+		dashboardService,
+		rollupService,
 		accountService,
+		activityService,
+		catalogService,
+		breakdownService,
+		businessManagerInsightService,
+		anomalyService,
+		forecastService,
+		reachOverviewService,
 		rankingService,
+		deckService,
+		workbookService,
+		pdfReportService,
+		dailyExportService,
+		savedReportService,
+		reportScheduleService,
+		webhookService,
+		erasureService,
+		policyService,
+		auditService,
+		fieldMasker,
+		permissionPolicy,
+		roleService,
+		avatarService,
+		preferencesService,
+		uploadedFiles,
+		searchService,
+		syncScheduleService,
+		syncRunService,
+		syncPauseService,
+		syncFailureService,
 		authenticator,
+		captchaClient,
 		metaInsightSyncService,        // Serviço de sincronização Meta
 		ssoticaInsightSyncService,     // Serviço de sincronização SSOtica
 		monthlyInsightsSyncService,    // Serviço de sincronização mensal
 		topRankingAccountsSyncService, // Serviço de sincronização de top ranking de contas
+		reportScheduleRunner,          // Serviço de envio de relatórios agendados
+		appScheduler,                  // Agendador das rotinas de todos os serviços
+		dependencyMonitor,
 	)
 	if err != nil {
 		logrus.Fatal(err)
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// Listen assina o canal de notificações (LISTEN/NOTIFY) e chama handle com o payload de cada notificação
+// até o contexto ser cancelado. A conexão é restabelecida automaticamente; como notificações enviadas
+// durante a queda são perdidas, onReconnect é chamado após cada reconexão.
+func Listen(ctx context.Context, dsn, channel string, handle func(payload string), onReconnect func()) error {
+	listener := pq.NewListener(dsn, listenerMinReconnect, listenerMaxReconnect, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logrus.WithError(err).WithField("channel", channel).Warn("Erro na conexão de notificações do banco")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// Notificação nil indica que a conexão foi restabelecida
+			if notification == nil {
+				if onReconnect != nil {
+					onReconnect()
+				}
+				continue
+			}
+			handle(notification.Extra)
+		case <-ticker.C:
+			// Verificar a conexão periodicamente para detectar quedas silenciosas
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+// URLs de verificação dos provedores suportados. Todos seguem o mesmo contrato de "siteverify".
+const (
+	HCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	ReCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+	TurnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+type Client interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+type CaptchaClient struct {
+	httpClient *http.Client
+	secret     string
+	verifyURL  string
+}
+
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// NewClient cria o cliente de verificação para o provedor configurado (hcaptcha, recaptcha ou turnstile).
+// CAPTCHA_VERIFY_URL sobrescreve a URL do provedor, útil para testes.
+func NewClient(cfg *config.Config) (Client, error) {
+	verifyURL := cfg.Security.CaptchaVerifyURL
+	if verifyURL == "" {
+		switch strings.ToLower(cfg.Security.CaptchaProvider) {
+		case "hcaptcha":
+			verifyURL = HCaptchaVerifyURL
+		case "recaptcha":
+			verifyURL = ReCaptchaVerifyURL
+		case "turnstile":
+			verifyURL = TurnstileVerifyURL
+		default:
+			return nil, fmt.Errorf("provedor de captcha não suportado: %s", cfg.Security.CaptchaProvider)
+		}
+	}
+
+	if cfg.Security.CaptchaSecret == "" {
+		return nil, fmt.Errorf("CAPTCHA_SECRET não configurado")
+	}
+
+	return &CaptchaClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		secret:    cfg.Security.CaptchaSecret,
+		verifyURL: verifyURL,
+	}, nil
+}
+
+// Verify valida o token de captcha informado pelo cliente junto ao provedor
+func (c *CaptchaClient) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", c.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("erro ao criar requisição de verificação do captcha: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("verificação do captcha retornou status %d", resp.StatusCode)
+	}
+
+	var result verifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("erro ao decodificar resposta do captcha: %w", err)
+	}
+
+	return result.Success, nil
+}
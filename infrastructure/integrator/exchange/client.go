@@ -0,0 +1,205 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// FrankfurterURL é a API pública de cotações do Banco Central Europeu
+const FrankfurterURL = "https://api.frankfurter.app"
+
+const (
+	ProviderStatic = "static"
+	ProviderHTTP   = "http"
+)
+
+var ErrRateNotFound = errors.New("cotação não encontrada")
+
+type RatesProvider interface {
+	// Rate retorna quantas unidades de to valem uma unidade de from
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// NewProvider cria o provedor configurado em CURRENCY_RATES_PROVIDER, com cache das cotações
+func NewProvider(cfg *config.Config) (RatesProvider, error) {
+	var provider RatesProvider
+	switch cfg.CurrencyRates.Provider {
+	case ProviderHTTP:
+		provider = NewFrankfurterClient(cfg.CurrencyRates.URL)
+	case ProviderStatic, "":
+		static, err := NewStaticRates(cfg.CurrencyRates.Static)
+		if err != nil {
+			return nil, err
+		}
+		provider = static
+	default:
+		return nil, fmt.Errorf("provedor de cotações desconhecido: %s", cfg.CurrencyRates.Provider)
+	}
+
+	return NewCachedProvider(provider, cfg.CurrencyRates.CacheTTL), nil
+}
+
+// StaticRates usa cotações fixas em reais por unidade de cada moeda
+type StaticRates struct {
+	perBRL map[string]float64
+}
+
+// NewStaticRates lê as cotações no formato MOEDA=valor separadas por vírgula (ex: USD=5.40,EUR=6.10)
+func NewStaticRates(spec string) (*StaticRates, error) {
+	rates := map[string]float64{domain.DefaultCurrency: 1}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		currency, value, ok := strings.Cut(pair, "=")
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !ok || !domain.IsValidCurrency(currency) {
+			return nil, fmt.Errorf("cotação inválida em CURRENCY_RATES_STATIC: %q", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("cotação inválida em CURRENCY_RATES_STATIC: %q", pair)
+		}
+		rates[currency] = rate
+	}
+
+	return &StaticRates{perBRL: rates}, nil
+}
+
+func (s *StaticRates) Rate(_ context.Context, from, to string) (float64, error) {
+	fromRate, ok := s.perBRL[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrRateNotFound, from)
+	}
+	toRate, ok := s.perBRL[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrRateNotFound, to)
+	}
+	return fromRate / toRate, nil
+}
+
+// FrankfurterClient consulta a cotação do dia em uma API compatível com o Frankfurter
+type FrankfurterClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+type latestResponse struct {
+	Rates map[string]float64 `json:"rates"`
+}
+
+// NewFrankfurterClient cria o cliente HTTP de cotações. CURRENCY_RATES_URL permite apontar para uma instância própria.
+func NewFrankfurterClient(baseURL string) *FrankfurterClient {
+	if baseURL == "" {
+		baseURL = FrankfurterURL
+	}
+
+	return &FrankfurterClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (c *FrankfurterClient) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/latest?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao criar requisição de cotação: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao consultar cotação: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("consulta de cotação retornou status %d", resp.StatusCode)
+	}
+
+	var latest latestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return 0, fmt.Errorf("erro ao decodificar resposta da cotação: %w", err)
+	}
+
+	rate, ok := latest.Rates[to]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: %s para %s", ErrRateNotFound, from, to)
+	}
+	return rate, nil
+}
+
+// CachedProvider guarda as cotações por par de moedas durante ttl
+type CachedProvider struct {
+	provider RatesProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	rates map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+func NewCachedProvider(provider RatesProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		rates:    make(map[string]cachedRate),
+	}
+}
+
+func (c *CachedProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	key := from + ":" + to
+	c.mu.Lock()
+	cached, ok := c.rates[key]
+	c.mu.Unlock()
+	if ok && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return cached.rate, nil
+	}
+
+	rate, err := c.provider.Rate(ctx, from, to)
+	if err != nil {
+		// Uma cotação vencida é melhor que deixar as métricas sem conversão
+		if ok {
+			return cached.rate, nil
+		}
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.rates[key] = cachedRate{rate: rate, fetchedAt: c.now()}
+	c.mu.Unlock()
+
+	return rate, nil
+}
@@ -0,0 +1,99 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+// NominatimURL é o serviço público de geocodificação do OpenStreetMap
+const NominatimURL = "https://nominatim.openstreetmap.org"
+
+var ErrAddressNotFound = errors.New("endereço não encontrado")
+
+type Geocoder interface {
+	// Geocode retorna a latitude e a longitude do endereço informado
+	Geocode(ctx context.Context, address string) (float64, float64, error)
+}
+
+type NominatimClient struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+}
+
+type searchResult struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// NewClient cria o cliente de geocodificação compatível com a API de busca do Nominatim.
+// GEOCODING_URL permite apontar para uma instância própria.
+func NewClient(cfg *config.Config) Geocoder {
+	baseURL := cfg.Geocoding.URL
+	if baseURL == "" {
+		baseURL = NominatimURL
+	}
+
+	return &NominatimClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		userAgent: cfg.Geocoding.UserAgent,
+	}
+}
+
+// Geocode busca o endereço restrito ao Brasil e usa o resultado mais relevante
+func (c *NominatimClient) Geocode(ctx context.Context, address string) (float64, float64, error) {
+	query := url.Values{}
+	query.Set("q", address)
+	query.Set("format", "json")
+	query.Set("limit", "1")
+	query.Set("countrycodes", "br")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/search?"+query.Encode(), nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("erro ao criar requisição de geocodificação: %w", err)
+	}
+	// A política de uso do Nominatim exige um User-Agent que identifique a aplicação
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept-Language", "pt-BR")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("erro ao geocodificar endereço: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("geocodificação retornou status %d", resp.StatusCode)
+	}
+
+	var results []searchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, 0, fmt.Errorf("erro ao decodificar resposta da geocodificação: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, 0, ErrAddressNotFound
+	}
+
+	latitude, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("latitude inválida na resposta da geocodificação: %w", err)
+	}
+	longitude, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("longitude inválida na resposta da geocodificação: %w", err)
+	}
+
+	return latitude, longitude, nil
+}
@@ -0,0 +1,127 @@
+package meta
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	metadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+var genderLabels = map[string]string{
+	"male":    "Masculino",
+	"female":  "Feminino",
+	"unknown": "Não informado",
+}
+
+var platformLabels = map[string]string{
+	"facebook":         "Facebook",
+	"instagram":        "Instagram",
+	"audience_network": "Audience Network",
+	"messenger":        "Messenger",
+	"threads":          "Threads",
+	"unknown":          "Não informado",
+}
+
+// GetAdAccountBreakdowns obtém as métricas da conta no período quebradas por faixa etária, gênero e
+// plataforma de veiculação. Idade e gênero vêm na mesma consulta e são consolidados separadamente.
+func (s *MetaIntegrator) GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdowns, error) {
+	demographics, err := s.Client.GetAdAccountBreakdownInsights(ctx, accountID, filters, "age,gender")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id": accountID,
+			"error":      err.Error(),
+		}).Error("insights: failed to get demographic breakdowns from API")
+		return nil, err
+	}
+
+	platforms, err := s.Client.GetAdAccountBreakdownInsights(ctx, accountID, filters, "publisher_platform")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id": accountID,
+			"error":      err.Error(),
+		}).Error("insights: failed to get platform breakdowns from API")
+		return nil, err
+	}
+
+	age := aggregateBreakdown(demographics, func(i *metadomain.BreakdownInsight) string { return i.Age }, nil)
+	gender := aggregateBreakdown(demographics, func(i *metadomain.BreakdownInsight) string { return i.Gender }, genderLabels)
+	platform := aggregateBreakdown(platforms, func(i *metadomain.BreakdownInsight) string { return i.PublisherPlatform }, platformLabels)
+
+	// Faixas etárias seguem a ordem natural ("13-17", "18-24", ..., "65+"); as demais, o investimento
+	sort.SliceStable(age, func(i, j int) bool { return age[i].Key < age[j].Key })
+	sortBySpend(gender)
+	sortBySpend(platform)
+
+	return &domain.AdAccountBreakdowns{
+		Age:      age,
+		Gender:   gender,
+		Platform: platform,
+	}, nil
+}
+
+// aggregateBreakdown soma as linhas por campanha no segmento retornado por key. O resultado de cada
+// linha é calculado pelo objetivo da campanha, como no total da conta.
+func aggregateBreakdown(insights []metadomain.BreakdownInsight, key func(*metadomain.BreakdownInsight) string, labels map[string]string) []*domain.BreakdownSegment {
+	segments := make(map[string]*domain.BreakdownSegment)
+	order := make([]string, 0)
+
+	var totalSpend float64
+	var totalResult int
+	for i := range insights {
+		insight := &insights[i]
+
+		k := key(insight)
+		if k == "" {
+			k = "unknown"
+		}
+
+		segment, ok := segments[k]
+		if !ok {
+			label := k
+			if translated, ok := labels[k]; ok {
+				label = translated
+			} else if k == "unknown" {
+				label = "Não informado"
+			}
+			segment = &domain.BreakdownSegment{Key: k, Label: label}
+			segments[k] = segment
+			order = append(order, k)
+		}
+
+		spend, _ := strconv.ParseFloat(insight.Spend, 64)
+		impressions, _ := strconv.Atoi(insight.Impressions)
+		result := insight.GetResult()
+
+		segment.Spend += spend
+		segment.Impressions += impressions
+		segment.Result += result
+		totalSpend += spend
+		totalResult += result
+	}
+
+	result := make([]*domain.BreakdownSegment, 0, len(order))
+	for _, k := range order {
+		segment := segments[k]
+		if segment.Result > 0 {
+			segment.CostPerResult = utils.RoundWithTwoDecimalPlace(segment.Spend / float64(segment.Result))
+		}
+		if totalSpend > 0 {
+			segment.SpendShare = utils.RoundWithTwoDecimalPlace(segment.Spend / totalSpend * 100)
+		}
+		if totalResult > 0 {
+			segment.ResultShare = utils.RoundWithTwoDecimalPlace(float64(segment.Result) / float64(totalResult) * 100)
+		}
+		segment.Spend = utils.RoundWithTwoDecimalPlace(segment.Spend)
+		result = append(result, segment)
+	}
+
+	return result
+}
+
+func sortBySpend(segments []*domain.BreakdownSegment) {
+	sort.SliceStable(segments, func(i, j int) bool { return segments[i].Spend > segments[j].Spend })
+}
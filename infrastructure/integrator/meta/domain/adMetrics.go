@@ -5,9 +5,12 @@ type AdAccountMetrics struct {
 	Actions     map[string]float64 `json:"actions"`
 	Clicks      int                `json:"clicks"`
 	Conversions int                `json:"conversions"`
+	CPC         float64            `json:"cpc"`
+	CPM         float64            `json:"cpm"`
 	CTR         float64            `json:"ctr"`
 	Frequency   float64            `json:"frequency"`
 	Impressions int                `json:"impressions"`
+	LinkClicks  int                `json:"link_clicks"`
 	Name        string             `json:"account_name"`
 	Objective   string             `json:"objective"`
 	Reach       int                `json:"reach"`
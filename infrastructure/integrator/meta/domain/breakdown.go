@@ -0,0 +1,10 @@
+package metadomain
+
+// BreakdownInsight é uma linha de insights de campanha quebrada por idade, gênero ou plataforma.
+// Apenas as colunas da quebra solicitada vêm preenchidas.
+type BreakdownInsight struct {
+	CampaignInsight
+	Age               string `json:"age"`
+	Gender            string `json:"gender"`
+	PublisherPlatform string `json:"publisher_platform"`
+}
@@ -20,23 +20,39 @@ type Cursors struct {
 
 type Paging struct {
 	Cursors Cursors `json:"cursors"`
+	Next    string  `json:"next"`
+}
+
+// CampaignCatalogEntry é uma campanha com orçamento e as métricas dos últimos 7 dias (campo insights expandido)
+type CampaignCatalogEntry struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Objective       string `json:"objective"`
+	Status          string `json:"status"`
+	EffectiveStatus string `json:"effective_status"`
+	DailyBudget     string `json:"daily_budget"`
+	LifetimeBudget  string `json:"lifetime_budget"`
+	Insights        *struct {
+		Data []CampaignInsight `json:"data"`
+	} `json:"insights"`
 }
 
 type CampaignInsight struct {
-	AccountID      string   `json:"account_id"`
-	AccountName    string   `json:"account_name"`
-	Actions        []Action `json:"actions"`
-	CampaignID     string   `json:"campaign_id"`
-	CampaignName   string   `json:"campaign_name"`
-	Clicks         string   `json:"clicks"`
-	CostPerActions []Action `json:"cost_per_action_type"`
-	DateStart      string   `json:"date_start"`
-	DateStop       string   `json:"date_stop"`
-	Frequency      string   `json:"frequency"`
-	Impressions    string   `json:"impressions"`
-	Objective      string   `json:"objective"`
-	Reach          string   `json:"reach"`
-	Spend          string   `json:"spend"`
+	AccountID        string   `json:"account_id"`
+	AccountName      string   `json:"account_name"`
+	Actions          []Action `json:"actions"`
+	CampaignID       string   `json:"campaign_id"`
+	CampaignName     string   `json:"campaign_name"`
+	Clicks           string   `json:"clicks"`
+	CostPerActions   []Action `json:"cost_per_action_type"`
+	DateStart        string   `json:"date_start"`
+	DateStop         string   `json:"date_stop"`
+	Frequency        string   `json:"frequency"`
+	Impressions      string   `json:"impressions"`
+	InlineLinkClicks string   `json:"inline_link_clicks"`
+	Objective        string   `json:"objective"`
+	Reach            string   `json:"reach"`
+	Spend            string   `json:"spend"`
 }
 
 func (c *CampaignInsight) GetResult() int {
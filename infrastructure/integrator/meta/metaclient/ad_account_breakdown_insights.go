@@ -0,0 +1,82 @@
+package metaclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// breakdownInsightsMaxPages limita a paginação das quebras (uma linha por campanha e segmento)
+const breakdownInsightsMaxPages = 10
+
+type ResponseAdAccountBreakdownInsights struct {
+	Data   []metadomain.BreakdownInsight `json:"data"`
+	Paging metadomain.Paging             `json:"paging"`
+}
+
+// GetAdAccountBreakdownInsights obtém os insights das campanhas da conta no período quebrados pelas
+// dimensões informadas (ex: "age,gender" ou "publisher_platform"). A consulta é feita no nível de
+// campanha para que o resultado possa ser calculado a partir do objetivo de cada uma.
+func (c *MetaClient) GetAdAccountBreakdownInsights(ctx context.Context, accountID string, filters *domain.InsigthFilters, breakdowns string) ([]metadomain.BreakdownInsight, error) {
+	// Garantir que o token seja válido antes de fazer a requisição
+	if err := c.EnsureValidToken(); err != nil {
+		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
+	}
+
+	timeRange := fmt.Sprintf("{\"since\":\"%s\",\"until\":\"%s\"}", filters.StartDate.Format(time.DateOnly), filters.EndDate.Format(time.DateOnly))
+
+	params := url.Values{}
+	params.Add("level", "campaign")
+	params.Add("fields", "campaign_id,objective,spend,impressions,reach,actions,cost_per_action_type")
+	params.Add("breakdowns", breakdowns)
+	params.Add("time_range", timeRange)
+	params.Add("limit", "200")
+	params.Add("access_token", c.Cfg.Meta.AccessToken)
+
+	nextURL := fmt.Sprintf("%s/act_%s/insights?%s", c.Cfg.Meta.URL, accountID, params.Encode())
+
+	insights := make([]metadomain.BreakdownInsight, 0)
+	for page := 0; nextURL != "" && page < breakdownInsightsMaxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", nextURL, nil)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao criar a requisição")
+			return nil, err
+		}
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao fazer a requisição")
+			return nil, err
+		}
+
+		// Usar o manipulador de resposta que verifica tokens expirados
+		body, err := c.HandleResponse(resp)
+		resp.Body.Close()
+		if err != nil {
+			// Se o erro indica que o token foi renovado, tentar novamente
+			if err.Error() == "token expirado e renovado, por favor tente novamente" {
+				return c.GetAdAccountBreakdownInsights(ctx, accountID, filters, breakdowns)
+			}
+			return nil, err
+		}
+
+		var response ResponseAdAccountBreakdownInsights
+		if err := json.Unmarshal(body, &response); err != nil {
+			logrus.WithError(err).Error("Erro ao decodificar JSON")
+			return nil, err
+		}
+
+		insights = append(insights, response.Data...)
+		nextURL = response.Paging.Next
+	}
+
+	return insights, nil
+}
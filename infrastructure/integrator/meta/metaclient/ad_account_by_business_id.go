@@ -38,7 +38,7 @@ func (c *MetaClient) GetAdAccountsByBusinessID(businessID string) ([]metadomain.
 	baseURL := fmt.Sprintf("%s/%s/owned_ad_accounts", c.Cfg.Meta.URL, businessID)
 
 	params := url.Values{}
-	params.Add("fields", "id,name")
+	params.Add("fields", "id,name,currency")
 	params.Add("access_token", c.Cfg.Meta.AccessToken)
 
 	url := baseURL + "?" + params.Encode()
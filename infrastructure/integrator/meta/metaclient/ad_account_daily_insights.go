@@ -0,0 +1,80 @@
+package metaclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// dailyInsightsMaxPages limita a paginação do resumo diário (o período de sincronização é curto)
+const dailyInsightsMaxPages = 5
+
+type ResponseAdAccountDailyInsights struct {
+	Data   []metadomain.AdAccountInsight `json:"data"`
+	Paging metadomain.Paging             `json:"paging"`
+}
+
+// GetAdAccountDailyInsights obtém os insights da conta quebrados por dia (time_increment=1) em uma única consulta.
+// Dias sem veiculação não são retornados pelo Meta.
+func (c *MetaClient) GetAdAccountDailyInsights(ctx context.Context, accountID string, filters *domain.InsigthFilters, fields string) ([]metadomain.AdAccountInsight, error) {
+	// Garantir que o token seja válido antes de fazer a requisição
+	if err := c.EnsureValidToken(); err != nil {
+		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
+	}
+
+	timeRange := fmt.Sprintf("{\"since\":\"%s\",\"until\":\"%s\"}", filters.StartDate.Format(time.DateOnly), filters.EndDate.Format(time.DateOnly))
+
+	params := url.Values{}
+	params.Add("fields", fields)
+	params.Add("time_range", timeRange)
+	params.Add("time_increment", "1")
+	params.Add("limit", "100")
+	params.Add("access_token", c.Cfg.Meta.AccessToken)
+
+	nextURL := fmt.Sprintf("%s/act_%s/insights?%s", c.Cfg.Meta.URL, accountID, params.Encode())
+
+	insights := make([]metadomain.AdAccountInsight, 0)
+	for page := 0; nextURL != "" && page < dailyInsightsMaxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", nextURL, nil)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao criar a requisição")
+			return nil, err
+		}
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao fazer a requisição")
+			return nil, err
+		}
+
+		// Usar o manipulador de resposta que verifica tokens expirados
+		body, err := c.HandleResponse(resp)
+		resp.Body.Close()
+		if err != nil {
+			// Se o erro indica que o token foi renovado, tentar novamente
+			if err.Error() == "token expirado e renovado, por favor tente novamente" {
+				return c.GetAdAccountDailyInsights(ctx, accountID, filters, fields)
+			}
+			return nil, err
+		}
+
+		var response ResponseAdAccountDailyInsights
+		if err := json.Unmarshal(body, &response); err != nil {
+			logrus.WithError(err).Error("Erro ao decodificar JSON")
+			return nil, err
+		}
+
+		insights = append(insights, response.Data...)
+		nextURL = response.Paging.Next
+	}
+
+	return insights, nil
+}
@@ -1,6 +1,7 @@
 package metaclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,7 +18,7 @@ type ResponseAdAccountMetrics struct {
 	Data []metadomain.AdAccountInsight `json:"data"`
 }
 
-func (c *MetaClient) GetAdAccountInsightsByID(accountID string, filters *domain.InsigthFilters, params *url.Values) (*metadomain.AdAccountInsight, error) {
+func (c *MetaClient) GetAdAccountInsightsByID(ctx context.Context, accountID string, filters *domain.InsigthFilters, params *url.Values) (*metadomain.AdAccountInsight, error) {
 	// Garantir que o token seja válido antes de fazer a requisição
 	if err := c.EnsureValidToken(); err != nil {
 		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
@@ -32,7 +33,7 @@ func (c *MetaClient) GetAdAccountInsightsByID(accountID string, filters *domain.
 
 	url := baseURL + "?" + params.Encode()
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao criar a requisição")
 		return nil, err
@@ -51,7 +52,7 @@ func (c *MetaClient) GetAdAccountInsightsByID(accountID string, filters *domain.
 	if err != nil {
 		// Se o erro indica que o token foi renovado, tentar novamente
 		if err.Error() == "token expirado e renovado, por favor tente novamente" {
-			return c.GetAdAccountInsightsByID(accountID, filters, params)
+			return c.GetAdAccountInsightsByID(ctx, accountID, filters, params)
 		}
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package metaclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,7 +18,7 @@ type ResponseAdCampaign struct {
 }
 
 // TODO adicionar loop para pegar todas as páginas
-func (c *MetaClient) GetAdCampaignByAccountID(accountID string) ([]metadomain.Campaign, error) {
+func (c *MetaClient) GetAdCampaignByAccountID(ctx context.Context, accountID string) ([]metadomain.Campaign, error) {
 	// Garantir que o token seja válido antes de fazer a requisição
 	if err := c.EnsureValidToken(); err != nil {
 		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
@@ -32,7 +33,7 @@ func (c *MetaClient) GetAdCampaignByAccountID(accountID string) ([]metadomain.Ca
 
 	url := baseURL + "?" + params.Encode()
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao criar a requisição")
 		return nil, err
@@ -51,7 +52,7 @@ func (c *MetaClient) GetAdCampaignByAccountID(accountID string) ([]metadomain.Ca
 	if err != nil {
 		// Se o erro indica que o token foi renovado, tentar novamente
 		if err.Error() == "token expirado e renovado, por favor tente novamente" {
-			return c.GetAdCampaignByAccountID(accountID)
+			return c.GetAdCampaignByAccountID(ctx, accountID)
 		}
 		return nil, err
 	}
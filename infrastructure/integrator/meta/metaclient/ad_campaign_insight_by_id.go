@@ -1,6 +1,7 @@
 package metaclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,7 +19,7 @@ type ResponseAdCampaignInsight struct {
 	Paging metadomain.Paging            `json:"paging"`
 }
 
-func (c *MetaClient) GetAdCampaignInsightsByID(campaignID string, filters *domain.InsigthFilters) (*metadomain.CampaignInsight, error) {
+func (c *MetaClient) GetAdCampaignInsightsByID(ctx context.Context, campaignID string, filters *domain.InsigthFilters) (*metadomain.CampaignInsight, error) {
 	// Garantir que o token seja válido antes de fazer a requisição
 	if err := c.EnsureValidToken(); err != nil {
 		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
@@ -29,14 +30,14 @@ func (c *MetaClient) GetAdCampaignInsightsByID(campaignID string, filters *domai
 	timeRange := fmt.Sprintf("{\"since\":\"%s\",\"until\":\"%s\"}", filters.StartDate.Format(time.DateOnly), filters.EndDate.Format(time.DateOnly))
 
 	params := url.Values{}
-	params.Add("fields", "account_id,account_name,campaign_name,campaign_id,spend,impressions,frequency,reach,objective,clicks,actions,cost_per_action_type")
+	params.Add("fields", "account_id,account_name,campaign_name,campaign_id,spend,impressions,frequency,reach,objective,clicks,inline_link_clicks,actions,cost_per_action_type")
 	params.Add("filtering", "[{\"field\":\"objective\",\"operator\":\"IN\",\"value\":[\"OUTCOME_ENGAGEMENT\"]}]")
 	params.Add("time_range", timeRange)
 	params.Add("access_token", c.Cfg.Meta.AccessToken)
 
 	url := baseURL + "?" + params.Encode()
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao criar a requisição")
 		return nil, err
@@ -55,7 +56,7 @@ func (c *MetaClient) GetAdCampaignInsightsByID(campaignID string, filters *domai
 	if err != nil {
 		// Se o erro indica que o token foi renovado, tentar novamente
 		if err.Error() == "token expirado e renovado, por favor tente novamente" {
-			return c.GetAdCampaignInsightsByID(campaignID, filters)
+			return c.GetAdCampaignInsightsByID(ctx, campaignID, filters)
 		}
 		return nil, err
 	}
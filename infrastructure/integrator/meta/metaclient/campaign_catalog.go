@@ -0,0 +1,75 @@
+package metaclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	metadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/domain"
+)
+
+// campaignCatalogMaxPages limita a paginação para contas com muitas campanhas arquivadas
+const campaignCatalogMaxPages = 10
+
+type ResponseCampaignCatalog struct {
+	Data   []metadomain.CampaignCatalogEntry `json:"data"`
+	Paging metadomain.Paging                 `json:"paging"`
+}
+
+// GetCampaignCatalog lista as campanhas não excluídas da conta com orçamento e métricas dos últimos 7 dias.
+// As métricas vêm expandidas no campo insights, evitando uma requisição por campanha.
+func (c *MetaClient) GetCampaignCatalog(accountID string) ([]metadomain.CampaignCatalogEntry, error) {
+	// Garantir que o token seja válido antes de fazer a requisição
+	if err := c.EnsureValidToken(); err != nil {
+		return nil, fmt.Errorf("erro ao verificar validade do token: %w", err)
+	}
+
+	params := url.Values{}
+	params.Add("fields", "id,name,objective,status,effective_status,daily_budget,lifetime_budget,"+
+		"insights.date_preset(last_7d){spend,impressions,reach,clicks,objective,actions,cost_per_action_type}")
+	params.Add("effective_status", "['ACTIVE','PAUSED','CAMPAIGN_PAUSED','IN_PROCESS','WITH_ISSUES']")
+	params.Add("limit", "100")
+	params.Add("access_token", c.Cfg.Meta.AccessToken)
+
+	nextURL := fmt.Sprintf("%s/act_%s/campaigns?%s", c.Cfg.Meta.URL, accountID, params.Encode())
+
+	campaigns := make([]metadomain.CampaignCatalogEntry, 0)
+	for page := 0; nextURL != "" && page < campaignCatalogMaxPages; page++ {
+		req, err := http.NewRequest("GET", nextURL, nil)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao criar a requisição")
+			return nil, err
+		}
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao fazer a requisição")
+			return nil, err
+		}
+
+		// Usar o manipulador de resposta que verifica tokens expirados
+		body, err := c.HandleResponse(resp)
+		resp.Body.Close()
+		if err != nil {
+			// Se o erro indica que o token foi renovado, tentar novamente
+			if err.Error() == "token expirado e renovado, por favor tente novamente" {
+				return c.GetCampaignCatalog(accountID)
+			}
+			return nil, err
+		}
+
+		var response ResponseCampaignCatalog
+		if err := json.Unmarshal(body, &response); err != nil {
+			logrus.WithError(err).Error("Erro ao decodificar JSON")
+			return nil, err
+		}
+
+		campaigns = append(campaigns, response.Data...)
+		nextURL = response.Paging.Next
+	}
+
+	return campaigns, nil
+}
@@ -1,6 +1,7 @@
 package metaclient
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -10,10 +11,14 @@ import (
 )
 
 type Client interface {
-	GetAdAccountInsightsByID(accountID string, filters *domain.InsigthFilters, params *url.Values) (*metadomain.AdAccountInsight, error)
-	GetAdCampaignByAccountID(accountID string) ([]metadomain.Campaign, error)
-	GetAdCampaignInsightsByID(campaignID string, filters *domain.InsigthFilters) (*metadomain.CampaignInsight, error)
+	GetAdAccountInsightsByID(ctx context.Context, accountID string, filters *domain.InsigthFilters, params *url.Values) (*metadomain.AdAccountInsight, error)
+	GetAdAccountDailyInsights(ctx context.Context, accountID string, filters *domain.InsigthFilters, fields string) ([]metadomain.AdAccountInsight, error)
+	GetAdAccountBreakdownInsights(ctx context.Context, accountID string, filters *domain.InsigthFilters, breakdowns string) ([]metadomain.BreakdownInsight, error)
+	GetAdCampaignByAccountID(ctx context.Context, accountID string) ([]metadomain.Campaign, error)
+	GetAdCampaignInsightsByID(ctx context.Context, campaignID string, filters *domain.InsigthFilters) (*metadomain.CampaignInsight, error)
+	GetCampaignCatalog(accountID string) ([]metadomain.CampaignCatalogEntry, error)
 	GetAdAccountsByBusinessID(businessID string) ([]metadomain.AdAccount, error)
+	Ping(ctx context.Context) error
 	RefreshToken() error
 	EnsureValidToken() error
 	HandleResponse(resp *http.Response) ([]byte, error)
@@ -0,0 +1,35 @@
+package metaclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Ping faz a consulta mais barata da Graph API (/me) para verificar a disponibilidade do Meta e a validade do token.
+// Não tenta renovar o token: a verificação periódica não deve ter efeitos colaterais.
+func (c *MetaClient) Ping(ctx context.Context) error {
+	params := url.Values{}
+	params.Add("fields", "id")
+	params.Add("access_token", c.Cfg.Meta.AccessToken)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/me?%s", c.Cfg.Meta.URL, params.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("requisição falhou com status: %s, corpo: %s", resp.Status, string(body))
+	}
+
+	return nil
+}
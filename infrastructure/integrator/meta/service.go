@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -13,26 +14,29 @@ import (
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta/metaclient"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/naming"
 	"github.com/vfg2006/traffic-manager-api/pkg/utils"
 )
 
 type MetaIntegrator struct {
-	cfg    *config.Config
-	Client metaclient.Client
+	cfg          *config.Config
+	Client       metaclient.Client
+	namingParser *naming.Parser
 }
 
 func New(cfg *config.Config, client metaclient.Client) *MetaIntegrator {
 	return &MetaIntegrator{
-		cfg:    cfg,
-		Client: client,
+		cfg:          cfg,
+		Client:       client,
+		namingParser: naming.NewParser(cfg.CampaignNaming.Separator, cfg.CampaignNaming.Dimensions),
 	}
 }
 
-func (s *MetaIntegrator) GetAdAccountReachImpressions(accountID string, filters *domain.InsigthFilters) (*domain.ReachImpressionsResponse, error) {
+func (s *MetaIntegrator) GetAdAccountReachImpressions(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.ReachImpressionsResponse, error) {
 	params := &url.Values{}
 	params.Add("fields", "account_id,account_name, impressions, reach, frequency")
 
-	resp, err := s.Client.GetAdAccountInsightsByID(accountID, filters, params)
+	resp, err := s.Client.GetAdAccountInsightsByID(ctx, accountID, filters, params)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"account_id": accountID,
@@ -62,11 +66,11 @@ func (s *MetaIntegrator) GetAdAccountReachImpressions(accountID string, filters
 	}, nil
 }
 
-func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
+func (s *MetaIntegrator) GetAdAccountsInsights(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
 	params := &url.Values{}
-	params.Add("fields", "account_id,account_name,spend,actions,cost_per_action_type, objective, impressions, reach, frequency")
+	params.Add("fields", "account_id,account_name,spend,actions,cost_per_action_type, objective, impressions, reach, frequency, clicks, inline_link_clicks")
 
-	resp, err := s.Client.GetAdAccountInsightsByID(accountID, filters, params)
+	resp, err := s.Client.GetAdAccountInsightsByID(ctx, accountID, filters, params)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"account_id": accountID,
@@ -86,7 +90,7 @@ func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain
 		"account_name": adAccountMetrics.Name,
 	}).Debug("insights: successfully retrieved ad account metrics")
 
-	campaigns, err := s.Client.GetAdCampaignByAccountID(accountID)
+	campaigns, err := s.Client.GetAdCampaignByAccountID(ctx, accountID)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"account_id": accountID,
@@ -98,7 +102,12 @@ func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain
 	AccountResult := 0
 	AccountSpend := 0.0
 	for _, campaign := range campaigns {
-		campaignInsight, err := s.Client.GetAdCampaignInsightsByID(campaign.ID, filters)
+		// Interromper a coleta das campanhas restantes quando a operação for cancelada
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		campaignInsight, err := s.Client.GetAdCampaignInsightsByID(ctx, campaign.ID, filters)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"campaign_id": campaign.ID,
@@ -125,18 +134,23 @@ func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain
 			AccountSpend += spend
 		}
 
+		linkClicks, _ := strconv.Atoi(campaignInsight.InlineLinkClicks)
+
 		cp := &domain.CampaignInsight{
 			CampaignID:    campaignInsight.CampaignID,
 			CampaignName:  campaignInsight.CampaignName,
+			Dimensions:    s.namingParser.Parse(campaignInsight.CampaignName),
 			Clicks:        campaignInsight.Clicks,
 			Frequency:     campaignInsight.Frequency,
 			Impressions:   campaignInsight.Impressions,
+			LinkClicks:    linkClicks,
 			Objective:     campaignInsight.Objective,
 			Reach:         campaignInsight.Reach,
 			Spend:         spend,
 			Result:        result,
 			CostPerResult: costPerResult,
 		}
+		cp.CalculateDeliveryRates()
 
 		campaignsInsights = append(campaignsInsights, cp)
 	}
@@ -155,6 +169,11 @@ func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain
 			Reach:         adAccountMetrics.Reach,
 			Impressions:   adAccountMetrics.Impressions,
 			Frequency:     adAccountMetrics.Frequency,
+			Clicks:        adAccountMetrics.Clicks,
+			LinkClicks:    adAccountMetrics.LinkClicks,
+			CTR:           adAccountMetrics.CTR,
+			CPM:           adAccountMetrics.CPM,
+			CPC:           adAccountMetrics.CPC,
 			Campaigns:     campaignsInsights,
 			Result:        AccountResult,
 			CostPerResult: utils.RoundWithTwoDecimalPlace(costPerResult),
@@ -162,6 +181,40 @@ func (s *MetaIntegrator) GetAdAccountsInsights(accountID string, filters *domain
 	}, nil
 }
 
+// GetAdAccountDailySnapshots obtém, em uma única consulta, o resumo diário (investimento, impressões e alcance) da conta no período
+func (s *MetaIntegrator) GetAdAccountDailySnapshots(ctx context.Context, accountID string, filters *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error) {
+	insights, err := s.Client.GetAdAccountDailyInsights(ctx, accountID, filters, "spend,impressions,reach")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id": accountID,
+			"error":      err.Error(),
+		}).Error("insights: failed to get daily ad account insights from API")
+		return nil, err
+	}
+
+	snapshots := make([]*domain.AdDailySnapshot, 0, len(insights))
+	for _, insight := range insights {
+		date, err := time.Parse(time.DateOnly, insight.DateStart)
+		if err != nil {
+			logrus.WithField("date_start", insight.DateStart).Warn("insights: invalid date in daily ad account insights")
+			continue
+		}
+
+		spend, _ := strconv.ParseFloat(insight.Spend, 64)
+		impressions, _ := strconv.Atoi(insight.Impressions)
+		reach, _ := strconv.Atoi(insight.Reach)
+
+		snapshots = append(snapshots, &domain.AdDailySnapshot{
+			Date:        date,
+			Spend:       utils.RoundWithTwoDecimalPlace(spend),
+			Impressions: impressions,
+			Reach:       reach,
+		})
+	}
+
+	return snapshots, nil
+}
+
 func (s *MetaIntegrator) GetAdAccounts() ([]*domain.AdAccount, error) {
 	bms, err := s.getBusinessManagers()
 	if err != nil {
@@ -193,6 +246,7 @@ func (s *MetaIntegrator) GetAdAccounts() ([]*domain.AdAccount, error) {
 				Origin:              "meta",
 				BusinessManagerID:   b.ID,
 				BusinessManagerName: b.Name,
+				Currency:            adAccount.Currency,
 			})
 		}
 	}
@@ -289,6 +343,12 @@ func FactoryAdAccountMetrics(adAccountInsight *metadomain.AdAccountInsight) *met
 		}).Warn("insights: error converting impressions to integer")
 	}
 
+	// clicks e inline_link_clicks só vêm quando solicitados nos fields; ausentes contam como zero
+	clicks, _ := strconv.Atoi(adAccountInsight.Clicks)
+	linkClicks, _ := strconv.Atoi(adAccountInsight.InlineLinkClicks)
+
+	ctr, cpm, cpc := domain.DeliveryRates(spend, impressions, clicks)
+
 	return &metadomain.AdAccountMetrics{
 		AccountID:   adAccountInsight.AccountID,
 		Name:        adAccountInsight.Name,
@@ -298,5 +358,86 @@ func FactoryAdAccountMetrics(adAccountInsight *metadomain.AdAccountInsight) *met
 		Reach:       reach,
 		Impressions: impressions,
 		Frequency:   frequency,
+		Clicks:      clicks,
+		LinkClicks:  linkClicks,
+		CTR:         ctr,
+		CPM:         cpm,
+		CPC:         cpc,
+	}
+}
+
+// GetCampaignCatalog lista as campanhas da conta com orçamento e métricas dos últimos 7 dias
+func (s *MetaIntegrator) GetCampaignCatalog(accountID string) ([]*domain.CampaignCatalogItem, error) {
+	entries, err := s.Client.GetCampaignCatalog(accountID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id": accountID,
+			"error":      err.Error(),
+		}).Error("campaigns: failed to get campaign catalog")
+		return nil, err
 	}
+
+	campaigns := make([]*domain.CampaignCatalogItem, 0, len(entries))
+	for _, entry := range entries {
+		status := entry.EffectiveStatus
+		if status == "" {
+			status = entry.Status
+		}
+
+		item := &domain.CampaignCatalogItem{
+			ID:             entry.ID,
+			Name:           entry.Name,
+			Objective:      entry.Objective,
+			ObjectiveInfo:  domain.TranslateObjective(entry.Objective),
+			Status:         status,
+			DailyBudget:    parseBudget(entry.DailyBudget),
+			LifetimeBudget: parseBudget(entry.LifetimeBudget),
+			Dimensions:     s.namingParser.Parse(entry.Name),
+		}
+
+		if entry.Insights != nil && len(entry.Insights.Data) > 0 {
+			insight := entry.Insights.Data[0]
+			if insight.Objective == "" {
+				insight.Objective = entry.Objective
+			}
+
+			spend, _ := strconv.ParseFloat(insight.Spend, 64)
+			impressions, _ := strconv.Atoi(insight.Impressions)
+			reach, _ := strconv.Atoi(insight.Reach)
+			clicks, _ := strconv.Atoi(insight.Clicks)
+
+			item.Last7Days = &domain.CampaignMiniMetrics{
+				Spend:         utils.RoundWithTwoDecimalPlace(spend),
+				Impressions:   impressions,
+				Reach:         reach,
+				Clicks:        clicks,
+				Result:        insight.GetResult(),
+				CostPerResult: insight.GetCostPerResult(),
+			}
+		}
+
+		campaigns = append(campaigns, item)
+	}
+
+	return campaigns, nil
+}
+
+// parseBudget converte o orçamento do Meta, informado em centavos, para a unidade da moeda
+func parseBudget(value string) *float64 {
+	if value == "" {
+		return nil
+	}
+
+	cents, err := strconv.ParseFloat(value, 64)
+	if err != nil || cents <= 0 {
+		return nil
+	}
+
+	budget := utils.RoundWithTwoDecimalPlace(cents / 100)
+	return &budget
+}
+
+// Ping verifica a disponibilidade da Graph API com a consulta mais barata disponível
+func (s *MetaIntegrator) Ping(ctx context.Context) error {
+	return s.Client.Ping(ctx)
 }
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
@@ -57,16 +58,16 @@ func (mr *MockSSOticaIntegratorMockRecorder) CheckConnection(params any) *gomock
 }
 
 // GetSalesByAccount mocks base method.
-func (m *MockSSOticaIntegrator) GetSalesByAccount(params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
+func (m *MockSSOticaIntegrator) GetSalesByAccount(ctx context.Context, params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSalesByAccount", params, filters)
+	ret := m.ctrl.Call(m, "GetSalesByAccount", ctx, params, filters)
 	ret0, _ := ret[0].([]ssoticadomain.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSalesByAccount indicates an expected call of GetSalesByAccount.
-func (mr *MockSSOticaIntegratorMockRecorder) GetSalesByAccount(params, filters any) *gomock.Call {
+func (mr *MockSSOticaIntegratorMockRecorder) GetSalesByAccount(ctx, params, filters any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByAccount", reflect.TypeOf((*MockSSOticaIntegrator)(nil).GetSalesByAccount), params, filters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByAccount", reflect.TypeOf((*MockSSOticaIntegrator)(nil).GetSalesByAccount), ctx, params, filters)
 }
@@ -1,6 +1,7 @@
 package ssotica
 
 import (
+	"context"
 	"time"
 
 	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
@@ -10,7 +11,7 @@ import (
 )
 
 type SSOticaIntegrator interface {
-	GetSalesByAccount(params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error)
+	GetSalesByAccount(ctx context.Context, params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error)
 	CheckConnection(params ssoticadomain.CheckConnectionParams) (bool, error)
 }
 
@@ -26,7 +27,7 @@ func New(cfg *config.Config, client ssoticaclient.Client) SSOticaIntegrator {
 	}
 }
 
-func (s *SSOticaService) GetSalesByAccount(params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
+func (s *SSOticaService) GetSalesByAccount(ctx context.Context, params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
 	ssoticaConfig := s.cfg.SSOticaMultiClient[params.SecretName]
 
 	paramsClient := ssoticaclient.SalesConsultationParams{
@@ -36,7 +37,7 @@ func (s *SSOticaService) GetSalesByAccount(params ssoticadomain.GetSalesParams,
 		Token:     ssoticaConfig.AccessToken,
 	}
 
-	resp, err := s.Client.GetSales(paramsClient, &ssoticaConfig)
+	resp, err := s.Client.GetSales(ctx, paramsClient, &ssoticaConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +54,7 @@ func (s *SSOticaService) CheckConnection(params ssoticadomain.CheckConnectionPar
 
 	s.cfg.SSOtica.AccessToken = params.Token
 
-	_, err := s.Client.GetSales(paramsClient, &s.cfg.SSOtica)
+	_, err := s.Client.GetSales(context.Background(), paramsClient, &s.cfg.SSOtica)
 	if err != nil {
 		return false, err
 	}
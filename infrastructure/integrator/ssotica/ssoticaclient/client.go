@@ -1,6 +1,7 @@
 package ssoticaclient
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -8,7 +9,7 @@ import (
 )
 
 type Client interface {
-	GetSales(params SalesConsultationParams, ssoticaConfig *config.SSOtica) (SalesConsultationResponse, error)
+	GetSales(ctx context.Context, params SalesConsultationParams, ssoticaConfig *config.SSOtica) (SalesConsultationResponse, error)
 }
 
 type SSOticaClient struct {
@@ -22,10 +22,10 @@ type SalesConsultationParams struct {
 
 type SalesConsultationResponse []ssoticadomain.Order
 
-func (c *SSOticaClient) GetSales(params SalesConsultationParams, ssoticaConfig *config.SSOtica) (SalesConsultationResponse, error) {
+func (c *SSOticaClient) GetSales(ctx context.Context, params SalesConsultationParams, ssoticaConfig *config.SSOtica) (SalesConsultationResponse, error) {
 	var response SalesConsultationResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 45*time.Second)
 	defer cancel()
 
 	// Construir a URL da requisição.
@@ -238,3 +238,1078 @@ EXECUTE FUNCTION set_timestamp();
 
 -- Índices para melhorar performance de consultas 
 CREATE INDEX idx_store_ranking_account_id_month ON store_ranking (account_id, month);
+
+
+-- REPORTING VIEWS
+-- Views somente leitura para ferramentas de BI (ex: Metabase).
+-- Expõem os caches JSONB como colunas tipadas; o contrato das colunas deve ser mantido estável.
+
+-- Métricas diárias por conta (anúncios + vendas)
+CREATE OR REPLACE VIEW v_daily_metrics AS
+SELECT
+    a.id AS account_id,
+    a.external_id,
+    COALESCE(a.nickname, a.name) AS account_name,
+    bm.id AS business_manager_id,
+    bm.name AS business_manager_name,
+    d.date,
+    COALESCE((ai.ad_metrics->>'spend')::NUMERIC, 0) AS spend,
+    COALESCE((ai.ad_metrics->>'impressions')::BIGINT, 0) AS impressions,
+    COALESCE((ai.ad_metrics->>'reach')::BIGINT, 0) AS reach,
+    COALESCE((ai.ad_metrics->>'frequency')::NUMERIC, 0) AS frequency,
+    COALESCE((ai.ad_metrics->>'result')::BIGINT, 0) AS results,
+    COALESCE((ai.ad_metrics->>'cost_per_result')::NUMERIC, 0) AS cost_per_result,
+    COALESCE((si.sales_metrics->'SocialNetwork'->>'TotalRevenue')::NUMERIC, 0) AS social_network_revenue,
+    COALESCE((si.sales_metrics->'SocialNetwork'->>'SalesQuantity')::INT, 0) AS social_network_sales,
+    COALESCE((si.sales_metrics->'Store'->>'TotalRevenue')::NUMERIC, 0) AS store_revenue,
+    COALESCE((si.sales_metrics->'Store'->>'SalesQuantity')::INT, 0) AS store_sales
+FROM (
+    SELECT account_id, date FROM ad_insights
+    UNION
+    SELECT account_id, date FROM sales_insights
+) d
+JOIN accounts a ON a.id = d.account_id
+LEFT JOIN business_manager bm ON bm.id = a.business_id
+LEFT JOIN ad_insights ai ON ai.account_id = d.account_id AND ai.date = d.date
+LEFT JOIN sales_insights si ON si.account_id = d.account_id AND si.date = d.date;
+
+COMMENT ON VIEW v_daily_metrics IS 'Métricas diárias por conta combinando ad_insights e sales_insights (somente leitura)';
+COMMENT ON COLUMN v_daily_metrics.account_id IS 'ID interno da conta (accounts.id)';
+COMMENT ON COLUMN v_daily_metrics.external_id IS 'ID da conta de anúncios no Meta';
+COMMENT ON COLUMN v_daily_metrics.account_name IS 'Apelido da conta ou, na ausência, o nome no Meta';
+COMMENT ON COLUMN v_daily_metrics.date IS 'Dia de referência das métricas';
+COMMENT ON COLUMN v_daily_metrics.spend IS 'Investimento no Meta em reais';
+COMMENT ON COLUMN v_daily_metrics.results IS 'Resultados do objetivo da campanha (ex: conversas iniciadas)';
+COMMENT ON COLUMN v_daily_metrics.social_network_revenue IS 'Faturamento líquido de vendas com origem em redes sociais (SSOtica)';
+COMMENT ON COLUMN v_daily_metrics.store_revenue IS 'Faturamento líquido das demais origens (SSOtica)';
+
+-- Métricas mensais por conta (anúncios + vendas)
+CREATE OR REPLACE VIEW v_monthly_metrics AS
+SELECT
+    a.id AS account_id,
+    a.external_id,
+    COALESCE(a.nickname, a.name) AS account_name,
+    bm.id AS business_manager_id,
+    bm.name AS business_manager_name,
+    p.period,
+    to_date(p.period, 'MM-YYYY') AS month_start,
+    COALESCE((mai.ad_metrics->>'spend')::NUMERIC, 0) AS spend,
+    COALESCE((mai.ad_metrics->>'impressions')::BIGINT, 0) AS impressions,
+    COALESCE((mai.ad_metrics->>'reach')::BIGINT, 0) AS reach,
+    COALESCE((mai.ad_metrics->>'frequency')::NUMERIC, 0) AS frequency,
+    COALESCE((mai.ad_metrics->>'result')::BIGINT, 0) AS results,
+    COALESCE((mai.ad_metrics->>'cost_per_result')::NUMERIC, 0) AS cost_per_result,
+    COALESCE((msi.sales_metrics->'SocialNetwork'->>'TotalRevenue')::NUMERIC, 0) AS social_network_revenue,
+    COALESCE((msi.sales_metrics->'SocialNetwork'->>'SalesQuantity')::INT, 0) AS social_network_sales,
+    COALESCE((msi.sales_metrics->'Store'->>'TotalRevenue')::NUMERIC, 0) AS store_revenue,
+    COALESCE((msi.sales_metrics->'Store'->>'SalesQuantity')::INT, 0) AS store_sales
+FROM (
+    SELECT account_id, period FROM monthly_ad_insights
+    UNION
+    SELECT account_id, period FROM monthly_sales_insights
+) p
+JOIN accounts a ON a.id = p.account_id
+LEFT JOIN business_manager bm ON bm.id = a.business_id
+LEFT JOIN monthly_ad_insights mai ON mai.account_id = p.account_id AND mai.period = p.period
+LEFT JOIN monthly_sales_insights msi ON msi.account_id = p.account_id AND msi.period = p.period;
+
+COMMENT ON VIEW v_monthly_metrics IS 'Métricas mensais por conta combinando monthly_ad_insights e monthly_sales_insights (somente leitura)';
+COMMENT ON COLUMN v_monthly_metrics.period IS 'Período no formato mm-yyyy';
+COMMENT ON COLUMN v_monthly_metrics.month_start IS 'Primeiro dia do período, para ordenação e filtros por data';
+COMMENT ON COLUMN v_monthly_metrics.spend IS 'Investimento no Meta em reais';
+COMMENT ON COLUMN v_monthly_metrics.social_network_revenue IS 'Faturamento líquido de vendas com origem em redes sociais (SSOtica)';
+
+-- Ranking de lojas por faturamento em redes sociais
+CREATE OR REPLACE VIEW v_store_ranking AS
+SELECT
+    sr.account_id,
+    COALESCE(a.nickname, a.name) AS account_name,
+    bm.id AS business_manager_id,
+    bm.name AS business_manager_name,
+    sr.month AS period,
+    to_date(sr.month, 'MM-YYYY') AS month_start,
+    sr.store_name,
+    sr.social_network_revenue,
+    sr.position,
+    sr.previous_position,
+    sr.position_change,
+    sr.updated_at
+FROM store_ranking sr
+JOIN accounts a ON a.id = sr.account_id
+LEFT JOIN business_manager bm ON bm.id = a.business_id;
+
+COMMENT ON VIEW v_store_ranking IS 'Ranking mensal de lojas por faturamento em redes sociais (somente leitura)';
+COMMENT ON COLUMN v_store_ranking.period IS 'Período no formato mm-yyyy';
+COMMENT ON COLUMN v_store_ranking.position_change IS 'Valor positivo = subiu, negativo = desceu, 0 = manteve';
+
+
+-- POLÍTICA DE SENHAS
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN users.password_changed_at IS 'Data da última troca de senha, usada para calcular a expiração';
+COMMENT ON COLUMN users.must_change_password IS 'Exige troca de senha no próximo login (ex: senha gerada pelo administrador)';
+
+CREATE TABLE password_history (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_history_user_created ON password_history(user_id, created_at DESC);
+
+COMMENT ON TABLE password_history IS 'Hashes das senhas anteriores de cada usuário, para impedir reutilização';
+
+
+-- PERMISSÕES POR CONTA
+CREATE TYPE account_permission AS ENUM ('viewer', 'analyst', 'manager');
+
+-- Vínculos existentes mantêm acesso total; novos vínculos começam como viewer
+ALTER TABLE user_accounts ADD COLUMN permission account_permission NOT NULL DEFAULT 'manager';
+ALTER TABLE user_accounts ALTER COLUMN permission SET DEFAULT 'viewer';
+
+COMMENT ON COLUMN user_accounts.permission IS 'Nível de acesso à conta: viewer (métricas), analyst (edita apelido), manager (edita CNPJ, token e status)';
+
+
+-- WEBHOOKS
+CREATE TABLE webhook_subscriptions (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_webhook_subscriptions_timestamp
+BEFORE UPDATE ON webhook_subscriptions
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+CREATE INDEX idx_webhook_subscriptions_events ON webhook_subscriptions USING GIN (events);
+
+COMMENT ON TABLE webhook_subscriptions IS 'Destinos de webhooks de saída. Cada assinatura tem um segredo próprio para assinatura HMAC dos payloads';
+COMMENT ON COLUMN webhook_subscriptions.events IS 'Tipos de evento assinados (ex: ranking.updated)';
+
+
+-- LGPD: PEDIDOS DE ELIMINAÇÃO DE DADOS
+-- O identificador do titular é guardado apenas como hash SHA-256, para comprovar o atendimento sem reter o dado pessoal
+CREATE TABLE privacy_erasure_requests (
+    id SERIAL PRIMARY KEY,
+    subject_hash CHAR(64) NOT NULL,
+    requested_by INT,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    records_anonymized INT NOT NULL DEFAULT 0,
+    details JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    FOREIGN KEY (requested_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_privacy_erasure_requests_subject ON privacy_erasure_requests(subject_hash);
+
+COMMENT ON TABLE privacy_erasure_requests IS 'Registro dos pedidos de eliminação/anonimização de dados pessoais (LGPD art. 18)';
+COMMENT ON COLUMN privacy_erasure_requests.details IS 'Quantidade de registros anonimizados por origem de dados';
+
+
+-- MASCARAMENTO DE CAMPOS POR PERFIL
+-- Campos JSON removidos das respostas da API para o perfil (em qualquer nível do payload).
+-- Contagens e alcance não devem ser listados aqui para que perfis restritos continuem vendo o volume.
+CREATE TABLE role_masked_fields (
+    role_id INT NOT NULL,
+    field VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role_id, field),
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE role_masked_fields IS 'Campos sensíveis ocultados das respostas por perfil (ex: spend, cnpj, cost_per_result)';
+
+-- Perfil cliente (role_id = 3 na aplicação): oculta investimento, custos e dados cadastrais da loja
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'spend'),
+    (3, 'cost_per_result'),
+    (3, 'cost_per_result_by_date'),
+    (3, 'ROI'),
+    (3, 'cnpj'),
+    (3, 'secret_name');
+
+
+-- AVATAR DE USUÁRIOS
+-- URLs de storage/CDN excedem facilmente 100 caracteres
+ALTER TABLE users ALTER COLUMN avatar_url TYPE TEXT;
+
+
+-- PREFERÊNCIAS DO USUÁRIO
+CREATE TABLE user_preferences (
+    user_id INT PRIMARY KEY,
+    default_period VARCHAR(20) NOT NULL DEFAULT 'last_30_days',
+    favorite_accounts TEXT[] NOT NULL DEFAULT '{}',
+    currency_format VARCHAR(20) NOT NULL DEFAULT 'full',
+    dashboard_layout JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_user_preferences_timestamp
+BEFORE UPDATE ON user_preferences
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+COMMENT ON TABLE user_preferences IS 'Preferências de exibição do dashboard, compartilhadas entre dispositivos';
+COMMENT ON COLUMN user_preferences.dashboard_layout IS 'Layout do dashboard definido pelo frontend, armazenado sem interpretação';
+
+
+-- RELATÓRIOS SALVOS
+CREATE TABLE saved_reports (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    account_ids TEXT[] NOT NULL,
+    period_preset VARCHAR(20) NOT NULL,
+    metrics TEXT[] NOT NULL,
+    comparison VARCHAR(20) NOT NULL DEFAULT 'none',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_saved_reports_timestamp
+BEFORE UPDATE ON saved_reports
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+COMMENT ON TABLE saved_reports IS 'Definições de relatório salvas pelos usuários (contas, período, métricas e comparação)';
+COMMENT ON COLUMN saved_reports.account_ids IS 'IDs internos das contas (accounts.id)';
+
+-- O relatório expõe o ROI com a chave "roi"; mantém oculto para o perfil cliente
+INSERT INTO role_masked_fields (role_id, field) VALUES (3, 'roi') ON CONFLICT DO NOTHING;
+
+
+-- AGENDAMENTO DE RELATÓRIOS
+CREATE TABLE report_schedules (
+    id SERIAL PRIMARY KEY,
+    report_id INT NOT NULL,
+    user_id INT NOT NULL,
+    frequency VARCHAR(10) NOT NULL,
+    weekday SMALLINT,
+    day_of_month SMALLINT,
+    hour SMALLINT NOT NULL DEFAULT 8,
+    channel VARCHAR(10) NOT NULL,
+    destination TEXT NOT NULL,
+    secret TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (report_id) REFERENCES saved_reports(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_report_schedules_timestamp
+BEFORE UPDATE ON report_schedules
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+CREATE INDEX idx_report_schedules_due ON report_schedules(next_run_at) WHERE active;
+
+COMMENT ON TABLE report_schedules IS 'Envio recorrente de relatórios salvos por e-mail ou webhook';
+COMMENT ON COLUMN report_schedules.secret IS 'Segredo de assinatura HMAC das entregas por webhook';
+
+
+-- ATIVIDADE DAS CONTAS
+CREATE TABLE account_activities (
+    id BIGSERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    summary TEXT NOT NULL,
+    details JSONB,
+    user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_account_activities_account_created ON account_activities(account_id, created_at DESC);
+
+COMMENT ON TABLE account_activities IS 'Linha do tempo por conta: sincronizações, edições, anomalias e notas';
+COMMENT ON COLUMN account_activities.type IS 'sync, edit, anomaly ou note';
+COMMENT ON COLUMN account_activities.user_id IS 'Usuário que originou a atividade (vazio para eventos automáticos)';
+
+
+-- ANOTAÇÕES DE DATAS NOS INSIGHTS
+CREATE TABLE insight_annotations (
+    id SERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    date DATE NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    text TEXT NOT NULL,
+    user_id INT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TRIGGER update_insight_annotations_timestamp
+BEFORE UPDATE ON insight_annotations
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+CREATE INDEX idx_insight_annotations_account_date ON insight_annotations(account_id, date);
+
+COMMENT ON TABLE insight_annotations IS 'Explicações por data exibidas junto da linha do tempo de métricas (promoções, feriados, etc.)';
+COMMENT ON COLUMN insight_annotations.type IS 'promocao, feriado, evento, problema ou outro';
+
+
+-- FORMATAÇÃO POR CONTA
+ALTER TABLE accounts ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE accounts ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT 'pt-BR';
+
+COMMENT ON COLUMN accounts.currency IS 'Código ISO 4217 da moeda dos valores da conta';
+COMMENT ON COLUMN accounts.locale IS 'Locale BCP 47 usado pelos frontends para formatar os valores (ex: pt-BR)';
+
+
+-- HISTÓRICO DE EXECUÇÃO DOS AGENDADORES
+CREATE TABLE scheduler_runs (
+    name VARCHAR(50) PRIMARY KEY,
+    last_started_at TIMESTAMPTZ,
+    last_success_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_scheduler_runs_timestamp
+BEFORE UPDATE ON scheduler_runs
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+COMMENT ON TABLE scheduler_runs IS 'Última execução de cada agendador, usada para recuperar execuções perdidas na inicialização';
+COMMENT ON COLUMN scheduler_runs.last_success_at IS 'Início da última execução concluída com sucesso';
+
+
+-- AGENDAS DE SINCRONIZAÇÃO POR CONTA
+CREATE TABLE account_sync_schedules (
+    account_id CHAR(6) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    cron_schedule VARCHAR(100) NOT NULL,
+    lookback_days INT NOT NULL DEFAULT 1,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, source),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_account_sync_schedules_timestamp
+BEFORE UPDATE ON account_sync_schedules
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+COMMENT ON TABLE account_sync_schedules IS 'Agenda própria de sincronização por conta, substituindo o cron global da origem';
+COMMENT ON COLUMN account_sync_schedules.source IS 'meta ou ssotica';
+COMMENT ON COLUMN account_sync_schedules.lookback_days IS 'Dias sincronizados em cada execução, contando o dia atual';
+
+
+-- VISUALIZAÇÕES DE CONTAS
+CREATE TABLE account_views (
+    account_id CHAR(6) NOT NULL,
+    date DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, date),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE account_views IS 'Visualizações diárias do dashboard de cada conta, usadas para priorizar a ordem das sincronizações';
+
+
+-- INSIGHTS TRIMESTRAIS E ANUAIS
+CREATE TABLE rollup_insights (
+    id SERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    granularity VARCHAR(10) NOT NULL,
+    period VARCHAR(7) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    months INT NOT NULL,
+    ad_metrics JSONB,
+    sales_metrics JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE (account_id, granularity, period)
+);
+
+CREATE TRIGGER trigger_set_timestamp_rollup_insights
+BEFORE UPDATE ON rollup_insights
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+CREATE INDEX idx_rollup_insights_account_start ON rollup_insights (account_id, granularity, start_date);
+
+COMMENT ON TABLE rollup_insights IS 'Métricas trimestrais e anuais por conta, consolidadas a partir dos insights mensais';
+COMMENT ON COLUMN rollup_insights.granularity IS 'quarter ou year';
+COMMENT ON COLUMN rollup_insights.period IS 'Q1-2025 para trimestres ou 2025 para anos';
+COMMENT ON COLUMN rollup_insights.months IS 'Quantidade de meses com dados consolidados no período';
+
+
+-- CHECKPOINT DAS SINCRONIZAÇÕES
+ALTER TABLE scheduler_runs ADD COLUMN checkpoint JSONB;
+
+COMMENT ON COLUMN scheduler_runs.checkpoint IS 'Progresso da última execução interrompida (limite de duração ou encerramento), retomado na execução seguinte';
+
+
+-- ESTATÍSTICAS DAS SINCRONIZAÇÕES
+ALTER TABLE scheduler_runs ADD COLUMN stats JSONB;
+
+COMMENT ON COLUMN scheduler_runs.stats IS 'Chamadas, falhas por classe de erro, linhas gravadas e acertos de cache por provedor na última execução';
+
+
+-- BLOCOS DE BACKFILL
+CREATE TABLE IF NOT EXISTS backfill_chunks (
+    source VARCHAR(20) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    failed_accounts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (source, start_date, end_date)
+);
+
+CREATE TRIGGER trigger_set_timestamp_backfill_chunks
+BEFORE UPDATE ON backfill_chunks
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+COMMENT ON TABLE backfill_chunks IS 'Progresso dos backfills de insights por bloco mensal; blocos concluídos não são refeitos';
+COMMENT ON COLUMN backfill_chunks.status IS 'pending, succeeded ou failed';
+COMMENT ON COLUMN backfill_chunks.failed_accounts IS 'Contas com alguma data não sincronizada no bloco';
+
+
+-- NOTIFICAÇÃO DE ALTERAÇÕES DAS CONTAS
+CREATE FUNCTION notify_account_change() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('account_changes', NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_notify_account_change
+AFTER UPDATE OF status, cnpj, secret_name, external_id ON accounts
+FOR EACH ROW
+WHEN (
+    OLD.status IS DISTINCT FROM NEW.status
+    OR OLD.cnpj IS DISTINCT FROM NEW.cnpj
+    OR OLD.secret_name IS DISTINCT FROM NEW.secret_name
+    OR OLD.external_id IS DISTINCT FROM NEW.external_id
+)
+EXECUTE FUNCTION notify_account_change();
+
+COMMENT ON FUNCTION notify_account_change() IS 'Publica no canal account_changes o ID da conta com status ou credenciais alterados, para os agendadores em execução';
+
+
+-- PERFIL VENDEDOR
+-- role_id = 5 na aplicação: acessa vendas e ranking, sem investimento e custos de anúncios
+INSERT INTO roles (id, name) VALUES (5, 'vendedor') ON CONFLICT DO NOTHING;
+SELECT setval(pg_get_serial_sequence('roles', 'id'), (SELECT MAX(id) FROM roles));
+
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (5, 'spend'),
+    (5, 'cost_per_result'),
+    (5, 'cost_per_result_by_date'),
+    (5, 'daily_budget'),
+    (5, 'lifetime_budget'),
+    (5, 'ROI'),
+    (5, 'roi')
+ON CONFLICT DO NOTHING;
+
+
+-- MESCLAGEM DE CONTAS DUPLICADAS
+ALTER TABLE accounts ADD COLUMN merged_into CHAR(6) REFERENCES accounts(id) ON DELETE SET NULL;
+ALTER TABLE accounts ADD COLUMN merged_at TIMESTAMP;
+
+COMMENT ON COLUMN accounts.merged_into IS 'Conta que recebeu os dados desta conta duplicada; a conta mesclada fica inativa';
+
+CREATE TABLE account_merges (
+    id SERIAL PRIMARY KEY,
+    source_id CHAR(6) NOT NULL,
+    target_id CHAR(6) NOT NULL,
+    merged_by INT,
+    reason TEXT,
+    moved_rows JSONB NOT NULL DEFAULT '{}',
+    kept_rows JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (source_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (merged_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_account_merges_source ON account_merges(source_id);
+CREATE INDEX idx_account_merges_target ON account_merges(target_id);
+
+COMMENT ON TABLE account_merges IS 'Histórico das mesclagens de contas duplicadas (ex: conta de anúncios recriada na Meta)';
+COMMENT ON COLUMN account_merges.moved_rows IS 'Linhas transferidas para a conta destino, por tabela';
+COMMENT ON COLUMN account_merges.kept_rows IS 'Linhas mantidas na conta de origem porque o destino já tinha o mesmo período, por tabela';
+
+
+-- LOCALIZAÇÃO DAS LOJAS
+ALTER TABLE accounts ADD COLUMN latitude DOUBLE PRECISION;
+ALTER TABLE accounts ADD COLUMN longitude DOUBLE PRECISION;
+ALTER TABLE accounts ADD COLUMN city VARCHAR(120);
+ALTER TABLE accounts ADD COLUMN state CHAR(2);
+ALTER TABLE accounts ADD COLUMN geocoded_at TIMESTAMP;
+
+CREATE INDEX idx_accounts_state_city ON accounts(state, city);
+
+COMMENT ON COLUMN accounts.latitude IS 'Latitude da loja, obtida na geocodificação ou informada pelo administrador';
+COMMENT ON COLUMN accounts.longitude IS 'Longitude da loja, obtida na geocodificação ou informada pelo administrador';
+COMMENT ON COLUMN accounts.state IS 'UF da loja, usada na agregação do mapa de desempenho';
+COMMENT ON COLUMN accounts.geocoded_at IS 'Quando a localização da loja foi definida pela última vez';
+
+
+-- QUEBRAS DEMOGRÁFICAS E DE POSICIONAMENTO
+CREATE TABLE ad_insight_breakdowns (
+    id SERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    breakdowns JSONB NOT NULL,
+    fetched_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE (account_id, start_date, end_date)
+);
+
+COMMENT ON TABLE ad_insight_breakdowns IS 'Métricas do Meta quebradas por faixa etária, gênero e plataforma, por conta e período consultado';
+COMMENT ON COLUMN ad_insight_breakdowns.breakdowns IS 'Segmentos de idade, gênero e plataforma com investimento, impressões e resultados';
+COMMENT ON COLUMN ad_insight_breakdowns.fetched_at IS 'Quando as quebras foram obtidas do Meta; períodos em andamento são renovados após a validade configurada';
+
+
+-- FUSO HORÁRIO DAS CONTAS
+ALTER TABLE accounts ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo';
+
+COMMENT ON COLUMN accounts.timezone IS 'Fuso IANA em que os dias da conta são contados; deve ser o mesmo fuso da conta de anúncios no Meta';
+
+
+-- BUSCA DE CONTAS POR APELIDO, NOME E CNPJ
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_accounts_nickname_trgm ON accounts USING GIN (nickname gin_trgm_ops);
+CREATE INDEX idx_accounts_name_trgm ON accounts USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_accounts_cnpj_trgm ON accounts USING GIN (cnpj gin_trgm_ops);
+
+COMMENT ON INDEX idx_accounts_nickname_trgm IS 'Atende ILIKE e similaridade no apelido na busca de contas';
+COMMENT ON INDEX idx_accounts_name_trgm IS 'Atende ILIKE e similaridade no nome na busca de contas';
+COMMENT ON INDEX idx_accounts_cnpj_trgm IS 'Atende a busca de contas por parte do CNPJ';
+
+
+-- METAS MENSAIS POR CONTA
+CREATE TABLE account_goals (
+    id SERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    month DATE NOT NULL,
+    revenue_target NUMERIC(14, 2),
+    max_cpa NUMERIC(14, 2),
+    spend_budget NUMERIC(14, 2),
+    user_id INT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE (account_id, month)
+);
+
+CREATE TRIGGER update_account_goals_timestamp
+BEFORE UPDATE ON account_goals
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+CREATE INDEX idx_account_goals_month ON account_goals(month);
+
+COMMENT ON TABLE account_goals IS 'Metas mensais de faturamento, CPA e investimento por conta, comparadas nos insights e no relatório mensal';
+COMMENT ON COLUMN account_goals.month IS 'Primeiro dia do mês a que as metas se referem';
+COMMENT ON COLUMN account_goals.revenue_target IS 'Faturamento em redes sociais esperado no mês, na moeda do faturamento';
+COMMENT ON COLUMN account_goals.max_cpa IS 'Custo por resultado máximo aceito, na moeda do faturamento';
+COMMENT ON COLUMN account_goals.spend_budget IS 'Investimento previsto para o mês, na moeda do faturamento';
+
+
+-- ANOMALIAS DIÁRIAS DE INVESTIMENTO E CUSTO POR RESULTADO
+CREATE TABLE insight_anomalies (
+    id SERIAL PRIMARY KEY,
+    account_id CHAR(6) NOT NULL,
+    date DATE NOT NULL,
+    metric VARCHAR(30) NOT NULL,
+    value NUMERIC(14, 2) NOT NULL,
+    mean NUMERIC(14, 2) NOT NULL,
+    std_dev NUMERIC(14, 2) NOT NULL,
+    z_score NUMERIC(8, 2) NOT NULL,
+    direction VARCHAR(5) NOT NULL,
+    detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE (account_id, date, metric)
+);
+
+COMMENT ON TABLE insight_anomalies IS 'Dias em que o investimento ou o custo por resultado se afastou da média dos 30 dias anteriores';
+COMMENT ON COLUMN insight_anomalies.metric IS 'spend ou cost_per_result';
+COMMENT ON COLUMN insight_anomalies.z_score IS 'Desvios-padrão entre o valor do dia e a média da janela anterior';
+COMMENT ON COLUMN insight_anomalies.direction IS 'above ou below a média';
+
+
+-- QUEBRAS NOS RELATÓRIOS SALVOS
+ALTER TABLE saved_reports ADD COLUMN IF NOT EXISTS breakdowns TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN saved_reports.breakdowns IS 'Quebras incluídas por conta na execução: age, gender e/ou platform';
+
+
+-- EXPRESSÃO CRON E VÁRIOS DESTINATÁRIOS NOS AGENDAMENTOS DE RELATÓRIO
+ALTER TABLE report_schedules ADD COLUMN IF NOT EXISTS cron_schedule VARCHAR(100);
+
+COMMENT ON COLUMN report_schedules.cron_schedule IS 'Expressão cron padrão usada quando frequency = cron';
+COMMENT ON COLUMN report_schedules.destination IS 'URL do webhook ou e-mails dos destinatários separados por vírgula';
+
+
+-- REFRESH TOKENS
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE refresh_tokens IS 'Refresh tokens emitidos no login, trocados por um novo a cada uso';
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'SHA-256 (hex) do token; o valor em si não é armazenado';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'Preenchido no uso (rotação), no logout ou na troca de senha';
+
+
+-- REVOKED TOKENS
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(36) PRIMARY KEY,
+    user_id INT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+COMMENT ON TABLE revoked_tokens IS 'Tokens de acesso encerrados no logout, rejeitados até a expiração';
+COMMENT ON COLUMN revoked_tokens.jti IS 'Claim jti do token de acesso';
+
+CREATE TABLE user_token_revocations (
+    user_id INT PRIMARY KEY,
+    revoked_before TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE user_token_revocations IS 'Revogação de todos os tokens de acesso do usuário (desativação, exclusão ou troca de senha)';
+COMMENT ON COLUMN user_token_revocations.revoked_before IS 'Tokens emitidos antes deste instante são rejeitados';
+
+
+-- PASSWORD RESET TOKENS
+CREATE TABLE password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+COMMENT ON TABLE password_reset_tokens IS 'Tokens de uso único enviados por e-mail em /v1/auth/forgot-password';
+COMMENT ON COLUMN password_reset_tokens.token_hash IS 'SHA-256 (hex) do token; o valor em si só aparece no link do e-mail';
+COMMENT ON COLUMN password_reset_tokens.used_at IS 'Preenchido na redefinição ou quando um novo link é solicitado';
+
+
+-- PERMISSÕES POR PERFIL
+CREATE TABLE role_permissions (
+    role_id INT NOT NULL,
+    permission VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role_id, permission),
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE role_permissions IS 'Permissões de cada perfil (ex: insights:read, accounts:write), verificadas pelo middleware RequirePermission';
+
+-- Mantém o acesso dos perfis existentes (role_id na aplicação: 1 admin, 2 supervisor, 3 cliente, 5 vendedor)
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'insights:read'),
+    (1, 'accounts:write'),
+    (1, 'accounts:all'),
+    (1, 'accounts:admin'),
+    (1, 'reports:consolidated'),
+    (1, 'reports:deck'),
+    (1, 'sales:read'),
+    (1, 'users:manage'),
+    (1, 'operations:manage'),
+    (1, 'webhooks:manage'),
+    (1, 'privacy:manage'),
+    (2, 'insights:read'),
+    (2, 'accounts:write'),
+    (2, 'accounts:all'),
+    (2, 'reports:consolidated'),
+    (2, 'sales:read'),
+    (3, 'insights:read'),
+    (3, 'accounts:write'),
+    (5, 'insights:read'),
+    (5, 'accounts:write'),
+    (5, 'sales:read')
+ON CONFLICT DO NOTHING;
+
+-- Novos perfis: analista (somente leitura de insights) e gerente (edita as contas vinculadas)
+INSERT INTO roles (name) VALUES ('analista'), ('gerente') ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'insights:read' FROM roles WHERE name IN ('analista', 'gerente')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'accounts:write' FROM roles WHERE name = 'gerente'
+ON CONFLICT DO NOTHING;
+
+
+-- CHAVES DE API
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+
+COMMENT ON TABLE api_keys IS 'Chaves de acesso para integrações (ferramentas de BI, scripts), enviadas no cabeçalho X-API-Key; agem em nome do usuário';
+COMMENT ON COLUMN api_keys.prefix IS 'Início da chave, exibido na listagem para identificá-la';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 (hex) da chave; o valor em si só é retornado na criação';
+COMMENT ON COLUMN api_keys.scopes IS 'Permissões liberadas para a chave, limitadas também às permissões do perfil do usuário';
+
+
+-- LOG DE AUDITORIA
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INT,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(30) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    details JSONB,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id);
+
+COMMENT ON TABLE audit_log IS 'Operações sensíveis (edição de usuários e contas, geração de senha, vínculos, sincronizações manuais), consultadas em /v1/admin/audit-log';
+COMMENT ON COLUMN audit_log.actor_id IS 'Usuário que executou a operação; nulo se o usuário foi removido';
+COMMENT ON COLUMN audit_log.details IS 'Dados da operação, sem senhas nem tokens';
+
+INSERT INTO role_permissions (role_id, permission) VALUES (1, 'audit:read') ON CONFLICT DO NOTHING;
+
+
+-- SESSÕES
+-- Cada login abre uma sessão; os refresh tokens emitidos nas renovações mantêm o session_id
+ALTER TABLE refresh_tokens
+    ADD COLUMN session_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN session_started_at TIMESTAMPTZ,
+    ADD COLUMN user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '';
+
+UPDATE refresh_tokens SET session_started_at = created_at WHERE session_started_at IS NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN session_started_at SET NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens(session_id) WHERE revoked_at IS NULL;
+
+COMMENT ON COLUMN refresh_tokens.session_id IS 'Sessão aberta no login, mantida nas renovações; também vai no token de acesso';
+COMMENT ON COLUMN refresh_tokens.user_agent IS 'User-Agent do login ou da última renovação';
+COMMENT ON COLUMN refresh_tokens.ip_address IS 'IP do login ou da última renovação';
+COMMENT ON COLUMN revoked_tokens.jti IS 'jti do token de acesso revogado, ou session_id quando a sessão inteira é encerrada';
+
+
+-- GESTÃO DE PERFIS
+ALTER TABLE roles
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+CREATE TRIGGER trigger_set_timestamp
+BEFORE UPDATE ON roles
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp();
+
+UPDATE roles SET description = CASE name
+    WHEN 'admin' THEN 'Acesso total à API'
+    WHEN 'manager' THEN 'Todas as contas, relatórios consolidados e ranking de lojas'
+    WHEN 'supervisor' THEN 'Leitura e edição das contas vinculadas'
+    WHEN 'customer' THEN 'Cliente, sem permissões por padrão'
+    WHEN 'vendedor' THEN 'Contas vinculadas e ranking de lojas'
+    WHEN 'analista' THEN 'Leitura dos insights das contas vinculadas'
+    WHEN 'gerente' THEN 'Leitura e edição das contas vinculadas'
+    ELSE description
+END
+WHERE description = '';
+
+COMMENT ON TABLE roles IS 'Perfis de acesso; as permissões de cada perfil ficam em role_permissions';
+COMMENT ON COLUMN roles.description IS 'Descrição exibida no cadastro de usuários';
+
+
+-- HISTÓRICO DE SENHAS: senha inicial
+-- A senha do cadastro passa a entrar no histórico; usuários sem histórico recebem a senha atual
+INSERT INTO password_history (user_id, password_hash)
+SELECT u.id, u.password_hash FROM users u
+WHERE NOT EXISTS (SELECT 1 FROM password_history ph WHERE ph.user_id = u.id);
+
+
+-- VERIFICAÇÃO DE E-MAIL
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- Usuários existentes foram cadastrados antes da verificação e são considerados verificados
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+COMMENT ON COLUMN users.email_verified_at IS 'Confirmação do e-mail pelo link enviado no cadastro; NULL = não verificado';
+
+CREATE TABLE email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens(user_id) WHERE used_at IS NULL;
+
+COMMENT ON TABLE email_verification_tokens IS 'Tokens de uso único enviados por e-mail no cadastro e em /v1/auth/resend-verification';
+COMMENT ON COLUMN email_verification_tokens.token_hash IS 'SHA-256 (hex) do token; o valor em si só aparece no link do e-mail';
+COMMENT ON COLUMN email_verification_tokens.used_at IS 'Preenchido na confirmação ou quando um novo link é solicitado';
+
+
+-- ATIVIDADE DOS USUÁRIOS
+-- Fora da tabela users para que a atividade não altere users.updated_at a cada requisição
+CREATE TABLE user_activity (
+    user_id INT PRIMARY KEY,
+    last_login_at TIMESTAMPTZ,
+    last_seen_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE user_activity IS 'Último login e última requisição autenticada de cada usuário, para encontrar contas sem uso';
+COMMENT ON COLUMN user_activity.last_seen_at IS 'Gravado no máximo a cada poucos minutos por instância da API';
+
+
+-- ACEITE DOS TERMOS DE USO E DA POLÍTICA DE PRIVACIDADE (LGPD)
+CREATE TABLE policy_acceptances (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    policy_version VARCHAR(50) NOT NULL,
+    accepted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    UNIQUE (user_id, policy_version),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE policy_acceptances IS 'Versões dos termos de uso e da política de privacidade aceitas por cada usuário';
+COMMENT ON COLUMN policy_acceptances.policy_version IS 'Versão vigente (POLICY_VERSION) no momento do aceite';
+COMMENT ON COLUMN policy_acceptances.ip_address IS 'IP de origem do aceite, mantido como prova do consentimento';
+
+
+-- EXECUÇÕES DOS AGENDADORES
+-- Uma linha por execução; scheduler_runs continua guardando apenas a última, para a recuperação
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    trigger VARCHAR(20) NOT NULL,
+    params JSONB,
+    status VARCHAR(20) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    error TEXT,
+    stats JSONB
+);
+
+CREATE INDEX idx_jobs_type_started_at ON jobs(type, started_at DESC);
+CREATE INDEX idx_jobs_running ON jobs(type) WHERE status = 'running';
+
+COMMENT ON TABLE jobs IS 'Histórico das execuções dos agendadores (sincronizações do Meta, SSOtica, mensal e top ranking)';
+COMMENT ON COLUMN jobs.type IS 'Nome do agendador, o mesmo de scheduler_runs.name';
+COMMENT ON COLUMN jobs.trigger IS 'Origem da execução: cron, manual ou catch_up (recuperação de execução perdida)';
+COMMENT ON COLUMN jobs.status IS 'running, succeeded, failed ou interrupted (encerramento da aplicação ou limite de duração)';
+COMMENT ON COLUMN jobs.stats IS 'Estatísticas por provedor (chamadas, falhas, linhas gravadas) ao fim da execução';
+
+
+-- RESULTADO DAS CONTAS EM CADA EXECUÇÃO DOS AGENDADORES
+-- Sem chave estrangeira para accounts: o histórico é mantido mesmo após a exclusão ou mesclagem da conta
+CREATE TABLE job_accounts (
+    job_id BIGINT NOT NULL,
+    account_id CHAR(6) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    items INT NOT NULL DEFAULT 0,
+    failed_items INT NOT NULL DEFAULT 0,
+    reason TEXT,
+    PRIMARY KEY (job_id, account_id),
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE job_accounts IS 'Resultado de cada conta nas execuções registradas em jobs';
+COMMENT ON COLUMN job_accounts.status IS 'succeeded, failed, interrupted ou skipped (conta ignorada, com o motivo em reason)';
+COMMENT ON COLUMN job_accounts.items IS 'Dias (ou meses, na sincronização mensal) processados para a conta';
+COMMENT ON COLUMN job_accounts.reason IS 'Última falha da conta na execução ou motivo de ter sido ignorada';
+
+
+-- PAUSA DOS AGENDADORES EM TEMPO DE EXECUÇÃO
+-- A linha existe enquanto o agendador estiver pausado; a retomada a remove
+CREATE TABLE scheduler_pauses (
+    scheduler VARCHAR(50) PRIMARY KEY,
+    paused_by INT,
+    reason TEXT,
+    paused_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (paused_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+COMMENT ON TABLE scheduler_pauses IS 'Agendadores pausados pelas rotas de administração, sem alterar a configuração nem reiniciar a API';
+COMMENT ON COLUMN scheduler_pauses.scheduler IS 'Nome do agendador, o mesmo de jobs.type';
+COMMENT ON COLUMN scheduler_pauses.reason IS 'Motivo informado na pausa (ex: limite de requisições do Meta)';
+
+
+-- FALHAS DE SINCRONIZAÇÃO (DEAD-LETTER)
+-- Datas de uma conta que falharam em todas as tentativas; a linha é removida quando a data é sincronizada
+CREATE TABLE sync_failures (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    account_id CHAR(6) NOT NULL,
+    date DATE NOT NULL,
+    attempts INT NOT NULL,
+    failures INT NOT NULL DEFAULT 1,
+    error TEXT NOT NULL,
+    first_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    requeued_at TIMESTAMP,
+    UNIQUE (source, account_id, date),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_sync_failures_date ON sync_failures(date DESC);
+
+COMMENT ON TABLE sync_failures IS 'Datas de contas que não foram sincronizadas depois de todas as tentativas, para consulta e nova sincronização';
+COMMENT ON COLUMN sync_failures.source IS 'meta ou ssotica';
+COMMENT ON COLUMN sync_failures.attempts IS 'Tentativas da última execução que falhou';
+COMMENT ON COLUMN sync_failures.failures IS 'Execuções que falharam para a data';
+COMMENT ON COLUMN sync_failures.requeued_at IS 'Última nova sincronização solicitada pelas rotas de administração';
+
+
+-- CONTAS PRIORITÁRIAS (VIP)
+ALTER TABLE accounts ADD COLUMN vip BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN accounts.vip IS 'Conta prioritária, sincronizada antes das demais nas execuções agendadas';
+
+
+-- DADOS DO DIA EM ANDAMENTO (INTRADIÁRIO)
+-- Métricas parciais do dia atual, atualizadas pela sincronização intradiária; os insights diários só guardam dias encerrados
+CREATE TABLE intraday_insights (
+    account_id CHAR(6) NOT NULL,
+    date DATE NOT NULL,
+    external_id VARCHAR(30),
+    ad_metrics JSONB,
+    ad_synced_at TIMESTAMPTZ,
+    sales_metrics JSONB,
+    sales_synced_at TIMESTAMPTZ,
+    PRIMARY KEY (account_id, date),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE intraday_insights IS 'Métricas parciais do dia em andamento de cada conta, combinadas aos insights diários nos dashboards';
+COMMENT ON COLUMN intraday_insights.date IS 'Dia atual no fuso da conta; os dias anteriores são removidos pela própria sincronização';
+COMMENT ON COLUMN intraday_insights.ad_synced_at IS 'Última atualização das métricas do Meta; dados mais antigos que INTRADAY_SYNC_MAX_AGE são ignorados';
+COMMENT ON COLUMN intraday_insights.sales_synced_at IS 'Última atualização das métricas do SSOtica; dados mais antigos que INTRADAY_SYNC_MAX_AGE são ignorados';
+
+
+-- MASCARAMENTO DE CPC E CPM
+-- Custos por clique e por mil impressões revelam o investimento; seguem a mesma regra de spend para cliente e vendedor
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'cpc'),
+    (3, 'cpm'),
+    (5, 'cpc'),
+    (5, 'cpm')
+ON CONFLICT DO NOTHING;
+
+
+-- MASCARAMENTO DE ROAS
+-- ROAS e ROAS de equilíbrio permitem deduzir o investimento a partir do faturamento; ocultos como o ROI
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'ROAS'),
+    (3, 'BreakEvenROAS'),
+    (5, 'ROAS'),
+    (5, 'BreakEvenROAS')
+ON CONFLICT DO NOTHING;
+
+
+-- MASCARAMENTO DA PARTICIPAÇÃO NO INVESTIMENTO
+-- A participação de cada segmento nos detalhamentos é derivada do investimento
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'spend_share'),
+    (5, 'spend_share')
+ON CONFLICT DO NOTHING;
+
+
+-- MASCARAMENTO DA META DE INVESTIMENTO
+-- O investimento previsto do mês é tão sensível quanto o realizado
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'spend_budget'),
+    (5, 'spend_budget')
+ON CONFLICT DO NOTHING;
+
+
+-- MASCARAMENTO DO RITMO DE INVESTIMENTO
+-- Cliente e vendedor veem só a situação do ritmo (status e over_budget), sem os valores investidos e esperados
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'spend_to_date'),
+    (3, 'expected_spend'),
+    (3, 'projected_spend'),
+    (3, 'pace_percent'),
+    (5, 'spend_to_date'),
+    (5, 'expected_spend'),
+    (5, 'projected_spend'),
+    (5, 'pace_percent')
+ON CONFLICT DO NOTHING;
+
+-- MASCARAMENTO DAS METAS DE CUSTO E DE INVESTIMENTO
+-- O CPA máximo e os percentuais atingidos permitem deduzir o custo por resultado e o orçamento, já ocultos
+INSERT INTO role_masked_fields (role_id, field) VALUES
+    (3, 'max_cpa'),
+    (3, 'cpa_attainment'),
+    (3, 'spend_attainment'),
+    (5, 'max_cpa'),
+    (5, 'cpa_attainment'),
+    (5, 'spend_attainment')
+ON CONFLICT DO NOTHING;
+
+-- LGPD: PEDIDOS SEM DADOS DO TITULAR
+COMMENT ON COLUMN privacy_erasure_requests.status IS 'pending, completed, not_found (nenhuma origem tinha dados do titular) ou failed';
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
@@ -22,10 +24,19 @@ type AccountRepository interface {
 	GetAccountByID(accountID string) (*domain.AdAccount, error)
 	GetAccountByExternalID(accountExternalID string) (*domain.AdAccount, error)
 	ListAccounts(availableStatus []domain.AdAccountStatus) ([]*domain.AdAccount, error)
+	ListAccountsPage(filters *domain.AccountListFilters) ([]*domain.AdAccount, int, error)
+	SearchAccounts(term string, scope domain.SearchScope) ([]*domain.AdAccount, error)
 	ListAccountsMap() (map[string]struct{}, error)
 	SaveOrUpdate(account []*domain.AdAccount, businessManagerIDs map[string]string) error
 	SaveOrUpdateBusinessManager(bms []*domain.BusinessManager) (map[string]string, error)
+	UpdateAccountCurrencies(origin string, currencies map[string]string) error
 	UpdateAccount(account *domain.UpdateAdAccountRequest) error
+	MergeAccounts(merge *domain.AccountMerge) error
+	ListAccountMerges(accountID string) ([]*domain.AccountMerge, error)
+	ListBusinessManagerSummaries(startDate, endDate, staleBefore time.Time) ([]*domain.BusinessManagerSummary, error)
+	UpdateAccountLocation(accountID string, location *domain.AccountLocation) error
+	UpdateAccountVIP(accountID string, vip bool) error
+	ListMapInsights(startDate, endDate time.Time) ([]*domain.MapCityInsight, error)
 }
 
 type accountRepository struct {
@@ -48,7 +59,7 @@ func (a *accountRepository) GetAccountByID(accountID string) (*domain.AdAccount,
 
 func (a *accountRepository) GetAccount(whereClause map[string]interface{}) (*domain.AdAccount, error) {
 	accountsSQL, accountsArgs, err := squirrel.
-		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, a.origin, a.business_id").
+		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, a.origin, a.business_id, a.currency, a.locale, a.timezone, a.merged_into, a.vip, " + accountLocationColumns).
 		From(accountsTable).
 		Where(whereClause).
 		PlaceholderFormat(squirrel.Dollar).
@@ -72,6 +83,7 @@ func (a *accountRepository) GetAccount(whereClause map[string]interface{}) (*dom
 
 func (a *accountRepository) deserializeAccount(row *sql.Row) (*domain.AdAccount, error) {
 	acc := &domain.AdAccount{}
+	var location nullableLocation
 
 	if err := row.Scan(
 		&acc.ID,
@@ -83,16 +95,27 @@ func (a *accountRepository) deserializeAccount(row *sql.Row) (*domain.AdAccount,
 		&acc.Status,
 		&acc.Origin,
 		&acc.BusinessManagerID,
+		&acc.Currency,
+		&acc.Locale,
+		&acc.Timezone,
+		&acc.MergedInto,
+		&acc.VIP,
+		&location.city,
+		&location.state,
+		&location.latitude,
+		&location.longitude,
+		&location.geocodedAt,
 	); err != nil {
 		return nil, err
 	}
 
+	acc.Location = location.toDomain()
 	return acc, nil
 }
 
 func (a *accountRepository) ListAccounts(availableStatus []domain.AdAccountStatus) ([]*domain.AdAccount, error) {
 	queryBuilder := squirrel.
-		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, bm.id, bm.name").
+		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, bm.id, bm.name, a.currency, a.locale, a.timezone, a.merged_into, a.vip, " + accountLocationColumns).
 		From(accountsTable).
 		Join("business_manager bm ON a.business_id = bm.id").
 		OrderBy("a.nickname ASC").
@@ -139,6 +162,133 @@ func (a *accountRepository) ListAccounts(availableStatus []domain.AdAccountStatu
 	return accounts, err
 }
 
+// SearchAccounts busca contas pelo apelido, nome ou CNPJ. Além do ILIKE, aceita apelidos e nomes
+// parecidos (similaridade de trigramas do pg_trgm), ordenando pelos mais parecidos com o termo.
+func (a *accountRepository) SearchAccounts(term string, scope domain.SearchScope) ([]*domain.AdAccount, error) {
+	pattern := likePattern(term)
+
+	matches := squirrel.Or{
+		squirrel.ILike{"a.nickname": pattern},
+		squirrel.ILike{"a.name": pattern},
+		squirrel.Expr("a.nickname % ?", term),
+		squirrel.Expr("a.name % ?", term),
+	}
+	if digits := onlyDigits(term); len(digits) >= 3 {
+		matches = append(matches, squirrel.Like{"a.cnpj": likePattern(digits)})
+	}
+
+	query := squirrel.
+		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, bm.id, bm.name, a.currency, a.locale, a.timezone, a.merged_into, a.vip, "+accountLocationColumns).
+		From(accountsTable).
+		Join("business_manager bm ON a.business_id = bm.id").
+		Where(matches).
+		OrderByClause("GREATEST(similarity(COALESCE(a.nickname, ''), ?), similarity(a.name, ?)) DESC", term, term).
+		OrderBy("a.nickname ASC", "a.id ASC").
+		Limit(uint64(scope.Limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if !scope.Unrestricted {
+		query = query.Where("a.id IN (SELECT account_id FROM user_accounts WHERE user_id = ?)", scope.UserID)
+	}
+
+	accountsSQL, accountsArgs, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	rows, err := a.conn.Query(accountsSQL, accountsArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar contas: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]*domain.AdAccount, 0)
+	for rows.Next() {
+		acc, err := a.deserializeAccountWithBM(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// ListAccountsPage retorna uma página das contas que atendem aos filtros e o total de contas encontradas
+func (a *accountRepository) ListAccountsPage(filters *domain.AccountListFilters) ([]*domain.AdAccount, int, error) {
+	where := squirrel.And{}
+	if len(filters.Status) > 0 {
+		where = append(where, squirrel.Eq{"a.status": filters.Status})
+	}
+	if filters.BusinessManagerID != "" {
+		where = append(where, squirrel.Eq{"bm.id": filters.BusinessManagerID})
+	}
+	if filters.Search != "" {
+		term := "%" + filters.Search + "%"
+		where = append(where, squirrel.Or{
+			squirrel.ILike{"a.nickname": term},
+			squirrel.ILike{"a.name": term},
+			squirrel.Like{"a.cnpj": term},
+		})
+	}
+
+	countSQL, countArgs, err := squirrel.
+		Select("COUNT(*)").
+		From(accountsTable).
+		Join("business_manager bm ON a.business_id = bm.id").
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := a.conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar contas: %w", err)
+	}
+
+	// O ID desempata contas com o mesmo apelido para que as páginas não se sobreponham
+	accountsSQL, accountsArgs, err := squirrel.
+		Select("a.id, a.external_id, a.name, a.nickname, a.cnpj, a.secret_name, a.status, bm.id, bm.name, a.currency, a.locale, a.timezone, a.merged_into, a.vip, "+accountLocationColumns).
+		From(accountsTable).
+		Join("business_manager bm ON a.business_id = bm.id").
+		Where(where).
+		OrderBy("a.nickname ASC", "a.id ASC").
+		Limit(uint64(filters.Limit)).
+		Offset(uint64(filters.Offset)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := a.conn.Query(accountsSQL, accountsArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar contas: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]*domain.AdAccount, 0, filters.Limit)
+	for rows.Next() {
+		acc, err := a.deserializeAccountWithBM(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return accounts, total, nil
+}
+
 func (r *accountRepository) SaveOrUpdate(accounts []*domain.AdAccount, businessManagerIDs map[string]string) error {
 	if len(accounts) == 0 {
 		return nil
@@ -147,7 +297,7 @@ func (r *accountRepository) SaveOrUpdate(accounts []*domain.AdAccount, businessM
 	// Cria a query de inserção ou atualização
 	query := squirrel.StatementBuilder.
 		Insert("accounts").
-		Columns("id", "external_id", "cnpj", "secret_name", "name", "nickname", "origin", "business_id", "status").
+		Columns("id", "external_id", "cnpj", "secret_name", "name", "nickname", "origin", "business_id", "status", "currency").
 		PlaceholderFormat(squirrel.Dollar)
 
 	// Adiciona os valores de cada account ao batch
@@ -172,6 +322,7 @@ func (r *accountRepository) SaveOrUpdate(accounts []*domain.AdAccount, businessM
 			account.Origin,
 			businessID,
 			account.Status,
+			account.FormatMetadata().Currency,
 		)
 	}
 
@@ -203,6 +354,39 @@ func (r *accountRepository) SaveOrUpdate(accounts []*domain.AdAccount, businessM
 	return nil
 }
 
+// UpdateAccountCurrencies atualiza a moeda das contas da origem, indexadas pelo external_id. A moeda
+// de cobrança vem da plataforma e prevalece sobre a informada manualmente.
+func (r *accountRepository) UpdateAccountCurrencies(origin string, currencies map[string]string) error {
+	if len(currencies) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(currencies))
+	args := []interface{}{origin}
+	for externalID, currency := range currencies {
+		values = append(values, fmt.Sprintf("($%d, $%d)", len(args)+1, len(args)+2))
+		args = append(args, externalID, currency)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE accounts SET currency = v.currency
+		FROM (VALUES %s) AS v(external_id, currency)
+		WHERE accounts.origin = $1
+			AND accounts.external_id = v.external_id
+			AND accounts.currency <> v.currency
+	`, strings.Join(values, ", "))
+
+	_, err := r.conn.Exec(query, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			return fmt.Errorf("database error: %w (code: %s)", pqErr, pqErr.Code)
+		}
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
 func (r *accountRepository) SaveOrUpdateBusinessManager(bms []*domain.BusinessManager) (map[string]string, error) {
 	// Inicializa o mapa para armazenar os IDs dos business managers
 	businessManagerIDS := make(map[string]string, 0)
@@ -267,6 +451,7 @@ func (r *accountRepository) SaveOrUpdateBusinessManager(bms []*domain.BusinessMa
 
 func (a *accountRepository) deserializeAccountWithBM(row *sql.Rows) (*domain.AdAccount, error) {
 	acc := domain.AdAccount{}
+	var location nullableLocation
 
 	if err := row.Scan(
 		&acc.ID,
@@ -278,6 +463,16 @@ func (a *accountRepository) deserializeAccountWithBM(row *sql.Rows) (*domain.AdA
 		&acc.Status,
 		&acc.BusinessManagerID,
 		&acc.BusinessManagerName,
+		&acc.Currency,
+		&acc.Locale,
+		&acc.Timezone,
+		&acc.MergedInto,
+		&acc.VIP,
+		&location.city,
+		&location.state,
+		&location.latitude,
+		&location.longitude,
+		&location.geocodedAt,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -286,6 +481,7 @@ func (a *accountRepository) deserializeAccountWithBM(row *sql.Rows) (*domain.AdA
 		return nil, err
 	}
 
+	acc.Location = location.toDomain()
 	return &acc, nil
 }
 
@@ -317,6 +513,18 @@ func (a *accountRepository) UpdateAccount(account *domain.UpdateAdAccountRequest
 		queryBuilder = queryBuilder.Set("status", *account.Status)
 	}
 
+	if account.Currency != nil {
+		queryBuilder = queryBuilder.Set("currency", *account.Currency)
+	}
+
+	if account.Locale != nil {
+		queryBuilder = queryBuilder.Set("locale", *account.Locale)
+	}
+
+	if account.Timezone != nil {
+		queryBuilder = queryBuilder.Set("timezone", *account.Timezone)
+	}
+
 	// Converte a query para SQL
 	sqlQuery, args, err := queryBuilder.ToSql()
 	if err != nil {
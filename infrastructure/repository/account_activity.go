@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	accountActivitiesTable = "account_activities"
+	accountViewsTable      = "account_views"
+)
+
+type AccountActivityRepository interface {
+	// CreateActivity aceita o ID interno ou o external_id da conta e retorna sql.ErrNoRows se ela não existir
+	CreateActivity(activity *domain.AccountActivity) (*domain.AccountActivity, error)
+	ListActivities(accountID string, filters domain.AccountActivityFilters) ([]*domain.AccountActivity, error)
+	// RecordView aceita o ID interno ou o external_id da conta; contas inexistentes são ignoradas
+	RecordView(accountID string, at time.Time) error
+	// ListActivityScores soma visualizações e investimento de cada conta a partir de since
+	ListActivityScores(since time.Time) ([]*domain.AccountActivityScore, error)
+}
+
+type accountActivityRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAccountActivityRepository(conn *postgres.Connection) AccountActivityRepository {
+	return &accountActivityRepository{
+		conn: conn,
+	}
+}
+
+func (r *accountActivityRepository) CreateActivity(activity *domain.AccountActivity) (*domain.AccountActivity, error) {
+	var details any
+	if len(activity.Details) > 0 {
+		details = string(activity.Details)
+	}
+
+	// Resolve o ID interno a partir do ID informado no mesmo comando do insert
+	account := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?", activity.Type)).
+		Column(squirrel.Expr("?", activity.Summary)).
+		Column(squirrel.Expr("?::jsonb", details)).
+		Column(squirrel.Expr("?::int", activity.UserID)).
+		From("accounts").
+		Where("id = ? OR external_id = ?", activity.AccountID, activity.AccountID).
+		Limit(1)
+
+	query := squirrel.
+		Insert(accountActivitiesTable).
+		Columns("account_id", "type", "summary", "details", "user_id").
+		Select(account).
+		Suffix("RETURNING id, account_id, created_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&activity.ID, &activity.AccountID, &activity.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar atividade da conta: %w", err)
+	}
+
+	return activity, nil
+}
+
+func (r *accountActivityRepository) ListActivities(accountID string, filters domain.AccountActivityFilters) ([]*domain.AccountActivity, error) {
+	query := squirrel.
+		Select("aa.id", "aa.account_id", "aa.type", "aa.summary", "aa.details", "aa.user_id",
+			"COALESCE(u.name || ' ' || u.lastname, '')", "aa.created_at").
+		From(accountActivitiesTable+" aa").
+		Join("accounts a ON a.id = aa.account_id").
+		LeftJoin("users u ON u.id = aa.user_id").
+		Where("(a.id = ? OR a.external_id = ?)", accountID, accountID).
+		OrderBy("aa.created_at DESC", "aa.id DESC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	if len(filters.Types) > 0 {
+		query = query.Where(squirrel.Eq{"aa.type": filters.Types})
+	}
+	if filters.Before != nil {
+		query = query.Where(squirrel.Lt{"aa.created_at": *filters.Before})
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(uint64(filters.Limit))
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar atividades da conta: %w", err)
+	}
+	defer rows.Close()
+
+	activities := make([]*domain.AccountActivity, 0)
+	for rows.Next() {
+		var activity domain.AccountActivity
+		var details []byte
+		var userID sql.NullInt64
+		if err := rows.Scan(
+			&activity.ID,
+			&activity.AccountID,
+			&activity.Type,
+			&activity.Summary,
+			&details,
+			&userID,
+			&activity.UserName,
+			&activity.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler atividade da conta: %w", err)
+		}
+
+		if len(details) > 0 {
+			activity.Details = json.RawMessage(details)
+		}
+		if userID.Valid {
+			id := int(userID.Int64)
+			activity.UserID = &id
+		}
+
+		activities = append(activities, &activity)
+	}
+
+	return activities, rows.Err()
+}
+
+func (r *accountActivityRepository) RecordView(accountID string, at time.Time) error {
+	account := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?::date", at.Format(time.DateOnly))).
+		Column(squirrel.Expr("1")).
+		Column(squirrel.Expr("?::timestamptz", at)).
+		From("accounts").
+		Where("id = ? OR external_id = ?", accountID, accountID).
+		Limit(1)
+
+	query := squirrel.
+		Insert(accountViewsTable).
+		Columns("account_id", "date", "views", "last_viewed_at").
+		Select(account).
+		Suffix(`
+			ON CONFLICT (account_id, date) DO UPDATE SET
+				views = account_views.views + 1,
+				last_viewed_at = EXCLUDED.last_viewed_at`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao registrar visualização da conta: %w", err)
+	}
+
+	return nil
+}
+
+func (r *accountActivityRepository) ListActivityScores(since time.Time) ([]*domain.AccountActivityScore, error) {
+	sinceDate := since.Format(time.DateOnly)
+
+	views := squirrel.
+		Select("account_id", "SUM(views) AS views").
+		From(accountViewsTable).
+		Where("date >= ?", sinceDate).
+		GroupBy("account_id")
+
+	spend := squirrel.
+		Select("account_id", "SUM(COALESCE((ad_metrics->>'spend')::NUMERIC, 0)) AS spend").
+		From("ad_insights").
+		Where("date >= ?", sinceDate).
+		GroupBy("account_id")
+
+	query := squirrel.
+		Select("a.id", "COALESCE(v.views, 0)", "COALESCE(s.spend, 0)").
+		From("accounts a").
+		JoinClause(views.Prefix("LEFT JOIN (").Suffix(") v ON v.account_id = a.id")).
+		JoinClause(spend.Prefix("LEFT JOIN (").Suffix(") s ON s.account_id = a.id")).
+		Where("(v.views IS NOT NULL OR s.spend IS NOT NULL)").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar score de atividade das contas: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make([]*domain.AccountActivityScore, 0)
+	for rows.Next() {
+		var score domain.AccountActivityScore
+		if err := rows.Scan(&score.AccountID, &score.Views, &score.Spend); err != nil {
+			return nil, fmt.Errorf("erro ao ler score de atividade da conta: %w", err)
+		}
+		scores = append(scores, &score)
+	}
+
+	return scores, rows.Err()
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const accountLocationColumns = "a.city, a.state, a.latitude, a.longitude, a.geocoded_at"
+
+// nullableLocation recebe as colunas de localização da conta, que ficam nulas até a loja ser localizada
+type nullableLocation struct {
+	city       sql.NullString
+	state      sql.NullString
+	latitude   sql.NullFloat64
+	longitude  sql.NullFloat64
+	geocodedAt sql.NullTime
+}
+
+func (n nullableLocation) toDomain() *domain.AccountLocation {
+	if !n.state.Valid {
+		return nil
+	}
+
+	location := &domain.AccountLocation{
+		City:  n.city.String,
+		State: n.state.String,
+	}
+	if n.latitude.Valid && n.longitude.Valid {
+		location.Latitude = &n.latitude.Float64
+		location.Longitude = &n.longitude.Float64
+	}
+	if n.geocodedAt.Valid {
+		location.GeocodedAt = &n.geocodedAt.Time
+	}
+	return location
+}
+
+// UpdateAccountLocation grava a localização da loja da conta
+func (a *accountRepository) UpdateAccountLocation(accountID string, location *domain.AccountLocation) error {
+	sqlQuery, args, err := squirrel.
+		Update("accounts").
+		Set("city", location.City).
+		Set("state", location.State).
+		Set("latitude", location.Latitude).
+		Set("longitude", location.Longitude).
+		Set("geocoded_at", location.GeocodedAt).
+		Where(squirrel.Eq{"id": accountID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := a.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar localização da conta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("conta não encontrada: %s", accountID)
+	}
+
+	return nil
+}
+
+// ListMapInsights soma o investimento e o faturamento em redes sociais do período (caches diários) das contas
+// ativas por UF e cidade, com a posição média das lojas. Contas sem UF voltam agrupadas em uma linha com State vazio.
+func (a *accountRepository) ListMapInsights(startDate, endDate time.Time) ([]*domain.MapCityInsight, error) {
+	query := `
+		SELECT
+			COALESCE(a.state, ''),
+			COALESCE(a.city, ''),
+			AVG(a.latitude),
+			AVG(a.longitude),
+			COUNT(a.id),
+			COALESCE(SUM(ad.spend), 0),
+			COALESCE(SUM(sales.revenue), 0)
+		FROM accounts a
+		LEFT JOIN (
+			SELECT account_id, SUM(COALESCE((ad_metrics->>'spend')::NUMERIC, 0)) AS spend
+			FROM ad_insights
+			WHERE date BETWEEN $1 AND $2
+			GROUP BY account_id
+		) ad ON ad.account_id = a.id
+		LEFT JOIN (
+			SELECT account_id, SUM(COALESCE((sales_metrics->'SocialNetwork'->>'TotalRevenue')::NUMERIC, 0)) AS revenue
+			FROM sales_insights
+			WHERE date BETWEEN $1 AND $2
+			GROUP BY account_id
+		) sales ON sales.account_id = a.id
+		WHERE a.status = 'ACTIVE' AND a.merged_into IS NULL
+		GROUP BY a.state, a.city
+		ORDER BY a.state, a.city`
+
+	rows, err := a.conn.Query(query, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar insights por localização: %w", err)
+	}
+	defer rows.Close()
+
+	cities := make([]*domain.MapCityInsight, 0)
+	for rows.Next() {
+		var city domain.MapCityInsight
+		var latitude, longitude sql.NullFloat64
+		if err := rows.Scan(
+			&city.State,
+			&city.City,
+			&latitude,
+			&longitude,
+			&city.Accounts,
+			&city.Spend,
+			&city.SocialNetworkRevenue,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+
+		if latitude.Valid && longitude.Valid {
+			city.Latitude = &latitude.Float64
+			city.Longitude = &longitude.Float64
+		}
+		cities = append(cities, &city)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return cities, nil
+}
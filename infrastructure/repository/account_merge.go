@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	accountMergesTable = "account_merges"
+	accountMergesLimit = 100
+)
+
+// mergeTables são as tabelas transferidas na mesclagem de contas, com as colunas que, junto com
+// account_id, identificam uma linha. Linhas da origem cujo período já existe no destino são mantidas na origem.
+var mergeTables = []struct {
+	table string
+	keys  []string
+}{
+	{table: "ad_insights", keys: []string{"date"}},
+	{table: "sales_insights", keys: []string{"date"}},
+	{table: "monthly_ad_insights", keys: []string{"period"}},
+	{table: "monthly_sales_insights", keys: []string{"period"}},
+	{table: "rollup_insights", keys: []string{"granularity", "period"}},
+	{table: "store_ranking", keys: []string{"month"}},
+	{table: "user_accounts", keys: []string{"user_id"}},
+	{table: "insight_annotations"},
+	{table: "account_activities"},
+}
+
+// MergeAccounts transfere, em uma transação, os dados da conta de origem para a conta destino,
+// inativa a origem apontando para o destino e registra o histórico da mesclagem
+func (a *accountRepository) MergeAccounts(merge *domain.AccountMerge) error {
+	tx, err := a.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	merge.MovedRows = make(map[string]int, len(mergeTables))
+	merge.KeptRows = make(map[string]int, len(mergeTables))
+
+	for _, t := range mergeTables {
+		conditions := make([]string, 0, len(t.keys))
+		for _, key := range t.keys {
+			conditions = append(conditions, fmt.Sprintf("dup.%s = t.%s", key, key))
+		}
+
+		query := fmt.Sprintf("UPDATE %s t SET account_id = $1 WHERE t.account_id = $2", t.table)
+		if len(conditions) > 0 {
+			query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM %s dup WHERE dup.account_id = $1 AND %s)", t.table, strings.Join(conditions, " AND "))
+		}
+
+		result, err := tx.Exec(query, merge.TargetID, merge.SourceID)
+		if err != nil {
+			return fmt.Errorf("erro ao transferir %s: %w", t.table, err)
+		}
+		moved, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("erro ao contar linhas transferidas de %s: %w", t.table, err)
+		}
+		merge.MovedRows[t.table] = int(moved)
+
+		var kept int
+		if err := tx.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE account_id = $1", t.table), merge.SourceID).Scan(&kept); err != nil {
+			return fmt.Errorf("erro ao contar linhas mantidas em %s: %w", t.table, err)
+		}
+		merge.KeptRows[t.table] = kept
+	}
+
+	result, err := tx.Exec(`
+		UPDATE accounts SET status = $1, merged_into = $2, merged_at = NOW()
+		WHERE id = $3 AND merged_into IS NULL`, domain.AdAccountStatusInactive, merge.TargetID, merge.SourceID)
+	if err != nil {
+		return fmt.Errorf("erro ao inativar conta mesclada: %w", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return fmt.Errorf("conta de origem já mesclada ou inexistente: %s", merge.SourceID)
+	}
+
+	moved, err := json.Marshal(merge.MovedRows)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar linhas transferidas: %w", err)
+	}
+	kept, err := json.Marshal(merge.KeptRows)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar linhas mantidas: %w", err)
+	}
+
+	insertSQL, args, err := squirrel.
+		Insert(accountMergesTable).
+		Columns("source_id", "target_id", "merged_by", "reason", "moved_rows", "kept_rows").
+		Values(merge.SourceID, merge.TargetID, merge.MergedBy, merge.Reason, moved, kept).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := tx.QueryRow(insertSQL, args...).Scan(&merge.ID, &merge.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao registrar mesclagem: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+// ListAccountMerges retorna as mesclagens mais recentes em que a conta foi origem ou destino; todas se accountID for vazio
+func (a *accountRepository) ListAccountMerges(accountID string) ([]*domain.AccountMerge, error) {
+	query := squirrel.
+		Select("id", "source_id", "target_id", "merged_by", "reason", "moved_rows", "kept_rows", "created_at").
+		From(accountMergesTable).
+		OrderBy("created_at DESC").
+		Limit(accountMergesLimit).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if accountID != "" {
+		query = query.Where(squirrel.Or{
+			squirrel.Eq{"source_id": accountID},
+			squirrel.Eq{"target_id": accountID},
+		})
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := a.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar mesclagens de contas: %w", err)
+	}
+	defer rows.Close()
+
+	merges := make([]*domain.AccountMerge, 0)
+	for rows.Next() {
+		var merge domain.AccountMerge
+		var reason sql.NullString
+		var moved, kept []byte
+		if err := rows.Scan(
+			&merge.ID,
+			&merge.SourceID,
+			&merge.TargetID,
+			&merge.MergedBy,
+			&reason,
+			&moved,
+			&kept,
+			&merge.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+
+		merge.Reason = reason.String
+		if err := json.Unmarshal(moved, &merge.MovedRows); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar linhas transferidas: %w", err)
+		}
+		if err := json.Unmarshal(kept, &merge.KeptRows); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar linhas mantidas: %w", err)
+		}
+		merges = append(merges, &merge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return merges, nil
+}
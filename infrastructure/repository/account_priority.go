@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// UpdateAccountVIP marca ou desmarca a conta como prioritária na sincronização
+func (a *accountRepository) UpdateAccountVIP(accountID string, vip bool) error {
+	sqlQuery, args, err := squirrel.
+		Update("accounts").
+		Set("vip", vip).
+		Where(squirrel.Eq{"id": accountID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := a.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar prioridade da conta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("conta não encontrada: %s", accountID)
+	}
+
+	return nil
+}
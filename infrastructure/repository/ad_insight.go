@@ -22,6 +22,8 @@ type AdInsightRepository interface {
 	SaveOrUpdate(insight *domain.AdInsightEntry) error
 	DeleteOlderThan(days int) (int64, error)
 	GetByDateRange(accountID string, startDate, endDate time.Time) ([]*domain.AdInsightEntry, error)
+	// GetLastSyncedAt retorna quando os insights da conta foram gravados pela última vez, ou nil se não houver
+	GetLastSyncedAt(accountID string) (*time.Time, error)
 }
 
 type adInsightRepository struct {
@@ -163,6 +165,28 @@ func (r *adInsightRepository) SaveOrUpdate(insight *domain.AdInsightEntry) error
 	return nil
 }
 
+func (r *adInsightRepository) GetLastSyncedAt(accountID string) (*time.Time, error) {
+	query, args, err := squirrel.
+		Select("MAX(updated_at)").
+		From("ad_insights").
+		Where(squirrel.Eq{"account_id": accountID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	var lastSyncedAt sql.NullTime
+	if err := r.conn.QueryRow(query, args...).Scan(&lastSyncedAt); err != nil {
+		return nil, fmt.Errorf("erro ao buscar última sincronização de insights de anúncios: %w", err)
+	}
+	if !lastSyncedAt.Valid {
+		return nil, nil
+	}
+
+	return &lastSyncedAt.Time, nil
+}
+
 func (r *adInsightRepository) DeleteOlderThan(days int) (int64, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
 
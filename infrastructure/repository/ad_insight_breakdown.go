@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	adInsightBreakdownsTable = "ad_insight_breakdowns"
+)
+
+type AdInsightBreakdownRepository interface {
+	Get(accountID string, startDate, endDate time.Time) (*domain.AdInsightBreakdownEntry, error)
+	SaveOrUpdate(entry *domain.AdInsightBreakdownEntry) error
+}
+
+type adInsightBreakdownRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAdInsightBreakdownRepository(conn *postgres.Connection) AdInsightBreakdownRepository {
+	return &adInsightBreakdownRepository{
+		conn: conn,
+	}
+}
+
+func (r *adInsightBreakdownRepository) Get(accountID string, startDate, endDate time.Time) (*domain.AdInsightBreakdownEntry, error) {
+	query, args, err := squirrel.
+		Select("id, account_id, start_date, end_date, breakdowns, fetched_at, created_at, updated_at").
+		From(adInsightBreakdownsTable).
+		Where(squirrel.Eq{
+			"account_id": accountID,
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	entry := &domain.AdInsightBreakdownEntry{}
+	var breakdownsJSON []byte
+
+	err = r.conn.QueryRow(query, args...).Scan(
+		&entry.ID,
+		&entry.AccountID,
+		&entry.StartDate,
+		&entry.EndDate,
+		&breakdownsJSON,
+		&entry.FetchedAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao escanear quebras de insights: %w", err)
+	}
+
+	entry.Breakdowns = &domain.AdAccountBreakdowns{}
+	if err := json.Unmarshal(breakdownsJSON, entry.Breakdowns); err != nil {
+		return nil, fmt.Errorf("erro ao deserializar JSON de breakdowns: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *adInsightBreakdownRepository) SaveOrUpdate(entry *domain.AdInsightBreakdownEntry) error {
+	breakdownsJSON, err := json.Marshal(entry.Breakdowns)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar breakdowns para JSON: %w", err)
+	}
+
+	query, args, err := squirrel.StatementBuilder.
+		Insert(adInsightBreakdownsTable).
+		Columns("account_id", "start_date", "end_date", "breakdowns", "fetched_at").
+		Values(
+			entry.AccountID,
+			entry.StartDate.Format("2006-01-02"),
+			entry.EndDate.Format("2006-01-02"),
+			breakdownsJSON,
+			entry.FetchedAt,
+		).
+		Suffix(`
+			ON CONFLICT (account_id, start_date, end_date) DO UPDATE SET
+				breakdowns = EXCLUDED.breakdowns,
+				fetched_at = EXCLUDED.fetched_at,
+				updated_at = NOW()
+		`).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	_, err = r.conn.Exec(query, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			return fmt.Errorf("erro no banco de dados: %w (código: %s)", pqErr, pqErr.Code)
+		}
+		return fmt.Errorf("erro ao executar a query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	insightAnnotationsTable = "insight_annotations"
+)
+
+var annotationColumns = []string{"id", "account_id", "date", "type", "text", "user_id", "created_at", "updated_at"}
+
+// As operações aceitam o ID interno ou o external_id da conta
+type AnnotationRepository interface {
+	// CreateAnnotation retorna sql.ErrNoRows se a conta não existir
+	CreateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error)
+	ListAnnotations(accountID string, startDate, endDate time.Time) ([]*domain.Annotation, error)
+	// UpdateAnnotation retorna sql.ErrNoRows se a anotação não existir na conta
+	UpdateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error)
+	// DeleteAnnotation retorna sql.ErrNoRows se a anotação não existir na conta
+	DeleteAnnotation(accountID string, annotationID int) error
+}
+
+type annotationRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAnnotationRepository(conn *postgres.Connection) AnnotationRepository {
+	return &annotationRepository{
+		conn: conn,
+	}
+}
+
+func (r *annotationRepository) CreateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error) {
+	account := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?::date", annotation.Date)).
+		Column(squirrel.Expr("?", annotation.Type)).
+		Column(squirrel.Expr("?", annotation.Text)).
+		Column(squirrel.Expr("?::int", annotation.UserID)).
+		From("accounts").
+		Where("id = ? OR external_id = ?", annotation.AccountID, annotation.AccountID).
+		Limit(1)
+
+	query := squirrel.
+		Insert(insightAnnotationsTable).
+		Columns("account_id", "date", "type", "text", "user_id").
+		Select(account).
+		Suffix("RETURNING " + strings.Join(annotationColumns, ", ")).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	created, err := scanAnnotation(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar anotação: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *annotationRepository) ListAnnotations(accountID string, startDate, endDate time.Time) ([]*domain.Annotation, error) {
+	query := squirrel.
+		Select(annotationColumns...).
+		From(insightAnnotationsTable).
+		Where(accountScope(accountID)).
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		OrderBy("date ASC", "id ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar anotações: %w", err)
+	}
+	defer rows.Close()
+
+	annotations := []*domain.Annotation{}
+	for rows.Next() {
+		annotation, err := scanAnnotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, rows.Err()
+}
+
+func (r *annotationRepository) UpdateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error) {
+	query := squirrel.
+		Update(insightAnnotationsTable).
+		Set("date", annotation.Date).
+		Set("type", annotation.Type).
+		Set("text", annotation.Text).
+		Where(squirrel.Eq{"id": annotation.ID}).
+		Where(accountScope(annotation.AccountID)).
+		Suffix("RETURNING " + strings.Join(annotationColumns, ", ")).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	updated, err := scanAnnotation(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar anotação: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *annotationRepository) DeleteAnnotation(accountID string, annotationID int) error {
+	query := squirrel.
+		Delete(insightAnnotationsTable).
+		Where(squirrel.Eq{"id": annotationID}).
+		Where(accountScope(accountID)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao remover anotação: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao remover anotação: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// accountScope filtra pela conta informada pelo ID interno ou pelo external_id
+func accountScope(accountID string) squirrel.Sqlizer {
+	return squirrel.Expr("account_id IN (SELECT id FROM accounts WHERE id = ? OR external_id = ?)", accountID, accountID)
+}
+
+func scanAnnotation(row rowScanner) (*domain.Annotation, error) {
+	var annotation domain.Annotation
+	var date time.Time
+	var userID sql.NullInt64
+
+	if err := row.Scan(
+		&annotation.ID,
+		&annotation.AccountID,
+		&date,
+		&annotation.Type,
+		&annotation.Text,
+		&userID,
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	annotation.Date = date.Format(time.DateOnly)
+	if userID.Valid {
+		id := int(userID.Int64)
+		annotation.UserID = &id
+	}
+
+	return &annotation, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	insightAnomaliesTable = "insight_anomalies"
+)
+
+var anomalyColumns = []string{"id", "account_id", "date", "metric", "value", "mean", "std_dev", "z_score", "direction", "detected_at"}
+
+type AnomalyRepository interface {
+	// ReplaceAnomalies substitui as anomalias da conta entre startDate e endDate pelas informadas, removendo
+	// as que deixaram de ser anomalias após a reavaliação
+	ReplaceAnomalies(accountID string, startDate, endDate time.Time, anomalies []*domain.InsightAnomaly) error
+	// ListAnomalies aceita o ID interno ou o external_id da conta
+	ListAnomalies(accountID string, startDate, endDate time.Time) ([]*domain.InsightAnomaly, error)
+}
+
+type anomalyRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAnomalyRepository(conn *postgres.Connection) AnomalyRepository {
+	return &anomalyRepository{
+		conn: conn,
+	}
+}
+
+func (r *anomalyRepository) ReplaceAnomalies(accountID string, startDate, endDate time.Time, anomalies []*domain.InsightAnomaly) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery, args, err := squirrel.
+		Delete(insightAnomaliesTable).
+		Where(squirrel.Eq{"account_id": accountID}).
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(deleteQuery, args...); err != nil {
+		return fmt.Errorf("erro ao remover anomalias: %w", err)
+	}
+
+	if len(anomalies) > 0 {
+		insert := squirrel.
+			Insert(insightAnomaliesTable).
+			Columns("account_id", "date", "metric", "value", "mean", "std_dev", "z_score", "direction")
+		for _, anomaly := range anomalies {
+			insert = insert.Values(accountID, anomaly.Date, anomaly.Metric, anomaly.Value, anomaly.Mean, anomaly.StdDev, anomaly.ZScore, anomaly.Direction)
+		}
+
+		insertQuery, args, err := insert.PlaceholderFormat(squirrel.Dollar).ToSql()
+		if err != nil {
+			return fmt.Errorf("erro ao construir consulta: %w", err)
+		}
+
+		if _, err := tx.Exec(insertQuery, args...); err != nil {
+			return fmt.Errorf("erro ao salvar anomalias: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+func (r *anomalyRepository) ListAnomalies(accountID string, startDate, endDate time.Time) ([]*domain.InsightAnomaly, error) {
+	query := squirrel.
+		Select(anomalyColumns...).
+		From(insightAnomaliesTable).
+		Where(accountScope(accountID)).
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		OrderBy("date ASC", "metric ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar anomalias: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := []*domain.InsightAnomaly{}
+	for rows.Next() {
+		var anomaly domain.InsightAnomaly
+		var date time.Time
+		if err := rows.Scan(
+			&anomaly.ID,
+			&anomaly.AccountID,
+			&date,
+			&anomaly.Metric,
+			&anomaly.Value,
+			&anomaly.Mean,
+			&anomaly.StdDev,
+			&anomaly.ZScore,
+			&anomaly.Direction,
+			&anomaly.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear anomalia: %w", err)
+		}
+		anomaly.Date = date.Format(time.DateOnly)
+		anomalies = append(anomalies, &anomaly)
+	}
+
+	return anomalies, rows.Err()
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	apiKeysTable = "api_keys"
+)
+
+var apiKeyColumns = []string{
+	"id", "user_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at",
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(key *domain.APIKey) error
+	// GetAPIKeyByHash retorna a chave ou nil se não existir
+	GetAPIKeyByHash(keyHash string) (*domain.APIKey, error)
+	ListUserAPIKeys(userID int) ([]*domain.APIKey, error)
+	// RevokeAPIKey revoga a chave do usuário e informa se ela existia e ainda estava ativa
+	RevokeAPIKey(userID, keyID int) (bool, error)
+	// RevokeUserAPIKeys revoga todas as chaves ativas do usuário e retorna quantas foram revogadas
+	RevokeUserAPIKeys(userID int) (int, error)
+	TouchAPIKey(keyID int, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAPIKeyRepository(conn *postgres.Connection) APIKeyRepository {
+	return &apiKeyRepository{
+		conn: conn,
+	}
+}
+
+func (r *apiKeyRepository) CreateAPIKey(key *domain.APIKey) error {
+	query, args, err := squirrel.
+		Insert(apiKeysTable).
+		Columns("user_id", "name", "prefix", "key_hash", "scopes", "expires_at").
+		Values(key.UserID, key.Name, key.Prefix, key.KeyHash, pq.Array(permissionsToStrings(key.Scopes)), key.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&key.ID, &key.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao salvar chave de API: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetAPIKeyByHash(keyHash string) (*domain.APIKey, error) {
+	query, args, err := squirrel.
+		Select(apiKeyColumns...).
+		From(apiKeysTable).
+		Where(squirrel.Eq{"key_hash": keyHash}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	key, err := scanAPIKey(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) ListUserAPIKeys(userID int) ([]*domain.APIKey, error) {
+	query, args, err := squirrel.
+		Select(apiKeyColumns...).
+		From(apiKeysTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar chaves de API: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepository) RevokeAPIKey(userID, keyID int) (bool, error) {
+	query, args, err := squirrel.
+		Update(apiKeysTable).
+		Set("revoked_at", time.Now()).
+		Where(squirrel.Eq{"id": keyID, "user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao revogar chave de API: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar revogação da chave de API: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *apiKeyRepository) RevokeUserAPIKeys(userID int) (int, error) {
+	query, args, err := squirrel.
+		Update(apiKeysTable).
+		Set("revoked_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao revogar chaves de API do usuário: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar revogação das chaves de API: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+func (r *apiKeyRepository) TouchAPIKey(keyID int, usedAt time.Time) error {
+	query, args, err := squirrel.
+		Update(apiKeysTable).
+		Set("last_used_at", usedAt).
+		Where(squirrel.Eq{"id": keyID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao registrar uso da chave de API: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes []string
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		pq.Array(&scopes),
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler chave de API: %w", err)
+	}
+
+	key.Scopes = make([]domain.Permission, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.Permission(scope))
+	}
+
+	return &key, nil
+}
+
+func permissionsToStrings(permissions []domain.Permission) []string {
+	values := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		values = append(values, string(p))
+	}
+	return values
+}
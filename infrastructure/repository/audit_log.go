@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	auditLogTable = "audit_log"
+)
+
+type AuditLogRepository interface {
+	CreateEntry(entry *domain.AuditEntry) error
+	// ListEntries retorna a página do log e o total de registros que atendem aos filtros
+	ListEntries(filters domain.AuditLogFilters) ([]*domain.AuditEntry, int, error)
+}
+
+type auditLogRepository struct {
+	conn *postgres.Connection
+}
+
+func NewAuditLogRepository(conn *postgres.Connection) AuditLogRepository {
+	return &auditLogRepository{
+		conn: conn,
+	}
+}
+
+func (r *auditLogRepository) CreateEntry(entry *domain.AuditEntry) error {
+	var details any
+	if len(entry.Details) > 0 {
+		details = string(entry.Details)
+	}
+
+	query, args, err := squirrel.
+		Insert(auditLogTable).
+		Columns("actor_id", "action", "entity_type", "entity_id", "details").
+		Values(entry.ActorID, entry.Action, entry.EntityType, entry.EntityID, squirrel.Expr("?::jsonb", details)).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao registrar auditoria: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) ListEntries(filters domain.AuditLogFilters) ([]*domain.AuditEntry, int, error) {
+	where := squirrel.And{}
+	if filters.ActorID != nil {
+		where = append(where, squirrel.Eq{"al.actor_id": *filters.ActorID})
+	}
+	if filters.Action != "" {
+		where = append(where, squirrel.Eq{"al.action": filters.Action})
+	}
+	if filters.EntityType != "" {
+		where = append(where, squirrel.Eq{"al.entity_type": filters.EntityType})
+	}
+	if filters.EntityID != "" {
+		where = append(where, squirrel.Eq{"al.entity_id": filters.EntityID})
+	}
+	if filters.From != nil {
+		where = append(where, squirrel.GtOrEq{"al.created_at": *filters.From})
+	}
+	if filters.To != nil {
+		where = append(where, squirrel.Lt{"al.created_at": *filters.To})
+	}
+
+	countSQL, countArgs, err := squirrel.
+		Select("COUNT(*)").
+		From(auditLogTable + " al").
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var total int
+	if err := r.conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar registros de auditoria: %w", err)
+	}
+
+	entriesSQL, entriesArgs, err := squirrel.
+		Select("al.id", "al.actor_id", "COALESCE(u.name || ' ' || u.lastname, '')", "al.action",
+			"al.entity_type", "al.entity_id", "al.details", "al.created_at").
+		From(auditLogTable+" al").
+		LeftJoin("users u ON u.id = al.actor_id").
+		Where(where).
+		OrderBy("al.created_at DESC", "al.id DESC").
+		Limit(uint64(filters.Limit)).
+		Offset(uint64(filters.Offset)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(entriesSQL, entriesArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao consultar log de auditoria: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0, filters.Limit)
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var details []byte
+		var actorID sql.NullInt64
+		if err := rows.Scan(
+			&entry.ID,
+			&actorID,
+			&entry.ActorName,
+			&entry.Action,
+			&entry.EntityType,
+			&entry.EntityID,
+			&details,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler registro de auditoria: %w", err)
+		}
+
+		if len(details) > 0 {
+			entry.Details = json.RawMessage(details)
+		}
+		if actorID.Valid {
+			id := int(actorID.Int64)
+			entry.ActorID = &id
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return entries, total, nil
+}
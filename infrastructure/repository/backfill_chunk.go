@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	backfillChunksTable = "backfill_chunks"
+)
+
+type BackfillChunkRepository interface {
+	// ListChunks retorna os blocos já registrados da origem contidos no período
+	ListChunks(source string, startDate, endDate time.Time) ([]*domain.BackfillChunk, error)
+	SaveChunk(chunk *domain.BackfillChunk) error
+}
+
+type backfillChunkRepository struct {
+	conn *postgres.Connection
+}
+
+func NewBackfillChunkRepository(conn *postgres.Connection) BackfillChunkRepository {
+	return &backfillChunkRepository{
+		conn: conn,
+	}
+}
+
+func (r *backfillChunkRepository) ListChunks(source string, startDate, endDate time.Time) ([]*domain.BackfillChunk, error) {
+	query := squirrel.
+		Select("source", "start_date", "end_date", "status", "failed_accounts", "updated_at").
+		From(backfillChunksTable).
+		Where(squirrel.Eq{"source": source}).
+		Where(squirrel.GtOrEq{"start_date": startDate.Format(time.DateOnly)}).
+		Where(squirrel.LtOrEq{"end_date": endDate.Format(time.DateOnly)}).
+		OrderBy("start_date ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar blocos de backfill: %w", err)
+	}
+	defer rows.Close()
+
+	chunks := []*domain.BackfillChunk{}
+	for rows.Next() {
+		var chunk domain.BackfillChunk
+		if err := rows.Scan(&chunk.Source, &chunk.StartDate, &chunk.EndDate, &chunk.Status, &chunk.FailedAccounts, &chunk.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("erro ao ler bloco de backfill: %w", err)
+		}
+		chunks = append(chunks, &chunk)
+	}
+
+	return chunks, rows.Err()
+}
+
+func (r *backfillChunkRepository) SaveChunk(chunk *domain.BackfillChunk) error {
+	query := squirrel.
+		Insert(backfillChunksTable).
+		Columns("source", "start_date", "end_date", "status", "failed_accounts").
+		Values(chunk.Source, chunk.StartDate.Format(time.DateOnly), chunk.EndDate.Format(time.DateOnly), chunk.Status, chunk.FailedAccounts).
+		Suffix(`
+			ON CONFLICT (source, start_date, end_date) DO UPDATE SET
+				status = EXCLUDED.status,
+				failed_accounts = EXCLUDED.failed_accounts`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao salvar bloco de backfill: %w", err)
+	}
+
+	return nil
+}
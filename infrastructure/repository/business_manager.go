@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// ListBusinessManagerSummaries resume os business managers com a contagem de contas, o investimento e o
+// faturamento em redes sociais do período (caches diários) e as contas ativas sem insights desde staleBefore.
+// Contas mescladas em outra conta não são contadas.
+func (a *accountRepository) ListBusinessManagerSummaries(startDate, endDate, staleBefore time.Time) ([]*domain.BusinessManagerSummary, error) {
+	query := `
+		SELECT
+			bm.id,
+			COALESCE(bm.external_id, ''),
+			bm.name,
+			bm.origin,
+			COUNT(a.id),
+			COUNT(a.id) FILTER (WHERE a.status = 'ACTIVE'),
+			COALESCE(SUM(ad.spend), 0),
+			COALESCE(SUM(sales.revenue), 0),
+			MAX(ad.last_sync_at),
+			MAX(sales.last_sync_at),
+			COUNT(a.id) FILTER (WHERE a.status = 'ACTIVE' AND (ad.last_date IS NULL OR ad.last_date < $3))
+		FROM business_manager bm
+		LEFT JOIN accounts a ON a.business_id = bm.id AND a.merged_into IS NULL
+		LEFT JOIN (
+			SELECT account_id,
+				SUM(COALESCE((ad_metrics->>'spend')::NUMERIC, 0)) AS spend,
+				MAX(updated_at) AS last_sync_at,
+				MAX(date) AS last_date
+			FROM ad_insights
+			WHERE date BETWEEN $1 AND $2
+			GROUP BY account_id
+		) ad ON ad.account_id = a.id
+		LEFT JOIN (
+			SELECT account_id,
+				SUM(COALESCE((sales_metrics->'SocialNetwork'->>'TotalRevenue')::NUMERIC, 0)) AS revenue,
+				MAX(updated_at) AS last_sync_at
+			FROM sales_insights
+			WHERE date BETWEEN $1 AND $2
+			GROUP BY account_id
+		) sales ON sales.account_id = a.id
+		GROUP BY bm.id, bm.external_id, bm.name, bm.origin
+		ORDER BY bm.name`
+
+	rows, err := a.conn.Query(query, startDate, endDate, staleBefore)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar business managers: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]*domain.BusinessManagerSummary, 0)
+	for rows.Next() {
+		var summary domain.BusinessManagerSummary
+		var lastAdSync, lastSalesSync sql.NullTime
+		if err := rows.Scan(
+			&summary.ID,
+			&summary.ExternalID,
+			&summary.Name,
+			&summary.Origin,
+			&summary.Accounts,
+			&summary.ActiveAccounts,
+			&summary.Spend,
+			&summary.SocialNetworkRevenue,
+			&lastAdSync,
+			&lastSalesSync,
+			&summary.StaleAccounts,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+
+		if lastAdSync.Valid {
+			summary.LastAdSyncAt = &lastAdSync.Time
+		}
+		if lastSalesSync.Valid {
+			summary.LastSalesSyncAt = &lastSalesSync.Time
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return summaries, nil
+}
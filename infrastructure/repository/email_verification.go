@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	emailVerificationTokensTable = "email_verification_tokens"
+)
+
+type EmailVerificationRepository interface {
+	CreateEmailVerificationToken(token *domain.EmailVerificationToken) error
+	// GetEmailVerificationTokenByHash retorna o token ou nil se não existir
+	GetEmailVerificationTokenByHash(tokenHash string) (*domain.EmailVerificationToken, error)
+	// ConfirmEmailVerification consome o token e marca o e-mail do usuário como verificado na mesma transação.
+	// Informa se o token ainda estava disponível, garantindo o uso único mesmo com requisições simultâneas.
+	ConfirmEmailVerification(tokenID, userID int) (bool, error)
+	// InvalidateUserEmailVerificationTokens descarta os links ainda não usados do usuário
+	InvalidateUserEmailVerificationTokens(userID int) error
+}
+
+type emailVerificationRepository struct {
+	conn *postgres.Connection
+}
+
+func NewEmailVerificationRepository(conn *postgres.Connection) EmailVerificationRepository {
+	return &emailVerificationRepository{
+		conn: conn,
+	}
+}
+
+func (r *emailVerificationRepository) CreateEmailVerificationToken(token *domain.EmailVerificationToken) error {
+	query, args, err := squirrel.
+		Insert(emailVerificationTokensTable).
+		Columns("user_id", "token_hash", "expires_at").
+		Values(token.UserID, token.TokenHash, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&token.ID, &token.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao salvar token de verificação de e-mail: %w", err)
+	}
+
+	return nil
+}
+
+func (r *emailVerificationRepository) GetEmailVerificationTokenByHash(tokenHash string) (*domain.EmailVerificationToken, error) {
+	query, args, err := squirrel.
+		Select("id", "user_id", "token_hash", "expires_at", "used_at", "created_at").
+		From(emailVerificationTokensTable).
+		Where(squirrel.Eq{"token_hash": tokenHash}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var token domain.EmailVerificationToken
+	err = r.conn.QueryRow(query, args...).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar token de verificação de e-mail: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *emailVerificationRepository) ConfirmEmailVerification(tokenID, userID int) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	tokenQuery, args, err := squirrel.
+		Update(emailVerificationTokensTable).
+		Set("used_at", now).
+		Where(squirrel.Eq{"id": tokenID, "user_id": userID, "used_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := tx.Exec(tokenQuery, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao marcar token de verificação de e-mail como usado: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar uso do token de verificação de e-mail: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	userQuery, args, err := squirrel.
+		Update(usersTable).
+		Set("email_verified_at", now).
+		Where(squirrel.Eq{"id": userID, "email_verified_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(userQuery, args...); err != nil {
+		return false, fmt.Errorf("erro ao marcar e-mail como verificado: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *emailVerificationRepository) InvalidateUserEmailVerificationTokens(userID int) error {
+	query, args, err := squirrel.
+		Update(emailVerificationTokensTable).
+		Set("used_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "used_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao invalidar tokens de verificação de e-mail: %w", err)
+	}
+
+	return nil
+}
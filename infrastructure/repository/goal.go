@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	accountGoalsTable = "account_goals"
+)
+
+var goalColumns = []string{"id", "account_id", "month", "revenue_target", "max_cpa", "spend_budget", "user_id", "created_at", "updated_at"}
+
+// As operações por conta aceitam o ID interno ou o external_id. month é o primeiro dia do mês.
+type GoalRepository interface {
+	// UpsertGoal cria ou substitui as metas do mês e retorna sql.ErrNoRows se a conta não existir
+	UpsertGoal(goal *domain.AccountGoal, month time.Time) (*domain.AccountGoal, error)
+	// GetGoal retorna nil se a conta não tiver metas no mês
+	GetGoal(accountID string, month time.Time) (*domain.AccountGoal, error)
+	ListGoals(accountID string) ([]*domain.AccountGoal, error)
+	// ListGoalsByMonth retorna as metas do mês indexadas pelo ID interno da conta
+	ListGoalsByMonth(month time.Time) (map[string]*domain.AccountGoal, error)
+	// DeleteGoal retorna sql.ErrNoRows se a conta não tiver metas no mês
+	DeleteGoal(accountID string, month time.Time) error
+}
+
+type goalRepository struct {
+	conn *postgres.Connection
+}
+
+func NewGoalRepository(conn *postgres.Connection) GoalRepository {
+	return &goalRepository{
+		conn: conn,
+	}
+}
+
+func (r *goalRepository) UpsertGoal(goal *domain.AccountGoal, month time.Time) (*domain.AccountGoal, error) {
+	account := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?::date", month.Format(time.DateOnly))).
+		Column(squirrel.Expr("?::numeric", goal.RevenueTarget)).
+		Column(squirrel.Expr("?::numeric", goal.MaxCPA)).
+		Column(squirrel.Expr("?::numeric", goal.SpendBudget)).
+		Column(squirrel.Expr("?::int", goal.UserID)).
+		From("accounts").
+		Where("id = ? OR external_id = ?", goal.AccountID, goal.AccountID).
+		Limit(1)
+
+	query := squirrel.
+		Insert(accountGoalsTable).
+		Columns("account_id", "month", "revenue_target", "max_cpa", "spend_budget", "user_id").
+		Select(account).
+		Suffix(`
+			ON CONFLICT (account_id, month) DO UPDATE SET
+				revenue_target = EXCLUDED.revenue_target,
+				max_cpa = EXCLUDED.max_cpa,
+				spend_budget = EXCLUDED.spend_budget,
+				user_id = EXCLUDED.user_id
+			RETURNING ` + strings.Join(goalColumns, ", ")).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	saved, err := scanGoal(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar metas: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *goalRepository) GetGoal(accountID string, month time.Time) (*domain.AccountGoal, error) {
+	query := squirrel.
+		Select(goalColumns...).
+		From(accountGoalsTable).
+		Where(accountScope(accountID)).
+		Where(squirrel.Eq{"month": month.Format(time.DateOnly)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	goal, err := scanGoal(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar metas: %w", err)
+	}
+
+	return goal, nil
+}
+
+func (r *goalRepository) ListGoals(accountID string) ([]*domain.AccountGoal, error) {
+	return r.listGoals(squirrel.
+		Select(goalColumns...).
+		From(accountGoalsTable).
+		Where(accountScope(accountID)).
+		OrderBy("month DESC"))
+}
+
+func (r *goalRepository) ListGoalsByMonth(month time.Time) (map[string]*domain.AccountGoal, error) {
+	goals, err := r.listGoals(squirrel.
+		Select(goalColumns...).
+		From(accountGoalsTable).
+		Where(squirrel.Eq{"month": month.Format(time.DateOnly)}))
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[string]*domain.AccountGoal, len(goals))
+	for _, goal := range goals {
+		byAccount[goal.AccountID] = goal
+	}
+
+	return byAccount, nil
+}
+
+func (r *goalRepository) listGoals(query squirrel.SelectBuilder) ([]*domain.AccountGoal, error) {
+	sqlQuery, args, err := query.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar metas: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []*domain.AccountGoal{}
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+
+	return goals, rows.Err()
+}
+
+func (r *goalRepository) DeleteGoal(accountID string, month time.Time) error {
+	query := squirrel.
+		Delete(accountGoalsTable).
+		Where(accountScope(accountID)).
+		Where(squirrel.Eq{"month": month.Format(time.DateOnly)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao remover metas: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao remover metas: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanGoal(row rowScanner) (*domain.AccountGoal, error) {
+	var goal domain.AccountGoal
+	var month time.Time
+	var revenueTarget, maxCPA, spendBudget sql.NullFloat64
+	var userID sql.NullInt64
+
+	if err := row.Scan(
+		&goal.ID,
+		&goal.AccountID,
+		&month,
+		&revenueTarget,
+		&maxCPA,
+		&spendBudget,
+		&userID,
+		&goal.CreatedAt,
+		&goal.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	goal.Period = month.Format(domain.GoalPeriodFormat)
+	goal.RevenueTarget = nullFloatPtr(revenueTarget)
+	goal.MaxCPA = nullFloatPtr(maxCPA)
+	goal.SpendBudget = nullFloatPtr(spendBudget)
+	if userID.Valid {
+		id := int(userID.Int64)
+		goal.UserID = &id
+	}
+
+	return &goal, nil
+}
+
+func nullFloatPtr(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
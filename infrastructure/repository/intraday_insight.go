@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const intradayInsightsTable = "intraday_insights"
+
+// IntradayInsightRepository guarda as métricas parciais do dia em andamento de cada conta, separadas dos
+// insights diários, que só recebem dias encerrados. O UpdatedAt das entradas é a última atualização da origem.
+type IntradayInsightRepository interface {
+	// SaveAdInsight grava as métricas do Meta do dia, substituindo as anteriores
+	SaveAdInsight(insight *domain.AdInsightEntry) error
+	// SaveSalesInsight grava as métricas do SSOtica do dia, substituindo as anteriores
+	SaveSalesInsight(insight *domain.SalesInsightEntry) error
+	// GetAdInsight retorna as métricas do Meta do dia, ou nil se não houver
+	GetAdInsight(accountID string, date time.Time) (*domain.AdInsightEntry, error)
+	// GetSalesInsight retorna as métricas do SSOtica do dia, ou nil se não houver
+	GetSalesInsight(accountID string, date time.Time) (*domain.SalesInsightEntry, error)
+	// DeleteBefore remove os dias anteriores a date, já cobertos pelos insights diários
+	DeleteBefore(date time.Time) (int64, error)
+}
+
+type intradayInsightRepository struct {
+	conn *postgres.Connection
+}
+
+func NewIntradayInsightRepository(conn *postgres.Connection) IntradayInsightRepository {
+	return &intradayInsightRepository{
+		conn: conn,
+	}
+}
+
+func (r *intradayInsightRepository) SaveAdInsight(insight *domain.AdInsightEntry) error {
+	adMetricsJSON, err := json.Marshal(insight.AdMetrics)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar AdMetrics para JSON: %w", err)
+	}
+
+	query, args, err := squirrel.
+		Insert(intradayInsightsTable).
+		Columns("account_id", "date", "external_id", "ad_metrics", "ad_synced_at").
+		Values(insight.AccountID, insight.Date.Format(time.DateOnly), insight.ExternalID, adMetricsJSON, insight.UpdatedAt).
+		Suffix(`ON CONFLICT (account_id, date) DO UPDATE SET
+			external_id = EXCLUDED.external_id,
+			ad_metrics = EXCLUDED.ad_metrics,
+			ad_synced_at = EXCLUDED.ad_synced_at`).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao gravar insights intradiários de anúncios: %w", err)
+	}
+
+	return nil
+}
+
+func (r *intradayInsightRepository) SaveSalesInsight(insight *domain.SalesInsightEntry) error {
+	salesMetricsJSON, err := json.Marshal(insight.SalesMetrics)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar SalesMetrics para JSON: %w", err)
+	}
+
+	query, args, err := squirrel.
+		Insert(intradayInsightsTable).
+		Columns("account_id", "date", "sales_metrics", "sales_synced_at").
+		Values(insight.AccountID, insight.Date.Format(time.DateOnly), salesMetricsJSON, insight.UpdatedAt).
+		Suffix(`ON CONFLICT (account_id, date) DO UPDATE SET
+			sales_metrics = EXCLUDED.sales_metrics,
+			sales_synced_at = EXCLUDED.sales_synced_at`).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao gravar insights intradiários de vendas: %w", err)
+	}
+
+	return nil
+}
+
+func (r *intradayInsightRepository) GetAdInsight(accountID string, date time.Time) (*domain.AdInsightEntry, error) {
+	query, args, err := squirrel.
+		Select("COALESCE(external_id, ''), ad_metrics, ad_synced_at").
+		From(intradayInsightsTable).
+		Where(squirrel.Eq{"account_id": accountID, "date": date.Format(time.DateOnly)}).
+		Where("ad_metrics IS NOT NULL").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	insight := &domain.AdInsightEntry{AccountID: accountID, Date: date}
+	var adMetricsJSON []byte
+	var syncedAt sql.NullTime
+	if err := r.conn.QueryRow(query, args...).Scan(&insight.ExternalID, &adMetricsJSON, &syncedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar insights intradiários de anúncios: %w", err)
+	}
+
+	if err := json.Unmarshal(adMetricsJSON, &insight.AdMetrics); err != nil {
+		return nil, fmt.Errorf("erro ao deserializar JSON de ad_metrics: %w", err)
+	}
+	insight.UpdatedAt = syncedAt.Time
+
+	return insight, nil
+}
+
+func (r *intradayInsightRepository) GetSalesInsight(accountID string, date time.Time) (*domain.SalesInsightEntry, error) {
+	query, args, err := squirrel.
+		Select("sales_metrics, sales_synced_at").
+		From(intradayInsightsTable).
+		Where(squirrel.Eq{"account_id": accountID, "date": date.Format(time.DateOnly)}).
+		Where("sales_metrics IS NOT NULL").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	insight := &domain.SalesInsightEntry{AccountID: accountID, Date: date}
+	var salesMetricsJSON []byte
+	var syncedAt sql.NullTime
+	if err := r.conn.QueryRow(query, args...).Scan(&salesMetricsJSON, &syncedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar insights intradiários de vendas: %w", err)
+	}
+
+	if err := json.Unmarshal(salesMetricsJSON, &insight.SalesMetrics); err != nil {
+		return nil, fmt.Errorf("erro ao deserializar JSON de sales_metrics: %w", err)
+	}
+	insight.UpdatedAt = syncedAt.Time
+
+	return insight, nil
+}
+
+func (r *intradayInsightRepository) DeleteBefore(date time.Time) (int64, error) {
+	query, args, err := squirrel.
+		Delete(intradayInsightsTable).
+		Where(squirrel.Lt{"date": date.Format(time.DateOnly)}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover insights intradiários antigos: %w", err)
+	}
+
+	return result.RowsAffected()
+}
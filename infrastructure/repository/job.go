@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	jobsTable        = "jobs"
+	jobAccountsTable = "job_accounts"
+)
+
+// jobColumns inclui a contagem das contas por resultado; exige o LEFT JOIN de job_accounts ja e o GROUP BY j.id
+var jobColumns = []string{
+	"j.id", "j.type", "j.trigger", "j.params", "j.status", "j.started_at", "j.finished_at", "COALESCE(j.error, '')", "j.stats",
+	"COUNT(ja.account_id)",
+	"COUNT(ja.account_id) FILTER (WHERE ja.status = 'succeeded')",
+	"COUNT(ja.account_id) FILTER (WHERE ja.status = 'failed')",
+	"COUNT(ja.account_id) FILTER (WHERE ja.status = 'interrupted')",
+	"COUNT(ja.account_id) FILTER (WHERE ja.status = 'skipped')",
+}
+
+type JobRepository interface {
+	// CreateJob registra o início da execução e preenche o ID
+	CreateJob(job *domain.Job) error
+	// FinishJob grava o status final, o fim, o erro e as estatísticas da execução
+	FinishJob(job *domain.Job) error
+	// InterruptRunningJobs marca como interrompidas as execuções do tipo que ficaram em andamento quando a
+	// aplicação foi encerrada e retorna quantas foram marcadas
+	InterruptRunningJobs(jobType string, at time.Time) (int, error)
+	// SaveJobAccounts grava o resultado de cada conta na execução
+	SaveJobAccounts(jobID int64, accounts []*domain.JobAccount) error
+	// ListJobs retorna uma página das execuções, da mais recente para a mais antiga, e o total que atende aos filtros
+	ListJobs(filters domain.JobFilters) ([]*domain.Job, int, error)
+	// GetJob retorna a execução, ou nil se ela não existir
+	GetJob(id int64) (*domain.Job, error)
+	// ListJobAccounts retorna o resultado de cada conta na execução, com as falhas primeiro
+	ListJobAccounts(jobID int64) ([]*domain.JobAccount, error)
+}
+
+type jobRepository struct {
+	conn *postgres.Connection
+}
+
+func NewJobRepository(conn *postgres.Connection) JobRepository {
+	return &jobRepository{
+		conn: conn,
+	}
+}
+
+func (r *jobRepository) CreateJob(job *domain.Job) error {
+	var params []byte
+	if len(job.Params) > 0 {
+		var err error
+		if params, err = json.Marshal(job.Params); err != nil {
+			return fmt.Errorf("erro ao serializar parâmetros da execução: %w", err)
+		}
+	}
+
+	query, args, err := squirrel.
+		Insert(jobsTable).
+		Columns("type", "trigger", "params", "status", "started_at").
+		Values(job.Type, job.Trigger, params, job.Status, job.StartedAt).
+		Suffix("RETURNING id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&job.ID); err != nil {
+		return fmt.Errorf("erro ao registrar execução do agendador: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jobRepository) FinishJob(job *domain.Job) error {
+	var stats []byte
+	if len(job.Stats) > 0 {
+		var err error
+		if stats, err = json.Marshal(job.Stats); err != nil {
+			return fmt.Errorf("erro ao serializar estatísticas da execução: %w", err)
+		}
+	}
+
+	var jobError any
+	if job.Error != "" {
+		jobError = job.Error
+	}
+
+	query, args, err := squirrel.
+		Update(jobsTable).
+		Set("status", job.Status).
+		Set("finished_at", job.FinishedAt).
+		Set("error", jobError).
+		Set("stats", stats).
+		Where(squirrel.Eq{"id": job.ID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao finalizar execução do agendador: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jobRepository) InterruptRunningJobs(jobType string, at time.Time) (int, error) {
+	query, args, err := squirrel.
+		Update(jobsTable).
+		Set("status", domain.JobStatusInterrupted).
+		Set("finished_at", at).
+		Set("error", "aplicação encerrada durante a execução").
+		Where(squirrel.Eq{"type": jobType, "status": domain.JobStatusRunning}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao marcar execuções interrompidas: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar execuções interrompidas: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+func (r *jobRepository) SaveJobAccounts(jobID int64, accounts []*domain.JobAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	insert := squirrel.
+		Insert(jobAccountsTable).
+		Columns("job_id", "account_id", "status", "items", "failed_items", "reason")
+	for _, account := range accounts {
+		var reason any
+		if account.Reason != "" {
+			reason = account.Reason
+		}
+		insert = insert.Values(jobID, account.AccountID, account.Status, account.Items, account.FailedItems, reason)
+	}
+
+	query, args, err := insert.
+		Suffix("ON CONFLICT (job_id, account_id) DO UPDATE SET status = EXCLUDED.status, items = EXCLUDED.items, " +
+			"failed_items = EXCLUDED.failed_items, reason = EXCLUDED.reason").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao gravar resultado das contas na execução: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jobRepository) ListJobs(filters domain.JobFilters) ([]*domain.Job, int, error) {
+	where := squirrel.And{}
+	if filters.Type != "" {
+		where = append(where, squirrel.Eq{"j.type": filters.Type})
+	}
+	if filters.Status != "" {
+		where = append(where, squirrel.Eq{"j.status": filters.Status})
+	}
+	if filters.From != nil {
+		where = append(where, squirrel.GtOrEq{"j.started_at": *filters.From})
+	}
+	if filters.To != nil {
+		where = append(where, squirrel.Lt{"j.started_at": *filters.To})
+	}
+
+	countSQL, countArgs, err := squirrel.
+		Select("COUNT(*)").
+		From(jobsTable + " j").
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var total int
+	if err := r.conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar execuções: %w", err)
+	}
+
+	jobsSQL, jobsArgs, err := squirrel.
+		Select(jobColumns...).
+		From(jobsTable+" j").
+		LeftJoin(jobAccountsTable+" ja ON ja.job_id = j.id").
+		Where(where).
+		GroupBy("j.id").
+		OrderBy("j.started_at DESC", "j.id DESC").
+		Limit(uint64(filters.Limit)).
+		Offset(uint64(filters.Offset)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(jobsSQL, jobsArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao consultar execuções: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := make([]*domain.Job, 0, filters.Limit)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return jobs, total, nil
+}
+
+func (r *jobRepository) GetJob(id int64) (*domain.Job, error) {
+	query, args, err := squirrel.
+		Select(jobColumns...).
+		From(jobsTable + " j").
+		LeftJoin(jobAccountsTable + " ja ON ja.job_id = j.id").
+		Where(squirrel.Eq{"j.id": id}).
+		GroupBy("j.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	job, err := scanJob(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *jobRepository) ListJobAccounts(jobID int64) ([]*domain.JobAccount, error) {
+	query, args, err := squirrel.
+		Select("ja.account_id", "COALESCE(a.name, '')", "ja.status", "ja.items", "ja.failed_items", "COALESCE(ja.reason, '')").
+		From(jobAccountsTable+" ja").
+		LeftJoin(accountsTable+" ON a.id = ja.account_id").
+		Where(squirrel.Eq{"ja.job_id": jobID}).
+		OrderBy("CASE ja.status WHEN 'failed' THEN 0 WHEN 'interrupted' THEN 1 ELSE 2 END", "a.name", "ja.account_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar resultado das contas na execução: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]*domain.JobAccount, 0)
+	for rows.Next() {
+		var account domain.JobAccount
+		if err := rows.Scan(
+			&account.AccountID,
+			&account.AccountName,
+			&account.Status,
+			&account.Items,
+			&account.FailedItems,
+			&account.Reason,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler resultado da conta na execução: %w", err)
+		}
+		accounts = append(accounts, &account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// scanJob lê uma linha com as colunas de jobColumns
+func scanJob(row squirrel.RowScanner) (*domain.Job, error) {
+	job := &domain.Job{Accounts: &domain.JobAccountSummary{}}
+	var params, stats []byte
+	var finishedAt sql.NullTime
+	err := row.Scan(
+		&job.ID,
+		&job.Type,
+		&job.Trigger,
+		&params,
+		&job.Status,
+		&job.StartedAt,
+		&finishedAt,
+		&job.Error,
+		&stats,
+		&job.Accounts.Total,
+		&job.Accounts.Succeeded,
+		&job.Accounts.Failed,
+		&job.Accounts.Interrupted,
+		&job.Accounts.Skipped,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler execução: %w", err)
+	}
+
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &job.Params); err != nil {
+			return nil, fmt.Errorf("erro ao ler parâmetros da execução: %w", err)
+		}
+	}
+	if len(stats) > 0 {
+		if err := json.Unmarshal(stats, &job.Stats); err != nil {
+			return nil, fmt.Errorf("erro ao ler estatísticas da execução: %w", err)
+		}
+	}
+
+	return job, nil
+}
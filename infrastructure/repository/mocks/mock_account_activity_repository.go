@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/account_activity.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/account_activity.go -destination=infrastructure/repository/mocks/mock_account_activity_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountActivityRepository is a mock of AccountActivityRepository interface.
+type MockAccountActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountActivityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountActivityRepositoryMockRecorder is the mock recorder for MockAccountActivityRepository.
+type MockAccountActivityRepositoryMockRecorder struct {
+	mock *MockAccountActivityRepository
+}
+
+// NewMockAccountActivityRepository creates a new mock instance.
+func NewMockAccountActivityRepository(ctrl *gomock.Controller) *MockAccountActivityRepository {
+	mock := &MockAccountActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountActivityRepository) EXPECT() *MockAccountActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateActivity mocks base method.
+func (m *MockAccountActivityRepository) CreateActivity(activity *domain.AccountActivity) (*domain.AccountActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActivity", activity)
+	ret0, _ := ret[0].(*domain.AccountActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActivity indicates an expected call of CreateActivity.
+func (mr *MockAccountActivityRepositoryMockRecorder) CreateActivity(activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockAccountActivityRepository)(nil).CreateActivity), activity)
+}
+
+// ListActivities mocks base method.
+func (m *MockAccountActivityRepository) ListActivities(accountID string, filters domain.AccountActivityFilters) ([]*domain.AccountActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivities", accountID, filters)
+	ret0, _ := ret[0].([]*domain.AccountActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivities indicates an expected call of ListActivities.
+func (mr *MockAccountActivityRepositoryMockRecorder) ListActivities(accountID, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivities", reflect.TypeOf((*MockAccountActivityRepository)(nil).ListActivities), accountID, filters)
+}
+
+// ListActivityScores mocks base method.
+func (m *MockAccountActivityRepository) ListActivityScores(since time.Time) ([]*domain.AccountActivityScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivityScores", since)
+	ret0, _ := ret[0].([]*domain.AccountActivityScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivityScores indicates an expected call of ListActivityScores.
+func (mr *MockAccountActivityRepositoryMockRecorder) ListActivityScores(since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivityScores", reflect.TypeOf((*MockAccountActivityRepository)(nil).ListActivityScores), since)
+}
+
+// RecordView mocks base method.
+func (m *MockAccountActivityRepository) RecordView(accountID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", accountID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockAccountActivityRepositoryMockRecorder) RecordView(accountID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockAccountActivityRepository)(nil).RecordView), accountID, at)
+}
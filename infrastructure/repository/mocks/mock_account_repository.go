@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -70,6 +71,21 @@ func (mr *MockAccountRepositoryMockRecorder) GetAccountByID(accountID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountRepository)(nil).GetAccountByID), accountID)
 }
 
+// ListAccountMerges mocks base method.
+func (m *MockAccountRepository) ListAccountMerges(accountID string) ([]*domain.AccountMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountMerges", accountID)
+	ret0, _ := ret[0].([]*domain.AccountMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountMerges indicates an expected call of ListAccountMerges.
+func (mr *MockAccountRepositoryMockRecorder) ListAccountMerges(accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountMerges", reflect.TypeOf((*MockAccountRepository)(nil).ListAccountMerges), accountID)
+}
+
 // ListAccounts mocks base method.
 func (m *MockAccountRepository) ListAccounts(availableStatus []domain.AdAccountStatus) ([]*domain.AdAccount, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +116,66 @@ func (mr *MockAccountRepositoryMockRecorder) ListAccountsMap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsMap", reflect.TypeOf((*MockAccountRepository)(nil).ListAccountsMap))
 }
 
+// ListAccountsPage mocks base method.
+func (m *MockAccountRepository) ListAccountsPage(filters *domain.AccountListFilters) ([]*domain.AdAccount, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsPage", filters)
+	ret0, _ := ret[0].([]*domain.AdAccount)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAccountsPage indicates an expected call of ListAccountsPage.
+func (mr *MockAccountRepositoryMockRecorder) ListAccountsPage(filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsPage", reflect.TypeOf((*MockAccountRepository)(nil).ListAccountsPage), filters)
+}
+
+// ListBusinessManagerSummaries mocks base method.
+func (m *MockAccountRepository) ListBusinessManagerSummaries(startDate, endDate, staleBefore time.Time) ([]*domain.BusinessManagerSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBusinessManagerSummaries", startDate, endDate, staleBefore)
+	ret0, _ := ret[0].([]*domain.BusinessManagerSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBusinessManagerSummaries indicates an expected call of ListBusinessManagerSummaries.
+func (mr *MockAccountRepositoryMockRecorder) ListBusinessManagerSummaries(startDate, endDate, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBusinessManagerSummaries", reflect.TypeOf((*MockAccountRepository)(nil).ListBusinessManagerSummaries), startDate, endDate, staleBefore)
+}
+
+// ListMapInsights mocks base method.
+func (m *MockAccountRepository) ListMapInsights(startDate, endDate time.Time) ([]*domain.MapCityInsight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMapInsights", startDate, endDate)
+	ret0, _ := ret[0].([]*domain.MapCityInsight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMapInsights indicates an expected call of ListMapInsights.
+func (mr *MockAccountRepositoryMockRecorder) ListMapInsights(startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMapInsights", reflect.TypeOf((*MockAccountRepository)(nil).ListMapInsights), startDate, endDate)
+}
+
+// MergeAccounts mocks base method.
+func (m *MockAccountRepository) MergeAccounts(merge *domain.AccountMerge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeAccounts", merge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeAccounts indicates an expected call of MergeAccounts.
+func (mr *MockAccountRepositoryMockRecorder) MergeAccounts(merge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeAccounts", reflect.TypeOf((*MockAccountRepository)(nil).MergeAccounts), merge)
+}
+
 // SaveOrUpdate mocks base method.
 func (m *MockAccountRepository) SaveOrUpdate(account []*domain.AdAccount, businessManagerIDs map[string]string) error {
 	m.ctrl.T.Helper()
@@ -129,6 +205,21 @@ func (mr *MockAccountRepositoryMockRecorder) SaveOrUpdateBusinessManager(bms any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrUpdateBusinessManager", reflect.TypeOf((*MockAccountRepository)(nil).SaveOrUpdateBusinessManager), bms)
 }
 
+// SearchAccounts mocks base method.
+func (m *MockAccountRepository) SearchAccounts(term string, scope domain.SearchScope) ([]*domain.AdAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAccounts", term, scope)
+	ret0, _ := ret[0].([]*domain.AdAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockAccountRepositoryMockRecorder) SearchAccounts(term, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockAccountRepository)(nil).SearchAccounts), term, scope)
+}
+
 // UpdateAccount mocks base method.
 func (m *MockAccountRepository) UpdateAccount(account *domain.UpdateAdAccountRequest) error {
 	m.ctrl.T.Helper()
@@ -142,3 +233,45 @@ func (mr *MockAccountRepositoryMockRecorder) UpdateAccount(account any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccount), account)
 }
+
+// UpdateAccountCurrencies mocks base method.
+func (m *MockAccountRepository) UpdateAccountCurrencies(origin string, currencies map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountCurrencies", origin, currencies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountCurrencies indicates an expected call of UpdateAccountCurrencies.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccountCurrencies(origin, currencies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountCurrencies", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccountCurrencies), origin, currencies)
+}
+
+// UpdateAccountLocation mocks base method.
+func (m *MockAccountRepository) UpdateAccountLocation(accountID string, location *domain.AccountLocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountLocation", accountID, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountLocation indicates an expected call of UpdateAccountLocation.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccountLocation(accountID, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountLocation", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccountLocation), accountID, location)
+}
+
+// UpdateAccountVIP mocks base method.
+func (m *MockAccountRepository) UpdateAccountVIP(accountID string, vip bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountVIP", accountID, vip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountVIP indicates an expected call of UpdateAccountVIP.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccountVIP(accountID, vip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountVIP", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccountVIP), accountID, vip)
+}
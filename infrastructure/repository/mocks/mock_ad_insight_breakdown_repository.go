@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/ad_insight_breakdown.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/ad_insight_breakdown.go -destination=infrastructure/repository/mocks/mock_ad_insight_breakdown_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdInsightBreakdownRepository is a mock of AdInsightBreakdownRepository interface.
+type MockAdInsightBreakdownRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdInsightBreakdownRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAdInsightBreakdownRepositoryMockRecorder is the mock recorder for MockAdInsightBreakdownRepository.
+type MockAdInsightBreakdownRepositoryMockRecorder struct {
+	mock *MockAdInsightBreakdownRepository
+}
+
+// NewMockAdInsightBreakdownRepository creates a new mock instance.
+func NewMockAdInsightBreakdownRepository(ctrl *gomock.Controller) *MockAdInsightBreakdownRepository {
+	mock := &MockAdInsightBreakdownRepository{ctrl: ctrl}
+	mock.recorder = &MockAdInsightBreakdownRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdInsightBreakdownRepository) EXPECT() *MockAdInsightBreakdownRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAdInsightBreakdownRepository) Get(accountID string, startDate, endDate time.Time) (*domain.AdInsightBreakdownEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", accountID, startDate, endDate)
+	ret0, _ := ret[0].(*domain.AdInsightBreakdownEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAdInsightBreakdownRepositoryMockRecorder) Get(accountID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdInsightBreakdownRepository)(nil).Get), accountID, startDate, endDate)
+}
+
+// SaveOrUpdate mocks base method.
+func (m *MockAdInsightBreakdownRepository) SaveOrUpdate(entry *domain.AdInsightBreakdownEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrUpdate", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrUpdate indicates an expected call of SaveOrUpdate.
+func (mr *MockAdInsightBreakdownRepositoryMockRecorder) SaveOrUpdate(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrUpdate", reflect.TypeOf((*MockAdInsightBreakdownRepository)(nil).SaveOrUpdate), entry)
+}
@@ -101,6 +101,21 @@ func (mr *MockAdInsightRepositoryMockRecorder) GetByExternalIDAndDate(externalID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalIDAndDate", reflect.TypeOf((*MockAdInsightRepository)(nil).GetByExternalIDAndDate), externalID, date)
 }
 
+// GetLastSyncedAt mocks base method.
+func (m *MockAdInsightRepository) GetLastSyncedAt(accountID string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncedAt", accountID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncedAt indicates an expected call of GetLastSyncedAt.
+func (mr *MockAdInsightRepositoryMockRecorder) GetLastSyncedAt(accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncedAt", reflect.TypeOf((*MockAdInsightRepository)(nil).GetLastSyncedAt), accountID)
+}
+
 // SaveOrUpdate mocks base method.
 func (m *MockAdInsightRepository) SaveOrUpdate(insight *domain.AdInsightEntry) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/annotation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/annotation.go -destination=infrastructure/repository/mocks/mock_annotation_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnnotationRepository is a mock of AnnotationRepository interface.
+type MockAnnotationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnnotationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAnnotationRepositoryMockRecorder is the mock recorder for MockAnnotationRepository.
+type MockAnnotationRepositoryMockRecorder struct {
+	mock *MockAnnotationRepository
+}
+
+// NewMockAnnotationRepository creates a new mock instance.
+func NewMockAnnotationRepository(ctrl *gomock.Controller) *MockAnnotationRepository {
+	mock := &MockAnnotationRepository{ctrl: ctrl}
+	mock.recorder = &MockAnnotationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnnotationRepository) EXPECT() *MockAnnotationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAnnotation mocks base method.
+func (m *MockAnnotationRepository) CreateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnnotation", annotation)
+	ret0, _ := ret[0].(*domain.Annotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnnotation indicates an expected call of CreateAnnotation.
+func (mr *MockAnnotationRepositoryMockRecorder) CreateAnnotation(annotation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnnotation", reflect.TypeOf((*MockAnnotationRepository)(nil).CreateAnnotation), annotation)
+}
+
+// DeleteAnnotation mocks base method.
+func (m *MockAnnotationRepository) DeleteAnnotation(accountID string, annotationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnnotation", accountID, annotationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAnnotation indicates an expected call of DeleteAnnotation.
+func (mr *MockAnnotationRepositoryMockRecorder) DeleteAnnotation(accountID, annotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnnotation", reflect.TypeOf((*MockAnnotationRepository)(nil).DeleteAnnotation), accountID, annotationID)
+}
+
+// ListAnnotations mocks base method.
+func (m *MockAnnotationRepository) ListAnnotations(accountID string, startDate, endDate time.Time) ([]*domain.Annotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAnnotations", accountID, startDate, endDate)
+	ret0, _ := ret[0].([]*domain.Annotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAnnotations indicates an expected call of ListAnnotations.
+func (mr *MockAnnotationRepositoryMockRecorder) ListAnnotations(accountID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnnotations", reflect.TypeOf((*MockAnnotationRepository)(nil).ListAnnotations), accountID, startDate, endDate)
+}
+
+// UpdateAnnotation mocks base method.
+func (m *MockAnnotationRepository) UpdateAnnotation(annotation *domain.Annotation) (*domain.Annotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotation", annotation)
+	ret0, _ := ret[0].(*domain.Annotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnnotation indicates an expected call of UpdateAnnotation.
+func (mr *MockAnnotationRepositoryMockRecorder) UpdateAnnotation(annotation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotation", reflect.TypeOf((*MockAnnotationRepository)(nil).UpdateAnnotation), annotation)
+}
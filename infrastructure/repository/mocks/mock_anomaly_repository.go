@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/anomaly.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/anomaly.go -destination=infrastructure/repository/mocks/mock_anomaly_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnomalyRepository is a mock of AnomalyRepository interface.
+type MockAnomalyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnomalyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAnomalyRepositoryMockRecorder is the mock recorder for MockAnomalyRepository.
+type MockAnomalyRepositoryMockRecorder struct {
+	mock *MockAnomalyRepository
+}
+
+// NewMockAnomalyRepository creates a new mock instance.
+func NewMockAnomalyRepository(ctrl *gomock.Controller) *MockAnomalyRepository {
+	mock := &MockAnomalyRepository{ctrl: ctrl}
+	mock.recorder = &MockAnomalyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnomalyRepository) EXPECT() *MockAnomalyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListAnomalies mocks base method.
+func (m *MockAnomalyRepository) ListAnomalies(accountID string, startDate, endDate time.Time) ([]*domain.InsightAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAnomalies", accountID, startDate, endDate)
+	ret0, _ := ret[0].([]*domain.InsightAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAnomalies indicates an expected call of ListAnomalies.
+func (mr *MockAnomalyRepositoryMockRecorder) ListAnomalies(accountID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnomalies", reflect.TypeOf((*MockAnomalyRepository)(nil).ListAnomalies), accountID, startDate, endDate)
+}
+
+// ReplaceAnomalies mocks base method.
+func (m *MockAnomalyRepository) ReplaceAnomalies(accountID string, startDate, endDate time.Time, anomalies []*domain.InsightAnomaly) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAnomalies", accountID, startDate, endDate, anomalies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAnomalies indicates an expected call of ReplaceAnomalies.
+func (mr *MockAnomalyRepositoryMockRecorder) ReplaceAnomalies(accountID, startDate, endDate, anomalies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAnomalies", reflect.TypeOf((*MockAnomalyRepository)(nil).ReplaceAnomalies), accountID, startDate, endDate, anomalies)
+}
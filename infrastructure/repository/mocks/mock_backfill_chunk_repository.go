@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/backfill_chunk.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/backfill_chunk.go -destination=infrastructure/repository/mocks/mock_backfill_chunk_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackfillChunkRepository is a mock of BackfillChunkRepository interface.
+type MockBackfillChunkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackfillChunkRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBackfillChunkRepositoryMockRecorder is the mock recorder for MockBackfillChunkRepository.
+type MockBackfillChunkRepositoryMockRecorder struct {
+	mock *MockBackfillChunkRepository
+}
+
+// NewMockBackfillChunkRepository creates a new mock instance.
+func NewMockBackfillChunkRepository(ctrl *gomock.Controller) *MockBackfillChunkRepository {
+	mock := &MockBackfillChunkRepository{ctrl: ctrl}
+	mock.recorder = &MockBackfillChunkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackfillChunkRepository) EXPECT() *MockBackfillChunkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListChunks mocks base method.
+func (m *MockBackfillChunkRepository) ListChunks(source string, startDate, endDate time.Time) ([]*domain.BackfillChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChunks", source, startDate, endDate)
+	ret0, _ := ret[0].([]*domain.BackfillChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChunks indicates an expected call of ListChunks.
+func (mr *MockBackfillChunkRepositoryMockRecorder) ListChunks(source, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChunks", reflect.TypeOf((*MockBackfillChunkRepository)(nil).ListChunks), source, startDate, endDate)
+}
+
+// SaveChunk mocks base method.
+func (m *MockBackfillChunkRepository) SaveChunk(chunk *domain.BackfillChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChunk", chunk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChunk indicates an expected call of SaveChunk.
+func (mr *MockBackfillChunkRepositoryMockRecorder) SaveChunk(chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChunk", reflect.TypeOf((*MockBackfillChunkRepository)(nil).SaveChunk), chunk)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/email_verification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/email_verification.go -destination=infrastructure/repository/mocks/mock_email_verification_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailVerificationRepository is a mock of EmailVerificationRepository interface.
+type MockEmailVerificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationRepositoryMockRecorder is the mock recorder for MockEmailVerificationRepository.
+type MockEmailVerificationRepositoryMockRecorder struct {
+	mock *MockEmailVerificationRepository
+}
+
+// NewMockEmailVerificationRepository creates a new mock instance.
+func NewMockEmailVerificationRepository(ctrl *gomock.Controller) *MockEmailVerificationRepository {
+	mock := &MockEmailVerificationRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationRepository) EXPECT() *MockEmailVerificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmailVerification mocks base method.
+func (m *MockEmailVerificationRepository) ConfirmEmailVerification(tokenID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailVerification", tokenID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmailVerification indicates an expected call of ConfirmEmailVerification.
+func (mr *MockEmailVerificationRepositoryMockRecorder) ConfirmEmailVerification(tokenID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailVerification", reflect.TypeOf((*MockEmailVerificationRepository)(nil).ConfirmEmailVerification), tokenID, userID)
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MockEmailVerificationRepository) CreateEmailVerificationToken(token *domain.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockEmailVerificationRepositoryMockRecorder) CreateEmailVerificationToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockEmailVerificationRepository)(nil).CreateEmailVerificationToken), token)
+}
+
+// GetEmailVerificationTokenByHash mocks base method.
+func (m *MockEmailVerificationRepository) GetEmailVerificationTokenByHash(tokenHash string) (*domain.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationTokenByHash", tokenHash)
+	ret0, _ := ret[0].(*domain.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationTokenByHash indicates an expected call of GetEmailVerificationTokenByHash.
+func (mr *MockEmailVerificationRepositoryMockRecorder) GetEmailVerificationTokenByHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationTokenByHash", reflect.TypeOf((*MockEmailVerificationRepository)(nil).GetEmailVerificationTokenByHash), tokenHash)
+}
+
+// InvalidateUserEmailVerificationTokens mocks base method.
+func (m *MockEmailVerificationRepository) InvalidateUserEmailVerificationTokens(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserEmailVerificationTokens", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserEmailVerificationTokens indicates an expected call of InvalidateUserEmailVerificationTokens.
+func (mr *MockEmailVerificationRepositoryMockRecorder) InvalidateUserEmailVerificationTokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserEmailVerificationTokens", reflect.TypeOf((*MockEmailVerificationRepository)(nil).InvalidateUserEmailVerificationTokens), userID)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/goal.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/goal.go -destination=infrastructure/repository/mocks/mock_goal_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGoalRepository is a mock of GoalRepository interface.
+type MockGoalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGoalRepositoryMockRecorder is the mock recorder for MockGoalRepository.
+type MockGoalRepositoryMockRecorder struct {
+	mock *MockGoalRepository
+}
+
+// NewMockGoalRepository creates a new mock instance.
+func NewMockGoalRepository(ctrl *gomock.Controller) *MockGoalRepository {
+	mock := &MockGoalRepository{ctrl: ctrl}
+	mock.recorder = &MockGoalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoalRepository) EXPECT() *MockGoalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteGoal mocks base method.
+func (m *MockGoalRepository) DeleteGoal(accountID string, month time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", accountID, month)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockGoalRepositoryMockRecorder) DeleteGoal(accountID, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalRepository)(nil).DeleteGoal), accountID, month)
+}
+
+// GetGoal mocks base method.
+func (m *MockGoalRepository) GetGoal(accountID string, month time.Time) (*domain.AccountGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoal", accountID, month)
+	ret0, _ := ret[0].(*domain.AccountGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoal indicates an expected call of GetGoal.
+func (mr *MockGoalRepositoryMockRecorder) GetGoal(accountID, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoal", reflect.TypeOf((*MockGoalRepository)(nil).GetGoal), accountID, month)
+}
+
+// ListGoals mocks base method.
+func (m *MockGoalRepository) ListGoals(accountID string) ([]*domain.AccountGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoals", accountID)
+	ret0, _ := ret[0].([]*domain.AccountGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoals indicates an expected call of ListGoals.
+func (mr *MockGoalRepositoryMockRecorder) ListGoals(accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoals", reflect.TypeOf((*MockGoalRepository)(nil).ListGoals), accountID)
+}
+
+// ListGoalsByMonth mocks base method.
+func (m *MockGoalRepository) ListGoalsByMonth(month time.Time) (map[string]*domain.AccountGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoalsByMonth", month)
+	ret0, _ := ret[0].(map[string]*domain.AccountGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoalsByMonth indicates an expected call of ListGoalsByMonth.
+func (mr *MockGoalRepositoryMockRecorder) ListGoalsByMonth(month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoalsByMonth", reflect.TypeOf((*MockGoalRepository)(nil).ListGoalsByMonth), month)
+}
+
+// UpsertGoal mocks base method.
+func (m *MockGoalRepository) UpsertGoal(goal *domain.AccountGoal, month time.Time) (*domain.AccountGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGoal", goal, month)
+	ret0, _ := ret[0].(*domain.AccountGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertGoal indicates an expected call of UpsertGoal.
+func (mr *MockGoalRepositoryMockRecorder) UpsertGoal(goal, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGoal", reflect.TypeOf((*MockGoalRepository)(nil).UpsertGoal), goal, month)
+}
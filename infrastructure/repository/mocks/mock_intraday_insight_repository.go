@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/intraday_insight.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/intraday_insight.go -destination=infrastructure/repository/mocks/mock_intraday_insight_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIntradayInsightRepository is a mock of IntradayInsightRepository interface.
+type MockIntradayInsightRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntradayInsightRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIntradayInsightRepositoryMockRecorder is the mock recorder for MockIntradayInsightRepository.
+type MockIntradayInsightRepositoryMockRecorder struct {
+	mock *MockIntradayInsightRepository
+}
+
+// NewMockIntradayInsightRepository creates a new mock instance.
+func NewMockIntradayInsightRepository(ctrl *gomock.Controller) *MockIntradayInsightRepository {
+	mock := &MockIntradayInsightRepository{ctrl: ctrl}
+	mock.recorder = &MockIntradayInsightRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntradayInsightRepository) EXPECT() *MockIntradayInsightRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockIntradayInsightRepository) DeleteBefore(date time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", date)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockIntradayInsightRepositoryMockRecorder) DeleteBefore(date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockIntradayInsightRepository)(nil).DeleteBefore), date)
+}
+
+// GetAdInsight mocks base method.
+func (m *MockIntradayInsightRepository) GetAdInsight(accountID string, date time.Time) (*domain.AdInsightEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdInsight", accountID, date)
+	ret0, _ := ret[0].(*domain.AdInsightEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdInsight indicates an expected call of GetAdInsight.
+func (mr *MockIntradayInsightRepositoryMockRecorder) GetAdInsight(accountID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdInsight", reflect.TypeOf((*MockIntradayInsightRepository)(nil).GetAdInsight), accountID, date)
+}
+
+// GetSalesInsight mocks base method.
+func (m *MockIntradayInsightRepository) GetSalesInsight(accountID string, date time.Time) (*domain.SalesInsightEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesInsight", accountID, date)
+	ret0, _ := ret[0].(*domain.SalesInsightEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesInsight indicates an expected call of GetSalesInsight.
+func (mr *MockIntradayInsightRepositoryMockRecorder) GetSalesInsight(accountID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesInsight", reflect.TypeOf((*MockIntradayInsightRepository)(nil).GetSalesInsight), accountID, date)
+}
+
+// SaveAdInsight mocks base method.
+func (m *MockIntradayInsightRepository) SaveAdInsight(insight *domain.AdInsightEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAdInsight", insight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAdInsight indicates an expected call of SaveAdInsight.
+func (mr *MockIntradayInsightRepositoryMockRecorder) SaveAdInsight(insight any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAdInsight", reflect.TypeOf((*MockIntradayInsightRepository)(nil).SaveAdInsight), insight)
+}
+
+// SaveSalesInsight mocks base method.
+func (m *MockIntradayInsightRepository) SaveSalesInsight(insight *domain.SalesInsightEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSalesInsight", insight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSalesInsight indicates an expected call of SaveSalesInsight.
+func (mr *MockIntradayInsightRepositoryMockRecorder) SaveSalesInsight(insight any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSalesInsight", reflect.TypeOf((*MockIntradayInsightRepository)(nil).SaveSalesInsight), insight)
+}
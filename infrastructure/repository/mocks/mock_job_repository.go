@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/job.go -destination=infrastructure/repository/mocks/mock_job_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateJob mocks base method.
+func (m *MockJobRepository) CreateJob(job *domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockJobRepositoryMockRecorder) CreateJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockJobRepository)(nil).CreateJob), job)
+}
+
+// FinishJob mocks base method.
+func (m *MockJobRepository) FinishJob(job *domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishJob indicates an expected call of FinishJob.
+func (mr *MockJobRepositoryMockRecorder) FinishJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishJob", reflect.TypeOf((*MockJobRepository)(nil).FinishJob), job)
+}
+
+// GetJob mocks base method.
+func (m *MockJobRepository) GetJob(id int64) (*domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", id)
+	ret0, _ := ret[0].(*domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockJobRepositoryMockRecorder) GetJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockJobRepository)(nil).GetJob), id)
+}
+
+// InterruptRunningJobs mocks base method.
+func (m *MockJobRepository) InterruptRunningJobs(jobType string, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InterruptRunningJobs", jobType, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InterruptRunningJobs indicates an expected call of InterruptRunningJobs.
+func (mr *MockJobRepositoryMockRecorder) InterruptRunningJobs(jobType, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InterruptRunningJobs", reflect.TypeOf((*MockJobRepository)(nil).InterruptRunningJobs), jobType, at)
+}
+
+// ListJobAccounts mocks base method.
+func (m *MockJobRepository) ListJobAccounts(jobID int64) ([]*domain.JobAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobAccounts", jobID)
+	ret0, _ := ret[0].([]*domain.JobAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobAccounts indicates an expected call of ListJobAccounts.
+func (mr *MockJobRepositoryMockRecorder) ListJobAccounts(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobAccounts", reflect.TypeOf((*MockJobRepository)(nil).ListJobAccounts), jobID)
+}
+
+// ListJobs mocks base method.
+func (m *MockJobRepository) ListJobs(filters domain.JobFilters) ([]*domain.Job, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", filters)
+	ret0, _ := ret[0].([]*domain.Job)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockJobRepositoryMockRecorder) ListJobs(filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockJobRepository)(nil).ListJobs), filters)
+}
+
+// SaveJobAccounts mocks base method.
+func (m *MockJobRepository) SaveJobAccounts(jobID int64, accounts []*domain.JobAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveJobAccounts", jobID, accounts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveJobAccounts indicates an expected call of SaveJobAccounts.
+func (mr *MockJobRepositoryMockRecorder) SaveJobAccounts(jobID, accounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveJobAccounts", reflect.TypeOf((*MockJobRepository)(nil).SaveJobAccounts), jobID, accounts)
+}
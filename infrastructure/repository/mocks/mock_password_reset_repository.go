@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/password_reset.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/password_reset.go -destination=infrastructure/repository/mocks/mock_password_reset_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordResetRepository is a mock of PasswordResetRepository interface.
+type MockPasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetRepositoryMockRecorder is the mock recorder for MockPasswordResetRepository.
+type MockPasswordResetRepositoryMockRecorder struct {
+	mock *MockPasswordResetRepository
+}
+
+// NewMockPasswordResetRepository creates a new mock instance.
+func NewMockPasswordResetRepository(ctrl *gomock.Controller) *MockPasswordResetRepository {
+	mock := &MockPasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepository) EXPECT() *MockPasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockPasswordResetRepository) CreatePasswordResetToken(token *domain.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockPasswordResetRepositoryMockRecorder) CreatePasswordResetToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockPasswordResetRepository)(nil).CreatePasswordResetToken), token)
+}
+
+// GetPasswordResetTokenByHash mocks base method.
+func (m *MockPasswordResetRepository) GetPasswordResetTokenByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetTokenByHash", tokenHash)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetTokenByHash indicates an expected call of GetPasswordResetTokenByHash.
+func (mr *MockPasswordResetRepositoryMockRecorder) GetPasswordResetTokenByHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetTokenByHash", reflect.TypeOf((*MockPasswordResetRepository)(nil).GetPasswordResetTokenByHash), tokenHash)
+}
+
+// InvalidateUserPasswordResetTokens mocks base method.
+func (m *MockPasswordResetRepository) InvalidateUserPasswordResetTokens(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserPasswordResetTokens", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserPasswordResetTokens indicates an expected call of InvalidateUserPasswordResetTokens.
+func (mr *MockPasswordResetRepositoryMockRecorder) InvalidateUserPasswordResetTokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserPasswordResetTokens", reflect.TypeOf((*MockPasswordResetRepository)(nil).InvalidateUserPasswordResetTokens), userID)
+}
+
+// MarkPasswordResetTokenUsed mocks base method.
+func (m *MockPasswordResetRepository) MarkPasswordResetTokenUsed(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPasswordResetTokenUsed", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPasswordResetTokenUsed indicates an expected call of MarkPasswordResetTokenUsed.
+func (mr *MockPasswordResetRepositoryMockRecorder) MarkPasswordResetTokenUsed(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPasswordResetTokenUsed", reflect.TypeOf((*MockPasswordResetRepository)(nil).MarkPasswordResetTokenUsed), id)
+}
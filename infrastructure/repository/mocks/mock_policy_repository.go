@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/policy.go -destination=infrastructure/repository/mocks/mock_policy_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPolicyRepository is a mock of PolicyRepository interface.
+type MockPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPolicyRepositoryMockRecorder is the mock recorder for MockPolicyRepository.
+type MockPolicyRepositoryMockRecorder struct {
+	mock *MockPolicyRepository
+}
+
+// NewMockPolicyRepository creates a new mock instance.
+func NewMockPolicyRepository(ctrl *gomock.Controller) *MockPolicyRepository {
+	mock := &MockPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyRepository) EXPECT() *MockPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptPolicy mocks base method.
+func (m *MockPolicyRepository) AcceptPolicy(acceptance *domain.PolicyAcceptance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptPolicy", acceptance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptPolicy indicates an expected call of AcceptPolicy.
+func (mr *MockPolicyRepositoryMockRecorder) AcceptPolicy(acceptance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptPolicy", reflect.TypeOf((*MockPolicyRepository)(nil).AcceptPolicy), acceptance)
+}
+
+// GetPolicyAcceptance mocks base method.
+func (m *MockPolicyRepository) GetPolicyAcceptance(userID int, version string) (*domain.PolicyAcceptance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyAcceptance", userID, version)
+	ret0, _ := ret[0].(*domain.PolicyAcceptance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyAcceptance indicates an expected call of GetPolicyAcceptance.
+func (mr *MockPolicyRepositoryMockRecorder) GetPolicyAcceptance(userID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyAcceptance", reflect.TypeOf((*MockPolicyRepository)(nil).GetPolicyAcceptance), userID, version)
+}
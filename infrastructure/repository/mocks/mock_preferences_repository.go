@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/preferences.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/preferences.go -destination=infrastructure/repository/mocks/mock_preferences_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferencesRepository is a mock of PreferencesRepository interface.
+type MockPreferencesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferencesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPreferencesRepositoryMockRecorder is the mock recorder for MockPreferencesRepository.
+type MockPreferencesRepositoryMockRecorder struct {
+	mock *MockPreferencesRepository
+}
+
+// NewMockPreferencesRepository creates a new mock instance.
+func NewMockPreferencesRepository(ctrl *gomock.Controller) *MockPreferencesRepository {
+	mock := &MockPreferencesRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferencesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferencesRepository) EXPECT() *MockPreferencesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserPreferences mocks base method.
+func (m *MockPreferencesRepository) GetUserPreferences(userID int) (*domain.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreferences", userID)
+	ret0, _ := ret[0].(*domain.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPreferences indicates an expected call of GetUserPreferences.
+func (mr *MockPreferencesRepositoryMockRecorder) GetUserPreferences(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockPreferencesRepository)(nil).GetUserPreferences), userID)
+}
+
+// SaveUserPreferences mocks base method.
+func (m *MockPreferencesRepository) SaveUserPreferences(userID int, preferences *domain.UserPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserPreferences", userID, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserPreferences indicates an expected call of SaveUserPreferences.
+func (mr *MockPreferencesRepositoryMockRecorder) SaveUserPreferences(userID, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserPreferences", reflect.TypeOf((*MockPreferencesRepository)(nil).SaveUserPreferences), userID, preferences)
+}
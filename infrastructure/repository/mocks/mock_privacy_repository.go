@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/privacy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/privacy.go -destination=infrastructure/repository/mocks/mock_privacy_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPrivacyRepository is a mock of PrivacyRepository interface.
+type MockPrivacyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPrivacyRepositoryMockRecorder is the mock recorder for MockPrivacyRepository.
+type MockPrivacyRepositoryMockRecorder struct {
+	mock *MockPrivacyRepository
+}
+
+// NewMockPrivacyRepository creates a new mock instance.
+func NewMockPrivacyRepository(ctrl *gomock.Controller) *MockPrivacyRepository {
+	mock := &MockPrivacyRepository{ctrl: ctrl}
+	mock.recorder = &MockPrivacyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacyRepository) EXPECT() *MockPrivacyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeUsersByEmail mocks base method.
+func (m *MockPrivacyRepository) AnonymizeUsersByEmail(email string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUsersByEmail", email)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUsersByEmail indicates an expected call of AnonymizeUsersByEmail.
+func (mr *MockPrivacyRepositoryMockRecorder) AnonymizeUsersByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUsersByEmail", reflect.TypeOf((*MockPrivacyRepository)(nil).AnonymizeUsersByEmail), email)
+}
+
+// CompleteErasureRecord mocks base method.
+func (m *MockPrivacyRepository) CompleteErasureRecord(record *domain.ErasureRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteErasureRecord", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteErasureRecord indicates an expected call of CompleteErasureRecord.
+func (mr *MockPrivacyRepositoryMockRecorder) CompleteErasureRecord(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteErasureRecord", reflect.TypeOf((*MockPrivacyRepository)(nil).CompleteErasureRecord), record)
+}
+
+// CreateErasureRecord mocks base method.
+func (m *MockPrivacyRepository) CreateErasureRecord(record *domain.ErasureRecord) (*domain.ErasureRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateErasureRecord", record)
+	ret0, _ := ret[0].(*domain.ErasureRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateErasureRecord indicates an expected call of CreateErasureRecord.
+func (mr *MockPrivacyRepositoryMockRecorder) CreateErasureRecord(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateErasureRecord", reflect.TypeOf((*MockPrivacyRepository)(nil).CreateErasureRecord), record)
+}
+
+// ListErasureRecords mocks base method.
+func (m *MockPrivacyRepository) ListErasureRecords(limit int) ([]*domain.ErasureRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListErasureRecords", limit)
+	ret0, _ := ret[0].([]*domain.ErasureRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListErasureRecords indicates an expected call of ListErasureRecords.
+func (mr *MockPrivacyRepositoryMockRecorder) ListErasureRecords(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListErasureRecords", reflect.TypeOf((*MockPrivacyRepository)(nil).ListErasureRecords), limit)
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/report_schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/report_schedule.go -destination=infrastructure/repository/mocks/mock_report_schedule_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportScheduleRepository is a mock of ReportScheduleRepository interface.
+type MockReportScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportScheduleRepositoryMockRecorder is the mock recorder for MockReportScheduleRepository.
+type MockReportScheduleRepositoryMockRecorder struct {
+	mock *MockReportScheduleRepository
+}
+
+// NewMockReportScheduleRepository creates a new mock instance.
+func NewMockReportScheduleRepository(ctrl *gomock.Controller) *MockReportScheduleRepository {
+	mock := &MockReportScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockReportScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportScheduleRepository) EXPECT() *MockReportScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSchedule mocks base method.
+func (m *MockReportScheduleRepository) CreateSchedule(schedule *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", schedule)
+	ret0, _ := ret[0].(*domain.ReportSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockReportScheduleRepositoryMockRecorder) CreateSchedule(schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockReportScheduleRepository)(nil).CreateSchedule), schedule)
+}
+
+// DeactivateSchedule mocks base method.
+func (m *MockReportScheduleRepository) DeactivateSchedule(scheduleID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateSchedule", scheduleID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateSchedule indicates an expected call of DeactivateSchedule.
+func (mr *MockReportScheduleRepositoryMockRecorder) DeactivateSchedule(scheduleID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateSchedule", reflect.TypeOf((*MockReportScheduleRepository)(nil).DeactivateSchedule), scheduleID, reason)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockReportScheduleRepository) DeleteSchedule(userID, reportID, scheduleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", userID, reportID, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockReportScheduleRepositoryMockRecorder) DeleteSchedule(userID, reportID, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockReportScheduleRepository)(nil).DeleteSchedule), userID, reportID, scheduleID)
+}
+
+// ListDueSchedules mocks base method.
+func (m *MockReportScheduleRepository) ListDueSchedules(now time.Time, limit int) ([]*domain.ReportSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueSchedules", now, limit)
+	ret0, _ := ret[0].([]*domain.ReportSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueSchedules indicates an expected call of ListDueSchedules.
+func (mr *MockReportScheduleRepositoryMockRecorder) ListDueSchedules(now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueSchedules", reflect.TypeOf((*MockReportScheduleRepository)(nil).ListDueSchedules), now, limit)
+}
+
+// ListSchedulesByReport mocks base method.
+func (m *MockReportScheduleRepository) ListSchedulesByReport(userID, reportID int) ([]*domain.ReportSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedulesByReport", userID, reportID)
+	ret0, _ := ret[0].([]*domain.ReportSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedulesByReport indicates an expected call of ListSchedulesByReport.
+func (mr *MockReportScheduleRepositoryMockRecorder) ListSchedulesByReport(userID, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedulesByReport", reflect.TypeOf((*MockReportScheduleRepository)(nil).ListSchedulesByReport), userID, reportID)
+}
+
+// MarkScheduleRun mocks base method.
+func (m *MockReportScheduleRepository) MarkScheduleRun(scheduleID int, runAt, nextRunAt time.Time, runErr *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkScheduleRun", scheduleID, runAt, nextRunAt, runErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkScheduleRun indicates an expected call of MarkScheduleRun.
+func (mr *MockReportScheduleRepositoryMockRecorder) MarkScheduleRun(scheduleID, runAt, nextRunAt, runErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkScheduleRun", reflect.TypeOf((*MockReportScheduleRepository)(nil).MarkScheduleRun), scheduleID, runAt, nextRunAt, runErr)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/rollup_insight.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/rollup_insight.go -destination=infrastructure/repository/mocks/mock_rollup_insight_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRollupInsightRepository is a mock of RollupInsightRepository interface.
+type MockRollupInsightRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRollupInsightRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRollupInsightRepositoryMockRecorder is the mock recorder for MockRollupInsightRepository.
+type MockRollupInsightRepositoryMockRecorder struct {
+	mock *MockRollupInsightRepository
+}
+
+// NewMockRollupInsightRepository creates a new mock instance.
+func NewMockRollupInsightRepository(ctrl *gomock.Controller) *MockRollupInsightRepository {
+	mock := &MockRollupInsightRepository{ctrl: ctrl}
+	mock.recorder = &MockRollupInsightRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRollupInsightRepository) EXPECT() *MockRollupInsightRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByRange mocks base method.
+func (m *MockRollupInsightRepository) GetByRange(accountID, granularity string, startDate, endDate time.Time) ([]*domain.RollupInsightEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRange", accountID, granularity, startDate, endDate)
+	ret0, _ := ret[0].([]*domain.RollupInsightEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRange indicates an expected call of GetByRange.
+func (mr *MockRollupInsightRepositoryMockRecorder) GetByRange(accountID, granularity, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRange", reflect.TypeOf((*MockRollupInsightRepository)(nil).GetByRange), accountID, granularity, startDate, endDate)
+}
+
+// SaveOrUpdate mocks base method.
+func (m *MockRollupInsightRepository) SaveOrUpdate(entry *domain.RollupInsightEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrUpdate", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrUpdate indicates an expected call of SaveOrUpdate.
+func (mr *MockRollupInsightRepositoryMockRecorder) SaveOrUpdate(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrUpdate", reflect.TypeOf((*MockRollupInsightRepository)(nil).SaveOrUpdate), entry)
+}
@@ -86,6 +86,21 @@ func (mr *MockSalesInsightRepositoryMockRecorder) GetByDateRange(accountID, star
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateRange", reflect.TypeOf((*MockSalesInsightRepository)(nil).GetByDateRange), accountID, startDate, endDate)
 }
 
+// GetLastSyncedAt mocks base method.
+func (m *MockSalesInsightRepository) GetLastSyncedAt(accountID string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncedAt", accountID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncedAt indicates an expected call of GetLastSyncedAt.
+func (mr *MockSalesInsightRepositoryMockRecorder) GetLastSyncedAt(accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncedAt", reflect.TypeOf((*MockSalesInsightRepository)(nil).GetLastSyncedAt), accountID)
+}
+
 // SaveOrUpdate mocks base method.
 func (m *MockSalesInsightRepository) SaveOrUpdate(insight *domain.SalesInsightEntry) error {
 	m.ctrl.T.Helper()
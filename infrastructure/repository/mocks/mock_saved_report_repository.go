@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/saved_report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/saved_report.go -destination=infrastructure/repository/mocks/mock_saved_report_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSavedReportRepository is a mock of SavedReportRepository interface.
+type MockSavedReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSavedReportRepositoryMockRecorder is the mock recorder for MockSavedReportRepository.
+type MockSavedReportRepositoryMockRecorder struct {
+	mock *MockSavedReportRepository
+}
+
+// NewMockSavedReportRepository creates a new mock instance.
+func NewMockSavedReportRepository(ctrl *gomock.Controller) *MockSavedReportRepository {
+	mock := &MockSavedReportRepository{ctrl: ctrl}
+	mock.recorder = &MockSavedReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedReportRepository) EXPECT() *MockSavedReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSavedReport mocks base method.
+func (m *MockSavedReportRepository) CreateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedReport", report)
+	ret0, _ := ret[0].(*domain.SavedReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedReport indicates an expected call of CreateSavedReport.
+func (mr *MockSavedReportRepositoryMockRecorder) CreateSavedReport(report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedReport", reflect.TypeOf((*MockSavedReportRepository)(nil).CreateSavedReport), report)
+}
+
+// DeleteSavedReport mocks base method.
+func (m *MockSavedReportRepository) DeleteSavedReport(userID, reportID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedReport", userID, reportID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedReport indicates an expected call of DeleteSavedReport.
+func (mr *MockSavedReportRepositoryMockRecorder) DeleteSavedReport(userID, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedReport", reflect.TypeOf((*MockSavedReportRepository)(nil).DeleteSavedReport), userID, reportID)
+}
+
+// GetSavedReport mocks base method.
+func (m *MockSavedReportRepository) GetSavedReport(userID, reportID int) (*domain.SavedReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedReport", userID, reportID)
+	ret0, _ := ret[0].(*domain.SavedReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedReport indicates an expected call of GetSavedReport.
+func (mr *MockSavedReportRepositoryMockRecorder) GetSavedReport(userID, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedReport", reflect.TypeOf((*MockSavedReportRepository)(nil).GetSavedReport), userID, reportID)
+}
+
+// ListSavedReports mocks base method.
+func (m *MockSavedReportRepository) ListSavedReports(userID int) ([]*domain.SavedReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedReports", userID)
+	ret0, _ := ret[0].([]*domain.SavedReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedReports indicates an expected call of ListSavedReports.
+func (mr *MockSavedReportRepositoryMockRecorder) ListSavedReports(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedReports", reflect.TypeOf((*MockSavedReportRepository)(nil).ListSavedReports), userID)
+}
+
+// UpdateSavedReport mocks base method.
+func (m *MockSavedReportRepository) UpdateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedReport", report)
+	ret0, _ := ret[0].(*domain.SavedReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedReport indicates an expected call of UpdateSavedReport.
+func (mr *MockSavedReportRepositoryMockRecorder) UpdateSavedReport(report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedReport", reflect.TypeOf((*MockSavedReportRepository)(nil).UpdateSavedReport), report)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/scheduler_lock.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/scheduler_lock.go -destination=infrastructure/repository/mocks/mock_scheduler_lock_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchedulerLockRepository is a mock of SchedulerLockRepository interface.
+type MockSchedulerLockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerLockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerLockRepositoryMockRecorder is the mock recorder for MockSchedulerLockRepository.
+type MockSchedulerLockRepositoryMockRecorder struct {
+	mock *MockSchedulerLockRepository
+}
+
+// NewMockSchedulerLockRepository creates a new mock instance.
+func NewMockSchedulerLockRepository(ctrl *gomock.Controller) *MockSchedulerLockRepository {
+	mock := &MockSchedulerLockRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulerLockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerLockRepository) EXPECT() *MockSchedulerLockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockSchedulerLockRepository) TryLock(name string) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", name)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockSchedulerLockRepositoryMockRecorder) TryLock(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockSchedulerLockRepository)(nil).TryLock), name)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/scheduler_pause.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/scheduler_pause.go -destination=infrastructure/repository/mocks/mock_scheduler_pause_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchedulerPauseRepository is a mock of SchedulerPauseRepository interface.
+type MockSchedulerPauseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerPauseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerPauseRepositoryMockRecorder is the mock recorder for MockSchedulerPauseRepository.
+type MockSchedulerPauseRepositoryMockRecorder struct {
+	mock *MockSchedulerPauseRepository
+}
+
+// NewMockSchedulerPauseRepository creates a new mock instance.
+func NewMockSchedulerPauseRepository(ctrl *gomock.Controller) *MockSchedulerPauseRepository {
+	mock := &MockSchedulerPauseRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulerPauseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerPauseRepository) EXPECT() *MockSchedulerPauseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPause mocks base method.
+func (m *MockSchedulerPauseRepository) GetPause(scheduler string) (*domain.SchedulerState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPause", scheduler)
+	ret0, _ := ret[0].(*domain.SchedulerState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPause indicates an expected call of GetPause.
+func (mr *MockSchedulerPauseRepositoryMockRecorder) GetPause(scheduler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPause", reflect.TypeOf((*MockSchedulerPauseRepository)(nil).GetPause), scheduler)
+}
+
+// ListPauses mocks base method.
+func (m *MockSchedulerPauseRepository) ListPauses() ([]*domain.SchedulerState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPauses")
+	ret0, _ := ret[0].([]*domain.SchedulerState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPauses indicates an expected call of ListPauses.
+func (mr *MockSchedulerPauseRepositoryMockRecorder) ListPauses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPauses", reflect.TypeOf((*MockSchedulerPauseRepository)(nil).ListPauses))
+}
+
+// PauseScheduler mocks base method.
+func (m *MockSchedulerPauseRepository) PauseScheduler(state *domain.SchedulerState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseScheduler", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseScheduler indicates an expected call of PauseScheduler.
+func (mr *MockSchedulerPauseRepositoryMockRecorder) PauseScheduler(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseScheduler", reflect.TypeOf((*MockSchedulerPauseRepository)(nil).PauseScheduler), state)
+}
+
+// ResumeScheduler mocks base method.
+func (m *MockSchedulerPauseRepository) ResumeScheduler(scheduler string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeScheduler", scheduler)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeScheduler indicates an expected call of ResumeScheduler.
+func (mr *MockSchedulerPauseRepositoryMockRecorder) ResumeScheduler(scheduler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeScheduler", reflect.TypeOf((*MockSchedulerPauseRepository)(nil).ResumeScheduler), scheduler)
+}
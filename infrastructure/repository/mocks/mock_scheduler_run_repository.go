@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/scheduler_run.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/scheduler_run.go -destination=infrastructure/repository/mocks/mock_scheduler_run_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchedulerRunRepository is a mock of SchedulerRunRepository interface.
+type MockSchedulerRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerRunRepositoryMockRecorder is the mock recorder for MockSchedulerRunRepository.
+type MockSchedulerRunRepositoryMockRecorder struct {
+	mock *MockSchedulerRunRepository
+}
+
+// NewMockSchedulerRunRepository creates a new mock instance.
+func NewMockSchedulerRunRepository(ctrl *gomock.Controller) *MockSchedulerRunRepository {
+	mock := &MockSchedulerRunRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulerRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerRunRepository) EXPECT() *MockSchedulerRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRun mocks base method.
+func (m *MockSchedulerRunRepository) GetRun(name string) (*domain.SchedulerRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", name)
+	ret0, _ := ret[0].(*domain.SchedulerRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockSchedulerRunRepositoryMockRecorder) GetRun(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockSchedulerRunRepository)(nil).GetRun), name)
+}
+
+// MarkStarted mocks base method.
+func (m *MockSchedulerRunRepository) MarkStarted(name string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkStarted", name, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkStarted indicates an expected call of MarkStarted.
+func (mr *MockSchedulerRunRepositoryMockRecorder) MarkStarted(name, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStarted", reflect.TypeOf((*MockSchedulerRunRepository)(nil).MarkStarted), name, at)
+}
+
+// MarkSucceeded mocks base method.
+func (m *MockSchedulerRunRepository) MarkSucceeded(name string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSucceeded", name, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSucceeded indicates an expected call of MarkSucceeded.
+func (mr *MockSchedulerRunRepositoryMockRecorder) MarkSucceeded(name, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSucceeded", reflect.TypeOf((*MockSchedulerRunRepository)(nil).MarkSucceeded), name, at)
+}
+
+// SaveCheckpoint mocks base method.
+func (m *MockSchedulerRunRepository) SaveCheckpoint(name string, checkpoint *domain.SyncCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCheckpoint", name, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCheckpoint indicates an expected call of SaveCheckpoint.
+func (mr *MockSchedulerRunRepositoryMockRecorder) SaveCheckpoint(name, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCheckpoint", reflect.TypeOf((*MockSchedulerRunRepository)(nil).SaveCheckpoint), name, checkpoint)
+}
+
+// SaveStats mocks base method.
+func (m *MockSchedulerRunRepository) SaveStats(name string, stats map[string]*domain.ProviderStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveStats", name, stats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveStats indicates an expected call of SaveStats.
+func (mr *MockSchedulerRunRepositoryMockRecorder) SaveStats(name, stats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStats", reflect.TypeOf((*MockSchedulerRunRepository)(nil).SaveStats), name, stats)
+}
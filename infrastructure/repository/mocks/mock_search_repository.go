@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/search.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/search.go -destination=infrastructure/repository/mocks/mock_search_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SearchAccounts mocks base method.
+func (m *MockSearchRepository) SearchAccounts(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAccounts", term, scope)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockSearchRepositoryMockRecorder) SearchAccounts(term, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockSearchRepository)(nil).SearchAccounts), term, scope)
+}
+
+// SearchBusinessManagers mocks base method.
+func (m *MockSearchRepository) SearchBusinessManagers(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBusinessManagers", term, scope)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBusinessManagers indicates an expected call of SearchBusinessManagers.
+func (mr *MockSearchRepositoryMockRecorder) SearchBusinessManagers(term, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBusinessManagers", reflect.TypeOf((*MockSearchRepository)(nil).SearchBusinessManagers), term, scope)
+}
+
+// SearchCampaigns mocks base method.
+func (m *MockSearchRepository) SearchCampaigns(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCampaigns", term, scope)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCampaigns indicates an expected call of SearchCampaigns.
+func (mr *MockSearchRepositoryMockRecorder) SearchCampaigns(term, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCampaigns", reflect.TypeOf((*MockSearchRepository)(nil).SearchCampaigns), term, scope)
+}
+
+// SearchUsers mocks base method.
+func (m *MockSearchRepository) SearchUsers(term string, limit int) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", term, limit)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockSearchRepositoryMockRecorder) SearchUsers(term, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockSearchRepository)(nil).SearchUsers), term, limit)
+}
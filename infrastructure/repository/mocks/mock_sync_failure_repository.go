@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/sync_failure.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/sync_failure.go -destination=infrastructure/repository/mocks/mock_sync_failure_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSyncFailureRepository is a mock of SyncFailureRepository interface.
+type MockSyncFailureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncFailureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncFailureRepositoryMockRecorder is the mock recorder for MockSyncFailureRepository.
+type MockSyncFailureRepositoryMockRecorder struct {
+	mock *MockSyncFailureRepository
+}
+
+// NewMockSyncFailureRepository creates a new mock instance.
+func NewMockSyncFailureRepository(ctrl *gomock.Controller) *MockSyncFailureRepository {
+	mock := &MockSyncFailureRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncFailureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncFailureRepository) EXPECT() *MockSyncFailureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteFailures mocks base method.
+func (m *MockSyncFailureRepository) DeleteFailures(source, accountID string, dates []time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFailures", source, accountID, dates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFailures indicates an expected call of DeleteFailures.
+func (mr *MockSyncFailureRepositoryMockRecorder) DeleteFailures(source, accountID, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFailures", reflect.TypeOf((*MockSyncFailureRepository)(nil).DeleteFailures), source, accountID, dates)
+}
+
+// GetFailure mocks base method.
+func (m *MockSyncFailureRepository) GetFailure(id int64) (*domain.SyncFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailure", id)
+	ret0, _ := ret[0].(*domain.SyncFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFailure indicates an expected call of GetFailure.
+func (mr *MockSyncFailureRepositoryMockRecorder) GetFailure(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailure", reflect.TypeOf((*MockSyncFailureRepository)(nil).GetFailure), id)
+}
+
+// ListAccountFailureDates mocks base method.
+func (m *MockSyncFailureRepository) ListAccountFailureDates(source, accountID string) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountFailureDates", source, accountID)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountFailureDates indicates an expected call of ListAccountFailureDates.
+func (mr *MockSyncFailureRepositoryMockRecorder) ListAccountFailureDates(source, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountFailureDates", reflect.TypeOf((*MockSyncFailureRepository)(nil).ListAccountFailureDates), source, accountID)
+}
+
+// ListFailures mocks base method.
+func (m *MockSyncFailureRepository) ListFailures(filters domain.SyncFailureFilters) ([]*domain.SyncFailure, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailures", filters)
+	ret0, _ := ret[0].([]*domain.SyncFailure)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFailures indicates an expected call of ListFailures.
+func (mr *MockSyncFailureRepositoryMockRecorder) ListFailures(filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailures", reflect.TypeOf((*MockSyncFailureRepository)(nil).ListFailures), filters)
+}
+
+// MarkRequeued mocks base method.
+func (m *MockSyncFailureRepository) MarkRequeued(source, accountID string, requeuedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRequeued", source, accountID, requeuedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRequeued indicates an expected call of MarkRequeued.
+func (mr *MockSyncFailureRepositoryMockRecorder) MarkRequeued(source, accountID, requeuedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRequeued", reflect.TypeOf((*MockSyncFailureRepository)(nil).MarkRequeued), source, accountID, requeuedAt)
+}
+
+// SaveFailure mocks base method.
+func (m *MockSyncFailureRepository) SaveFailure(failure *domain.SyncFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFailure", failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFailure indicates an expected call of SaveFailure.
+func (mr *MockSyncFailureRepositoryMockRecorder) SaveFailure(failure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFailure", reflect.TypeOf((*MockSyncFailureRepository)(nil).SaveFailure), failure)
+}
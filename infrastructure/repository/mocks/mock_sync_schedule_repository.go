@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: infrastructure/repository/sync_schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=infrastructure/repository/sync_schedule.go -destination=infrastructure/repository/mocks/mock_sync_schedule_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSyncScheduleRepository is a mock of SyncScheduleRepository interface.
+type MockSyncScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncScheduleRepositoryMockRecorder is the mock recorder for MockSyncScheduleRepository.
+type MockSyncScheduleRepositoryMockRecorder struct {
+	mock *MockSyncScheduleRepository
+}
+
+// NewMockSyncScheduleRepository creates a new mock instance.
+func NewMockSyncScheduleRepository(ctrl *gomock.Controller) *MockSyncScheduleRepository {
+	mock := &MockSyncScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncScheduleRepository) EXPECT() *MockSyncScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockSyncScheduleRepository) DeleteSchedule(accountID, source string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", accountID, source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockSyncScheduleRepositoryMockRecorder) DeleteSchedule(accountID, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockSyncScheduleRepository)(nil).DeleteSchedule), accountID, source)
+}
+
+// ListAccountSchedules mocks base method.
+func (m *MockSyncScheduleRepository) ListAccountSchedules(accountID string) ([]*domain.AccountSyncSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountSchedules", accountID)
+	ret0, _ := ret[0].([]*domain.AccountSyncSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountSchedules indicates an expected call of ListAccountSchedules.
+func (mr *MockSyncScheduleRepositoryMockRecorder) ListAccountSchedules(accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountSchedules", reflect.TypeOf((*MockSyncScheduleRepository)(nil).ListAccountSchedules), accountID)
+}
+
+// ListEnabledSchedules mocks base method.
+func (m *MockSyncScheduleRepository) ListEnabledSchedules(source string) ([]*domain.AccountSyncSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabledSchedules", source)
+	ret0, _ := ret[0].([]*domain.AccountSyncSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabledSchedules indicates an expected call of ListEnabledSchedules.
+func (mr *MockSyncScheduleRepositoryMockRecorder) ListEnabledSchedules(source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledSchedules", reflect.TypeOf((*MockSyncScheduleRepository)(nil).ListEnabledSchedules), source)
+}
+
+// MarkScheduleRun mocks base method.
+func (m *MockSyncScheduleRepository) MarkScheduleRun(accountID, source string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkScheduleRun", accountID, source, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkScheduleRun indicates an expected call of MarkScheduleRun.
+func (mr *MockSyncScheduleRepositoryMockRecorder) MarkScheduleRun(accountID, source, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkScheduleRun", reflect.TypeOf((*MockSyncScheduleRepository)(nil).MarkScheduleRun), accountID, source, at)
+}
+
+// UpsertSchedule mocks base method.
+func (m *MockSyncScheduleRepository) UpsertSchedule(schedule *domain.AccountSyncSchedule) (*domain.AccountSyncSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSchedule", schedule)
+	ret0, _ := ret[0].(*domain.AccountSyncSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertSchedule indicates an expected call of UpsertSchedule.
+func (mr *MockSyncScheduleRepositoryMockRecorder) UpsertSchedule(schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSchedule", reflect.TypeOf((*MockSyncScheduleRepository)(nil).UpsertSchedule), schedule)
+}
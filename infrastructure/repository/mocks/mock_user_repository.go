@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/vfg2006/traffic-manager-api/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,51 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), user)
 }
 
+// GetPasswordHistory mocks base method.
+func (m *MockUserRepository) GetPasswordHistory(userID, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHistory", userID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordHistory indicates an expected call of GetPasswordHistory.
+func (mr *MockUserRepositoryMockRecorder) GetPasswordHistory(userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHistory", reflect.TypeOf((*MockUserRepository)(nil).GetPasswordHistory), userID, limit)
+}
+
+// GetUserAccountPermission mocks base method.
+func (m *MockUserRepository) GetUserAccountPermission(userID int, accountID string) (domain.AccountPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccountPermission", userID, accountID)
+	ret0, _ := ret[0].(domain.AccountPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccountPermission indicates an expected call of GetUserAccountPermission.
+func (mr *MockUserRepositoryMockRecorder) GetUserAccountPermission(userID, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccountPermission", reflect.TypeOf((*MockUserRepository)(nil).GetUserAccountPermission), userID, accountID)
+}
+
+// GetUserAccountPermissions mocks base method.
+func (m *MockUserRepository) GetUserAccountPermissions(userID int) (map[string]domain.AccountPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccountPermissions", userID)
+	ret0, _ := ret[0].(map[string]domain.AccountPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccountPermissions indicates an expected call of GetUserAccountPermissions.
+func (mr *MockUserRepositoryMockRecorder) GetUserAccountPermissions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccountPermissions", reflect.TypeOf((*MockUserRepository)(nil).GetUserAccountPermissions), userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserRepository) GetUserByEmail(email string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -100,18 +146,32 @@ func (mr *MockUserRepositoryMockRecorder) GetUserLinkedAccounts(userID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinkedAccounts", reflect.TypeOf((*MockUserRepository)(nil).GetUserLinkedAccounts), userID)
 }
 
+// ImportUsers mocks base method.
+func (m *MockUserRepository) ImportUsers(imports []*domain.UserImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", imports)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockUserRepositoryMockRecorder) ImportUsers(imports any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockUserRepository)(nil).ImportUsers), imports)
+}
+
 // LinkUserAccount mocks base method.
-func (m *MockUserRepository) LinkUserAccount(userID int, accountID string) error {
+func (m *MockUserRepository) LinkUserAccount(userID int, accountID string, permission domain.AccountPermission) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LinkUserAccount", userID, accountID)
+	ret := m.ctrl.Call(m, "LinkUserAccount", userID, accountID, permission)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LinkUserAccount indicates an expected call of LinkUserAccount.
-func (mr *MockUserRepositoryMockRecorder) LinkUserAccount(userID, accountID any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) LinkUserAccount(userID, accountID, permission any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUserAccount", reflect.TypeOf((*MockUserRepository)(nil).LinkUserAccount), userID, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUserAccount", reflect.TypeOf((*MockUserRepository)(nil).LinkUserAccount), userID, accountID, permission)
 }
 
 // ListUser mocks base method.
@@ -129,6 +189,49 @@ func (mr *MockUserRepositoryMockRecorder) ListUser() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUser", reflect.TypeOf((*MockUserRepository)(nil).ListUser))
 }
 
+// RecordLogin mocks base method.
+func (m *MockUserRepository) RecordLogin(userID int, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLogin", userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockUserRepositoryMockRecorder) RecordLogin(userID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockUserRepository)(nil).RecordLogin), userID, at)
+}
+
+// TouchLastSeen mocks base method.
+func (m *MockUserRepository) TouchLastSeen(userID int, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastSeen", userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastSeen indicates an expected call of TouchLastSeen.
+func (mr *MockUserRepositoryMockRecorder) TouchLastSeen(userID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastSeen", reflect.TypeOf((*MockUserRepository)(nil).TouchLastSeen), userID, at)
+}
+
+// UnlinkAllUserAccounts mocks base method.
+func (m *MockUserRepository) UnlinkAllUserAccounts(userID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkAllUserAccounts", userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlinkAllUserAccounts indicates an expected call of UnlinkAllUserAccounts.
+func (mr *MockUserRepositoryMockRecorder) UnlinkAllUserAccounts(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkAllUserAccounts", reflect.TypeOf((*MockUserRepository)(nil).UnlinkAllUserAccounts), userID)
+}
+
 // UnlinkUserAccount mocks base method.
 func (m *MockUserRepository) UnlinkUserAccount(userID int, accountID string) error {
 	m.ctrl.T.Helper()
@@ -143,6 +246,34 @@ func (mr *MockUserRepositoryMockRecorder) UnlinkUserAccount(userID, accountID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkUserAccount", reflect.TypeOf((*MockUserRepository)(nil).UnlinkUserAccount), userID, accountID)
 }
 
+// UpdateAvatarURL mocks base method.
+func (m *MockUserRepository) UpdateAvatarURL(userID int, avatarURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatarURL", userID, avatarURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAvatarURL indicates an expected call of UpdateAvatarURL.
+func (mr *MockUserRepositoryMockRecorder) UpdateAvatarURL(userID, avatarURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatarURL", reflect.TypeOf((*MockUserRepository)(nil).UpdateAvatarURL), userID, avatarURL)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(userID int, passwordHash string, mustChange bool, historySize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", userID, passwordHash, mustChange, historySize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(userID, passwordHash, mustChange, historySize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), userID, passwordHash, mustChange, historySize)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(user *domain.User) error {
 	m.ctrl.T.Helper()
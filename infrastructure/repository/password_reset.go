@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	passwordResetTokensTable = "password_reset_tokens"
+)
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(token *domain.PasswordResetToken) error
+	// GetPasswordResetTokenByHash retorna o token ou nil se não existir
+	GetPasswordResetTokenByHash(tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkPasswordResetTokenUsed marca o token como usado e informa se ele ainda estava disponível, garantindo
+	// o uso único mesmo com requisições simultâneas
+	MarkPasswordResetTokenUsed(id int) (bool, error)
+	// InvalidateUserPasswordResetTokens descarta os links ainda não usados do usuário
+	InvalidateUserPasswordResetTokens(userID int) error
+}
+
+type passwordResetRepository struct {
+	conn *postgres.Connection
+}
+
+func NewPasswordResetRepository(conn *postgres.Connection) PasswordResetRepository {
+	return &passwordResetRepository{
+		conn: conn,
+	}
+}
+
+func (r *passwordResetRepository) CreatePasswordResetToken(token *domain.PasswordResetToken) error {
+	query, args, err := squirrel.
+		Insert(passwordResetTokensTable).
+		Columns("user_id", "token_hash", "expires_at").
+		Values(token.UserID, token.TokenHash, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&token.ID, &token.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao salvar token de redefinição de senha: %w", err)
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepository) GetPasswordResetTokenByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	query, args, err := squirrel.
+		Select("id", "user_id", "token_hash", "expires_at", "used_at", "created_at").
+		From(passwordResetTokensTable).
+		Where(squirrel.Eq{"token_hash": tokenHash}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var token domain.PasswordResetToken
+	err = r.conn.QueryRow(query, args...).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar token de redefinição de senha: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *passwordResetRepository) MarkPasswordResetTokenUsed(id int) (bool, error) {
+	query, args, err := squirrel.
+		Update(passwordResetTokensTable).
+		Set("used_at", time.Now()).
+		Where(squirrel.Eq{"id": id, "used_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao marcar token de redefinição de senha como usado: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar uso do token de redefinição de senha: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *passwordResetRepository) InvalidateUserPasswordResetTokens(userID int) error {
+	query, args, err := squirrel.
+		Update(passwordResetTokensTable).
+		Set("used_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "used_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao invalidar tokens de redefinição de senha: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const policyAcceptancesTable = "policy_acceptances"
+
+type PolicyRepository interface {
+	// AcceptPolicy registra o aceite da versão. Um aceite repetido mantém o registro original.
+	AcceptPolicy(acceptance *domain.PolicyAcceptance) error
+	// GetPolicyAcceptance retorna o aceite da versão pelo usuário ou nil se ele ainda não a aceitou
+	GetPolicyAcceptance(userID int, version string) (*domain.PolicyAcceptance, error)
+}
+
+type policyRepository struct {
+	conn *postgres.Connection
+}
+
+func NewPolicyRepository(conn *postgres.Connection) PolicyRepository {
+	return &policyRepository{
+		conn: conn,
+	}
+}
+
+func (r *policyRepository) AcceptPolicy(acceptance *domain.PolicyAcceptance) error {
+	query, args, err := squirrel.
+		Insert(policyAcceptancesTable).
+		Columns("user_id", "policy_version", "accepted_at", "ip_address", "user_agent").
+		Values(acceptance.UserID, acceptance.Version, acceptance.AcceptedAt, acceptance.IPAddress, acceptance.UserAgent).
+		Suffix("ON CONFLICT (user_id, policy_version) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao registrar aceite da política: %w", err)
+	}
+
+	return nil
+}
+
+func (r *policyRepository) GetPolicyAcceptance(userID int, version string) (*domain.PolicyAcceptance, error) {
+	query, args, err := squirrel.
+		Select("id", "user_id", "policy_version", "accepted_at", "COALESCE(ip_address, '')", "COALESCE(user_agent, '')").
+		From(policyAcceptancesTable).
+		Where(squirrel.Eq{"user_id": userID, "policy_version": version}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var acceptance domain.PolicyAcceptance
+	err = r.conn.QueryRow(query, args...).Scan(
+		&acceptance.ID,
+		&acceptance.UserID,
+		&acceptance.Version,
+		&acceptance.AcceptedAt,
+		&acceptance.IPAddress,
+		&acceptance.UserAgent,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar aceite da política: %w", err)
+	}
+
+	return &acceptance, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type PreferencesRepository interface {
+	GetUserPreferences(userID int) (*domain.UserPreferences, error)
+	SaveUserPreferences(userID int, preferences *domain.UserPreferences) error
+}
+
+type preferencesRepository struct {
+	conn *postgres.Connection
+}
+
+func NewPreferencesRepository(conn *postgres.Connection) PreferencesRepository {
+	return &preferencesRepository{
+		conn: conn,
+	}
+}
+
+// GetUserPreferences retorna as preferências salvas do usuário ou nil se ainda não houver
+func (r *preferencesRepository) GetUserPreferences(userID int) (*domain.UserPreferences, error) {
+	var preferences domain.UserPreferences
+	var layout []byte
+
+	err := r.conn.QueryRow(`
+		SELECT default_period, favorite_accounts, currency_format, dashboard_layout, updated_at
+		FROM user_preferences
+		WHERE user_id = $1`, userID).Scan(
+		&preferences.DefaultPeriod,
+		pq.Array(&preferences.FavoriteAccounts),
+		&preferences.CurrencyFormat,
+		&layout,
+		&preferences.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar preferências do usuário: %w", err)
+	}
+
+	if preferences.FavoriteAccounts == nil {
+		preferences.FavoriteAccounts = []string{}
+	}
+	if len(layout) > 0 {
+		preferences.DashboardLayout = layout
+	}
+
+	return &preferences, nil
+}
+
+// SaveUserPreferences cria ou substitui as preferências do usuário
+func (r *preferencesRepository) SaveUserPreferences(userID int, preferences *domain.UserPreferences) error {
+	var layout interface{}
+	if len(preferences.DashboardLayout) > 0 && string(preferences.DashboardLayout) != "null" {
+		layout = []byte(preferences.DashboardLayout)
+	}
+
+	err := r.conn.QueryRow(`
+		INSERT INTO user_preferences (user_id, default_period, favorite_accounts, currency_format, dashboard_layout)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_period = EXCLUDED.default_period,
+			favorite_accounts = EXCLUDED.favorite_accounts,
+			currency_format = EXCLUDED.currency_format,
+			dashboard_layout = EXCLUDED.dashboard_layout
+		RETURNING updated_at`,
+		userID,
+		preferences.DefaultPeriod,
+		pq.Array(preferences.FavoriteAccounts),
+		preferences.CurrencyFormat,
+		layout,
+	).Scan(&preferences.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar preferências do usuário: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	privacyErasureRequestsTable = "privacy_erasure_requests"
+)
+
+type PrivacyRepository interface {
+	CreateErasureRecord(record *domain.ErasureRecord) (*domain.ErasureRecord, error)
+	CompleteErasureRecord(record *domain.ErasureRecord) error
+	ListErasureRecords(limit int) ([]*domain.ErasureRecord, error)
+	AnonymizeUsersByEmail(email string) (int, error)
+}
+
+type privacyRepository struct {
+	conn *postgres.Connection
+}
+
+func NewPrivacyRepository(conn *postgres.Connection) PrivacyRepository {
+	return &privacyRepository{
+		conn: conn,
+	}
+}
+
+func (r *privacyRepository) CreateErasureRecord(record *domain.ErasureRecord) (*domain.ErasureRecord, error) {
+	query := squirrel.
+		Insert(privacyErasureRequestsTable).
+		Columns("subject_hash", "requested_by", "reason", "status").
+		Values(record.SubjectHash, record.RequestedBy, record.Reason, record.Status).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(sqlQuery, args...).Scan(&record.ID, &record.CreatedAt); err != nil {
+		return nil, fmt.Errorf("erro ao registrar pedido de eliminação: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *privacyRepository) CompleteErasureRecord(record *domain.ErasureRecord) error {
+	details, err := json.Marshal(record.Details)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar detalhes: %w", err)
+	}
+
+	query := squirrel.
+		Update(privacyErasureRequestsTable).
+		Set("status", record.Status).
+		Set("records_anonymized", record.RecordsAnonymized).
+		Set("details", details).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": record.ID}).
+		Suffix("RETURNING completed_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(sqlQuery, args...).Scan(&record.CompletedAt); err != nil {
+		return fmt.Errorf("erro ao atualizar pedido de eliminação: %w", err)
+	}
+
+	return nil
+}
+
+func (r *privacyRepository) ListErasureRecords(limit int) ([]*domain.ErasureRecord, error) {
+	query := squirrel.
+		Select("id", "subject_hash", "requested_by", "reason", "status", "records_anonymized", "details", "created_at", "completed_at").
+		From(privacyErasureRequestsTable).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar pedidos de eliminação: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*domain.ErasureRecord, 0)
+	for rows.Next() {
+		var record domain.ErasureRecord
+		var reason sql.NullString
+		var details []byte
+		if err := rows.Scan(
+			&record.ID,
+			&record.SubjectHash,
+			&record.RequestedBy,
+			&reason,
+			&record.Status,
+			&record.RecordsAnonymized,
+			&details,
+			&record.CreatedAt,
+			&record.CompletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+
+		record.Reason = reason.String
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &record.Details); err != nil {
+				return nil, fmt.Errorf("erro ao decodificar detalhes: %w", err)
+			}
+		}
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return records, nil
+}
+
+// AnonymizeUsersByEmail substitui os dados pessoais dos usuários com o e-mail informado e os desativa.
+// O ID é mantido para preservar vínculos e registros históricos.
+func (r *privacyRepository) AnonymizeUsersByEmail(email string) (int, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		UPDATE users SET
+			name = 'Titular',
+			lastname = 'Anonimizado',
+			email = 'anonimizado-' || id || '@anonimizado.invalid',
+			password_hash = '!',
+			avatar_url = NULL,
+			active = false,
+			deleted = true,
+			deleted_at = COALESCE(deleted_at, NOW())
+		WHERE lower(email) = $1
+		RETURNING id`, strings.ToLower(email))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao anonimizar usuários: %w", err)
+	}
+
+	var userIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	for _, id := range userIDs {
+		if _, err := tx.Exec("DELETE FROM password_history WHERE user_id = $1", id); err != nil {
+			return 0, fmt.Errorf("erro ao remover histórico de senhas: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return len(userIDs), nil
+}
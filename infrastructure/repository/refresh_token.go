@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	refreshTokensTable = "refresh_tokens"
+)
+
+var refreshTokenColumns = []string{
+	"id", "user_id", "token_hash", "session_id", "session_started_at", "user_agent", "ip_address",
+	"expires_at", "revoked_at", "created_at",
+}
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(token *domain.RefreshToken) error
+	// GetRefreshTokenByHash retorna o token ou nil se não existir
+	GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error)
+	// RevokeRefreshToken revoga o token e informa se ele ainda estava ativo. Duas trocas simultâneas do
+	// mesmo token não são possíveis: só uma delas o encontra ativo.
+	RevokeRefreshToken(id int) (bool, error)
+	RevokeUserRefreshTokens(userID int) error
+	// ListActiveRefreshTokens retorna os tokens vigentes do usuário, um por sessão ativa
+	ListActiveRefreshTokens(userID int) ([]*domain.RefreshToken, error)
+	// RevokeSessionRefreshTokens revoga os tokens vigentes da sessão e informa se ela estava ativa
+	RevokeSessionRefreshTokens(userID int, sessionID string) (bool, error)
+}
+
+type refreshTokenRepository struct {
+	conn *postgres.Connection
+}
+
+func NewRefreshTokenRepository(conn *postgres.Connection) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		conn: conn,
+	}
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	query, args, err := squirrel.
+		Insert(refreshTokensTable).
+		Columns("user_id", "token_hash", "session_id", "session_started_at", "user_agent", "ip_address", "expires_at").
+		Values(token.UserID, token.TokenHash, token.SessionID, token.SessionStartedAt, token.UserAgent, token.IPAddress, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := r.conn.QueryRow(query, args...).Scan(&token.ID, &token.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao salvar refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	query, args, err := squirrel.
+		Select(refreshTokenColumns...).
+		From(refreshTokensTable).
+		Where(squirrel.Eq{"token_hash": tokenHash}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	token, err := scanRefreshToken(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *refreshTokenRepository) RevokeRefreshToken(id int) (bool, error) {
+	query, args, err := squirrel.
+		Update(refreshTokensTable).
+		Set("revoked_at", time.Now()).
+		Where(squirrel.Eq{"id": id, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao revogar refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar revogação do refresh token: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeUserRefreshTokens(userID int) error {
+	query, args, err := squirrel.
+		Update(refreshTokensTable).
+		Set("revoked_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao revogar refresh tokens do usuário: %w", err)
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) ListActiveRefreshTokens(userID int) ([]*domain.RefreshToken, error) {
+	query, args, err := squirrel.
+		Select(refreshTokenColumns...).
+		From(refreshTokensTable).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil}).
+		Where(squirrel.Gt{"expires_at": time.Now()}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar refresh tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*domain.RefreshToken{}
+	for rows.Next() {
+		token, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *refreshTokenRepository) RevokeSessionRefreshTokens(userID int, sessionID string) (bool, error) {
+	query, args, err := squirrel.
+		Update(refreshTokensTable).
+		Set("revoked_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "session_id": sessionID, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao encerrar sessão: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar encerramento da sessão: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func scanRefreshToken(row rowScanner) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.SessionID,
+		&token.SessionStartedAt,
+		&token.UserAgent,
+		&token.IPAddress,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler refresh token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	reportSchedulesTable = "report_schedules"
+)
+
+var reportScheduleColumns = []string{
+	"id", "report_id", "user_id", "frequency", "weekday", "day_of_month", "cron_schedule", "hour", "channel",
+	"destination", "secret", "active", "next_run_at", "last_run_at", "last_error", "created_at", "updated_at",
+}
+
+type ReportScheduleRepository interface {
+	CreateSchedule(schedule *domain.ReportSchedule) (*domain.ReportSchedule, error)
+	ListSchedulesByReport(userID, reportID int) ([]*domain.ReportSchedule, error)
+	DeleteSchedule(userID, reportID, scheduleID int) error
+	ListDueSchedules(now time.Time, limit int) ([]*domain.ReportSchedule, error)
+	MarkScheduleRun(scheduleID int, runAt, nextRunAt time.Time, runErr *string) error
+	DeactivateSchedule(scheduleID int, reason string) error
+}
+
+type reportScheduleRepository struct {
+	conn *postgres.Connection
+}
+
+func NewReportScheduleRepository(conn *postgres.Connection) ReportScheduleRepository {
+	return &reportScheduleRepository{
+		conn: conn,
+	}
+}
+
+func (r *reportScheduleRepository) CreateSchedule(schedule *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+	var secret *string
+	if schedule.Secret != "" {
+		secret = &schedule.Secret
+	}
+
+	query := squirrel.
+		Insert(reportSchedulesTable).
+		Columns("report_id", "user_id", "frequency", "weekday", "day_of_month", "cron_schedule", "hour", "channel", "destination", "secret", "active", "next_run_at").
+		Values(schedule.ReportID, schedule.UserID, schedule.Frequency, schedule.Weekday, schedule.DayOfMonth, schedule.CronSchedule, schedule.Hour,
+			schedule.Channel, schedule.Destination, secret, schedule.Active, schedule.NextRunAt).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&schedule.ID, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar agendamento de relatório: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// ListSchedulesByReport lista os agendamentos de um relatório do usuário, sem o segredo
+func (r *reportScheduleRepository) ListSchedulesByReport(userID, reportID int) ([]*domain.ReportSchedule, error) {
+	schedules, err := r.list(squirrel.Eq{"user_id": userID, "report_id": reportID}, "id ASC", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range schedules {
+		schedule.Secret = ""
+	}
+	return schedules, nil
+}
+
+func (r *reportScheduleRepository) DeleteSchedule(userID, reportID, scheduleID int) error {
+	query := squirrel.
+		Delete(reportSchedulesTable).
+		Where(squirrel.Eq{"id": scheduleID, "report_id": reportID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao remover agendamento de relatório: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar remoção do agendamento: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListDueSchedules retorna os agendamentos ativos com envio vencido, incluindo o segredo
+func (r *reportScheduleRepository) ListDueSchedules(now time.Time, limit int) ([]*domain.ReportSchedule, error) {
+	return r.list(squirrel.And{
+		squirrel.Eq{"active": true},
+		squirrel.LtOrEq{"next_run_at": now},
+	}, "next_run_at ASC", limit)
+}
+
+// MarkScheduleRun registra a execução e agenda o próximo envio. runErr nil limpa o último erro.
+func (r *reportScheduleRepository) MarkScheduleRun(scheduleID int, runAt, nextRunAt time.Time, runErr *string) error {
+	query := squirrel.
+		Update(reportSchedulesTable).
+		Set("last_run_at", runAt).
+		Set("next_run_at", nextRunAt).
+		Set("last_error", runErr).
+		Where(squirrel.Eq{"id": scheduleID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao registrar execução do agendamento: %w", err)
+	}
+
+	return nil
+}
+
+// DeactivateSchedule desativa um agendamento que não pode mais ser executado (ex: usuário removido)
+func (r *reportScheduleRepository) DeactivateSchedule(scheduleID int, reason string) error {
+	query := squirrel.
+		Update(reportSchedulesTable).
+		Set("active", false).
+		Set("last_error", reason).
+		Where(squirrel.Eq{"id": scheduleID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao desativar agendamento: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reportScheduleRepository) list(where squirrel.Sqlizer, orderBy string, limit int) ([]*domain.ReportSchedule, error) {
+	query := squirrel.
+		Select(reportScheduleColumns...).
+		From(reportSchedulesTable).
+		Where(where).
+		OrderBy(orderBy).
+		PlaceholderFormat(squirrel.Dollar)
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar agendamentos de relatório: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*domain.ReportSchedule{}
+	for rows.Next() {
+		var schedule domain.ReportSchedule
+		var secret sql.NullString
+		if err := rows.Scan(
+			&schedule.ID,
+			&schedule.ReportID,
+			&schedule.UserID,
+			&schedule.Frequency,
+			&schedule.Weekday,
+			&schedule.DayOfMonth,
+			&schedule.CronSchedule,
+			&schedule.Hour,
+			&schedule.Channel,
+			&schedule.Destination,
+			&secret,
+			&schedule.Active,
+			&schedule.NextRunAt,
+			&schedule.LastRunAt,
+			&schedule.LastError,
+			&schedule.CreatedAt,
+			&schedule.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler agendamento de relatório: %w", err)
+		}
+		schedule.Secret = secret.String
+		schedules = append(schedules, &schedule)
+	}
+
+	return schedules, rows.Err()
+}
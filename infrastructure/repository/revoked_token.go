@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+)
+
+const (
+	revokedTokensTable        = "revoked_tokens"
+	userTokenRevocationsTable = "user_token_revocations"
+)
+
+type RevokedTokenRepository interface {
+	// RevokeToken revoga o token de acesso pelo jti até sua expiração. Revogações já expiradas são
+	// removidas na mesma operação.
+	RevokeToken(jti string, userID int, expiresAt time.Time) error
+	// RevokeUserTokens revoga todos os tokens de acesso do usuário emitidos antes de revokedBefore
+	RevokeUserTokens(userID int, revokedBefore time.Time) error
+	// IsTokenRevoked informa se o token foi revogado pelo jti, pelo encerramento da sessão (session_id
+	// registrado como jti) ou pela revogação de todos os tokens do usuário
+	IsTokenRevoked(jti, sessionID string, userID int, issuedAt time.Time) (bool, error)
+}
+
+type revokedTokenRepository struct {
+	conn *postgres.Connection
+}
+
+func NewRevokedTokenRepository(conn *postgres.Connection) RevokedTokenRepository {
+	return &revokedTokenRepository{
+		conn: conn,
+	}
+}
+
+func (r *revokedTokenRepository) RevokeToken(jti string, userID int, expiresAt time.Time) error {
+	query, args, err := squirrel.
+		Insert(revokedTokensTable).
+		Columns("jti", "user_id", "expires_at").
+		Values(jti, userID, expiresAt).
+		Suffix("ON CONFLICT (jti) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao revogar token: %w", err)
+	}
+
+	cleanupQuery, args, err := squirrel.
+		Delete(revokedTokensTable).
+		Where(squirrel.Lt{"expires_at": time.Now()}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(cleanupQuery, args...); err != nil {
+		return fmt.Errorf("erro ao remover tokens revogados expirados: %w", err)
+	}
+
+	return nil
+}
+
+func (r *revokedTokenRepository) RevokeUserTokens(userID int, revokedBefore time.Time) error {
+	query, args, err := squirrel.
+		Insert(userTokenRevocationsTable).
+		Columns("user_id", "revoked_before").
+		Values(userID, revokedBefore).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET revoked_before = GREATEST(" + userTokenRevocationsTable + ".revoked_before, EXCLUDED.revoked_before)").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao revogar tokens do usuário: %w", err)
+	}
+
+	return nil
+}
+
+func (r *revokedTokenRepository) IsTokenRevoked(jti, sessionID string, userID int, issuedAt time.Time) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM ` + revokedTokensTable + ` WHERE jti IN ($1, $2))
+		OR EXISTS (SELECT 1 FROM ` + userTokenRevocationsTable + ` WHERE user_id = $3 AND revoked_before > $4)`
+
+	var revoked bool
+	if err := r.conn.QueryRow(query, jti, sessionID, userID, issuedAt).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("erro ao verificar revogação do token: %w", err)
+	}
+
+	return revoked, nil
+}
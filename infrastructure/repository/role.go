@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	rolesTable            = "roles"
+	roleMaskedFieldsTable = "role_masked_fields"
+	rolePermissionsTable  = "role_permissions"
+)
+
+// ErrRoleExists indica que já existe um perfil com o mesmo nome
+var ErrRoleExists = errors.New("já existe um perfil com este nome")
+
+var roleColumns = []string{
+	"r.id", "r.name", "r.description", "r.created_at", "r.updated_at",
+	"ARRAY(SELECT permission FROM " + rolePermissionsTable + " rp WHERE rp.role_id = r.id ORDER BY permission)",
+	"(SELECT COUNT(*) FROM " + usersTable + " u WHERE u.role_id = r.id AND NOT COALESCE(u.deleted, FALSE))",
+}
+
+type RoleRepository interface {
+	GetMaskedFields() (map[int][]string, error)
+	GetRolePermissions() (map[int][]string, error)
+	ListRoles() ([]*domain.Role, error)
+	// GetRoleByID retorna nil quando o perfil não existe
+	GetRoleByID(id int) (*domain.Role, error)
+	// CreateRole grava o perfil com suas permissões e preenche ID e datas
+	CreateRole(role *domain.Role) error
+	// UpdateRole substitui nome, descrição e permissões do perfil. Retorna false se o perfil não existe.
+	UpdateRole(role *domain.Role) (bool, error)
+	// DeleteRole exclui o perfil se nenhum usuário o utiliza. Retorna false se o perfil não existe ou está em uso.
+	DeleteRole(id int) (bool, error)
+}
+
+type roleRepository struct {
+	conn *postgres.Connection
+}
+
+func NewRoleRepository(conn *postgres.Connection) RoleRepository {
+	return &roleRepository{
+		conn: conn,
+	}
+}
+
+// GetMaskedFields retorna os campos ocultados das respostas, agrupados por perfil
+func (r *roleRepository) GetMaskedFields() (map[int][]string, error) {
+	query := squirrel.
+		Select("role_id", "field").
+		From(roleMaskedFieldsTable).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar campos mascarados: %w", err)
+	}
+	defer rows.Close()
+
+	fields := make(map[int][]string)
+	for rows.Next() {
+		var roleID int
+		var field string
+		if err := rows.Scan(&roleID, &field); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		fields[roleID] = append(fields[roleID], field)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return fields, nil
+}
+
+// GetRolePermissions retorna as permissões agrupadas por perfil
+func (r *roleRepository) GetRolePermissions() (map[int][]string, error) {
+	query := squirrel.
+		Select("role_id", "permission").
+		From(rolePermissionsTable).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar permissões dos perfis: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := make(map[int][]string)
+	for rows.Next() {
+		var roleID int
+		var permission string
+		if err := rows.Scan(&roleID, &permission); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		permissions[roleID] = append(permissions[roleID], permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (r *roleRepository) ListRoles() ([]*domain.Role, error) {
+	query, args, err := squirrel.
+		Select(roleColumns...).
+		From(rolesTable + " r").
+		OrderBy("r.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar perfis: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*domain.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) GetRoleByID(id int) (*domain.Role, error) {
+	query, args, err := squirrel.
+		Select(roleColumns...).
+		From(rolesTable + " r").
+		Where(squirrel.Eq{"r.id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	role, err := scanRole(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r *roleRepository) CreateRole(role *domain.Role) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := squirrel.
+		Insert(rolesTable).
+		Columns("name", "description").
+		Values(role.Name, role.Description).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = tx.QueryRow(query, args...).Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrRoleExists
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao criar perfil: %w", err)
+	}
+
+	if err := insertRolePermissions(tx, role.ID, role.Permissions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+func (r *roleRepository) UpdateRole(role *domain.Role) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := squirrel.
+		Update(rolesTable).
+		Set("name", role.Name).
+		Set("description", role.Description).
+		Where(squirrel.Eq{"id": role.ID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := tx.Exec(query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return false, ErrRoleExists
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar perfil: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar atualização do perfil: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	deleteSQL, deleteArgs, err := squirrel.
+		Delete(rolePermissionsTable).
+		Where(squirrel.Eq{"role_id": role.ID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(deleteSQL, deleteArgs...); err != nil {
+		return false, fmt.Errorf("erro ao remover permissões do perfil: %w", err)
+	}
+
+	if err := insertRolePermissions(tx, role.ID, role.Permissions); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *roleRepository) DeleteRole(id int) (bool, error) {
+	query, args, err := squirrel.
+		Delete(rolesTable).
+		Where(squirrel.Eq{"id": id}).
+		Where("NOT EXISTS (SELECT 1 FROM "+usersTable+" WHERE role_id = ? AND NOT COALESCE(deleted, FALSE))", id).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao excluir perfil: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar exclusão do perfil: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func insertRolePermissions(tx *sql.Tx, roleID int, permissions []domain.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	insert := squirrel.
+		Insert(rolePermissionsTable).
+		Columns("role_id", "permission").
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar)
+	for _, permission := range permissions {
+		insert = insert.Values(roleID, string(permission))
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao gravar permissões do perfil: %w", err)
+	}
+
+	return nil
+}
+
+func scanRole(row rowScanner) (*domain.Role, error) {
+	var role domain.Role
+	var permissions []string
+	err := row.Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+		pq.Array(&permissions),
+		&role.UserCount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler perfil: %w", err)
+	}
+
+	role.Permissions = make([]domain.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, domain.Permission(permission))
+	}
+
+	return &role, nil
+}
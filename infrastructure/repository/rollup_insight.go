@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	rollupInsightsTable = "rollup_insights"
+)
+
+var rollupInsightColumns = []string{"id", "account_id", "granularity", "period", "start_date", "end_date", "months", "ad_metrics", "sales_metrics", "created_at", "updated_at"}
+
+type RollupInsightRepository interface {
+	SaveOrUpdate(entry *domain.RollupInsightEntry) error
+	// GetByRange aceita o ID interno ou o external_id da conta e retorna os períodos que começam no intervalo
+	GetByRange(accountID, granularity string, startDate, endDate time.Time) ([]*domain.RollupInsightEntry, error)
+}
+
+type rollupInsightRepository struct {
+	conn *postgres.Connection
+}
+
+func NewRollupInsightRepository(conn *postgres.Connection) RollupInsightRepository {
+	return &rollupInsightRepository{
+		conn: conn,
+	}
+}
+
+func (r *rollupInsightRepository) SaveOrUpdate(entry *domain.RollupInsightEntry) error {
+	var adMetricsJSON, salesMetricsJSON []byte
+	var err error
+
+	if entry.AdMetrics != nil {
+		adMetricsJSON, err = json.Marshal(entry.AdMetrics)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar AdMetrics para JSON: %w", err)
+		}
+	}
+	if entry.SalesMetrics != nil {
+		salesMetricsJSON, err = json.Marshal(entry.SalesMetrics)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar SalesMetrics para JSON: %w", err)
+		}
+	}
+
+	query := squirrel.
+		Insert(rollupInsightsTable).
+		Columns("account_id", "granularity", "period", "start_date", "end_date", "months", "ad_metrics", "sales_metrics").
+		Values(
+			entry.AccountID,
+			entry.Granularity,
+			entry.Period,
+			entry.StartDate.Format(time.DateOnly),
+			entry.EndDate.Format(time.DateOnly),
+			entry.Months,
+			adMetricsJSON,
+			salesMetricsJSON,
+		).
+		Suffix(`
+			ON CONFLICT (account_id, granularity, period) DO UPDATE SET
+				months = EXCLUDED.months,
+				ad_metrics = EXCLUDED.ad_metrics,
+				sales_metrics = EXCLUDED.sales_metrics
+		`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao salvar insight consolidado: %w", err)
+	}
+
+	return nil
+}
+
+func (r *rollupInsightRepository) GetByRange(accountID, granularity string, startDate, endDate time.Time) ([]*domain.RollupInsightEntry, error) {
+	query := squirrel.
+		Select(rollupInsightColumns...).
+		From(rollupInsightsTable).
+		Where(accountScope(accountID)).
+		Where(squirrel.Eq{"granularity": granularity}).
+		Where("start_date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		OrderBy("start_date ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar insights consolidados: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.RollupInsightEntry, 0)
+	for rows.Next() {
+		var entry domain.RollupInsightEntry
+		var adMetricsJSON, salesMetricsJSON []byte
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.AccountID,
+			&entry.Granularity,
+			&entry.Period,
+			&entry.StartDate,
+			&entry.EndDate,
+			&entry.Months,
+			&adMetricsJSON,
+			&salesMetricsJSON,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear insight consolidado: %w", err)
+		}
+
+		if adMetricsJSON != nil {
+			if err := json.Unmarshal(adMetricsJSON, &entry.AdMetrics); err != nil {
+				return nil, fmt.Errorf("erro ao deserializar JSON de ad_metrics: %w", err)
+			}
+		}
+		if salesMetricsJSON != nil {
+			if err := json.Unmarshal(salesMetricsJSON, &entry.SalesMetrics); err != nil {
+				return nil, fmt.Errorf("erro ao deserializar JSON de sales_metrics: %w", err)
+			}
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -21,6 +21,8 @@ type SalesInsightRepository interface {
 	SaveOrUpdate(insight *domain.SalesInsightEntry) error
 	DeleteOlderThan(days int) (int64, error)
 	GetByDateRange(accountID string, startDate, endDate time.Time) ([]*domain.SalesInsightEntry, error)
+	// GetLastSyncedAt retorna quando os insights da conta foram gravados pela última vez, ou nil se não houver
+	GetLastSyncedAt(accountID string) (*time.Time, error)
 }
 
 type salesInsightRepository struct {
@@ -137,6 +139,28 @@ func (r *salesInsightRepository) SaveOrUpdate(insight *domain.SalesInsightEntry)
 	return nil
 }
 
+func (r *salesInsightRepository) GetLastSyncedAt(accountID string) (*time.Time, error) {
+	query, args, err := squirrel.
+		Select("MAX(updated_at)").
+		From("sales_insights").
+		Where(squirrel.Eq{"account_id": accountID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir a query: %w", err)
+	}
+
+	var lastSyncedAt sql.NullTime
+	if err := r.conn.QueryRow(query, args...).Scan(&lastSyncedAt); err != nil {
+		return nil, fmt.Errorf("erro ao buscar última sincronização de insights de vendas: %w", err)
+	}
+	if !lastSyncedAt.Valid {
+		return nil, nil
+	}
+
+	return &lastSyncedAt.Time, nil
+}
+
 func (r *salesInsightRepository) DeleteOlderThan(days int) (int64, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -days).Format(time.DateOnly)
 
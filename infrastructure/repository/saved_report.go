@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	savedReportsTable = "saved_reports"
+)
+
+// ErrSavedReportExists indica que o usuário já possui um relatório com o mesmo nome
+var ErrSavedReportExists = errors.New("já existe um relatório salvo com este nome")
+
+var savedReportColumns = []string{"id", "user_id", "name", "account_ids", "period_preset", "metrics", "breakdowns", "comparison", "created_at", "updated_at"}
+
+type SavedReportRepository interface {
+	CreateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error)
+	ListSavedReports(userID int) ([]*domain.SavedReport, error)
+	GetSavedReport(userID, reportID int) (*domain.SavedReport, error)
+	// UpdateSavedReport substitui a definição do relatório do usuário; retorna sql.ErrNoRows se ele não existir
+	UpdateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error)
+	DeleteSavedReport(userID, reportID int) error
+}
+
+type savedReportRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSavedReportRepository(conn *postgres.Connection) SavedReportRepository {
+	return &savedReportRepository{
+		conn: conn,
+	}
+}
+
+func (r *savedReportRepository) CreateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error) {
+	query := squirrel.
+		Insert(savedReportsTable).
+		Columns("user_id", "name", "account_ids", "period_preset", "metrics", "breakdowns", "comparison").
+		Values(report.UserID, report.Name, pq.Array(report.AccountIDs), report.PeriodPreset, pq.Array(report.Metrics), pq.Array(report.Breakdowns), report.Comparison).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&report.ID, &report.CreatedAt, &report.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, ErrSavedReportExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar relatório: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *savedReportRepository) ListSavedReports(userID int) ([]*domain.SavedReport, error) {
+	query := squirrel.
+		Select(savedReportColumns...).
+		From(savedReportsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("name ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar relatórios salvos: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []*domain.SavedReport{}
+	for rows.Next() {
+		report, err := scanSavedReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// GetSavedReport retorna o relatório do usuário ou nil se não existir
+func (r *savedReportRepository) GetSavedReport(userID, reportID int) (*domain.SavedReport, error) {
+	query := squirrel.
+		Select(savedReportColumns...).
+		From(savedReportsTable).
+		Where(squirrel.Eq{"id": reportID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	report, err := scanSavedReport(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *savedReportRepository) UpdateSavedReport(report *domain.SavedReport) (*domain.SavedReport, error) {
+	query := squirrel.
+		Update(savedReportsTable).
+		Set("name", report.Name).
+		Set("account_ids", pq.Array(report.AccountIDs)).
+		Set("period_preset", report.PeriodPreset).
+		Set("metrics", pq.Array(report.Metrics)).
+		Set("breakdowns", pq.Array(report.Breakdowns)).
+		Set("comparison", report.Comparison).
+		Where(squirrel.Eq{"id": report.ID, "user_id": report.UserID}).
+		Suffix("RETURNING created_at, updated_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&report.CreatedAt, &report.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, ErrSavedReportExists
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar relatório: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *savedReportRepository) DeleteSavedReport(userID, reportID int) error {
+	query := squirrel.
+		Delete(savedReportsTable).
+		Where(squirrel.Eq{"id": reportID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao remover relatório salvo: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar remoção do relatório: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSavedReport(row rowScanner) (*domain.SavedReport, error) {
+	var report domain.SavedReport
+	err := row.Scan(
+		&report.ID,
+		&report.UserID,
+		&report.Name,
+		pq.Array(&report.AccountIDs),
+		&report.PeriodPreset,
+		pq.Array(&report.Metrics),
+		pq.Array(&report.Breakdowns),
+		&report.Comparison,
+		&report.CreatedAt,
+		&report.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler relatório salvo: %w", err)
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+)
+
+// schedulerLockPrefix separa os advisory locks dos agendadores de outros usos de pg_advisory_lock
+const schedulerLockPrefix = "scheduler:"
+
+type SchedulerLockRepository interface {
+	// TryLock tenta obter, sem esperar, o lock do agendador entre as instâncias da API. O lock é um
+	// advisory lock do Postgres preso a uma conexão dedicada: unlock o libera e devolve a conexão ao pool.
+	// Se a instância cair, o Postgres libera o lock junto com a conexão.
+	TryLock(name string) (unlock func(), acquired bool, err error)
+}
+
+type schedulerLockRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSchedulerLockRepository(conn *postgres.Connection) SchedulerLockRepository {
+	return &schedulerLockRepository{
+		conn: conn,
+	}
+}
+
+func (r *schedulerLockRepository) TryLock(name string) (func(), bool, error) {
+	ctx := context.Background()
+
+	// O advisory lock pertence à sessão, então a mesma conexão precisa ser usada até a liberação
+	conn, err := r.conn.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao obter conexão para o lock do agendador: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", schedulerLockPrefix+name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("erro ao obter lock do agendador: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		defer conn.Close()
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", schedulerLockPrefix+name); err != nil {
+			logrus.WithError(err).WithField("scheduler", name).Error("Erro ao liberar lock do agendador, descartando a conexão")
+			// Descartar a conexão encerra a sessão e, com ela, o lock; devolvê-la ao pool manteria o lock preso
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}
+
+	return unlock, true, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const schedulerPausesTable = "scheduler_pauses"
+
+type SchedulerPauseRepository interface {
+	// PauseScheduler pausa o agendador; uma nova pausa substitui o autor e o motivo da anterior
+	PauseScheduler(state *domain.SchedulerState) error
+	// ResumeScheduler remove a pausa e informa se o agendador estava pausado
+	ResumeScheduler(scheduler string) (bool, error)
+	// GetPause retorna a pausa do agendador, ou nil se ele não estiver pausado
+	GetPause(scheduler string) (*domain.SchedulerState, error)
+	// ListPauses retorna os agendadores pausados
+	ListPauses() ([]*domain.SchedulerState, error)
+}
+
+type schedulerPauseRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSchedulerPauseRepository(conn *postgres.Connection) SchedulerPauseRepository {
+	return &schedulerPauseRepository{
+		conn: conn,
+	}
+}
+
+func (r *schedulerPauseRepository) PauseScheduler(state *domain.SchedulerState) error {
+	var reason *string
+	if state.Reason != "" {
+		reason = &state.Reason
+	}
+
+	query, args, err := squirrel.
+		Insert(schedulerPausesTable).
+		Columns("scheduler", "paused_by", "reason", "paused_at").
+		Values(state.Scheduler, state.PausedBy, reason, state.PausedAt).
+		Suffix("ON CONFLICT (scheduler) DO UPDATE SET paused_by = EXCLUDED.paused_by, reason = EXCLUDED.reason, paused_at = EXCLUDED.paused_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao pausar agendador: %w", err)
+	}
+
+	return nil
+}
+
+func (r *schedulerPauseRepository) ResumeScheduler(scheduler string) (bool, error) {
+	query, args, err := squirrel.
+		Delete(schedulerPausesTable).
+		Where(squirrel.Eq{"scheduler": scheduler}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("erro ao retomar agendador: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao retomar agendador: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *schedulerPauseRepository) GetPause(scheduler string) (*domain.SchedulerState, error) {
+	query, args, err := squirrel.
+		Select("scheduler", "paused_by", "COALESCE(reason, '')", "paused_at").
+		From(schedulerPausesTable).
+		Where(squirrel.Eq{"scheduler": scheduler}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	state, err := scanSchedulerPause(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar pausa do agendador: %w", err)
+	}
+
+	return state, nil
+}
+
+func (r *schedulerPauseRepository) ListPauses() ([]*domain.SchedulerState, error) {
+	query, args, err := squirrel.
+		Select("scheduler", "paused_by", "COALESCE(reason, '')", "paused_at").
+		From(schedulerPausesTable).
+		OrderBy("scheduler").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar agendadores pausados: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([]*domain.SchedulerState, 0)
+	for rows.Next() {
+		state, err := scanSchedulerPause(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler agendador pausado: %w", err)
+		}
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
+}
+
+func scanSchedulerPause(row squirrel.RowScanner) (*domain.SchedulerState, error) {
+	state := &domain.SchedulerState{Paused: true}
+	var pausedBy sql.NullInt64
+	var pausedAt time.Time
+	if err := row.Scan(&state.Scheduler, &pausedBy, &state.Reason, &pausedAt); err != nil {
+		return nil, err
+	}
+	if pausedBy.Valid {
+		id := int(pausedBy.Int64)
+		state.PausedBy = &id
+	}
+	state.PausedAt = &pausedAt
+	return state, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	schedulerRunsTable = "scheduler_runs"
+)
+
+type SchedulerRunRepository interface {
+	// GetRun retorna nil quando o agendador ainda não tem execução registrada
+	GetRun(name string) (*domain.SchedulerRun, error)
+	MarkStarted(name string, at time.Time) error
+	MarkSucceeded(name string, at time.Time) error
+	// SaveCheckpoint grava o progresso de uma execução interrompida; nil remove o checkpoint
+	SaveCheckpoint(name string, checkpoint *domain.SyncCheckpoint) error
+	// SaveStats grava as estatísticas por provedor da última execução
+	SaveStats(name string, stats map[string]*domain.ProviderStats) error
+}
+
+type schedulerRunRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSchedulerRunRepository(conn *postgres.Connection) SchedulerRunRepository {
+	return &schedulerRunRepository{
+		conn: conn,
+	}
+}
+
+func (r *schedulerRunRepository) GetRun(name string) (*domain.SchedulerRun, error) {
+	query := squirrel.
+		Select("name", "last_started_at", "last_success_at", "checkpoint", "stats").
+		From(schedulerRunsTable).
+		Where(squirrel.Eq{"name": name}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var run domain.SchedulerRun
+	var startedAt, successAt sql.NullTime
+	var checkpointJSON, statsJSON []byte
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&run.Name, &startedAt, &successAt, &checkpointJSON, &statsJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar execução do agendador: %w", err)
+	}
+
+	if startedAt.Valid {
+		run.LastStartedAt = &startedAt.Time
+	}
+	if successAt.Valid {
+		run.LastSuccessAt = &successAt.Time
+	}
+	if len(checkpointJSON) > 0 {
+		run.Checkpoint = &domain.SyncCheckpoint{}
+		if err := json.Unmarshal(checkpointJSON, run.Checkpoint); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar checkpoint do agendador: %w", err)
+		}
+	}
+	if len(statsJSON) > 0 {
+		if err := json.Unmarshal(statsJSON, &run.Stats); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar estatísticas do agendador: %w", err)
+		}
+	}
+
+	return &run, nil
+}
+
+func (r *schedulerRunRepository) MarkStarted(name string, at time.Time) error {
+	return r.upsert(name, "last_started_at", at)
+}
+
+func (r *schedulerRunRepository) MarkSucceeded(name string, at time.Time) error {
+	return r.upsert(name, "last_success_at", at)
+}
+
+func (r *schedulerRunRepository) SaveCheckpoint(name string, checkpoint *domain.SyncCheckpoint) error {
+	if checkpoint == nil {
+		return r.upsert(name, "checkpoint", nil)
+	}
+
+	checkpointJSON, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar checkpoint do agendador: %w", err)
+	}
+
+	return r.upsert(name, "checkpoint", checkpointJSON)
+}
+
+func (r *schedulerRunRepository) SaveStats(name string, stats map[string]*domain.ProviderStats) error {
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar estatísticas do agendador: %w", err)
+	}
+
+	return r.upsert(name, "stats", statsJSON)
+}
+
+func (r *schedulerRunRepository) upsert(name, column string, value any) error {
+	query := squirrel.
+		Insert(schedulerRunsTable).
+		Columns("name", column).
+		Values(name, value).
+		Suffix(fmt.Sprintf("ON CONFLICT (name) DO UPDATE SET %s = EXCLUDED.%s", column, column)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao registrar execução do agendador: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// campaignSearchWindowDays limita a busca de campanhas aos nomes vistos nos insights recentes
+const campaignSearchWindowDays = 90
+
+type SearchRepository interface {
+	SearchAccounts(term string, scope domain.SearchScope) ([]*domain.SearchResult, error)
+	SearchCampaigns(term string, scope domain.SearchScope) ([]*domain.SearchResult, error)
+	SearchBusinessManagers(term string, scope domain.SearchScope) ([]*domain.SearchResult, error)
+	SearchUsers(term string, limit int) ([]*domain.SearchResult, error)
+}
+
+type searchRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSearchRepository(conn *postgres.Connection) SearchRepository {
+	return &searchRepository{
+		conn: conn,
+	}
+}
+
+func (r *searchRepository) SearchAccounts(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	pattern := likePattern(term)
+
+	matches := squirrel.Or{
+		squirrel.ILike{"a.name": pattern},
+		squirrel.ILike{"a.nickname": pattern},
+		squirrel.ILike{"a.external_id": pattern},
+	}
+	if digits := onlyDigits(term); len(digits) >= 3 {
+		matches = append(matches, squirrel.Like{"a.cnpj": likePattern(digits)})
+	}
+
+	query := squirrel.
+		Select("a.id", "COALESCE(NULLIF(a.nickname, ''), a.name)", "COALESCE(bm.name, '')").
+		From("accounts a").
+		LeftJoin("business_manager bm ON bm.id = a.business_id").
+		Where(matches).
+		OrderBy("COALESCE(NULLIF(a.nickname, ''), a.name)").
+		Limit(uint64(scope.Limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if !scope.Unrestricted {
+		query = query.Where("a.id IN (SELECT account_id FROM user_accounts WHERE user_id = ?)", scope.UserID)
+	}
+
+	return r.querySearch(query, domain.SearchTypeAccount)
+}
+
+// SearchCampaigns busca pelos nomes de campanha gravados em ad_insights, retornando a ocorrência mais recente de cada campanha
+func (r *searchRepository) SearchCampaigns(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	campaigns := squirrel.
+		Select("DISTINCT ON (c->>'campaign_id') c->>'campaign_id' AS id", "c->>'campaign_name' AS title",
+			"COALESCE(NULLIF(a.nickname, ''), a.name) AS subtitle", "a.id AS account_id").
+		From("ad_insights ai").
+		Join("accounts a ON a.id = ai.account_id").
+		JoinClause("CROSS JOIN LATERAL jsonb_array_elements(COALESCE(ai.ad_metrics->'ad_campaigns', '[]'::jsonb)) c").
+		Where(fmt.Sprintf("ai.date >= CURRENT_DATE - %d", campaignSearchWindowDays)).
+		Where("c->>'campaign_name' ILIKE ?", likePattern(term)).
+		OrderBy("c->>'campaign_id'", "ai.date DESC")
+
+	if !scope.Unrestricted {
+		campaigns = campaigns.Where("a.id IN (SELECT account_id FROM user_accounts WHERE user_id = ?)", scope.UserID)
+	}
+
+	query := squirrel.
+		Select("id", "title", "subtitle", "account_id").
+		FromSelect(campaigns, "campaigns").
+		OrderBy("title").
+		Limit(uint64(scope.Limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	return r.querySearch(query, domain.SearchTypeCampaign)
+}
+
+func (r *searchRepository) SearchBusinessManagers(term string, scope domain.SearchScope) ([]*domain.SearchResult, error) {
+	query := squirrel.
+		Select("bm.id", "bm.name", "bm.origin").
+		From("business_manager bm").
+		Where(squirrel.Or{
+			squirrel.ILike{"bm.name": likePattern(term)},
+			squirrel.ILike{"bm.external_id": likePattern(term)},
+		}).
+		OrderBy("bm.name").
+		Limit(uint64(scope.Limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if !scope.Unrestricted {
+		query = query.Where(`bm.id IN (
+			SELECT a.business_id FROM accounts a
+			JOIN user_accounts ua ON ua.account_id = a.id
+			WHERE ua.user_id = ?)`, scope.UserID)
+	}
+
+	return r.querySearch(query, domain.SearchTypeBusinessManager)
+}
+
+func (r *searchRepository) SearchUsers(term string, limit int) ([]*domain.SearchResult, error) {
+	pattern := likePattern(term)
+
+	query := squirrel.
+		Select("id::text", "name || ' ' || lastname", "email").
+		From("users").
+		Where(squirrel.Or{
+			squirrel.Expr("name || ' ' || lastname ILIKE ?", pattern),
+			squirrel.ILike{"email": pattern},
+		}).
+		Where(squirrel.Eq{"deleted": false}).
+		OrderBy("name", "lastname").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	return r.querySearch(query, domain.SearchTypeUser)
+}
+
+// querySearch executa uma consulta que retorna id, título, subtítulo e, opcionalmente, a conta relacionada
+func (r *searchRepository) querySearch(query squirrel.SelectBuilder, resultType string) ([]*domain.SearchResult, error) {
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar %s: %w", resultType, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler colunas da busca: %w", err)
+	}
+
+	results := make([]*domain.SearchResult, 0)
+	for rows.Next() {
+		result := domain.SearchResult{Type: resultType}
+		dest := []any{&result.ID, &result.Title, &result.Subtitle}
+		if len(columns) > 3 {
+			dest = append(dest, &result.AccountID)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("erro ao ler resultado da busca: %w", err)
+		}
+
+		result.ID = strings.TrimSpace(result.ID)
+		result.AccountID = strings.TrimSpace(result.AccountID)
+		results = append(results, &result)
+	}
+
+	return results, rows.Err()
+}
+
+// likePattern monta o padrão de busca por substring, escapando os curingas do LIKE
+func likePattern(term string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+	return "%" + escaped + "%"
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const syncFailuresTable = "sync_failures"
+
+var syncFailureColumns = []string{
+	"id", "source", "account_id", "date", "attempts", "failures", "error",
+	"first_failed_at", "last_failed_at", "requeued_at",
+}
+
+type SyncFailureRepository interface {
+	// SaveFailure registra a falha da data; uma nova falha da mesma data atualiza o erro e soma as execuções
+	SaveFailure(failure *domain.SyncFailure) error
+	// DeleteFailures remove as falhas das datas da conta que foram sincronizadas
+	DeleteFailures(source, accountID string, dates []time.Time) error
+	ListFailures(filters domain.SyncFailureFilters) ([]*domain.SyncFailure, int, error)
+	// GetFailure retorna a falha, ou nil se ela não existir
+	GetFailure(id int64) (*domain.SyncFailure, error)
+	// ListAccountFailureDates retorna as datas com falha da conta, da mais antiga para a mais recente
+	ListAccountFailureDates(source, accountID string) ([]time.Time, error)
+	// MarkRequeued registra a nova sincronização das falhas da conta
+	MarkRequeued(source, accountID string, requeuedAt time.Time) error
+}
+
+type syncFailureRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSyncFailureRepository(conn *postgres.Connection) SyncFailureRepository {
+	return &syncFailureRepository{
+		conn: conn,
+	}
+}
+
+func (r *syncFailureRepository) SaveFailure(failure *domain.SyncFailure) error {
+	query, args, err := squirrel.
+		Insert(syncFailuresTable).
+		Columns("source", "account_id", "date", "attempts", "error", "first_failed_at", "last_failed_at").
+		Values(failure.Source, failure.AccountID, failure.Date.Format(time.DateOnly), failure.Attempts, failure.Error,
+			failure.LastFailedAt, failure.LastFailedAt).
+		Suffix(`ON CONFLICT (source, account_id, date) DO UPDATE SET
+			attempts = EXCLUDED.attempts,
+			error = EXCLUDED.error,
+			failures = sync_failures.failures + 1,
+			last_failed_at = EXCLUDED.last_failed_at`).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao registrar falha de sincronização: %w", err)
+	}
+
+	return nil
+}
+
+func (r *syncFailureRepository) DeleteFailures(source, accountID string, dates []time.Time) error {
+	if len(dates) == 0 {
+		return nil
+	}
+
+	days := make([]string, len(dates))
+	for i, date := range dates {
+		days[i] = date.Format(time.DateOnly)
+	}
+
+	query, args, err := squirrel.
+		Delete(syncFailuresTable).
+		Where(squirrel.Eq{"source": source, "account_id": accountID, "date": days}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao remover falhas de sincronização: %w", err)
+	}
+
+	return nil
+}
+
+func (r *syncFailureRepository) ListFailures(filters domain.SyncFailureFilters) ([]*domain.SyncFailure, int, error) {
+	where := squirrel.Eq{}
+	if filters.Source != "" {
+		where["source"] = filters.Source
+	}
+	if filters.AccountID != "" {
+		where["account_id"] = filters.AccountID
+	}
+
+	countSQL, countArgs, err := squirrel.
+		Select("COUNT(*)").
+		From(syncFailuresTable).
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var total int
+	if err := r.conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar falhas de sincronização: %w", err)
+	}
+
+	query, args, err := squirrel.
+		Select(syncFailureColumns...).
+		From(syncFailuresTable).
+		Where(where).
+		OrderBy("date DESC", "account_id", "source").
+		Limit(uint64(filters.Limit)).
+		Offset(uint64(filters.Offset)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao consultar falhas de sincronização: %w", err)
+	}
+	defer rows.Close()
+
+	failures := make([]*domain.SyncFailure, 0, filters.Limit)
+	for rows.Next() {
+		failure, err := scanSyncFailure(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler falha de sincronização: %w", err)
+		}
+		failures = append(failures, failure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return failures, total, nil
+}
+
+func (r *syncFailureRepository) GetFailure(id int64) (*domain.SyncFailure, error) {
+	query, args, err := squirrel.
+		Select(syncFailureColumns...).
+		From(syncFailuresTable).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	failure, err := scanSyncFailure(r.conn.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar falha de sincronização: %w", err)
+	}
+
+	return failure, nil
+}
+
+func (r *syncFailureRepository) ListAccountFailureDates(source, accountID string) ([]time.Time, error) {
+	query, args, err := squirrel.
+		Select("date").
+		From(syncFailuresTable).
+		Where(squirrel.Eq{"source": source, "account_id": accountID}).
+		OrderBy("date").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar falhas de sincronização da conta: %w", err)
+	}
+	defer rows.Close()
+
+	dates := make([]time.Time, 0)
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("erro ao ler falha de sincronização: %w", err)
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, rows.Err()
+}
+
+func (r *syncFailureRepository) MarkRequeued(source, accountID string, requeuedAt time.Time) error {
+	query, args, err := squirrel.
+		Update(syncFailuresTable).
+		Set("requeued_at", requeuedAt).
+		Where(squirrel.Eq{"source": source, "account_id": accountID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(query, args...); err != nil {
+		return fmt.Errorf("erro ao registrar nova sincronização das falhas: %w", err)
+	}
+
+	return nil
+}
+
+func scanSyncFailure(row squirrel.RowScanner) (*domain.SyncFailure, error) {
+	failure := &domain.SyncFailure{}
+	var requeuedAt sql.NullTime
+	if err := row.Scan(&failure.ID, &failure.Source, &failure.AccountID, &failure.Date, &failure.Attempts,
+		&failure.Failures, &failure.Error, &failure.FirstFailedAt, &failure.LastFailedAt, &requeuedAt); err != nil {
+		return nil, err
+	}
+	if requeuedAt.Valid {
+		failure.RequeuedAt = &requeuedAt.Time
+	}
+	return failure, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	accountSyncSchedulesTable = "account_sync_schedules"
+)
+
+var syncScheduleColumns = []string{"account_id", "source", "cron_schedule", "lookback_days", "enabled", "last_run_at", "created_at", "updated_at"}
+
+type SyncScheduleRepository interface {
+	// ListAccountSchedules aceita o ID interno ou o external_id da conta
+	ListAccountSchedules(accountID string) ([]*domain.AccountSyncSchedule, error)
+	// ListEnabledSchedules retorna as agendas habilitadas da origem, usadas pelos agendadores
+	ListEnabledSchedules(source string) ([]*domain.AccountSyncSchedule, error)
+	// UpsertSchedule aceita o ID interno ou o external_id da conta e retorna sql.ErrNoRows se ela não existir
+	UpsertSchedule(schedule *domain.AccountSyncSchedule) (*domain.AccountSyncSchedule, error)
+	// DeleteSchedule retorna sql.ErrNoRows se a conta não tiver agenda para a origem
+	DeleteSchedule(accountID, source string) error
+	MarkScheduleRun(accountID, source string, at time.Time) error
+}
+
+type syncScheduleRepository struct {
+	conn *postgres.Connection
+}
+
+func NewSyncScheduleRepository(conn *postgres.Connection) SyncScheduleRepository {
+	return &syncScheduleRepository{
+		conn: conn,
+	}
+}
+
+func (r *syncScheduleRepository) ListAccountSchedules(accountID string) ([]*domain.AccountSyncSchedule, error) {
+	return r.list(squirrel.
+		Select(syncScheduleColumns...).
+		From(accountSyncSchedulesTable).
+		Where(accountScope(accountID)).
+		OrderBy("source ASC"))
+}
+
+func (r *syncScheduleRepository) ListEnabledSchedules(source string) ([]*domain.AccountSyncSchedule, error) {
+	return r.list(squirrel.
+		Select(syncScheduleColumns...).
+		From(accountSyncSchedulesTable).
+		Where(squirrel.Eq{"source": source, "enabled": true}).
+		OrderBy("account_id ASC"))
+}
+
+func (r *syncScheduleRepository) list(query squirrel.SelectBuilder) ([]*domain.AccountSyncSchedule, error) {
+	sqlQuery, args, err := query.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar agendas de sincronização: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*domain.AccountSyncSchedule{}
+	for rows.Next() {
+		schedule, err := scanSyncSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, rows.Err()
+}
+
+func (r *syncScheduleRepository) UpsertSchedule(schedule *domain.AccountSyncSchedule) (*domain.AccountSyncSchedule, error) {
+	account := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?", schedule.Source)).
+		Column(squirrel.Expr("?", schedule.CronSchedule)).
+		Column(squirrel.Expr("?::int", schedule.LookbackDays)).
+		Column(squirrel.Expr("?::boolean", schedule.Enabled)).
+		From("accounts").
+		Where("id = ? OR external_id = ?", schedule.AccountID, schedule.AccountID).
+		Limit(1)
+
+	query := squirrel.
+		Insert(accountSyncSchedulesTable).
+		Columns("account_id", "source", "cron_schedule", "lookback_days", "enabled").
+		Select(account).
+		Suffix(`
+			ON CONFLICT (account_id, source) DO UPDATE SET
+				cron_schedule = EXCLUDED.cron_schedule,
+				lookback_days = EXCLUDED.lookback_days,
+				enabled = EXCLUDED.enabled
+			RETURNING ` + strings.Join(syncScheduleColumns, ", ")).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	saved, err := scanSyncSchedule(r.conn.QueryRow(sqlQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar agenda de sincronização: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *syncScheduleRepository) DeleteSchedule(accountID, source string) error {
+	query := squirrel.
+		Delete(accountSyncSchedulesTable).
+		Where(squirrel.Eq{"source": source}).
+		Where(accountScope(accountID)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	result, err := r.conn.Exec(sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao remover agenda de sincronização: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao remover agenda de sincronização: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *syncScheduleRepository) MarkScheduleRun(accountID, source string, at time.Time) error {
+	query := squirrel.
+		Update(accountSyncSchedulesTable).
+		Set("last_run_at", at).
+		Where(squirrel.Eq{"account_id": accountID, "source": source}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sqlQuery, args...); err != nil {
+		return fmt.Errorf("erro ao registrar execução da agenda de sincronização: %w", err)
+	}
+
+	return nil
+}
+
+func scanSyncSchedule(row rowScanner) (*domain.AccountSyncSchedule, error) {
+	var schedule domain.AccountSyncSchedule
+	var lastRunAt sql.NullTime
+
+	if err := row.Scan(
+		&schedule.AccountID,
+		&schedule.Source,
+		&schedule.CronSchedule,
+		&schedule.LookbackDays,
+		&schedule.Enabled,
+		&lastRunAt,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastRunAt.Valid {
+		schedule.LastRunAt = &lastRunAt.Time
+	}
+
+	return &schedule, nil
+}
@@ -2,29 +2,52 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 )
 
 const (
-	usersTable        = "users"
-	userAccountsTable = "user_accounts"
+	usersTable           = "users"
+	userAccountsTable    = "user_accounts"
+	passwordHistoryTable = "password_history"
+	userActivityTable    = "user_activity"
 )
 
+// ErrUserEmailExists indica que o e-mail já foi cadastrado por outra requisição durante a importação
+var ErrUserEmailExists = errors.New("e-mail já cadastrado")
+
 type UserRepository interface {
 	CreateUser(user *domain.User) (*domain.User, error)
+	// ImportUsers grava os usuários e os vínculos com contas em uma única transação: com qualquer falha,
+	// nenhum usuário é criado. Preenche o ID de cada usuário.
+	ImportUsers(imports []*domain.UserImport) error
 	UpdateUser(user *domain.User) error
 	GetUserByEmail(email string) (*domain.User, error)
 	GetUserByID(userID int) (*domain.User, error)
 	ListUser() ([]*domain.User, error)
 	GetUserLinkedAccounts(userID int) ([]string, error)
-	LinkUserAccount(userID int, accountID string) error
+	GetUserAccountPermissions(userID int) (map[string]domain.AccountPermission, error)
+	GetUserAccountPermission(userID int, accountID string) (domain.AccountPermission, error)
+	LinkUserAccount(userID int, accountID string, permission domain.AccountPermission) error
 	UnlinkUserAccount(userID int, accountID string) error
+	// UnlinkAllUserAccounts remove todos os vínculos do usuário com contas e retorna as contas desvinculadas
+	UnlinkAllUserAccounts(userID int) ([]string, error)
+	// UpdatePassword grava a nova senha e a registra no histórico, mantendo só as últimas historySize
+	// (todas, se historySize <= 0)
+	UpdatePassword(userID int, passwordHash string, mustChange bool, historySize int) error
+	GetPasswordHistory(userID, limit int) ([]string, error)
+	UpdateAvatarURL(userID int, avatarURL string) error
+	// RecordLogin grava o login bem-sucedido, que também conta como última atividade
+	RecordLogin(userID int, at time.Time) error
+	// TouchLastSeen grava a última atividade do usuário, sem retroceder um valor mais recente
+	TouchLastSeen(userID int, at time.Time) error
 }
 
 type userRepository struct {
@@ -37,25 +60,99 @@ func NewUserRepository(conn *postgres.Connection) UserRepository {
 	}
 }
 
+// CreateUser grava o usuário e registra a senha inicial no histórico
 func (r *userRepository) CreateUser(user *domain.User) (*domain.User, error) {
-	queryBuilder := squirrel.
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertUser(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) ImportUsers(imports []*domain.UserImport) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range imports {
+		err := insertUser(tx, item.User)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return fmt.Errorf("%w: %s", ErrUserEmailExists, item.User.Email)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, accountID := range item.User.LinkedAccounts {
+			query, args, err := squirrel.
+				Insert(userAccountsTable).
+				Columns("user_id", "account_id", "permission").
+				Values(item.User.ID, accountID, item.AccountPermission).
+				Suffix("ON CONFLICT (user_id, account_id) DO NOTHING").
+				PlaceholderFormat(squirrel.Dollar).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("erro ao construir consulta: %w", err)
+			}
+
+			if _, err := tx.Exec(query, args...); err != nil {
+				return fmt.Errorf("erro ao vincular conta %s ao usuário %s: %w", accountID, item.User.Email, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+// insertUser grava o usuário na transação, preenche o ID e registra a senha inicial no histórico
+func insertUser(tx *sql.Tx, user *domain.User) error {
+	usersSQL, usersArgs, err := squirrel.
 		Insert(usersTable).
-		Columns("name", "lastname", "email", "password_hash", "active", "role_id").
-		Values(user.Name, user.Lastname, user.Email, user.PasswordHash, user.Active, user.RoleID).
+		Columns("name", "lastname", "email", "password_hash", "active", "role_id", "must_change_password", "email_verified_at").
+		Values(user.Name, user.Lastname, user.Email, user.PasswordHash, user.Active, user.RoleID, user.MustChangePassword, user.EmailVerifiedAt).
 		Suffix("RETURNING id").
-		PlaceholderFormat(squirrel.Dollar)
-
-	usersSQL, usersArgs, err := queryBuilder.ToSql()
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if err := tx.QueryRow(usersSQL, usersArgs...).Scan(&user.ID); err != nil {
+		return fmt.Errorf("erro ao criar usuário: %w", err)
 	}
 
-	err = r.conn.QueryRow(usersSQL, usersArgs...).Scan(&user.ID)
+	historySQL, historyArgs, err := squirrel.
+		Insert(passwordHistoryTable).
+		Columns("user_id", "password_hash").
+		Values(user.ID, user.PasswordHash).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("erro ao construir consulta: %w", err)
 	}
 
-	return user, nil
+	if _, err := tx.Exec(historySQL, historyArgs...); err != nil {
+		return fmt.Errorf("erro ao registrar histórico de senha: %w", err)
+	}
+
+	return nil
 }
 
 func (r *userRepository) UpdateUser(user *domain.User) error {
@@ -111,7 +208,7 @@ func (r *userRepository) UpdateUser(user *domain.User) error {
 
 func (r *userRepository) GetUserByEmail(email string) (*domain.User, error) {
 	var user domain.User
-	err := r.conn.QueryRow("SELECT id, name, lastname, email, password_hash, active, role_id, avatar_url, created_at, updated_at FROM users WHERE email = $1", email).Scan(
+	err := r.conn.QueryRow("SELECT id, name, lastname, email, password_hash, active, role_id, avatar_url, created_at, updated_at, password_changed_at, must_change_password, email_verified_at FROM users WHERE email = $1", email).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Lastname,
@@ -122,6 +219,9 @@ func (r *userRepository) GetUserByEmail(email string) (*domain.User, error) {
 		&user.AvatarURL,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
+		&user.EmailVerifiedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -129,6 +229,7 @@ func (r *userRepository) GetUserByEmail(email string) (*domain.User, error) {
 	if err != nil {
 		return nil, err
 	}
+	user.EmailVerified = user.EmailVerifiedAt != nil
 
 	// Buscar contas vinculadas
 	linkedAccounts, err := r.GetUserLinkedAccounts(user.ID)
@@ -144,7 +245,7 @@ func (r *userRepository) GetUserByEmail(email string) (*domain.User, error) {
 
 func (r *userRepository) GetUserByID(userID int) (*domain.User, error) {
 	var user domain.User
-	err := r.conn.QueryRow("SELECT id, name, lastname, email, password_hash, active, role_id, avatar_url, created_at, updated_at FROM users WHERE deleted = false AND id = $1", userID).Scan(
+	err := r.conn.QueryRow("SELECT id, name, lastname, email, password_hash, active, role_id, avatar_url, created_at, updated_at, password_changed_at, must_change_password, email_verified_at FROM users WHERE deleted = false AND id = $1", userID).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Lastname,
@@ -155,10 +256,14 @@ func (r *userRepository) GetUserByID(userID int) (*domain.User, error) {
 		&user.AvatarURL,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
+		&user.EmailVerifiedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	user.EmailVerified = user.EmailVerifiedAt != nil
 
 	// Buscar contas vinculadas
 	linkedAccounts, err := r.GetUserLinkedAccounts(user.ID)
@@ -174,10 +279,12 @@ func (r *userRepository) GetUserByID(userID int) (*domain.User, error) {
 
 func (r *userRepository) ListUser() ([]*domain.User, error) {
 	queryBuilder := squirrel.
-		Select("id", "name", "lastname", "email", "active", "role_id", "avatar_url", "created_at", "updated_at").
-		From(usersTable).
-		Where(squirrel.Eq{"deleted": false}).
-		OrderBy("name ASC").
+		Select("u.id", "u.name", "u.lastname", "u.email", "u.active", "u.role_id", "u.avatar_url", "u.created_at", "u.updated_at",
+			"u.email_verified_at", "a.last_login_at", "a.last_seen_at").
+		From(usersTable + " u").
+		LeftJoin(userActivityTable + " a ON a.user_id = u.id").
+		Where(squirrel.Eq{"u.deleted": false}).
+		OrderBy("u.name ASC").
 		PlaceholderFormat(squirrel.Dollar)
 
 	usersSQL, usersArgs, err := queryBuilder.ToSql()
@@ -204,9 +311,13 @@ func (r *userRepository) ListUser() ([]*domain.User, error) {
 			&user.AvatarURL,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.EmailVerifiedAt,
+			&user.LastLoginAt,
+			&user.LastSeenAt,
 		); err != nil {
 			return nil, err
 		}
+		user.EmailVerified = user.EmailVerifiedAt != nil
 
 		// Buscar contas vinculadas
 		linkedAccounts, err := r.GetUserLinkedAccounts(user.ID)
@@ -261,12 +372,81 @@ func (r *userRepository) GetUserLinkedAccounts(userID int) ([]string, error) {
 	return linkedAccounts, nil
 }
 
-func (r *userRepository) LinkUserAccount(userID int, accountID string) error {
+// GetUserAccountPermissions retorna o nível de acesso do usuário em cada conta vinculada
+func (r *userRepository) GetUserAccountPermissions(userID int) (map[string]domain.AccountPermission, error) {
+	query := squirrel.
+		Select("account_id", "permission").
+		From(userAccountsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar permissões das contas: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := make(map[string]domain.AccountPermission)
+	for rows.Next() {
+		var accountID string
+		var permission domain.AccountPermission
+		if err := rows.Scan(&accountID, &permission); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		permissions[accountID] = permission
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetUserAccountPermission retorna o nível de acesso do usuário na conta, identificada pelo ID interno
+// ou pelo ID externo (Meta). Vazio se a conta não estiver vinculada.
+func (r *userRepository) GetUserAccountPermission(userID int, accountID string) (domain.AccountPermission, error) {
+	sqlQuery, args, err := userAccountPermissionQuery(userID, accountID).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	var permission domain.AccountPermission
+	err = r.conn.QueryRow(sqlQuery, args...).Scan(&permission)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao consultar permissão da conta: %w", err)
+	}
+
+	return permission, nil
+}
+
+// userAccountPermissionQuery busca o vínculo pela conta com o ID interno ou o external_id informado
+func userAccountPermissionQuery(userID int, accountID string) squirrel.SelectBuilder {
+	return squirrel.
+		Select("ua.permission").
+		From(userAccountsTable + " ua").
+		Join("accounts a ON a.id = ua.account_id").
+		Where(squirrel.Eq{"ua.user_id": userID}).
+		Where(squirrel.Or{squirrel.Eq{"a.id": accountID}, squirrel.Eq{"a.external_id": accountID}}).
+		Limit(1).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+// LinkUserAccount vincula a conta ao usuário ou atualiza o nível de acesso de um vínculo existente
+func (r *userRepository) LinkUserAccount(userID int, accountID string, permission domain.AccountPermission) error {
 	query := squirrel.
 		Insert(userAccountsTable).
-		Columns("user_id", "account_id").
-		Values(userID, accountID).
-		Suffix("ON CONFLICT (user_id, account_id) DO NOTHING").
+		Columns("user_id", "account_id", "permission").
+		Values(userID, accountID, permission).
+		Suffix("ON CONFLICT (user_id, account_id) DO UPDATE SET permission = EXCLUDED.permission").
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
@@ -300,3 +480,164 @@ func (r *userRepository) UnlinkUserAccount(userID int, accountID string) error {
 
 	return nil
 }
+
+func (r *userRepository) UnlinkAllUserAccounts(userID int) ([]string, error) {
+	query, args, err := squirrel.
+		Delete(userAccountsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		Suffix("RETURNING account_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao desvincular contas do usuário: %w", err)
+	}
+	defer rows.Close()
+
+	accountIDs := []string{}
+	for rows.Next() {
+		var accountID string
+		if err := rows.Scan(&accountID); err != nil {
+			return nil, fmt.Errorf("erro ao ler conta desvinculada: %w", err)
+		}
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	return accountIDs, rows.Err()
+}
+
+// UpdateAvatarURL grava o endereço do avatar do usuário
+func (r *userRepository) UpdateAvatarURL(userID int, avatarURL string) error {
+	result, err := r.conn.Exec("UPDATE users SET avatar_url = $1 WHERE id = $2 AND deleted = false", avatarURL, userID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar avatar do usuário: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar atualização do avatar: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdatePassword grava a nova senha, registra o hash no histórico e reinicia a contagem de expiração
+func (r *userRepository) RecordLogin(userID int, at time.Time) error {
+	query := `INSERT INTO ` + userActivityTable + ` (user_id, last_login_at, last_seen_at) VALUES ($1, $2, $2)
+		ON CONFLICT (user_id) DO UPDATE SET last_login_at = EXCLUDED.last_login_at,
+			last_seen_at = GREATEST(` + userActivityTable + `.last_seen_at, EXCLUDED.last_seen_at)`
+
+	if _, err := r.conn.Exec(query, userID, at); err != nil {
+		return fmt.Errorf("erro ao registrar login do usuário: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) TouchLastSeen(userID int, at time.Time) error {
+	query := `INSERT INTO ` + userActivityTable + ` (user_id, last_seen_at) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET last_seen_at = GREATEST(` + userActivityTable + `.last_seen_at, EXCLUDED.last_seen_at)`
+
+	if _, err := r.conn.Exec(query, userID, at); err != nil {
+		return fmt.Errorf("erro ao registrar atividade do usuário: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(userID int, passwordHash string, mustChange bool, historySize int) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	updateSQL, updateArgs, err := squirrel.
+		Update(usersTable).
+		Set("password_hash", passwordHash).
+		Set("password_changed_at", squirrel.Expr("NOW()")).
+		Set("must_change_password", mustChange).
+		Where(squirrel.Eq{"id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(updateSQL, updateArgs...); err != nil {
+		return fmt.Errorf("erro ao atualizar senha: %w", err)
+	}
+
+	insertSQL, insertArgs, err := squirrel.
+		Insert(passwordHistoryTable).
+		Columns("user_id", "password_hash").
+		Values(userID, passwordHash).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := tx.Exec(insertSQL, insertArgs...); err != nil {
+		return fmt.Errorf("erro ao registrar histórico de senha: %w", err)
+	}
+
+	// Senhas além do tamanho do histórico não são mais verificadas e podem ser descartadas
+	if historySize > 0 {
+		trimSQL := `DELETE FROM ` + passwordHistoryTable + ` WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM ` + passwordHistoryTable + ` WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2)`
+		if _, err := tx.Exec(trimSQL, userID, historySize); err != nil {
+			return fmt.Errorf("erro ao limpar histórico de senhas: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+// GetPasswordHistory retorna os hashes das últimas senhas do usuário, da mais recente para a mais antiga
+func (r *userRepository) GetPasswordHistory(userID, limit int) ([]string, error) {
+	query := squirrel.
+		Select("password_hash").
+		From(passwordHistoryTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar histórico de senhas: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAccountPermissionQuery(t *testing.T) {
+	for _, accountID := range []string{"AAA111", "act_9876543"} {
+		t.Run(accountID, func(t *testing.T) {
+			sqlQuery, args, err := userAccountPermissionQuery(7, accountID).ToSql()
+			assert.NoError(t, err)
+			assert.Equal(t,
+				"SELECT ua.permission FROM user_accounts ua JOIN accounts a ON a.id = ua.account_id "+
+					"WHERE ua.user_id = $1 AND (a.id = $2 OR a.external_id = $3) LIMIT 1",
+				sqlQuery)
+			// O mesmo valor é comparado às duas colunas, então o vínculo é encontrado por qualquer um dos IDs
+			assert.Equal(t, []any{7, accountID, accountID}, args)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/database/postgres"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	webhookSubscriptionsTable = "webhook_subscriptions"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	ListSubscriptions() ([]*domain.WebhookSubscription, error)
+	ListActiveSubscriptionsByEvent(eventType string) ([]*domain.WebhookSubscription, error)
+	DeleteSubscription(id int) error
+}
+
+type webhookRepository struct {
+	conn *postgres.Connection
+}
+
+func NewWebhookRepository(conn *postgres.Connection) WebhookRepository {
+	return &webhookRepository{
+		conn: conn,
+	}
+}
+
+func (r *webhookRepository) CreateSubscription(subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	query := squirrel.
+		Insert(webhookSubscriptionsTable).
+		Columns("url", "secret", "events", "active").
+		Values(subscription.URL, subscription.Secret, pq.Array(subscription.Events), subscription.Active).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	err = r.conn.QueryRow(sql, args...).Scan(&subscription.ID, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar assinatura de webhook: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptions retorna todas as assinaturas, sem o segredo
+func (r *webhookRepository) ListSubscriptions() ([]*domain.WebhookSubscription, error) {
+	query := squirrel.
+		Select("id", "url", "events", "active", "created_at", "updated_at").
+		From(webhookSubscriptionsTable).
+		OrderBy("id ASC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar assinaturas de webhook: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*domain.WebhookSubscription, 0)
+	for rows.Next() {
+		var subscription domain.WebhookSubscription
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			pq.Array(&subscription.Events),
+			&subscription.Active,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// ListActiveSubscriptionsByEvent retorna as assinaturas ativas do evento, incluindo o segredo para assinatura
+func (r *webhookRepository) ListActiveSubscriptionsByEvent(eventType string) ([]*domain.WebhookSubscription, error) {
+	query := squirrel.
+		Select("id", "url", "secret", "events").
+		From(webhookSubscriptionsTable).
+		Where(squirrel.Eq{"active": true}).
+		Where("? = ANY(events)", eventType).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	rows, err := r.conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar assinaturas de webhook: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.WebhookSubscription
+	for rows.Next() {
+		subscription := domain.WebhookSubscription{Active: true}
+		if err := rows.Scan(&subscription.ID, &subscription.URL, &subscription.Secret, pq.Array(&subscription.Events)); err != nil {
+			return nil, fmt.Errorf("erro ao processar resultado: %w", err)
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro durante iteração: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) DeleteSubscription(id int) error {
+	query := squirrel.
+		Delete(webhookSubscriptionsTable).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("erro ao construir consulta: %w", err)
+	}
+
+	if _, err := r.conn.Exec(sql, args...); err != nil {
+		return fmt.Errorf("erro ao remover assinatura de webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+// LocalPublicPath é o caminho em que a API serve os arquivos do armazenamento local
+const LocalPublicPath = "/uploads/"
+
+// LocalStorage grava os arquivos em um diretório do servidor, servidos pela própria API em LocalPublicPath
+// ou por um servidor estático/CDN configurado em STORAGE_LOCAL_PUBLIC_URL. Indicado para desenvolvimento
+// e instalações com uma única instância.
+type LocalStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStorage(cfg config.Storage) (*LocalStorage, error) {
+	if cfg.LocalDir == "" {
+		return nil, ErrNotConfigured
+	}
+
+	dir, err := filepath.Abs(cfg.LocalDir)
+	if err != nil {
+		return nil, fmt.Errorf("STORAGE_LOCAL_DIR inválido: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar STORAGE_LOCAL_DIR: %w", err)
+	}
+
+	publicURL := strings.TrimSuffix(cfg.LocalPublicURL, "/")
+	if publicURL == "" {
+		publicURL = strings.TrimSuffix(LocalPublicPath, "/")
+	}
+
+	return &LocalStorage{
+		dir:       dir,
+		publicURL: publicURL,
+	}, nil
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, body []byte, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("erro ao criar diretório do arquivo: %w", err)
+	}
+
+	// Grava em um arquivo temporário e renomeia, para nunca servir um arquivo pela metade
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("erro ao remover arquivo: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.publicURL + "/" + strings.TrimPrefix(key, "/")
+}
+
+// Handler serve os arquivos gravados, sem listagem de diretórios. Deve ser montado em LocalPublicPath.
+func (s *LocalStorage) Handler() http.Handler {
+	files := http.StripPrefix(strings.TrimSuffix(LocalPublicPath, "/"), http.FileServer(http.Dir(s.dir)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// path resolve a chave dentro do diretório, rejeitando chaves que escapem dele (ex: ../)
+func (s *LocalStorage) path(key string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("chave de arquivo inválida: %s", key)
+	}
+	return path, nil
+}
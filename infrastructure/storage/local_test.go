@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(config.Storage{LocalDir: dir})
+	require.NoError(t, err)
+
+	t.Run("grava, publica e remove o arquivo", func(t *testing.T) {
+		require.NoError(t, s.Put(context.Background(), "avatars/1/abc.jpg", []byte("jpeg"), "image/jpeg"))
+		assert.Equal(t, "/uploads/avatars/1/abc.jpg", s.URL("avatars/1/abc.jpg"))
+
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/uploads/avatars/1/abc.jpg", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "jpeg", rec.Body.String())
+
+		require.NoError(t, s.Delete(context.Background(), "avatars/1/abc.jpg"))
+		_, err := os.Stat(filepath.Join(dir, "avatars", "1", "abc.jpg"))
+		assert.True(t, os.IsNotExist(err))
+		assert.NoError(t, s.Delete(context.Background(), "avatars/1/abc.jpg"))
+	})
+
+	t.Run("rejeita chaves fora do diretório", func(t *testing.T) {
+		assert.Error(t, s.Put(context.Background(), "../fora.jpg", []byte("x"), "image/jpeg"))
+		assert.Error(t, s.Delete(context.Background(), "avatars/../../fora.jpg"))
+	})
+
+	t.Run("não lista diretórios", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/uploads/avatars/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestNewLocalStorage_NotConfigured(t *testing.T) {
+	_, err := NewLocalStorage(config.Storage{})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+}
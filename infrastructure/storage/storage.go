@@ -0,0 +1,16 @@
+// Package storage contém os backends de armazenamento de arquivos enviados pelos usuários
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNotConfigured = errors.New("armazenamento de arquivos não configurado")
+
+// Storage grava arquivos e gera a URL pública de acesso
+type Storage interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
@@ -17,6 +17,7 @@ const (
 	ErrExpiredToken          = "AUTH_007" // Token expirado
 	ErrInsufficientPrivilege = "AUTH_008" // Privilégios insuficientes
 	ErrUserAlreadyExists     = "AUTH_009" // Usuário já existe
+	ErrEmailNotVerified      = "AUTH_013" // E-mail ainda não confirmado
 	// Erros de validação (2000-2999)
 	ErrInvalidRequest      = "VAL_001" // Requisição inválida
 	ErrMissingRequiredData = "VAL_002" // Dados obrigatórios ausentes
@@ -39,6 +40,7 @@ var httpStatusMap = map[string]int{
 	ErrInvalidToken:          http.StatusUnauthorized,
 	ErrExpiredToken:          http.StatusUnauthorized,
 	ErrInsufficientPrivilege: http.StatusForbidden,
+	ErrEmailNotVerified:      http.StatusForbidden,
 	ErrInvalidRequest:        http.StatusBadRequest,
 	ErrMissingRequiredData:   http.StatusBadRequest,
 	ErrInvalidFormat:         http.StatusBadRequest,
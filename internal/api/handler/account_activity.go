@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// GetAccountActivity retorna a linha do tempo da conta (sincronizações, edições, anomalias e notas).
+// Aceita os filtros type (lista separada por vírgula), before (RFC3339) e limit.
+func GetAccountActivity(service activity.ActivityService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		var filters domain.AccountActivityFilters
+		if types := query.Get("type"); types != "" {
+			filters.Types = strings.Split(types, ",")
+		}
+
+		if before := query.Get("before"); before != "" {
+			parsed, err := time.Parse(time.RFC3339, before)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro before deve estar no formato RFC3339", nil)
+				return
+			}
+			filters.Before = &parsed
+		}
+
+		if limit := query.Get("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro limit inválido", nil)
+				return
+			}
+			filters.Limit = parsed
+		}
+
+		activities, err := service.ListActivities(accountID, filters)
+		if err != nil {
+			if errors.Is(err, activity.ErrInvalidFilter) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+					"available_types": domain.AccountActivityTypes,
+				})
+				return
+			}
+			logger.WithError(err).Error("account activity: erro ao listar atividades")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar atividades da conta", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(activities); err != nil {
+			logger.WithError(err).Error("account activity: erro ao enviar resposta")
+		}
+	}
+}
+
+// CreateAccountNote adiciona uma nota do usuário autenticado à linha do tempo da conta
+func CreateAccountNote(service activity.ActivityService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.CreateAccountNoteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		note, err := service.AddNote(accountID, userClaims.UserID, &req)
+		if err != nil {
+			switch {
+			case errors.Is(err, activity.ErrInvalidNote):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			case errors.Is(err, activity.ErrAccountNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+			default:
+				logger.WithError(err).Error("account activity: erro ao salvar nota")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao salvar nota", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(note); err != nil {
+			logger.WithError(err).Error("account activity: erro ao enviar resposta")
+		}
+	}
+}
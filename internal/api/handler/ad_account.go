@@ -4,30 +4,49 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/account"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
 	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
 )
 
+// AdAccountList lista as contas paginadas. Aceita os filtros status (lista separada por vírgula),
+// business_id, search (apelido, nome ou CNPJ), limit e offset.
 func AdAccountList(service account.AccountService) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		filterStatus := r.URL.Query().Get("status")
+		query := r.URL.Query()
 
-		var availableStatusList []string
-		availableStatus := make([]domain.AdAccountStatus, 0)
-		if filterStatus != "" {
-			availableStatusList = strings.Split(filterStatus, ",")
+		filters := &domain.AccountListFilters{
+			BusinessManagerID: query.Get("business_id"),
+			Search:            query.Get("search"),
+		}
+
+		if filterStatus := query.Get("status"); filterStatus != "" {
+			for _, status := range strings.Split(filterStatus, ",") {
+				filters.Status = append(filters.Status, domain.AdAccountStatus(status))
+			}
+		}
 
-			for _, status := range availableStatusList {
-				availableStatus = append(availableStatus, domain.AdAccountStatus(status))
+		for param, target := range map[string]*int{"limit": &filters.Limit, "offset": &filters.Offset} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" inválido", nil)
+				return
 			}
+			*target = parsed
 		}
 
-		adAccounts, err := service.ListAdAccounts(availableStatus)
+		adAccounts, err := service.ListAdAccounts(filters)
 		if err != nil {
 			logrus.Error("Error listing accounts:", err)
 
@@ -55,11 +74,55 @@ func AdAccountList(service account.AccountService) http.Handler {
 	})
 }
 
+// SearchAdAccounts busca contas pelo apelido, nome ou CNPJ (q) e retorna até limit contas, das mais
+// parecidas com o termo para as menos parecidas. Usuários sem perfil administrativo veem apenas suas contas.
+func SearchAdAccounts(service account.AccountService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		query := r.URL.Query()
+		scope := domain.SearchScope{
+			UserID:       userClaims.UserID,
+			Unrestricted: hasUnrestrictedAccountAccess(r.Context()),
+		}
+
+		if limit := query.Get("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro limit inválido", nil)
+				return
+			}
+			scope.Limit = parsed
+		}
+
+		response, err := service.SearchAdAccounts(query.Get("q"), scope)
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), nil)
+				return
+			}
+			logrus.WithError(err).Error("Erro ao buscar contas")
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao buscar contas", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
 func SyncAccounts(service account.AccountService) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("INIT - SyncAccounts")
 
-		resp, err := service.SyncAccounts()
+		resp, err := service.SyncAccounts(actorIDFromRequest(r))
 		if err != nil {
 			logrus.Error("Error syncing accounts:", err)
 
@@ -96,7 +159,7 @@ func SyncAccounts(service account.AccountService) http.Handler {
 }
 
 // TODO talvez adicionar qual usuário está modificando a conta a partir do token
-func UpdateAdAccount(service account.AccountService) http.Handler {
+func UpdateAdAccount(service account.AccountService, recorder activity.Recorder) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("INIT - UpdateAdAccount")
 
@@ -119,8 +182,15 @@ func UpdateAdAccount(service account.AccountService) http.Handler {
 		// Garante que o ID da URL seja usado
 		updateRequest.ID = id
 
+		// CNPJ, token/secret e status exigem permissão de manager; analyst altera apenas o apelido
+		sensitive := updateRequest.CNPJ != nil || updateRequest.SecretName != nil || updateRequest.Token != nil || updateRequest.Status != nil
+		if sensitive && !middleware.AccountPermissionFromContext(r.Context()).Allows(domain.AccountPermissionManager) {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas usuários com permissão de manager podem alterar CNPJ, token ou status da conta", nil)
+			return
+		}
+
 		// Atualiza a conta
-		resp, err := service.UpdateAccount(&updateRequest)
+		resp, err := service.UpdateAccount(&updateRequest, actorIDFromRequest(r))
 		if err != nil {
 			logrus.Error("Error updating account:", err)
 
@@ -163,8 +233,242 @@ func UpdateAdAccount(service account.AccountService) http.Handler {
 			return
 		}
 
+		if userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims); ok {
+			recordAccountEdit(recorder, id, userClaims.UserID, &updateRequest)
+		}
+
 		if err := json.NewEncoder(w).Encode(resp); err != nil {
 			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
 		}
 	})
 }
+
+// recordAccountEdit registra na linha do tempo os campos alterados, sem os valores de token e secret
+func recordAccountEdit(recorder activity.Recorder, accountID string, userID int, req *domain.UpdateAdAccountRequest) {
+	fields := make([]string, 0, 7)
+	details := map[string]any{}
+
+	if req.Nickname != nil {
+		fields = append(fields, "nickname")
+		details["nickname"] = *req.Nickname
+	}
+	if req.CNPJ != nil {
+		fields = append(fields, "cnpj")
+	}
+	if req.SecretName != nil {
+		fields = append(fields, "secret_name")
+	}
+	if req.Token != nil {
+		fields = append(fields, "token")
+	}
+	if req.Status != nil {
+		fields = append(fields, "status")
+		details["status"] = *req.Status
+	}
+	if req.Currency != nil {
+		fields = append(fields, "currency")
+		details["currency"] = *req.Currency
+	}
+	if req.Locale != nil {
+		fields = append(fields, "locale")
+		details["locale"] = *req.Locale
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+	details["fields"] = fields
+
+	recorder.Record(accountID, domain.AccountActivityEdit, "Conta editada: "+strings.Join(fields, ", "), details, &userID)
+}
+
+// MergeAdAccounts mescla uma conta duplicada na conta que permanece e registra o evento nas duas linhas do tempo
+func MergeAdAccounts(service account.AccountService, recorder activity.Recorder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		var mergeRequest domain.MergeAccountsRequest
+		if err := json.NewDecoder(r.Body).Decode(&mergeRequest); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Corpo da requisição inválido: "+err.Error(), nil)
+			return
+		}
+
+		merge, err := service.MergeAccounts(&mergeRequest, userClaims.UserID)
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), map[string]interface{}{
+					"account_id": accountErr.AccountID,
+					"error_type": accountErr.Err.Error(),
+				})
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro interno ao mesclar contas", nil)
+			return
+		}
+
+		recorder.Record(merge.TargetID, domain.AccountActivityEdit, "Conta "+merge.SourceID+" mesclada nesta conta", merge, &userClaims.UserID)
+		recorder.Record(merge.SourceID, domain.AccountActivityEdit, "Conta mesclada em "+merge.TargetID, merge, &userClaims.UserID)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(merge); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// ListAccountMerges retorna o histórico de mesclagens, filtrado opcionalmente por ?account_id=
+func ListAccountMerges(service account.AccountService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		merges, err := service.ListAccountMerges(r.URL.Query().Get("account_id"))
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), nil)
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao listar mesclagens de contas", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(merges); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// ListBusinessManagers retorna o diretório de business managers com métricas dos últimos 30 dias e situação das sincronizações
+func ListBusinessManagers(service account.AccountService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		directory, err := service.ListBusinessManagers()
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), nil)
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao listar business managers", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(directory); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// GeocodeAdAccount define a localização da loja da conta, geocodificando o endereço quando as coordenadas não são informadas
+func GeocodeAdAccount(service account.AccountService, recorder activity.Recorder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		var request domain.GeocodeAccountRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Corpo da requisição inválido: "+err.Error(), nil)
+			return
+		}
+
+		location, err := service.GeocodeAccount(id, &request)
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), map[string]interface{}{
+					"account_id": accountErr.AccountID,
+					"error_type": accountErr.Err.Error(),
+				})
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro interno ao definir localização da conta", nil)
+			return
+		}
+
+		recorder.Record(id, domain.AccountActivityEdit, "Localização da loja definida: "+location.City+"/"+location.State, location, &userClaims.UserID)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(location); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// SetAdAccountPriority marca ou desmarca a conta como prioritária (VIP) na sincronização agendada
+func SetAdAccountPriority(service account.AccountService, recorder activity.Recorder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		var request domain.AccountPriorityRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Corpo da requisição inválido: "+err.Error(), nil)
+			return
+		}
+
+		resp, err := service.SetAccountVIP(id, &request)
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), map[string]interface{}{
+					"account_id": accountErr.AccountID,
+					"error_type": accountErr.Err.Error(),
+				})
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro interno ao definir prioridade da conta", nil)
+			return
+		}
+
+		description := "Conta removida das prioritárias na sincronização"
+		if resp.VIP {
+			description = "Conta marcada como prioritária (VIP) na sincronização"
+		}
+		recorder.Record(id, domain.AccountActivityEdit, description, request, &userClaims.UserID)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// GetInsightsMap agrega investimento e faturamento em redes sociais por UF e cidade no período (?period=, padrão last_30_days)
+func GetInsightsMap(service account.AccountService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		period := r.URL.Query().Get("period")
+		if period == "" {
+			period = domain.PeriodLast30Days
+		}
+
+		insightsMap, err := service.GetInsightsMap(period)
+		if err != nil {
+			var accountErr *account.AccountError
+			if errors.As(err, &accountErr) {
+				apiErrors.WriteError(w, accountErr.Code, accountErr.Error(), nil)
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao agregar insights por localização", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(insightsMap); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
 	"github.com/vfg2006/traffic-manager-api/pkg/log"
 	"github.com/vfg2006/traffic-manager-api/pkg/utils"
 )
@@ -43,15 +45,28 @@ func GetAdAccountsByID(service insighting.CombinedInsighter) http.Handler {
 			return
 		}
 
+		aggregation := r.URL.Query().Get("aggregation")
+		if aggregation != "" && !domain.IsValidAggregation(aggregation) {
+			logger.WithFields(log.Fields{
+				"account_id":  id,
+				"aggregation": aggregation,
+			}).Warn("insights: invalid aggregation parameter")
+
+			http.Error(w, "agregação inválida: use daily, weekly, monthly ou quarterly", http.StatusBadRequest)
+			return
+		}
+
 		filters := &domain.InsigthFilters{
-			StartDate: startDate,
-			EndDate:   endDate,
+			StartDate:   startDate,
+			EndDate:     endDate,
+			Aggregation: aggregation,
 		}
 
 		logger.WithFields(log.Fields{
-			"account_id": id,
-			"start_date": startDate.Format(time.DateOnly),
-			"end_date":   endDate.Format(time.DateOnly),
+			"account_id":  id,
+			"start_date":  startDate.Format(time.DateOnly),
+			"end_date":    endDate.Format(time.DateOnly),
+			"aggregation": aggregation,
 		}).Debug("insights: fetching insights with filters")
 
 		insights, err := service.GetAdAccountsByID(id, filters)
@@ -155,3 +170,118 @@ func GetAdAccountReachImpressions(service insighting.CombinedInsighter) http.Han
 		}
 	})
 }
+
+// GetAdAccountInsightsByDimension agrupa as campanhas da conta por uma dimensão do nome (ex: produto)
+func GetAdAccountInsightsByDimension(service insighting.CombinedInsighter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		dimension := query.Get("dimension")
+		if dimension == "" || query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros dimension, start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		response, err := service.GetAdAccountInsightsByDimension(id, dimension, &domain.InsigthFilters{
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			if errors.Is(err, insighting.ErrUnknownDimension) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+
+			logger.WithFields(log.Fields{
+				"account_id": id,
+				"dimension":  dimension,
+				"error":      err.Error(),
+			}).Error("insights: failed to group insights by dimension")
+
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao agrupar insights da conta", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.WithError(err).Error("insights: failed to encode response")
+		}
+	})
+}
+
+// CompareAdAccountInsights compara os insights do período com o período anterior equivalente
+// (comparison=previous_period, padrão) ou com o mesmo período do ano anterior (comparison=previous_year)
+func CompareAdAccountInsights(service insighting.CombinedInsighter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		if startDate.After(*endDate) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "A data de início não pode ser posterior à data de fim", nil)
+			return
+		}
+
+		comparison := query.Get("comparison")
+		if comparison == "" {
+			comparison = domain.ComparisonPreviousPeriod
+		}
+		if comparison != domain.ComparisonPreviousPeriod && comparison != domain.ComparisonPreviousYear {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro comparison deve ser previous_period ou previous_year", nil)
+			return
+		}
+
+		response, err := service.CompareAdAccountInsights(id, comparison, &domain.InsigthFilters{
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"account_id": id,
+				"comparison": comparison,
+				"error":      err.Error(),
+			}).Error("insights: failed to compare insights")
+
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao comparar insights da conta", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.WithError(err).Error("insights: failed to encode response")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/annotating"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// ListAnnotations lista as anotações da conta entre start_date e end_date
+func ListAnnotations(service annotating.AnnotationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		query := r.URL.Query()
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		annotations, err := service.ListAnnotations(accountID, *startDate, *endDate)
+		if err != nil {
+			writeAnnotationError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(annotations); err != nil {
+			logger.WithError(err).Error("annotation: erro ao enviar resposta")
+		}
+	}
+}
+
+// CreateAnnotation registra uma anotação em uma data da conta
+func CreateAnnotation(service annotating.AnnotationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.AnnotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		annotation, err := service.CreateAnnotation(accountID, userClaims.UserID, &req)
+		if err != nil {
+			writeAnnotationError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(annotation); err != nil {
+			logger.WithError(err).Error("annotation: erro ao enviar resposta")
+		}
+	}
+}
+
+// UpdateAnnotation altera data, tipo e texto de uma anotação
+func UpdateAnnotation(service annotating.AnnotationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID, annotationID, ok := annotationParams(w, r)
+		if !ok {
+			return
+		}
+
+		var req domain.AnnotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		annotation, err := service.UpdateAnnotation(accountID, annotationID, &req)
+		if err != nil {
+			writeAnnotationError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(annotation); err != nil {
+			logger.WithError(err).Error("annotation: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteAnnotation remove uma anotação da conta
+func DeleteAnnotation(service annotating.AnnotationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, annotationID, ok := annotationParams(w, r)
+		if !ok {
+			return
+		}
+
+		if err := service.DeleteAnnotation(accountID, annotationID); err != nil {
+			writeAnnotationError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func annotationParams(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	annotationID, err := strconv.Atoi(params.ByName("annotation_id"))
+	if err != nil {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID da anotação inválido", nil)
+		return "", 0, false
+	}
+
+	return params.ByName("id"), annotationID, true
+}
+
+func writeAnnotationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, annotating.ErrInvalidAnnotation):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+			"available_types": domain.AnnotationTypes,
+		})
+	case errors.Is(err, annotating.ErrAnnotationNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Anotação não encontrada", nil)
+	case errors.Is(err, annotating.ErrAccountNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+	default:
+		log.ForContext(r.Context()).WithError(err).Error("annotation: erro ao acessar anotações")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao acessar anotações", nil)
+	}
+}
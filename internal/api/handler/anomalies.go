@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// ListAnomalies lista os dias entre start_date e end_date em que o investimento ou o custo por resultado
+// da conta se afastou do padrão dos 30 dias anteriores
+func ListAnomalies(service insighting.AnomalyDetector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		query := r.URL.Query()
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		anomalies, err := service.ListAnomalies(accountID, *startDate, *endDate)
+		if err != nil {
+			if errors.Is(err, insighting.ErrInvalidAnomalyRange) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).WithField("account_id", accountID).Error("anomalies: erro ao listar anomalias")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar anomalias", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(anomalies); err != nil {
+			logger.WithError(err).Error("anomalies: erro ao enviar resposta")
+		}
+	}
+}
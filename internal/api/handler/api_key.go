@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// CreateAPIKey emite uma chave de API do usuário autenticado para integrações (BI, scripts).
+// A chave só é exibida nesta resposta.
+func CreateAPIKey(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.CreateAPIKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		// A chave não pode ter mais acesso que o próprio usuário
+		for _, scope := range req.Scopes {
+			if domain.IsValidAPIKeyScope(scope) && !middleware.HasPermission(r.Context(), scope) {
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Seu perfil não tem a permissão "+string(scope), nil)
+				return
+			}
+		}
+
+		created, err := service.CreateAPIKey(userClaims.UserID, &req)
+		if err != nil {
+			var authErr *authenticating.AuthError
+			if errors.As(err, &authErr) && (authErr.Code == apiErrors.ErrInvalidRequest || authErr.Code == apiErrors.ErrMissingRequiredData) {
+				apiErrors.WriteError(w, authErr.Code, authErr.Details, map[string]any{
+					"available_scopes": domain.APIKeyScopes,
+				})
+				return
+			}
+			log.ForContext(r.Context()).WithError(err).Error("api key: erro ao criar chave")
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao criar chave de API", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(created); err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("api key: erro ao enviar resposta")
+		}
+	}
+}
+
+// ListAPIKeys lista as chaves de API do usuário autenticado, sem o valor das chaves
+func ListAPIKeys(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		keys, err := service.ListAPIKeys(userClaims.UserID)
+		if err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("api key: erro ao listar chaves")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar chaves de API", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(keys); err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("api key: erro ao enviar resposta")
+		}
+	}
+}
+
+// RevokeAPIKey revoga uma chave de API do usuário autenticado
+func RevokeAPIKey(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		keyID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID da chave inválido", nil)
+			return
+		}
+
+		if err := service.RevokeAPIKey(userClaims.UserID, keyID); err != nil {
+			if errors.Is(err, authenticating.ErrAPIKeyNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Chave de API não encontrada", nil)
+				return
+			}
+			log.ForContext(r.Context()).WithError(err).Error("api key: erro ao revogar chave")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao revogar chave de API", nil)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
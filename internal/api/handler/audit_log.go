@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// ListAuditLog lista o log de auditoria paginado, do registro mais recente para o mais antigo.
+// Aceita os filtros actor_id, action, entity_type, entity_id, from e to (RFC3339), limit e offset.
+func ListAuditLog(service auditing.AuditService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+		query := r.URL.Query()
+
+		filters := domain.AuditLogFilters{
+			Action:     query.Get("action"),
+			EntityType: query.Get("entity_type"),
+			EntityID:   query.Get("entity_id"),
+		}
+
+		if value := query.Get("actor_id"); value != "" {
+			actorID, err := strconv.Atoi(value)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro actor_id inválido", nil)
+				return
+			}
+			filters.ActorID = &actorID
+		}
+
+		for param, target := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" deve estar no formato RFC3339", nil)
+				return
+			}
+			*target = &parsed
+		}
+
+		for param, target := range map[string]*int{"limit": &filters.Limit, "offset": &filters.Offset} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" inválido", nil)
+				return
+			}
+			*target = parsed
+		}
+
+		page, err := service.ListEntries(filters)
+		if err != nil {
+			if errors.Is(err, auditing.ErrInvalidFilter) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).Error("audit: erro ao listar log de auditoria")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar log de auditoria", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			logger.WithError(err).Error("audit: erro ao enviar resposta")
+		}
+	}
+}
+
+// actorIDFromRequest retorna o usuário autenticado, registrado como autor das operações auditadas
+func actorIDFromRequest(r *http.Request) int {
+	if userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims); ok {
+		return userClaims.UserID
+	}
+	return 0
+}
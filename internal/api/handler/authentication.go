@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
 	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
 	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
 )
@@ -24,12 +26,17 @@ type GeneratePasswordResponse struct {
 	Password string `json:"password"`
 }
 
+type PermissionsResponse struct {
+	RoleID      int                 `json:"role_id"`
+	Permissions []domain.Permission `json:"permissions"`
+}
+
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password"`
 	NewPassword     string `json:"new_password"`
 }
 
-func Login(service authenticating.Authenticator) http.HandlerFunc {
+func Login(service authenticating.Authenticator, cookieAuth middleware.CookieAuthConfig, trustProxyHeaders bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req LoginRequest
 
@@ -40,22 +47,210 @@ func Login(service authenticating.Authenticator) http.HandlerFunc {
 		}
 
 		// Tentar realizar o login
-		token, err := service.LoginUser(req.Email, req.Password)
+		result, err := service.LoginUser(req.Email, req.Password, sessionClientFromRequest(r, trustProxyHeaders))
+		if err != nil {
+			handleLoginError(w, err)
+			return
+		}
+
+		// Modo cookie: o token fica em cookie HttpOnly e apenas o token CSRF é retornado
+		if cookieAuth.Enabled && middleware.WantsCookieAuth(r) {
+			csrfToken, err := middleware.SetSessionCookies(w, cookieAuth, result.Token)
+			if err != nil {
+				logrus.WithError(err).Error("Erro ao gerar token CSRF")
+				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao iniciar sessão", nil)
+				return
+			}
+			if result.RefreshToken != "" {
+				middleware.SetRefreshCookie(w, cookieAuth, result.RefreshToken, *result.RefreshTokenExpiresAt)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"csrf_token":           csrfToken,
+				"must_change_password": result.MustChangePassword,
+				"password_expires_at":  result.PasswordExpiresAt,
+			})
+			return
+		}
+
+		// Sucesso: retornar o token e o estado da senha
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// RefreshSession troca o refresh token por um novo token de acesso e um novo refresh token. O refresh token
+// vem no corpo ou, no modo cookie, no cookie HttpOnly (com o token CSRF no cabeçalho).
+func RefreshSession(service authenticating.Authenticator, cookieAuth middleware.CookieAuthConfig, trustProxyHeaders bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		refreshToken, fromCookie, ok := refreshTokenFromRequest(w, r, cookieAuth)
+		if !ok {
+			return
+		}
+
+		result, err := service.RefreshSession(refreshToken, sessionClientFromRequest(r, trustProxyHeaders))
 		if err != nil {
 			handleLoginError(w, err)
 			return
 		}
 
-		// Sucesso: retornar o token
+		if fromCookie {
+			csrfToken, err := middleware.SetSessionCookies(w, cookieAuth, result.Token)
+			if err != nil {
+				logrus.WithError(err).Error("Erro ao gerar token CSRF")
+				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao renovar sessão", nil)
+				return
+			}
+			middleware.SetRefreshCookie(w, cookieAuth, result.RefreshToken, *result.RefreshTokenExpiresAt)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"csrf_token":          csrfToken,
+				"expires_at":          result.ExpiresAt,
+				"password_expires_at": result.PasswordExpiresAt,
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// Logout revoga o refresh token e o token de acesso apresentado e, no modo cookie, remove os cookies
+// da sessão.
+func Logout(service authenticating.Authenticator, cookieAuth middleware.CookieAuthConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		refreshToken, _, ok := refreshTokenFromRequest(w, r, cookieAuth)
+		if !ok {
+			return
+		}
+
+		if err := service.RevokeRefreshToken(refreshToken); err != nil {
+			logrus.WithError(err).Error("Erro ao revogar refresh token")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao encerrar sessão", nil)
+			return
+		}
+
+		if err := service.RevokeAccessToken(middleware.AccessTokenFromRequest(r)); err != nil {
+			logrus.WithError(err).Error("Erro ao revogar token de acesso")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao encerrar sessão", nil)
+			return
+		}
+
+		if cookieAuth.Enabled {
+			middleware.ClearSessionCookies(w, cookieAuth)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ForgotPassword envia o link de redefinição de senha. A resposta é a mesma para e-mails cadastrados
+// ou não, para não revelar quais e-mails existem.
+func ForgotPassword(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req domain.ForgotPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		if err := service.RequestPasswordReset(req.Email); err != nil {
+			logrus.WithError(err).Error("Erro ao solicitar redefinição de senha")
+			handleLoginError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Se o e-mail estiver cadastrado, você receberá um link para redefinir a senha",
+		})
+	}
+}
+
+// ResetPassword define a nova senha com o token do link enviado por e-mail
+func ResetPassword(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req domain.ResetPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		if err := service.ResetPassword(req.Token, req.NewPassword); err != nil {
+			logrus.WithError(err).Warn("Erro ao redefinir senha")
+			handleLoginError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// VerifyEmail confirma o e-mail do usuário com o token do link enviado no cadastro
+func VerifyEmail(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req domain.VerifyEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		if err := service.VerifyEmail(req.Token); err != nil {
+			logrus.WithError(err).Warn("Erro ao verificar e-mail")
+			handleLoginError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ResendEmailVerification envia um novo link de confirmação de e-mail. A resposta é a mesma para e-mails
+// cadastrados ou não, para não revelar quais e-mails existem.
+func ResendEmailVerification(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req domain.ResendVerificationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		if err := service.ResendEmailVerification(req.Email); err != nil {
+			logrus.WithError(err).Error("Erro ao reenviar verificação de e-mail")
+			handleLoginError(w, err)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
 		json.NewEncoder(w).Encode(map[string]string{
-			"token": token,
+			"message": "Se o e-mail estiver cadastrado e ainda não confirmado, você receberá um novo link de confirmação",
 		})
 	}
 }
 
-// GetMe retorna as informações do usuário logado
-func GetMe(service authenticating.Authenticator) http.HandlerFunc {
+// refreshTokenFromRequest lê o refresh token do corpo (opcional) ou do cookie do modo cookie
+func refreshTokenFromRequest(w http.ResponseWriter, r *http.Request, cookieAuth middleware.CookieAuthConfig) (string, bool, bool) {
+	var req domain.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+		return "", false, false
+	}
+
+	if req.RefreshToken != "" || !cookieAuth.Enabled {
+		return req.RefreshToken, false, true
+	}
+
+	refreshToken := middleware.RefreshTokenFromCookie(r)
+	return refreshToken, refreshToken != "", true
+}
+
+// GetMe retorna as informações do usuário logado, com o nome, a descrição e as permissões do seu perfil de acesso
+func GetMe(service authenticating.Authenticator, roles authorizing.RoleManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Obter o token do usuário do contexto
 		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
@@ -72,6 +267,15 @@ func GetMe(service authenticating.Authenticator) http.HandlerFunc {
 			return
 		}
 
+		// Os dados do perfil de acesso são complementares: a falha não impede a resposta
+		if user.RoleID != 0 {
+			role, err := roles.GetRole(user.RoleID)
+			if err != nil && !errors.Is(err, authorizing.ErrRoleNotFound) {
+				logrus.WithError(err).WithField("role_id", user.RoleID).Warn("Erro ao obter perfil de acesso do usuário")
+			}
+			user.Role = role
+		}
+
 		// Retornar o usuário como resposta
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(user)
@@ -83,6 +287,29 @@ func GetMe(service authenticating.Authenticator) http.HandlerFunc {
 	}
 }
 
+// GetMyPermissions retorna as permissões do perfil do usuário logado
+func GetMyPermissions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		response := PermissionsResponse{
+			RoleID:      userClaims.UserRoleID,
+			Permissions: middleware.PermissionsFromContext(r.Context()).List(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logrus.Error(err)
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao enviar resposta", nil)
+			return
+		}
+	}
+}
+
 // handleLoginError trata erros específicos de login e retorna a resposta apropriada
 func handleLoginError(w http.ResponseWriter, err error) {
 	// Tentar fazer cast para AuthError para obter mais detalhes
@@ -173,6 +400,9 @@ func ChangePassword(service authenticating.Authenticator) http.HandlerFunc {
 			case strings.Contains(errorMsg, "a senha deve conter"):
 				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, errorMsg, nil)
 
+			case errors.Is(err, authenticating.ErrPasswordReused):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, errorMsg, nil)
+
 			default:
 				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao alterar senha", nil)
 			}
@@ -186,18 +416,11 @@ func ChangePassword(service authenticating.Authenticator) http.HandlerFunc {
 }
 
 // GeneratePassword é um handler para gerar uma senha forte para um usuário
-// Requer que o usuário que faz a requisição seja um administrador (role_id = 1)
+// Requer a permissão users:manage (ver rota)
 func GeneratePassword(service authenticating.Authenticator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("INIT - GeneratePassword")
 
-		// Obter claims do usuário que faz a requisição
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok {
-			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
-			return
-		}
-
 		// Obter ID do usuário alvo da URL
 		targetUserIDStr := httprouter.ParamsFromContext(r.Context()).ByName("id")
 		if targetUserIDStr == "" {
@@ -213,16 +436,13 @@ func GeneratePassword(service authenticating.Authenticator) http.HandlerFunc {
 		}
 
 		// Gerar nova senha forte
-		newPassword, err := service.GenerateStrongPassword(userClaims.UserID, targetUserID)
+		newPassword, err := service.GenerateStrongPassword(targetUserID, actorIDFromRequest(r))
 		if err != nil {
 			logrus.Error(err)
 
 			errorMsg := err.Error()
 			switch {
-			case errorMsg == "apenas administradores podem gerar novas senhas":
-				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, errorMsg, nil)
-
-			case errorMsg == "usuário alvo não encontrado" || errorMsg == "usuário solicitante não encontrado":
+			case errorMsg == "usuário alvo não encontrado":
 				apiErrors.WriteError(w, apiErrors.ErrUserNotFound, errorMsg, nil)
 
 			default:
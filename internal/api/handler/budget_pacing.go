@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetBudgetPacing retorna o ritmo do investimento do mês corrente em relação ao orçamento das metas,
+// sinalizando se a conta está acima (over_pace) ou abaixo (under_pace) do esperado para o dia.
+func GetBudgetPacing(service insighting.BudgetPacer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		pacing, err := service.GetBudgetPacing(r.Context(), accountID)
+		if err != nil {
+			switch {
+			case errors.Is(err, insighting.ErrAccountNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+			case errors.Is(err, insighting.ErrNoSpendBudget):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "A conta não tem orçamento definido nas metas do mês", nil)
+			default:
+				logger.WithError(err).WithField("account_id", accountID).Error("pacing: erro ao calcular ritmo de investimento")
+				apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao calcular o ritmo de investimento da conta", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(pacing); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// GetBusinessManagerInsights retorna as métricas de anúncios e vendas somadas das contas ativas do business
+// manager no período. Usuários sem perfil administrativo veem apenas as contas que podem visualizar.
+func GetBusinessManagerInsights(service insighting.BusinessManagerInsighter, permissions middleware.AccountPermissionChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		businessManagerID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		if startDate.After(*endDate) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "A data de início não pode ser posterior à data de fim", nil)
+			return
+		}
+
+		var canView func(accountID string) bool
+		if !hasUnrestrictedAccountAccess(r.Context()) {
+			canView = func(accountID string) bool {
+				permission, err := permissions.GetAccountPermission(userClaims.UserID, accountID)
+				if err != nil {
+					logger.WithError(err).WithField("account_id", accountID).Warn("Erro ao consultar permissão da conta no consolidado do business manager")
+					return false
+				}
+				return permission.Allows(domain.AccountPermissionViewer)
+			}
+		}
+
+		insights, err := service.GetBusinessManagerInsights(businessManagerID, &domain.InsigthFilters{
+			StartDate: startDate,
+			EndDate:   endDate,
+		}, canView)
+		if err != nil {
+			if errors.Is(err, insighting.ErrBusinessManagerNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Business manager não encontrado ou sem contas ativas", nil)
+				return
+			}
+			logger.WithError(err).WithField("business_manager_id", businessManagerID).Error("Erro ao consolidar insights do business manager")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao consolidar insights do business manager", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(insights); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetCampaignCatalog retorna as campanhas da conta com status atual, orçamento e métricas dos
+// últimos 7 dias, para os seletores de campanha do dashboard. A resposta é cacheada por conta.
+func GetCampaignCatalog(service insighting.CampaignCataloger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		catalog, err := service.GetCampaignCatalog(accountID)
+		if err != nil {
+			if errors.Is(err, insighting.ErrAccountNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+				return
+			}
+			logger.WithError(err).WithField("account_id", accountID).Error("campaign catalog: erro ao buscar campanhas")
+			apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao buscar campanhas da conta no Meta", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(catalog); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/scheduler"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
 	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
 	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
 )
 
@@ -18,6 +22,7 @@ const (
 	CronJobTypeSSOtica            = "ssotica"
 	CronJobTypeMonthly            = "monthly"
 	CronJobTypeTopRankingAccounts = "top-ranking-accounts"
+	CronJobTypeReportSchedules    = "report-schedules"
 	CronJobTypeAll                = "all"
 )
 
@@ -27,6 +32,18 @@ type CronJobServices struct {
 	SSOticaInsightSyncService     *scheduler.SSOticaInsightSyncService
 	MonthlyInsightsSyncService    *scheduler.MonthlyInsightsSyncService
 	TopRankingAccountsSyncService *scheduler.TopRankingAccountsService
+	ReportScheduleService         *scheduler.ReportScheduleService
+	// Scheduler é o agendador das rotinas, com a próxima e a última execução de cada uma
+	Scheduler *scheduler.Scheduler
+	// AuditRecorder registra as execuções manuais no log de auditoria
+	AuditRecorder auditing.Recorder
+}
+
+// recordAudit registra a execução manual no log de auditoria, quando configurado
+func (services CronJobServices) recordAudit(r *http.Request, jobType string, details any) {
+	if services.AuditRecorder != nil {
+		services.AuditRecorder.Record(actorIDFromRequest(r), domain.AuditActionSyncTrigger, domain.AuditEntityJob, jobType, details)
+	}
 }
 
 // RunCronJob executa manualmente uma cron job específica
@@ -34,10 +51,9 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("INIT - RunCronJob")
 
-		// Verificar permissões - apenas administradores podem executar cron jobs
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
-			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem executar cron jobs", nil)
+		// Verificar permissões - execução manual exige operations:manage
+		if !middleware.HasPermission(r.Context(), domain.PermissionOperationsManage) {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para executar cron jobs", nil)
 			return
 		}
 
@@ -56,6 +72,10 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Serviço de sincronização do Meta não disponível", nil)
 				return
 			}
+			if services.MetaInsightSyncService.Paused() {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Agendador de sincronização do Meta pausado", nil)
+				return
+			}
 			services.MetaInsightSyncService.TriggerManualSync()
 
 		case CronJobTypeSSOtica:
@@ -64,6 +84,10 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Serviço de sincronização do SSOtica não disponível", nil)
 				return
 			}
+			if services.SSOticaInsightSyncService.Paused() {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Agendador de sincronização do SSOtica pausado", nil)
+				return
+			}
 			services.SSOticaInsightSyncService.TriggerManualSync()
 
 		case CronJobTypeMonthly:
@@ -72,6 +96,10 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Serviço de sincronização mensal não disponível", nil)
 				return
 			}
+			if services.MonthlyInsightsSyncService.Paused() {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Agendador de sincronização mensal pausado", nil)
+				return
+			}
 			services.MonthlyInsightsSyncService.TriggerManualSync()
 
 		case CronJobTypeTopRankingAccounts:
@@ -80,8 +108,20 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Serviço de sincronização de top ranking de contas não disponível", nil)
 				return
 			}
+			if services.TopRankingAccountsSyncService.Paused() {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Agendador de sincronização de top ranking de contas pausado", nil)
+				return
+			}
 			services.TopRankingAccountsSyncService.TriggerManualSync()
 
+		case CronJobTypeReportSchedules:
+			// Enviar relatórios agendados vencidos
+			if services.ReportScheduleService == nil {
+				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Serviço de relatórios agendados não disponível", nil)
+				return
+			}
+			services.ReportScheduleService.TriggerManualSync()
+
 		case CronJobTypeAll:
 			// Executar ambas as sincronizações
 			if services.MetaInsightSyncService != nil {
@@ -98,6 +138,8 @@ func RunCronJob(services CronJobServices) http.HandlerFunc {
 			return
 		}
 
+		services.recordAudit(r, cronType, nil)
+
 		// Responder com sucesso
 		response := map[string]any{
 			"message": "Cron job iniciada com sucesso",
@@ -112,10 +154,9 @@ func GetCronStatus(services CronJobServices) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("INIT - GetCronStatus")
 
-		// Verificar permissões - apenas administradores podem ver status das crons
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
-			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem verificar status de cron jobs", nil)
+		// Verificar permissões - status das crons exige operations:manage
+		if !middleware.HasPermission(r.Context(), domain.PermissionOperationsManage) {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para verificar status de cron jobs", nil)
 			return
 		}
 
@@ -124,8 +165,172 @@ func GetCronStatus(services CronJobServices) http.HandlerFunc {
 			"ssotica":              services.SSOticaInsightSyncService.GetStatus(),
 			"monthly":              services.MonthlyInsightsSyncService.GetStatus(),
 			"top-ranking-accounts": services.TopRankingAccountsSyncService.GetStatus(),
+			"report-schedules":     services.ReportScheduleService.GetStatus(),
+			"jobs":                 services.Scheduler.Status(),
 		}
 
 		json.NewEncoder(w).Encode(status)
 	}
 }
+
+// RunBackfill sincroniza os insights do Meta ou do SSOtica de um período longo, em blocos mensais
+func RunBackfill(services CronJobServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logrus.Info("INIT - RunBackfill")
+
+		var req domain.BackfillRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		startBackfill(w, r, services, httprouter.ParamsFromContext(r.Context()).ByName("type"), req)
+	}
+}
+
+// RunSyncBackfill sincroniza os insights do Meta ou do SSOtica de um período qualquer, opcionalmente apenas das
+// contas informadas, para corrigir lacunas no histórico sem alterar o LookbackDays
+func RunSyncBackfill(services CronJobServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logrus.Info("INIT - RunSyncBackfill")
+
+		var req domain.BackfillRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		startBackfill(w, r, services, req.Integrator, req)
+	}
+}
+
+func startBackfill(w http.ResponseWriter, r *http.Request, services CronJobServices, integrator string, req domain.BackfillRequest) {
+	// Verificar permissões - backfills exigem operations:manage
+	if !middleware.HasPermission(r.Context(), domain.PermissionOperationsManage) {
+		apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para executar backfills", nil)
+		return
+	}
+
+	startDate, err := time.Parse(time.DateOnly, req.StartDate)
+	if err != nil {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "start_date deve estar no formato AAAA-MM-DD", nil)
+		return
+	}
+	endDate, err := time.Parse(time.DateOnly, req.EndDate)
+	if err != nil {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "end_date deve estar no formato AAAA-MM-DD", nil)
+		return
+	}
+
+	switch {
+	case integrator == CronJobTypeMeta && services.MetaInsightSyncService != nil:
+		err = services.MetaInsightSyncService.TriggerBackfill(startDate, endDate, req.AccountIDs)
+	case integrator == CronJobTypeSSOtica && services.SSOticaInsightSyncService != nil:
+		err = services.SSOticaInsightSyncService.TriggerBackfill(startDate, endDate, req.AccountIDs)
+	default:
+		apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Tipo de backfill inválido. Valores aceitos: meta, ssotica", nil)
+		return
+	}
+
+	switch {
+	case errors.Is(err, scheduler.ErrInvalidBackfillRange), errors.Is(err, scheduler.ErrBackfillRunning),
+		errors.Is(err, scheduler.ErrBackfillAccountNotFound), errors.Is(err, scheduler.ErrSchedulerPaused):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+		return
+	case err != nil:
+		logrus.WithError(err).Error("Erro ao iniciar backfill")
+		apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao iniciar backfill", nil)
+		return
+	}
+
+	services.recordAudit(r, integrator, map[string]any{
+		"backfill":    true,
+		"start_date":  req.StartDate,
+		"end_date":    req.EndDate,
+		"account_ids": req.AccountIDs,
+	})
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":     "Backfill iniciado com sucesso",
+		"type":        integrator,
+		"start_date":  req.StartDate,
+		"end_date":    req.EndDate,
+		"account_ids": req.AccountIDs,
+	})
+}
+
+// RunAccountSync sincroniza os insights de uma única conta na janela de LookbackDays, para corrigir uma loja sem
+// sincronizar todas as contas. Sem o parâmetro integrator, sincroniza o Meta e o SSOtica, conforme as credenciais da conta.
+func RunAccountSync(services CronJobServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logrus.Info("INIT - RunAccountSync")
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		type accountSyncer interface {
+			TriggerAccountSync(accountID string) error
+		}
+		syncers := map[string]accountSyncer{}
+		if services.MetaInsightSyncService != nil {
+			syncers[CronJobTypeMeta] = services.MetaInsightSyncService
+		}
+		if services.SSOticaInsightSyncService != nil {
+			syncers[CronJobTypeSSOtica] = services.SSOticaInsightSyncService
+		}
+
+		integrator := r.URL.Query().Get("integrator")
+		integrators := []string{CronJobTypeMeta, CronJobTypeSSOtica}
+		if integrator != "" {
+			if _, ok := syncers[integrator]; !ok {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Integrador inválido. Valores aceitos: meta, ssotica", nil)
+				return
+			}
+			integrators = []string{integrator}
+		}
+
+		started := make([]string, 0, len(integrators))
+		var lastErr error
+		for _, name := range integrators {
+			syncer, ok := syncers[name]
+			if !ok {
+				continue
+			}
+
+			err := syncer.TriggerAccountSync(accountID)
+			switch {
+			case err == nil:
+				started = append(started, name)
+			case errors.Is(err, scheduler.ErrAccountSyncNotFound) && integrator == "":
+				// Sem integrador informado, as origens sem credenciais da conta são ignoradas
+			case errors.Is(err, scheduler.ErrAccountSyncNotFound), errors.Is(err, scheduler.ErrAccountSyncRunning),
+				errors.Is(err, scheduler.ErrSchedulerPaused):
+				lastErr = err
+			default:
+				log.ForContext(r.Context()).WithError(err).WithField("account_id", accountID).Error("Erro ao iniciar sincronização da conta")
+				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao iniciar sincronização da conta", nil)
+				return
+			}
+		}
+
+		if len(started) == 0 {
+			if lastErr == nil || errors.Is(lastErr, scheduler.ErrAccountSyncNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, scheduler.ErrAccountSyncNotFound.Error(), nil)
+				return
+			}
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, lastErr.Error(), nil)
+			return
+		}
+
+		for _, name := range started {
+			services.recordAudit(r, name, map[string]any{"account_id": accountID})
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message":     "Sincronização da conta iniciada com sucesso",
+			"account_id":  accountID,
+			"integrators": started,
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/dashboarding"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// GetAccountDashboard retorna em uma única resposta os insights do período, a posição no ranking,
+// o progresso das metas e a última sincronização da conta, evitando as várias chamadas do dashboard.
+func GetAccountDashboard(service dashboarding.DashboardService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		if startDate.After(*endDate) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "A data de início não pode ser posterior à data de fim", nil)
+			return
+		}
+
+		aggregation := query.Get("aggregation")
+		if aggregation != "" && !domain.IsValidAggregation(aggregation) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Agregação inválida: use daily, weekly, monthly ou quarterly", nil)
+			return
+		}
+
+		dashboard, err := service.GetAccountDashboard(accountID, &domain.InsigthFilters{
+			StartDate:   startDate,
+			EndDate:     endDate,
+			Aggregation: aggregation,
+		})
+		if err != nil {
+			if errors.Is(err, dashboarding.ErrAccountNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+				return
+			}
+			logger.WithError(err).WithField("account_id", accountID).Error("dashboard: erro ao montar dashboard da conta")
+			apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao buscar os dados do dashboard", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dashboard); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetMonthForecast projeta o faturamento de redes sociais e o investimento da conta no fim do mês.
+// O parâmetro method aceita linear (padrão) ou moving_average.
+func GetMonthForecast(service insighting.Forecaster) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		method := r.URL.Query().Get("method")
+
+		forecast, err := service.ForecastMonth(accountID, method)
+		if err != nil {
+			switch {
+			case errors.Is(err, insighting.ErrInvalidForecastMethod):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			case errors.Is(err, insighting.ErrAccountNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+			case errors.Is(err, insighting.ErrForecastUnavailable):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+			default:
+				logger.WithError(err).WithField("account_id", accountID).Error("forecast: erro ao projetar fechamento do mês")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao projetar fechamento do mês", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(forecast); err != nil {
+			logger.WithError(err).Error("forecast: erro ao enviar resposta")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/goaling"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// ListGoals lista as metas mensais da conta, do mês mais recente para o mais antigo
+func ListGoals(service goaling.GoalService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		goals, err := service.ListGoals(accountID)
+		if err != nil {
+			writeGoalError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(goals); err != nil {
+			logger.WithError(err).Error("goal: erro ao enviar resposta")
+		}
+	}
+}
+
+// GetGoal retorna as metas da conta no mês informado (mm-yyyy)
+func GetGoal(service goaling.GoalService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		params := httprouter.ParamsFromContext(r.Context())
+
+		goal, err := service.GetGoal(params.ByName("id"), params.ByName("period"))
+		if err != nil {
+			writeGoalError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(goal); err != nil {
+			logger.WithError(err).Error("goal: erro ao enviar resposta")
+		}
+	}
+}
+
+// SaveGoal cria ou substitui as metas da conta no mês informado (mm-yyyy)
+func SaveGoal(service goaling.GoalService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.AccountGoalRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		params := httprouter.ParamsFromContext(r.Context())
+
+		goal, err := service.SaveGoal(params.ByName("id"), params.ByName("period"), userClaims.UserID, &req)
+		if err != nil {
+			writeGoalError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(goal); err != nil {
+			logger.WithError(err).Error("goal: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteGoal remove as metas da conta no mês informado (mm-yyyy)
+func DeleteGoal(service goaling.GoalService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+
+		if err := service.DeleteGoal(params.ByName("id"), params.ByName("period")); err != nil {
+			writeGoalError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeGoalError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, goaling.ErrInvalidGoal):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+	case errors.Is(err, goaling.ErrGoalNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Metas não encontradas para o período", nil)
+	case errors.Is(err, goaling.ErrAccountNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+	default:
+		log.ForContext(r.Context()).WithError(err).Error("goal: erro ao acessar metas")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao acessar metas", nil)
+	}
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/account"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/graphql"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// maxGraphQLQueryBytes limita o tamanho do corpo aceito pelo endpoint GraphQL
+const maxGraphQLQueryBytes = 64 << 10
+
+var (
+	errGraphQLForbidden    = errors.New("você não tem permissão para consultar este campo")
+	errGraphQLUnauthorized = errors.New("usuário não autenticado")
+)
+
+// NewGraphQLSchema monta os campos raiz do GraphQL sobre os casos de uso de contas, insights e ranking.
+// Cada campo aplica as mesmas permissões do perfil e da conta da rota REST equivalente.
+func NewGraphQLSchema(
+	insightService insighting.CombinedInsighter,
+	accountService account.AccountService,
+	rankingService ranking.RankingService,
+	permissions middleware.AccountPermissionChecker,
+	linkedAccounts LinkedAccountsProvider,
+) *graphql.Schema {
+	return &graphql.Schema{Query: map[string]graphql.FieldResolver{
+		"accounts": func(p graphql.ResolveParams) (any, error) {
+			if err := requireGraphQLPermission(p.Context, domain.PermissionAccountsAdmin); err != nil {
+				return nil, err
+			}
+
+			statuses, err := p.Strings("status")
+			if err != nil {
+				return nil, err
+			}
+			filters := &domain.AccountListFilters{
+				BusinessManagerID: p.String("businessId"),
+				Search:            p.String("search"),
+			}
+			for _, status := range statuses {
+				filters.Status = append(filters.Status, domain.AdAccountStatus(status))
+			}
+			if filters.Limit, err = p.Int("limit", 0); err != nil {
+				return nil, err
+			}
+			if filters.Offset, err = p.Int("offset", 0); err != nil {
+				return nil, err
+			}
+
+			return accountService.ListAdAccounts(filters)
+		},
+		"businessManagers": func(p graphql.ResolveParams) (any, error) {
+			if err := requireGraphQLPermission(p.Context, domain.PermissionAccountsAdmin); err != nil {
+				return nil, err
+			}
+			return accountService.ListBusinessManagers()
+		},
+		"insights": func(p graphql.ResolveParams) (any, error) {
+			accountID, filters, err := graphQLInsightFilters(p, permissions)
+			if err != nil {
+				return nil, err
+			}
+
+			filters.Aggregation = p.String("aggregation")
+			if filters.Aggregation != "" && !domain.IsValidAggregation(filters.Aggregation) {
+				return nil, fmt.Errorf("agregação inválida: use daily, weekly, monthly ou quarterly")
+			}
+
+			return insightService.GetAdAccountsByID(accountID, filters)
+		},
+		"insightsByDimension": func(p graphql.ResolveParams) (any, error) {
+			accountID, filters, err := graphQLInsightFilters(p, permissions)
+			if err != nil {
+				return nil, err
+			}
+
+			dimension := p.String("dimension")
+			if dimension == "" {
+				return nil, fmt.Errorf("argumento dimension é obrigatório")
+			}
+
+			return insightService.GetAdAccountInsightsByDimension(accountID, dimension, filters)
+		},
+		"compareInsights": func(p graphql.ResolveParams) (any, error) {
+			accountID, filters, err := graphQLInsightFilters(p, permissions)
+			if err != nil {
+				return nil, err
+			}
+
+			comparison := p.String("comparison")
+			if comparison == "" {
+				comparison = domain.ComparisonPreviousPeriod
+			}
+			if comparison != domain.ComparisonPreviousPeriod && comparison != domain.ComparisonPreviousYear {
+				return nil, fmt.Errorf("argumento comparison deve ser previous_period ou previous_year")
+			}
+
+			return insightService.CompareAdAccountInsights(accountID, comparison, filters)
+		},
+		"monthlyReport": func(p graphql.ResolveParams) (any, error) {
+			if err := requireGraphQLPermission(p.Context, domain.PermissionReportsConsolidated); err != nil {
+				return nil, err
+			}
+
+			period := p.String("period")
+			if period == "" {
+				return nil, fmt.Errorf("argumento period (mm-aaaa) é obrigatório")
+			}
+			return insightService.GetMonthlyInsightsByPeriod(period)
+		},
+		"insightsMap": func(p graphql.ResolveParams) (any, error) {
+			if err := requireGraphQLPermission(p.Context, domain.PermissionReportsConsolidated); err != nil {
+				return nil, err
+			}
+
+			period := p.String("period")
+			if period == "" {
+				period = domain.PeriodLast30Days
+			}
+			return accountService.GetInsightsMap(period)
+		},
+		"storeRanking": func(p graphql.ResolveParams) (any, error) {
+			if err := requireGraphQLPermission(p.Context, domain.PermissionSalesRead); err != nil {
+				return nil, err
+			}
+			userClaims := p.Context.Value(middleware.ContextKeyUser).(*domain.Claims)
+			return storeRankingForUser(p.Context, rankingService, linkedAccounts, userClaims.UserID)
+		},
+	}}
+}
+
+// requireGraphQLPermission verifica se o perfil do usuário autenticado tem a permissão exigida pelo campo
+func requireGraphQLPermission(ctx context.Context, permission domain.Permission) error {
+	if _, ok := ctx.Value(middleware.ContextKeyUser).(*domain.Claims); !ok {
+		return errGraphQLUnauthorized
+	}
+	if !middleware.HasPermission(ctx, permission) {
+		return errGraphQLForbidden
+	}
+	return nil
+}
+
+// graphQLInsightFilters valida accountId, startDate e endDate e exige permissão de leitura na conta
+func graphQLInsightFilters(p graphql.ResolveParams, permissions middleware.AccountPermissionChecker) (string, *domain.InsigthFilters, error) {
+	userClaims, ok := p.Context.Value(middleware.ContextKeyUser).(*domain.Claims)
+	if !ok {
+		return "", nil, errGraphQLUnauthorized
+	}
+
+	accountID := p.String("accountId")
+	if accountID == "" || p.String("startDate") == "" || p.String("endDate") == "" {
+		return "", nil, fmt.Errorf("argumentos accountId, startDate e endDate são obrigatórios")
+	}
+
+	if !hasUnrestrictedAccountAccess(p.Context) {
+		permission, err := permissions.GetAccountPermission(userClaims.UserID, accountID)
+		if err != nil {
+			return "", nil, fmt.Errorf("erro ao verificar permissão da conta")
+		}
+		if !permission.Allows(domain.AccountPermissionViewer) {
+			return "", nil, errGraphQLForbidden
+		}
+	}
+
+	startDate, err := utils.ParseDate(p.String("startDate"))
+	if err != nil {
+		return "", nil, fmt.Errorf("startDate deve estar no formato AAAA-MM-DD")
+	}
+	endDate, err := utils.ParseDate(p.String("endDate"))
+	if err != nil {
+		return "", nil, fmt.Errorf("endDate deve estar no formato AAAA-MM-DD")
+	}
+	if startDate.After(*endDate) {
+		return "", nil, fmt.Errorf("a data de início não pode ser posterior à data de fim")
+	}
+
+	return accountID, &domain.InsigthFilters{StartDate: startDate, EndDate: endDate}, nil
+}
+
+// GraphQL executa uma consulta GraphQL. Erros dos campos voltam em "errors" com status 200;
+// consultas inválidas respondem 400.
+func GraphQL(schema *graphql.Schema, masker middleware.MaskedFieldsProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		var req graphql.Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLQueryBytes)).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Corpo da requisição inválido: "+err.Error(), nil)
+			return
+		}
+
+		// Os campos ocultos do perfil são removidos antes da seleção para que um alias não os exponha
+		var hidden map[string]bool
+		if masker != nil {
+			var err error
+			hidden, err = masker.MaskedFields(userClaims.UserRoleID)
+			if err != nil {
+				logger.WithError(err).Error("graphql: erro ao carregar campos mascarados")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao carregar as regras de acesso aos campos", nil)
+				return
+			}
+		}
+
+		response := schema.Execute(graphql.ExecuteParams{
+			Context:      r.Context(),
+			Request:      &req,
+			HiddenFields: hidden,
+		})
+
+		status := http.StatusOK
+		if response.Data == nil {
+			status = http.StatusBadRequest
+		}
+		if len(response.Errors) > 0 {
+			logger.WithFields(log.Fields{
+				"operation": req.OperationName,
+				"errors":    len(response.Errors),
+			}).Warn("graphql: consulta com erros")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.WithError(err).Error("graphql: erro ao codificar resposta")
+		}
+	})
+}
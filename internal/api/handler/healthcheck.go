@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/monitoring"
 )
 
 func HealthcheckHandler() http.Handler {
@@ -16,3 +18,32 @@ func HealthcheckHandler() http.Handler {
 		}
 	})
 }
+
+// ReadinessHandler responde 503 enquanto alguma dependência crítica estiver indisponível na última verificação
+func ReadinessHandler(monitor monitoring.DependencyMonitor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, code := "ok", http.StatusOK
+		if !monitor.Ready() {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"status":       status,
+			"dependencies": monitor.Statuses(),
+		}); err != nil {
+			logrus.WithError(err).Warn("error responding to readiness")
+		}
+	})
+}
+
+// ListDependencies retorna a última verificação de cada dependência externa, com latência e último sucesso
+func ListDependencies(monitor monitoring.DependencyMonitor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(monitor.Statuses()); err != nil {
+			logrus.WithError(err).Error("dependencies: erro ao enviar resposta")
+		}
+	}
+}
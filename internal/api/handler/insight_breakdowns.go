@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// GetAdAccountBreakdowns retorna investimento, impressões e resultados da conta no período
+// quebrados por faixa etária, gênero e plataforma (Facebook, Instagram, ...).
+func GetAdAccountBreakdowns(service insighting.BreakdownInsighter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		if startDate.After(*endDate) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "A data de início não pode ser posterior à data de fim", nil)
+			return
+		}
+
+		breakdowns, err := service.GetAdAccountBreakdowns(r.Context(), accountID, &domain.InsigthFilters{
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			if errors.Is(err, insighting.ErrAccountNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+				return
+			}
+			logger.WithError(err).WithField("account_id", accountID).Error("breakdowns: erro ao buscar quebras da conta")
+			apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao buscar quebras da conta no Meta", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(breakdowns); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// ExportDailyInsightsCSV envia o CSV com os insights diários de anúncios e vendas da conta no intervalo.
+// O FieldMasking só atua em respostas JSON, então as colunas ocultas do perfil são removidas aqui.
+func ExportDailyInsightsCSV(service reporting.DailyInsightExporter, masker middleware.MaskedFieldsProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		if query.Get("start_date") == "" || query.Get("end_date") == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Parâmetros start_date e end_date são obrigatórios", nil)
+			return
+		}
+
+		startDate, err := utils.ParseDate(query.Get("start_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		endDate, err := utils.ParseDate(query.Get("end_date"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
+		export, err := service.PrepareDailyExport(id, *startDate, *endDate)
+		if err != nil {
+			switch {
+			case errors.Is(err, reporting.ErrInvalidExport):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+			case errors.Is(err, reporting.ErrAccountNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+			default:
+				logger.WithError(err).WithField("account_id", id).Error("insight-export: erro ao carregar insights diários")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao carregar insights diários", nil)
+			}
+			return
+		}
+
+		var hidden map[string]bool
+		if masker != nil {
+			hidden, err = masker.MaskedFields(userClaims.UserRoleID)
+			if err != nil {
+				logger.WithError(err).WithField("account_id", id).Error("insight-export: erro ao carregar campos mascarados")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao carregar as regras de acesso aos campos", nil)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename()))
+		if err := export.WriteCSV(w, hidden); err != nil {
+			logger.WithError(err).WithField("account_id", id).Error("insight-export: erro ao enviar CSV")
+		}
+	})
+}
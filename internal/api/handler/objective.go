@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// ListObjectives retorna a tradução dos objetivos de campanha da Meta, agrupados pela etapa do funil
+func ListObjectives() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(domain.ListObjectives()); err != nil {
+			logrus.WithError(err).Error("objectives: erro ao enviar resposta")
+		}
+	}
+}
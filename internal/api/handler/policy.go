@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/privacy"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// GetMyPolicy informa a versão vigente dos termos de uso e se o usuário autenticado já a aceitou
+func GetMyPolicy(service privacy.PolicyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		status, err := service.Status(userClaims.UserID)
+		if err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("policy: erro ao consultar aceite da política")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao consultar aceite dos termos de uso", nil)
+			return
+		}
+
+		writePolicyStatus(w, r, http.StatusOK, status)
+	}
+}
+
+// AcceptMyPolicy registra o aceite da versão vigente dos termos de uso pelo usuário autenticado, com o IP
+// e o User-Agent como prova do consentimento
+func AcceptMyPolicy(service privacy.PolicyService, trustProxyHeaders bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.AcceptPolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+		if req.Version == "" {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Informe a versão aceita", nil)
+			return
+		}
+
+		status, err := service.Accept(userClaims.UserID, req.Version, sessionClientFromRequest(r, trustProxyHeaders))
+		if err != nil {
+			if errors.Is(err, privacy.ErrPolicyVersionMismatch) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+				return
+			}
+			log.ForContext(r.Context()).WithError(err).Error("policy: erro ao registrar aceite da política")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao registrar aceite dos termos de uso", nil)
+			return
+		}
+
+		writePolicyStatus(w, r, http.StatusOK, status)
+	}
+}
+
+func writePolicyStatus(w http.ResponseWriter, r *http.Request, statusCode int, status *domain.PolicyStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.ForContext(r.Context()).WithError(err).Error("policy: erro ao enviar resposta")
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/privacy"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// ErasePersonalData atende um pedido de eliminação de dados pessoais (LGPD), anonimizando o titular
+func ErasePersonalData(service privacy.ErasureService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.ErasureRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		record, err := service.Erase(userClaims.UserID, &req)
+		if err != nil {
+			switch {
+			case errors.Is(err, privacy.ErrMissingSubject) || errors.Is(err, privacy.ErrCPFNotSearchable):
+				apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, err.Error(), nil)
+			case errors.Is(err, privacy.ErrInvalidEmail) || errors.Is(err, privacy.ErrInvalidCPF):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			default:
+				logger.WithError(err).Error("privacy: erro ao processar pedido de eliminação")
+				var details any
+				if record != nil {
+					details = map[string]any{"erasure_id": record.ID}
+				}
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao processar pedido de eliminação", details)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(record); err != nil {
+			logger.WithError(err).Error("privacy: erro ao enviar resposta")
+		}
+	}
+}
+
+// ListErasureRequests lista os pedidos de eliminação atendidos, do mais recente para o mais antigo
+func ListErasureRequests(service privacy.ErasureService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		limit := 100
+		if value := r.URL.Query().Get("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro limit inválido", nil)
+				return
+			}
+			limit = min(parsed, 500)
+		}
+
+		records, err := service.ListErasures(limit)
+		if err != nil {
+			logger.WithError(err).Error("privacy: erro ao listar pedidos de eliminação")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar pedidos de eliminação", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(records); err != nil {
+			logger.WithError(err).Error("privacy: erro ao enviar resposta")
+		}
+	}
+}
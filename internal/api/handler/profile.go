@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/profile"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/imaging"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// avatarContentTypes são os formatos aceitos no upload, identificados pelo conteúdo do arquivo
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type UploadAvatarResponse struct {
+	AvatarURL string `json:"avatar_url"`
+}
+
+// UploadAvatar recebe a imagem no campo multipart "avatar". O próprio usuário ou um administrador podem alterar.
+func UploadAvatar(service profile.AvatarService, maxUploadBytes int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID do usuário inválido", nil)
+			return
+		}
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		if userClaims.UserID != userID && !middleware.HasPermission(r.Context(), domain.PermissionUsersManage) {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Não autorizado a alterar o avatar de outro usuário", nil)
+			return
+		}
+
+		// Margem para os cabeçalhos do multipart além do próprio arquivo
+		r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes+64*1024)
+		if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Arquivo inválido ou maior que o limite permitido", map[string]interface{}{
+				"max_bytes": maxUploadBytes,
+			})
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		file, _, err := r.FormFile("avatar")
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrMissingRequiredData, "Envie a imagem no campo 'avatar'", nil)
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(io.LimitReader(file, maxUploadBytes+1))
+		if err != nil {
+			logger.WithError(err).Error("UploadAvatar: erro ao ler arquivo")
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Erro ao ler arquivo", nil)
+			return
+		}
+		if int64(len(data)) > maxUploadBytes {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Arquivo maior que o limite permitido", map[string]interface{}{
+				"max_bytes": maxUploadBytes,
+			})
+			return
+		}
+
+		if !avatarContentTypes[http.DetectContentType(data)] {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, imaging.ErrUnsupportedImage.Error(), nil)
+			return
+		}
+
+		avatarURL, err := service.UploadAvatar(r.Context(), userID, data)
+		if err != nil {
+			switch {
+			case errors.Is(err, profile.ErrUserNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrUserNotFound, err.Error(), nil)
+			case errors.Is(err, profile.ErrStorageUnavailable):
+				apiErrors.WriteError(w, apiErrors.ErrCommunication, err.Error(), nil)
+			case errors.Is(err, imaging.ErrUnsupportedImage), errors.Is(err, imaging.ErrImageTooLarge):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			default:
+				logger.WithError(err).Error("UploadAvatar: erro ao salvar avatar")
+				apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao salvar avatar", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(UploadAvatarResponse{AvatarURL: avatarURL}); err != nil {
+			logger.WithError(err).Error("UploadAvatar: erro ao enviar resposta")
+		}
+	}
+}
+
+// GetPreferences retorna as preferências do usuário autenticado
+func GetPreferences(service profile.PreferencesService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		preferences, err := service.GetPreferences(userClaims.UserID)
+		if err != nil {
+			logger.WithError(err).Error("GetPreferences: erro ao buscar preferências")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao buscar preferências", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(preferences); err != nil {
+			logger.WithError(err).Error("GetPreferences: erro ao enviar resposta")
+		}
+	}
+}
+
+// UpdatePreferences substitui as preferências do usuário autenticado
+func UpdatePreferences(service profile.PreferencesService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.UserPreferences
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Erro ao decodificar requisição", nil)
+			return
+		}
+
+		preferences, err := service.UpdatePreferences(userClaims.UserID, hasUnrestrictedAccountAccess(r.Context()), &req)
+		if err != nil {
+			if errors.Is(err, profile.ErrInvalidPreferences) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).Error("UpdatePreferences: erro ao salvar preferências")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao salvar preferências", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(preferences); err != nil {
+			logger.WithError(err).Error("UpdatePreferences: erro ao enviar resposta")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+	apiErrors "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// GetMyReachImpressions retorna Reach e Impressions de todas as contas vinculadas ao usuário autenticado
+func GetMyReachImpressions(service insighting.ReachOverviewer, authenticator authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		period := r.URL.Query().Get("period")
+		if period == "" {
+			period = domain.PeriodLast30Days
+		}
+		if !slices.Contains(domain.PeriodPresets, period) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Período inválido", map[string]any{
+				"period":  period,
+				"allowed": domain.PeriodPresets,
+			})
+			return
+		}
+
+		accounts, err := authenticator.GetUserLinkedAccounts(userClaims.UserID)
+		if err != nil {
+			logrus.WithError(err).WithField("user_id", userClaims.UserID).Error("Erro ao buscar contas vinculadas para a visão geral")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao buscar contas vinculadas", nil)
+			return
+		}
+
+		overview, err := service.GetReachImpressionsOverview(accounts, period)
+		if err != nil {
+			logrus.WithError(err).WithField("user_id", userClaims.UserID).Error("Erro ao obter Reach e Impressions das contas vinculadas")
+			apiErrors.WriteError(w, apiErrors.ErrExternalService, "Erro ao obter Reach e Impressions das contas", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(overview); err != nil {
+			logrus.Error(err)
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao enviar resposta", nil)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetMonthlyReviewDeck gera a apresentação (.pptx) da reunião mensal de franquias
+func GetMonthlyReviewDeck(service reporting.DeckGenerator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		month := r.URL.Query().Get("month")
+		year := r.URL.Query().Get("year")
+		businessManagerID := r.URL.Query().Get("business_manager_id")
+
+		if month == "" || year == "" {
+			http.Error(w, "É necessário informar mês e ano nos parâmetros", http.StatusBadRequest)
+			return
+		}
+
+		if len(month) != 2 || month < "01" || month > "12" {
+			http.Error(w, "Mês inválido. Use formato de dois dígitos (01-12)", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := strconv.Atoi(year); err != nil || len(year) != 4 {
+			http.Error(w, "Ano inválido. Use formato de quatro dígitos (ex: 2025)", http.StatusBadRequest)
+			return
+		}
+
+		period := fmt.Sprintf("%s-%s", month, year)
+
+		logger.WithFields(log.Fields{
+			"period":              period,
+			"business_manager_id": businessManagerID,
+		}).Info("report-deck: gerando apresentação mensal")
+
+		deck, err := service.GenerateMonthlyDeck(period, businessManagerID)
+		if err != nil {
+			logger.WithError(err).WithField("period", period).Error("report-deck: erro ao gerar apresentação mensal")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("reuniao-mensal-%s.pptx", period)
+		if businessManagerID != "" {
+			filename = fmt.Sprintf("reuniao-mensal-%s-%s.pptx", businessManagerID, period)
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.presentationml.presentation")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.Header().Set("Content-Length", strconv.Itoa(len(deck)))
+		if _, err := w.Write(deck); err != nil {
+			logger.WithError(err).Error("report-deck: erro ao enviar apresentação")
+		}
+	})
+}
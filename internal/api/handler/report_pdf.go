@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// RequestMonthlyPDFReport agenda a geração do relatório mensal em PDF e responde 202 com o job
+func RequestMonthlyPDFReport(service reporting.PDFReportGenerator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		var req domain.PDFReportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Corpo da requisição inválido: "+err.Error(), nil)
+			return
+		}
+
+		job, err := service.RequestMonthlyPDF(&req, userClaims.UserID)
+		if err != nil {
+			if errors.Is(err, reporting.ErrInvalidPDFReport) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).Error("report-pdf: erro ao agendar relatório em PDF")
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao agendar relatório em PDF", nil)
+			return
+		}
+
+		logger.WithFields(log.Fields{
+			"job_id":     job.ID,
+			"period":     job.Period,
+			"account_id": job.AccountID,
+		}).Info("report-pdf: relatório em PDF agendado")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(job); err != nil {
+			logger.WithError(err).Error("report-pdf: erro ao codificar resposta")
+		}
+	})
+}
+
+// GetMonthlyPDFReport retorna a situação da geração de um relatório em PDF
+func GetMonthlyPDFReport(service reporting.PDFReportGenerator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		job, err := service.GetPDFReport(httprouter.ParamsFromContext(r.Context()).ByName("id"), userClaims.UserID)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Relatório em PDF não encontrado ou expirado", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(job); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao codificar resposta", nil)
+		}
+	})
+}
+
+// DownloadMonthlyPDFReport envia o PDF de uma geração concluída
+func DownloadMonthlyPDFReport(service reporting.PDFReportGenerator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		job, content, err := service.DownloadPDFReport(httprouter.ParamsFromContext(r.Context()).ByName("id"), userClaims.UserID)
+		switch {
+		case errors.Is(err, reporting.ErrPDFReportNotReady):
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+			return
+		case err != nil:
+			apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Relatório em PDF não encontrado ou expirado", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", job.Filename))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if _, err := w.Write(content); err != nil {
+			logger.WithError(err).Error("report-pdf: erro ao enviar relatório em PDF")
+		}
+	})
+}
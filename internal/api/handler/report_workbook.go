@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetMonthlyInsightWorkbook gera a planilha (.xlsx) do relatório mensal com abas de anúncios, vendas e resultados
+func GetMonthlyInsightWorkbook(service reporting.WorkbookGenerator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		month := r.URL.Query().Get("month")
+		year := r.URL.Query().Get("year")
+
+		if month == "" || year == "" {
+			http.Error(w, "É necessário informar mês e ano nos parâmetros", http.StatusBadRequest)
+			return
+		}
+
+		if len(month) != 2 || month < "01" || month > "12" {
+			http.Error(w, "Mês inválido. Use formato de dois dígitos (01-12)", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := strconv.Atoi(year); err != nil || len(year) != 4 {
+			http.Error(w, "Ano inválido. Use formato de quatro dígitos (ex: 2025)", http.StatusBadRequest)
+			return
+		}
+
+		period := fmt.Sprintf("%s-%s", month, year)
+
+		logger.WithField("period", period).Info("report-workbook: gerando planilha mensal")
+
+		workbook, err := service.GenerateMonthlyWorkbook(period)
+		if err != nil {
+			logger.WithError(err).WithField("period", period).Error("report-workbook: erro ao gerar planilha mensal")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("relatorio-mensal-%s.xlsx", period)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(workbook)))
+		if _, err := w.Write(workbook); err != nil {
+			logger.WithError(err).Error("report-workbook: erro ao enviar planilha")
+		}
+	})
+}
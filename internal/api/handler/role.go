@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListRoles lista os perfis com suas permissões e a quantidade de usuários
+func ListRoles(service authorizing.RoleManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roles, err := service.ListRoles()
+		if err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("role: erro ao listar perfis")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar perfis", nil)
+			return
+		}
+
+		writeRole(w, r, http.StatusOK, roles)
+	}
+}
+
+func GetRole(service authorizing.RoleManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleID, ok := roleIDFromRequest(w, r)
+		if !ok {
+			return
+		}
+
+		role, err := service.GetRole(roleID)
+		if err != nil {
+			handleRoleError(w, r, err, "Erro ao consultar perfil")
+			return
+		}
+
+		writeRole(w, r, http.StatusOK, role)
+	}
+}
+
+func CreateRole(service authorizing.RoleManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req domain.RoleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		role, err := service.CreateRole(&req, actorIDFromRequest(r))
+		if err != nil {
+			handleRoleError(w, r, err, "Erro ao criar perfil")
+			return
+		}
+
+		writeRole(w, r, http.StatusCreated, role)
+	}
+}
+
+// UpdateRole substitui nome, descrição e permissões do perfil. As novas permissões valem na próxima requisição.
+func UpdateRole(service authorizing.RoleManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleID, ok := roleIDFromRequest(w, r)
+		if !ok {
+			return
+		}
+
+		var req domain.RoleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		role, err := service.UpdateRole(roleID, &req, actorIDFromRequest(r))
+		if err != nil {
+			handleRoleError(w, r, err, "Erro ao atualizar perfil")
+			return
+		}
+
+		writeRole(w, r, http.StatusOK, role)
+	}
+}
+
+// DeleteRole exclui um perfil sem usuários
+func DeleteRole(service authorizing.RoleManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleID, ok := roleIDFromRequest(w, r)
+		if !ok {
+			return
+		}
+
+		if err := service.DeleteRole(roleID, actorIDFromRequest(r)); err != nil {
+			handleRoleError(w, r, err, "Erro ao excluir perfil")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func roleIDFromRequest(w http.ResponseWriter, r *http.Request) (int, bool) {
+	roleID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID do perfil inválido", nil)
+		return 0, false
+	}
+	return roleID, true
+}
+
+func handleRoleError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, authorizing.ErrInvalidRole):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+			"available_permissions": domain.Permissions,
+		})
+	case errors.Is(err, authorizing.ErrRoleNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Perfil não encontrado", nil)
+	case errors.Is(err, authorizing.ErrRoleInUse):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "O perfil está atribuído a usuários; altere o perfil deles antes de excluí-lo", nil)
+	default:
+		log.ForContext(r.Context()).WithError(err).Error("role: " + message)
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, message, nil)
+	}
+}
+
+func writeRole(w http.ResponseWriter, r *http.Request, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.ForContext(r.Context()).WithError(err).Error("role: erro ao enviar resposta")
+	}
+}
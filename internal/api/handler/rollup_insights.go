@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// GetQuarterlyInsights retorna os trimestres consolidados da conta entre start_year e end_year (padrão: ano atual)
+func GetQuarterlyInsights(service insighting.RollupInsighter) http.HandlerFunc {
+	return getRollupInsights(service, domain.RollupQuarter)
+}
+
+// GetYearlyInsights retorna os anos consolidados da conta entre start_year e end_year (padrão: ano atual)
+func GetYearlyInsights(service insighting.RollupInsighter) http.HandlerFunc {
+	return getRollupInsights(service, domain.RollupYear)
+}
+
+func getRollupInsights(service insighting.RollupInsighter, granularity string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		query := r.URL.Query()
+
+		currentYear := time.Now().Year()
+		startYear, err := yearParam(query.Get("start_year"), currentYear)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro start_year inválido", nil)
+			return
+		}
+		endYear, err := yearParam(query.Get("end_year"), currentYear)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro end_year inválido", nil)
+			return
+		}
+
+		reports, err := service.GetRollupInsights(accountID, granularity, startYear, endYear)
+		if err != nil {
+			switch {
+			case errors.Is(err, insighting.ErrInvalidRollupRange):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			case errors.Is(err, insighting.ErrAccountNotFound):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+			default:
+				logger.WithError(err).WithField("account_id", accountID).Error("insights: erro ao buscar insights consolidados")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao buscar insights consolidados", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reports); err != nil {
+			logger.WithError(err).Error("insights: erro ao enviar resposta")
+		}
+	}
+}
+
+func yearParam(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 2000 || year > 9999 {
+		return 0, errors.New("ano inválido")
+	}
+	return year, nil
+}
@@ -4,10 +4,25 @@ import (
 	"net/http"
 
 	"github.com/vfg2006/traffic-manager-api/internal/api/handler/router"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/account"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/annotating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/dashboarding"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/goaling"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/monitoring"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/privacy"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/profile"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/searching"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
+	"github.com/vfg2006/traffic-manager-api/pkg/graphql"
 	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
 )
 
@@ -21,64 +36,303 @@ func Healthcheck() []router.Route {
 	}
 }
 
-func AdAccounts(service account.AccountService) []router.Route {
+// Readiness retorna a verificação de prontidão e o status administrativo das dependências externas
+func Readiness(monitor monitoring.DependencyMonitor) []router.Route {
+	return []router.Route{
+		{
+			Path:    "/readyz",
+			Method:  http.MethodGet,
+			Handler: ReadinessHandler(monitor),
+		},
+		{
+			Path:        "/v1/admin/dependencies",
+			Method:      http.MethodGet,
+			Handler:     ListDependencies(monitor),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+	}
+}
+
+func AdAccounts(service account.AccountService, activityService activity.ActivityService, catalogService insighting.CampaignCataloger, breakdownService insighting.BreakdownInsighter, businessManagerInsightService insighting.BusinessManagerInsighter, permissions middleware.AccountPermissionChecker, liveQuota middleware.UsageQuotaConfig) []router.Route {
 	return []router.Route{
 		{
 			Path:        "/v1/accounts",
 			Method:      http.MethodGet,
 			Handler:     AdAccountList(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
 		},
 		{
 			Path:        "/v1/accounts/sync",
 			Method:      http.MethodGet,
 			Handler:     SyncAccounts(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
+		},
+		{
+			Path:        "/v1/accounts/search",
+			Method:      http.MethodGet,
+			Handler:     SearchAdAccounts(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/business-managers",
+			Method:      http.MethodGet,
+			Handler:     ListBusinessManagers(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
+		},
+		{
+			Path:        "/v1/business-managers/:id/insights",
+			Method:      http.MethodGet,
+			Handler:     GetBusinessManagerInsights(businessManagerInsightService, permissions),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.UsageQuota(liveQuota)},
+		},
+		{
+			Path:        "/v1/admin/accounts/merge",
+			Method:      http.MethodPost,
+			Handler:     MergeAdAccounts(service, activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
+		},
+		{
+			Path:        "/v1/admin/accounts/merges",
+			Method:      http.MethodGet,
+			Handler:     ListAccountMerges(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
 		},
 		{
 			Path:        "/v1/accounts/:id",
 			Method:      http.MethodPut,
-			Handler:     UpdateAdAccount(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Handler:     UpdateAdAccount(service, activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionAnalyst)},
+		},
+		{
+			Path:        "/v1/accounts/:id/location",
+			Method:      http.MethodPut,
+			Handler:     GeocodeAdAccount(service, activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
+		},
+		{
+			Path:        "/v1/accounts/:id/priority",
+			Method:      http.MethodPut,
+			Handler:     SetAdAccountPriority(service, activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsAdmin)},
+		},
+		{
+			Path:        "/v1/insights/map",
+			Method:      http.MethodGet,
+			Handler:     GetInsightsMap(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/activity",
+			Method:      http.MethodGet,
+			Handler:     GetAccountActivity(activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/notes",
+			Method:      http.MethodPost,
+			Handler:     CreateAccountNote(activityService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionAnalyst)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/campaigns",
+			Method:      http.MethodGet,
+			Handler:     GetCampaignCatalog(catalogService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/insights/breakdowns",
+			Method:      http.MethodGet,
+			Handler:     GetAdAccountBreakdowns(breakdownService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
 		},
 	}
 }
 
-func Insights(service insighting.CombinedInsighter) []router.Route {
+func Insights(service insighting.CombinedInsighter, annotationService annotating.AnnotationService, goalService goaling.GoalService, viewRecorder middleware.AccountViewRecorder, permissions middleware.AccountPermissionChecker, liveQuota middleware.UsageQuotaConfig) []router.Route {
 	return []router.Route{
 		{
 			Path:        "/v1/adAccount/:id/insights",
 			Method:      http.MethodGet,
 			Handler:     GetAdAccountsByID(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota), middleware.TrackAccountView(viewRecorder)},
 		},
 		{
 			Path:        "/v1/adAccount/:id/insights/reach-impressions",
 			Method:      http.MethodGet,
 			Handler:     GetAdAccountReachImpressions(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/insights/by-dimension",
+			Method:      http.MethodGet,
+			Handler:     GetAdAccountInsightsByDimension(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/insights/compare",
+			Method:      http.MethodGet,
+			Handler:     CompareAdAccountInsights(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/annotations",
+			Method:      http.MethodGet,
+			Handler:     ListAnnotations(annotationService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/annotations",
+			Method:      http.MethodPost,
+			Handler:     CreateAnnotation(annotationService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionAnalyst)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/annotations/:annotation_id",
+			Method:      http.MethodPut,
+			Handler:     UpdateAnnotation(annotationService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionAnalyst)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/annotations/:annotation_id",
+			Method:      http.MethodDelete,
+			Handler:     DeleteAnnotation(annotationService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionAnalyst)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/goals",
+			Method:      http.MethodGet,
+			Handler:     ListGoals(goalService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/goals/:period",
+			Method:      http.MethodGet,
+			Handler:     GetGoal(goalService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/goals/:period",
+			Method:      http.MethodPut,
+			Handler:     SaveGoal(goalService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionManager)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/goals/:period",
+			Method:      http.MethodDelete,
+			Handler:     DeleteGoal(goalService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionManager)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/budget-pacing",
+			Method:      http.MethodGet,
+			Handler:     GetBudgetPacing(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
 		},
 		{
 			Path:        "/v1/insights/report",
 			Method:      http.MethodGet,
 			Handler:     GetMonthlyInsightReport(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOrSupervisor()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated)},
 		},
 		{
 			Path:        "/v1/insights/periods",
 			Method:      http.MethodGet,
 			Handler:     GetAvailableMonthlyPeriods(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOrSupervisor()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated)},
+		},
+	}
+}
+
+// Objectives retorna a rota com a tradução dos objetivos de campanha
+func Objectives() []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/objectives",
+			Method:      http.MethodGet,
+			Handler:     ListObjectives(),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+	}
+}
+
+// Reports retorna as rotas de relatórios exportáveis
+func Reports(deckService reporting.DeckGenerator, workbookService reporting.WorkbookGenerator, pdfReportService reporting.PDFReportGenerator, dailyExporter reporting.DailyInsightExporter, masker middleware.MaskedFieldsProvider, permissions middleware.AccountPermissionChecker, exportQuota middleware.UsageQuotaConfig) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/insights/report/deck",
+			Method:      http.MethodGet,
+			Handler:     GetMonthlyReviewDeck(deckService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsDeck), middleware.UsageQuota(exportQuota)},
+		},
+		{
+			Path:        "/v1/insights/report/xlsx",
+			Method:      http.MethodGet,
+			Handler:     GetMonthlyInsightWorkbook(workbookService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated), middleware.UsageQuota(exportQuota)},
+		},
+		{
+			Path:        "/v1/insights/report/pdf",
+			Method:      http.MethodPost,
+			Handler:     RequestMonthlyPDFReport(pdfReportService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated), middleware.UsageQuota(exportQuota)},
+		},
+		{
+			Path:        "/v1/insights/report/pdf/:id",
+			Method:      http.MethodGet,
+			Handler:     GetMonthlyPDFReport(pdfReportService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated)},
+		},
+		{
+			Path:        "/v1/insights/report/pdf/:id/download",
+			Method:      http.MethodGet,
+			Handler:     DownloadMonthlyPDFReport(pdfReportService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionReportsConsolidated)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/insights/daily.csv",
+			Method:      http.MethodGet,
+			Handler:     ExportDailyInsightsCSV(dailyExporter, masker),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(exportQuota)},
 		},
 	}
 }
 
-func Authentication(service authenticating.Authenticator) []router.Route {
+func Authentication(service authenticating.Authenticator, roles authorizing.RoleManager, cookieAuth middleware.CookieAuthConfig, trustProxyHeaders bool) []router.Route {
 	return []router.Route{
 		{
 			Path:    "/v1/login",
 			Method:  http.MethodPost,
-			Handler: Login(service),
+			Handler: Login(service, cookieAuth, trustProxyHeaders),
+		},
+		{
+			Path:    "/v1/auth/refresh",
+			Method:  http.MethodPost,
+			Handler: RefreshSession(service, cookieAuth, trustProxyHeaders),
+		},
+		{
+			Path:    "/v1/auth/logout",
+			Method:  http.MethodPost,
+			Handler: Logout(service, cookieAuth),
+		},
+		{
+			Path:    "/v1/auth/forgot-password",
+			Method:  http.MethodPost,
+			Handler: ForgotPassword(service),
+		},
+		{
+			Path:    "/v1/auth/reset-password",
+			Method:  http.MethodPost,
+			Handler: ResetPassword(service),
+		},
+		{
+			Path:    "/v1/auth/verify-email",
+			Method:  http.MethodPost,
+			Handler: VerifyEmail(service),
+		},
+		{
+			Path:    "/v1/auth/resend-verification",
+			Method:  http.MethodPost,
+			Handler: ResendEmailVerification(service),
 		},
 		{
 			Path:    "/v1/register",
@@ -89,19 +343,91 @@ func Authentication(service authenticating.Authenticator) []router.Route {
 			Path:        "/v1/users/:id/generate-password",
 			Method:      http.MethodPost,
 			Handler:     GeneratePassword(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 		{
 			Path:        "/v1/users/:id/change-password",
 			Method:      http.MethodPost,
 			Handler:     ChangePassword(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
 		},
 		{
 			Path:        "/v1/me",
 			Method:      http.MethodGet,
-			Handler:     GetMe(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Handler:     GetMe(service, roles),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/permissions",
+			Method:      http.MethodGet,
+			Handler:     GetMyPermissions(),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/api-keys",
+			Method:      http.MethodGet,
+			Handler:     ListAPIKeys(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/api-keys",
+			Method:      http.MethodPost,
+			Handler:     CreateAPIKey(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/api-keys/:id",
+			Method:      http.MethodDelete,
+			Handler:     RevokeAPIKey(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/sessions",
+			Method:      http.MethodGet,
+			Handler:     ListMySessions(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/sessions/:id",
+			Method:      http.MethodDelete,
+			Handler:     RevokeMySession(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+	}
+}
+
+// Roles retorna as rotas do cadastro de perfis e permissões
+func Roles(service authorizing.RoleManager) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/roles",
+			Method:      http.MethodGet,
+			Handler:     ListRoles(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/roles",
+			Method:      http.MethodPost,
+			Handler:     CreateRole(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/roles/:id",
+			Method:      http.MethodGet,
+			Handler:     GetRole(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/roles/:id",
+			Method:      http.MethodPut,
+			Handler:     UpdateRole(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/roles/:id",
+			Method:      http.MethodDelete,
+			Handler:     DeleteRole(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 	}
 }
@@ -112,66 +438,102 @@ func User(service authenticating.Authenticator) []router.Route {
 			Path:        "/v1/users",
 			Method:      http.MethodGet,
 			Handler:     ListUsers(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 		{
 			Path:        "/v1/users",
 			Method:      http.MethodPost,
 			Handler:     CreateUser(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/admin/users/import",
+			Method:      http.MethodPost,
+			Handler:     ImportUsers(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 		{
 			Path:        "/v1/users/:id",
 			Method:      http.MethodGet,
 			Handler:     GetUser(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
 		},
 		{
 			Path:        "/v1/users/:id",
 			Method:      http.MethodPut,
 			Handler:     UpdateUser(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/users/:id/sessions",
+			Method:      http.MethodGet,
+			Handler:     ListUserSessions(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
+		},
+		{
+			Path:        "/v1/users/:id/sessions/:session_id",
+			Method:      http.MethodDelete,
+			Handler:     RevokeUserSession(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 	}
 }
 
 // UserAccounts retorna as rotas para gerenciamento de contas vinculadas a usuários
-func UserAccounts(service authenticating.Authenticator) []router.Route {
+func UserAccounts(service authenticating.Authenticator, reachOverview insighting.ReachOverviewer, liveQuota middleware.UsageQuotaConfig) []router.Route {
 	return []router.Route{
 		{
 			Path:        "/v1/me/accounts",
 			Method:      http.MethodGet,
 			Handler:     GetUserAccounts(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AllRoles()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/reach-impressions",
+			Method:      http.MethodGet,
+			Handler:     GetMyReachImpressions(reachOverview, service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.UsageQuota(liveQuota)},
 		},
 		{
 			Path:        "/v1/users/:id/accounts",
 			Method:      http.MethodPut,
 			Handler:     UpdateUserAccounts(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 		{
 			Path:        "/v1/users/:id/accounts/link",
 			Method:      http.MethodPost,
 			Handler:     LinkUserAccount(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 		{
 			Path:        "/v1/users/:id/accounts/:account_id",
 			Method:      http.MethodDelete,
 			Handler:     UnlinkUserAccount(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOnly()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionUsersManage)},
 		},
 	}
 }
 
-func StoreRanking(service ranking.RankingService) []router.Route {
+// GraphQLRoutes retorna a rota de consultas GraphQL sobre contas, insights e ranking
+func GraphQLRoutes(schema *graphql.Schema, masker middleware.MaskedFieldsProvider, liveQuota middleware.UsageQuotaConfig) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/graphql",
+			Method:      http.MethodPost,
+			Handler:     GraphQL(schema, masker),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.UsageQuota(liveQuota)},
+		},
+	}
+}
+
+func StoreRanking(service ranking.RankingService, accounts LinkedAccountsProvider) []router.Route {
 	return []router.Route{
 		{
 			Path:        "/v1/stores/ranking/social-network-revenue",
 			Method:      http.MethodGet,
-			Handler:     GetStoreRanking(service),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOrSupervisor()},
+			Handler:     GetStoreRanking(service, accounts),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionSalesRead)},
 		},
 	}
 }
@@ -182,13 +544,348 @@ func CronJobs(services CronJobServices) []router.Route {
 			Path:        "/v1/cron/:type/run",
 			Method:      http.MethodPost,
 			Handler:     RunCronJob(services),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOrSupervisor()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/cron/:type/backfill",
+			Method:      http.MethodPost,
+			Handler:     RunBackfill(services),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
 		},
 		{
 			Path:        "/v1/cron/status",
 			Method:      http.MethodGet,
 			Handler:     GetCronStatus(services),
-			Middlewares: []func(http.Handler) http.Handler{middleware.AdminOrSupervisor()},
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/backfill",
+			Method:      http.MethodPost,
+			Handler:     RunSyncBackfill(services),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/accounts/:id",
+			Method:      http.MethodPost,
+			Handler:     RunAccountSync(services),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+	}
+}
+
+// Webhooks retorna as rotas de gerenciamento dos webhooks de saída
+func Webhooks(service notifying.WebhookService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/webhooks",
+			Method:      http.MethodGet,
+			Handler:     ListWebhookSubscriptions(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionWebhooksManage)},
+		},
+		{
+			Path:        "/v1/admin/webhooks",
+			Method:      http.MethodPost,
+			Handler:     CreateWebhookSubscription(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionWebhooksManage)},
+		},
+		{
+			Path:        "/v1/admin/webhooks/:id",
+			Method:      http.MethodDelete,
+			Handler:     DeleteWebhookSubscription(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionWebhooksManage)},
+		},
+	}
+}
+
+// SavedReports retorna as rotas de relatórios salvos do usuário autenticado
+func SavedReports(service reporting.SavedReportService, scheduleService reporting.ReportScheduleService, exportQuota middleware.UsageQuotaConfig) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/me/reports",
+			Method:      http.MethodGet,
+			Handler:     ListSavedReports(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports",
+			Method:      http.MethodPost,
+			Handler:     CreateSavedReport(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id",
+			Method:      http.MethodGet,
+			Handler:     GetSavedReport(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id",
+			Method:      http.MethodPut,
+			Handler:     UpdateSavedReport(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id",
+			Method:      http.MethodDelete,
+			Handler:     DeleteSavedReport(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id/run",
+			Method:      http.MethodPost,
+			Handler:     RunSavedReport(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.UsageQuota(exportQuota)},
+		},
+		{
+			Path:        "/v1/me/reports/:id/schedules",
+			Method:      http.MethodGet,
+			Handler:     ListReportSchedules(scheduleService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id/schedules",
+			Method:      http.MethodPost,
+			Handler:     CreateReportSchedule(scheduleService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+		{
+			Path:        "/v1/me/reports/:id/schedules/:schedule_id",
+			Method:      http.MethodDelete,
+			Handler:     DeleteReportSchedule(scheduleService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+	}
+}
+
+// Profile retorna as rotas de perfil do usuário
+func Profile(avatarService profile.AvatarService, preferencesService profile.PreferencesService, maxAvatarBytes int64) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/me/preferences",
+			Method:      http.MethodGet,
+			Handler:     GetPreferences(preferencesService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/preferences",
+			Method:      http.MethodPut,
+			Handler:     UpdatePreferences(preferencesService),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/users/:id/avatar",
+			Method:      http.MethodPost,
+			Handler:     UploadAvatar(avatarService, maxAvatarBytes),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+	}
+}
+
+// Uploads retorna a rota pública dos arquivos enviados quando o armazenamento local está ativo; sem ele
+// (nil), os arquivos são servidos pelo próprio storage (S3, CDN)
+func Uploads(files http.Handler) []router.Route {
+	if files == nil {
+		return nil
+	}
+
+	return []router.Route{
+		{
+			Path:    "/uploads/*filepath",
+			Method:  http.MethodGet,
+			Handler: files,
+		},
+	}
+}
+
+// Privacy retorna as rotas de atendimento a titulares de dados (LGPD)
+func Privacy(service privacy.ErasureService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/privacy/erase",
+			Method:      http.MethodPost,
+			Handler:     ErasePersonalData(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionPrivacyManage)},
+		},
+		{
+			Path:        "/v1/admin/privacy/requests",
+			Method:      http.MethodGet,
+			Handler:     ListErasureRequests(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionPrivacyManage)},
+		},
+	}
+}
+
+// Policy retorna as rotas de consulta e aceite dos termos de uso pelo próprio usuário
+func Policy(service privacy.PolicyService, trustProxyHeaders bool) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/me/policy",
+			Method:      http.MethodGet,
+			Handler:     GetMyPolicy(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+		{
+			Path:        "/v1/me/policy/accept",
+			Method:      http.MethodPost,
+			Handler:     AcceptMyPolicy(service, trustProxyHeaders),
+			Middlewares: []func(http.Handler) http.Handler{middleware.Authenticated()},
+		},
+	}
+}
+
+// AuditLog retorna a rota de consulta do log de auditoria
+func AuditLog(service auditing.AuditService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/audit-log",
+			Method:      http.MethodGet,
+			Handler:     ListAuditLog(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAuditRead)},
+		},
+	}
+}
+
+// SyncRuns retorna as rotas do histórico de execuções dos agendadores de sincronização
+func SyncRuns(service syncing.SyncRunService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/sync/runs",
+			Method:      http.MethodGet,
+			Handler:     ListSyncRuns(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/runs/:id",
+			Method:      http.MethodGet,
+			Handler:     GetSyncRun(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+	}
+}
+
+// SyncSchedulers retorna as rotas de pausa e retomada dos agendadores de sincronização em tempo de execução
+func SyncSchedulers(service syncing.SchedulerPauseService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/sync/schedulers",
+			Method:      http.MethodGet,
+			Handler:     ListSyncSchedulers(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/schedulers/:type/pause",
+			Method:      http.MethodPost,
+			Handler:     PauseSyncScheduler(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/schedulers/:type/resume",
+			Method:      http.MethodPost,
+			Handler:     ResumeSyncScheduler(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+	}
+}
+
+// SyncFailures retorna as rotas das datas que falharam em todas as tentativas da sincronização
+func SyncFailures(service syncing.SyncFailureService) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/admin/sync/failures",
+			Method:      http.MethodGet,
+			Handler:     ListSyncFailures(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+		{
+			Path:        "/v1/admin/sync/failures/:id/requeue",
+			Method:      http.MethodPost,
+			Handler:     RequeueSyncFailure(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionOperationsManage)},
+		},
+	}
+}
+
+// Search retorna a rota de busca global usada pela paleta de comandos do dashboard
+func Search(service searching.Searcher) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/search",
+			Method:      http.MethodGet,
+			Handler:     GlobalSearch(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead)},
+		},
+	}
+}
+
+func SyncSchedules(service syncing.SyncScheduleService, permissions middleware.AccountPermissionChecker) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/adAccount/:id/sync-schedules",
+			Method:      http.MethodGet,
+			Handler:     ListSyncSchedules(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/sync-schedules/:source",
+			Method:      http.MethodPut,
+			Handler:     SaveSyncSchedule(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionManager)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/sync-schedules/:source",
+			Method:      http.MethodDelete,
+			Handler:     DeleteSyncSchedule(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionAccountsWrite), middleware.RequireAccountPermission(permissions, domain.AccountPermissionManager)},
+		},
+	}
+}
+
+func RollupInsights(service insighting.RollupInsighter, permissions middleware.AccountPermissionChecker) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/adAccount/:id/insights/quarterly",
+			Method:      http.MethodGet,
+			Handler:     GetQuarterlyInsights(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+		{
+			Path:        "/v1/adAccount/:id/insights/yearly",
+			Method:      http.MethodGet,
+			Handler:     GetYearlyInsights(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+	}
+}
+
+func Dashboard(service dashboarding.DashboardService, permissions middleware.AccountPermissionChecker, liveQuota middleware.UsageQuotaConfig) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/adAccount/:id/dashboard",
+			Method:      http.MethodGet,
+			Handler:     GetAccountDashboard(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer), middleware.UsageQuota(liveQuota)},
+		},
+	}
+}
+
+func Anomalies(service insighting.AnomalyDetector, permissions middleware.AccountPermissionChecker) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/adAccount/:id/anomalies",
+			Method:      http.MethodGet,
+			Handler:     ListAnomalies(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
+		},
+	}
+}
+
+func Forecast(service insighting.Forecaster, permissions middleware.AccountPermissionChecker) []router.Route {
+	return []router.Route{
+		{
+			Path:        "/v1/adAccount/:id/forecast",
+			Method:      http.MethodGet,
+			Handler:     GetMonthForecast(service),
+			Middlewares: []func(http.Handler) http.Handler{middleware.RequirePermission(domain.PermissionInsightsRead), middleware.RequireAccountPermission(permissions, domain.AccountPermissionViewer)},
 		},
 	}
 }
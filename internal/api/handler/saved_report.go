@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// CreateSavedReport salva uma definição de relatório do usuário autenticado
+func CreateSavedReport(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		var req domain.CreateSavedReportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		report, err := service.CreateReport(userClaims.UserID, hasUnrestrictedAccountAccess(r.Context()), &req)
+		if err != nil {
+			switch {
+			case errors.Is(err, reporting.ErrInvalidReport), errors.Is(err, repository.ErrSavedReportExists):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), savedReportOptions())
+			case errors.Is(err, reporting.ErrAccountDenied):
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, err.Error(), nil)
+			default:
+				logger.WithError(err).Error("saved report: erro ao salvar relatório")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao salvar relatório", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// ListSavedReports lista os relatórios salvos do usuário autenticado
+func ListSavedReports(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		reports, err := service.ListReports(userClaims.UserID)
+		if err != nil {
+			logger.WithError(err).Error("saved report: erro ao listar relatórios")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar relatórios", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reports); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// GetSavedReport retorna a definição de um relatório salvo
+func GetSavedReport(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		report, err := service.GetReport(userClaims.UserID, reportID)
+		if err != nil {
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// UpdateSavedReport substitui a definição de um relatório salvo
+func UpdateSavedReport(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		var req domain.CreateSavedReportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		report, err := service.UpdateReport(userClaims.UserID, hasUnrestrictedAccountAccess(r.Context()), reportID, &req)
+		if err != nil {
+			switch {
+			case errors.Is(err, reporting.ErrInvalidReport), errors.Is(err, repository.ErrSavedReportExists):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), savedReportOptions())
+			case errors.Is(err, reporting.ErrAccountDenied):
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, err.Error(), nil)
+			default:
+				writeSavedReportError(w, r, err)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteSavedReport remove um relatório salvo
+func DeleteSavedReport(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		if err := service.DeleteReport(userClaims.UserID, reportID); err != nil {
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RunSavedReport executa um relatório salvo com o período resolvido na data atual
+func RunSavedReport(service reporting.SavedReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		run, err := service.RunReport(userClaims.UserID, hasUnrestrictedAccountAccess(r.Context()), reportID)
+		if err != nil {
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(run); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// CreateReportSchedule agenda o envio recorrente de um relatório salvo.
+// Para o canal webhook, o segredo de assinatura só é exibido nesta resposta.
+func CreateReportSchedule(service reporting.ReportScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		var req domain.CreateReportScheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		schedule, err := service.CreateSchedule(userClaims.UserID, reportID, &req)
+		if err != nil {
+			if errors.Is(err, reporting.ErrInvalidSchedule) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+					"available_frequencies": domain.ScheduleFrequencies,
+					"available_channels":    domain.DeliveryChannels,
+				})
+				return
+			}
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		logger.WithFields(log.Fields{
+			"report_id":   reportID,
+			"schedule_id": schedule.ID,
+			"channel":     schedule.Channel,
+		}).Info("saved report: agendamento criado")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(schedule); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// ListReportSchedules lista os agendamentos de um relatório salvo (sem os segredos)
+func ListReportSchedules(service reporting.ReportScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		schedules, err := service.ListSchedules(userClaims.UserID, reportID)
+		if err != nil {
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(schedules); err != nil {
+			logger.WithError(err).Error("saved report: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteReportSchedule remove um agendamento de relatório
+func DeleteReportSchedule(service reporting.ReportScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, reportID, ok := savedReportParams(w, r)
+		if !ok {
+			return
+		}
+
+		scheduleID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("schedule_id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID do agendamento inválido", nil)
+			return
+		}
+
+		if err := service.DeleteSchedule(userClaims.UserID, reportID, scheduleID); err != nil {
+			writeSavedReportError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// hasUnrestrictedAccountAccess indica os perfis que acessam todas as contas sem vínculo (accounts:all)
+func hasUnrestrictedAccountAccess(ctx context.Context) bool {
+	return middleware.HasPermission(ctx, domain.PermissionAccountsAll)
+}
+
+func savedReportParams(w http.ResponseWriter, r *http.Request) (*domain.Claims, int, bool) {
+	userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+	if !ok {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+		return nil, 0, false
+	}
+
+	reportID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+	if err != nil {
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID do relatório inválido", nil)
+		return nil, 0, false
+	}
+
+	return userClaims, reportID, true
+}
+
+// savedReportOptions lista os valores aceitos na definição, devolvidos junto com erros de validação
+func savedReportOptions() map[string]any {
+	return map[string]any{
+		"available_metrics":     domain.ReportMetrics,
+		"available_periods":     domain.PeriodPresets,
+		"available_comparisons": domain.ReportComparisons,
+		"available_breakdowns":  domain.ReportBreakdowns,
+	}
+}
+
+func writeSavedReportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, reporting.ErrReportNotFound), errors.Is(err, reporting.ErrScheduleNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, err.Error(), nil)
+	case errors.Is(err, reporting.ErrInvalidReport):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+	default:
+		log.ForContext(r.Context()).WithError(err).Error("saved report: erro ao processar relatório")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao processar relatório", nil)
+	}
+}
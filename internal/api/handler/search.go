@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/searching"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// GlobalSearch pesquisa contas, campanhas, business managers e, para administradores, usuários.
+// Aceita q (obrigatório), type (lista separada por vírgula) e limit (máximo por tipo).
+func GlobalSearch(service searching.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		query := r.URL.Query()
+		req := &searching.SearchRequest{
+			Query:        query.Get("q"),
+			UserID:       userClaims.UserID,
+			Unrestricted: hasUnrestrictedAccountAccess(r.Context()),
+			IncludeUsers: middleware.HasPermission(r.Context(), domain.PermissionUsersManage),
+		}
+
+		if types := query.Get("type"); types != "" {
+			req.Types = strings.Split(types, ",")
+		}
+
+		if limit := query.Get("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro limit inválido", nil)
+				return
+			}
+			req.Limit = parsed
+		}
+
+		response, err := service.Search(req)
+		if err != nil {
+			if errors.Is(err, searching.ErrInvalidQuery) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+					"available_types": domain.SearchTypes,
+				})
+				return
+			}
+			logger.WithError(err).Error("search: erro ao executar busca")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao executar busca", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.WithError(err).Error("search: erro ao enviar resposta")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+)
+
+// maxUserAgentLength é o tamanho da coluna user_agent dos refresh tokens
+const maxUserAgentLength = 255
+
+// sessionClientFromRequest identifica o dispositivo que abriu ou renovou a sessão
+func sessionClientFromRequest(r *http.Request, trustProxyHeaders bool) domain.SessionClient {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return domain.SessionClient{
+		IPAddress: middleware.ClientIP(r, trustProxyHeaders),
+		UserAgent: userAgent,
+	}
+}
+
+// ListMySessions lista as sessões ativas do usuário autenticado, marcando a da própria requisição
+func ListMySessions(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		writeSessions(w, r, service, userClaims.UserID, userClaims.SessionID)
+	}
+}
+
+// RevokeMySession encerra uma sessão do usuário autenticado, por exemplo a de um dispositivo perdido
+func RevokeMySession(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Não autorizado", nil)
+			return
+		}
+
+		sessionID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+		revokeSession(w, r, service, userClaims.UserID, sessionID)
+	}
+}
+
+// ListUserSessions lista as sessões ativas de um usuário (administração)
+func ListUserSessions(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID de usuário inválido", nil)
+			return
+		}
+
+		writeSessions(w, r, service, userID, "")
+	}
+}
+
+// RevokeUserSession encerra uma sessão de um usuário (administração)
+func RevokeUserSession(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		userID, err := strconv.Atoi(params.ByName("id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID de usuário inválido", nil)
+			return
+		}
+
+		revokeSession(w, r, service, userID, params.ByName("session_id"))
+	}
+}
+
+func writeSessions(w http.ResponseWriter, r *http.Request, service authenticating.Authenticator, userID int, currentSessionID string) {
+	sessions, err := service.ListSessions(userID, currentSessionID)
+	if err != nil {
+		log.ForContext(r.Context()).WithError(err).Error("session: erro ao listar sessões")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar sessões", nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessions); err != nil {
+		log.ForContext(r.Context()).WithError(err).Error("session: erro ao enviar resposta")
+	}
+}
+
+func revokeSession(w http.ResponseWriter, r *http.Request, service authenticating.Authenticator, userID int, sessionID string) {
+	if err := service.RevokeSession(userID, sessionID, actorIDFromRequest(r)); err != nil {
+		if errors.Is(err, authenticating.ErrSessionNotFound) {
+			apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Sessão não encontrada", nil)
+			return
+		}
+		log.ForContext(r.Context()).WithError(err).Error("session: erro ao encerrar sessão")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao encerrar sessão", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,19 +1,33 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
 	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
 )
 
+// LinkedAccountsProvider lista as contas vinculadas ao usuário
+type LinkedAccountsProvider interface {
+	GetUserLinkedAccounts(userID int) ([]*domain.AdAccountResponse, error)
+}
+
 // GetStoreRanking retorna o ranking das lojas por receita de redes sociais
-func GetStoreRanking(service ranking.RankingService) http.HandlerFunc {
+func GetStoreRanking(service ranking.RankingService, accounts LinkedAccountsProvider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Buscar o ranking das lojas
-		ranking, err := service.GetStoreRanking()
+		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
+		if !ok {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+			return
+		}
+
+		// Buscar o ranking das lojas que o usuário pode ver
+		ranking, err := storeRankingForUser(r.Context(), service, accounts, userClaims.UserID)
 		if err != nil {
 			logrus.Error("Erro ao buscar ranking das lojas:", err)
 			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao buscar ranking das lojas", nil)
@@ -35,3 +49,24 @@ func GetStoreRanking(service ranking.RankingService) http.HandlerFunc {
 		}
 	}
 }
+
+// storeRankingForUser retorna o ranking completo para perfis com accounts:all e, para os demais, apenas as
+// lojas das contas vinculadas ao usuário, com as posições do ranking geral
+func storeRankingForUser(ctx context.Context, service ranking.RankingService, accounts LinkedAccountsProvider, userID int) (*domain.StoreRankingResponse, error) {
+	storeRanking, err := service.GetStoreRanking()
+	if err != nil || storeRanking == nil || hasUnrestrictedAccountAccess(ctx) {
+		return storeRanking, err
+	}
+
+	linked, err := accounts.GetUserLinkedAccounts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountIDs := make(map[string]bool, len(linked))
+	for _, account := range linked {
+		accountIDs[account.ID] = true
+	}
+
+	return storeRanking.ForAccounts(accountIDs), nil
+}
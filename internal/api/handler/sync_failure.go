@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/scheduler"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListSyncFailures lista as datas de contas que falharam em todas as tentativas da sincronização, das mais
+// recentes para as mais antigas. Aceita os filtros source, account_id, limit e offset.
+func ListSyncFailures(service syncing.SyncFailureService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+		query := r.URL.Query()
+
+		filters := domain.SyncFailureFilters{
+			Source:    query.Get("source"),
+			AccountID: query.Get("account_id"),
+		}
+
+		for param, target := range map[string]*int{"limit": &filters.Limit, "offset": &filters.Offset} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" inválido", nil)
+				return
+			}
+			*target = parsed
+		}
+
+		page, err := service.ListFailures(filters)
+		if err != nil {
+			if errors.Is(err, syncing.ErrInvalidFailureFilter) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).Error("sync_failure: erro ao listar falhas")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar falhas de sincronização", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			logger.WithError(err).Error("sync_failure: erro ao enviar resposta")
+		}
+	}
+}
+
+// RequeueSyncFailure sincroniza novamente, em background, as datas com falha da conta e origem da falha informada
+func RequeueSyncFailure(service syncing.SyncFailureService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		id, err := strconv.ParseInt(httprouter.ParamsFromContext(r.Context()).ByName("id"), 10, 64)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID de falha inválido", nil)
+			return
+		}
+
+		requeue, err := service.RequeueFailure(id, actorIDFromRequest(r))
+		if err != nil {
+			switch {
+			case errors.Is(err, syncing.ErrSyncFailureNotFound), errors.Is(err, scheduler.ErrNoSyncFailures):
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Falha de sincronização não encontrada", nil)
+			case errors.Is(err, scheduler.ErrAccountSyncNotFound), errors.Is(err, scheduler.ErrAccountSyncRunning),
+				errors.Is(err, scheduler.ErrSchedulerPaused):
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+			default:
+				logger.WithError(err).WithField("failure_id", id).Error("sync_failure: erro ao sincronizar falha novamente")
+				apiErrors.WriteError(w, apiErrors.ErrInternalServer, "Erro ao sincronizar falha novamente", nil)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(requeue); err != nil {
+			logger.WithError(err).Error("sync_failure: erro ao enviar resposta")
+		}
+	}
+}
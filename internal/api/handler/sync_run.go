@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListSyncRuns lista as execuções dos agendadores de sincronização, da mais recente para a mais antiga, com o
+// resumo das contas. Aceita os filtros type, status, from e to (RFC3339, pelo início), limit e offset.
+func ListSyncRuns(service syncing.SyncRunService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+		query := r.URL.Query()
+
+		filters := domain.JobFilters{
+			Type:   query.Get("type"),
+			Status: query.Get("status"),
+		}
+
+		for param, target := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" deve estar no formato RFC3339", nil)
+				return
+			}
+			*target = &parsed
+		}
+
+		for param, target := range map[string]*int{"limit": &filters.Limit, "offset": &filters.Offset} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "Parâmetro "+param+" inválido", nil)
+				return
+			}
+			*target = parsed
+		}
+
+		page, err := service.ListRuns(filters)
+		if err != nil {
+			if errors.Is(err, syncing.ErrInvalidRunFilter) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			logger.WithError(err).Error("sync_run: erro ao listar execuções")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar execuções", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			logger.WithError(err).Error("sync_run: erro ao enviar resposta")
+		}
+	}
+}
+
+// GetSyncRun retorna uma execução com o resultado e o motivo da falha de cada conta
+func GetSyncRun(service syncing.SyncRunService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		id, err := strconv.ParseInt(httprouter.ParamsFromContext(r.Context()).ByName("id"), 10, 64)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID de execução inválido", nil)
+			return
+		}
+
+		run, err := service.GetRun(id)
+		if err != nil {
+			if errors.Is(err, syncing.ErrRunNotFound) {
+				apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Execução não encontrada", nil)
+				return
+			}
+			logger.WithError(err).Error("sync_run: erro ao buscar execução")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao buscar execução", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(run); err != nil {
+			logger.WithError(err).Error("sync_run: erro ao enviar resposta")
+		}
+	}
+}
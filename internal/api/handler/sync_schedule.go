@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListSyncSchedules lista as agendas de sincronização próprias da conta
+func ListSyncSchedules(service syncing.SyncScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+		schedules, err := service.ListSchedules(accountID)
+		if err != nil {
+			writeSyncScheduleError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(schedules); err != nil {
+			logger.WithError(err).Error("sync schedule: erro ao enviar resposta")
+		}
+	}
+}
+
+// SaveSyncSchedule cria ou substitui a agenda da conta para a origem (meta ou ssotica)
+func SaveSyncSchedule(service syncing.SyncScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		params := httprouter.ParamsFromContext(r.Context())
+
+		var req domain.AccountSyncScheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		schedule, err := service.SaveSchedule(params.ByName("id"), params.ByName("source"), &req)
+		if err != nil {
+			writeSyncScheduleError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(schedule); err != nil {
+			logger.WithError(err).Error("sync schedule: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteSyncSchedule remove a agenda da conta, que volta a ser sincronizada pelo cron global
+func DeleteSyncSchedule(service syncing.SyncScheduleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+
+		if err := service.DeleteSchedule(params.ByName("id"), params.ByName("source")); err != nil {
+			writeSyncScheduleError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeSyncScheduleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, syncing.ErrInvalidSchedule):
+		apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+			"available_sources": domain.SyncSources,
+		})
+	case errors.Is(err, syncing.ErrScheduleNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Agenda de sincronização não encontrada", nil)
+	case errors.Is(err, syncing.ErrAccountNotFound):
+		apiErrors.WriteError(w, apiErrors.ErrResourceNotFound, "Conta não encontrada", nil)
+	default:
+		log.ForContext(r.Context()).WithError(err).Error("sync schedule: erro ao acessar agendas de sincronização")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao acessar agendas de sincronização", nil)
+	}
+}
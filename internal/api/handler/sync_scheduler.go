@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListSyncSchedulers lista os agendadores de sincronização e se cada um está pausado
+func ListSyncSchedulers(service syncing.SchedulerPauseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		states, err := service.ListSchedulers()
+		if err != nil {
+			logger.WithError(err).Error("sync_scheduler: erro ao listar agendadores")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar agendadores", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(states); err != nil {
+			logger.WithError(err).Error("sync_scheduler: erro ao enviar resposta")
+		}
+	}
+}
+
+// PauseSyncScheduler pausa o agendador em todas as instâncias da API, com o motivo opcional no corpo
+func PauseSyncScheduler(service syncing.SchedulerPauseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+		scheduler := httprouter.ParamsFromContext(r.Context()).ByName("type")
+
+		var req domain.SchedulerPauseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		state, err := service.PauseScheduler(scheduler, req.Reason, actorIDFromRequest(r))
+		writeSchedulerState(w, logger, state, err)
+	}
+}
+
+// ResumeSyncScheduler retoma o agendador pausado, a partir da próxima ocorrência do cron
+func ResumeSyncScheduler(service syncing.SchedulerPauseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+		scheduler := httprouter.ParamsFromContext(r.Context()).ByName("type")
+
+		state, err := service.ResumeScheduler(scheduler, actorIDFromRequest(r))
+		writeSchedulerState(w, logger, state, err)
+	}
+}
+
+func writeSchedulerState(w http.ResponseWriter, logger log.Logger, state *domain.SchedulerState, err error) {
+	if err != nil {
+		if errors.Is(err, syncing.ErrInvalidScheduler) {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, err.Error(), nil)
+			return
+		}
+		logger.WithError(err).Error("sync_scheduler: erro ao alterar pausa do agendador")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao alterar pausa do agendador", nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		logger.WithError(err).Error("sync_scheduler: erro ao enviar resposta")
+	}
+}
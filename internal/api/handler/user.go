@@ -120,9 +120,8 @@ func CreateUser(service authenticating.Authenticator) http.HandlerFunc {
 func ListUsers(service authenticating.Authenticator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Verificar se o usuário que faz a requisição é um administrador
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
-			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem listar todos os usuários", nil)
+		if !middleware.HasPermission(r.Context(), domain.PermissionUsersManage) {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para listar todos os usuários", nil)
 			return
 		}
 
@@ -164,9 +163,10 @@ func UpdateUser(service authenticating.Authenticator) http.HandlerFunc {
 			return
 		}
 
-		// Verificar permissões: o usuário pode editar apenas seu próprio perfil, a menos que seja admin
+		// Verificar permissões: o usuário pode editar apenas seu próprio perfil, a menos que gerencie usuários
+		canManageUsers := middleware.HasPermission(r.Context(), domain.PermissionUsersManage)
 		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || (userClaims.UserID != id && userClaims.UserRoleID != 1) {
+		if !ok || (userClaims.UserID != id && !canManageUsers) {
 			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para editar este usuário", nil)
 			return
 		}
@@ -182,14 +182,14 @@ func UpdateUser(service authenticating.Authenticator) http.HandlerFunc {
 		// Definir o ID do usuário a ser atualizado
 		updateReq.ID = id
 
-		// Restringir alterações de RoleID apenas para administradores
-		if updateReq.RoleID != nil && userClaims.UserRoleID != 1 {
-			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem alterar o tipo de usuário", nil)
+		// Restringir alterações de RoleID a quem gerencia usuários
+		if updateReq.RoleID != nil && !canManageUsers {
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para alterar o tipo de usuário", nil)
 			return
 		}
 
 		// Atualizar o usuário
-		err = service.UpdateUser(&updateReq)
+		err = service.UpdateUser(&updateReq, userClaims.UserID)
 		if err != nil {
 			logrus.Error(err)
 			if err.Error() == "email already exists" {
@@ -15,6 +15,8 @@ import (
 
 type UserAccountsRequest struct {
 	AccountIDs []string `json:"account_ids"`
+	// Permission é o nível de acesso (viewer, analyst ou manager) aplicado às contas informadas
+	Permission string `json:"permission,omitempty"`
 }
 
 // GetUserAccounts retorna as contas vinculadas a um usuário
@@ -61,8 +63,7 @@ func UpdateUserAccounts(service authenticating.Authenticator) http.HandlerFunc {
 		}
 
 		// Verificar permissões: apenas administradores podem alterar contas vinculadas
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
+		if !middleware.HasPermission(r.Context(), domain.PermissionUsersManage) {
 			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem alterar as contas vinculadas", nil)
 			return
 		}
@@ -75,8 +76,17 @@ func UpdateUserAccounts(service authenticating.Authenticator) http.HandlerFunc {
 			return
 		}
 
+		var permission domain.AccountPermission
+		if req.Permission != "" {
+			permission, err = domain.ParseAccountPermission(req.Permission)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+		}
+
 		// Atualizar contas vinculadas
-		err = service.ManageUserAccounts(id, req.AccountIDs)
+		err = service.ManageUserAccounts(id, req.AccountIDs, permission, actorIDFromRequest(r))
 		if err != nil {
 			logrus.Error(err)
 			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao atualizar contas vinculadas", nil)
@@ -118,8 +128,7 @@ func LinkUserAccount(service authenticating.Authenticator) http.HandlerFunc {
 		}
 
 		// Verificar permissões: apenas administradores podem vincular contas
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
+		if !middleware.HasPermission(r.Context(), domain.PermissionUsersManage) {
 			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem vincular contas", nil)
 			return
 		}
@@ -137,12 +146,18 @@ func LinkUserAccount(service authenticating.Authenticator) http.HandlerFunc {
 			return
 		}
 
+		permission, err := domain.ParseAccountPermission(req.Permission)
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+			return
+		}
+
 		// Vincular cada conta da lista ao usuário
 		var successfulLinks []string
 		var failedLinks []string
 
 		for _, accountID := range req.AccountIDs {
-			err = service.LinkUserAccount(userID, accountID)
+			err = service.LinkUserAccount(userID, accountID, permission, actorIDFromRequest(r))
 			if err != nil {
 				logrus.Warnf("Erro ao vincular conta %s ao usuário %d: %v", accountID, userID, err)
 				failedLinks = append(failedLinks, accountID)
@@ -157,6 +172,7 @@ func LinkUserAccount(service authenticating.Authenticator) http.HandlerFunc {
 			"message":          "Contas vinculadas processadas",
 			"user_id":          userID,
 			"successful_links": successfulLinks,
+			"permission":       permission,
 		}
 
 		if len(failedLinks) > 0 {
@@ -191,14 +207,13 @@ func UnlinkUserAccount(service authenticating.Authenticator) http.HandlerFunc {
 		}
 
 		// Verificar permissões: apenas administradores podem desvincular contas
-		userClaims, ok := r.Context().Value(middleware.ContextKeyUser).(*domain.Claims)
-		if !ok || userClaims.UserRoleID != 1 {
+		if !middleware.HasPermission(r.Context(), domain.PermissionUsersManage) {
 			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Apenas administradores podem desvincular contas", nil)
 			return
 		}
 
 		// Desvincular conta
-		err = service.UnlinkUserAccount(userID, accountID)
+		err = service.UnlinkUserAccount(userID, accountID, actorIDFromRequest(r))
 		if err != nil {
 			logrus.Error(err)
 			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao desvincular conta", nil)
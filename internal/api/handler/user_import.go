@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// maxUserImportBytes limita o corpo da importação em lote (CSV ou JSON)
+const maxUserImportBytes = 1 << 20
+
+// ImportUsers cria usuários em lote a partir de um CSV (Content-Type text/csv) ou de uma lista JSON.
+// Responde 201 com o relatório por linha, ou 422 com os erros de cada linha quando nada foi criado.
+func ImportUsers(service authenticating.Authenticator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := http.MaxBytesReader(w, r.Body, maxUserImportBytes)
+
+		var rows []domain.UserImportRow
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv", "application/csv":
+			parsed, err := domain.ParseUserImportCSV(body)
+			if err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), nil)
+				return
+			}
+			rows = parsed
+		default:
+			if err := json.NewDecoder(body).Decode(&rows); err != nil {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido: envie uma lista JSON de usuários ou um CSV (text/csv)", nil)
+				return
+			}
+		}
+
+		report, err := service.ImportUsers(rows, actorIDFromRequest(r))
+		if err != nil {
+			var authErr *authenticating.AuthError
+			if errors.As(err, &authErr) && authErr.Code != apiErrors.ErrDatabaseOperation && authErr.Code != apiErrors.ErrInternalServer {
+				apiErrors.WriteError(w, authErr.Code, authErr.Details, nil)
+				return
+			}
+			log.ForContext(r.Context()).WithError(err).Error("user import: erro ao importar usuários")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao importar usuários", nil)
+			return
+		}
+
+		status := http.StatusCreated
+		if report.Failed > 0 {
+			status = http.StatusUnprocessableEntity
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			log.ForContext(r.Context()).WithError(err).Error("user import: erro ao enviar resposta")
+		}
+	}
+}
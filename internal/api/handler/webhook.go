@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/log"
+)
+
+// ListWebhookSubscriptions lista os destinos de webhook cadastrados (sem os segredos)
+func ListWebhookSubscriptions(service notifying.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		subscriptions, err := service.ListSubscriptions()
+		if err != nil {
+			logger.WithError(err).Error("webhook: erro ao listar assinaturas")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao listar webhooks", nil)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(subscriptions); err != nil {
+			logger.WithError(err).Error("webhook: erro ao enviar resposta")
+		}
+	}
+}
+
+// CreateWebhookSubscription cadastra um destino de webhook. O segredo de assinatura só é exibido nesta resposta.
+func CreateWebhookSubscription(service notifying.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		var req domain.CreateWebhookSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidRequest, "Formato de requisição inválido", nil)
+			return
+		}
+
+		subscription, err := service.CreateSubscription(&req)
+		if err != nil {
+			if errors.Is(err, notifying.ErrInvalidWebhookURL) || errors.Is(err, notifying.ErrInvalidWebhookEvent) {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, err.Error(), map[string]any{
+					"available_events": domain.WebhookEventTypes,
+				})
+				return
+			}
+
+			logger.WithError(err).Error("webhook: erro ao criar assinatura")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao cadastrar webhook", nil)
+			return
+		}
+
+		logger.WithFields(log.Fields{
+			"subscription_id": subscription.ID,
+			"events":          subscription.Events,
+		}).Info("webhook: assinatura criada")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(subscription); err != nil {
+			logger.WithError(err).Error("webhook: erro ao enviar resposta")
+		}
+	}
+}
+
+// DeleteWebhookSubscription remove um destino de webhook
+func DeleteWebhookSubscription(service notifying.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.ForContext(r.Context())
+
+		id, err := strconv.Atoi(httprouter.ParamsFromContext(r.Context()).ByName("id"))
+		if err != nil {
+			apiErrors.WriteError(w, apiErrors.ErrInvalidFormat, "ID do webhook inválido", nil)
+			return
+		}
+
+		if err := service.DeleteSubscription(id); err != nil {
+			logger.WithError(err).WithField("subscription_id", id).Error("webhook: erro ao remover assinatura")
+			apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao remover webhook", nil)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
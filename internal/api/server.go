@@ -12,33 +12,88 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/justinas/alice"
 	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/captcha"
 	"github.com/vfg2006/traffic-manager-api/internal/api/handler"
 	"github.com/vfg2006/traffic-manager-api/internal/api/handler/router"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/scheduler"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/account"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/annotating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/dashboarding"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/goaling"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/masking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/monitoring"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/privacy"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/profile"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/searching"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/syncing"
 	"github.com/vfg2006/traffic-manager-api/pkg/middleware"
+	"github.com/vfg2006/traffic-manager-api/pkg/ratelimit"
 )
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 type Server struct {
 	httpServer *http.Server
+	// scheduler é parado depois do servidor HTTP, aguardando as sincronizações em andamento
+	scheduler *scheduler.Scheduler
+	shutdown  config.SchedulerShutdown
 }
 
 func New(
 	config *config.Config,
 	insightService insighting.CombinedInsighter,
+	annotationService annotating.AnnotationService,
+	goalService goaling.GoalService,
+	dashboardService dashboarding.DashboardService,
+	rollupService insighting.RollupInsighter,
 	accountService account.AccountService,
+	activityService activity.ActivityService,
+	catalogService insighting.CampaignCataloger,
+	breakdownService insighting.BreakdownInsighter,
+	businessManagerInsightService insighting.BusinessManagerInsighter,
+	anomalyService insighting.AnomalyDetector,
+	forecastService insighting.Forecaster,
+	reachOverviewService insighting.ReachOverviewer,
 	rankingService ranking.RankingService,
+	deckService reporting.DeckGenerator,
+	workbookService reporting.WorkbookGenerator,
+	pdfReportService reporting.PDFReportGenerator,
+	dailyExporter reporting.DailyInsightExporter,
+	savedReportService reporting.SavedReportService,
+	reportScheduleService reporting.ReportScheduleService,
+	webhookService notifying.WebhookService,
+	erasureService privacy.ErasureService,
+	policyService privacy.PolicyService,
+	auditService auditing.AuditService,
+	fieldMasker masking.FieldMasker,
+	policy authorizing.Policy,
+	roleService authorizing.RoleManager,
+	avatarService profile.AvatarService,
+	preferencesService profile.PreferencesService,
+	uploadedFiles http.Handler,
+	searchService searching.Searcher,
+	syncScheduleService syncing.SyncScheduleService,
+	syncRunService syncing.SyncRunService,
+	syncPauseService syncing.SchedulerPauseService,
+	syncFailureService syncing.SyncFailureService,
 	authenticator authenticating.Authenticator,
+	captchaClient captcha.Client,
 	metaSyncService *scheduler.MetaInsightSyncService,
 	ssoticaSyncService *scheduler.SSOticaInsightSyncService,
 	monthlyInsightsSyncService *scheduler.MonthlyInsightsSyncService,
 	topRankingAccountsSyncService *scheduler.TopRankingAccountsService,
+	reportScheduleRunner *scheduler.ReportScheduleService,
+	appScheduler *scheduler.Scheduler,
+	dependencyMonitor monitoring.DependencyMonitor,
 ) (*Server, error) {
 	// Inicializar o struct com os serviços de cron jobs
 	cronServices := handler.CronJobServices{
@@ -46,24 +101,88 @@ func New(
 		SSOticaInsightSyncService:     ssoticaSyncService,
 		MonthlyInsightsSyncService:    monthlyInsightsSyncService,
 		TopRankingAccountsSyncService: topRankingAccountsSyncService,
+		ReportScheduleService:         reportScheduleRunner,
+		Scheduler:                     appScheduler,
+		AuditRecorder:                 auditService,
 	}
 
+	cookieAuth := middleware.CookieAuthConfig{
+		Enabled:  config.Security.CookieAuthEnabled,
+		Domain:   config.Security.CookieAuthDomain,
+		Secure:   config.Security.CookieAuthSecure,
+		SameSite: middleware.ParseSameSite(config.Security.CookieAuthSameSite),
+		MaxAge:   config.Auth.SessionMaxLifetime,
+	}
+
+	loginCaptcha := middleware.LoginCaptchaConfig{
+		MaxFailures:       config.Security.CaptchaFailedAttempts,
+		TrustProxyHeaders: config.Security.TrustProxyHeaders,
+	}
+	if captchaClient != nil {
+		loginCaptcha.Verifier = captchaClient
+		loginCaptcha.Failures = ratelimit.NewCounter(config.Security.CaptchaFailureWindow)
+	}
+
+	authRateLimit := middleware.NewAuthRateLimitConfig(
+		config.Security.AuthRateLimitPerIP,
+		config.Security.AuthRateLimitPerUser,
+		config.Security.AuthRateLimitWindow,
+		config.Security.TrustProxyHeaders,
+	)
+
+	// Cotas diárias por usuário das rotas que consultam a Meta ou geram exportações
+	liveInsightsQuota := middleware.NewUsageQuotaConfig("live_insights", config.UsageQuotas.LiveInsightsDaily)
+	exportQuota := middleware.NewUsageQuotaConfig("exports", config.UsageQuotas.ExportsDaily)
+
 	rt := router.New(
 		router.WithRoutes(handler.Healthcheck()...),
-		router.WithRoutes(handler.Authentication(authenticator)...),
+		router.WithRoutes(handler.Readiness(dependencyMonitor)...),
+		router.WithRoutes(handler.Authentication(authenticator, roleService, cookieAuth, config.Security.TrustProxyHeaders)...),
 		router.WithRoutes(handler.User(authenticator)...),
-		router.WithRoutes(handler.Insights(insightService)...),
-		router.WithRoutes(handler.AdAccounts(accountService)...),
-		router.WithRoutes(handler.UserAccounts(authenticator)...),
-		router.WithRoutes(handler.StoreRanking(rankingService)...),
+		router.WithRoutes(handler.Roles(roleService)...),
+		router.WithRoutes(handler.Insights(insightService, annotationService, goalService, activityService, authenticator, liveInsightsQuota)...),
+		router.WithRoutes(handler.Objectives()...),
+		router.WithRoutes(handler.Reports(deckService, workbookService, pdfReportService, dailyExporter, fieldMasker, authenticator, exportQuota)...),
+		router.WithRoutes(handler.SavedReports(savedReportService, reportScheduleService, exportQuota)...),
+		router.WithRoutes(handler.AdAccounts(accountService, activityService, catalogService, breakdownService, businessManagerInsightService, authenticator, liveInsightsQuota)...),
+		router.WithRoutes(handler.UserAccounts(authenticator, reachOverviewService, liveInsightsQuota)...),
+		router.WithRoutes(handler.StoreRanking(rankingService, authenticator)...),
+		router.WithRoutes(handler.GraphQLRoutes(handler.NewGraphQLSchema(insightService, accountService, rankingService, authenticator, authenticator), fieldMasker, liveInsightsQuota)...),
 		router.WithRoutes(handler.CronJobs(cronServices)...),
+		router.WithRoutes(handler.Webhooks(webhookService)...),
+		router.WithRoutes(handler.Privacy(erasureService)...),
+		router.WithRoutes(handler.Policy(policyService, config.Security.TrustProxyHeaders)...),
+		router.WithRoutes(handler.AuditLog(auditService)...),
+		router.WithRoutes(handler.Profile(avatarService, preferencesService, config.Storage.AvatarMaxUploadBytes)...),
+		router.WithRoutes(handler.Uploads(uploadedFiles)...),
+		router.WithRoutes(handler.Search(searchService)...),
+		router.WithRoutes(handler.SyncSchedules(syncScheduleService, authenticator)...),
+		router.WithRoutes(handler.SyncRuns(syncRunService)...),
+		router.WithRoutes(handler.SyncSchedulers(syncPauseService)...),
+		router.WithRoutes(handler.SyncFailures(syncFailureService)...),
+		router.WithRoutes(handler.RollupInsights(rollupService, authenticator)...),
+		router.WithRoutes(handler.Dashboard(dashboardService, authenticator, liveInsightsQuota)...),
+		router.WithRoutes(handler.Anomalies(anomalyService, authenticator)...),
+		router.WithRoutes(handler.Forecast(forecastService, authenticator)...),
 	)
 
+	ipAllowlist, err := middleware.IPAllowlist(config.Security.AdminIPAllowlist, config.Security.TrustProxyHeaders)
+	if err != nil {
+		return nil, err
+	}
+
 	middlewares := []alice.Constructor{
 		middleware.LogPanicMiddleware(),
 		middleware.LoggingMiddleware(),
+		middleware.SecurityHeaders(),
 		middleware.Cors(),
-		middleware.AuthMiddleware(authenticator),
+		ipAllowlist,
+		middleware.AuthRateLimit(authRateLimit),
+		middleware.LoginCaptcha(loginCaptcha),
+		middleware.AuthMiddleware(authenticator, cookieAuth),
+		middleware.PolicyAcceptance(policyService),
+		middleware.Permissions(policy),
+		middleware.FieldMasking(fieldMasker),
 	}
 
 	handler := alice.New(middlewares...).Then(rt)
@@ -74,6 +193,8 @@ func New(
 			Handler:           handler,
 			ReadHeaderTimeout: 2 * time.Second,
 		},
+		scheduler: appScheduler,
+		shutdown:  config.SchedulerShutdown,
 	}
 
 	return srv, nil
@@ -132,5 +253,16 @@ func (s Server) Shutdown(ctx context.Context) error {
 	}
 
 	logrus.Info("Servidor HTTP desligado com sucesso")
+
+	// Sem o servidor HTTP, não há novas execuções manuais; as em andamento podem terminar ou gravar o checkpoint
+	if s.scheduler != nil {
+		schedulerCtx, cancel := context.WithTimeout(context.Background(), s.shutdown.GracePeriod+s.shutdown.Timeout)
+		defer cancel()
+
+		if err := s.scheduler.Shutdown(schedulerCtx, s.shutdown.GracePeriod); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+// O httprouter entra em pânico na inicialização quando duas rotas conflitam (por exemplo, um segmento
+// fixo ao lado de um parâmetro); montar o roteador completo garante que a API sobe com todas as rotas
+func TestNew_RegistersAllRoutes(t *testing.T) {
+	assert.NotPanics(t, func() {
+		server, err := New(&config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, server)
+	})
+}
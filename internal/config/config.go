@@ -23,7 +23,33 @@ type Config struct {
 	MetaInsightSync     MetaInsightSync     `mapstructure:",squash"`
 	SSOticaInsightSync  SSOticaInsightSync  `mapstructure:",squash"`
 	MonthlyInsightsSync MonthlyInsightsSync `mapstructure:",squash"`
+	SyncRateLimit       SyncRateLimit       `mapstructure:",squash"`
 	TopRankingAccounts  TopRankingAccounts  `mapstructure:",squash"`
+	RollupInsightsSync  RollupInsightsSync  `mapstructure:",squash"`
+	BudgetPacing        BudgetPacing        `mapstructure:",squash"`
+	ResultMetrics       ResultMetrics       `mapstructure:",squash"`
+	AnomalyDetection    AnomalyDetection    `mapstructure:",squash"`
+	IntradaySync        IntradaySync        `mapstructure:",squash"`
+	Security            Security            `mapstructure:",squash"`
+	PasswordPolicy      PasswordPolicy      `mapstructure:",squash"`
+	Webhook             Webhook             `mapstructure:",squash"`
+	Storage             Storage             `mapstructure:",squash"`
+	Email               Email               `mapstructure:",squash"`
+	ReportSchedule      ReportSchedule      `mapstructure:",squash"`
+	CampaignNaming      CampaignNaming      `mapstructure:",squash"`
+	CampaignCatalog     CampaignCatalog     `mapstructure:",squash"`
+	InsightBreakdowns   InsightBreakdowns   `mapstructure:",squash"`
+	ReachOverview       ReachOverview       `mapstructure:",squash"`
+	Geocoding           Geocoding           `mapstructure:",squash"`
+	CurrencyRates       CurrencyRates       `mapstructure:",squash"`
+	PDFReport           PDFReport           `mapstructure:",squash"`
+	SchedulerCatchUp    SchedulerCatchUp    `mapstructure:",squash"`
+	SchedulerShutdown   SchedulerShutdown   `mapstructure:",squash"`
+	AccountSyncSchedule AccountSyncSchedule `mapstructure:",squash"`
+	AccountChanges      AccountChanges      `mapstructure:",squash"`
+	HealthProbes        HealthProbes        `mapstructure:",squash"`
+	UsageQuotas         UsageQuotas         `mapstructure:",squash"`
+	Policy              Policy              `mapstructure:",squash"`
 	SecretKey           string              `mapstructure:"secret_key"`
 	SSOticaMultiClient  map[string]SSOtica  `mapstructure:"-"`
 }
@@ -67,31 +93,236 @@ type App struct {
 }
 
 type Auth struct {
-	Secret string `mapstructure:"auth_secret"`
+	Secret                 string        `mapstructure:"auth_secret"`
+	SigningKeyID           string        `mapstructure:"auth_signing_key_id"`
+	PreviousSecret         string        `mapstructure:"auth_previous_secret"`
+	PreviousSigningKeyID   string        `mapstructure:"auth_previous_signing_key_id"`
+	PreviousKeyGracePeriod time.Duration `mapstructure:"auth_previous_key_grace_period"`
+	KeyRotatedAt           string        `mapstructure:"auth_key_rotated_at"` // RFC3339
+	SessionIdleTimeout     time.Duration `mapstructure:"auth_session_idle_timeout"`
+	SessionMaxLifetime     time.Duration `mapstructure:"auth_session_max_lifetime"`
+	SessionRenewInterval   time.Duration `mapstructure:"auth_session_renew_interval"`
+	AccessTokenTTL         time.Duration `mapstructure:"auth_access_token_ttl"`
+	RefreshTokenTTL        time.Duration `mapstructure:"auth_refresh_token_ttl"`
+	PasswordResetTTL       time.Duration `mapstructure:"auth_password_reset_ttl"`
+	PasswordResetURL       string        `mapstructure:"auth_password_reset_url"` // Página do front que recebe ?token=
+	EmailVerificationTTL   time.Duration `mapstructure:"auth_email_verification_ttl"`
+	EmailVerificationURL   string        `mapstructure:"auth_email_verification_url"` // Página do front que recebe ?token=
+	APIKeyMaxTTL           time.Duration `mapstructure:"auth_api_key_max_ttl"`
+}
+
+type RollupInsightsSync struct {
+	CronSchedule string `mapstructure:"rollup_insights_sync_cron"`
+	Enabled      bool   `mapstructure:"rollup_insights_sync_enabled"`
+}
+
+type BudgetPacing struct {
+	CronSchedule string  `mapstructure:"budget_pacing_cron"`
+	Enabled      bool    `mapstructure:"budget_pacing_enabled"`
+	Tolerance    float64 `mapstructure:"budget_pacing_tolerance"`
+}
+
+type ResultMetrics struct {
+	GrossMargin float64 `mapstructure:"gross_margin"`
+}
+
+type AnomalyDetection struct {
+	CronSchedule string  `mapstructure:"anomaly_detection_cron"`
+	Enabled      bool    `mapstructure:"anomaly_detection_enabled"`
+	Threshold    float64 `mapstructure:"anomaly_detection_threshold"`
+	LookbackDays int     `mapstructure:"anomaly_detection_lookback_days"`
+}
+
+type IntradaySync struct {
+	CronSchedule string        `mapstructure:"intraday_sync_cron"`
+	Enabled      bool          `mapstructure:"intraday_sync_enabled"`
+	MaxAge       time.Duration `mapstructure:"intraday_sync_max_age"`
+}
+
+type Security struct {
+	AdminIPAllowlist   []string `mapstructure:"admin_ip_allowlist"`
+	TrustProxyHeaders  bool     `mapstructure:"trust_proxy_headers"`
+	CookieAuthEnabled  bool     `mapstructure:"auth_cookie_enabled"`
+	CookieAuthDomain   string   `mapstructure:"auth_cookie_domain"`
+	CookieAuthSecure   bool     `mapstructure:"auth_cookie_secure"`
+	CookieAuthSameSite string   `mapstructure:"auth_cookie_same_site"`
+
+	CaptchaEnabled        bool          `mapstructure:"captcha_enabled"`
+	CaptchaProvider       string        `mapstructure:"captcha_provider"`
+	CaptchaSecret         string        `mapstructure:"captcha_secret"`
+	CaptchaVerifyURL      string        `mapstructure:"captcha_verify_url"`
+	CaptchaFailedAttempts int           `mapstructure:"captcha_failed_attempts"`
+	CaptchaFailureWindow  time.Duration `mapstructure:"captcha_failure_window"`
+
+	AuthRateLimitPerIP   int           `mapstructure:"auth_rate_limit_per_ip"`
+	AuthRateLimitPerUser int           `mapstructure:"auth_rate_limit_per_user"`
+	AuthRateLimitWindow  time.Duration `mapstructure:"auth_rate_limit_window"`
+
+	FieldMaskingCacheTTL time.Duration `mapstructure:"field_masking_cache_ttl"`
+	PermissionsCacheTTL  time.Duration `mapstructure:"permissions_cache_ttl"`
+}
+
+// Policy é a versão vigente dos termos de uso e da política de privacidade, que os usuários precisam aceitar
+type Policy struct {
+	Version string `mapstructure:"policy_version"`
+	URL     string `mapstructure:"policy_url"`
+}
+
+type PasswordPolicy struct {
+	HistorySize int `mapstructure:"password_history_size"`
+	MaxAgeDays  int `mapstructure:"password_max_age_days"`
+}
+
+type Webhook struct {
+	Timeout    time.Duration `mapstructure:"webhook_timeout"`
+	MaxRetries int           `mapstructure:"webhook_max_retries"`
+}
+
+type Storage struct {
+	Backend        string `mapstructure:"storage_backend"` // s3 ou local
+	LocalDir       string `mapstructure:"storage_local_dir"`
+	LocalPublicURL string `mapstructure:"storage_local_public_url"`
+
+	S3Endpoint        string `mapstructure:"storage_s3_endpoint"`
+	S3Region          string `mapstructure:"storage_s3_region"`
+	S3Bucket          string `mapstructure:"storage_s3_bucket"`
+	S3AccessKeyID     string `mapstructure:"storage_s3_access_key_id"`
+	S3SecretAccessKey string `mapstructure:"storage_s3_secret_access_key"`
+	S3PublicURL       string `mapstructure:"storage_s3_public_url"`
+	S3PathStyle       bool   `mapstructure:"storage_s3_path_style"`
+
+	AvatarSize           int   `mapstructure:"avatar_size"`
+	AvatarMaxUploadBytes int64 `mapstructure:"avatar_max_upload_bytes"`
+}
+
+type Email struct {
+	SMTPHost     string `mapstructure:"smtp_host"`
+	SMTPPort     int    `mapstructure:"smtp_port"`
+	SMTPUser     string `mapstructure:"smtp_user"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	From         string `mapstructure:"email_from"`
+}
+
+type ReportSchedule struct {
+	CronSchedule string `mapstructure:"report_schedule_cron"`
+	Enabled      bool   `mapstructure:"report_schedule_enabled"`
+}
+
+type CampaignNaming struct {
+	Separator  string   `mapstructure:"campaign_naming_separator"`
+	Dimensions []string `mapstructure:"campaign_naming_dimensions"`
+}
+
+type SchedulerCatchUp struct {
+	Enabled bool          `mapstructure:"scheduler_catch_up_enabled"`
+	Delay   time.Duration `mapstructure:"scheduler_catch_up_delay"`
+}
+
+// SchedulerShutdown define a espera pelas execuções em andamento no encerramento da aplicação: por até
+// GracePeriod elas podem terminar; depois, são interrompidas e têm até Timeout para gravar o checkpoint.
+type SchedulerShutdown struct {
+	GracePeriod time.Duration `mapstructure:"scheduler_shutdown_grace_period"`
+	Timeout     time.Duration `mapstructure:"scheduler_shutdown_timeout"`
+}
+
+type AccountSyncSchedule struct {
+	CheckInterval time.Duration `mapstructure:"account_sync_schedule_check_interval"`
+}
+
+type AccountChanges struct {
+	ListenEnabled bool `mapstructure:"account_changes_listen_enabled"`
+}
+
+type HealthProbes struct {
+	Interval time.Duration `mapstructure:"health_probe_interval"`
+	Timeout  time.Duration `mapstructure:"health_probe_timeout"`
+}
+
+// UsageQuotas são as cotas diárias de requisições por usuário nas rotas caras; 0 desabilita
+type UsageQuotas struct {
+	LiveInsightsDaily int `mapstructure:"usage_quota_live_insights_daily"`
+	ExportsDaily      int `mapstructure:"usage_quota_exports_daily"`
+}
+
+type CampaignCatalog struct {
+	CacheTTL time.Duration `mapstructure:"campaign_catalog_cache_ttl"`
+}
+
+// InsightBreakdowns configura o armazenamento das quebras por idade, gênero e plataforma do Meta
+type InsightBreakdowns struct {
+	CacheTTL time.Duration `mapstructure:"insight_breakdowns_cache_ttl"`
+}
+
+// Geocoding configura a geocodificação dos endereços das lojas
+type Geocoding struct {
+	URL       string `mapstructure:"geocoding_url"`
+	UserAgent string `mapstructure:"geocoding_user_agent"`
+}
+
+// CurrencyRates configura as cotações usadas para converter o investimento de contas cobradas em
+// outra moeda para a moeda do faturamento
+type CurrencyRates struct {
+	Provider string        `mapstructure:"currency_rates_provider"`
+	URL      string        `mapstructure:"currency_rates_url"`
+	Static   string        `mapstructure:"currency_rates_static"`
+	CacheTTL time.Duration `mapstructure:"currency_rates_cache_ttl"`
+}
+
+// PDFReport configura a geração assíncrona dos relatórios mensais em PDF
+type PDFReport struct {
+	BrandName         string        `mapstructure:"pdf_report_brand_name"`
+	BrandColor        string        `mapstructure:"pdf_report_brand_color"`
+	JobTTL            time.Duration `mapstructure:"pdf_report_job_ttl"`
+	MaxConcurrentJobs int           `mapstructure:"pdf_report_max_concurrent_jobs"`
+}
+
+// ReachOverview configura a consulta de Reach e Impressions de todas as contas do usuário na visão geral
+type ReachOverview struct {
+	CacheTTL       time.Duration `mapstructure:"reach_overview_cache_ttl"`
+	MaxConcurrency int           `mapstructure:"reach_overview_max_concurrency"`
 }
 
 type MetaInsightSync struct {
-	CronSchedule        string `mapstructure:"meta_insight_sync_cron"`
-	LookbackDays        int    `mapstructure:"meta_insight_sync_lookback_days"`
-	RequestDelaySeconds int    `mapstructure:"meta_insight_sync_request_delay_seconds"`
-	MaxConcurrentJobs   int    `mapstructure:"meta_insight_sync_max_concurrent_jobs"`
-	Enabled             bool   `mapstructure:"meta_insight_sync_enabled"`
+	CronSchedule       string        `mapstructure:"meta_insight_sync_cron"`
+	LookbackDays       int           `mapstructure:"meta_insight_sync_lookback_days"`
+	MaxConcurrentJobs  int           `mapstructure:"meta_insight_sync_max_concurrent_jobs"`
+	Enabled            bool          `mapstructure:"meta_insight_sync_enabled"`
+	IncrementalEnabled bool          `mapstructure:"meta_insight_sync_incremental_enabled"`
+	FullRefreshWeekday int           `mapstructure:"meta_insight_sync_full_refresh_weekday"`
+	DeltaEnabled       bool          `mapstructure:"meta_insight_sync_delta_enabled"`
+	SettleDays         int           `mapstructure:"meta_insight_sync_settle_days"`
+	MaxDuration        time.Duration `mapstructure:"meta_insight_sync_max_duration"`
+	MaxRetries         int           `mapstructure:"meta_insight_sync_max_retries"`
+	RetryBackoff       time.Duration `mapstructure:"meta_insight_sync_retry_backoff"`
 }
 
 type SSOticaInsightSync struct {
-	CronSchedule        string `mapstructure:"ssotica_insight_sync_cron"`
-	LookbackDays        int    `mapstructure:"ssotica_insight_sync_lookback_days"`
-	RequestDelaySeconds int    `mapstructure:"ssotica_insight_sync_request_delay_seconds"`
-	MaxConcurrentJobs   int    `mapstructure:"ssotica_insight_sync_max_concurrent_jobs"`
-	Enabled             bool   `mapstructure:"ssotica_insight_sync_enabled"`
+	CronSchedule      string        `mapstructure:"ssotica_insight_sync_cron"`
+	LookbackDays      int           `mapstructure:"ssotica_insight_sync_lookback_days"`
+	MaxConcurrentJobs int           `mapstructure:"ssotica_insight_sync_max_concurrent_jobs"`
+	Enabled           bool          `mapstructure:"ssotica_insight_sync_enabled"`
+	DeltaEnabled      bool          `mapstructure:"ssotica_insight_sync_delta_enabled"`
+	SettleDays        int           `mapstructure:"ssotica_insight_sync_settle_days"`
+	MaxDuration       time.Duration `mapstructure:"ssotica_insight_sync_max_duration"`
+	MaxRetries        int           `mapstructure:"ssotica_insight_sync_max_retries"`
+	RetryBackoff      time.Duration `mapstructure:"ssotica_insight_sync_retry_backoff"`
 }
 
 type MonthlyInsightsSync struct {
-	CronSchedule        string `mapstructure:"monthly_insights_sync_cron"`
-	RequestDelaySeconds int    `mapstructure:"monthly_insights_sync_request_delay_seconds"`
-	MaxConcurrentJobs   int    `mapstructure:"monthly_insights_sync_max_concurrent_jobs"`
-	Enabled             bool   `mapstructure:"monthly_insights_sync_enabled"`
-	MonthLookBack       int    `mapstructure:"monthly_insights_sync_month_lookback"`
+	CronSchedule      string `mapstructure:"monthly_insights_sync_cron"`
+	MaxConcurrentJobs int    `mapstructure:"monthly_insights_sync_max_concurrent_jobs"`
+	Enabled           bool   `mapstructure:"monthly_insights_sync_enabled"`
+	MonthLookBack     int    `mapstructure:"monthly_insights_sync_month_lookback"`
+}
+
+// SyncRateLimit limita as requisições dos agendadores a cada integrador, somando todos os agendadores e contas
+// em andamento. Quando o integrador recusa uma requisição por limite, as seguintes aguardam Cooldown.
+type SyncRateLimit struct {
+	MetaRequestsPerSecond    float64       `mapstructure:"meta_sync_rate_limit_per_second"`
+	MetaBurst                int           `mapstructure:"meta_sync_rate_limit_burst"`
+	SSOticaRequestsPerSecond float64       `mapstructure:"ssotica_sync_rate_limit_per_second"`
+	SSOticaBurst             int           `mapstructure:"ssotica_sync_rate_limit_burst"`
+	Cooldown                 time.Duration `mapstructure:"sync_rate_limit_cooldown"`
 }
 
 type TopRankingAccounts struct {
@@ -105,47 +336,182 @@ func SetDefaults() {
 
 	viper.SetDefault("DATABASE_DRIVER", "postgres")
 	viper.SetDefault("DATABASE_URL", "localhost:5432/traffic")
-	viper.SetDefault("DATABASE_USER", "postgres")
-	viper.SetDefault("DATABASE_PASSWORD", "root")
+	viper.SetDefault("DATABASE_USER", "")     // Obrigatório
+	viper.SetDefault("DATABASE_PASSWORD", "") // Obrigatório
 
 	viper.SetDefault("META_BASE_URL", "https://graph.facebook.com")
 	viper.SetDefault("META_URL", "https://graph.facebook.com/v22.0")
 	viper.SetDefault("META_VERSION", "v22.0")
-	viper.SetDefault("META_APP_ID", "your_app_id")
-	viper.SetDefault("META_APP_SECRET", "your_app_secret")
-	viper.SetDefault("META_ACCESS_TOKEN", "your_access_token") // ONLY LOCAL
-
-	viper.SetDefault("SECRET_KEY", "your_secret_key")
+	viper.SetDefault("META_APP_ID", "")       // Obrigatório
+	viper.SetDefault("META_APP_SECRET", "")   // Obrigatório
+	viper.SetDefault("META_ACCESS_TOKEN", "") // Obrigatório
+
+	viper.SetDefault("SECRET_KEY", "") // Obrigatório se AUTH_SECRET não for informado
+
+	// Defaults para chaves de assinatura JWT
+	viper.SetDefault("AUTH_SECRET", "")                       // Se vazio, usa SECRET_KEY
+	viper.SetDefault("AUTH_SIGNING_KEY_ID", "default")        // kid da chave atual
+	viper.SetDefault("AUTH_PREVIOUS_SECRET", "")              // Chave anterior, aceita durante a janela de rotação
+	viper.SetDefault("AUTH_PREVIOUS_SIGNING_KEY_ID", "")      // kid da chave anterior
+	viper.SetDefault("AUTH_PREVIOUS_KEY_GRACE_PERIOD", "24h") // Janela em que tokens da chave anterior são aceitos
+	viper.SetDefault("AUTH_KEY_ROTATED_AT", "")               // Momento da rotação (RFC3339); obrigatório com AUTH_PREVIOUS_SECRET
+	viper.SetDefault("AUTH_SESSION_IDLE_TIMEOUT", "30m")      // Validade do token; renovado enquanto o usuário estiver ativo, expira após esse tempo sem requisições
+	viper.SetDefault("AUTH_SESSION_MAX_LIFETIME", "24h")      // Duração máxima da sessão desde o login, mesmo com renovações
+	viper.SetDefault("AUTH_SESSION_RENEW_INTERVAL", "5m")     // Idade mínima do token para ser renovado, evitando um novo token a cada requisição
+	viper.SetDefault("AUTH_ACCESS_TOKEN_TTL", "15m")          // Validade máxima do token de acesso; limitada também por AUTH_SESSION_IDLE_TIMEOUT
+	viper.SetDefault("AUTH_REFRESH_TOKEN_TTL", "720h")        // Validade do refresh token, trocado por um novo a cada uso em /v1/auth/refresh
+	viper.SetDefault("AUTH_PASSWORD_RESET_TTL", "1h")         // Validade do link de redefinição de senha enviado por e-mail
+	viper.SetDefault("AUTH_PASSWORD_RESET_URL", "")           // Página de redefinição de senha do front; sem ela a recuperação por e-mail fica desabilitada
+	viper.SetDefault("AUTH_EMAIL_VERIFICATION_TTL", "48h")    // Validade do link de confirmação de e-mail enviado no cadastro
+	viper.SetDefault("AUTH_EMAIL_VERIFICATION_URL", "")       // Página de confirmação de e-mail do front; sem ela a verificação fica desabilitada
+	viper.SetDefault("AUTH_API_KEY_MAX_TTL", "8760h")         // Validade máxima das chaves de API, usada também quando a chave é criada sem expires_at
 
 	viper.SetDefault("RENDER_API_KEY", "")
 	viper.SetDefault("RENDER_SERVICE_ID", "")
 
 	viper.SetDefault("SSOTICA_URL", "https://app.ssotica.com.br/api/v1")
-	viper.SetDefault("SSOTICA_ACCESS_TOKEN", "your_access_token")
+	viper.SetDefault("SSOTICA_ACCESS_TOKEN", "")
 
 	// Defaults para sincronização de insights
-	viper.SetDefault("META_INSIGHT_SYNC_CRON", "0 3 * * *")        // Todos os dias às 3h da manhã
-	viper.SetDefault("META_INSIGHT_SYNC_LOOKBACK_DAYS", 7)         // 7 dias para buscar dados
-	viper.SetDefault("META_INSIGHT_SYNC_REQUEST_DELAY_SECONDS", 2) // 2 segundos entre requisições
-	viper.SetDefault("META_INSIGHT_SYNC_MAX_CONCURRENT_JOBS", 3)   // 3 jobs concorrentes
-	viper.SetDefault("META_INSIGHT_SYNC_ENABLED", false)           // Habilitar sincronização de anúncios
-
-	viper.SetDefault("SSOTICA_INSIGHT_SYNC_CRON", "0 4 * * *")        // Todos os dias às 4h da manhã
-	viper.SetDefault("SSOTICA_INSIGHT_SYNC_LOOKBACK_DAYS", 7)         // 7 dias para buscar dados
-	viper.SetDefault("SSOTICA_INSIGHT_SYNC_REQUEST_DELAY_SECONDS", 2) // 2 segundos entre requisições
-	viper.SetDefault("SSOTICA_INSIGHT_SYNC_MAX_CONCURRENT_JOBS", 3)   // 3 jobs concorrentes
-	viper.SetDefault("SSOTICA_INSIGHT_SYNC_ENABLED", false)           // Habilitar sincronização de vendas
+	viper.SetDefault("META_INSIGHT_SYNC_CRON", "0 3 * * *")         // Todos os dias às 3h da manhã
+	viper.SetDefault("META_INSIGHT_SYNC_LOOKBACK_DAYS", 7)          // 7 dias para buscar dados
+	viper.SetDefault("META_INSIGHT_SYNC_MAX_CONCURRENT_JOBS", 3)    // 3 jobs concorrentes
+	viper.SetDefault("META_INSIGHT_SYNC_ENABLED", false)            // Habilitar sincronização de anúncios
+	viper.SetDefault("META_INSIGHT_SYNC_INCREMENTAL_ENABLED", true) // Rebuscar apenas os dias cujos dados mudaram no Meta
+	viper.SetDefault("META_INSIGHT_SYNC_FULL_REFRESH_WEEKDAY", 0)   // Dia da semana (0 = domingo) com rebusca completa do período
+	viper.SetDefault("META_INSIGHT_SYNC_DELTA_ENABLED", true)       // Buscar apenas os dias sem insights armazenados ou ainda não consolidados
+	viper.SetDefault("META_INSIGHT_SYNC_SETTLE_DAYS", 3)            // Dias após a data em que os insights do Meta ainda podem mudar
+	viper.SetDefault("META_INSIGHT_SYNC_MAX_DURATION", "6h")        // Duração máxima da execução; o restante é retomado na próxima (0 = sem limite)
+	viper.SetDefault("META_INSIGHT_SYNC_MAX_RETRIES", 2)            // Novas tentativas de uma conta/data após falha (0 = sem novas tentativas)
+	viper.SetDefault("META_INSIGHT_SYNC_RETRY_BACKOFF", "10s")      // Espera antes da primeira nova tentativa; dobra a cada tentativa, com jitter
+
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_CRON", "0 4 * * *")      // Todos os dias às 4h da manhã
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_LOOKBACK_DAYS", 7)       // 7 dias para buscar dados
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_MAX_CONCURRENT_JOBS", 3) // 3 jobs concorrentes
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_ENABLED", false)         // Habilitar sincronização de vendas
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_DELTA_ENABLED", true)    // Buscar apenas os dias sem vendas armazenadas ou ainda não consolidados
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_SETTLE_DAYS", 2)         // Dias após a data em que as vendas do SSOtica ainda podem mudar
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_MAX_DURATION", "6h")     // Duração máxima da execução; o restante é retomado na próxima (0 = sem limite)
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_MAX_RETRIES", 2)         // Novas tentativas de uma conta/data após falha (0 = sem novas tentativas)
+	viper.SetDefault("SSOTICA_INSIGHT_SYNC_RETRY_BACKOFF", "10s")   // Espera antes da primeira nova tentativa; dobra a cada tentativa, com jitter
 
 	// Defaults para sincronização mensal de insights
-	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_CRON", "0 5 1 * *")        // No primeiro dia de cada mês às 5h da manhã
-	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_REQUEST_DELAY_SECONDS", 2) // 2 segundos entre requisições
-	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_MAX_CONCURRENT_JOBS", 3)   // 3 jobs concorrentes
-	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_ENABLED", false)           // Habilitar sincronização mensal
-	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_MONTH_LOOKBACK", 1)        // 1 mês para buscar dados
+	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_CRON", "0 5 1 * *")      // No primeiro dia de cada mês às 5h da manhã
+	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_MAX_CONCURRENT_JOBS", 3) // 3 jobs concorrentes
+	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_ENABLED", false)         // Habilitar sincronização mensal
+	viper.SetDefault("MONTHLY_INSIGHTS_SYNC_MONTH_LOOKBACK", 1)      // 1 mês para buscar dados
+
+	// Limite de requisições dos agendadores por integrador, somando todas as sincronizações em andamento
+	viper.SetDefault("META_SYNC_RATE_LIMIT_PER_SECOND", 1.5)    // Requisições por segundo ao Meta (0 = sem limite)
+	viper.SetDefault("META_SYNC_RATE_LIMIT_BURST", 3)           // Requisições liberadas de uma vez antes do limite
+	viper.SetDefault("SSOTICA_SYNC_RATE_LIMIT_PER_SECOND", 1.5) // Requisições por segundo ao SSOtica (0 = sem limite)
+	viper.SetDefault("SSOTICA_SYNC_RATE_LIMIT_BURST", 3)        // Requisições liberadas de uma vez antes do limite
+	viper.SetDefault("SYNC_RATE_LIMIT_COOLDOWN", "1m")          // Pausa das requisições ao integrador que recusou por limite (0 = sem pausa)
 
 	viper.SetDefault("TOP_RANKING_ACCOUNTS_CRON", "0 6 * * *")   // Todos os dias às 6h da manhã
 	viper.SetDefault("TOP_RANKING_ACCOUNTS_SYNC_ENABLED", false) // Habilitar sincronização de top ranking de contas
 
+	viper.SetDefault("ROLLUP_INSIGHTS_SYNC_CRON", "0 7 1 * *") // No primeiro dia de cada mês às 7h, após a sincronização mensal
+	viper.SetDefault("ROLLUP_INSIGHTS_SYNC_ENABLED", false)    // Habilitar consolidação trimestral e anual
+
+	viper.SetDefault("BUDGET_PACING_CRON", "0 10 * * *") // Todos os dias às 10h, após as sincronizações diárias
+	viper.SetDefault("BUDGET_PACING_ENABLED", false)     // Habilitar a verificação diária do ritmo de investimento das contas com orçamento
+	viper.SetDefault("BUDGET_PACING_TOLERANCE", 0.1)     // Variação aceita em relação ao investimento esperado para o dia (0.1 = 10%)
+
+	viper.SetDefault("GROSS_MARGIN", 0.5) // Margem bruta das vendas usada no ROAS de equilíbrio (0.5 = 50%)
+
+	viper.SetDefault("ANOMALY_DETECTION_CRON", "0 8 * * *") // Todos os dias às 8h, após as sincronizações diárias
+	viper.SetDefault("ANOMALY_DETECTION_ENABLED", false)    // Habilitar a detecção de anomalias de investimento e custo por resultado
+	viper.SetDefault("ANOMALY_DETECTION_THRESHOLD", 3)      // Desvios-padrão em relação à média dos 30 dias anteriores para marcar o dia
+	viper.SetDefault("ANOMALY_DETECTION_LOOKBACK_DAYS", 7)  // Dias reavaliados a cada execução, acompanhando a janela da sincronização do Meta
+
+	viper.SetDefault("INTRADAY_SYNC_CRON", "0 8-22 * * *") // A cada hora, das 8h às 22h, durante o funcionamento das lojas
+	viper.SetDefault("INTRADAY_SYNC_ENABLED", false)       // Habilitar a sincronização dos dados do dia em andamento
+	viper.SetDefault("INTRADAY_SYNC_MAX_AGE", "2h")        // Idade máxima dos dados do dia usados nos dashboards; mais antigos, o dia é buscado nas APIs
+
+	viper.SetDefault("SCHEDULER_CATCH_UP_ENABLED", true) // Executar na inicialização as sincronizações perdidas enquanto o serviço estava parado
+	viper.SetDefault("SCHEDULER_CATCH_UP_DELAY", "1m")   // Espera após a inicialização antes de executar a recuperação
+
+	viper.SetDefault("SCHEDULER_SHUTDOWN_GRACE_PERIOD", "20s") // Espera, no encerramento, pelo fim das sincronizações em andamento
+	viper.SetDefault("SCHEDULER_SHUTDOWN_TIMEOUT", "10s")      // Espera adicional pelas sincronizações interrompidas, que gravam o checkpoint
+
+	viper.SetDefault("ACCOUNT_SYNC_SCHEDULE_CHECK_INTERVAL", "1m") // Frequência de verificação das agendas de sincronização por conta
+	viper.SetDefault("ACCOUNT_CHANGES_LISTEN_ENABLED", true)       // Aplicar nas sincronizações em andamento as alterações de status e credenciais das contas (LISTEN/NOTIFY)
+
+	viper.SetDefault("HEALTH_PROBE_INTERVAL", "1m") // Frequência das verificações ativas do banco, Meta e SSOtica (/readyz)
+	viper.SetDefault("HEALTH_PROBE_TIMEOUT", "10s") // Tempo máximo de cada verificação
+
+	viper.SetDefault("USAGE_QUOTA_LIVE_INSIGHTS_DAILY", 500) // Consultas diárias de insights por usuário (consomem o limite da Meta); 0 = sem cota
+	viper.SetDefault("USAGE_QUOTA_EXPORTS_DAILY", 50)        // Exportações diárias (apresentações e relatórios salvos) por usuário; 0 = sem cota
+
+	// Defaults de segurança
+	viper.SetDefault("ADMIN_IP_ALLOWLIST", "")        // CIDRs separados por vírgula para rotas /admin e de sincronização; vazio = sem restrição
+	viper.SetDefault("TRUST_PROXY_HEADERS", true)     // Usar o último endereço de X-Forwarded-For (adicionado pelo proxy) como IP do cliente (Render)
+	viper.SetDefault("AUTH_COOKIE_ENABLED", false)    // Permitir login em modo cookie HttpOnly + CSRF (dashboard web)
+	viper.SetDefault("AUTH_COOKIE_DOMAIN", "")        // Domínio dos cookies de sessão; vazio = host da API
+	viper.SetDefault("AUTH_COOKIE_SECURE", true)      // Cookies apenas via HTTPS
+	viper.SetDefault("AUTH_COOKIE_SAME_SITE", "none") // strict, lax ou none (dashboard em outro domínio exige none)
+	viper.SetDefault("CAPTCHA_ENABLED", false)        // Exigir captcha no login após falhas repetidas do mesmo IP
+	viper.SetDefault("CAPTCHA_PROVIDER", "hcaptcha")  // hcaptcha, recaptcha ou turnstile
+	viper.SetDefault("CAPTCHA_SECRET", "")            // Chave secreta do provedor de captcha
+	viper.SetDefault("CAPTCHA_VERIFY_URL", "")        // Sobrescreve a URL de verificação do provedor
+	viper.SetDefault("CAPTCHA_FAILED_ATTEMPTS", 5)    // Falhas de login por IP antes de exigir captcha
+	viper.SetDefault("CAPTCHA_FAILURE_WINDOW", "15m") // Janela de contagem das falhas de login
+	viper.SetDefault("AUTH_RATE_LIMIT_PER_IP", 30)    // Requisições de login, recuperação de senha e refresh por IP na janela; 0 desabilita
+	viper.SetDefault("AUTH_RATE_LIMIT_PER_USER", 10)  // Tentativas de login e recuperação de senha por e-mail na janela; 0 desabilita
+	viper.SetDefault("AUTH_RATE_LIMIT_WINDOW", "15m") // Janela de contagem do limite das rotas de autenticação
+	viper.SetDefault("FIELD_MASKING_CACHE_TTL", "5m") // Tempo de cache das regras de role_masked_fields
+	viper.SetDefault("PERMISSIONS_CACHE_TTL", "1m")   // Tempo de cache das permissões de role_permissions
+
+	viper.SetDefault("PASSWORD_HISTORY_SIZE", 5) // Quantidade de senhas anteriores que não podem ser reutilizadas; 0 = desabilitado
+	viper.SetDefault("PASSWORD_MAX_AGE_DAYS", 0) // Dias até a senha expirar e exigir troca no login; 0 = sem expiração
+
+	// Defaults para o aceite dos termos de uso e da política de privacidade
+	viper.SetDefault("POLICY_VERSION", "") // Versão vigente; vazio = aceite não exigido
+	viper.SetDefault("POLICY_URL", "")     // Página com o texto da versão vigente, exibida pelo front
+
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s") // Timeout de cada entrega de webhook
+	viper.SetDefault("WEBHOOK_MAX_RETRIES", 3) // Novas tentativas após falha na entrega (backoff exponencial)
+
+	viper.SetDefault("STORAGE_BACKEND", "s3")            // Onde gravar os arquivos enviados: s3 ou local (disco do servidor)
+	viper.SetDefault("STORAGE_LOCAL_DIR", "")            // Diretório do armazenamento local; vazio = upload desabilitado
+	viper.SetDefault("STORAGE_LOCAL_PUBLIC_URL", "")     // URL base pública dos arquivos locais; vazio = servidos pela API em /uploads
+	viper.SetDefault("STORAGE_S3_ENDPOINT", "")          // Endpoint S3 compatível (MinIO, R2); vazio = AWS na região configurada
+	viper.SetDefault("STORAGE_S3_REGION", "us-east-1")   // Região usada na assinatura das requisições
+	viper.SetDefault("STORAGE_S3_BUCKET", "")            // Bucket dos arquivos enviados; vazio = upload desabilitado
+	viper.SetDefault("STORAGE_S3_ACCESS_KEY_ID", "")     // Access key do bucket
+	viper.SetDefault("STORAGE_S3_SECRET_ACCESS_KEY", "") // Secret key do bucket
+	viper.SetDefault("STORAGE_S3_PUBLIC_URL", "")        // URL base pública (CDN) dos arquivos; vazio = URL do bucket
+	viper.SetDefault("STORAGE_S3_PATH_STYLE", true)      // Endereçar o bucket no caminho da URL (necessário no MinIO)
+	viper.SetDefault("AVATAR_SIZE", 256)                 // Lado em pixels do avatar quadrado gerado
+	viper.SetDefault("AVATAR_MAX_UPLOAD_BYTES", 5242880) // Tamanho máximo da imagem enviada (5 MB)
+
+	viper.SetDefault("SMTP_HOST", "")     // Servidor SMTP para envio de e-mails; vazio = envio desabilitado
+	viper.SetDefault("SMTP_PORT", 587)    // Porta SMTP (STARTTLS)
+	viper.SetDefault("SMTP_USER", "")     // Usuário SMTP
+	viper.SetDefault("SMTP_PASSWORD", "") // Senha SMTP
+	viper.SetDefault("EMAIL_FROM", "")    // Remetente dos e-mails (ex: Relatórios <relatorios@dominio.com>)
+
+	viper.SetDefault("REPORT_SCHEDULE_CRON", "*/5 * * * *") // Frequência de verificação dos relatórios agendados vencidos
+	viper.SetDefault("REPORT_SCHEDULE_ENABLED", false)      // Habilitar envio dos relatórios agendados
+
+	viper.SetDefault("CAMPAIGN_NAMING_SEPARATOR", "|")                       // Separador das partes do nome das campanhas
+	viper.SetDefault("CAMPAIGN_NAMING_DIMENSIONS", "loja,produto,objetivo")  // Dimensões de cada parte do nome, na ordem
+	viper.SetDefault("CAMPAIGN_CATALOG_CACHE_TTL", "5m")                     // Tempo de cache do catálogo de campanhas por conta
+	viper.SetDefault("REACH_OVERVIEW_CACHE_TTL", "15m")                      // Tempo de cache do Reach e Impressions por conta e período na visão geral
+	viper.SetDefault("REACH_OVERVIEW_MAX_CONCURRENCY", 5)                    // Contas consultadas em paralelo no Meta na visão geral
+	viper.SetDefault("INSIGHT_BREAKDOWNS_CACHE_TTL", "6h")                   // Validade das quebras armazenadas de períodos ainda em andamento
+	viper.SetDefault("GEOCODING_URL", "https://nominatim.openstreetmap.org") // API de busca compatível com o Nominatim
+	viper.SetDefault("GEOCODING_USER_AGENT", "traffic-manager-api")          // Identificação exigida pela política de uso do Nominatim
+	viper.SetDefault("PDF_REPORT_BRAND_NAME", "Traffic Manager")             // Nome exibido no cabeçalho dos relatórios em PDF
+	viper.SetDefault("PDF_REPORT_BRAND_COLOR", "1F4E79")                     // Cor (hexadecimal) do cabeçalho dos relatórios em PDF
+	viper.SetDefault("PDF_REPORT_JOB_TTL", "1h")                             // Tempo em que um PDF gerado fica disponível para download
+	viper.SetDefault("PDF_REPORT_MAX_CONCURRENT_JOBS", 2)                    // Relatórios em PDF gerados ao mesmo tempo
+	viper.SetDefault("CURRENCY_RATES_PROVIDER", "static")                    // Origem das cotações: static (CURRENCY_RATES_STATIC) ou http (CURRENCY_RATES_URL)
+	viper.SetDefault("CURRENCY_RATES_URL", "https://api.frankfurter.app")    // API de cotações compatível com o Frankfurter
+	viper.SetDefault("CURRENCY_RATES_STATIC", "")                            // Cotações fixas em reais por unidade (ex: USD=5.40,EUR=6.10)
+	viper.SetDefault("CURRENCY_RATES_CACHE_TTL", "12h")                      // Tempo de cache das cotações consultadas
+
 	viper.SetDefault("LOG_LEVEL", "debug")
 }
 
@@ -213,6 +579,21 @@ func NewConfig() (*Config, error) {
 		config.Meta.AccessToken = metaAccessToken
 	}
 
+	// Credenciais não têm valor padrão: sem elas a aplicação não sobe
+	if err := validateRequired(config); err != nil {
+		return nil, err
+	}
+
+	// Um cron inválido impede a inicialização, em vez de deixar o agendador parado
+	if err := validateCronSchedules(config); err != nil {
+		return nil, err
+	}
+
+	// A janela da chave anterior conta a partir da rotação informada, não da inicialização
+	if err := validateKeyRotation(config); err != nil {
+		return nil, err
+	}
+
 	config.Meta.URL = fmt.Sprintf("%s/%s", config.Meta.BaseURL, config.Meta.Version)
 	config.SSOticaMultiClient = make(map[string]SSOtica)
 	for key, token := range secretsByCode {
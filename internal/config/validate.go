@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// placeholderValues são os valores de exemplo que já foram padrão da configuração ou do .env.example.
+// Eles são tratados como ausentes para que a aplicação não suba com credenciais de exemplo.
+var placeholderValues = map[string]bool{
+	"your_secret_key":   true,
+	"your_app_id":       true,
+	"your_app_secret":   true,
+	"your_access_token": true,
+	"token_meta_api":    true,
+}
+
+// missingValue informa se o valor não foi configurado ou ainda é um valor de exemplo
+func missingValue(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || placeholderValues[value]
+}
+
+// validateRequired verifica as chaves sem as quais a aplicação não deve iniciar e retorna um erro com
+// todas as que faltam, para que sejam corrigidas de uma vez.
+func validateRequired(config *Config) error {
+	var missing []string
+
+	// Sem chave, os tokens seriam assinados com um segredo vazio ou conhecido
+	if missingValue(config.Auth.Secret) && missingValue(config.SecretKey) {
+		missing = append(missing, "AUTH_SECRET (ou SECRET_KEY)")
+	}
+
+	required := []struct {
+		key   string
+		value string
+	}{
+		{"DATABASE_USER", config.Database.User},
+		{"DATABASE_PASSWORD", config.Database.Password},
+		{"META_APP_ID", config.Meta.AppID},
+		{"META_APP_SECRET", config.Meta.AppSecret},
+		{"META_ACCESS_TOKEN", config.Meta.AccessToken},
+	}
+	for _, item := range required {
+		if missingValue(item.value) {
+			missing = append(missing, item.key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("configuração obrigatória ausente ou com valor de exemplo: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// validateCronSchedules verifica as expressões cron dos agendadores, mesmo dos desabilitados, e retorna um erro
+// com todas as inválidas. Sem a validação, o gocron só recusa a expressão ao agendar, e o agendador fica parado.
+func validateCronSchedules(config *Config) error {
+	schedules := []struct {
+		key   string
+		value string
+	}{
+		{"META_INSIGHT_SYNC_CRON", config.MetaInsightSync.CronSchedule},
+		{"SSOTICA_INSIGHT_SYNC_CRON", config.SSOticaInsightSync.CronSchedule},
+		{"MONTHLY_INSIGHTS_SYNC_CRON", config.MonthlyInsightsSync.CronSchedule},
+		{"TOP_RANKING_ACCOUNTS_CRON", config.TopRankingAccounts.CronSchedule},
+		{"ROLLUP_INSIGHTS_SYNC_CRON", config.RollupInsightsSync.CronSchedule},
+		{"BUDGET_PACING_CRON", config.BudgetPacing.CronSchedule},
+		{"ANOMALY_DETECTION_CRON", config.AnomalyDetection.CronSchedule},
+		{"INTRADAY_SYNC_CRON", config.IntradaySync.CronSchedule},
+		{"REPORT_SCHEDULE_CRON", config.ReportSchedule.CronSchedule},
+	}
+
+	var invalid []string
+	for _, schedule := range schedules {
+		if _, err := cron.ParseStandard(schedule.value); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s=%q (%v)", schedule.key, schedule.value, err))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("expressão cron inválida: %s", strings.Join(invalid, "; "))
+	}
+
+	return nil
+}
+
+// validateKeyRotation exige AUTH_KEY_ROTATED_AT (RFC3339) quando há uma chave anterior configurada. Sem o
+// momento da rotação, a janela de aceite da chave anterior recomeçaria a cada inicialização da aplicação.
+func validateKeyRotation(config *Config) error {
+	if missingValue(config.Auth.PreviousSecret) {
+		return nil
+	}
+
+	rotatedAt := strings.TrimSpace(config.Auth.KeyRotatedAt)
+	if rotatedAt == "" {
+		return fmt.Errorf("AUTH_KEY_ROTATED_AT é obrigatório quando AUTH_PREVIOUS_SECRET está configurado")
+	}
+	if _, err := time.Parse(time.RFC3339, rotatedAt); err != nil {
+		return fmt.Errorf("AUTH_KEY_ROTATED_AT inválido, use o formato RFC3339 (ex: 2025-03-10T12:00:00Z): %w", err)
+	}
+
+	return nil
+}
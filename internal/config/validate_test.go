@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validConfig() *Config {
+	return &Config{
+		SecretKey: "chave-secreta",
+		Database:  Database{User: "traffic", Password: "senha"},
+		Meta:      Meta{AppID: "123", AppSecret: "segredo", AccessToken: "EAAB"},
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	t.Run("configuração completa", func(t *testing.T) {
+		assert.NoError(t, validateRequired(validConfig()))
+	})
+
+	t.Run("AUTH_SECRET substitui SECRET_KEY", func(t *testing.T) {
+		config := validConfig()
+		config.SecretKey = ""
+		config.Auth.Secret = "chave-secreta"
+
+		assert.NoError(t, validateRequired(config))
+	})
+
+	t.Run("lista todas as chaves ausentes", func(t *testing.T) {
+		config := validConfig()
+		config.SecretKey = ""
+		config.Database.Password = ""
+		config.Meta.AppSecret = " "
+
+		err := validateRequired(config)
+		assert.EqualError(t, err, "configuração obrigatória ausente ou com valor de exemplo: AUTH_SECRET (ou SECRET_KEY), DATABASE_PASSWORD, META_APP_SECRET")
+	})
+
+	t.Run("valores de exemplo contam como ausentes", func(t *testing.T) {
+		config := validConfig()
+		config.SecretKey = "your_secret_key"
+		config.Meta.AppID = "your_app_id"
+
+		err := validateRequired(config)
+		assert.ErrorContains(t, err, "AUTH_SECRET (ou SECRET_KEY), META_APP_ID")
+	})
+}
+
+func TestValidateCronSchedules(t *testing.T) {
+	cronConfig := func() *Config {
+		return &Config{
+			MetaInsightSync:     MetaInsightSync{CronSchedule: "0 3 * * *"},
+			SSOticaInsightSync:  SSOticaInsightSync{CronSchedule: "0 4 * * *"},
+			MonthlyInsightsSync: MonthlyInsightsSync{CronSchedule: "0 5 1 * *"},
+			TopRankingAccounts:  TopRankingAccounts{CronSchedule: "CRON_TZ=America/Sao_Paulo 0 6 * * *"},
+			RollupInsightsSync:  RollupInsightsSync{CronSchedule: "@monthly"},
+			BudgetPacing:        BudgetPacing{CronSchedule: "0 10 * * 1-5"},
+			AnomalyDetection:    AnomalyDetection{CronSchedule: "0 8 * * *"},
+			IntradaySync:        IntradaySync{CronSchedule: "0 8-22 * * *"},
+			ReportSchedule:      ReportSchedule{CronSchedule: "*/5 * * * *"},
+		}
+	}
+
+	t.Run("expressões válidas", func(t *testing.T) {
+		assert.NoError(t, validateCronSchedules(cronConfig()))
+	})
+
+	t.Run("lista todas as expressões inválidas", func(t *testing.T) {
+		config := cronConfig()
+		config.MetaInsightSync.CronSchedule = "0 3 * *"
+		config.ReportSchedule.CronSchedule = ""
+
+		err := validateCronSchedules(config)
+		assert.ErrorContains(t, err, `META_INSIGHT_SYNC_CRON="0 3 * *"`)
+		assert.ErrorContains(t, err, `REPORT_SCHEDULE_CRON=""`)
+		assert.NotContains(t, err.Error(), "SSOTICA_INSIGHT_SYNC_CRON")
+	})
+}
+
+func TestValidateKeyRotation(t *testing.T) {
+	t.Run("sem chave anterior, o momento da rotação é opcional", func(t *testing.T) {
+		assert.NoError(t, validateKeyRotation(validConfig()))
+	})
+
+	t.Run("chave anterior com momento da rotação", func(t *testing.T) {
+		config := validConfig()
+		config.Auth.PreviousSecret = "chave-anterior"
+		config.Auth.KeyRotatedAt = "2025-03-10T12:00:00Z"
+
+		assert.NoError(t, validateKeyRotation(config))
+	})
+
+	t.Run("chave anterior sem momento da rotação", func(t *testing.T) {
+		config := validConfig()
+		config.Auth.PreviousSecret = "chave-anterior"
+
+		assert.ErrorContains(t, validateKeyRotation(config), "AUTH_KEY_ROTATED_AT é obrigatório")
+	})
+
+	t.Run("momento da rotação fora do formato RFC3339", func(t *testing.T) {
+		config := validConfig()
+		config.Auth.PreviousSecret = "chave-anterior"
+		config.Auth.KeyRotatedAt = "10/03/2025"
+
+		assert.ErrorContains(t, validateKeyRotation(config), "AUTH_KEY_ROTATED_AT inválido")
+	})
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
 )
 
 type BusinessManager struct {
@@ -19,41 +21,81 @@ const (
 )
 
 type AdAccount struct {
-	BusinessManagerID   string          `json:"business_id"`
-	BusinessManagerName string          `json:"business_name"`
-	CNPJ                *string         `json:"cnpj"`
-	ExternalID          string          `json:"external_id"`
-	ID                  string          `json:"id"`
-	Name                string          `json:"name"`
-	Nickname            *string         `json:"nickname"`
-	Origin              string          `json:"origin"`
-	SecretName          *string         `json:"secret_name"`
-	Status              AdAccountStatus `json:"status"`
+	BusinessManagerID   string           `json:"business_id"`
+	BusinessManagerName string           `json:"business_name"`
+	CNPJ                *string          `json:"cnpj"`
+	ExternalID          string           `json:"external_id"`
+	ID                  string           `json:"id"`
+	Name                string           `json:"name"`
+	Nickname            *string          `json:"nickname"`
+	Origin              string           `json:"origin"`
+	SecretName          *string          `json:"secret_name"`
+	Status              AdAccountStatus  `json:"status"`
+	Currency            string           `json:"currency"`
+	Locale              string           `json:"locale"`
+	Timezone            string           `json:"timezone"`
+	MergedInto          *string          `json:"merged_into,omitempty"` // conta que recebeu os dados desta conta duplicada
+	VIP                 bool             `json:"vip"`                   // sincronizada antes das demais contas
+	Location            *AccountLocation `json:"location,omitempty"`
+}
+
+// FormatMetadata retorna como os valores da conta devem ser formatados
+func (a *AdAccount) FormatMetadata() *FormatMetadata {
+	return NewFormatMetadata(a.Currency, a.Locale)
 }
 
 type AdAccountResponse struct {
-	CNPJ       *string         `json:"cnpj"`
-	ExternalID string          `json:"external_id"`
-	ID         string          `json:"id"`
-	Name       string          `json:"name"`
-	Nickname   *string         `json:"nickname"`
-	HasToken   bool            `json:"hasToken"`
-	Status     AdAccountStatus `json:"status"`
+	CNPJ       *string           `json:"cnpj"`
+	ExternalID string            `json:"external_id"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Nickname   *string           `json:"nickname"`
+	HasToken   bool              `json:"hasToken"`
+	Status     AdAccountStatus   `json:"status"`
+	Permission AccountPermission `json:"permission,omitempty"`
+	Format     *FormatMetadata   `json:"format,omitempty"`
+	Timezone   string            `json:"timezone,omitempty"`
+	MergedInto *string           `json:"merged_into,omitempty"`
+	VIP        bool              `json:"vip"`
+	Location   *AccountLocation  `json:"location,omitempty"`
 }
 
 type AdAccountInsight struct {
 	AccountID     string             `json:"account_id"`
 	Campaigns     []*CampaignInsight `json:"ad_campaigns"`
+	Clicks        int                `json:"clicks"`
 	CostPerResult float64            `json:"cost_per_result"`
+	CPC           float64            `json:"cpc"` // Custo por clique
+	CPM           float64            `json:"cpm"` // Custo por mil impressões
+	CTR           float64            `json:"ctr"` // Taxa de cliques, em percentual
 	Frequency     float64            `json:"frequency"`
 	Impressions   int                `json:"impressions"`
+	LinkClicks    int                `json:"link_clicks"`
 	Name          string             `json:"account_name"`
 	Objective     string             `json:"objective"`
+	ObjectiveInfo *ObjectiveInfo     `json:"objective_info,omitempty"`
 	Reach         int                `json:"reach"`
 	Result        int                `json:"result"`
 	Spend         float64            `json:"spend"`
 }
 
+// CalculateDeliveryRates recalcula CTR, CPM e CPC a partir dos totais de investimento, impressões e cliques
+func (i *AdAccountInsight) CalculateDeliveryRates() {
+	i.CTR, i.CPM, i.CPC = DeliveryRates(i.Spend, i.Impressions, i.Clicks)
+}
+
+// DeliveryRates calcula CTR (%), CPM e CPC. Taxas sem base (zero impressões ou cliques) ficam zeradas.
+func DeliveryRates(spend float64, impressions, clicks int) (ctr, cpm, cpc float64) {
+	if impressions > 0 {
+		ctr = utils.RoundWithTwoDecimalPlace(float64(clicks) / float64(impressions) * 100)
+		cpm = utils.RoundWithTwoDecimalPlace(spend / float64(impressions) * 1000)
+	}
+	if clicks > 0 {
+		cpc = utils.RoundWithTwoDecimalPlace(spend / float64(clicks))
+	}
+	return ctr, cpm, cpc
+}
+
 type AdAccountMetrics struct {
 	AdAccountInsight
 	CostPerResultByDate map[string]float64 `json:"cost_per_result_by_date"`
@@ -81,6 +123,14 @@ type UpdateAdAccountRequest struct {
 	SecretName *string `json:"secret_name,omitempty"`
 	Token      *string `json:"token,omitempty"`
 	Status     *string `json:"status,omitempty"`
+	Currency   *string `json:"currency,omitempty"`
+	Locale     *string `json:"locale,omitempty"`
+	Timezone   *string `json:"timezone,omitempty"`
+}
+
+// AccountPriorityRequest marca ou desmarca a conta como prioritária (VIP) na sincronização
+type AccountPriorityRequest struct {
+	VIP *bool `json:"vip"`
 }
 
 type UpdateAdAccountResponse struct {
@@ -89,6 +139,9 @@ type UpdateAdAccountResponse struct {
 	CNPJ       *string `json:"cnpj,omitempty"`
 	SecretName *string `json:"secret_name,omitempty"`
 	Status     *string `json:"status,omitempty"`
+	Currency   *string `json:"currency,omitempty"`
+	Locale     *string `json:"locale,omitempty"`
+	Timezone   *string `json:"timezone,omitempty"`
 }
 
 type SyncAccountsResponse struct {
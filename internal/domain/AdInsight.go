@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 )
 
@@ -14,3 +15,23 @@ type AdInsightEntry struct {
 	CreatedAt  time.Time         `json:"created_at"`
 	UpdatedAt  time.Time         `json:"updated_at"`
 }
+
+// AdDailySnapshot é o resumo de um dia de veiculação da conta, usado pela sincronização incremental
+// para identificar os dias cujos dados mudaram no Meta desde a última coleta
+type AdDailySnapshot struct {
+	Date        time.Time `json:"date"`
+	Spend       float64   `json:"spend"`
+	Impressions int       `json:"impressions"`
+	Reach       int       `json:"reach"`
+}
+
+// Matches indica se as métricas armazenadas ainda correspondem ao resumo do dia
+func (s *AdDailySnapshot) Matches(metrics *AdAccountMetrics) bool {
+	if s == nil || metrics == nil {
+		return false
+	}
+
+	return math.Round(s.Spend*100) == math.Round(metrics.Spend*100) &&
+		s.Impressions == metrics.Impressions &&
+		s.Reach == metrics.Reach
+}
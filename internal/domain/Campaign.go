@@ -1,19 +1,90 @@
 package domain
 
+import (
+	"strconv"
+	"time"
+)
+
 type Campaign struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
 type CampaignInsight struct {
-	CampaignID    string  `json:"campaign_id"`
-	CampaignName  string  `json:"campaign_name"`
-	Clicks        string  `json:"clicks"`
-	CostPerResult float64 `json:"cost_per_result"`
-	Frequency     string  `json:"frequency"`
-	Impressions   string  `json:"impressions"`
-	Objective     string  `json:"objective"`
-	Reach         string  `json:"reach"`
+	CampaignID   string `json:"campaign_id"`
+	CampaignName string `json:"campaign_name"`
+	// Dimensions são as partes do nome extraídas pela convenção de nomenclatura (ex: loja, produto, objetivo)
+	Dimensions    map[string]string `json:"dimensions,omitempty"`
+	Clicks        string            `json:"clicks"`
+	CostPerResult float64           `json:"cost_per_result"`
+	CPC           float64           `json:"cpc"`
+	CPM           float64           `json:"cpm"`
+	CTR           float64           `json:"ctr"`
+	Frequency     string            `json:"frequency"`
+	Impressions   string            `json:"impressions"`
+	LinkClicks    int               `json:"link_clicks"`
+	Objective     string            `json:"objective"`
+	ObjectiveInfo *ObjectiveInfo    `json:"objective_info,omitempty"`
+	Reach         string            `json:"reach"`
+	Result        int               `json:"result"`
+	Spend         float64           `json:"spend"`
+}
+
+// CalculateDeliveryRates recalcula CTR, CPM e CPC da campanha a partir dos totais
+func (c *CampaignInsight) CalculateDeliveryRates() {
+	impressions, _ := strconv.Atoi(c.Impressions)
+	clicks, _ := strconv.Atoi(c.Clicks)
+	c.CTR, c.CPM, c.CPC = DeliveryRates(c.Spend, impressions, clicks)
+}
+
+// DimensionGroup soma as métricas das campanhas que compartilham o mesmo valor de uma dimensão do nome
+type DimensionGroup struct {
+	Value         string  `json:"value"`
+	Campaigns     int     `json:"campaigns"`
+	Spend         float64 `json:"spend"`
+	Impressions   int     `json:"impressions"`
+	Reach         int     `json:"reach"`
+	Clicks        int     `json:"clicks"`
 	Result        int     `json:"result"`
+	CostPerResult float64 `json:"cost_per_result"`
+}
+
+type DimensionInsightsResponse struct {
+	AccountID string            `json:"account_id"`
+	Dimension string            `json:"dimension"`
+	StartDate string            `json:"start_date"`
+	EndDate   string            `json:"end_date"`
+	Groups    []*DimensionGroup `json:"groups"`
+	Format    *FormatMetadata   `json:"format,omitempty"`
+}
+
+// CampaignMiniMetrics resume os últimos 7 dias de uma campanha no catálogo
+type CampaignMiniMetrics struct {
 	Spend         float64 `json:"spend"`
+	Impressions   int     `json:"impressions"`
+	Reach         int     `json:"reach"`
+	Clicks        int     `json:"clicks"`
+	Result        int     `json:"result"`
+	CostPerResult float64 `json:"cost_per_result"`
+}
+
+// CampaignCatalogItem é uma campanha listada no seletor do dashboard.
+// Orçamentos vêm em unidades da moeda da conta; nil quando a campanha usa orçamento em outro nível.
+type CampaignCatalogItem struct {
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	Objective      string               `json:"objective"`
+	ObjectiveInfo  *ObjectiveInfo       `json:"objective_info,omitempty"`
+	Status         string               `json:"status"`
+	DailyBudget    *float64             `json:"daily_budget,omitempty"`
+	LifetimeBudget *float64             `json:"lifetime_budget,omitempty"`
+	Dimensions     map[string]string    `json:"dimensions,omitempty"`
+	Last7Days      *CampaignMiniMetrics `json:"last_7_days,omitempty"`
+}
+
+type CampaignCatalogResponse struct {
+	AccountID string                 `json:"account_id"`
+	Campaigns []*CampaignCatalogItem `json:"campaigns"`
+	CachedAt  time.Time              `json:"cached_at"`
+	Format    *FormatMetadata        `json:"format,omitempty"`
 }
@@ -9,4 +9,6 @@ type MonthlyInsightReport struct {
 	AdMetrics     *AdAccountMetrics        `json:"ad_metrics,omitempty"`
 	SalesMetrics  map[string]*SalesMetrics `json:"sales_metrics,omitempty"`
 	ResultMetrics *ResultMetrics           `json:"result_metrics,omitempty"`
+	Goals         *GoalAttainment          `json:"goals,omitempty"` // Progresso em relação às metas do mês, quando definidas
+	Format        *FormatMetadata          `json:"format,omitempty"`
 }
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de atividade exibidos na linha do tempo da conta
+const (
+	AccountActivitySync    = "sync"
+	AccountActivityEdit    = "edit"
+	AccountActivityAnomaly = "anomaly"
+	AccountActivityNote    = "note"
+)
+
+var AccountActivityTypes = []string{AccountActivitySync, AccountActivityEdit, AccountActivityAnomaly, AccountActivityNote}
+
+// AccountActivity é um item da linha do tempo de uma conta.
+// Details guarda dados específicos de cada tipo (ex: datas sincronizadas, campos alterados).
+type AccountActivity struct {
+	ID        int64           `json:"id"`
+	AccountID string          `json:"account_id"`
+	Type      string          `json:"type"`
+	Summary   string          `json:"summary"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	UserID    *int            `json:"user_id,omitempty"`
+	UserName  string          `json:"user_name,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AccountActivityFilters pagina a linha do tempo do mais recente para o mais antigo
+type AccountActivityFilters struct {
+	Types  []string
+	Before *time.Time
+	Limit  int
+}
+
+type CreateAccountNoteRequest struct {
+	Text string `json:"text"`
+}
+
+// Pesos do score de atividade: uma visualização vale o mesmo que R$ 100 investidos no período
+const (
+	activityScoreViewWeight = 1.0
+	activityScoreSpendUnit  = 100.0
+)
+
+// AccountActivityScore resume o uso recente de uma conta (visualizações do dashboard e investimento)
+type AccountActivityScore struct {
+	AccountID string
+	Views     int
+	Spend     float64
+}
+
+// Score ordena as contas na fila de sincronização: maior score é sincronizado primeiro
+func (s AccountActivityScore) Score() float64 {
+	return float64(s.Views)*activityScoreViewWeight + s.Spend/activityScoreSpendUnit
+}
@@ -0,0 +1,29 @@
+package domain
+
+const (
+	DefaultAccountListLimit = 100
+	MaxAccountListLimit     = 500
+)
+
+// AccountListFilters filtra e pagina a listagem de contas. Search busca no apelido, no nome e no CNPJ.
+type AccountListFilters struct {
+	Status            []AdAccountStatus
+	BusinessManagerID string
+	Search            string
+	Limit             int
+	Offset            int
+}
+
+// AdAccountListResponse é uma página da listagem de contas; Total conta todas as contas que atendem aos filtros
+type AdAccountListResponse struct {
+	Accounts []*AdAccountResponse `json:"accounts"`
+	Total    int                  `json:"total"`
+	Limit    int                  `json:"limit"`
+	Offset   int                  `json:"offset"`
+}
+
+// AdAccountSearchResponse traz as contas encontradas para o termo, das mais parecidas para as menos parecidas
+type AdAccountSearchResponse struct {
+	Query    string               `json:"query"`
+	Accounts []*AdAccountResponse `json:"accounts"`
+}
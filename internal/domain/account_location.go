@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// BrazilianStates são as UFs aceitas na localização das lojas
+var BrazilianStates = []string{
+	"AC", "AL", "AP", "AM", "BA", "CE", "DF", "ES", "GO", "MA", "MT", "MS", "MG", "PA",
+	"PB", "PR", "PE", "PI", "RJ", "RN", "RS", "RO", "RR", "SC", "SP", "SE", "TO",
+}
+
+// AccountLocation é a localização da loja de uma conta. As coordenadas ficam vazias
+// enquanto a loja não for geocodificada.
+type AccountLocation struct {
+	City       string     `json:"city"`
+	State      string     `json:"state"`
+	Latitude   *float64   `json:"latitude,omitempty"`
+	Longitude  *float64   `json:"longitude,omitempty"`
+	GeocodedAt *time.Time `json:"geocoded_at,omitempty"`
+}
+
+// GeocodeAccountRequest define a localização da loja. Sem coordenadas, o endereço, a cidade e a UF
+// são geocodificados; com coordenadas, elas são gravadas como informadas.
+type GeocodeAccountRequest struct {
+	Address   string   `json:"address,omitempty"`
+	City      string   `json:"city"`
+	State     string   `json:"state"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// NormalizeState retorna a UF em maiúsculas e se ela é válida
+func NormalizeState(state string) (string, bool) {
+	state = strings.ToUpper(strings.TrimSpace(state))
+	return state, slices.Contains(BrazilianStates, state)
+}
+
+// InsightsMapResponse agrega investimento e faturamento em redes sociais das lojas por UF e cidade
+type InsightsMapResponse struct {
+	Period               string             `json:"period"`
+	StartDate            string             `json:"start_date"`
+	EndDate              string             `json:"end_date"`
+	States               []*MapStateInsight `json:"states"`
+	Spend                float64            `json:"spend"`
+	SocialNetworkRevenue float64            `json:"social_network_revenue"`
+	UnlocatedAccounts    int                `json:"unlocated_accounts"` // contas ativas sem UF, fora do mapa
+}
+
+type MapStateInsight struct {
+	State                string            `json:"state"`
+	Accounts             int               `json:"accounts"`
+	Spend                float64           `json:"spend"`
+	SocialNetworkRevenue float64           `json:"social_network_revenue"`
+	ROI                  float64           `json:"roi"`
+	Cities               []*MapCityInsight `json:"cities"`
+}
+
+// MapCityInsight traz a posição média das lojas da cidade para o marcador no mapa
+type MapCityInsight struct {
+	City                 string   `json:"city"`
+	State                string   `json:"state"`
+	Latitude             *float64 `json:"latitude,omitempty"`
+	Longitude            *float64 `json:"longitude,omitempty"`
+	Accounts             int      `json:"accounts"`
+	Spend                float64  `json:"spend"`
+	SocialNetworkRevenue float64  `json:"social_network_revenue"`
+	ROI                  float64  `json:"roi"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// MergeAccountsRequest pede a mesclagem de uma conta duplicada (source) na conta que permanece (target)
+type MergeAccountsRequest struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// AccountMerge é o histórico de uma mesclagem. MovedRows conta, por tabela, as linhas transferidas
+// para a conta destino; KeptRows as linhas que ficaram na origem porque o destino já tinha o mesmo período.
+type AccountMerge struct {
+	ID        int            `json:"id"`
+	SourceID  string         `json:"source_id"`
+	TargetID  string         `json:"target_id"`
+	MergedBy  *int           `json:"merged_by,omitempty"`
+	Reason    string         `json:"reason,omitempty"`
+	MovedRows map[string]int `json:"moved_rows"`
+	KeptRows  map[string]int `json:"kept_rows"`
+	CreatedAt time.Time      `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "fmt"
+
+// AccountPermission é o nível de acesso de um usuário a uma conta vinculada
+type AccountPermission string
+
+const (
+	// AccountPermissionViewer permite apenas visualizar as métricas da conta
+	AccountPermissionViewer AccountPermission = "viewer"
+	// AccountPermissionAnalyst permite também editar dados não sensíveis da conta (ex: apelido)
+	AccountPermissionAnalyst AccountPermission = "analyst"
+	// AccountPermissionManager permite editar CNPJ, token/secret e status da conta
+	AccountPermissionManager AccountPermission = "manager"
+)
+
+var accountPermissionRank = map[AccountPermission]int{
+	AccountPermissionViewer:  1,
+	AccountPermissionAnalyst: 2,
+	AccountPermissionManager: 3,
+}
+
+// ParseAccountPermission valida o nível de acesso informado. Vazio retorna viewer.
+func ParseAccountPermission(value string) (AccountPermission, error) {
+	if value == "" {
+		return AccountPermissionViewer, nil
+	}
+
+	permission := AccountPermission(value)
+	if _, ok := accountPermissionRank[permission]; !ok {
+		return "", fmt.Errorf("permissão de conta inválida: %s (use viewer, analyst ou manager)", value)
+	}
+
+	return permission, nil
+}
+
+// Allows indica se o nível de acesso atende ao nível exigido
+func (p AccountPermission) Allows(required AccountPermission) bool {
+	rank, ok := accountPermissionRank[p]
+	return ok && rank >= accountPermissionRank[required]
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Agrupamentos da série de métricas retornada junto com os totais do período
+const (
+	AggregationDaily     = "daily"
+	AggregationWeekly    = "weekly"
+	AggregationMonthly   = "monthly"
+	AggregationQuarterly = "quarterly"
+)
+
+var Aggregations = []string{AggregationDaily, AggregationWeekly, AggregationMonthly, AggregationQuarterly}
+
+func IsValidAggregation(aggregation string) bool {
+	return slices.Contains(Aggregations, aggregation)
+}
+
+// AggregationPeriod retorna o período que contém a data e o seu intervalo completo. As semanas começam
+// na segunda-feira e seguem a numeração ISO (2025-W09); meses usam 03-2025 e trimestres Q1-2025.
+func AggregationPeriod(aggregation string, date time.Time) (string, time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch aggregation {
+	case AggregationWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week), start, start.AddDate(0, 0, 6)
+	case AggregationMonthly:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start.Format("01-2006"), start, start.AddDate(0, 1, -1)
+	case AggregationQuarterly:
+		return QuarterPeriod(day)
+	default:
+		return day.Format(time.DateOnly), day, day
+	}
+}
+
+// InsightSeriesPoint são as métricas de um período da série. StartDate e EndDate ficam limitados
+// ao intervalo consultado, então o primeiro e o último período podem ser parciais.
+type InsightSeriesPoint struct {
+	Period           string
+	StartDate        string
+	EndDate          string
+	AdAccountMetrics *AdAccountMetrics
+	SalesMetrics     map[string]*SalesMetrics
+	ResultMetrics    *ResultMetrics
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Tipos de anotação de data
+const (
+	AnnotationTypePromotion = "promocao"
+	AnnotationTypeHoliday   = "feriado"
+	AnnotationTypeEvent     = "evento"
+	AnnotationTypeIssue     = "problema"
+	AnnotationTypeOther     = "outro"
+)
+
+var AnnotationTypes = []string{AnnotationTypePromotion, AnnotationTypeHoliday, AnnotationTypeEvent, AnnotationTypeIssue, AnnotationTypeOther}
+
+// Annotation explica o que aconteceu em uma data da conta (ex: promoção que gerou um pico de vendas).
+// Date usa o formato YYYY-MM-DD.
+type Annotation struct {
+	ID        int       `json:"id"`
+	AccountID string    `json:"account_id"`
+	Date      string    `json:"date"`
+	Type      string    `json:"type"`
+	Text      string    `json:"text"`
+	UserID    *int      `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AnnotationRequest struct {
+	Date string `json:"date"`
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
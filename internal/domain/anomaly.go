@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// Métricas diárias avaliadas na detecção de anomalias
+const (
+	AnomalyMetricSpend         = "spend"
+	AnomalyMetricCostPerResult = "cost_per_result"
+)
+
+const (
+	AnomalyDirectionAbove = "above"
+	AnomalyDirectionBelow = "below"
+)
+
+const (
+	// AnomalyTrailingDays é a janela de dias anteriores usada como referência de cada dia
+	AnomalyTrailingDays = 30
+	// AnomalyMinSamples é o mínimo de dias com dados na janela para que um dia seja avaliado
+	AnomalyMinSamples = 7
+)
+
+// InsightAnomaly é um dia em que a métrica se afastou da média dos 30 dias anteriores mais que o limite
+// de desvios-padrão configurado. Date usa o formato YYYY-MM-DD.
+type InsightAnomaly struct {
+	ID         int       `json:"id"`
+	AccountID  string    `json:"account_id"`
+	Date       string    `json:"date"`
+	Metric     string    `json:"metric"`    // spend ou cost_per_result
+	Value      float64   `json:"value"`     // Valor do dia
+	Mean       float64   `json:"mean"`      // Média dos dias anteriores na janela
+	StdDev     float64   `json:"std_dev"`   // Desvio-padrão dos dias anteriores na janela
+	ZScore     float64   `json:"z_score"`   // Quantos desvios-padrão o dia está da média
+	Direction  string    `json:"direction"` // above ou below
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// DetectInsightAnomalies avalia os dias entre from e to comparando investimento e custo por resultado
+// com a média e o desvio-padrão dos AnomalyTrailingDays dias anteriores. entries deve conter também a
+// janela anterior a from. Dias sem resultado não entram na avaliação do custo por resultado.
+func DetectInsightAnomalies(accountID string, entries []*AdInsightEntry, from, to time.Time, threshold float64) []*InsightAnomaly {
+	spend := make(map[string]float64)
+	costPerResult := make(map[string]float64)
+	for _, entry := range entries {
+		if entry == nil || entry.AdMetrics == nil {
+			continue
+		}
+		day := entry.Date.Format(time.DateOnly)
+		spend[day] = entry.AdMetrics.Spend
+		if entry.AdMetrics.Result > 0 {
+			costPerResult[day] = entry.AdMetrics.Spend / float64(entry.AdMetrics.Result)
+		}
+	}
+
+	anomalies := make([]*InsightAnomaly, 0)
+	for day := truncateDate(from); !day.After(truncateDate(to)); day = day.AddDate(0, 0, 1) {
+		for _, metric := range []struct {
+			name   string
+			values map[string]float64
+		}{
+			{AnomalyMetricSpend, spend},
+			{AnomalyMetricCostPerResult, costPerResult},
+		} {
+			if anomaly := detectDayAnomaly(metric.values, day, threshold); anomaly != nil {
+				anomaly.AccountID = accountID
+				anomaly.Metric = metric.name
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+	}
+
+	return anomalies
+}
+
+func detectDayAnomaly(values map[string]float64, day time.Time, threshold float64) *InsightAnomaly {
+	value, ok := values[day.Format(time.DateOnly)]
+	if !ok {
+		return nil
+	}
+
+	window := make([]float64, 0, AnomalyTrailingDays)
+	for i := 1; i <= AnomalyTrailingDays; i++ {
+		if v, ok := values[day.AddDate(0, 0, -i).Format(time.DateOnly)]; ok {
+			window = append(window, v)
+		}
+	}
+	if len(window) < AnomalyMinSamples {
+		return nil
+	}
+
+	var sum float64
+	for _, v := range window {
+		sum += v
+	}
+	mean := sum / float64(len(window))
+
+	var variance float64
+	for _, v := range window {
+		variance += (v - mean) * (v - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(window)))
+	if stdDev == 0 {
+		return nil
+	}
+
+	zScore := (value - mean) / stdDev
+	if math.Abs(zScore) <= threshold {
+		return nil
+	}
+
+	direction := AnomalyDirectionAbove
+	if zScore < 0 {
+		direction = AnomalyDirectionBelow
+	}
+
+	return &InsightAnomaly{
+		Date:      day.Format(time.DateOnly),
+		Value:     utils.RoundWithTwoDecimalPlace(value),
+		Mean:      utils.RoundWithTwoDecimalPlace(mean),
+		StdDev:    utils.RoundWithTwoDecimalPlace(stdDev),
+		ZScore:    utils.RoundWithTwoDecimalPlace(zScore),
+		Direction: direction,
+	}
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
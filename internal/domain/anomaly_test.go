@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dailyEntries(start time.Time, spends []float64, results []int) []*AdInsightEntry {
+	entries := make([]*AdInsightEntry, 0, len(spends))
+	for i, spend := range spends {
+		entries = append(entries, &AdInsightEntry{
+			Date:      start.AddDate(0, 0, i),
+			AdMetrics: &AdAccountMetrics{AdAccountInsight: AdAccountInsight{Spend: spend, Result: results[i]}},
+		})
+	}
+	return entries
+}
+
+func TestDetectInsightAnomalies(t *testing.T) {
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	spends := make([]float64, 0, 31)
+	results := make([]int, 0, 31)
+	for i := 0; i < 30; i++ {
+		spends = append(spends, 100+float64(i%3)) // 100, 101, 102...
+		results = append(results, 10)
+	}
+	spends = append(spends, 400)
+	results = append(results, 10)
+
+	day := start.AddDate(0, 0, 30)
+	anomalies := DetectInsightAnomalies("abc123", dailyEntries(start, spends, results), day, day, 3)
+
+	assert.Len(t, anomalies, 2)
+	assert.Equal(t, AnomalyMetricSpend, anomalies[0].Metric)
+	assert.Equal(t, AnomalyDirectionAbove, anomalies[0].Direction)
+	assert.Equal(t, "2025-05-31", anomalies[0].Date)
+	assert.Equal(t, "abc123", anomalies[0].AccountID)
+	assert.Equal(t, AnomalyMetricCostPerResult, anomalies[1].Metric)
+
+	t.Run("dia dentro do padrão não é marcado", func(t *testing.T) {
+		spends[30] = 101
+		assert.Empty(t, DetectInsightAnomalies("abc123", dailyEntries(start, spends, results), day, day, 3))
+	})
+
+	t.Run("histórico curto não é avaliado", func(t *testing.T) {
+		short := dailyEntries(start, []float64{100, 101, 400}, []int{10, 10, 10})
+		assert.Empty(t, DetectInsightAnomalies("abc123", short, start.AddDate(0, 0, 2), start.AddDate(0, 0, 2), 3))
+	})
+}
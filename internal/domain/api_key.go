@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// APIKey é uma chave de acesso para integrações (ferramentas de BI, scripts). A chave age em nome do
+// usuário que a criou, limitada aos seus escopos. Só o hash SHA-256 é armazenado.
+type APIKey struct {
+	ID         int          `json:"id"`
+	UserID     int          `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"-"`
+	Scopes     []Permission `json:"scopes"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// Usable indica se a chave ainda dá acesso
+func (k *APIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// APIKeyScopes são as permissões que podem ser concedidas a uma chave: apenas leitura
+var APIKeyScopes = []Permission{
+	PermissionInsightsRead,
+	PermissionAccountsAll,
+	PermissionReportsConsolidated,
+	PermissionSalesRead,
+}
+
+// IsValidAPIKeyScope indica se a permissão pode ser concedida a uma chave de API
+func IsValidAPIKeyScope(p Permission) bool {
+	for _, scope := range APIKeyScopes {
+		if scope == p {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string       `json:"name"`
+	Scopes []Permission `json:"scopes"`
+	// ExpiresAt é opcional; sem ele a chave vale pelo prazo máximo configurado
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyResponse traz a chave em si, retornada apenas na criação
+type CreateAPIKeyResponse struct {
+	*APIKey
+	Key string `json:"key"`
+}
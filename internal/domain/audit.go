@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Ações registradas no log de auditoria
+const (
+	AuditActionUserUpdate          = "user.update"
+	AuditActionUserImport          = "user.import"
+	AuditActionUserDeactivate      = "user.deactivate"
+	AuditActionPasswordGenerate    = "user.password_generate"
+	AuditActionSessionRevoke       = "user.session_revoke"
+	AuditActionAccountUpdate       = "account.update"
+	AuditActionAccountMerge        = "account.merge"
+	AuditActionAccountLink         = "account.link"
+	AuditActionAccountUnlink       = "account.unlink"
+	AuditActionAccountLinksReplace = "account.links_replace"
+	AuditActionSyncTrigger         = "sync.trigger"
+	AuditActionSyncPause           = "sync.pause"
+	AuditActionSyncResume          = "sync.resume"
+	AuditActionSyncRequeue         = "sync.requeue"
+	AuditActionRoleCreate          = "role.create"
+	AuditActionRoleUpdate          = "role.update"
+	AuditActionRoleDelete          = "role.delete"
+)
+
+// Tipos de entidade afetados pelas ações auditadas
+const (
+	AuditEntityUser        = "user"
+	AuditEntityAccount     = "account"
+	AuditEntityJob         = "job"
+	AuditEntityRole        = "role"
+	AuditEntitySyncFailure = "sync_failure"
+)
+
+// Limites da paginação do log de auditoria
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 200
+)
+
+// AuditEntry registra quem executou uma operação sensível e sobre qual entidade.
+// Details guarda os dados da operação (ex: campos alterados), sem senhas nem tokens.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    *int            `json:"actor_id,omitempty"`
+	ActorName  string          `json:"actor_name,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditLogFilters filtra e pagina o log de auditoria, do registro mais recente para o mais antigo
+type AuditLogFilters struct {
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditLogPage é uma página do log de auditoria; Total conta todos os registros que atendem aos filtros
+type AuditLogPage struct {
+	Entries []*AuditEntry `json:"entries"`
+	Total   int           `json:"total"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Situações de um bloco de backfill
+const (
+	BackfillChunkPending   = "pending"
+	BackfillChunkSucceeded = "succeeded"
+	BackfillChunkFailed    = "failed"
+)
+
+// BackfillChunk é um mês de um backfill de insights. O sucesso é registrado por bloco,
+// para que uma nova execução do mesmo período refaça apenas os blocos que falharam.
+type BackfillChunk struct {
+	Source         string    `json:"source"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	Status         string    `json:"status"`
+	FailedAccounts int       `json:"failed_accounts"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// BackfillRequest define o período de um backfill. Integrator (meta ou ssotica) é informado no corpo em
+// /v1/admin/sync/backfill; sem AccountIDs, o backfill é de todas as contas ativas.
+type BackfillRequest struct {
+	Integrator string   `json:"integrator,omitempty"`
+	AccountIDs []string `json:"account_ids,omitempty"`
+	StartDate  string   `json:"start_date"`
+	EndDate    string   `json:"end_date"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// BreakdownSegment são as métricas de um segmento (faixa etária, gênero ou plataforma) no período
+type BreakdownSegment struct {
+	Key           string  `json:"key"`
+	Label         string  `json:"label"`
+	Spend         float64 `json:"spend"`
+	Impressions   int     `json:"impressions"`
+	Result        int     `json:"result"`
+	CostPerResult float64 `json:"cost_per_result"`
+	SpendShare    float64 `json:"spend_share"`  // Percentual do investimento do período
+	ResultShare   float64 `json:"result_share"` // Percentual dos resultados do período
+}
+
+// AdAccountBreakdowns agrupa as quebras demográficas e de posicionamento da conta
+type AdAccountBreakdowns struct {
+	Age      []*BreakdownSegment `json:"age"`
+	Gender   []*BreakdownSegment `json:"gender"`
+	Platform []*BreakdownSegment `json:"platform"`
+}
+
+// Select retorna apenas as quebras pedidas (age, gender ou platform)
+func (b *AdAccountBreakdowns) Select(dimensions []string) *AdAccountBreakdowns {
+	if b == nil {
+		return nil
+	}
+
+	selected := &AdAccountBreakdowns{}
+	for _, dimension := range dimensions {
+		switch dimension {
+		case BreakdownAge:
+			selected.Age = b.Age
+		case BreakdownGender:
+			selected.Gender = b.Gender
+		case BreakdownPlatform:
+			selected.Platform = b.Platform
+		}
+	}
+	return selected
+}
+
+// AdInsightBreakdownEntry é o registro armazenado das quebras de uma conta em um período
+type AdInsightBreakdownEntry struct {
+	ID         int64                `json:"id"`
+	AccountID  string               `json:"account_id"`
+	StartDate  time.Time            `json:"start_date"`
+	EndDate    time.Time            `json:"end_date"`
+	Breakdowns *AdAccountBreakdowns `json:"breakdowns"`
+	FetchedAt  time.Time            `json:"fetched_at"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+type AdAccountBreakdownsResponse struct {
+	AccountID string `json:"account_id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	AdAccountBreakdowns
+	FetchedAt time.Time       `json:"fetched_at"`
+	Format    *FormatMetadata `json:"format,omitempty"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// Situação das sincronizações das contas ativas de um business manager
+const (
+	SyncHealthHealthy  = "healthy"  // todas as contas ativas com insights recentes
+	SyncHealthDegraded = "degraded" // parte das contas ativas sem insights recentes
+	SyncHealthFailing  = "failing"  // nenhuma conta ativa com insights recentes
+	SyncHealthInactive = "inactive" // business manager sem contas ativas
+)
+
+// BusinessManagerSummary resume um business manager para o diretório administrativo. Investimento e
+// faturamento vêm dos caches de insights diários do período; StaleAccounts são as contas ativas sem insights recentes.
+type BusinessManagerSummary struct {
+	ID                   string     `json:"id"`
+	ExternalID           string     `json:"external_id"`
+	Name                 string     `json:"name"`
+	Origin               string     `json:"origin"`
+	Accounts             int        `json:"accounts"`
+	ActiveAccounts       int        `json:"active_accounts"`
+	Spend                float64    `json:"spend"`
+	SocialNetworkRevenue float64    `json:"social_network_revenue"`
+	LastAdSyncAt         *time.Time `json:"last_ad_sync_at,omitempty"`
+	LastSalesSyncAt      *time.Time `json:"last_sales_sync_at,omitempty"`
+	StaleAccounts        int        `json:"stale_accounts"`
+	SyncHealth           string     `json:"sync_health"`
+}
+
+type BusinessManagerDirectoryResponse struct {
+	StartDate        string                    `json:"start_date"`
+	EndDate          string                    `json:"end_date"`
+	BusinessManagers []*BusinessManagerSummary `json:"business_managers"`
+}
+
+// CalculateSyncHealth classifica a situação das sincronizações a partir das contas ativas sem insights recentes
+func (s *BusinessManagerSummary) CalculateSyncHealth() {
+	switch {
+	case s.ActiveAccounts == 0:
+		s.SyncHealth = SyncHealthInactive
+	case s.StaleAccounts == 0:
+		s.SyncHealth = SyncHealthHealthy
+	case s.StaleAccounts < s.ActiveAccounts:
+		s.SyncHealth = SyncHealthDegraded
+	default:
+		s.SyncHealth = SyncHealthFailing
+	}
+}
+
+// BusinessManagerAccountInsight é a participação de uma conta no consolidado do business manager
+type BusinessManagerAccountInsight struct {
+	AccountID            string  `json:"account_id"`
+	AccountName          string  `json:"account_name"`
+	Spend                float64 `json:"spend"`
+	Result               int     `json:"result"`
+	SocialNetworkRevenue float64 `json:"social_network_revenue"`
+}
+
+// BusinessManagerInsightFailure identifica uma conta que ficou fora do consolidado
+type BusinessManagerInsightFailure struct {
+	AccountID   string `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Error       string `json:"error"`
+}
+
+// BusinessManagerInsightsResponse consolida os insights das contas ativas de um business manager no período.
+// Os valores de investimento ficam na moeda do faturamento (ver Format).
+type BusinessManagerInsightsResponse struct {
+	BusinessManagerID   string                           `json:"business_manager_id"`
+	BusinessManagerName string                           `json:"business_manager_name"`
+	StartDate           string                           `json:"start_date"`
+	EndDate             string                           `json:"end_date"`
+	AdMetrics           *AdAccountMetrics                `json:"ad_metrics,omitempty"`
+	SalesMetrics        map[string]*SalesMetrics         `json:"sales_metrics,omitempty"`
+	ResultMetrics       *ResultMetrics                   `json:"result_metrics,omitempty"`
+	Accounts            []*BusinessManagerAccountInsight `json:"accounts"`
+	Failures            []*BusinessManagerInsightFailure `json:"failures,omitempty"`
+	Format              *FormatMetadata                  `json:"format,omitempty"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// AccountDashboard reúne em uma resposta o que o dashboard da conta exibe ao carregar: insights do período,
+// posição no ranking de lojas, progresso das metas e quando os dados foram sincronizados
+type AccountDashboard struct {
+	AccountID  string                     `json:"account_id"`
+	ExternalID string                     `json:"external_id"`
+	Name       string                     `json:"name"`
+	Insights   *AdAccountInsightsResponse `json:"insights"`
+	Ranking    *StoreRankingItem          `json:"ranking,omitempty"` // Ausente se a loja não estiver no ranking do mês
+	Goals      *GoalAttainment            `json:"goals,omitempty"`   // Presente quando o período está contido em um mês com metas
+	LastSync   *DashboardSyncStatus       `json:"last_sync"`
+	Format     *FormatMetadata            `json:"format,omitempty"`
+}
+
+// DashboardSyncStatus indica quando os insights da conta foram gravados pela última vez pelas sincronizações
+type DashboardSyncStatus struct {
+	AdInsightsAt    *time.Time `json:"ad_insights_at,omitempty"`
+	SalesInsightsAt *time.Time `json:"sales_insights_at,omitempty"`
+}
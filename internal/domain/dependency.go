@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// DependencyStatus é o resultado da última verificação ativa de uma dependência externa
+type DependencyStatus struct {
+	Name string `json:"name"`
+	// Critical indica que a aplicação não deve receber tráfego com a dependência indisponível
+	Critical      bool       `json:"critical"`
+	Healthy       bool       `json:"healthy"`
+	LatencyMs     int64      `json:"latency_ms"`
+	Error         string     `json:"error,omitempty"`
+	LastCheckAt   *time.Time `json:"last_check_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+}
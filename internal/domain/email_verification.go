@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// EmailVerificationToken é o token de uso único enviado por e-mail para confirmar o endereço do usuário.
+// Só o hash SHA-256 é armazenado.
+type EmailVerificationToken struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Usable indica se o token ainda pode confirmar o e-mail
+func (t *EmailVerificationToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
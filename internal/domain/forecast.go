@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// Métodos de projeção do fechamento do mês
+const (
+	ForecastMethodLinear        = "linear"         // Tendência linear (mínimos quadrados) dos dias do mês
+	ForecastMethodMovingAverage = "moving_average" // Média dos últimos ForecastMovingAverageDays dias
+)
+
+// ForecastMovingAverageDays é a janela da média móvel usada na projeção
+const ForecastMovingAverageDays = 7
+
+func IsValidForecastMethod(method string) bool {
+	return method == ForecastMethodLinear || method == ForecastMethodMovingAverage
+}
+
+// MetricForecast é a projeção de fechamento do mês de uma métrica diária
+type MetricForecast struct {
+	ToDate       float64 `json:"to_date"`       // Acumulado dos dias completos do mês
+	Projected    float64 `json:"projected"`     // Acumulado projetado para o fim do mês
+	DailyAverage float64 `json:"daily_average"` // Média diária dos dias completos
+}
+
+// ForecastRanking situa a projeção de faturamento no ranking de lojas do mês
+type ForecastRanking struct {
+	Position                     int      `json:"position"`
+	Revenue                      float64  `json:"revenue"`                                   // Faturamento considerado no ranking atual
+	NextPositionRevenue          *float64 `json:"next_position_revenue,omitempty"`           // Faturamento atual da loja uma posição acima
+	NextPositionProjectedRevenue *float64 `json:"next_position_projected_revenue,omitempty"` // Faturamento da loja acima no ritmo atual até o fim do mês
+	ReachesNextPosition          bool     `json:"reaches_next_position"`                     // A projeção da conta supera a da loja acima
+}
+
+// MonthForecast projeta o fechamento do mês da conta a partir dos dias completos já sincronizados
+type MonthForecast struct {
+	AccountID                  string           `json:"account_id"`
+	Period                     string           `json:"period"` // mm-yyyy
+	Method                     string           `json:"method"`
+	DaysElapsed                int              `json:"days_elapsed"` // Dias completos considerados
+	DaysInMonth                int              `json:"days_in_month"`
+	SocialNetworkRevenue       *MetricForecast  `json:"social_network_revenue"`
+	Spend                      *MetricForecast  `json:"spend"` // Na moeda da conta
+	RevenueTarget              *float64         `json:"revenue_target,omitempty"`
+	ProjectedRevenueAttainment *float64         `json:"projected_revenue_attainment,omitempty"` // Percentual da meta de faturamento projetado
+	Ranking                    *ForecastRanking `json:"ranking,omitempty"`
+	Format                     *FormatMetadata  `json:"format,omitempty"`
+}
+
+// ForecastMetric projeta o acumulado do mês a partir dos valores diários dos dias completos (daily[0] é o
+// dia 1). Os dias restantes seguem a tendência linear ou a média móvel, nunca abaixo de zero.
+func ForecastMetric(daily []float64, daysInMonth int, method string) *MetricForecast {
+	forecast := &MetricForecast{}
+	if len(daily) == 0 {
+		return forecast
+	}
+
+	var toDate float64
+	for _, v := range daily {
+		toDate += v
+	}
+	average := toDate / float64(len(daily))
+
+	remaining := 0.0
+	switch method {
+	case ForecastMethodMovingAverage:
+		window := daily
+		if len(window) > ForecastMovingAverageDays {
+			window = window[len(window)-ForecastMovingAverageDays:]
+		}
+		var sum float64
+		for _, v := range window {
+			sum += v
+		}
+		remaining = sum / float64(len(window)) * float64(daysInMonth-len(daily))
+	default:
+		intercept, slope := linearTrend(daily)
+		for day := len(daily) + 1; day <= daysInMonth; day++ {
+			if projected := intercept + slope*float64(day); projected > 0 {
+				remaining += projected
+			}
+		}
+	}
+
+	forecast.ToDate = utils.RoundWithTwoDecimalPlace(toDate)
+	forecast.Projected = utils.RoundWithTwoDecimalPlace(toDate + remaining)
+	forecast.DailyAverage = utils.RoundWithTwoDecimalPlace(average)
+	return forecast
+}
+
+// linearTrend ajusta y = intercept + slope*x por mínimos quadrados, com x = 1 para o primeiro dia.
+// Com um único ponto a tendência é constante.
+func linearTrend(values []float64) (float64, float64) {
+	n := float64(len(values))
+	if len(values) < 2 {
+		return values[0], 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i + 1)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	intercept := (sumY - slope*sumX) / n
+	return intercept, slope
+}
+
+// ForecastRankingPosition situa a conta no ranking do mês. A loja uma posição acima é projetada no ritmo
+// atual (faturamento / dias completos × dias do mês) para comparar com a projeção da conta. Retorna nil se
+// a conta não estiver classificada.
+func ForecastRankingPosition(ranking []StoreRankingItem, accountID string, projectedRevenue float64, daysElapsed, daysInMonth int) *ForecastRanking {
+	for i := range ranking {
+		if ranking[i].AccountID != accountID {
+			continue
+		}
+
+		position := &ForecastRanking{
+			Position: ranking[i].Position,
+			Revenue:  ranking[i].SocialNetworkRevenue,
+		}
+
+		for j := range ranking {
+			if ranking[j].Position != ranking[i].Position-1 {
+				continue
+			}
+			nextRevenue := ranking[j].SocialNetworkRevenue
+			position.NextPositionRevenue = &nextRevenue
+			if daysElapsed > 0 {
+				nextProjected := utils.RoundWithTwoDecimalPlace(nextRevenue / float64(daysElapsed) * float64(daysInMonth))
+				position.NextPositionProjectedRevenue = &nextProjected
+				position.ReachesNextPosition = projectedRevenue > nextProjected
+			}
+			break
+		}
+
+		return position
+	}
+
+	return nil
+}
+
+// ApplyRevenueTarget compara o faturamento projetado com a meta de faturamento do mês
+func (f *MonthForecast) ApplyRevenueTarget(target *float64) {
+	if f.SocialNetworkRevenue == nil || target == nil {
+		return
+	}
+	f.RevenueTarget = target
+	f.ProjectedRevenueAttainment = goalPercent(f.SocialNetworkRevenue.Projected, target)
+}
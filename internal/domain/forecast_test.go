@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastMetric(t *testing.T) {
+	t.Run("tendência linear crescente", func(t *testing.T) {
+		// 10, 20, 30 nos 3 primeiros dias: a tendência segue +10 por dia até o dia 5
+		forecast := ForecastMetric([]float64{10, 20, 30}, 5, ForecastMethodLinear)
+
+		assert.Equal(t, 60.0, forecast.ToDate)
+		assert.Equal(t, 150.0, forecast.Projected)
+		assert.Equal(t, 20.0, forecast.DailyAverage)
+	})
+
+	t.Run("tendência linear decrescente não projeta dias negativos", func(t *testing.T) {
+		forecast := ForecastMetric([]float64{30, 20, 10}, 6, ForecastMethodLinear)
+
+		// Dia 4 = 0, dias 5 e 6 seriam negativos
+		assert.Equal(t, 60.0, forecast.Projected)
+	})
+
+	t.Run("um único dia repete o valor", func(t *testing.T) {
+		forecast := ForecastMetric([]float64{50}, 30, ForecastMethodLinear)
+		assert.Equal(t, 1500.0, forecast.Projected)
+	})
+
+	t.Run("média móvel usa apenas os últimos dias", func(t *testing.T) {
+		daily := []float64{1000, 1000, 10, 10, 10, 10, 10, 10, 10}
+		forecast := ForecastMetric(daily, 10, ForecastMethodMovingAverage)
+
+		assert.Equal(t, 2070.0, forecast.ToDate)
+		assert.Equal(t, 2080.0, forecast.Projected)
+	})
+
+	t.Run("sem dias completos", func(t *testing.T) {
+		forecast := ForecastMetric(nil, 30, ForecastMethodLinear)
+		assert.Zero(t, forecast.Projected)
+	})
+}
+
+func TestForecastRankingPosition(t *testing.T) {
+	ranking := []StoreRankingItem{
+		{AccountID: "a", Position: 1, SocialNetworkRevenue: 1000},
+		{AccountID: "b", Position: 2, SocialNetworkRevenue: 800},
+	}
+
+	position := ForecastRankingPosition(ranking, "b", 3100, 10, 30)
+	if assert.NotNil(t, position) {
+		assert.Equal(t, 2, position.Position)
+		assert.Equal(t, 1000.0, *position.NextPositionRevenue)
+		assert.Equal(t, 3000.0, *position.NextPositionProjectedRevenue)
+		assert.True(t, position.ReachesNextPosition)
+	}
+
+	leader := ForecastRankingPosition(ranking, "a", 3000, 10, 30)
+	if assert.NotNil(t, leader) {
+		assert.Nil(t, leader.NextPositionRevenue)
+		assert.False(t, leader.ReachesNextPosition)
+	}
+
+	assert.Nil(t, ForecastRankingPosition(ranking, "c", 100, 10, 30))
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "regexp"
+
+// Formatação usada quando a conta não define moeda ou locale
+const (
+	DefaultCurrency = "BRL"
+	DefaultLocale   = "pt-BR"
+	// SalesCurrency é a moeda do faturamento das lojas (SSOtica)
+	SalesCurrency = "BRL"
+	// DefaultRateDecimals é a precisão de frequência, conversão e ROI
+	DefaultRateDecimals = 2
+)
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	localePattern   = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+	// zeroDecimalCurrencies são as moedas sem centavos (ISO 4217); as demais usam 2 casas
+	zeroDecimalCurrencies = map[string]bool{"CLP": true, "JPY": true, "KRW": true, "PYG": true}
+)
+
+// FormatMetadata informa como os frontends devem formatar os valores de uma conta
+type FormatMetadata struct {
+	Currency         string `json:"currency"`
+	Locale           string `json:"locale"`
+	CurrencyDecimals int    `json:"currency_decimals"`
+	RateDecimals     int    `json:"rate_decimals"`
+}
+
+// NewFormatMetadata monta os metadados de formatação, aplicando os padrões para valores vazios
+func NewFormatMetadata(currency, locale string) *FormatMetadata {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	currencyDecimals := 2
+	if zeroDecimalCurrencies[currency] {
+		currencyDecimals = 0
+	}
+
+	return &FormatMetadata{
+		Currency:         currency,
+		Locale:           locale,
+		CurrencyDecimals: currencyDecimals,
+		RateDecimals:     DefaultRateDecimals,
+	}
+}
+
+// IsValidCurrency valida o código de moeda no formato ISO 4217 (ex: BRL)
+func IsValidCurrency(currency string) bool {
+	return currencyPattern.MatchString(currency)
+}
+
+// IsValidLocale valida o locale no formato idioma-REGIÃO (ex: pt-BR)
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
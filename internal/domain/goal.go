@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// GoalPeriodFormat é o formato do mês das metas, o mesmo do relatório mensal (mm-yyyy)
+const GoalPeriodFormat = "01-2006"
+
+// AccountGoal são as metas mensais de uma conta. Metas não informadas ficam nulas e não são acompanhadas.
+// Todos os valores estão na moeda do faturamento (SalesCurrency); o investimento de contas cobradas em
+// outra moeda é convertido antes da comparação, como nas métricas de resultado.
+type AccountGoal struct {
+	ID            int       `json:"id"`
+	AccountID     string    `json:"account_id"`
+	Period        string    `json:"period"`                   // Mês no formato mm-yyyy
+	RevenueTarget *float64  `json:"revenue_target,omitempty"` // Faturamento em redes sociais esperado no mês
+	MaxCPA        *float64  `json:"max_cpa,omitempty"`        // Custo por resultado máximo aceito
+	SpendBudget   *float64  `json:"spend_budget,omitempty"`   // Investimento previsto para o mês
+	UserID        *int      `json:"user_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type AccountGoalRequest struct {
+	RevenueTarget *float64 `json:"revenue_target"`
+	MaxCPA        *float64 `json:"max_cpa"`
+	SpendBudget   *float64 `json:"spend_budget"`
+}
+
+// GoalAttainment compara as métricas do período com as metas do mês. Os percentuais só são
+// preenchidos para as metas definidas.
+type GoalAttainment struct {
+	Period            string        `json:"period"`
+	RevenueTarget     *float64      `json:"revenue_target,omitempty"`
+	Revenue           float64       `json:"revenue"`
+	RevenueAttainment *float64      `json:"revenue_attainment,omitempty"` // Percentual da meta de faturamento atingido
+	MaxCPA            *float64      `json:"max_cpa,omitempty"`
+	CostPerResult     float64       `json:"cost_per_result"`
+	CPAAttainment     *float64      `json:"cpa_attainment,omitempty"` // Percentual do CPA máximo consumido; acima de 100 a meta foi ultrapassada
+	SpendBudget       *float64      `json:"spend_budget,omitempty"`
+	Spend             float64       `json:"spend"`
+	SpendAttainment   *float64      `json:"spend_attainment,omitempty"` // Percentual do orçamento já investido
+	Pacing            *BudgetPacing `json:"pacing,omitempty"`           // Ritmo do investimento, quando o período vai do início do mês até hoje
+}
+
+// CalculateGoalAttainment calcula o progresso das métricas em relação às metas. O investimento é
+// convertido pela cotação exchangeRate; sem cotação (exchangeRate <= 0) só o faturamento é comparado.
+func CalculateGoalAttainment(goal *AccountGoal, adMetrics *AdAccountMetrics, salesMetrics map[string]*SalesMetrics, exchangeRate float64) *GoalAttainment {
+	if goal == nil {
+		return nil
+	}
+
+	attainment := &GoalAttainment{
+		Period:        goal.Period,
+		RevenueTarget: goal.RevenueTarget,
+		MaxCPA:        goal.MaxCPA,
+		SpendBudget:   goal.SpendBudget,
+	}
+
+	if sales := salesMetrics[SocialNetwork]; sales != nil {
+		attainment.Revenue = utils.RoundWithTwoDecimalPlace(sales.TotalRevenue)
+	}
+	attainment.RevenueAttainment = goalPercent(attainment.Revenue, goal.RevenueTarget)
+
+	if adMetrics == nil || exchangeRate <= 0 {
+		return attainment
+	}
+
+	spend := adMetrics.Spend * exchangeRate
+	attainment.Spend = utils.RoundWithTwoDecimalPlace(spend)
+	attainment.SpendAttainment = goalPercent(spend, goal.SpendBudget)
+
+	if adMetrics.Result > 0 {
+		attainment.CostPerResult = utils.RoundWithTwoDecimalPlace(spend / float64(adMetrics.Result))
+		attainment.CPAAttainment = goalPercent(spend/float64(adMetrics.Result), goal.MaxCPA)
+	}
+
+	return attainment
+}
+
+// goalPercent retorna value como percentual de target, ou nil se a meta não foi definida
+func goalPercent(value float64, target *float64) *float64 {
+	if target == nil || *target <= 0 {
+		return nil
+	}
+	percent := utils.RoundWithTwoDecimalPlace(value / *target * 100)
+	return &percent
+}
+
+// GoalPeriodForRange retorna o mês (mm-yyyy) das metas aplicáveis ao intervalo. Metas são mensais, então
+// só há comparação quando o intervalo está contido em um único mês.
+func GoalPeriodForRange(startDate, endDate time.Time) (string, bool) {
+	if startDate.Year() != endDate.Year() || startDate.Month() != endDate.Month() {
+		return "", false
+	}
+	return startDate.Format(GoalPeriodFormat), true
+}
+
+// Situação do ritmo de investimento em relação ao orçamento do mês
+const (
+	PaceOnTrack = "on_pace"
+	PaceOver    = "over_pace"
+	PaceUnder   = "under_pace"
+)
+
+// DefaultPacingTolerance é a variação aceita entre o investimento e o esperado para o dia antes de o
+// ritmo ser considerado acima ou abaixo do previsto
+const DefaultPacingTolerance = 0.1
+
+// BudgetPacing compara o investimento do mês até hoje com o esperado para o dia do mês, supondo
+// investimento uniforme ao longo do mês
+type BudgetPacing struct {
+	Period         string  `json:"period"`
+	SpendBudget    float64 `json:"spend_budget"`
+	SpendToDate    float64 `json:"spend_to_date"`
+	ExpectedSpend  float64 `json:"expected_spend"`  // Orçamento proporcional aos dias decorridos, incluindo hoje
+	ProjectedSpend float64 `json:"projected_spend"` // Investimento no fim do mês mantido o ritmo atual
+	PacePercent    float64 `json:"pace_percent"`    // Investimento até hoje como percentual do esperado
+	DaysElapsed    int     `json:"days_elapsed"`
+	DaysInMonth    int     `json:"days_in_month"`
+	Status         string  `json:"status"`      // on_pace, over_pace ou under_pace
+	OverBudget     bool    `json:"over_budget"` // O investimento até hoje já ultrapassou o orçamento do mês
+}
+
+// CalculateBudgetPacing calcula o ritmo do investimento no mês de today. tolerance é a variação aceita
+// em relação ao esperado (0.1 = 10%).
+func CalculateBudgetPacing(budget, spendToDate float64, today time.Time, tolerance float64) *BudgetPacing {
+	daysInMonth := time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()).Day()
+	daysElapsed := today.Day()
+
+	expected := budget * float64(daysElapsed) / float64(daysInMonth)
+
+	pacing := &BudgetPacing{
+		Period:         today.Format(GoalPeriodFormat),
+		SpendBudget:    utils.RoundWithTwoDecimalPlace(budget),
+		SpendToDate:    utils.RoundWithTwoDecimalPlace(spendToDate),
+		ExpectedSpend:  utils.RoundWithTwoDecimalPlace(expected),
+		ProjectedSpend: utils.RoundWithTwoDecimalPlace(spendToDate / float64(daysElapsed) * float64(daysInMonth)),
+		DaysElapsed:    daysElapsed,
+		DaysInMonth:    daysInMonth,
+		Status:         PaceOnTrack,
+		OverBudget:     spendToDate > budget,
+	}
+	if expected > 0 {
+		pacing.PacePercent = utils.RoundWithTwoDecimalPlace(spendToDate / expected * 100)
+	}
+
+	switch {
+	case spendToDate > expected*(1+tolerance):
+		pacing.Status = PaceOver
+	case spendToDate < expected*(1-tolerance):
+		pacing.Status = PaceUnder
+	}
+
+	return pacing
+}
+
+// IsMonthToDate indica se o intervalo vai do primeiro dia do mês até today, caso em que o investimento do
+// período é o investimento do mês até hoje
+func IsMonthToDate(startDate, endDate, today time.Time) bool {
+	return startDate.Day() == 1 &&
+		startDate.Year() == today.Year() && startDate.Month() == today.Month() &&
+		endDate.Year() == today.Year() && endDate.Month() == today.Month() && endDate.Day() >= today.Day()
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateGoalAttainment(t *testing.T) {
+	revenueTarget, maxCPA, spendBudget := 3000.0, 10.0, 1000.0
+	goal := &AccountGoal{Period: "06-2025", RevenueTarget: &revenueTarget, MaxCPA: &maxCPA, SpendBudget: &spendBudget}
+
+	ad := &AdAccountMetrics{AdAccountInsight: AdAccountInsight{Spend: 400, Result: 50}}
+	sales := map[string]*SalesMetrics{SocialNetwork: {TotalRevenue: 1500}}
+
+	attainment := CalculateGoalAttainment(goal, ad, sales, 1)
+	assert.Equal(t, 50.0, *attainment.RevenueAttainment)
+	assert.Equal(t, 8.0, attainment.CostPerResult)
+	assert.Equal(t, 80.0, *attainment.CPAAttainment)
+	assert.Equal(t, 40.0, *attainment.SpendAttainment)
+
+	t.Run("metas não definidas ficam sem percentual", func(t *testing.T) {
+		attainment := CalculateGoalAttainment(&AccountGoal{Period: "06-2025", RevenueTarget: &revenueTarget}, ad, sales, 1)
+		assert.Equal(t, 50.0, *attainment.RevenueAttainment)
+		assert.Nil(t, attainment.CPAAttainment)
+		assert.Nil(t, attainment.SpendAttainment)
+		assert.Equal(t, 400.0, attainment.Spend)
+	})
+
+	t.Run("investimento em outra moeda é convertido", func(t *testing.T) {
+		attainment := CalculateGoalAttainment(goal, ad, sales, 2)
+		assert.Equal(t, 80.0, *attainment.SpendAttainment)
+		assert.Equal(t, 160.0, *attainment.CPAAttainment)
+	})
+
+	t.Run("sem cotação só o faturamento é comparado", func(t *testing.T) {
+		attainment := CalculateGoalAttainment(goal, ad, sales, 0)
+		assert.Equal(t, 50.0, *attainment.RevenueAttainment)
+		assert.Nil(t, attainment.SpendAttainment)
+		assert.Nil(t, attainment.CPAAttainment)
+	})
+
+	t.Run("sem metas não há progresso", func(t *testing.T) {
+		assert.Nil(t, CalculateGoalAttainment(nil, ad, sales, 1))
+	})
+}
+
+func TestGoalPeriodForRange(t *testing.T) {
+	period, ok := GoalPeriodForRange(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "06-2025", period)
+
+	_, ok = GoalPeriodForRange(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestCalculateBudgetPacing(t *testing.T) {
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	pacing := CalculateBudgetPacing(3000, 1000, today, 0.1)
+	assert.Equal(t, 1000.0, pacing.ExpectedSpend)
+	assert.Equal(t, 3000.0, pacing.ProjectedSpend)
+	assert.Equal(t, 100.0, pacing.PacePercent)
+	assert.Equal(t, PaceOnTrack, pacing.Status)
+	assert.Equal(t, 30, pacing.DaysInMonth)
+	assert.False(t, pacing.OverBudget)
+
+	assert.Equal(t, PaceOver, CalculateBudgetPacing(3000, 1200, today, 0.1).Status)
+	assert.Equal(t, PaceUnder, CalculateBudgetPacing(3000, 800, today, 0.1).Status)
+	assert.True(t, CalculateBudgetPacing(900, 1000, today, 0.1).OverBudget)
+}
+
+func TestIsMonthToDate(t *testing.T) {
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, IsMonthToDate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), today, today))
+	assert.False(t, IsMonthToDate(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), today, today))
+	assert.False(t, IsMonthToDate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), today))
+}
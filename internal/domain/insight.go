@@ -8,13 +8,21 @@ import (
 )
 
 type InsigthFilters struct {
-	StartDate *time.Time
-	EndDate   *time.Time
+	StartDate   *time.Time
+	EndDate     *time.Time
+	Aggregation string `json:",omitempty"` // daily, weekly, monthly ou quarterly; vazio retorna apenas os totais
 }
 
+// DefaultGrossMargin é a margem bruta considerada no ROAS de equilíbrio quando GROSS_MARGIN não é
+// configurada: com 50% de margem, cada real investido precisa voltar em dois reais de faturamento
+const DefaultGrossMargin = 0.5
+
 type ResultMetrics struct {
-	Conversion float64
-	ROI        string
+	Conversion    float64
+	ROI           string
+	ROAS          float64 // Faturamento em redes sociais dividido pelo investimento
+	BreakEvenROAS float64 // ROAS mínimo para cobrir o investimento com a margem bruta
+	ExchangeRate  float64 `json:",omitempty"` // Cotação aplicada ao investimento quando a conta é cobrada em outra moeda que não a do faturamento
 }
 
 type AdAccountInsightsResponse struct {
@@ -22,11 +30,22 @@ type AdAccountInsightsResponse struct {
 	SalesMetrics     map[string]*SalesMetrics
 	ResultMetrics    *ResultMetrics
 	Filters          *InsigthFilters
+	Annotations      []*Annotation
+	Goals            *GoalAttainment `json:",omitempty"` // presente quando o período está contido em um mês com metas definidas
+	Format           *FormatMetadata
+	Series           []*InsightSeriesPoint `json:",omitempty"` // preenchida a partir dos insights diários armazenados quando Filters.Aggregation é informado
 }
 
-// CalculateResultMetrics calcula métricas de resultado combinando dados de anúncios e vendas
+// CalculateResultMetrics calcula métricas de resultado combinando dados de anúncios e vendas na mesma moeda
 func CalculateResultMetrics(adMetrics *AdAccountMetrics, salesMetrics map[string]*SalesMetrics) *ResultMetrics {
-	if adMetrics == nil || salesMetrics == nil || salesMetrics[SocialNetwork] == nil {
+	return CalculateResultMetricsWithRate(adMetrics, salesMetrics, 1, DefaultGrossMargin)
+}
+
+// CalculateResultMetricsWithRate calcula as métricas de resultado convertendo o investimento para a moeda
+// do faturamento pela cotação exchangeRate. Sem cotação (exchangeRate <= 0) as métricas não são calculadas.
+// O ROAS de equilíbrio usa grossMargin; valores fora de (0, 1] usam DefaultGrossMargin.
+func CalculateResultMetricsWithRate(adMetrics *AdAccountMetrics, salesMetrics map[string]*SalesMetrics, exchangeRate, grossMargin float64) *ResultMetrics {
+	if adMetrics == nil || salesMetrics == nil || salesMetrics[SocialNetwork] == nil || exchangeRate <= 0 {
 		return nil
 	}
 
@@ -36,16 +55,30 @@ func CalculateResultMetrics(adMetrics *AdAccountMetrics, salesMetrics map[string
 		conversion = (float64(salesMetrics[SocialNetwork].SalesQuantity) / float64(adMetrics.Result)) * 100
 	}
 
-	// Calcular ROI (retorno sobre investimento)
+	// Calcular ROI e ROAS (faturamento em redes sociais sobre o investimento)
 	roi := 0.0
-	if adMetrics.Spend > 0 {
-		roi = salesMetrics[SocialNetwork].TotalRevenue / adMetrics.Spend
+	if spend := adMetrics.Spend * exchangeRate; spend > 0 {
+		roi = salesMetrics[SocialNetwork].TotalRevenue / spend
 	}
 
-	return &ResultMetrics{
-		Conversion: utils.RoundWithTwoDecimalPlace(conversion),
-		ROI:        fmt.Sprintf("%dx", int(roi)),
+	result := &ResultMetrics{
+		Conversion:    utils.RoundWithTwoDecimalPlace(conversion),
+		ROI:           fmt.Sprintf("%dx", int(roi)),
+		ROAS:          utils.RoundWithTwoDecimalPlace(roi),
+		BreakEvenROAS: BreakEvenROAS(grossMargin),
+	}
+	if exchangeRate != 1 {
+		result.ExchangeRate = exchangeRate
+	}
+	return result
+}
+
+// BreakEvenROAS é o ROAS mínimo para que a margem bruta das vendas cubra o investimento
+func BreakEvenROAS(grossMargin float64) float64 {
+	if grossMargin <= 0 || grossMargin > 1 {
+		grossMargin = DefaultGrossMargin
 	}
+	return utils.RoundWithTwoDecimalPlace(1 / grossMargin)
 }
 
 // CombineInsights combina insights de anúncios e vendas em uma resposta completa
@@ -0,0 +1,77 @@
+package domain
+
+// ComparisonMetrics são as métricas comparadas entre os dois períodos. As derivadas
+// (frequency, cost_per_result, ctr, cpm, cpc, conversion e roi) vêm dos totais de cada período.
+var ComparisonMetrics = []string{
+	"spend",
+	"impressions",
+	"reach",
+	"frequency",
+	"result",
+	"cost_per_result",
+	"clicks",
+	"link_clicks",
+	"ctr",
+	"cpm",
+	"cpc",
+	"social_network_revenue",
+	"social_network_sales",
+	"store_revenue",
+	"store_sales",
+	"conversion",
+	"roi",
+}
+
+// InsightsComparisonResponse traz os insights do período consultado e do período de comparação,
+// com a variação percentual de cada métrica. A variação é nula quando o período anterior é zero.
+type InsightsComparisonResponse struct {
+	Comparison     string                     `json:"comparison"`
+	CurrentPeriod  ReportPeriod               `json:"current_period"`
+	PreviousPeriod ReportPeriod               `json:"previous_period"`
+	Current        *AdAccountInsightsResponse `json:"current"`
+	Previous       *AdAccountInsightsResponse `json:"previous"`
+	Metrics        map[string]float64         `json:"metrics"`
+	PreviousValues map[string]float64         `json:"previous_metrics"`
+	Deltas         map[string]*float64        `json:"deltas"`
+}
+
+// MetricValues extrai as métricas comparáveis da resposta de insights
+func (r *AdAccountInsightsResponse) MetricValues() map[string]float64 {
+	values := make(map[string]float64, len(ComparisonMetrics))
+	for _, key := range ComparisonMetrics {
+		values[key] = 0
+	}
+	if r == nil {
+		return values
+	}
+
+	if ad := r.AdAccountMetrics; ad != nil {
+		values["spend"] = ad.Spend
+		values["impressions"] = float64(ad.Impressions)
+		values["reach"] = float64(ad.Reach)
+		values["result"] = float64(ad.Result)
+		values["frequency"] = ad.Frequency
+		values["cost_per_result"] = ad.CostPerResult
+		values["clicks"] = float64(ad.Clicks)
+		values["link_clicks"] = float64(ad.LinkClicks)
+		values["ctr"] = ad.CTR
+		values["cpm"] = ad.CPM
+		values["cpc"] = ad.CPC
+	}
+	if sales := r.SalesMetrics[SocialNetwork]; sales != nil {
+		values["social_network_revenue"] = sales.TotalRevenue
+		values["social_network_sales"] = float64(sales.SalesQuantity)
+		if values["result"] > 0 {
+			values["conversion"] = values["social_network_sales"] / values["result"] * 100
+		}
+		if values["spend"] > 0 {
+			values["roi"] = sales.TotalRevenue / values["spend"]
+		}
+	}
+	if sales := r.SalesMetrics[Store]; sales != nil {
+		values["store_revenue"] = sales.TotalRevenue
+		values["store_sales"] = float64(sales.SalesQuantity)
+	}
+
+	return values
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateResultMetrics(t *testing.T) {
+	ad := &AdAccountMetrics{AdAccountInsight: AdAccountInsight{Spend: 400, Result: 50}}
+	sales := map[string]*SalesMetrics{SocialNetwork: {TotalRevenue: 1500, SalesQuantity: 10}}
+
+	result := CalculateResultMetrics(ad, sales)
+	assert.Equal(t, 20.0, result.Conversion)
+	assert.Equal(t, "3x", result.ROI)
+	assert.Equal(t, 3.75, result.ROAS)
+	assert.Equal(t, 2.0, result.BreakEvenROAS)
+
+	t.Run("sem investimento o ROAS fica zerado", func(t *testing.T) {
+		result := CalculateResultMetrics(&AdAccountMetrics{}, sales)
+		assert.Equal(t, 0.0, result.ROAS)
+		assert.Equal(t, 2.0, result.BreakEvenROAS)
+	})
+
+	t.Run("sem vendas em redes sociais não há métricas de resultado", func(t *testing.T) {
+		assert.Nil(t, CalculateResultMetrics(ad, map[string]*SalesMetrics{Store: {TotalRevenue: 100}}))
+	})
+
+	t.Run("investimento em outra moeda é convertido antes do ROAS", func(t *testing.T) {
+		usd := &AdAccountMetrics{AdAccountInsight: AdAccountInsight{Spend: 100, Result: 50}}
+		result := CalculateResultMetricsWithRate(usd, sales, 5, DefaultGrossMargin)
+		assert.Equal(t, 3.0, result.ROAS)
+		assert.Equal(t, "3x", result.ROI)
+		assert.Equal(t, 5.0, result.ExchangeRate)
+	})
+
+	t.Run("ROAS de equilíbrio pela margem bruta configurada", func(t *testing.T) {
+		result := CalculateResultMetricsWithRate(ad, sales, 1, 0.4)
+		assert.Equal(t, 2.5, result.BreakEvenROAS)
+		assert.Equal(t, 3.75, result.ROAS)
+	})
+
+	t.Run("sem cotação não há métricas de resultado", func(t *testing.T) {
+		assert.Nil(t, CalculateResultMetricsWithRate(ad, sales, 0, DefaultGrossMargin))
+	})
+}
+
+func TestBreakEvenROAS(t *testing.T) {
+	tests := []struct {
+		grossMargin float64
+		expected    float64
+	}{
+		{grossMargin: 0.5, expected: 2},
+		{grossMargin: 0.3, expected: 3.33},
+		{grossMargin: 1, expected: 1},
+		// Margens fora de (0, 1] usam a margem padrão
+		{grossMargin: 0, expected: 2},
+		{grossMargin: -0.2, expected: 2},
+		{grossMargin: 30, expected: 2},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, BreakEvenROAS(tt.grossMargin), tt.grossMargin)
+	}
+}
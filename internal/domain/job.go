@@ -0,0 +1,117 @@
+package domain
+
+import "time"
+
+// Status das execuções dos agendadores registradas na tabela jobs
+const (
+	JobStatusRunning     = "running"
+	JobStatusSucceeded   = "succeeded"
+	JobStatusFailed      = "failed"
+	JobStatusInterrupted = "interrupted"
+)
+
+// Origens de uma execução
+const (
+	JobTriggerCron    = "cron"
+	JobTriggerManual  = "manual"
+	JobTriggerCatchUp = "catch_up"
+)
+
+// Job é uma execução de um agendador. Type é o nome do agendador (SchedulerMetaInsightSync, ...).
+type Job struct {
+	ID         int64                     `json:"id"`
+	Type       string                    `json:"type"`
+	Trigger    string                    `json:"trigger"`
+	Params     map[string]any            `json:"params,omitempty"`
+	Status     string                    `json:"status"`
+	StartedAt  time.Time                 `json:"started_at"`
+	FinishedAt *time.Time                `json:"finished_at,omitempty"`
+	Error      string                    `json:"error,omitempty"`
+	Stats      map[string]*ProviderStats `json:"stats,omitempty"`
+	Accounts   *JobAccountSummary        `json:"accounts,omitempty"`
+}
+
+// Resultados de uma conta em uma execução
+const (
+	JobAccountSucceeded   = "succeeded"
+	JobAccountFailed      = "failed"
+	JobAccountInterrupted = "interrupted"
+	JobAccountSkipped     = "skipped"
+)
+
+const (
+	DefaultJobListLimit = 50
+	MaxJobListLimit     = 200
+)
+
+// JobAccount é o resultado de uma conta em uma execução. Items conta os dias (ou meses, na sincronização
+// mensal) processados e FailedItems os que falharam; Reason traz o motivo da falha ou de a conta ter sido
+// ignorada.
+type JobAccount struct {
+	AccountID   string `json:"account_id"`
+	AccountName string `json:"account_name,omitempty"`
+	Status      string `json:"status"`
+	Items       int    `json:"items"`
+	FailedItems int    `json:"failed_items"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// JobAccountSummary conta as contas de uma execução por resultado
+type JobAccountSummary struct {
+	Total       int `json:"total"`
+	Succeeded   int `json:"succeeded"`
+	Failed      int `json:"failed"`
+	Interrupted int `json:"interrupted"`
+	Skipped     int `json:"skipped"`
+}
+
+// Add contabiliza o resultado de uma conta
+func (s *JobAccountSummary) Add(status string) {
+	s.Total++
+	switch status {
+	case JobAccountSucceeded:
+		s.Succeeded++
+	case JobAccountFailed:
+		s.Failed++
+	case JobAccountInterrupted:
+		s.Interrupted++
+	case JobAccountSkipped:
+		s.Skipped++
+	}
+}
+
+// JobProgress é o andamento da execução em curso. Processed conta as contas com resultado e
+// CurrentAccounts as que estão em processamento; ETA é estimado pelo tempo médio das contas concluídas
+// e fica vazio até a primeira delas.
+type JobProgress struct {
+	Total            int        `json:"total"`
+	Processed        int        `json:"processed"`
+	Percent          float64    `json:"percent"`
+	CurrentAccounts  []string   `json:"current_accounts"`
+	ETA              *time.Time `json:"eta,omitempty"`
+	RemainingSeconds int64      `json:"remaining_seconds,omitempty"`
+}
+
+// JobDetail é uma execução com o resultado de cada conta
+type JobDetail struct {
+	*Job
+	AccountResults []*JobAccount `json:"account_results"`
+}
+
+// JobFilters filtra e pagina as execuções, da mais recente para a mais antiga
+type JobFilters struct {
+	Type   string
+	Status string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// JobPage é uma página das execuções; Total conta todas as que atendem aos filtros
+type JobPage struct {
+	Jobs   []*Job `json:"jobs"`
+	Total  int    `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
@@ -0,0 +1,102 @@
+package domain
+
+import "sort"
+
+// ObjectiveStage é a etapa do funil de um objetivo de campanha da Meta
+type ObjectiveStage string
+
+const (
+	ObjectiveStageAwareness     ObjectiveStage = "awareness"
+	ObjectiveStageConsideration ObjectiveStage = "consideration"
+	ObjectiveStageConversion    ObjectiveStage = "conversion"
+)
+
+var objectiveStageLabels = map[ObjectiveStage]string{
+	ObjectiveStageAwareness:     "Reconhecimento",
+	ObjectiveStageConsideration: "Consideração",
+	ObjectiveStageConversion:    "Conversão",
+}
+
+// ObjectiveInfo traduz o objetivo retornado pela Meta (ex: OUTCOME_LEADS) para exibição
+type ObjectiveInfo struct {
+	Code        string         `json:"code"`
+	Label       string         `json:"label"`
+	Description string         `json:"description,omitempty"`
+	Stage       ObjectiveStage `json:"stage,omitempty"`
+	StageLabel  string         `json:"stage_label,omitempty"`
+}
+
+// objectives cobre os objetivos atuais (OUTCOME_*) e os legados, ainda retornados por campanhas antigas
+var objectives = map[string]ObjectiveInfo{
+	"OUTCOME_AWARENESS":     {Label: "Reconhecimento", Description: "Alcançar o maior número de pessoas e aumentar a lembrança da marca", Stage: ObjectiveStageAwareness},
+	"OUTCOME_TRAFFIC":       {Label: "Tráfego", Description: "Levar pessoas para o site, aplicativo ou loja", Stage: ObjectiveStageConsideration},
+	"OUTCOME_ENGAGEMENT":    {Label: "Engajamento", Description: "Gerar mensagens, interações com publicações e visualizações de vídeo", Stage: ObjectiveStageConsideration},
+	"OUTCOME_LEADS":         {Label: "Cadastros", Description: "Coletar contatos de clientes interessados", Stage: ObjectiveStageConversion},
+	"OUTCOME_APP_PROMOTION": {Label: "Promoção do app", Description: "Gerar instalações e ações no aplicativo", Stage: ObjectiveStageConversion},
+	"OUTCOME_SALES":         {Label: "Vendas", Description: "Encontrar pessoas com maior chance de comprar", Stage: ObjectiveStageConversion},
+	"BRAND_AWARENESS":       {Label: "Reconhecimento da marca", Description: "Aumentar a lembrança da marca", Stage: ObjectiveStageAwareness},
+	"REACH":                 {Label: "Alcance", Description: "Exibir os anúncios para o maior número de pessoas", Stage: ObjectiveStageAwareness},
+	"LINK_CLICKS":           {Label: "Cliques no link", Description: "Levar pessoas para um destino fora da Meta", Stage: ObjectiveStageConsideration},
+	"POST_ENGAGEMENT":       {Label: "Engajamento com a publicação", Description: "Gerar curtidas, comentários e compartilhamentos", Stage: ObjectiveStageConsideration},
+	"PAGE_LIKES":            {Label: "Curtidas na página", Description: "Aumentar os seguidores da página", Stage: ObjectiveStageConsideration},
+	"EVENT_RESPONSES":       {Label: "Respostas a eventos", Description: "Gerar confirmações de presença em eventos", Stage: ObjectiveStageConsideration},
+	"VIDEO_VIEWS":           {Label: "Visualizações de vídeo", Description: "Exibir vídeos para quem tem mais chance de assistir", Stage: ObjectiveStageConsideration},
+	"MESSAGES":              {Label: "Mensagens", Description: "Iniciar conversas pelo Messenger, Instagram ou WhatsApp", Stage: ObjectiveStageConsideration},
+	"LEAD_GENERATION":       {Label: "Geração de cadastros", Description: "Coletar contatos por formulários instantâneos", Stage: ObjectiveStageConversion},
+	"APP_INSTALLS":          {Label: "Instalações do app", Description: "Levar pessoas para a loja de aplicativos", Stage: ObjectiveStageConversion},
+	"CONVERSIONS":           {Label: "Conversões", Description: "Gerar ações valiosas no site ou aplicativo", Stage: ObjectiveStageConversion},
+	"PRODUCT_CATALOG_SALES": {Label: "Vendas do catálogo", Description: "Exibir produtos do catálogo para gerar vendas", Stage: ObjectiveStageConversion},
+	"STORE_TRAFFIC":         {Label: "Visitas à loja", Description: "Levar pessoas para as lojas físicas", Stage: ObjectiveStageConversion},
+}
+
+// TranslateObjective retorna o rótulo em pt-BR do objetivo. Objetivos desconhecidos mantêm o código
+// como rótulo e ficam sem etapa do funil; objetivo vazio retorna nil.
+func TranslateObjective(code string) *ObjectiveInfo {
+	if code == "" {
+		return nil
+	}
+
+	info, ok := objectives[code]
+	if !ok {
+		return &ObjectiveInfo{Code: code, Label: code}
+	}
+
+	info.Code = code
+	info.StageLabel = objectiveStageLabels[info.Stage]
+	return &info
+}
+
+// ListObjectives retorna todos os objetivos conhecidos, agrupados pela etapa do funil
+func ListObjectives() []*ObjectiveInfo {
+	stageOrder := map[ObjectiveStage]int{
+		ObjectiveStageAwareness:     0,
+		ObjectiveStageConsideration: 1,
+		ObjectiveStageConversion:    2,
+	}
+
+	list := make([]*ObjectiveInfo, 0, len(objectives))
+	for code := range objectives {
+		list = append(list, TranslateObjective(code))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Stage != list[j].Stage {
+			return stageOrder[list[i].Stage] < stageOrder[list[j].Stage]
+		}
+		return list[i].Code < list[j].Code
+	})
+	return list
+}
+
+// TranslateObjectives preenche a tradução do objetivo da conta e de cada campanha
+func (a *AdAccountInsight) TranslateObjectives() {
+	if a == nil {
+		return
+	}
+
+	a.ObjectiveInfo = TranslateObjective(a.Objective)
+	for _, campaign := range a.Campaigns {
+		if campaign != nil {
+			campaign.ObjectiveInfo = TranslateObjective(campaign.Objective)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateObjective(t *testing.T) {
+	leads := TranslateObjective("OUTCOME_LEADS")
+	assert.Equal(t, "OUTCOME_LEADS", leads.Code)
+	assert.Equal(t, "Cadastros", leads.Label)
+	assert.Equal(t, ObjectiveStageConversion, leads.Stage)
+	assert.Equal(t, "Conversão", leads.StageLabel)
+
+	// Objetivos desconhecidos mantêm o código como rótulo
+	unknown := TranslateObjective("OUTCOME_NOVO")
+	assert.Equal(t, "OUTCOME_NOVO", unknown.Label)
+	assert.Empty(t, unknown.Stage)
+
+	assert.Nil(t, TranslateObjective(""))
+
+	list := ListObjectives()
+	assert.Equal(t, ObjectiveStageAwareness, list[0].Stage)
+	assert.Equal(t, ObjectiveStageConversion, list[len(list)-1].Stage)
+}
+
+func TestAdAccountInsight_TranslateObjectives(t *testing.T) {
+	insight := &AdAccountInsight{
+		Objective: "OUTCOME_ENGAGEMENT",
+		Campaigns: []*CampaignInsight{{Objective: "OUTCOME_SALES"}, {Objective: ""}},
+	}
+
+	insight.TranslateObjectives()
+
+	assert.Equal(t, "Engajamento", insight.ObjectiveInfo.Label)
+	assert.Equal(t, "Vendas", insight.Campaigns[0].ObjectiveInfo.Label)
+	assert.Nil(t, insight.Campaigns[1].ObjectiveInfo)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// PDFReportStatus é a situação de uma geração de relatório em PDF
+type PDFReportStatus string
+
+const (
+	PDFReportStatusPending PDFReportStatus = "pending"
+	PDFReportStatusRunning PDFReportStatus = "running"
+	PDFReportStatusDone    PDFReportStatus = "done"
+	PDFReportStatusFailed  PDFReportStatus = "failed"
+)
+
+// PDFReportRequest pede o relatório mensal em PDF do período (mm-yyyy). Sem AccountID o relatório
+// consolida todas as contas ativas com dados no período.
+type PDFReportRequest struct {
+	Period    string `json:"period"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// PDFReportJob acompanha a geração assíncrona de um relatório em PDF. O arquivo fica disponível
+// para download até ExpiresAt.
+type PDFReportJob struct {
+	ID          string          `json:"id"`
+	Period      string          `json:"period"`
+	AccountID   string          `json:"account_id,omitempty"`
+	Status      PDFReportStatus `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	Filename    string          `json:"filename,omitempty"`
+	Size        int             `json:"size,omitempty"`
+	RequestedBy int             `json:"requested_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+// Permission é uma ação liberada a um perfil (role). As permissões de cada perfil ficam na tabela
+// role_permissions, o que permite criar perfis novos sem alterar o código.
+type Permission string
+
+const (
+	// PermissionInsightsRead libera a leitura de insights, dashboards, metas e relatórios salvos das contas
+	// vinculadas (ou de todas, com PermissionAccountsAll)
+	PermissionInsightsRead Permission = "insights:read"
+	// PermissionAccountsWrite libera a edição das contas: apelido, anotações, metas e agendas de sincronização.
+	// O nível de acesso do vínculo com a conta continua valendo.
+	PermissionAccountsWrite Permission = "accounts:write"
+	// PermissionAccountsAll dá acesso a todas as contas, sem depender de vínculo
+	PermissionAccountsAll Permission = "accounts:all"
+	// PermissionAccountsAdmin libera a listagem geral, a sincronização, a mesclagem e a localização das contas
+	PermissionAccountsAdmin       Permission = "accounts:admin"
+	PermissionReportsConsolidated Permission = "reports:consolidated" // Relatórios e exportações consolidados de todas as contas
+	PermissionReportsDeck         Permission = "reports:deck"         // Apresentação (PPTX) consolidada
+	PermissionSalesRead           Permission = "sales:read"           // Ranking de lojas
+	PermissionUsersManage         Permission = "users:manage"         // Usuários, perfis, senhas e vínculos com contas
+	PermissionOperationsManage    Permission = "operations:manage"    // Rotinas agendadas (execução manual, backfill, status) e dependências externas
+	PermissionWebhooksManage      Permission = "webhooks:manage"
+	PermissionPrivacyManage       Permission = "privacy:manage" // Solicitações de exclusão de dados (LGPD)
+	PermissionAuditRead           Permission = "audit:read"     // Log de auditoria das operações sensíveis
+)
+
+// Permissions são todas as permissões reconhecidas pela API
+var Permissions = []Permission{
+	PermissionInsightsRead,
+	PermissionAccountsWrite,
+	PermissionAccountsAll,
+	PermissionAccountsAdmin,
+	PermissionReportsConsolidated,
+	PermissionReportsDeck,
+	PermissionSalesRead,
+	PermissionUsersManage,
+	PermissionOperationsManage,
+	PermissionWebhooksManage,
+	PermissionPrivacyManage,
+	PermissionAuditRead,
+}
+
+func IsValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if string(p) == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet é o conjunto de permissões de um perfil
+type PermissionSet map[Permission]bool
+
+// Has indica se todas as permissões informadas estão no conjunto
+func (s PermissionSet) Has(permissions ...Permission) bool {
+	for _, p := range permissions {
+		if !s[p] {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect retorna as permissões do conjunto que também estão em permissions
+func (s PermissionSet) Intersect(permissions []Permission) PermissionSet {
+	result := PermissionSet{}
+	for _, p := range permissions {
+		if s[p] {
+			result[p] = true
+		}
+	}
+	return result
+}
+
+// List retorna as permissões do conjunto na ordem do catálogo
+func (s PermissionSet) List() []Permission {
+	list := make([]Permission, 0, len(s))
+	for _, p := range Permissions {
+		if s[p] {
+			list = append(list, p)
+		}
+	}
+	return list
+}
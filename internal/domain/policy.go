@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// PolicyAcceptance registra o aceite de uma versão dos termos de uso e da política de privacidade
+type PolicyAcceptance struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+}
+
+// PolicyStatus informa a versão vigente da política e se o usuário já a aceitou
+type PolicyStatus struct {
+	Version    string     `json:"version"`
+	URL        string     `json:"url,omitempty"`
+	Accepted   bool       `json:"accepted"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+type AcceptPolicyRequest struct {
+	Version string `json:"version"`
+}
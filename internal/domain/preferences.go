@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Períodos padrão aceitos nas preferências do dashboard
+const (
+	PeriodLast7Days  = "last_7_days"
+	PeriodLast30Days = "last_30_days"
+	PeriodLast90Days = "last_90_days"
+	PeriodThisMonth  = "this_month"
+	PeriodLastMonth  = "last_month"
+)
+
+// Formatos de exibição de valores monetários
+const (
+	CurrencyFormatFull    = "full"    // R$ 1.234,56
+	CurrencyFormatCompact = "compact" // R$ 1,2 mil
+)
+
+var PeriodPresets = []string{PeriodLast7Days, PeriodLast30Days, PeriodLast90Days, PeriodThisMonth, PeriodLastMonth}
+
+var CurrencyFormats = []string{CurrencyFormatFull, CurrencyFormatCompact}
+
+// UserPreferences guarda as preferências de exibição do usuário, compartilhadas entre dispositivos.
+// DashboardLayout é definido pelo frontend e armazenado sem interpretação.
+type UserPreferences struct {
+	DefaultPeriod    string          `json:"default_period"`
+	FavoriteAccounts []string        `json:"favorite_accounts"`
+	CurrencyFormat   string          `json:"currency_format"`
+	DashboardLayout  json.RawMessage `json:"dashboard_layout"`
+	UpdatedAt        *time.Time      `json:"updated_at,omitempty"`
+}
+
+// DefaultUserPreferences retorna as preferências de quem ainda não salvou nenhuma
+func DefaultUserPreferences() *UserPreferences {
+	return &UserPreferences{
+		DefaultPeriod:    PeriodLast30Days,
+		FavoriteAccounts: []string{},
+		CurrencyFormat:   CurrencyFormatFull,
+		DashboardLayout:  json.RawMessage("null"),
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	ErasureStatusPending   = "pending"
+	ErasureStatusCompleted = "completed"
+	ErasureStatusFailed    = "failed"
+	ErasureStatusNotFound  = "not_found" // nenhuma origem tinha dados do titular
+)
+
+// ErasureRequest é o pedido de eliminação de dados pessoais de um titular (LGPD)
+type ErasureRequest struct {
+	Email  string `json:"email,omitempty"`
+	CPF    string `json:"cpf,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ErasureRecord é o registro de um pedido atendido. Não contém o dado pessoal, apenas o hash do identificador.
+type ErasureRecord struct {
+	ID                int            `json:"id"`
+	SubjectHash       string         `json:"subject_hash"`
+	RequestedBy       *int           `json:"requested_by,omitempty"`
+	Reason            string         `json:"reason,omitempty"`
+	Status            string         `json:"status"`
+	RecordsAnonymized int            `json:"records_anonymized"`
+	Details           map[string]int `json:"details,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	CompletedAt       *time.Time     `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// ReachImpressionsOverview reúne o Reach e as Impressions de todas as contas vinculadas ao usuário
+// para a tela de visão geral. Contas cuja consulta falhou aparecem em Failures, sem derrubar as demais.
+// O Reach não é somado entre contas porque o mesmo público pode ter sido alcançado por mais de uma.
+type ReachImpressionsOverview struct {
+	Period           string                      `json:"period"`
+	StartDate        string                      `json:"start_date"`
+	EndDate          string                      `json:"end_date"`
+	Accounts         []*ReachImpressionsResponse `json:"accounts"`
+	TotalImpressions int                         `json:"total_impressions"`
+	Failures         []*ReachImpressionsFailure  `json:"failures,omitempty"`
+	GeneratedAt      time.Time                   `json:"generated_at"`
+}
+
+// ReachImpressionsFailure indica uma conta cujo Reach e Impressions não puderam ser obtidos
+type ReachImpressionsFailure struct {
+	AccountID   string `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Error       string `json:"error"`
+}
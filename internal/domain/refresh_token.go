@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// RefreshToken é um refresh token emitido no login. Só o hash SHA-256 é armazenado; o valor é
+// retornado ao cliente uma única vez. Cada uso revoga o token e emite um novo (rotação), na mesma sessão.
+type RefreshToken struct {
+	ID               int        `json:"id"`
+	UserID           int        `json:"user_id"`
+	TokenHash        string     `json:"-"`
+	SessionID        string     `json:"session_id"`
+	SessionStartedAt time.Time  `json:"session_started_at"`
+	UserAgent        string     `json:"user_agent"`
+	IPAddress        string     `json:"ip_address"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// Usable indica se o token ainda pode ser trocado por uma nova sessão
+func (t *RefreshToken) Usable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// SessionClient identifica o dispositivo que abriu ou renovou a sessão
+type SessionClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// Session é uma sessão ativa do usuário, representada pelo refresh token vigente. LastUsedAt é a última
+// renovação (ou o login).
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	StartedAt  time.Time `json:"started_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
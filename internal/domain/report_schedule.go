@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Frequências de envio dos relatórios agendados
+const (
+	ScheduleDaily   = "daily"
+	ScheduleWeekly  = "weekly"
+	ScheduleMonthly = "monthly"
+	ScheduleCron    = "cron" // Expressão cron própria do agendamento (CronSchedule)
+)
+
+// MaxReportRecipients limita os destinatários de um agendamento por e-mail
+const MaxReportRecipients = 10
+
+// Canais de entrega dos relatórios agendados
+const (
+	DeliveryEmail   = "email"
+	DeliveryWebhook = "webhook"
+)
+
+// ReportDeliveredEvent é o tipo do evento enviado ao webhook de um agendamento
+const ReportDeliveredEvent = "report.delivered"
+
+var ScheduleFrequencies = []string{ScheduleDaily, ScheduleWeekly, ScheduleMonthly, ScheduleCron}
+
+var DeliveryChannels = []string{DeliveryEmail, DeliveryWebhook}
+
+// ReportSchedule agenda o envio recorrente de um relatório salvo.
+// Weekday (0 = domingo) vale para envios semanais, DayOfMonth (1 a 28) para mensais e CronSchedule
+// (expressão cron padrão, no fuso do servidor) para a frequência cron; Hour é ignorada nesse caso.
+// Destination é a URL do webhook ou os e-mails separados por vírgula; Secret só é retornado na criação.
+type ReportSchedule struct {
+	ID           int        `json:"id"`
+	ReportID     int        `json:"report_id"`
+	UserID       int        `json:"user_id"`
+	Frequency    string     `json:"frequency"`
+	Weekday      *int       `json:"weekday,omitempty"`
+	DayOfMonth   *int       `json:"day_of_month,omitempty"`
+	CronSchedule *string    `json:"cron_schedule,omitempty"`
+	Hour         int        `json:"hour"`
+	Channel      string     `json:"channel"`
+	Destination  string     `json:"destination"`
+	Secret       string     `json:"secret,omitempty"`
+	Active       bool       `json:"active"`
+	NextRunAt    time.Time  `json:"next_run_at"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastError    *string    `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CreateReportScheduleRequest struct {
+	Frequency    string  `json:"frequency"`
+	Weekday      *int    `json:"weekday,omitempty"`
+	DayOfMonth   *int    `json:"day_of_month,omitempty"`
+	CronSchedule *string `json:"cron_schedule,omitempty"`
+	Hour         *int    `json:"hour,omitempty"`
+	Channel      string  `json:"channel"`
+	Destination  string  `json:"destination"`
+}
+
+// NextRun calcula o próximo envio estritamente depois de after, no fuso de after
+func (s *ReportSchedule) NextRun(after time.Time) (time.Time, error) {
+	if s.Hour < 0 || s.Hour > 23 {
+		return time.Time{}, fmt.Errorf("hora inválida: %d", s.Hour)
+	}
+
+	candidate := time.Date(after.Year(), after.Month(), after.Day(), s.Hour, 0, 0, 0, after.Location())
+
+	switch s.Frequency {
+	case ScheduleDaily:
+		if !candidate.After(after) {
+			candidate = candidate.AddDate(0, 0, 1)
+		}
+		return candidate, nil
+
+	case ScheduleWeekly:
+		if s.Weekday == nil || *s.Weekday < 0 || *s.Weekday > 6 {
+			return time.Time{}, fmt.Errorf("dia da semana inválido")
+		}
+		days := (*s.Weekday - int(candidate.Weekday()) + 7) % 7
+		candidate = candidate.AddDate(0, 0, days)
+		if !candidate.After(after) {
+			candidate = candidate.AddDate(0, 0, 7)
+		}
+		return candidate, nil
+
+	case ScheduleMonthly:
+		if s.DayOfMonth == nil || *s.DayOfMonth < 1 || *s.DayOfMonth > 28 {
+			return time.Time{}, fmt.Errorf("dia do mês inválido (use de 1 a 28)")
+		}
+		candidate = time.Date(after.Year(), after.Month(), *s.DayOfMonth, s.Hour, 0, 0, 0, after.Location())
+		if !candidate.After(after) {
+			candidate = candidate.AddDate(0, 1, 0)
+		}
+		return candidate, nil
+
+	case ScheduleCron:
+		if s.CronSchedule == nil {
+			return time.Time{}, fmt.Errorf("expressão cron não informada")
+		}
+		schedule, err := cron.ParseStandard(*s.CronSchedule)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("expressão cron inválida: %w", err)
+		}
+		next := schedule.Next(after)
+		if next.IsZero() {
+			return time.Time{}, fmt.Errorf("expressão cron sem próximas execuções")
+		}
+		return next, nil
+
+	default:
+		return time.Time{}, fmt.Errorf("frequência desconhecida: %s", s.Frequency)
+	}
+}
+
+// EmailRecipients retorna os destinatários de um agendamento por e-mail
+func (s *ReportSchedule) EmailRecipients() []string {
+	recipients := make([]string, 0, 1)
+	for _, address := range strings.Split(s.Destination, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			recipients = append(recipients, address)
+		}
+	}
+	return recipients
+}
+
+// CronShortestInterval estima o menor intervalo entre execuções olhando as ocorrências de uma semana
+func CronShortestInterval(schedule cron.Schedule) time.Duration {
+	start := time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+	limit := start.AddDate(0, 0, 7)
+
+	shortest := time.Duration(1<<63 - 1)
+	previous := schedule.Next(start)
+	for !previous.IsZero() && previous.Before(limit) {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if interval := next.Sub(previous); interval < shortest {
+			shortest = interval
+		}
+		previous = next
+	}
+
+	return shortest
+}
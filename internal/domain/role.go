@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Role é um perfil de acesso. As permissões vêm de role_permissions; UserCount conta os usuários não
+// excluídos com o perfil.
+type Role struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	UserCount   int          `json:"user_count"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// RoleRequest cria ou substitui um perfil, incluindo todas as suas permissões
+type RoleRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
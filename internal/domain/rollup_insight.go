@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// Granularidades consolidadas a partir dos insights mensais
+const (
+	RollupQuarter = "quarter"
+	RollupYear    = "year"
+)
+
+// RollupInsightEntry guarda as métricas de um trimestre ou ano de uma conta
+type RollupInsightEntry struct {
+	ID           int64                    `json:"id"`
+	AccountID    string                   `json:"account_id"`
+	Granularity  string                   `json:"granularity"`
+	Period       string                   `json:"period"` // Q1-2025 ou 2025
+	StartDate    time.Time                `json:"start_date"`
+	EndDate      time.Time                `json:"end_date"`
+	Months       int                      `json:"months"`
+	AdMetrics    *AdAccountMetrics        `json:"ad_metrics"`
+	SalesMetrics map[string]*SalesMetrics `json:"sales_metrics"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+// RollupInsightReport é o item retornado pelos endpoints trimestral e anual
+type RollupInsightReport struct {
+	AccountID     string                   `json:"account_id"`
+	Granularity   string                   `json:"granularity"`
+	Period        string                   `json:"period"`
+	StartDate     string                   `json:"start_date"`
+	EndDate       string                   `json:"end_date"`
+	Months        int                      `json:"months"`
+	AdMetrics     *AdAccountMetrics        `json:"ad_metrics,omitempty"`
+	SalesMetrics  map[string]*SalesMetrics `json:"sales_metrics,omitempty"`
+	ResultMetrics *ResultMetrics           `json:"result_metrics,omitempty"`
+	Format        *FormatMetadata          `json:"format,omitempty"`
+}
+
+// QuarterPeriod retorna o período (Q1-2025) e o intervalo do trimestre que contém a data
+func QuarterPeriod(date time.Time) (string, time.Time, time.Time) {
+	quarter := (int(date.Month())-1)/3 + 1
+	start := time.Date(date.Year(), time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, -1)
+	return fmt.Sprintf("Q%d-%04d", quarter, date.Year()), start, end
+}
+
+// YearPeriod retorna o período (2025) e o intervalo do ano que contém a data
+func YearPeriod(date time.Time) (string, time.Time, time.Time) {
+	start := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("%04d", date.Year()), start, end
+}
+
+// RollupAdMetrics soma as métricas mensais de anúncios e recalcula custo por resultado, frequência, CTR, CPM e CPC.
+// O alcance é a soma dos alcances mensais, portanto um limite superior do alcance único do período.
+// As campanhas não são consolidadas.
+func RollupAdMetrics(months []*AdAccountMetrics) *AdAccountMetrics {
+	var rollup *AdAccountMetrics
+	for _, month := range months {
+		if month == nil {
+			continue
+		}
+		if rollup == nil {
+			rollup = &AdAccountMetrics{
+				AdAccountInsight: AdAccountInsight{
+					AccountID: month.AccountID,
+					Name:      month.Name,
+					Objective: month.Objective,
+				},
+				CostPerResultByDate: make(map[string]float64),
+				ResultByDate:        make(map[string]int),
+			}
+		}
+
+		rollup.Impressions += month.Impressions
+		rollup.Reach += month.Reach
+		rollup.Result += month.Result
+		rollup.Clicks += month.Clicks
+		rollup.LinkClicks += month.LinkClicks
+		rollup.Spend += month.Spend
+
+		for date, value := range month.CostPerResultByDate {
+			rollup.CostPerResultByDate[date] = value
+		}
+		for date, value := range month.ResultByDate {
+			rollup.ResultByDate[date] = value
+		}
+	}
+
+	if rollup == nil {
+		return nil
+	}
+
+	rollup.Spend = utils.RoundWithTwoDecimalPlace(rollup.Spend)
+	if rollup.Result > 0 {
+		rollup.CostPerResult = utils.RoundWithTwoDecimalPlace(rollup.Spend / float64(rollup.Result))
+	}
+	if rollup.Reach > 0 {
+		rollup.Frequency = utils.RoundWithTwoDecimalPlace(float64(rollup.Impressions) / float64(rollup.Reach))
+	}
+	rollup.CalculateDeliveryRates()
+
+	return rollup
+}
+
+// RollupSalesMetrics soma faturamento e quantidade de vendas por origem e recalcula o ticket médio.
+// As vendas individuais não são mantidas no consolidado.
+func RollupSalesMetrics(months []map[string]*SalesMetrics) map[string]*SalesMetrics {
+	var rollup map[string]*SalesMetrics
+	for _, month := range months {
+		for origin, metrics := range month {
+			if metrics == nil {
+				continue
+			}
+			if rollup == nil {
+				rollup = make(map[string]*SalesMetrics)
+			}
+			if rollup[origin] == nil {
+				rollup[origin] = &SalesMetrics{}
+			}
+			rollup[origin].TotalRevenue += metrics.TotalRevenue
+			rollup[origin].SalesQuantity += metrics.SalesQuantity
+		}
+	}
+
+	for _, metrics := range rollup {
+		metrics.TotalRevenue = utils.RoundWithTwoDecimalPlace(metrics.TotalRevenue)
+		if metrics.SalesQuantity > 0 {
+			metrics.AverageTicket = utils.RoundWithTwoDecimalPlace(metrics.TotalRevenue / float64(metrics.SalesQuantity))
+		}
+	}
+
+	return rollup
+}
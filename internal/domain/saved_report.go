@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Comparações disponíveis nos relatórios salvos
+const (
+	ComparisonNone           = "none"
+	ComparisonPreviousPeriod = "previous_period"
+	ComparisonPreviousYear   = "previous_year"
+)
+
+var ReportComparisons = []string{ComparisonNone, ComparisonPreviousPeriod, ComparisonPreviousYear}
+
+// Métricas disponíveis nos relatórios salvos. As derivadas (frequency, cost_per_result,
+// conversion e roi) são recalculadas a partir dos totais ao consolidar várias contas.
+var ReportMetrics = []string{
+	"spend",
+	"impressions",
+	"reach",
+	"frequency",
+	"result",
+	"cost_per_result",
+	"social_network_revenue",
+	"social_network_sales",
+	"store_revenue",
+	"store_sales",
+	"conversion",
+	"roi",
+}
+
+// Quebras disponíveis nos relatórios salvos, executadas por conta no período do relatório
+const (
+	BreakdownAge      = "age"
+	BreakdownGender   = "gender"
+	BreakdownPlatform = "platform"
+)
+
+var ReportBreakdowns = []string{BreakdownAge, BreakdownGender, BreakdownPlatform}
+
+// SavedReport é uma definição de relatório salva pelo usuário para ser executada novamente.
+// AccountIDs são os IDs internos das contas.
+type SavedReport struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	Name         string    `json:"name"`
+	AccountIDs   []string  `json:"account_ids"`
+	PeriodPreset string    `json:"period_preset"`
+	Metrics      []string  `json:"metrics"`
+	Breakdowns   []string  `json:"breakdowns"`
+	Comparison   string    `json:"comparison"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type CreateSavedReportRequest struct {
+	Name         string   `json:"name"`
+	AccountIDs   []string `json:"account_ids"`
+	PeriodPreset string   `json:"period_preset"`
+	Metrics      []string `json:"metrics"`
+	Breakdowns   []string `json:"breakdowns"`
+	Comparison   string   `json:"comparison"`
+}
+
+type ReportPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// ReportAccountResult são as métricas de uma conta na execução do relatório
+type ReportAccountResult struct {
+	AccountID   string               `json:"account_id"`
+	AccountName string               `json:"account_name"`
+	Metrics     map[string]float64   `json:"metrics,omitempty"`
+	Comparison  map[string]float64   `json:"comparison,omitempty"`
+	Breakdowns  *AdAccountBreakdowns `json:"breakdowns,omitempty"`
+	Format      *FormatMetadata      `json:"format,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+// ReportRun é o resultado da execução de um relatório salvo
+type ReportRun struct {
+	ReportID         int                    `json:"report_id"`
+	Name             string                 `json:"name"`
+	Period           ReportPeriod           `json:"period"`
+	ComparisonPeriod *ReportPeriod          `json:"comparison_period,omitempty"`
+	Metrics          []string               `json:"metric_keys"`
+	Accounts         []*ReportAccountResult `json:"accounts"`
+	Totals           map[string]float64     `json:"totals"`
+	ComparisonTotals map[string]float64     `json:"comparison_totals,omitempty"`
+	GeneratedAt      time.Time              `json:"generated_at"`
+}
+
+// RemoveMetrics retira do resultado as métricas ocultadas para o perfil do destinatário.
+// Usado nas entregas fora da API, que não passam pelo mascaramento das respostas HTTP.
+func (r *ReportRun) RemoveMetrics(masked map[string]bool) {
+	if len(masked) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(r.Metrics))
+	for _, key := range r.Metrics {
+		if !masked[key] {
+			keys = append(keys, key)
+		}
+	}
+	r.Metrics = keys
+
+	maps := []map[string]float64{r.Totals, r.ComparisonTotals}
+	for _, account := range r.Accounts {
+		maps = append(maps, account.Metrics, account.Comparison)
+	}
+	for _, values := range maps {
+		for key := range masked {
+			delete(values, key)
+		}
+	}
+}
+
+// ResolvePeriodPreset converte um período padrão em datas de início e fim (inclusivas) relativas a now
+func ResolvePeriodPreset(preset string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch preset {
+	case PeriodLast7Days:
+		return today.AddDate(0, 0, -6), today, nil
+	case PeriodLast30Days:
+		return today.AddDate(0, 0, -29), today, nil
+	case PeriodLast90Days:
+		return today.AddDate(0, 0, -89), today, nil
+	case PeriodThisMonth:
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()), today, nil
+	case PeriodLastMonth:
+		firstOfMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("período desconhecido: %s", preset)
+	}
+}
+
+// ComparisonRange retorna o intervalo de comparação para o período informado.
+// previous_period usa o intervalo de mesmo tamanho imediatamente anterior.
+func ComparisonRange(comparison string, start, end time.Time) (time.Time, time.Time, bool) {
+	switch comparison {
+	case ComparisonPreviousPeriod:
+		days := int(end.Sub(start).Hours()/24) + 1
+		return start.AddDate(0, 0, -days), start.AddDate(0, 0, -1), true
+	case ComparisonPreviousYear:
+		return start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0), true
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
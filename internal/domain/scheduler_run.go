@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Nomes dos agendadores registrados em scheduler_runs
+const (
+	SchedulerMetaInsightSync     = "meta_insight_sync"
+	SchedulerSSOticaInsightSync  = "ssotica_insight_sync"
+	SchedulerMonthlyInsightsSync = "monthly_insights_sync"
+	SchedulerTopRankingAccounts  = "top_ranking_accounts"
+	SchedulerRollupInsightsSync  = "rollup_insights_sync"
+	SchedulerBudgetPacing        = "budget_pacing"
+	SchedulerAnomalyDetection    = "anomaly_detection"
+	SchedulerIntradaySync        = "intraday_sync"
+)
+
+// ScheduledJob é uma rotina registrada no agendador da aplicação. RunCount conta as execuções desde a
+// inicialização; as execuções manuais e de recuperação não passam pelo agendador e não são contadas.
+type ScheduledJob struct {
+	Name      string     `json:"name"`
+	Running   bool       `json:"running"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	RunCount  int        `json:"run_count"`
+}
+
+// SchedulerRun guarda a última execução persistida de um agendador
+type SchedulerRun struct {
+	Name          string          `json:"name"`
+	LastStartedAt *time.Time      `json:"last_started_at,omitempty"`
+	LastSuccessAt *time.Time      `json:"last_success_at,omitempty"`
+	Checkpoint    *SyncCheckpoint `json:"checkpoint,omitempty"`
+	// Stats guarda as estatísticas da última execução por provedor (meta, ssotica)
+	Stats map[string]*ProviderStats `json:"stats,omitempty"`
+}
+
+// ProviderStats contabiliza o uso de um provedor externo durante uma execução do agendador
+type ProviderStats struct {
+	APICalls int64 `json:"api_calls"`
+	// Failures agrupa as chamadas com erro pela classe do erro (timeout, rate_limit, auth...)
+	Failures    map[string]int64 `json:"failures"`
+	RowsWritten int64            `json:"rows_written"`
+	// CacheHits conta os dados reaproveitados do banco sem nova consulta ao provedor
+	CacheHits int64 `json:"cache_hits"`
+}
+
+// SyncCheckpoint registra o progresso de uma sincronização diária interrompida antes do fim.
+// A execução seguinte processa primeiro as contas pendentes, incluindo as datas da janela interrompida.
+type SyncCheckpoint struct {
+	StartDate           time.Time `json:"start_date"`
+	EndDate             time.Time `json:"end_date"`
+	CompletedAccountIDs []string  `json:"completed_account_ids"`
+	InterruptedAt       time.Time `json:"interrupted_at"`
+}
+
+// IsCompleted indica se a conta já havia sido sincronizada por completo na execução interrompida
+func (c *SyncCheckpoint) IsCompleted(accountID string) bool {
+	if c == nil {
+		return false
+	}
+	for _, id := range c.CompletedAccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// SchedulerState informa se um agendador está pausado em tempo de execução e, se estiver, por quem e por quê
+type SchedulerState struct {
+	Scheduler string     `json:"scheduler"`
+	Paused    bool       `json:"paused"`
+	PausedBy  *int       `json:"paused_by,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	PausedAt  *time.Time `json:"paused_at,omitempty"`
+}
+
+// SchedulerPauseRequest é o corpo opcional da pausa de um agendador
+type SchedulerPauseRequest struct {
+	Reason string `json:"reason"`
+}
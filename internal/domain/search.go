@@ -0,0 +1,34 @@
+package domain
+
+// Tipos de resultado da busca global
+const (
+	SearchTypeAccount         = "account"
+	SearchTypeCampaign        = "campaign"
+	SearchTypeBusinessManager = "business_manager"
+	SearchTypeUser            = "user"
+)
+
+var SearchTypes = []string{SearchTypeAccount, SearchTypeCampaign, SearchTypeBusinessManager, SearchTypeUser}
+
+// SearchResult é um item da busca global, identificado pelo tipo.
+// AccountID aponta para a conta relacionada quando o resultado não é a própria conta (ex: campanha).
+type SearchResult struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Subtitle  string `json:"subtitle,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// SearchScope limita a busca às contas visíveis para o usuário
+type SearchScope struct {
+	UserID int
+	// Unrestricted indica acesso a todas as contas (admin e supervisor)
+	Unrestricted bool
+	Limit        int
+}
+
+type SearchResponse struct {
+	Query   string          `json:"query"`
+	Results []*SearchResult `json:"results"`
+}
@@ -20,3 +20,18 @@ type StoreRankingItem struct {
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
+
+// ForAccounts retorna o ranking apenas com as lojas das contas informadas. As posições continuam sendo as do
+// ranking geral.
+func (r *StoreRankingResponse) ForAccounts(accountIDs map[string]bool) *StoreRankingResponse {
+	scoped := &StoreRankingResponse{
+		Ranking:    []StoreRankingItem{},
+		LastUpdate: r.LastUpdate,
+	}
+	for _, item := range r.Ranking {
+		if accountIDs[item.AccountID] {
+			scoped.Ranking = append(scoped.Ranking, item)
+		}
+	}
+	return scoped
+}
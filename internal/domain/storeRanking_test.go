@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreRankingResponse_ForAccounts(t *testing.T) {
+	ranking := &StoreRankingResponse{Ranking: []StoreRankingItem{
+		{AccountID: "act_1", Position: 1},
+		{AccountID: "act_2", Position: 2},
+		{AccountID: "act_3", Position: 3},
+	}}
+
+	scoped := ranking.ForAccounts(map[string]bool{"act_3": true})
+	assert.Equal(t, []StoreRankingItem{{AccountID: "act_3", Position: 3}}, scoped.Ranking)
+	assert.Len(t, ranking.Ranking, 3)
+
+	assert.Empty(t, ranking.ForAccounts(nil).Ranking)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// SyncFailure é uma data de uma conta que continuou falhando depois de todas as tentativas da sincronização.
+// A falha fica registrada até uma nova sincronização da data ser concluída, em qualquer execução.
+type SyncFailure struct {
+	ID        int64     `json:"id"`
+	Source    string    `json:"source"`
+	AccountID string    `json:"account_id"`
+	Date      time.Time `json:"date"`
+	// Attempts são as tentativas da última falha e Failures quantas execuções falharam para a data
+	Attempts      int        `json:"attempts"`
+	Failures      int        `json:"failures"`
+	Error         string     `json:"error"`
+	FirstFailedAt time.Time  `json:"first_failed_at"`
+	LastFailedAt  time.Time  `json:"last_failed_at"`
+	RequeuedAt    *time.Time `json:"requeued_at,omitempty"`
+}
+
+const (
+	DefaultSyncFailureListLimit = 50
+	MaxSyncFailureListLimit     = 200
+)
+
+// SyncFailureFilters filtra e pagina as falhas, das datas mais recentes para as mais antigas
+type SyncFailureFilters struct {
+	Source    string
+	AccountID string
+	Limit     int
+	Offset    int
+}
+
+// SyncFailurePage é uma página das falhas de sincronização
+type SyncFailurePage struct {
+	Failures []*SyncFailure `json:"failures"`
+	Total    int            `json:"total"`
+	Limit    int            `json:"limit"`
+	Offset   int            `json:"offset"`
+}
+
+// SyncFailureRequeue é o resultado da nova sincronização das falhas de uma conta
+type SyncFailureRequeue struct {
+	Source    string   `json:"source"`
+	AccountID string   `json:"account_id"`
+	Dates     []string `json:"dates"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Origens de dados com agenda de sincronização configurável por conta
+const (
+	SyncSourceMeta    = "meta"
+	SyncSourceSSOtica = "ssotica"
+)
+
+var SyncSources = []string{SyncSourceMeta, SyncSourceSSOtica}
+
+// AccountSyncSchedule substitui o cron global de uma origem para a conta.
+// Contas com agenda própria habilitada deixam de ser sincronizadas pela execução global.
+type AccountSyncSchedule struct {
+	AccountID    string     `json:"account_id"`
+	Source       string     `json:"source"`
+	CronSchedule string     `json:"cron_schedule"`
+	LookbackDays int        `json:"lookback_days"`
+	Enabled      bool       `json:"enabled"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type AccountSyncScheduleRequest struct {
+	CronSchedule string `json:"cron_schedule"`
+	LookbackDays int    `json:"lookback_days"`
+	Enabled      *bool  `json:"enabled"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTimezone é o fuso usado quando a conta não define um
+const DefaultTimezone = "America/Sao_Paulo"
+
+// locations guarda os fusos já carregados, evitando ler a base de fusos a cada consulta
+var locations sync.Map
+
+// IsValidTimezone valida o nome do fuso na base IANA (ex: America/Sao_Paulo)
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+// LoadTimezone retorna o fuso informado, ou o DefaultTimezone quando ele é vazio ou inválido
+func LoadTimezone(timezone string) *time.Location {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	if loc, ok := locations.Load(timezone); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		if timezone == DefaultTimezone {
+			return time.UTC
+		}
+		return LoadTimezone(DefaultTimezone)
+	}
+	locations.Store(timezone, loc)
+	return loc
+}
+
+// TimeLocation retorna o fuso em que os dias da conta são contados (o mesmo do relatório no Meta)
+func (a *AdAccount) TimeLocation() *time.Location {
+	return LoadTimezone(a.Timezone)
+}
+
+// Today retorna a meia-noite do dia atual da conta
+func (a *AdAccount) Today(now time.Time) time.Time {
+	return DayIn(now.In(a.TimeLocation()), a.TimeLocation())
+}
+
+// DayIn retorna a meia-noite, no fuso informado, do dia de calendário da data.
+// Datas recebidas nos filtros (AAAA-MM-DD) representam o dia da conta, não um instante.
+func DayIn(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdAccountToday(t *testing.T) {
+	now := time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC)
+
+	t.Run("usa o fuso da conta", func(t *testing.T) {
+		account := &AdAccount{Timezone: "America/Sao_Paulo"}
+		assert.Equal(t, "2025-03-09", account.Today(now).Format(time.DateOnly))
+	})
+
+	t.Run("fuso inválido cai no padrão", func(t *testing.T) {
+		account := &AdAccount{Timezone: "Lua/Base"}
+		assert.Equal(t, DefaultTimezone, account.TimeLocation().String())
+	})
+
+	t.Run("fuso adiantado já está no dia seguinte", func(t *testing.T) {
+		account := &AdAccount{Timezone: "Asia/Tokyo"}
+		assert.Equal(t, "2025-03-10", account.Today(now.Add(-3*time.Hour)).Format(time.DateOnly))
+	})
+}
+
+func TestIsValidTimezone(t *testing.T) {
+	assert.True(t, IsValidTimezone("America/Manaus"))
+	assert.False(t, IsValidTimezone(""))
+	assert.False(t, IsValidTimezone("Local"))
+	assert.False(t, IsValidTimezone("Brasil/Centro"))
+}
@@ -20,6 +20,33 @@ type User struct {
 	LinkedAccounts []string   `json:"linked_accounts"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+	MustChangePassword bool       `json:"must_change_password"`
+
+	// EmailVerified indica se o usuário confirmou o e-mail pelo link enviado no cadastro
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+
+	// LastLoginAt e LastSeenAt ajudam a encontrar contas sem uso; LastSeenAt é atualizado a cada
+	// requisição autenticada, com precisão de alguns minutos
+	LastLoginAt *time.Time `json:"last_login_at"`
+	LastSeenAt  *time.Time `json:"last_seen_at"`
+
+	// Role é preenchido apenas no perfil do usuário logado (GET /v1/me)
+	Role *Role `json:"role,omitempty"`
+}
+
+// LoginResult é o retorno do login e da troca do refresh token. MustChangePassword indica que o token
+// emitido só permite trocar a senha (senha expirada ou gerada pelo administrador); nesse caso não há
+// refresh token.
+type LoginResult struct {
+	Token                 string     `json:"token"`
+	ExpiresAt             time.Time  `json:"expires_at"`
+	RefreshToken          string     `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at,omitempty"`
+	MustChangePassword    bool       `json:"must_change_password"`
+	PasswordExpiresAt     *time.Time `json:"password_expires_at,omitempty"`
 }
 
 type UpdateUserRequest struct {
@@ -33,14 +60,26 @@ type UpdateUserRequest struct {
 	Deleted   *bool   `json:"deleted"`
 }
 
+// CurrentClaimsVersion é a versão atual do formato das claims do JWT.
+// Deve ser incrementada a cada mudança incompatível, forçando novo login dos tokens antigos.
+const CurrentClaimsVersion = 3
+
+// Claims contém apenas a identificação do usuário. Dados de perfil e contas vinculadas
+// são resolvidos no servidor (ex: /v1/me) para manter o token pequeno e sempre atualizado.
+// O jti (RegisteredClaims.ID) identifica o token para revogação no logout.
 type Claims struct {
 	UserID        int
-	UserName      string
-	UserLastname  string
-	UserEmail     string
-	UserActive    bool
 	UserRoleID    int
-	UserAvatarURL *string
-	UserAccounts  []string
+	ClaimsVersion int
+	// PasswordChangeRequired restringe o token à troca de senha
+	PasswordChangeRequired bool `json:",omitempty"`
+	// SessionStartedAt é o login que originou a sessão, mantido nas renovações do token.
+	// Tokens anteriores à renovação não o possuem e usam IssuedAt.
+	SessionStartedAt *jwt.NumericDate `json:",omitempty"`
+	// SessionID liga o token à sessão do refresh token, permitindo encerrá-la remotamente
+	SessionID string `json:",omitempty"`
+	// APIKeyID e APIKeyScopes são preenchidos nas requisições autenticadas por chave de API; não vão no JWT
+	APIKeyID     int          `json:"-"`
+	APIKeyScopes []Permission `json:"-"`
 	jwt.RegisteredClaims
 }
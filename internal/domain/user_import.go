@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// UserImportRow é um usuário da importação em lote. O perfil é informado pelo ID (role_id) ou pelo nome (role);
+// sem senha, uma senha temporária é gerada.
+type UserImportRow struct {
+	Name              string   `json:"name"`
+	Lastname          string   `json:"lastname"`
+	Email             string   `json:"email"`
+	RoleID            int      `json:"role_id"`
+	Role              string   `json:"role"`
+	AccountIDs        []string `json:"account_ids"`
+	AccountPermission string   `json:"account_permission"`
+	Password          string   `json:"password"`
+}
+
+// UserImport é um usuário validado, gravado com os vínculos (User.LinkedAccounts) no nível de acesso informado
+type UserImport struct {
+	User              *User
+	AccountPermission AccountPermission
+}
+
+// UserImportResult é o resultado de uma linha da importação. Row começa em 1 e não conta o cabeçalho do CSV.
+type UserImportResult struct {
+	Row    int    `json:"row"`
+	Email  string `json:"email"`
+	UserID int    `json:"user_id,omitempty"`
+	// Password é a senha temporária gerada para linhas sem senha, exibida apenas nesta resposta
+	Password string   `json:"password,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// UserImportReport é o relatório da importação. Com alguma linha inválida nenhum usuário é criado.
+type UserImportReport struct {
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+	Rows    []*UserImportResult `json:"rows"`
+}
+
+// userImportColumns são as colunas aceitas no CSV; name, lastname, email e role são obrigatórias
+var userImportColumns = []string{"name", "lastname", "email", "role", "account_ids", "account_permission", "password"}
+
+// ParseUserImportCSV lê o CSV da importação em lote. A primeira linha é o cabeçalho com os nomes das colunas,
+// em qualquer ordem. O separador pode ser vírgula ou ponto e vírgula (padrão do Excel em português). A coluna
+// role aceita o ID ou o nome do perfil, e account_ids lista as contas separadas por espaço, vírgula, ponto e
+// vírgula ou barra vertical.
+func ParseUserImportCSV(r io.Reader) ([]UserImportRow, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o CSV: %w", err)
+	}
+
+	header, _, _ := strings.Cut(string(content), "\n")
+	reader := csv.NewReader(strings.NewReader(string(content)))
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV inválido: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV vazio")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "lastname", "email", "role"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("coluna obrigatória ausente no cabeçalho: %s (colunas aceitas: %s)", required, strings.Join(userImportColumns, ", "))
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]UserImportRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := UserImportRow{
+			Name:              field(record, "name"),
+			Lastname:          field(record, "lastname"),
+			Email:             field(record, "email"),
+			AccountPermission: field(record, "account_permission"),
+			Password:          field(record, "password"),
+			AccountIDs: strings.FieldsFunc(field(record, "account_ids"), func(r rune) bool {
+				return r == ',' || r == ';' || r == '|' || r == ' '
+			}),
+		}
+
+		role := field(record, "role")
+		if id, err := strconv.Atoi(role); err == nil {
+			row.RoleID = id
+		} else {
+			row.Role = role
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserImportCSV(t *testing.T) {
+	t.Run("vírgula, perfil por ID e contas entre aspas", func(t *testing.T) {
+		rows, err := ParseUserImportCSV(strings.NewReader(
+			"Email,Name,Lastname,Role,Account_IDs\n" +
+				"ana@example.com,Ana,Silva,3,\"ABC123, DEF456\"\n"))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, UserImportRow{
+			Name: "Ana", Lastname: "Silva", Email: "ana@example.com", RoleID: 3,
+			AccountIDs: []string{"ABC123", "DEF456"},
+		}, rows[0])
+	})
+
+	t.Run("ponto e vírgula do Excel, BOM e perfil pelo nome", func(t *testing.T) {
+		rows, err := ParseUserImportCSV(strings.NewReader(
+			"\uFEFFname;lastname;email;role;account_ids;account_permission\r\n" +
+				"Bruno;Souza;bruno@example.com;vendedor;ABC123|DEF456;analyst\r\n"))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "vendedor", rows[0].Role)
+		assert.Zero(t, rows[0].RoleID)
+		assert.Equal(t, []string{"ABC123", "DEF456"}, rows[0].AccountIDs)
+		assert.Equal(t, "analyst", rows[0].AccountPermission)
+	})
+
+	t.Run("coluna obrigatória ausente", func(t *testing.T) {
+		_, err := ParseUserImportCSV(strings.NewReader("name,email\nAna,ana@example.com\n"))
+		assert.ErrorContains(t, err, "lastname")
+	})
+}
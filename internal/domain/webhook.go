@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Tipos de evento enviados por webhook
+const (
+	WebhookEventRankingUpdated = "ranking.updated"
+	WebhookEventBudgetOverPace = "budget.over_pace"
+	WebhookEventSyncFinished   = "sync.finished"
+)
+
+// WebhookEventTypes lista os eventos que podem ser assinados
+var WebhookEventTypes = []string{
+	WebhookEventRankingUpdated,
+	WebhookEventBudgetOverPace,
+	WebhookEventSyncFinished,
+}
+
+// WebhookSubscription é um destino de webhook. O segredo só é retornado na criação.
+type WebhookSubscription struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookEvent é o envelope enviado no corpo de cada entrega
+type WebhookEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
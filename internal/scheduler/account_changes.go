@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// AccountChangesChannel é o canal de notificações publicado pelo banco quando o status
+// ou as credenciais de uma conta mudam (trigger notify_account_change)
+const AccountChangesChannel = "account_changes"
+
+// AccountChangeTracker guarda o estado atual das contas alteradas enquanto os agendadores executam.
+// As execuções carregam a lista de contas no início e consultam o tracker antes de processar cada conta,
+// deixando de sincronizar contas inativadas e usando as credenciais novas imediatamente.
+// Um AccountChangeTracker nil mantém a lista carregada no início da execução.
+type AccountChangeTracker struct {
+	accountRepo repository.AccountRepository
+	mu          sync.RWMutex
+	changed     map[string]*domain.AdAccount
+}
+
+func NewAccountChangeTracker(accountRepo repository.AccountRepository) *AccountChangeTracker {
+	return &AccountChangeTracker{
+		accountRepo: accountRepo,
+		changed:     make(map[string]*domain.AdAccount),
+	}
+}
+
+// HandleChange recarrega a conta notificada pelo banco. Uma conta removida é tratada como inativa.
+func (t *AccountChangeTracker) HandleChange(accountID string) {
+	account, err := t.accountRepo.GetAccountByID(accountID)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao recarregar conta alterada")
+		return
+	}
+	if account == nil {
+		account = &domain.AdAccount{ID: accountID, Status: domain.AdAccountStatusInactive}
+	}
+
+	t.mu.Lock()
+	t.changed[accountID] = account
+	t.mu.Unlock()
+
+	logrus.WithFields(logrus.Fields{
+		"account_id": accountID,
+		"status":     account.Status,
+	}).Info("Alteração da conta recebida pelos agendadores")
+}
+
+// Reset descarta as alterações conhecidas. Chamado após a reconexão ao banco, quando notificações
+// podem ter sido perdidas e o estado guardado pode estar desatualizado.
+func (t *AccountChangeTracker) Reset() {
+	t.mu.Lock()
+	t.changed = make(map[string]*domain.AdAccount)
+	t.mu.Unlock()
+}
+
+// refresh retorna a conta com o status e as credenciais atuais e se ela continua ativa
+func (t *AccountChangeTracker) refresh(acc *domain.AdAccount) (*domain.AdAccount, bool) {
+	if t == nil {
+		return acc, true
+	}
+
+	t.mu.RLock()
+	current, ok := t.changed[acc.ID]
+	t.mu.RUnlock()
+	if !ok {
+		return acc, true
+	}
+
+	// A lista das execuções traz dados do business manager que a consulta por ID não retorna
+	updated := *acc
+	updated.Status = current.Status
+	updated.ExternalID = current.ExternalID
+	updated.CNPJ = current.CNPJ
+	updated.SecretName = current.SecretName
+
+	return &updated, updated.Status == domain.AdAccountStatusActive
+}
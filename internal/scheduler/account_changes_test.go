@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountChangeTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	tracker := NewAccountChangeTracker(accountRepo)
+
+	oldSecret, newSecret := "antigo", "novo"
+	loaded := &domain.AdAccount{ID: "abc123", ExternalID: "999", Name: "Loja Centro", SecretName: &oldSecret, Status: domain.AdAccountStatusActive}
+
+	// Sem alterações a conta carregada no início da execução é mantida
+	current, active := tracker.refresh(loaded)
+	assert.True(t, active)
+	assert.Same(t, loaded, current)
+
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(&domain.AdAccount{ID: "abc123", ExternalID: "999", SecretName: &newSecret, Status: domain.AdAccountStatusActive}, nil)
+	tracker.HandleChange("abc123")
+
+	current, active = tracker.refresh(loaded)
+	assert.True(t, active)
+	assert.Equal(t, "novo", *current.SecretName)
+	assert.Equal(t, "Loja Centro", current.Name)
+
+	// Conta removida é tratada como inativa
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(nil, nil)
+	tracker.HandleChange("abc123")
+
+	_, active = tracker.refresh(loaded)
+	assert.False(t, active)
+
+	tracker.Reset()
+	_, active = tracker.refresh(loaded)
+	assert.True(t, active)
+
+	var disabled *AccountChangeTracker
+	current, active = disabled.refresh(loaded)
+	assert.True(t, active)
+	assert.Same(t, loaded, current)
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// accountSchedules aplica as agendas próprias das contas de uma origem: as contas com agenda habilitada
+// saem da execução global e são sincronizadas quando a própria expressão cron vence.
+// Um accountSchedules nil mantém todas as contas no cron global.
+type accountSchedules struct {
+	repo          repository.SyncScheduleRepository
+	source        string
+	checkInterval time.Duration
+	running       bool
+	mutex         sync.Mutex
+	now           func() time.Time
+}
+
+func newAccountSchedules(repo repository.SyncScheduleRepository, source string, checkInterval time.Duration) *accountSchedules {
+	return &accountSchedules{
+		repo:          repo,
+		source:        source,
+		checkInterval: checkInterval,
+		now:           time.Now,
+	}
+}
+
+// withoutOverrides remove as contas com agenda própria habilitada da execução global
+func (a *accountSchedules) withoutOverrides(accounts []*domain.AdAccount) []*domain.AdAccount {
+	if a == nil {
+		return accounts
+	}
+
+	schedules, err := a.repo.ListEnabledSchedules(a.source)
+	if err != nil {
+		// Na dúvida a conta segue no cron global, que é o comportamento padrão
+		logrus.WithError(err).WithField("source", a.source).Error("Erro ao consultar agendas por conta, sincronizando todas as contas")
+		return accounts
+	}
+	if len(schedules) == 0 {
+		return accounts
+	}
+
+	overridden := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		overridden[schedule.AccountID] = true
+	}
+
+	filtered := make([]*domain.AdAccount, 0, len(accounts))
+	for _, account := range accounts {
+		if !overridden[account.ID] {
+			filtered = append(filtered, account)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"source":     a.source,
+		"overridden": len(accounts) - len(filtered),
+	}).Info("Contas com agenda própria fora da sincronização global")
+
+	return filtered
+}
+
+// lockName identifica as agendas por conta da origem no lock entre as instâncias da API
+func (a *accountSchedules) lockName() string {
+	return "account_schedules:" + a.source
+}
+
+// runDue sincroniza as contas cuja agenda venceu. listAccounts deve retornar apenas as contas aptas
+// à sincronização da origem; process sincroniza uma conta nas datas informadas.
+func (a *accountSchedules) runDue(listAccounts func() ([]*domain.AdAccount, error), process func(acc *domain.AdAccount, dates []time.Time)) {
+	a.mutex.Lock()
+	if a.running {
+		a.mutex.Unlock()
+		return
+	}
+	a.running = true
+	a.mutex.Unlock()
+
+	defer func() {
+		a.mutex.Lock()
+		a.running = false
+		a.mutex.Unlock()
+	}()
+
+	schedules, err := a.repo.ListEnabledSchedules(a.source)
+	if err != nil {
+		logrus.WithError(err).WithField("source", a.source).Error("Erro ao consultar agendas por conta")
+		return
+	}
+
+	now := a.now()
+	due := make(map[string]*domain.AccountSyncSchedule)
+	for _, schedule := range schedules {
+		if isScheduleDue(schedule, now) {
+			due[schedule.AccountID] = schedule
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	accounts, err := listAccounts()
+	if err != nil {
+		logrus.WithError(err).WithField("source", a.source).Error("Erro ao buscar contas para sincronização por agenda")
+		return
+	}
+
+	for _, account := range accounts {
+		schedule, ok := due[account.ID]
+		if !ok {
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"source":        a.source,
+			"account_id":    account.ID,
+			"cron_schedule": schedule.CronSchedule,
+			"lookback_days": schedule.LookbackDays,
+		}).Info("Sincronizando conta pela agenda própria")
+
+		process(account, scheduleDates(schedule.LookbackDays, now.In(account.TimeLocation())))
+
+		if err := a.repo.MarkScheduleRun(account.ID, a.source, now); err != nil {
+			logrus.WithError(err).WithField("account_id", account.ID).Error("Erro ao registrar execução da agenda da conta")
+		}
+	}
+}
+
+// isScheduleDue indica se houve ocorrência do cron desde a última execução (ou desde a criação da agenda)
+func isScheduleDue(schedule *domain.AccountSyncSchedule, now time.Time) bool {
+	parsed, err := cron.ParseStandard(schedule.CronSchedule)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", schedule.AccountID).Warn("Cron inválido na agenda da conta, ignorando")
+		return false
+	}
+
+	since := schedule.CreatedAt
+	if schedule.LastRunAt != nil {
+		since = *schedule.LastRunAt
+	}
+
+	next := parsed.Next(since)
+	return !next.IsZero() && !next.After(now)
+}
+
+// scheduleDates retorna o dia atual (no fuso de now) e os anteriores, já que agendas por conta servem para atualizar o dia em andamento
+func scheduleDates(lookbackDays int, now time.Time) []time.Time {
+	if lookbackDays < 1 {
+		lookbackDays = 1
+	}
+
+	dates := make([]time.Time, lookbackDays)
+	for i := 0; i < lookbackDays; i++ {
+		dates[i] = truncateToDay(now).AddDate(0, 0, -i)
+	}
+	return dates
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIsScheduleDue(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 9, 20, 0, 0, time.Local)
+	lastRun := time.Date(2025, 3, 10, 10, 0, 0, 0, time.Local)
+
+	hourly := &domain.AccountSyncSchedule{AccountID: "abc123", CronSchedule: "0 * * * *", CreatedAt: createdAt}
+	assert.False(t, isScheduleDue(hourly, time.Date(2025, 3, 10, 9, 59, 0, 0, time.Local)))
+	assert.True(t, isScheduleDue(hourly, time.Date(2025, 3, 10, 10, 0, 30, 0, time.Local)))
+
+	hourly.LastRunAt = &lastRun
+	assert.False(t, isScheduleDue(hourly, time.Date(2025, 3, 10, 10, 30, 0, 0, time.Local)))
+	assert.True(t, isScheduleDue(hourly, time.Date(2025, 3, 10, 11, 0, 0, 0, time.Local)))
+
+	invalid := &domain.AccountSyncSchedule{AccountID: "abc123", CronSchedule: "x", CreatedAt: createdAt}
+	assert.False(t, isScheduleDue(invalid, time.Now()))
+}
+
+func TestAccountSchedules_WithoutOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSyncScheduleRepository(ctrl)
+	repo.EXPECT().ListEnabledSchedules(domain.SyncSourceSSOtica).Return([]*domain.AccountSyncSchedule{{AccountID: "bbb222"}}, nil)
+
+	schedules := newAccountSchedules(repo, domain.SyncSourceSSOtica, time.Minute)
+	accounts := []*domain.AdAccount{{ID: "aaa111"}, {ID: "bbb222"}, {ID: "ccc333"}}
+
+	filtered := schedules.withoutOverrides(accounts)
+	assert.Equal(t, []*domain.AdAccount{{ID: "aaa111"}, {ID: "ccc333"}}, filtered)
+
+	var disabled *accountSchedules
+	assert.Len(t, disabled.withoutOverrides(accounts), 3)
+}
+
+func TestScheduleDates(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.Local)
+	dates := scheduleDates(2, now)
+
+	assert.Len(t, dates, 2)
+	assert.Equal(t, "2025-03-10", dates[0].Format(time.DateOnly))
+	assert.Equal(t, "2025-03-09", dates[1].Format(time.DateOnly))
+}
+
+func TestScheduleDates_AccountTimezone(t *testing.T) {
+	// 01:30 UTC ainda é o dia anterior em São Paulo
+	now := time.Date(2025, 3, 10, 1, 30, 0, 0, time.UTC)
+	account := &domain.AdAccount{Timezone: "America/Sao_Paulo"}
+	dates := scheduleDates(1, now.In(account.TimeLocation()))
+
+	assert.Equal(t, "2025-03-09", dates[0].Format(time.DateOnly))
+}
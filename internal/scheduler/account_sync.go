@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrAccountSyncNotFound = errors.New("conta não encontrada, inativa ou sem as credenciais da origem")
+	ErrAccountSyncRunning  = errors.New("sincronização da conta já em andamento para a origem")
+)
+
+// accountSyncs controla as sincronizações manuais de uma única conta: uma por vez para cada conta,
+// independente da sincronização de todas as contas
+type accountSyncs struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func newAccountSyncs() *accountSyncs {
+	return &accountSyncs{running: make(map[string]bool)}
+}
+
+// start reserva a conta e informa se ela ainda não estava em sincronização
+func (a *accountSyncs) start(accountID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.running[accountID] {
+		return false
+	}
+	a.running[accountID] = true
+	return true
+}
+
+func (a *accountSyncs) finish(accountID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.running, accountID)
+}
+
+// status retorna as contas em sincronização manual, para o GetStatus
+func (a *accountSyncs) status() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	accountIDs := make([]string, 0, len(a.running))
+	for accountID := range a.running {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+	return accountIDs
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountSyncs(t *testing.T) {
+	syncs := newAccountSyncs()
+
+	assert.True(t, syncs.start("DEF456"))
+	assert.True(t, syncs.start("ABC123"))
+	assert.False(t, syncs.start("ABC123"))
+	assert.Equal(t, []string{"ABC123", "DEF456"}, syncs.status())
+
+	syncs.finish("ABC123")
+	assert.True(t, syncs.start("ABC123"))
+}
+
+func TestMetaInsightSyncService_TriggerAccountSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := NewMetaInsightSyncService(accountRepo, nil, nil, &config.Config{})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("ABC123").Return(nil, nil)
+		assert.ErrorIs(t, service.TriggerAccountSync("ABC123"), ErrAccountSyncNotFound)
+	})
+
+	t.Run("conta inativa", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("ABC123").Return(&domain.AdAccount{
+			ID: "ABC123", ExternalID: "act_1", Status: domain.AdAccountStatusInactive,
+		}, nil)
+		assert.ErrorIs(t, service.TriggerAccountSync("ABC123"), ErrAccountSyncNotFound)
+	})
+
+	t.Run("conta sem external_id", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("ABC123").Return(&domain.AdAccount{
+			ID: "ABC123", Status: domain.AdAccountStatusActive,
+		}, nil)
+		assert.ErrorIs(t, service.TriggerAccountSync("ABC123"), ErrAccountSyncNotFound)
+	})
+
+	t.Run("conta já em sincronização", func(t *testing.T) {
+		service.accountSyncs.start("ABC123")
+		defer service.accountSyncs.finish("ABC123")
+
+		accountRepo.EXPECT().GetAccountByID("ABC123").Return(&domain.AdAccount{
+			ID: "ABC123", ExternalID: "act_1", Status: domain.AdAccountStatusActive,
+		}, nil)
+		assert.ErrorIs(t, service.TriggerAccountSync("ABC123"), ErrAccountSyncRunning)
+	})
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+)
+
+// recordSyncActivity registra o resultado da sincronização de uma conta na linha do tempo
+func recordSyncActivity(recorder activity.Recorder, accountID, source string, dates, failedDates []time.Time) {
+	if recorder == nil || len(dates) == 0 {
+		return
+	}
+
+	summary := fmt.Sprintf("Sincronização %s concluída (%d dias)", source, len(dates))
+	if len(failedDates) > 0 {
+		summary = fmt.Sprintf("Sincronização %s com falha em %d de %d dias", source, len(failedDates), len(dates))
+	}
+
+	failed := make([]string, 0, len(failedDates))
+	for _, date := range failedDates {
+		failed = append(failed, date.Format(time.DateOnly))
+	}
+
+	recorder.Record(accountID, domain.AccountActivitySync, summary, map[string]any{
+		"source":       source,
+		"start_date":   dates[0].Format(time.DateOnly),
+		"end_date":     dates[len(dates)-1].Format(time.DateOnly),
+		"failed_dates": failed,
+	}, nil)
+}
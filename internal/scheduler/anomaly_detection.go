@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+)
+
+// AnomalyDetectionConfig representa a configuração da detecção diária de anomalias
+type AnomalyDetectionConfig struct {
+	CronSchedule string
+	Enabled      bool
+	LookbackDays int
+}
+
+// AnomalyDetectionService reavalia diariamente os últimos dias de cada conta ativa e grava os dias com
+// investimento ou custo por resultado fora do padrão, para o dashboard destacar
+type AnomalyDetectionService struct {
+	config      AnomalyDetectionConfig
+	accountRepo repository.AccountRepository
+	detector    insighting.AnomalyDetector
+	jobs        *jobRunner
+	now         func() time.Time
+}
+
+// NewAnomalyDetectionService cria uma nova instância da detecção de anomalias
+func NewAnomalyDetectionService(
+	accountRepo repository.AccountRepository,
+	detector insighting.AnomalyDetector,
+	appConfig *config.Config,
+	opts ...JobOption,
+) *AnomalyDetectionService {
+	detectionConfig := AnomalyDetectionConfig{
+		CronSchedule: appConfig.AnomalyDetection.CronSchedule,
+		Enabled:      appConfig.AnomalyDetection.Enabled,
+		LookbackDays: appConfig.AnomalyDetection.LookbackDays,
+	}
+	if detectionConfig.LookbackDays <= 0 {
+		detectionConfig.LookbackDays = 7
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cron_schedule": detectionConfig.CronSchedule,
+		"enabled":       detectionConfig.Enabled,
+		"lookback_days": detectionConfig.LookbackDays,
+	}).Info("Configuração da detecção de anomalias carregada")
+
+	return &AnomalyDetectionService{
+		jobs:        newJobRunner(domain.SchedulerAnomalyDetection, nil, opts...),
+		config:      detectionConfig,
+		accountRepo: accountRepo,
+		detector:    detector,
+		now:         time.Now,
+	}
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *AnomalyDetectionService) Start(ctx context.Context, scheduler *Scheduler) error {
+	if !s.config.Enabled {
+		logrus.Info("Detecção de anomalias desabilitada por configuração")
+		return nil
+	}
+
+	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de detecção de anomalias")
+
+	// O encerramento da aplicação aguarda as execuções em andamento, inclusive a de recuperação
+	s.jobs.runs = scheduler.runs
+
+	err := scheduler.Cron(domain.SchedulerAnomalyDetection, s.config.CronSchedule, func() {
+		s.detectAnomalies(ctx, domain.JobTriggerCron)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao agendar detecção de anomalias: %w", err)
+	}
+
+	// Executar a detecção perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() { s.detectAnomalies(ctx, domain.JobTriggerCatchUp) })
+
+	return nil
+}
+
+// detectAnomalies executa a detecção, se não houver outra em andamento nesta ou em outra instância da API
+func (s *AnomalyDetectionService) detectAnomalies(ctx context.Context, trigger string) {
+	started := s.jobs.run(trigger, nil, func(time.Time) error {
+		return s.detectAccountsAnomalies(ctx)
+	})
+	if !started {
+		logrus.Info("Detecção de anomalias já em andamento, ignorando")
+	}
+}
+
+// detectAccountsAnomalies avalia, para cada conta ativa, os últimos dias completos no fuso da conta. Interrompida
+// pelo encerramento da aplicação, não é registrada como concluída.
+func (s *AnomalyDetectionService) detectAccountsAnomalies(ctx context.Context) error {
+	startTime := s.now()
+
+	accounts, err := s.accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao buscar lista de contas para detecção de anomalias")
+		return err
+	}
+
+	found, failed := 0, 0
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			logrus.Warn("Detecção de anomalias interrompida pelo encerramento da aplicação")
+			return err
+		}
+		to := account.Today(startTime).AddDate(0, 0, -1)
+		from := to.AddDate(0, 0, -(s.config.LookbackDays - 1))
+
+		anomalies, err := s.detector.DetectAccountAnomalies(account, from, to)
+		if err != nil {
+			failed++
+			logrus.WithError(err).WithField("account_id", account.ID).Error("Erro ao detectar anomalias da conta")
+			continue
+		}
+		found += len(anomalies)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"duration":  time.Since(startTime).String(),
+		"accounts":  len(accounts),
+		"anomalies": found,
+		"failed":    failed,
+	}).Info("Detecção de anomalias concluída")
+
+	return nil
+}
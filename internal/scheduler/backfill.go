@@ -0,0 +1,270 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// backfillMaxMonths limita o período de um backfill
+const backfillMaxMonths = 24
+
+var (
+	ErrBackfillDisabled        = errors.New("backfill não habilitado para a origem")
+	ErrBackfillRunning         = errors.New("backfill já em andamento para a origem")
+	ErrInvalidBackfillRange    = errors.New("período de backfill inválido")
+	ErrBackfillAccountNotFound = errors.New("conta não encontrada ou não sincronizável")
+)
+
+// accountDatesProcessor sincroniza as datas de uma conta e indica se todas foram concluídas sem falhas
+type accountDatesProcessor func(ctx context.Context, acc *domain.AdAccount, dates []time.Time) bool
+
+// backfillRunner executa backfills de vários meses divididos em blocos mensais. Os blocos são
+// distribuídos, em ordem cronológica, para um único pool de workers e o resultado de cada bloco
+// é persistido: uma nova execução do mesmo período refaz apenas os blocos que não foram concluídos.
+// Backfills de contas específicas (accountIDs) refazem todos os blocos e não gravam a situação deles,
+// que vale para todas as contas. Um backfillRunner nil não executa backfills.
+type backfillRunner struct {
+	repo       repository.BackfillChunkRepository
+	source     string
+	mu         sync.Mutex
+	running    bool
+	chunks     []*domain.BackfillChunk
+	accountIDs []string
+}
+
+func newBackfillRunner(repo repository.BackfillChunkRepository, source string) *backfillRunner {
+	return &backfillRunner{repo: repo, source: source}
+}
+
+// start valida o período e marca o backfill como em andamento. accountIDs restringe o backfill às contas
+// informadas; vazio, o backfill é de todas as contas.
+func (b *backfillRunner) start(startDate, endDate, now time.Time, accountIDs []string) ([]*domain.BackfillChunk, error) {
+	if b == nil {
+		return nil, ErrBackfillDisabled
+	}
+
+	startDate, endDate = truncateToDay(startDate), truncateToDay(endDate)
+	switch {
+	case startDate.IsZero() || endDate.IsZero() || endDate.Before(startDate):
+		return nil, fmt.Errorf("%w: data final anterior à inicial", ErrInvalidBackfillRange)
+	case !endDate.Before(truncateToDay(now)):
+		return nil, fmt.Errorf("%w: a data final deve ser anterior a hoje", ErrInvalidBackfillRange)
+	case startDate.Before(endDate.AddDate(0, -backfillMaxMonths, 0)):
+		return nil, fmt.Errorf("%w: período máximo de %d meses", ErrInvalidBackfillRange, backfillMaxMonths)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.running {
+		return nil, ErrBackfillRunning
+	}
+
+	chunks := monthlyChunks(b.source, startDate, endDate)
+	if len(accountIDs) == 0 {
+		var err error
+		if chunks, err = b.pendingChunks(startDate, endDate); err != nil {
+			return nil, err
+		}
+	}
+
+	b.running = true
+	b.chunks = chunks
+	b.accountIDs = accountIDs
+	return chunks, nil
+}
+
+// pendingChunks divide o período em meses e reaproveita a situação dos blocos já registrados
+func (b *backfillRunner) pendingChunks(startDate, endDate time.Time) ([]*domain.BackfillChunk, error) {
+	stored, err := b.repo.ListChunks(b.source, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	storedByStart := make(map[string]*domain.BackfillChunk, len(stored))
+	for _, chunk := range stored {
+		storedByStart[chunk.StartDate.Format(time.DateOnly)+chunk.EndDate.Format(time.DateOnly)] = chunk
+	}
+
+	chunks := monthlyChunks(b.source, startDate, endDate)
+	for i, chunk := range chunks {
+		if previous, ok := storedByStart[chunk.StartDate.Format(time.DateOnly)+chunk.EndDate.Format(time.DateOnly)]; ok {
+			chunks[i].Status = previous.Status
+			chunks[i].FailedAccounts = previous.FailedAccounts
+			chunks[i].UpdatedAt = previous.UpdatedAt
+		}
+	}
+	return chunks, nil
+}
+
+// run processa os blocos pendentes. Cada conta de um bloco ocupa uma vaga do pool de workers
+// compartilhado; a situação do bloco é gravada assim que todas as suas contas terminam.
+func (b *backfillRunner) run(ctx context.Context, chunks []*domain.BackfillChunk, accounts []*domain.AdAccount, maxConcurrentJobs int, process accountDatesProcessor) {
+	defer func() {
+		b.mu.Lock()
+		b.running = false
+		b.mu.Unlock()
+	}()
+
+	semaphore := make(chan struct{}, max(maxConcurrentJobs, 1))
+	var wg sync.WaitGroup
+
+	for _, chunk := range chunks {
+		if chunk.Status == domain.BackfillChunkSucceeded {
+			logrus.WithFields(logrus.Fields{
+				"source":     b.source,
+				"start_date": chunk.StartDate.Format(time.DateOnly),
+			}).Info("Bloco de backfill já concluído, ignorando")
+			continue
+		}
+
+		dates := chunkDates(chunk)
+		chunkWG := &sync.WaitGroup{}
+		failed := &atomic.Int32{}
+
+		for _, account := range accounts {
+			// Parar de distribuir contas se o backfill for cancelado
+			if !acquireSlot(ctx, semaphore) {
+				break
+			}
+
+			chunkWG.Add(1)
+			go func(acc *domain.AdAccount) {
+				defer func() {
+					<-semaphore
+					chunkWG.Done()
+				}()
+
+				if !process(ctx, acc, dates) {
+					failed.Add(1)
+				}
+			}(account)
+		}
+
+		wg.Add(1)
+		go func(chunk *domain.BackfillChunk) {
+			defer wg.Done()
+			chunkWG.Wait()
+			b.finishChunk(ctx, chunk, int(failed.Load()))
+		}(chunk)
+	}
+
+	wg.Wait()
+}
+
+// finishChunk grava o resultado do bloco. Um bloco interrompido continua pendente.
+func (b *backfillRunner) finishChunk(ctx context.Context, chunk *domain.BackfillChunk, failedAccounts int) {
+	b.mu.Lock()
+	switch {
+	case ctx.Err() != nil:
+		chunk.Status = domain.BackfillChunkPending
+	case failedAccounts > 0:
+		chunk.Status = domain.BackfillChunkFailed
+	default:
+		chunk.Status = domain.BackfillChunkSucceeded
+	}
+	chunk.FailedAccounts = failedAccounts
+	chunk.UpdatedAt = time.Now()
+	saved := *chunk
+	scoped := len(b.accountIDs) > 0
+	b.mu.Unlock()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"source":          b.source,
+		"start_date":      saved.StartDate.Format(time.DateOnly),
+		"end_date":        saved.EndDate.Format(time.DateOnly),
+		"status":          saved.Status,
+		"failed_accounts": saved.FailedAccounts,
+	})
+	logger.Info("Bloco de backfill finalizado")
+
+	if scoped {
+		return
+	}
+	if err := b.repo.SaveChunk(&saved); err != nil {
+		logger.WithError(err).Error("Erro ao registrar bloco de backfill")
+	}
+}
+
+// status retorna o andamento do último backfill
+func (b *backfillRunner) status() map[string]any {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	chunks := make([]domain.BackfillChunk, 0, len(b.chunks))
+	for _, chunk := range b.chunks {
+		chunks = append(chunks, *chunk)
+	}
+	return map[string]any{
+		"running":     b.running,
+		"chunks":      chunks,
+		"account_ids": b.accountIDs,
+	}
+}
+
+// backfillAccounts restringe as contas sincronizáveis às informadas em accountIDs. Vazio, retorna todas.
+func backfillAccounts(accounts []*domain.AdAccount, accountIDs []string) ([]*domain.AdAccount, error) {
+	if len(accountIDs) == 0 {
+		return accounts, nil
+	}
+
+	byID := make(map[string]*domain.AdAccount, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+
+	selected := make([]*domain.AdAccount, 0, len(accountIDs))
+	missing := make([]string, 0)
+	for _, id := range accountIDs {
+		acc, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		selected = append(selected, acc)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrBackfillAccountNotFound, missing)
+	}
+
+	return selected, nil
+}
+
+// monthlyChunks divide o período em blocos de um mês de calendário
+func monthlyChunks(source string, startDate, endDate time.Time) []*domain.BackfillChunk {
+	chunks := make([]*domain.BackfillChunk, 0)
+	for chunkStart := startDate; !chunkStart.After(endDate); {
+		nextMonth := time.Date(chunkStart.Year(), chunkStart.Month()+1, 1, 0, 0, 0, 0, chunkStart.Location())
+		chunkEnd := nextMonth.AddDate(0, 0, -1)
+		if chunkEnd.After(endDate) {
+			chunkEnd = endDate
+		}
+
+		chunks = append(chunks, &domain.BackfillChunk{
+			Source:    source,
+			StartDate: chunkStart,
+			EndDate:   chunkEnd,
+			Status:    domain.BackfillChunkPending,
+		})
+		chunkStart = nextMonth
+	}
+	return chunks
+}
+
+func chunkDates(chunk *domain.BackfillChunk) []time.Time {
+	dates := make([]time.Time, 0, 31)
+	for date := chunk.StartDate; !date.After(chunk.EndDate); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
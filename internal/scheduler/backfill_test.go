@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMonthlyChunks(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	chunks := monthlyChunks(domain.SyncSourceMeta, start, end)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, start, chunks[0].StartDate)
+	assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), chunks[0].EndDate)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), chunks[1].StartDate)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), chunks[1].EndDate)
+	assert.Equal(t, end, chunks[2].EndDate)
+	assert.Len(t, chunkDates(chunks[1]), 28)
+}
+
+func TestBackfillRunner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockBackfillChunkRepository(ctrl)
+	runner := newBackfillRunner(repo, domain.SyncSourceMeta)
+
+	now := time.Date(2025, 7, 1, 3, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	// Janeiro concluído em um backfill anterior; fevereiro falhou e deve ser refeito
+	repo.EXPECT().ListChunks(domain.SyncSourceMeta, start, end).Return([]*domain.BackfillChunk{
+		{StartDate: start, EndDate: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), Status: domain.BackfillChunkSucceeded},
+		{StartDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), Status: domain.BackfillChunkFailed},
+	}, nil)
+
+	chunks, err := runner.start(start, end, now, nil)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 3)
+
+	_, err = runner.start(start, end, now, nil)
+	assert.ErrorIs(t, err, ErrBackfillRunning)
+
+	var mu sync.Mutex
+	saved := make(map[time.Month]*domain.BackfillChunk)
+	repo.EXPECT().SaveChunk(gomock.Any()).Times(2).DoAndReturn(func(chunk *domain.BackfillChunk) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved[chunk.StartDate.Month()] = chunk
+		return nil
+	})
+
+	processed := make(map[time.Month]int)
+	accounts := []*domain.AdAccount{{ID: "a"}, {ID: "b"}}
+	runner.run(context.Background(), chunks, accounts, 2, func(_ context.Context, acc *domain.AdAccount, dates []time.Time) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		processed[dates[0].Month()]++
+		// A conta b falha em março
+		return !(acc.ID == "b" && dates[0].Month() == time.March)
+	})
+
+	assert.Zero(t, processed[time.January])
+	assert.Equal(t, 2, processed[time.February])
+	assert.Equal(t, 2, processed[time.March])
+	assert.Equal(t, domain.BackfillChunkSucceeded, saved[time.February].Status)
+	assert.Equal(t, domain.BackfillChunkFailed, saved[time.March].Status)
+	assert.Equal(t, 1, saved[time.March].FailedAccounts)
+	assert.False(t, runner.status()["running"].(bool))
+}
+
+func TestBackfillRunner_InvalidRange(t *testing.T) {
+	runner := newBackfillRunner(nil, domain.SyncSourceMeta)
+	now := time.Date(2025, 7, 1, 3, 0, 0, 0, time.UTC)
+
+	_, err := runner.start(now.AddDate(0, -1, 0), now, now, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBackfillRange))
+
+	_, err = runner.start(now.AddDate(-3, 0, 0), now.AddDate(0, 0, -1), now, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBackfillRange))
+
+	var disabled *backfillRunner
+	_, err = disabled.start(now.AddDate(0, -1, 0), now.AddDate(0, 0, -1), now, nil)
+	assert.ErrorIs(t, err, ErrBackfillDisabled)
+}
+
+func TestBackfillRunner_Accounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Backfill de contas específicas não consulta nem grava a situação dos blocos, que vale para todas as contas
+	repo := mocks.NewMockBackfillChunkRepository(ctrl)
+	runner := newBackfillRunner(repo, domain.SyncSourceSSOtica)
+
+	now := time.Date(2025, 7, 1, 3, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	accounts, err := backfillAccounts([]*domain.AdAccount{{ID: "a"}, {ID: "b"}, {ID: "c"}}, []string{"b"})
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+
+	_, err = backfillAccounts(accounts, []string{"b", "x"})
+	assert.ErrorIs(t, err, ErrBackfillAccountNotFound)
+
+	chunks, err := runner.start(start, end, now, []string{"b"})
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 2)
+
+	processed := 0
+	runner.run(context.Background(), chunks, accounts, 1, func(_ context.Context, acc *domain.AdAccount, _ []time.Time) bool {
+		assert.Equal(t, "b", acc.ID)
+		processed++
+		return true
+	})
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{"b"}, runner.status()["account_ids"])
+}
@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+)
+
+// BudgetPacingConfig representa a configuração da verificação diária do ritmo de investimento
+type BudgetPacingConfig struct {
+	CronSchedule string
+	Enabled      bool
+}
+
+// BudgetPacingAlert identifica uma conta investindo acima do ritmo ou do orçamento do mês
+type BudgetPacingAlert struct {
+	AccountID string               `json:"account_id"`
+	Nickname  string               `json:"nickname,omitempty"`
+	Pacing    *domain.BudgetPacing `json:"pacing"`
+}
+
+// BudgetPacingService verifica diariamente as contas com orçamento no mês e sinaliza, por webhook,
+// as que estão acima do ritmo esperado ou já ultrapassaram o orçamento
+type BudgetPacingService struct {
+	config      BudgetPacingConfig
+	accountRepo repository.AccountRepository
+	pacer       insighting.BudgetPacer
+	dispatcher  notifying.Dispatcher
+	jobs        *jobRunner
+}
+
+// NewBudgetPacingService cria uma nova instância da verificação de ritmo de investimento
+func NewBudgetPacingService(
+	accountRepo repository.AccountRepository,
+	pacer insighting.BudgetPacer,
+	appConfig *config.Config,
+	opts ...JobOption,
+) *BudgetPacingService {
+	pacingConfig := BudgetPacingConfig{
+		CronSchedule: appConfig.BudgetPacing.CronSchedule,
+		Enabled:      appConfig.BudgetPacing.Enabled,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cron_schedule": pacingConfig.CronSchedule,
+		"enabled":       pacingConfig.Enabled,
+	}).Info("Configuração da verificação de ritmo de investimento carregada")
+
+	return &BudgetPacingService{
+		jobs:        newJobRunner(domain.SchedulerBudgetPacing, nil, opts...),
+		config:      pacingConfig,
+		accountRepo: accountRepo,
+		pacer:       pacer,
+	}
+}
+
+// WithDispatcher habilita a publicação do evento budget.over_pace para os webhooks assinados
+func (s *BudgetPacingService) WithDispatcher(dispatcher notifying.Dispatcher) *BudgetPacingService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *BudgetPacingService) Start(ctx context.Context, scheduler *Scheduler) error {
+	if !s.config.Enabled {
+		logrus.Info("Verificação de ritmo de investimento desabilitada por configuração")
+		return nil
+	}
+
+	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de verificação de ritmo de investimento")
+
+	// O encerramento da aplicação aguarda as execuções em andamento, inclusive a de recuperação
+	s.jobs.runs = scheduler.runs
+
+	err := scheduler.Cron(domain.SchedulerBudgetPacing, s.config.CronSchedule, func() {
+		s.checkPacing(ctx, domain.JobTriggerCron)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao agendar verificação de ritmo de investimento: %w", err)
+	}
+
+	// Executar a verificação perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() { s.checkPacing(ctx, domain.JobTriggerCatchUp) })
+
+	return nil
+}
+
+// checkPacing executa a verificação, se não houver outra em andamento nesta ou em outra instância da API,
+// e retorna as contas acima do ritmo
+func (s *BudgetPacingService) checkPacing(ctx context.Context, trigger string) []*BudgetPacingAlert {
+	var alerts []*BudgetPacingAlert
+	started := s.jobs.run(trigger, nil, func(time.Time) error {
+		var err error
+		alerts, err = s.checkAccountsPacing(ctx)
+		return err
+	})
+	if !started {
+		logrus.Info("Verificação de ritmo de investimento já em andamento, ignorando")
+	}
+	return alerts
+}
+
+// checkAccountsPacing calcula o ritmo das contas ativas e publica as que estão acima do esperado
+func (s *BudgetPacingService) checkAccountsPacing(ctx context.Context) ([]*BudgetPacingAlert, error) {
+	startTime := time.Now()
+
+	accounts, err := s.accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao buscar lista de contas para verificação de ritmo de investimento")
+		return nil, err
+	}
+
+	alerts := make([]*BudgetPacingAlert, 0)
+	checked, failed := 0, 0
+	for _, account := range accounts {
+		pacing, err := s.pacer.GetBudgetPacing(ctx, account.ID)
+		if errors.Is(err, insighting.ErrNoSpendBudget) {
+			continue
+		}
+		if err != nil {
+			failed++
+			logrus.WithError(err).WithField("account_id", account.ID).Error("Erro ao calcular ritmo de investimento da conta")
+			continue
+		}
+
+		checked++
+		if pacing.Status != domain.PaceOver && !pacing.OverBudget {
+			continue
+		}
+
+		alert := &BudgetPacingAlert{AccountID: account.ID, Pacing: pacing}
+		if account.Nickname != nil {
+			alert.Nickname = *account.Nickname
+		}
+		alerts = append(alerts, alert)
+
+		logrus.WithFields(logrus.Fields{
+			"account_id":    account.ID,
+			"status":        pacing.Status,
+			"over_budget":   pacing.OverBudget,
+			"spend_to_date": pacing.SpendToDate,
+			"spend_budget":  pacing.SpendBudget,
+		}).Warn("Conta investindo acima do ritmo do orçamento")
+	}
+
+	if len(alerts) > 0 && s.dispatcher != nil {
+		s.dispatcher.Dispatch(domain.WebhookEventBudgetOverPace, map[string]any{
+			"accounts": alerts,
+		})
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"duration": time.Since(startTime).String(),
+		"checked":  checked,
+		"flagged":  len(alerts),
+		"failed":   failed,
+	}).Info("Verificação de ritmo de investimento concluída")
+
+	return alerts, nil
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"go.uber.org/mock/gomock"
+)
+
+type fakePacer map[string]*domain.BudgetPacing
+
+func (f fakePacer) GetBudgetPacing(_ context.Context, accountID string) (*domain.BudgetPacing, error) {
+	if accountID == "err111" {
+		return nil, errors.New("meta indisponível")
+	}
+	pacing, ok := f[accountID]
+	if !ok {
+		return nil, insighting.ErrNoSpendBudget
+	}
+	return pacing, nil
+}
+
+type recordingDispatcher struct {
+	events []string
+	data   []any
+}
+
+func (d *recordingDispatcher) Dispatch(eventType string, data any) {
+	d.events = append(d.events, eventType)
+	d.data = append(d.data, data)
+}
+
+func TestBudgetPacing_FlagsAccountsOverPace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	accountRepo.EXPECT().ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive}).Return([]*domain.AdAccount{
+		{ID: "aaa111"}, {ID: "bbb222"}, {ID: "ccc333"}, {ID: "ddd444"}, {ID: "err111"},
+	}, nil)
+
+	pacer := fakePacer{
+		"aaa111": {Status: domain.PaceOver},
+		"bbb222": {Status: domain.PaceOnTrack},
+		"ccc333": {Status: domain.PaceUnder, OverBudget: true},
+	}
+	dispatcher := &recordingDispatcher{}
+
+	service := NewBudgetPacingService(accountRepo, pacer, &config.Config{}).WithDispatcher(dispatcher)
+	alerts := service.checkPacing(context.Background(), domain.JobTriggerCron)
+
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "aaa111", alerts[0].AccountID)
+	assert.Equal(t, "ccc333", alerts[1].AccountID)
+	assert.Equal(t, []string{domain.WebhookEventBudgetOverPace}, dispatcher.events)
+
+	// A execução fica no status do jobRunner, como nos agendadores de sincronização
+	lastRun := service.jobs.status()["last_run"].(*domain.Job)
+	assert.Equal(t, domain.JobTriggerCron, lastRun.Trigger)
+	assert.Equal(t, domain.JobStatusSucceeded, lastRun.Status)
+}
+
+func TestBudgetPacingService_CheckPacingLockedByOtherInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Sem o lock, as contas nem são consultadas
+	lockRepo := mocks.NewMockSchedulerLockRepository(ctrl)
+	lockRepo.EXPECT().TryLock(domain.SchedulerBudgetPacing).Return(nil, false, nil)
+
+	service := NewBudgetPacingService(mocks.NewMockAccountRepository(ctrl), fakePacer{}, &config.Config{}, WithInstanceLock(lockRepo))
+	assert.Nil(t, service.checkPacing(context.Background(), domain.JobTriggerCron))
+	assert.Nil(t, service.jobs.status()["last_run"])
+}
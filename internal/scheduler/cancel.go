@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// sleepWithContext aguarda a duração informada entre requisições às APIs externas.
+// Retorna false se o contexto for cancelado antes, para que o job interrompa o trabalho restante.
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// acquireSlot ocupa uma vaga no semáforo de workers, desistindo se o contexto for cancelado
+func acquireSlot(ctx context.Context, semaphore chan struct{}) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case semaphore <- struct{}{}:
+		return true
+	}
+}
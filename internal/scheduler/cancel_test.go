@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type cancelingMetaInsighter struct {
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (c *cancelingMetaInsighter) GetAdAccountMetrics(ctx context.Context, _ string, _ *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
+	c.calls++
+	// Simula o encerramento da aplicação durante a primeira requisição
+	c.cancel()
+	return nil, ctx.Err()
+}
+
+func (c *cancelingMetaInsighter) GetAdAccountDailySnapshots(context.Context, string, *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error) {
+	return nil, nil
+}
+
+func TestSleepWithContext(t *testing.T) {
+	assert.True(t, sleepWithContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, sleepWithContext(ctx, time.Hour))
+	assert.False(t, sleepWithContext(ctx, 0))
+}
+
+func TestAcquireSlot(t *testing.T) {
+	semaphore := make(chan struct{}, 1)
+	assert.True(t, acquireSlot(context.Background(), semaphore))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, acquireSlot(ctx, semaphore))
+}
+
+func TestMetaInsightSync_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	meta := &cancelingMetaInsighter{cancel: cancel}
+	service := &MetaInsightSyncService{metaService: meta, limiter: NewRateLimiter(domain.SyncSourceMeta, 1.0/60, 1, 0)}
+
+	dates := []time.Time{
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		service.processAccountForAllDates(ctx, &domain.AdAccount{ID: "abc123", ExternalID: "999"}, dates)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a sincronização da conta não foi interrompida")
+	}
+	assert.Equal(t, 1, meta.calls)
+}
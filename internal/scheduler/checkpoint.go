@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// checkpointMaxAge descarta checkpoints antigos: depois disso a janela interrompida já saiu do período de interesse
+const checkpointMaxAge = 7 * 24 * time.Hour
+
+// withTimeBox limita a duração da execução; sem limite (d <= 0) o contexto só é cancelado no encerramento da aplicação
+func withTimeBox(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// syncProgress acompanha as contas concluídas em uma execução diária para que, se ela for
+// interrompida, o progresso seja gravado e a execução seguinte retome as contas pendentes.
+type syncProgress struct {
+	mu        sync.Mutex
+	dates     []time.Time
+	resumed   *domain.SyncCheckpoint
+	completed []string
+	startedAt time.Time
+}
+
+// newSyncProgress cria o acompanhamento da execução. Checkpoints mais antigos que checkpointMaxAge são ignorados.
+func newSyncProgress(dates []time.Time, resumed *domain.SyncCheckpoint, now time.Time) *syncProgress {
+	if resumed != nil && now.Sub(resumed.InterruptedAt) > checkpointMaxAge {
+		resumed = nil
+	}
+	return &syncProgress{dates: dates, resumed: resumed, startedAt: now}
+}
+
+// isPending indica se a conta ficou sem sincronizar na execução interrompida
+func (p *syncProgress) isPending(accountID string) bool {
+	return p.resumed != nil && !p.resumed.IsCompleted(accountID)
+}
+
+// pendingFirst coloca as contas pendentes da execução interrompida no início da fila, preservando a ordem das demais
+func (p *syncProgress) pendingFirst(accounts []*domain.AdAccount) []*domain.AdAccount {
+	if p.resumed == nil {
+		return accounts
+	}
+
+	ordered := make([]*domain.AdAccount, len(accounts))
+	copy(ordered, accounts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return p.isPending(ordered[i].ID) && !p.isPending(ordered[j].ID)
+	})
+	return ordered
+}
+
+// datesFor retorna as datas a sincronizar para a conta a partir do período atual no fuso dela (window).
+// Contas pendentes recebem também as datas da janela interrompida que não fazem parte do período.
+func (p *syncProgress) datesFor(accountID string, window []time.Time) []time.Time {
+	dates := make([]time.Time, len(window))
+	copy(dates, window)
+
+	if !p.isPending(accountID) {
+		return dates
+	}
+
+	seen := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		seen[date.Format(time.DateOnly)] = true
+	}
+	for date := p.resumed.StartDate; !date.After(p.resumed.EndDate); date = date.AddDate(0, 0, 1) {
+		if !seen[date.Format(time.DateOnly)] {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// markCompleted registra que a conta foi sincronizada em todas as suas datas
+func (p *syncProgress) markCompleted(accountID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed = append(p.completed, accountID)
+}
+
+// checkpoint monta o progresso a gravar quando a execução é interrompida.
+// A janela cobre o período atual e, se houver, o da execução retomada, limitada a checkpointMaxAge.
+func (p *syncProgress) checkpoint(now time.Time) *domain.SyncCheckpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var start, end time.Time
+	for i, date := range p.dates {
+		day := truncateToDay(date)
+		if i == 0 || day.Before(start) {
+			start = day
+		}
+		if i == 0 || day.After(end) {
+			end = day
+		}
+	}
+
+	if p.resumed != nil {
+		resumedStart := p.resumed.StartDate
+		if oldest := truncateToDay(now.Add(-checkpointMaxAge)); resumedStart.Before(oldest) {
+			resumedStart = oldest
+		}
+		if resumedStart.Before(start) {
+			start = resumedStart
+		}
+		if p.resumed.EndDate.After(end) {
+			end = p.resumed.EndDate
+		}
+	}
+
+	completed := make([]string, len(p.completed))
+	copy(completed, p.completed)
+
+	return &domain.SyncCheckpoint{
+		StartDate:           start,
+		EndDate:             end,
+		CompletedAccountIDs: completed,
+		InterruptedAt:       now,
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestSyncProgress(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	now := time.Date(2025, 3, 10, 3, 0, 0, 0, time.UTC)
+	dates := []time.Time{day(9), day(8), day(7)}
+
+	resumed := &domain.SyncCheckpoint{
+		StartDate:           day(6),
+		EndDate:             day(8),
+		CompletedAccountIDs: []string{"a"},
+		InterruptedAt:       now.Add(-24 * time.Hour),
+	}
+	progress := newSyncProgress(dates, resumed, now)
+
+	accounts := []*domain.AdAccount{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	ordered := progress.pendingFirst(accounts)
+	assert.Equal(t, []string{"b", "c", "a"}, []string{ordered[0].ID, ordered[1].ID, ordered[2].ID})
+
+	// Conta concluída recebe só o período atual; pendente recebe também o dia 6 da janela interrompida
+	assert.Equal(t, dates, progress.datesFor("a", dates))
+	assert.Equal(t, []time.Time{day(9), day(8), day(7), day(6)}, progress.datesFor("b", dates))
+
+	progress.markCompleted("b")
+	checkpoint := progress.checkpoint(now)
+	assert.Equal(t, day(6), checkpoint.StartDate)
+	assert.Equal(t, day(9), checkpoint.EndDate)
+	assert.Equal(t, []string{"b"}, checkpoint.CompletedAccountIDs)
+	assert.True(t, checkpoint.IsCompleted("b"))
+	assert.False(t, checkpoint.IsCompleted("a"))
+}
+
+func TestSyncProgress_IgnoresStaleCheckpoint(t *testing.T) {
+	now := time.Date(2025, 3, 20, 3, 0, 0, 0, time.UTC)
+	dates := []time.Time{time.Date(2025, 3, 19, 0, 0, 0, 0, time.UTC)}
+
+	progress := newSyncProgress(dates, &domain.SyncCheckpoint{
+		StartDate:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+		InterruptedAt: now.Add(-8 * 24 * time.Hour),
+	}, now)
+
+	assert.Nil(t, progress.resumed)
+	assert.Equal(t, dates, progress.datesFor("a", dates))
+}
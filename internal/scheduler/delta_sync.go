@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// deltaDates retorna as datas da sincronização agendada que ainda precisam ser buscadas no Meta: as sem
+// insights armazenados e as coletadas antes de SettleDays dias do fim do dia, quando ainda podiam mudar.
+// No dia de rebusca completa, todas as datas são buscadas.
+func (s *MetaInsightSyncService) deltaDates(acc *domain.AdAccount, dates []time.Time, now time.Time) []time.Time {
+	if !s.config.DeltaEnabled || len(dates) == 0 || now.Weekday() == time.Weekday(s.config.FullRefreshWeekday) {
+		return dates
+	}
+
+	startDate, endDate := dateRange(dates)
+	stored, err := s.adInsightRepo.GetByDateRange(acc.ID, startDate, endDate)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao buscar insights armazenados, buscando todas as datas no Meta")
+		return dates
+	}
+
+	syncedAt := make(map[string]time.Time, len(stored))
+	for _, entry := range stored {
+		syncedAt[entry.Date.Format(time.DateOnly)] = entry.UpdatedAt
+	}
+
+	pending := pendingDates(dates, syncedAt, s.config.SettleDays)
+	s.stats.recordCacheHits(domain.SyncSourceMeta, len(dates)-len(pending))
+	logDelta(acc.ID, "Meta", len(dates), len(pending))
+	return pending
+}
+
+// deltaDates retorna as datas da sincronização agendada que ainda precisam ser buscadas no SSOtica: as sem
+// vendas armazenadas e as coletadas antes de SettleDays dias do fim do dia, quando ainda podiam mudar
+func (s *SSOticaInsightSyncService) deltaDates(acc *domain.AdAccount, dates []time.Time) []time.Time {
+	if !s.config.DeltaEnabled || len(dates) == 0 {
+		return dates
+	}
+
+	startDate, endDate := dateRange(dates)
+	stored, err := s.salesInsightRepo.GetByDateRange(acc.ID, startDate, endDate)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao buscar vendas armazenadas, buscando todas as datas no SSOtica")
+		return dates
+	}
+
+	syncedAt := make(map[string]time.Time, len(stored))
+	for _, entry := range stored {
+		syncedAt[entry.Date.Format(time.DateOnly)] = entry.UpdatedAt
+	}
+
+	pending := pendingDates(dates, syncedAt, s.config.SettleDays)
+	s.stats.recordCacheHits(domain.SyncSourceSSOtica, len(dates)-len(pending))
+	logDelta(acc.ID, "SSOtica", len(dates), len(pending))
+	return pending
+}
+
+// pendingDates retorna as datas sem dados armazenados ou cujos dados foram gravados antes de settleDays dias
+// completos depois da data. syncedAt traz a última gravação de cada data armazenada, pela data (YYYY-MM-DD).
+func pendingDates(dates []time.Time, syncedAt map[string]time.Time, settleDays int) []time.Time {
+	pending := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		updatedAt, ok := syncedAt[date.Format(time.DateOnly)]
+		if ok && !updatedAt.Before(date.AddDate(0, 0, settleDays+1)) {
+			continue
+		}
+		pending = append(pending, date)
+	}
+	return pending
+}
+
+// dateRange retorna a menor e a maior data
+func dateRange(dates []time.Time) (time.Time, time.Time) {
+	startDate, endDate := dates[0], dates[0]
+	for _, date := range dates {
+		if date.Before(startDate) {
+			startDate = date
+		}
+		if date.After(endDate) {
+			endDate = date
+		}
+	}
+	return startDate, endDate
+}
+
+func logDelta(accountID, provider string, total, pending int) {
+	logrus.WithFields(logrus.Fields{
+		"account_id":    accountID,
+		"total_dates":   total,
+		"pending_dates": pending,
+	}).Info("Sincronização delta de insights do " + provider)
+}
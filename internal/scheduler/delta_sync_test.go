@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPendingDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	dates := []time.Time{day(1), day(2), day(3), day(4)}
+	syncedAt := map[string]time.Time{
+		// gravado depois de consolidado
+		"2025-03-01": time.Date(2025, 3, 5, 3, 0, 0, 0, time.UTC),
+		// gravado no limite da consolidação
+		"2025-03-02": day(6),
+		// gravado antes de consolidado
+		"2025-03-03": time.Date(2025, 3, 6, 23, 0, 0, 0, time.UTC),
+	}
+
+	// dia 4 sem dados armazenados
+	assert.Equal(t, []time.Time{day(3), day(4)}, pendingDates(dates, syncedAt, 3))
+	assert.Equal(t, dates, pendingDates(dates, nil, 3))
+}
+
+func TestMetaDeltaDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	dates := []time.Time{day(3), day(1), day(2)}
+	account := &domain.AdAccount{ID: "abc123"}
+	monday := time.Date(2025, 3, 10, 3, 0, 0, 0, time.UTC)
+	config := MetaInsightSyncConfig{DeltaEnabled: true, SettleDays: 3, FullRefreshWeekday: int(time.Sunday)}
+
+	t.Run("busca apenas as datas pendentes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockAdInsightRepository(ctrl)
+		stats := newRunStats()
+		service := &MetaInsightSyncService{adInsightRepo: repo, config: config, stats: stats}
+
+		repo.EXPECT().GetByDateRange("abc123", day(1), day(3)).Return([]*domain.AdInsightEntry{
+			{Date: day(1), UpdatedAt: day(8)},
+			{Date: day(2), UpdatedAt: day(3)},
+		}, nil)
+
+		assert.Equal(t, []time.Time{day(3), day(2)}, service.deltaDates(account, dates, monday))
+		assert.Equal(t, int64(1), stats.snapshot()[domain.SyncSourceMeta].CacheHits)
+	})
+
+	t.Run("busca tudo se não for possível consultar o banco", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockAdInsightRepository(ctrl)
+		service := &MetaInsightSyncService{adInsightRepo: repo, config: config}
+
+		repo.EXPECT().GetByDateRange("abc123", day(1), day(3)).Return(nil, errors.New("conexão recusada"))
+		assert.Equal(t, dates, service.deltaDates(account, dates, monday))
+	})
+
+	t.Run("busca tudo no dia de atualização completa", func(t *testing.T) {
+		service := &MetaInsightSyncService{config: config}
+		assert.Equal(t, dates, service.deltaDates(account, dates, monday.AddDate(0, 0, -1)))
+	})
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// datesToRefresh retorna as datas da conta que precisam ser rebuscadas no Meta.
+// Na sincronização incremental uma única consulta diária (time_increment=1) é comparada com os insights
+// armazenados e só os dias alterados são rebuscados por completo. Resultados atribuídos com atraso podem
+// mudar sem alterar investimento, impressões ou alcance, por isso um dia da semana faz a rebusca completa.
+// Qualquer falha na comparação também resulta na rebusca completa.
+func (s *MetaInsightSyncService) datesToRefresh(ctx context.Context, acc *domain.AdAccount, dates []time.Time, now time.Time) []time.Time {
+	if !s.config.IncrementalEnabled || len(dates) == 0 {
+		return dates
+	}
+
+	if now.Weekday() == time.Weekday(s.config.FullRefreshWeekday) {
+		logrus.WithField("account_id", acc.ID).Debug("Dia de rebusca completa dos insights do Meta")
+		return dates
+	}
+
+	startDate, endDate := dateRange(dates)
+
+	if err := s.limiter.wait(ctx); err != nil {
+		return dates
+	}
+	snapshots, err := s.metaService.GetAdAccountDailySnapshots(ctx, acc.ExternalID, &domain.InsigthFilters{
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	})
+	s.stats.recordCall(domain.SyncSourceMeta, err)
+	s.limiter.observe(err)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao obter resumo diário do Meta, rebuscando todas as datas")
+		return dates
+	}
+
+	stored, err := s.adInsightRepo.GetByDateRange(acc.ID, startDate, endDate)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao buscar insights armazenados, rebuscando todas as datas")
+		return dates
+	}
+
+	changed := changedDates(dates, snapshots, stored)
+	// Dias inalterados reaproveitam os insights já armazenados
+	s.stats.recordCacheHits(domain.SyncSourceMeta, len(dates)-len(changed))
+
+	logrus.WithFields(logrus.Fields{
+		"account_id":    acc.ID,
+		"total_dates":   len(dates),
+		"changed_dates": len(changed),
+	}).Info("Sincronização incremental de insights do Meta")
+
+	return changed
+}
+
+// changedDates compara o resumo diário do Meta com os insights armazenados e retorna as datas divergentes.
+// Dias sem veiculação não aparecem no resumo: só são rebuscados se houver métricas armazenadas para eles.
+func changedDates(dates []time.Time, snapshots []*domain.AdDailySnapshot, stored []*domain.AdInsightEntry) []time.Time {
+	snapshotByDate := make(map[string]*domain.AdDailySnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotByDate[snapshot.Date.Format(time.DateOnly)] = snapshot
+	}
+
+	storedByDate := make(map[string]*domain.AdInsightEntry, len(stored))
+	for _, entry := range stored {
+		storedByDate[entry.Date.Format(time.DateOnly)] = entry
+	}
+
+	changed := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		key := date.Format(time.DateOnly)
+		snapshot, hasSnapshot := snapshotByDate[key]
+		entry, hasEntry := storedByDate[key]
+
+		switch {
+		case !hasSnapshot && (!hasEntry || entry.AdMetrics.IsEmpty()):
+			continue
+		case hasSnapshot && hasEntry && snapshot.Matches(entry.AdMetrics):
+			continue
+		}
+
+		changed = append(changed, date)
+	}
+
+	return changed
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type fakeDailySnapshotter struct {
+	snapshots []*domain.AdDailySnapshot
+	err       error
+	calls     int
+}
+
+func (f *fakeDailySnapshotter) GetAdAccountMetrics(context.Context, string, *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
+	return nil, nil
+}
+
+func (f *fakeDailySnapshotter) GetAdAccountDailySnapshots(context.Context, string, *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error) {
+	f.calls++
+	return f.snapshots, f.err
+}
+
+func TestChangedDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	metrics := func(spend float64, impressions, reach int) *domain.AdAccountMetrics {
+		return &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: spend, Impressions: impressions, Reach: reach}}
+	}
+
+	dates := []time.Time{day(1), day(2), day(3), day(4), day(5)}
+	snapshots := []*domain.AdDailySnapshot{
+		{Date: day(1), Spend: 10.5, Impressions: 100, Reach: 80},
+		{Date: day(2), Spend: 20, Impressions: 200, Reach: 150},
+		{Date: day(3), Spend: 30, Impressions: 300, Reach: 250},
+	}
+	stored := []*domain.AdInsightEntry{
+		{Date: day(1), AdMetrics: metrics(10.499, 100, 80)},
+		{Date: day(2), AdMetrics: metrics(18, 190, 150)},
+		{Date: day(4), AdMetrics: metrics(5, 40, 30)},
+	}
+
+	// dia 1 igual, dia 2 alterado, dia 3 não armazenado, dia 4 zerado no Meta, dia 5 sem dados nos dois lados
+	assert.Equal(t, []time.Time{day(2), day(3), day(4)}, changedDates(dates, snapshots, stored))
+}
+
+func TestDatesToRefresh(t *testing.T) {
+	dates := []time.Time{time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)}
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "999"}
+	sunday := time.Date(2025, 3, 9, 3, 0, 0, 0, time.UTC)
+
+	t.Run("rebusca tudo no dia de atualização completa", func(t *testing.T) {
+		meta := &fakeDailySnapshotter{}
+		service := &MetaInsightSyncService{metaService: meta, config: MetaInsightSyncConfig{IncrementalEnabled: true, FullRefreshWeekday: int(time.Sunday)}}
+
+		assert.Equal(t, dates, service.datesToRefresh(context.Background(), account, dates, sunday))
+		assert.Zero(t, meta.calls)
+	})
+
+	t.Run("rebusca tudo quando o resumo diário falha", func(t *testing.T) {
+		meta := &fakeDailySnapshotter{err: errors.New("timeout")}
+		service := &MetaInsightSyncService{metaService: meta, config: MetaInsightSyncConfig{IncrementalEnabled: true, FullRefreshWeekday: int(time.Sunday)}}
+
+		assert.Equal(t, dates, service.datesToRefresh(context.Background(), account, dates, sunday.AddDate(0, 0, 1)))
+		assert.Equal(t, 1, meta.calls)
+	})
+
+	t.Run("ignora a comparação com a sincronização incremental desabilitada", func(t *testing.T) {
+		meta := &fakeDailySnapshotter{}
+		service := &MetaInsightSyncService{metaService: meta}
+
+		assert.Equal(t, dates, service.datesToRefresh(context.Background(), account, dates, sunday.AddDate(0, 0, 1)))
+		assert.Zero(t, meta.calls)
+	})
+}
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+)
+
+// IntradaySyncConfig representa a configuração da sincronização do dia em andamento
+type IntradaySyncConfig struct {
+	CronSchedule string
+	SyncEnabled  bool
+}
+
+// IntradaySyncService atualiza, ao longo do dia, as métricas parciais do dia em andamento do Meta e do SSOtica.
+// Os insights diários só guardam dias encerrados; as métricas do dia ficam em um armazenamento separado,
+// combinado aos insights diários nas consultas dos dashboards.
+type IntradaySyncService struct {
+	config         IntradaySyncConfig
+	accountRepo    repository.AccountRepository
+	intradayRepo   repository.IntradayInsightRepository
+	metaService    insighting.MetaInsighter
+	ssoticaService insighting.SSOticaInsighter
+	prioritizer    activity.Prioritizer
+	metaLimiter    *RateLimiter
+	ssoticaLimiter *RateLimiter
+	jobs           *jobRunner
+	now            func() time.Time
+}
+
+// NewIntradaySyncService cria uma nova instância da sincronização do dia em andamento
+func NewIntradaySyncService(
+	accountRepo repository.AccountRepository,
+	intradayRepo repository.IntradayInsightRepository,
+	metaService insighting.MetaInsighter,
+	ssoticaService insighting.SSOticaInsighter,
+	appConfig *config.Config,
+	opts ...JobOption,
+) *IntradaySyncService {
+	intradayConfig := IntradaySyncConfig{
+		CronSchedule: appConfig.IntradaySync.CronSchedule,
+		SyncEnabled:  appConfig.IntradaySync.Enabled,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cron_schedule": intradayConfig.CronSchedule,
+		"sync_enabled":  intradayConfig.SyncEnabled,
+		"max_age":       appConfig.IntradaySync.MaxAge.String(),
+	}).Info("Configuração da sincronização intradiária carregada")
+
+	return &IntradaySyncService{
+		jobs:           newJobRunner(domain.SchedulerIntradaySync, nil, opts...),
+		config:         intradayConfig,
+		accountRepo:    accountRepo,
+		intradayRepo:   intradayRepo,
+		metaService:    metaService,
+		ssoticaService: ssoticaService,
+		now:            time.Now,
+	}
+}
+
+// WithPrioritizer atualiza primeiro as contas VIP e as mais acompanhadas
+func (s *IntradaySyncService) WithPrioritizer(prioritizer activity.Prioritizer) *IntradaySyncService {
+	s.prioritizer = prioritizer
+	return s
+}
+
+// WithRateLimiters compartilha com as demais sincronizações os limites de requisições do Meta e do SSOtica
+func (s *IntradaySyncService) WithRateLimiters(meta, ssotica *RateLimiter) *IntradaySyncService {
+	s.metaLimiter = meta
+	s.ssoticaLimiter = ssotica
+	return s
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação. Uma execução perdida não é recuperada:
+// a próxima hora já traz os dados atualizados.
+func (s *IntradaySyncService) Start(ctx context.Context, scheduler *Scheduler) error {
+	if !s.config.SyncEnabled {
+		logrus.Info("Sincronização intradiária desabilitada por configuração")
+		return nil
+	}
+
+	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de sincronização intradiária")
+
+	// O encerramento da aplicação aguarda as execuções em andamento
+	s.jobs.runs = scheduler.runs
+
+	err := scheduler.Cron(domain.SchedulerIntradaySync, s.config.CronSchedule, func() {
+		s.syncToday(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao agendar sincronização intradiária: %w", err)
+	}
+
+	return nil
+}
+
+// syncToday executa a sincronização, se não houver outra em andamento nesta ou em outra instância da API
+func (s *IntradaySyncService) syncToday(ctx context.Context) {
+	started := s.jobs.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		return s.syncAccountsToday(ctx)
+	})
+	if !started {
+		logrus.Info("Sincronização intradiária já em andamento, ignorando")
+	}
+}
+
+// syncAccountsToday grava as métricas do dia em andamento, no fuso de cada conta, de todas as contas ativas
+func (s *IntradaySyncService) syncAccountsToday(ctx context.Context) error {
+	startTime := s.now()
+
+	// Os dias anteriores já estão nos insights diários; a margem de um dia cobre os fusos das contas
+	if removed, err := s.intradayRepo.DeleteBefore(startTime.AddDate(0, 0, -1)); err != nil {
+		logrus.WithError(err).Warn("Erro ao remover insights intradiários de dias anteriores")
+	} else if removed > 0 {
+		logrus.WithField("removed", removed).Info("Insights intradiários de dias anteriores removidos")
+	}
+
+	accounts, err := s.accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao buscar lista de contas para sincronização intradiária")
+		return err
+	}
+	accounts = prioritizeAccounts(s.prioritizer, accounts)
+
+	updated, failed := 0, 0
+	for _, acc := range accounts {
+		if ctx.Err() != nil {
+			logrus.Warn("Sincronização intradiária interrompida pelo encerramento da aplicação")
+			break
+		}
+
+		today := acc.Today(s.now())
+		if acc.ExternalID != "" {
+			if err := s.syncAdMetrics(ctx, acc, today); err != nil {
+				failed++
+				logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao sincronizar insights intradiários do Meta")
+			} else {
+				updated++
+			}
+		}
+		if acc.CNPJ != nil && *acc.CNPJ != "" && acc.SecretName != nil && *acc.SecretName != "" {
+			if err := s.syncSalesMetrics(ctx, acc, today); err != nil {
+				failed++
+				logrus.WithError(err).WithField("account_id", acc.ID).Warn("Erro ao sincronizar insights intradiários do SSOtica")
+			} else {
+				updated++
+			}
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"duration": time.Since(startTime).String(),
+		"accounts": len(accounts),
+		"updated":  updated,
+		"failed":   failed,
+	}).Info("Sincronização intradiária concluída")
+
+	return ctx.Err()
+}
+
+// syncAdMetrics grava as métricas do Meta da conta no dia em andamento
+func (s *IntradaySyncService) syncAdMetrics(ctx context.Context, acc *domain.AdAccount, today time.Time) error {
+	if err := s.metaLimiter.wait(ctx); err != nil {
+		return err
+	}
+	adMetrics, err := s.metaService.GetAdAccountMetrics(ctx, acc.ExternalID, &domain.InsigthFilters{StartDate: &today, EndDate: &today})
+	s.metaLimiter.observe(err)
+	if err != nil {
+		return err
+	}
+	if adMetrics == nil {
+		return nil
+	}
+
+	return s.intradayRepo.SaveAdInsight(&domain.AdInsightEntry{
+		AccountID:  acc.ID,
+		ExternalID: acc.ExternalID,
+		Date:       today,
+		AdMetrics:  adMetrics,
+		UpdatedAt:  s.now(),
+	})
+}
+
+// syncSalesMetrics grava as métricas do SSOtica da conta no dia em andamento
+func (s *IntradaySyncService) syncSalesMetrics(ctx context.Context, acc *domain.AdAccount, today time.Time) error {
+	if err := s.ssoticaLimiter.wait(ctx); err != nil {
+		return err
+	}
+	salesMetrics, err := s.ssoticaService.GetSalesMetrics(ctx, *acc.CNPJ, *acc.SecretName, &domain.InsigthFilters{StartDate: &today, EndDate: &today})
+	s.ssoticaLimiter.observe(err)
+	if err != nil {
+		return err
+	}
+	if len(salesMetrics) == 0 {
+		return nil
+	}
+
+	return s.intradayRepo.SaveSalesInsight(&domain.SalesInsightEntry{
+		AccountID:    acc.ID,
+		Date:         today,
+		SalesMetrics: salesMetrics,
+		UpdatedAt:    s.now(),
+	})
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeIntradayInsighter struct {
+	metaCalls []string
+}
+
+func (f *fakeIntradayInsighter) GetAdAccountMetrics(_ context.Context, accountID string, _ *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
+	f.metaCalls = append(f.metaCalls, accountID)
+	return &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 42}}, nil
+}
+
+func (f *fakeIntradayInsighter) GetAdAccountDailySnapshots(context.Context, string, *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error) {
+	return nil, nil
+}
+
+func (f *fakeIntradayInsighter) GetSalesMetrics(context.Context, string, string, *domain.InsigthFilters) (map[string]*domain.SalesMetrics, error) {
+	return map[string]*domain.SalesMetrics{domain.SocialNetwork: {}}, nil
+}
+
+func TestIntradaySyncService_SyncToday(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	intradayRepo := mocks.NewMockIntradayInsightRepository(ctrl)
+	insighter := &fakeIntradayInsighter{}
+
+	service := NewIntradaySyncService(accountRepo, intradayRepo, insighter, insighter, &config.Config{})
+	now := time.Date(2025, 3, 2, 2, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	cnpj, secret := "12345678000199", "loja-centro"
+	accounts := []*domain.AdAccount{
+		{ID: "aaa111", ExternalID: "111"},
+		{ID: "bbb222", ExternalID: "222", CNPJ: &cnpj, SecretName: &secret, VIP: true},
+	}
+	accountRepo.EXPECT().ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive}).Return(accounts, nil)
+	intradayRepo.EXPECT().DeleteBefore(now.AddDate(0, 0, -1)).Return(int64(3), nil)
+
+	// 2h UTC ainda é o dia 1º no fuso padrão das contas
+	today := time.Date(2025, 3, 1, 0, 0, 0, 0, accounts[0].TimeLocation())
+	intradayRepo.EXPECT().SaveAdInsight(gomock.Any()).DoAndReturn(func(insight *domain.AdInsightEntry) error {
+		assert.Equal(t, today, insight.Date)
+		assert.Equal(t, now, insight.UpdatedAt)
+		assert.Equal(t, 42.0, insight.AdMetrics.Spend)
+		return nil
+	}).Times(2)
+	intradayRepo.EXPECT().SaveSalesInsight(gomock.Any()).DoAndReturn(func(insight *domain.SalesInsightEntry) error {
+		assert.Equal(t, "bbb222", insight.AccountID)
+		assert.Equal(t, today, insight.Date)
+		return nil
+	})
+
+	service.syncToday(context.Background())
+
+	// A conta VIP é atualizada primeiro
+	assert.Equal(t, []string{"222", "111"}, insighter.metaCalls)
+}
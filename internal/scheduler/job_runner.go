@@ -0,0 +1,434 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+)
+
+// jobRunner concentra o que os agendadores têm em comum: uma execução por vez, o status exibido em
+// GetStatus, o registro no runHistory (recuperação de execuções perdidas) e as estatísticas por provedor.
+// Com um JobRepository, cada execução também é persistida na tabela jobs com a origem, os parâmetros, o
+// status, o início, o fim, o erro e o resultado de cada conta (job_accounts). Com um Dispatcher, o fim de
+// cada execução é publicado no evento sync.finished dos webhooks.
+type jobRunner struct {
+	jobType    string
+	repo       repository.JobRepository
+	history    *runHistory
+	stats      *runStats
+	lock       *instanceLock
+	pauses     *pauseChecker
+	runs       *inFlight
+	dispatcher notifying.Dispatcher
+	now        func() time.Time
+
+	mu       sync.Mutex
+	running  bool
+	accounts map[string]*domain.JobAccount
+	lastJob  *domain.Job
+	// total e current alimentam o progresso da execução em andamento: o total de contas e as contas em
+	// processamento, com o início de cada uma
+	total   int
+	current map[string]time.Time
+}
+
+// accountResult é o resultado da sincronização de uma conta: processed dias (ou meses) processados,
+// failed com falha, a última em err. skipped informa o motivo de a conta não ter sido sincronizada.
+type accountResult struct {
+	processed int
+	failed    int
+	err       error
+	skipped   string
+}
+
+// JobOption configura uma dependência opcional do jobRunner, comum a todos os agendadores
+type JobOption func(*jobRunner)
+
+// WithRunHistory habilita o registro das execuções e a recuperação, na inicialização, de uma execução perdida
+func WithRunHistory(repo repository.SchedulerRunRepository, catchUp config.SchedulerCatchUp) JobOption {
+	return func(r *jobRunner) {
+		r.history = newRunHistory(repo, r.jobType, catchUp)
+	}
+}
+
+// WithInstanceLock faz apenas uma instância da API executar o agendador por vez, com um advisory lock no Postgres
+func WithInstanceLock(repo repository.SchedulerLockRepository) JobOption {
+	return func(r *jobRunner) {
+		r.lock = newInstanceLock(repo)
+	}
+}
+
+// WithPauses faz o agendador respeitar a pausa gravada pelas rotas de administração, lida a cada execução
+func WithPauses(repo repository.SchedulerPauseRepository) JobOption {
+	return func(r *jobRunner) {
+		r.pauses = newPauseChecker(repo)
+	}
+}
+
+// WithJobRepository habilita o histórico de execuções na tabela jobs
+func WithJobRepository(repo repository.JobRepository) JobOption {
+	return func(r *jobRunner) {
+		r.repo = repo
+	}
+}
+
+func newJobRunner(jobType string, stats *runStats, opts ...JobOption) *jobRunner {
+	r := &jobRunner{
+		jobType: jobType,
+		stats:   stats,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// run executa fn se o job não estiver pausado nem em andamento, nesta ou em outra instância da API,
+// e informa se ela foi executada. O retorno de fn define o status: nil conclui com sucesso; o cancelamento
+// do contexto (encerramento da aplicação ou limite de duração) marca a execução como interrompida; os
+// demais erros, como falha. Durante o encerramento da aplicação, novas execuções não começam.
+func (r *jobRunner) run(trigger string, params map[string]any, fn func(startedAt time.Time) error) bool {
+	if r.isPaused() {
+		logrus.WithFields(logrus.Fields{"scheduler": r.jobType, "trigger": trigger}).Info("Agendador pausado, execução ignorada")
+		return false
+	}
+
+	done, ok := r.runs.begin(r.jobType)
+	if !ok {
+		logrus.WithFields(logrus.Fields{"scheduler": r.jobType, "trigger": trigger}).Info("Aplicação em encerramento, execução ignorada")
+		return false
+	}
+	defer done()
+
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return false
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	unlock, acquired := r.lock.acquire(r.jobType)
+	if !acquired {
+		r.mu.Lock()
+		r.running = false
+		r.mu.Unlock()
+		return false
+	}
+	defer unlock()
+
+	r.mu.Lock()
+	startedAt := r.now()
+	job := &domain.Job{
+		Type:      r.jobType,
+		Trigger:   trigger,
+		Params:    params,
+		Status:    domain.JobStatusRunning,
+		StartedAt: startedAt,
+	}
+	r.accounts = make(map[string]*domain.JobAccount)
+	r.total = 0
+	r.current = make(map[string]time.Time)
+	r.mu.Unlock()
+
+	r.createJob(job)
+	r.mu.Lock()
+	r.lastJob = job
+	r.mu.Unlock()
+
+	r.history.markStarted(startedAt)
+	r.stats.reset()
+
+	// Libera o job mesmo se fn entrar em pânico, para que as próximas execuções não fiquem bloqueadas
+	var err error
+	finished := false
+	defer func() {
+		if !finished {
+			err = errors.New("execução encerrada por pânico")
+		}
+		r.finish(job, err)
+	}()
+
+	err = fn(startedAt)
+	finished = true
+	return true
+}
+
+// finish grava o resultado da execução e libera o job
+func (r *jobRunner) finish(job *domain.Job, err error) {
+	finishedAt := r.now()
+	stats := r.stats.snapshot()
+
+	r.mu.Lock()
+	accounts := make([]*domain.JobAccount, 0, len(r.accounts))
+	summary := &domain.JobAccountSummary{}
+	for _, account := range r.accounts {
+		accounts = append(accounts, account)
+		summary.Add(account.Status)
+	}
+	r.accounts = nil
+	r.current = nil
+	r.mu.Unlock()
+
+	// O job também é lido por status(), então o resultado é montado em uma cópia
+	finished := *job
+	job = &finished
+	job.FinishedAt = &finishedAt
+	job.Stats = stats
+	job.Accounts = summary
+
+	switch {
+	case err == nil:
+		job.Status = domain.JobStatusSucceeded
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		job.Status = domain.JobStatusInterrupted
+		job.Error = err.Error()
+	default:
+		job.Status = domain.JobStatusFailed
+		job.Error = err.Error()
+	}
+
+	r.history.saveStats(job.Stats)
+	if job.Status == domain.JobStatusSucceeded {
+		r.history.markSucceeded(job.StartedAt)
+	}
+	r.finishJob(job, accounts)
+
+	r.mu.Lock()
+	r.running = false
+	r.lastJob = job
+	r.mu.Unlock()
+
+	r.notifyFinished(job)
+}
+
+// notifyFinished publica o resultado da execução para os webhooks assinados em sync.finished
+func (r *jobRunner) notifyFinished(job *domain.Job) {
+	if r.dispatcher == nil {
+		return
+	}
+
+	r.dispatcher.Dispatch(domain.WebhookEventSyncFinished, map[string]any{
+		"job_id":           job.ID,
+		"scheduler":        job.Type,
+		"trigger":          job.Trigger,
+		"status":           job.Status,
+		"error":            job.Error,
+		"started_at":       job.StartedAt,
+		"finished_at":      job.FinishedAt,
+		"duration_seconds": int64(job.FinishedAt.Sub(job.StartedAt).Seconds()),
+		"accounts":         job.Accounts,
+		"stats":            job.Stats,
+	})
+}
+
+// recordAccount registra o resultado de uma conta na execução em andamento. Resultados da mesma conta
+// (ex: um por mês na sincronização mensal) são somados, prevalecendo a falha. Fora de uma execução (agendas
+// por conta, backfill) não registra nada.
+func (r *jobRunner) recordAccount(accountID string, result accountResult) {
+	if r == nil {
+		return
+	}
+
+	status, reason := domain.JobAccountSucceeded, ""
+	switch {
+	case errors.Is(result.err, context.Canceled) || errors.Is(result.err, context.DeadlineExceeded):
+		status, reason = domain.JobAccountInterrupted, result.err.Error()
+	case result.failed > 0 || result.err != nil:
+		status = domain.JobAccountFailed
+		if result.err != nil {
+			reason = result.err.Error()
+		}
+	case result.processed == 0 && result.skipped != "":
+		status, reason = domain.JobAccountSkipped, result.skipped
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.accounts == nil {
+		return
+	}
+	delete(r.current, accountID)
+
+	account, ok := r.accounts[accountID]
+	if !ok {
+		r.accounts[accountID] = &domain.JobAccount{
+			AccountID:   accountID,
+			Status:      status,
+			Items:       result.processed,
+			FailedItems: result.failed,
+			Reason:      reason,
+		}
+		return
+	}
+
+	account.Items += result.processed
+	account.FailedItems += result.failed
+	if accountStatusPriority[status] > accountStatusPriority[account.Status] {
+		account.Status, account.Reason = status, reason
+	}
+}
+
+// setTotal informa o total de contas da execução em andamento, base do progresso exibido em GetStatus
+func (r *jobRunner) setTotal(total int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.accounts != nil {
+		r.total = total
+	}
+}
+
+// startAccount marca a conta como em processamento até o registro do seu resultado
+func (r *jobRunner) startAccount(accountID string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil {
+		r.current[accountID] = r.now()
+	}
+}
+
+// progress calcula o andamento da execução: contas com resultado sobre o total, as contas em processamento
+// e a previsão de término pelo tempo médio das contas concluídas. Deve ser chamado com r.mu travado.
+func (r *jobRunner) progress(startedAt, now time.Time) *domain.JobProgress {
+	progress := &domain.JobProgress{
+		Total:           r.total,
+		Processed:       len(r.accounts),
+		CurrentAccounts: make([]string, 0, len(r.current)),
+	}
+	if progress.Total < progress.Processed {
+		progress.Total = progress.Processed
+	}
+
+	for accountID := range r.current {
+		progress.CurrentAccounts = append(progress.CurrentAccounts, accountID)
+	}
+	sort.Slice(progress.CurrentAccounts, func(i, j int) bool {
+		return r.current[progress.CurrentAccounts[i]].Before(r.current[progress.CurrentAccounts[j]])
+	})
+
+	if progress.Total == 0 {
+		return progress
+	}
+	progress.Percent = math.Round(float64(progress.Processed)*1000/float64(progress.Total)) / 10
+
+	if progress.Processed > 0 && progress.Processed < progress.Total {
+		elapsed := now.Sub(startedAt)
+		remaining := time.Duration(float64(elapsed) / float64(progress.Processed) * float64(progress.Total-progress.Processed))
+		eta := now.Add(remaining)
+		progress.ETA = &eta
+		progress.RemainingSeconds = int64(remaining.Seconds())
+	}
+	return progress
+}
+
+// accountStatusPriority define o resultado que prevalece quando a conta tem mais de um na execução
+var accountStatusPriority = map[string]int{
+	domain.JobAccountSkipped:     0,
+	domain.JobAccountSucceeded:   1,
+	domain.JobAccountInterrupted: 2,
+	domain.JobAccountFailed:      3,
+}
+
+// isPaused informa se o job foi pausado pelas rotas de administração
+func (r *jobRunner) isPaused() bool {
+	return r.pauses.isPaused(r.jobType)
+}
+
+// isRunning informa se há uma execução do job em andamento
+func (r *jobRunner) isRunning() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
+
+// status retorna os campos comuns do GetStatus dos agendadores: se há execução em andamento e a última
+// execução desde a inicialização, com o resumo das contas. O histórico fica em /v1/admin/sync/runs.
+func (r *jobRunner) status() map[string]any {
+	paused := r.isPaused()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var lastRun *domain.Job
+	var progress *domain.JobProgress
+	if r.lastJob != nil {
+		job := *r.lastJob
+		if r.running {
+			job.Stats = r.stats.snapshot()
+			job.Accounts = &domain.JobAccountSummary{}
+			for _, account := range r.accounts {
+				job.Accounts.Add(account.Status)
+			}
+			progress = r.progress(job.StartedAt, r.now())
+		}
+		lastRun = &job
+	}
+
+	return map[string]any{
+		"sync_running": r.running,
+		"sync_paused":  paused,
+		"progress":     progress,
+		"last_run":     lastRun,
+	}
+}
+
+// interruptAbandoned marca como interrompidas as execuções que ficaram em andamento quando a aplicação
+// foi encerrada. Deve ser chamado na inicialização, antes da primeira execução.
+func (r *jobRunner) interruptAbandoned() {
+	if r.repo == nil {
+		return
+	}
+
+	// Com o job em execução em outra instância, as execuções "running" não foram abandonadas
+	unlock, acquired := r.lock.acquire(r.jobType)
+	if !acquired {
+		return
+	}
+	defer unlock()
+
+	count, err := r.repo.InterruptRunningJobs(r.jobType, r.now())
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", r.jobType).Error("Erro ao marcar execuções interrompidas do agendador")
+		return
+	}
+	if count > 0 {
+		logrus.WithFields(logrus.Fields{"scheduler": r.jobType, "jobs": count}).Warn("Execuções interrompidas pelo encerramento da aplicação")
+	}
+}
+
+func (r *jobRunner) createJob(job *domain.Job) {
+	if r.repo == nil {
+		return
+	}
+	if err := r.repo.CreateJob(job); err != nil {
+		logrus.WithError(err).WithField("scheduler", r.jobType).Error("Erro ao registrar execução do agendador")
+	}
+}
+
+func (r *jobRunner) finishJob(job *domain.Job, accounts []*domain.JobAccount) {
+	if r.repo == nil || job.ID == 0 {
+		return
+	}
+	if err := r.repo.SaveJobAccounts(job.ID, accounts); err != nil {
+		logrus.WithError(err).WithField("scheduler", r.jobType).Error("Erro ao registrar resultado das contas na execução do agendador")
+	}
+	if err := r.repo.FinishJob(job); err != nil {
+		logrus.WithError(err).WithField("scheduler", r.jobType).Error("Erro ao finalizar execução do agendador")
+	}
+}
@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJobRunner_Status(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus string
+		wantError  string
+	}{
+		{
+			name:       "concluída",
+			wantStatus: domain.JobStatusSucceeded,
+		},
+		{
+			name:       "falha",
+			err:        errors.New("erro ao buscar contas"),
+			wantStatus: domain.JobStatusFailed,
+			wantError:  "erro ao buscar contas",
+		},
+		{
+			name:       "limite de duração atingido",
+			err:        fmt.Errorf("sincronização: %w", context.DeadlineExceeded),
+			wantStatus: domain.JobStatusInterrupted,
+			wantError:  "sincronização: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockJobRepository(ctrl)
+			runner := newJobRunner(domain.SchedulerMetaInsightSync, newRunStats())
+			runner.repo = repo
+
+			repo.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *domain.Job) error {
+				assert.Equal(t, domain.SchedulerMetaInsightSync, job.Type)
+				assert.Equal(t, domain.JobTriggerManual, job.Trigger)
+				assert.Equal(t, domain.JobStatusRunning, job.Status)
+				job.ID = 7
+				return nil
+			})
+			repo.EXPECT().SaveJobAccounts(int64(7), gomock.Any()).DoAndReturn(func(_ int64, accounts []*domain.JobAccount) error {
+				assert.Len(t, accounts, 1)
+				return nil
+			})
+			repo.EXPECT().FinishJob(gomock.Any()).DoAndReturn(func(job *domain.Job) error {
+				assert.Equal(t, int64(7), job.ID)
+				assert.Equal(t, tt.wantStatus, job.Status)
+				assert.Equal(t, tt.wantError, job.Error)
+				assert.NotNil(t, job.FinishedAt)
+				return nil
+			})
+
+			started := runner.run(domain.JobTriggerManual, map[string]any{"lookback_days": 3}, func(time.Time) error {
+				runner.recordAccount("ABC123", accountResult{processed: 3})
+				return tt.err
+			})
+			assert.True(t, started)
+			assert.False(t, runner.isRunning())
+
+			lastRun := runner.status()["last_run"].(*domain.Job)
+			assert.Equal(t, tt.wantStatus, lastRun.Status)
+			assert.Equal(t, 1, lastRun.Accounts.Succeeded)
+		})
+	}
+}
+
+func TestJobRunner_SkipsWhenRunning(t *testing.T) {
+	runner := newJobRunner(domain.SchedulerTopRankingAccounts, newRunStats())
+
+	started := runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		assert.True(t, runner.isRunning())
+		nested := runner.run(domain.JobTriggerManual, nil, func(time.Time) error {
+			t.Fatal("execução concorrente não deveria começar")
+			return nil
+		})
+		assert.False(t, nested)
+		return nil
+	})
+
+	assert.True(t, started)
+	assert.False(t, runner.isRunning())
+}
+
+func TestJobRunner_ReleasesAfterPanic(t *testing.T) {
+	runner := newJobRunner(domain.SchedulerMonthlyInsightsSync, newRunStats())
+
+	assert.Panics(t, func() {
+		runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+			panic("falha inesperada")
+		})
+	})
+	assert.False(t, runner.isRunning())
+}
+
+func TestJobRunner_RecordAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockJobRepository(ctrl)
+	runner := newJobRunner(domain.SchedulerMonthlyInsightsSync, newRunStats())
+	runner.repo = repo
+
+	repo.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *domain.Job) error {
+		job.ID = 3
+		return nil
+	})
+	repo.EXPECT().SaveJobAccounts(int64(3), gomock.Any()).DoAndReturn(func(_ int64, accounts []*domain.JobAccount) error {
+		results := make(map[string]*domain.JobAccount, len(accounts))
+		for _, account := range accounts {
+			results[account.AccountID] = account
+		}
+		assert.Len(t, results, 4)
+
+		// Um resultado por mês: a falha prevalece e os meses são somados
+		assert.Equal(t, domain.JobAccountFailed, results["ABC123"].Status)
+		assert.Equal(t, 2, results["ABC123"].Items)
+		assert.Equal(t, 1, results["ABC123"].FailedItems)
+		assert.Equal(t, "token expirado", results["ABC123"].Reason)
+
+		assert.Equal(t, domain.JobAccountSucceeded, results["DEF456"].Status)
+		assert.Equal(t, domain.JobAccountInterrupted, results["GHI789"].Status)
+		assert.Equal(t, domain.JobAccountSkipped, results["JKL012"].Status)
+		assert.Equal(t, "conta sem external_id", results["JKL012"].Reason)
+		return nil
+	})
+	repo.EXPECT().FinishJob(gomock.Any()).Return(nil)
+
+	runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		runner.recordAccount("ABC123", accountResult{processed: 1, failed: 1, err: errors.New("token expirado")})
+		runner.recordAccount("ABC123", accountResult{processed: 1})
+		runner.recordAccount("DEF456", accountResult{processed: 2})
+		runner.recordAccount("GHI789", accountResult{processed: 1, err: context.Canceled})
+		runner.recordAccount("JKL012", accountResult{skipped: "conta sem external_id"})
+		return nil
+	})
+
+	// Fora de uma execução, os resultados são ignorados
+	runner.recordAccount("ABC123", accountResult{processed: 1})
+
+	summary := runner.status()["last_run"].(*domain.Job).Accounts
+	assert.Equal(t, domain.JobAccountSummary{Total: 4, Succeeded: 1, Failed: 1, Interrupted: 1, Skipped: 1}, *summary)
+}
+
+func TestJobRunner_SkipsWhenPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pauseRepo := mocks.NewMockSchedulerPauseRepository(ctrl)
+	runner := newJobRunner(domain.SchedulerSSOticaInsightSync, newRunStats())
+	runner.pauses = newPauseChecker(pauseRepo)
+
+	pauseRepo.EXPECT().GetPause(domain.SchedulerSSOticaInsightSync).Return(&domain.SchedulerState{Paused: true}, nil)
+	started := runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		t.Fatal("não deveria executar com o agendador pausado")
+		return nil
+	})
+	assert.False(t, started)
+
+	// Com a retomada, a próxima execução acontece normalmente
+	pauseRepo.EXPECT().GetPause(domain.SchedulerSSOticaInsightSync).Return(nil, nil)
+	started = runner.run(domain.JobTriggerCron, nil, func(time.Time) error { return nil })
+	assert.True(t, started)
+}
+
+func TestJobRunner_Progress(t *testing.T) {
+	start := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	now := start
+	runner := newJobRunner(domain.SchedulerMetaInsightSync, newRunStats())
+	runner.now = func() time.Time { return now }
+
+	runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		runner.setTotal(4)
+		runner.startAccount("ABC123")
+		now = now.Add(time.Second)
+		runner.startAccount("DEF456")
+
+		// Sem conta concluída, não há base para a previsão
+		progress := runner.status()["progress"].(*domain.JobProgress)
+		assert.Equal(t, domain.JobProgress{Total: 4, CurrentAccounts: []string{"ABC123", "DEF456"}}, *progress)
+
+		now = start.Add(2 * time.Minute)
+		runner.recordAccount("ABC123", accountResult{processed: 3})
+
+		progress = runner.status()["progress"].(*domain.JobProgress)
+		assert.Equal(t, 1, progress.Processed)
+		assert.Equal(t, 25.0, progress.Percent)
+		assert.Equal(t, []string{"DEF456"}, progress.CurrentAccounts)
+		// 2 minutos por conta, com 3 contas restantes
+		assert.Equal(t, int64(360), progress.RemainingSeconds)
+		assert.Equal(t, now.Add(6*time.Minute), *progress.ETA)
+		return nil
+	})
+
+	// Sem execução em andamento, o progresso não é informado
+	assert.Nil(t, runner.status()["progress"])
+}
+
+func TestJobRunner_NotifiesFinished(t *testing.T) {
+	start := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	now := start
+	dispatcher := &recordingDispatcher{}
+	runner := newJobRunner(domain.SchedulerSSOticaInsightSync, newRunStats())
+	runner.dispatcher = dispatcher
+	runner.now = func() time.Time { return now }
+
+	runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		runner.recordAccount("ABC123", accountResult{processed: 7})
+		runner.recordAccount("DEF456", accountResult{processed: 6, failed: 1, err: errors.New("timeout")})
+		now = now.Add(90 * time.Second)
+		return errors.New("erro ao buscar contas")
+	})
+
+	assert.Equal(t, []string{domain.WebhookEventSyncFinished}, dispatcher.events)
+	data := dispatcher.data[0].(map[string]any)
+	assert.Equal(t, domain.SchedulerSSOticaInsightSync, data["scheduler"])
+	assert.Equal(t, domain.JobTriggerCron, data["trigger"])
+	assert.Equal(t, domain.JobStatusFailed, data["status"])
+	assert.Equal(t, "erro ao buscar contas", data["error"])
+	assert.Equal(t, int64(90), data["duration_seconds"])
+	assert.Equal(t, &domain.JobAccountSummary{Total: 2, Succeeded: 1, Failed: 1}, data["accounts"])
+}
+
+func TestJobRunner_SkipsWhenShuttingDown(t *testing.T) {
+	runner := newJobRunner(domain.SchedulerMetaInsightSync, newRunStats())
+	runner.runs = newInFlight()
+	runner.runs.close()
+
+	started := runner.run(domain.JobTriggerCatchUp, nil, func(time.Time) error {
+		t.Fatal("não deveria executar durante o encerramento")
+		return nil
+	})
+	assert.False(t, started)
+}
+
+func TestJobRunner_Options(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	runRepo := mocks.NewMockSchedulerRunRepository(ctrl)
+	jobRepo := mocks.NewMockJobRepository(ctrl)
+	runner := newJobRunner(domain.SchedulerRollupInsightsSync, nil,
+		WithRunHistory(runRepo, config.SchedulerCatchUp{Enabled: true, Delay: time.Minute}),
+		WithJobRepository(jobRepo),
+		WithInstanceLock(nil),
+		WithPauses(nil),
+	)
+
+	// O histórico usa o nome do job, sem repetir o nome em cada agendador
+	assert.Equal(t, domain.SchedulerRollupInsightsSync, runner.history.name)
+	assert.True(t, runner.history.catchUpEnabled)
+	assert.Equal(t, jobRepo, runner.repo)
+	assert.Nil(t, runner.lock)
+	assert.Nil(t, runner.pauses)
+
+	runRepo.EXPECT().MarkStarted(domain.SchedulerRollupInsightsSync, gomock.Any()).Return(nil)
+	runner.history.markStarted(time.Now())
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+)
+
+// instanceLock garante que, com várias instâncias da API, apenas uma execute cada agendador por vez.
+// Um instanceLock nil (sem repositório) não restringe nada, como em uma única instância.
+type instanceLock struct {
+	repo repository.SchedulerLockRepository
+}
+
+func newInstanceLock(repo repository.SchedulerLockRepository) *instanceLock {
+	if repo == nil {
+		return nil
+	}
+	return &instanceLock{repo: repo}
+}
+
+// acquire obtém o lock do agendador name e retorna a função que o libera. Retorna false se outra
+// instância estiver executando o agendador ou se não for possível consultar o banco: sem a garantia
+// do lock, a execução é deixada para a próxima ocorrência.
+func (l *instanceLock) acquire(name string) (func(), bool) {
+	if l == nil {
+		return func() {}, true
+	}
+
+	unlock, acquired, err := l.repo.TryLock(name)
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", name).Error("Erro ao obter lock do agendador, execução ignorada")
+		return nil, false
+	}
+	if !acquired {
+		logrus.WithField("scheduler", name).Info("Agendador em execução em outra instância, ignorando")
+		return nil, false
+	}
+	return unlock, true
+}
+
+// tryRun executa fn com o lock do agendador name e informa se ela foi executada
+func (l *instanceLock) tryRun(name string, fn func()) bool {
+	unlock, ok := l.acquire(name)
+	if !ok {
+		return false
+	}
+	defer unlock()
+
+	fn()
+	return true
+}
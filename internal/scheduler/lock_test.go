@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInstanceLock_TryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSchedulerLockRepository(ctrl)
+	lock := newInstanceLock(repo)
+
+	t.Run("executa e libera o lock", func(t *testing.T) {
+		released := false
+		repo.EXPECT().TryLock("report_schedules").Return(func() { released = true }, true, nil)
+
+		ran := lock.tryRun("report_schedules", func() {
+			assert.False(t, released)
+		})
+		assert.True(t, ran)
+		assert.True(t, released)
+	})
+
+	t.Run("agendador em execução em outra instância", func(t *testing.T) {
+		repo.EXPECT().TryLock("report_schedules").Return(nil, false, nil)
+
+		ran := lock.tryRun("report_schedules", func() {
+			t.Fatal("não deveria executar sem o lock")
+		})
+		assert.False(t, ran)
+	})
+
+	t.Run("erro ao consultar o banco", func(t *testing.T) {
+		repo.EXPECT().TryLock("report_schedules").Return(nil, false, errors.New("conexão recusada"))
+
+		ran := lock.tryRun("report_schedules", func() {
+			t.Fatal("não deveria executar sem o lock")
+		})
+		assert.False(t, ran)
+	})
+
+	t.Run("sem repositório não restringe", func(t *testing.T) {
+		assert.True(t, newInstanceLock(nil).tryRun("report_schedules", func() {}))
+	})
+}
+
+func TestJobRunner_SkipsWhenLockedByOtherInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lockRepo := mocks.NewMockSchedulerLockRepository(ctrl)
+	runner := newJobRunner(domain.SchedulerMetaInsightSync, newRunStats())
+	runner.lock = newInstanceLock(lockRepo)
+
+	lockRepo.EXPECT().TryLock(domain.SchedulerMetaInsightSync).Return(nil, false, nil)
+
+	started := runner.run(domain.JobTriggerCron, nil, func(time.Time) error {
+		t.Fatal("não deveria executar com o job em outra instância")
+		return nil
+	})
+	assert.False(t, started)
+	assert.False(t, runner.isRunning())
+}
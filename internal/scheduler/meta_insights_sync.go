@@ -7,35 +7,49 @@ import (
 	"sync"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
 )
 
 // MetaInsightSyncConfig representa a configuração do agendador de insights do Meta
 type MetaInsightSyncConfig struct {
-	CronSchedule        string
-	LookbackDays        int
-	RequestDelaySeconds int
-	MaxConcurrentJobs   int
-	SyncEnabled         bool
+	CronSchedule       string
+	LookbackDays       int
+	MaxConcurrentJobs  int
+	SyncEnabled        bool
+	IncrementalEnabled bool
+	FullRefreshWeekday int
+	DeltaEnabled       bool
+	SettleDays         int
+	MaxDuration        time.Duration
+	MaxRetries         int
+	RetryBackoff       time.Duration
 }
 
 // MetaInsightSyncService gerencia o agendamento e execução da sincronização de insights do Meta
 type MetaInsightSyncService struct {
-	scheduler           *gocron.Scheduler
-	config              MetaInsightSyncConfig
-	appConfig           *config.Config
-	accountRepo         repository.AccountRepository
-	adInsightRepo       repository.AdInsightRepository
-	metaService         insighting.MetaInsighter
-	syncRunning         bool
-	syncMutex           sync.Mutex
-	lastSyncStartedAt   time.Time
-	lastSyncCompletedAt time.Time
+	config           MetaInsightSyncConfig
+	appConfig        *config.Config
+	accountRepo      repository.AccountRepository
+	adInsightRepo    repository.AdInsightRepository
+	metaService      insighting.MetaInsighter
+	activityRecorder activity.Recorder
+	accountSchedules *accountSchedules
+	prioritizer      activity.Prioritizer
+	accountChanges   *AccountChangeTracker
+	stats            *runStats
+	backfill         *backfillRunner
+	failures         *failureQueue
+	limiter          *RateLimiter
+	jobs             *jobRunner
+	accountSyncs     *accountSyncs
+	retry            retryPolicy
+	runCtx           context.Context
 }
 
 // NewMetaInsightSyncService cria uma nova instância do serviço de sincronização de insights do Meta
@@ -44,40 +58,107 @@ func NewMetaInsightSyncService(
 	adInsightRepo repository.AdInsightRepository,
 	metaService insighting.MetaInsighter,
 	appConfig *config.Config,
+	opts ...JobOption,
 ) *MetaInsightSyncService {
 	// Criar a configuração com base na config global
 	insightConfig := MetaInsightSyncConfig{
-		CronSchedule:        appConfig.MetaInsightSync.CronSchedule,
-		LookbackDays:        appConfig.MetaInsightSync.LookbackDays,
-		RequestDelaySeconds: appConfig.MetaInsightSync.RequestDelaySeconds,
-		MaxConcurrentJobs:   appConfig.MetaInsightSync.MaxConcurrentJobs,
-		SyncEnabled:         appConfig.MetaInsightSync.Enabled,
+		CronSchedule:       appConfig.MetaInsightSync.CronSchedule,
+		LookbackDays:       appConfig.MetaInsightSync.LookbackDays,
+		MaxConcurrentJobs:  appConfig.MetaInsightSync.MaxConcurrentJobs,
+		SyncEnabled:        appConfig.MetaInsightSync.Enabled,
+		IncrementalEnabled: appConfig.MetaInsightSync.IncrementalEnabled,
+		FullRefreshWeekday: appConfig.MetaInsightSync.FullRefreshWeekday,
+		DeltaEnabled:       appConfig.MetaInsightSync.DeltaEnabled,
+		SettleDays:         appConfig.MetaInsightSync.SettleDays,
+		MaxDuration:        appConfig.MetaInsightSync.MaxDuration,
+		MaxRetries:         appConfig.MetaInsightSync.MaxRetries,
+		RetryBackoff:       appConfig.MetaInsightSync.RetryBackoff,
 	}
 
-	// Criar o agendador
-	scheduler := gocron.NewScheduler(time.Local)
-
 	logrus.WithFields(logrus.Fields{
-		"cron_schedule":         insightConfig.CronSchedule,
-		"lookback_days":         insightConfig.LookbackDays,
-		"request_delay_seconds": insightConfig.RequestDelaySeconds,
-		"max_concurrent_jobs":   insightConfig.MaxConcurrentJobs,
-		"sync_enabled":          insightConfig.SyncEnabled,
+		"cron_schedule":        insightConfig.CronSchedule,
+		"lookback_days":        insightConfig.LookbackDays,
+		"max_concurrent_jobs":  insightConfig.MaxConcurrentJobs,
+		"sync_enabled":         insightConfig.SyncEnabled,
+		"incremental_enabled":  insightConfig.IncrementalEnabled,
+		"full_refresh_weekday": insightConfig.FullRefreshWeekday,
+		"delta_enabled":        insightConfig.DeltaEnabled,
+		"settle_days":          insightConfig.SettleDays,
+		"max_duration":         insightConfig.MaxDuration.String(),
+		"max_retries":          insightConfig.MaxRetries,
+		"retry_backoff":        insightConfig.RetryBackoff.String(),
 	}).Info("Configuração do agendador de insights do Meta carregada")
 
+	stats := newRunStats()
 	return &MetaInsightSyncService{
-		scheduler:     scheduler,
 		config:        insightConfig,
 		appConfig:     appConfig,
 		accountRepo:   accountRepo,
 		adInsightRepo: adInsightRepo,
 		metaService:   metaService,
-		syncRunning:   false,
+		stats:         stats,
+		retry:         newRetryPolicy(insightConfig.MaxRetries, insightConfig.RetryBackoff),
+		jobs:          newJobRunner(domain.SchedulerMetaInsightSync, stats, opts...),
+		accountSyncs:  newAccountSyncs(),
+		runCtx:        context.Background(),
 	}
 }
 
-// Start inicia o agendador
-func (s *MetaInsightSyncService) Start(ctx context.Context) error {
+// WithActivityRecorder habilita o registro de cada sincronização na linha do tempo das contas
+func (s *MetaInsightSyncService) WithActivityRecorder(recorder activity.Recorder) *MetaInsightSyncService {
+	s.activityRecorder = recorder
+	return s
+}
+
+// WithPrioritizer ordena a fila de sincronização pelo score de atividade das contas
+func (s *MetaInsightSyncService) WithPrioritizer(prioritizer activity.Prioritizer) *MetaInsightSyncService {
+	s.prioritizer = prioritizer
+	return s
+}
+
+// WithAccountChanges faz a execução usar o status e as credenciais atuais das contas alteradas durante a sincronização
+func (s *MetaInsightSyncService) WithAccountChanges(tracker *AccountChangeTracker) *MetaInsightSyncService {
+	s.accountChanges = tracker
+	return s
+}
+
+// WithDispatcher habilita a publicação do evento sync.finished para os webhooks assinados ao fim de cada execução
+func (s *MetaInsightSyncService) WithDispatcher(dispatcher notifying.Dispatcher) *MetaInsightSyncService {
+	s.jobs.dispatcher = dispatcher
+	return s
+}
+
+// WithAccountSchedules habilita as agendas próprias por conta, verificadas a cada checkInterval
+func (s *MetaInsightSyncService) WithAccountSchedules(repo repository.SyncScheduleRepository, checkInterval time.Duration) *MetaInsightSyncService {
+	s.accountSchedules = newAccountSchedules(repo, domain.SyncSourceMeta, checkInterval)
+	return s
+}
+
+// WithBackfill habilita o backfill de períodos longos em blocos mensais, com a situação de cada bloco persistida
+func (s *MetaInsightSyncService) WithBackfill(repo repository.BackfillChunkRepository) *MetaInsightSyncService {
+	s.backfill = newBackfillRunner(repo, domain.SyncSourceMeta)
+	return s
+}
+
+// WithSyncFailures habilita o registro das datas que falharam em todas as tentativas, para consulta e nova sincronização
+func (s *MetaInsightSyncService) WithSyncFailures(repo repository.SyncFailureRepository) *MetaInsightSyncService {
+	s.failures = newFailureQueue(repo, domain.SyncSourceMeta)
+	return s
+}
+
+// WithRateLimiter limita as requisições ao Meta com o limite compartilhado entre os agendadores
+func (s *MetaInsightSyncService) WithRateLimiter(limiter *RateLimiter) *MetaInsightSyncService {
+	s.limiter = limiter
+	return s
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *MetaInsightSyncService) Start(ctx context.Context, scheduler *Scheduler) error {
+	// Sincronizações (inclusive as manuais) são interrompidas quando o contexto da aplicação é cancelado
+	s.runCtx = ctx
+	// O encerramento da aplicação aguarda as execuções em andamento
+	s.jobs.runs = scheduler.runs
+
 	if !s.config.SyncEnabled {
 		logrus.Info("Sincronização de insights do Meta desabilitada por configuração")
 		return nil
@@ -85,80 +166,118 @@ func (s *MetaInsightSyncService) Start(ctx context.Context) error {
 
 	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de sincronização de insights do Meta")
 
+	s.jobs.interruptAbandoned()
+
 	// Agendar a sincronização de insights
-	_, err := s.scheduler.Cron(s.config.CronSchedule).Do(func() {
-		s.syncAllMetaInsights()
+	err := scheduler.Cron(domain.SchedulerMetaInsightSync, s.config.CronSchedule, func() {
+		s.syncAllMetaInsights(ctx, domain.JobTriggerCron)
 	})
 	if err != nil {
 		return fmt.Errorf("erro ao agendar sincronização de insights do Meta: %w", err)
 	}
 
-	// Executar o agendador em uma goroutine separada
-	s.scheduler.StartAsync()
+	// Verificar periodicamente as contas com agenda própria
+	if s.accountSchedules != nil {
+		err = scheduler.Every(s.accountSchedules.lockName(), s.accountSchedules.checkInterval, func() {
+			// A pausa da origem também suspende as agendas por conta
+			if s.jobs.isPaused() {
+				return
+			}
+			s.jobs.lock.tryRun(s.accountSchedules.lockName(), func() {
+				s.accountSchedules.runDue(s.getActiveAccounts, func(acc *domain.AdAccount, dates []time.Time) {
+					s.processAccountForAllDates(ctx, acc, dates)
+				})
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao agendar verificação das agendas por conta do Meta: %w", err)
+		}
+	}
 
-	// Configurar o cancelamento do agendador quando o contexto for cancelado
-	go func() {
-		<-ctx.Done()
-		logrus.Info("Parando agendador de sincronização de insights do Meta")
-		s.scheduler.Stop()
-	}()
+	// Executar a sincronização perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() {
+		s.syncAllMetaInsights(ctx, domain.JobTriggerCatchUp)
+	})
 
 	return nil
 }
 
-// syncAllMetaInsights sincroniza os insights do Meta de todas as contas ativas
-func (s *MetaInsightSyncService) syncAllMetaInsights() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+// syncAllMetaInsights sincroniza os insights do Meta de todas as contas ativas, se não houver outra
+// sincronização em andamento
+func (s *MetaInsightSyncService) syncAllMetaInsights(ctx context.Context, trigger string) {
+	params := map[string]any{"lookback_days": s.config.LookbackDays}
+	started := s.jobs.run(trigger, params, func(startTime time.Time) error {
+		return s.runMetaInsightSync(ctx, startTime)
+	})
+	if !started {
 		logrus.Info("Sincronização de insights do Meta já em andamento, ignorando")
-		return
 	}
-	s.syncRunning = true
-	s.syncMutex.Unlock()
-
-	startTime := time.Now()
-	s.lastSyncStartedAt = startTime
-
-	defer func() {
-		s.syncMutex.Lock()
-		s.syncRunning = false
-		s.syncMutex.Unlock()
-	}()
+}
 
+func (s *MetaInsightSyncService) runMetaInsightSync(ctx context.Context, startTime time.Time) error {
 	logrus.Info("Iniciando sincronização de insights do Meta para todas as contas ativas")
 
+	// Limitar a duração para não sobrepor a execução do cron seguinte
+	runCtx, cancel := withTimeBox(ctx, s.config.MaxDuration)
+	defer cancel()
+
 	// Buscar todas as contas ativas
 	activeAccounts, err := s.getActiveAccounts()
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao buscar lista de contas para sincronização de insights do Meta")
-		return
+		return fmt.Errorf("erro ao buscar contas: %w", err)
 	}
 
+	// Contas com agenda própria são sincronizadas fora da execução global
+	activeAccounts = s.accountSchedules.withoutOverrides(activeAccounts)
+
+	// Contas VIP e as mais acompanhadas são sincronizadas primeiro
+	activeAccounts = prioritizeAccounts(s.prioritizer, activeAccounts)
+
 	if len(activeAccounts) == 0 {
 		logrus.Info("Nenhuma conta ativa encontrada para sincronização de insights do Meta")
-		return
+		return nil
 	}
 
 	// Criar datas para processamento
-	dates := s.getDatesToProcess()
+	dates := s.getDatesToProcess(startTime)
 	logrus.WithFields(logrus.Fields{
 		"days":       s.config.LookbackDays,
 		"start_date": dates[len(dates)-1].Format(time.DateOnly),
 		"end_date":   dates[0].Format(time.DateOnly),
 	}).Info("Período para sincronização de insights do Meta")
 
+	// Retomar a execução interrompida: contas pendentes primeiro, incluindo as datas que ficaram sem sincronizar
+	progress := newSyncProgress(dates, s.jobs.history.loadCheckpoint(), startTime)
+	activeAccounts = progress.pendingFirst(activeAccounts)
+
 	// Processar insights
-	s.processMetaInsightsForDates(activeAccounts, dates)
+	s.processMetaInsightsForDates(runCtx, activeAccounts, progress)
 
 	duration := time.Since(startTime)
+	if runCtx.Err() != nil {
+		checkpoint := progress.checkpoint(time.Now())
+		s.jobs.history.saveCheckpoint(checkpoint)
+		logrus.WithFields(logrus.Fields{
+			"duration":           duration.String(),
+			"completed_accounts": len(checkpoint.CompletedAccountIDs),
+			"accounts":           len(activeAccounts),
+			"time_limit_reached": ctx.Err() == nil,
+		}).Warn("Sincronização de insights do Meta interrompida, progresso salvo para a próxima execução")
+		return runCtx.Err()
+	}
+
+	if progress.resumed != nil {
+		s.jobs.history.saveCheckpoint(nil)
+	}
+
 	logrus.WithFields(logrus.Fields{
 		"duration": duration.String(),
 		"accounts": len(activeAccounts),
 		"days":     s.config.LookbackDays,
 	}).Info("Sincronização de insights do Meta concluída")
 
-	s.lastSyncCompletedAt = time.Now()
+	return nil
 }
 
 // getActiveAccounts busca e filtra contas ativas
@@ -180,17 +299,19 @@ func (s *MetaInsightSyncService) getActiveAccounts() ([]*domain.AdAccount, error
 	return activeAccounts, nil
 }
 
-// getDatesToProcess cria um conjunto de datas para processar
-func (s *MetaInsightSyncService) getDatesToProcess() []time.Time {
+// getDatesToProcess cria um conjunto de datas para processar, contadas no fuso de now
+func (s *MetaInsightSyncService) getDatesToProcess(now time.Time) []time.Time {
 	dates := make([]time.Time, s.config.LookbackDays)
 	for i := 0; i < s.config.LookbackDays; i++ {
-		dates[i] = time.Now().AddDate(0, 0, -i-1) // Começar de ontem e ir para trás
+		dates[i] = truncateToDay(now).AddDate(0, 0, -i-1) // Começar de ontem e ir para trás
 	}
 	return dates
 }
 
 // processMetaInsightsForDates processa insights do Meta para cada conta e todas as suas datas
-func (s *MetaInsightSyncService) processMetaInsightsForDates(accounts []*domain.AdAccount, dates []time.Time) {
+func (s *MetaInsightSyncService) processMetaInsightsForDates(ctx context.Context, accounts []*domain.AdAccount, progress *syncProgress) {
+	s.jobs.setTotal(len(accounts))
+
 	// Criar um canal para controlar o número de workers concorrentes
 	semaphore := make(chan struct{}, s.config.MaxConcurrentJobs)
 	var wg sync.WaitGroup
@@ -200,12 +321,17 @@ func (s *MetaInsightSyncService) processMetaInsightsForDates(accounts []*domain.
 		// Se a conta não tiver external_id, pular
 		if account.ExternalID == "" {
 			logrus.WithField("account_id", account.ID).Warn("Conta sem external_id. Pulando.")
+			s.jobs.recordAccount(account.ID, accountResult{skipped: "conta sem external_id"})
 			continue
 		}
 
+		// Adquirir semáforo, parando de distribuir contas se a sincronização for cancelada
+		if !acquireSlot(ctx, semaphore) {
+			break
+		}
+
 		// Adicionar uma tarefa ao grupo de espera
 		wg.Add(1)
-		semaphore <- struct{}{} // Adquirir semáforo
 
 		go func(acc *domain.AdAccount) {
 			defer func() {
@@ -213,6 +339,19 @@ func (s *MetaInsightSyncService) processMetaInsightsForDates(accounts []*domain.
 				wg.Done()
 			}()
 
+			s.jobs.startAccount(acc.ID)
+
+			// "Ontem" é o ontem da loja: a janela é contada no fuso da conta
+			dates := progress.datesFor(acc.ID, s.getDatesToProcess(progress.startedAt.In(acc.TimeLocation())))
+
+			// Buscar apenas as datas sem dados armazenados ou ainda não consolidadas
+			dates = s.deltaDates(acc, dates, time.Now())
+			if len(dates) == 0 {
+				s.jobs.recordAccount(acc.ID, accountResult{skipped: "datas já sincronizadas"})
+				progress.markCompleted(acc.ID)
+				return
+			}
+
 			logrus.WithFields(logrus.Fields{
 				"account_id":   acc.ID,
 				"external_id":  acc.ExternalID,
@@ -221,7 +360,10 @@ func (s *MetaInsightSyncService) processMetaInsightsForDates(accounts []*domain.
 			}).Info("Processando insights do Meta para conta")
 
 			// Processar todas as datas para esta conta
-			s.processAccountForAllDates(acc, dates)
+			s.jobs.recordAccount(acc.ID, s.syncAccountDates(ctx, acc, dates))
+			if ctx.Err() == nil {
+				progress.markCompleted(acc.ID)
+			}
 		}(account)
 	}
 
@@ -230,21 +372,91 @@ func (s *MetaInsightSyncService) processMetaInsightsForDates(accounts []*domain.
 }
 
 // processAccountForAllDates processa os insights do Meta para uma conta em todas as datas
-func (s *MetaInsightSyncService) processAccountForAllDates(acc *domain.AdAccount, dates []time.Time) {
+// Retorna true se todas as datas foram sincronizadas sem falhas.
+func (s *MetaInsightSyncService) processAccountForAllDates(ctx context.Context, acc *domain.AdAccount, dates []time.Time) bool {
+	result := s.syncAccountDates(ctx, acc, dates)
+	return result.failed == 0 && ctx.Err() == nil
+}
+
+// syncAccountDates processa os insights do Meta para uma conta em todas as datas e retorna o resultado
+func (s *MetaInsightSyncService) syncAccountDates(ctx context.Context, acc *domain.AdAccount, dates []time.Time) accountResult {
+	current, ok := s.currentAccount(acc)
+	if !ok {
+		logrus.WithField("account_id", acc.ID).Info("Conta inativada ou sem external_id, ignorando a sincronização do Meta")
+		return accountResult{skipped: "conta inativada ou sem external_id"}
+	}
+	acc = current
+
+	// Rebuscar apenas os dias cujos dados mudaram no Meta
+	dates = s.datesToRefresh(ctx, acc, dates, time.Now())
+	if len(dates) == 0 {
+		logrus.WithField("account_id", acc.ID).Info("Nenhuma alteração nos insights do Meta para a conta")
+		return accountResult{skipped: "nenhuma alteração nos insights do Meta"}
+	}
+
 	sort.Slice(dates, func(i, j int) bool {
 		return dates[i].Before(dates[j])
 	})
 
+	failedDates := make([]time.Time, 0)
+	processedDates := make([]time.Time, 0, len(dates))
+	syncedDates := make([]time.Time, 0, len(dates))
+	var lastErr error
 	for _, date := range dates {
-		s.processAccountMetaInsights(acc, date)
+		if ctx.Err() != nil {
+			break
+		}
 
-		// Aguardar antes da próxima requisição para evitar sobrecarga na API
-		time.Sleep(time.Duration(s.config.RequestDelaySeconds) * time.Second)
+		// A conta pode ter sido inativada ou ter as credenciais alteradas durante a execução
+		current, ok := s.currentAccount(acc)
+		if !ok {
+			logrus.WithField("account_id", acc.ID).Info("Conta inativada ou sem credenciais, interrompendo a sincronização do Meta")
+			break
+		}
+		acc = current
+
+		processedDates = append(processedDates, date)
+		// Falhas transitórias são repetidas com backoff; o erro final fica no resultado da conta na execução
+		// e a data, na fila de falhas
+		attempts := 0
+		err := s.retry.do(ctx, func() error {
+			attempts++
+			return s.processAccountMetaInsights(ctx, acc, date)
+		})
+		if err != nil {
+			failedDates = append(failedDates, date)
+			lastErr = err
+			s.failures.record(acc.ID, date, attempts, err)
+		} else {
+			syncedDates = append(syncedDates, date)
+		}
+	}
+
+	if ctx.Err() != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id":      acc.ID,
+			"processed_dates": len(processedDates),
+			"total_dates":     len(dates),
+		}).Warn("Sincronização de insights do Meta da conta interrompida")
 	}
+
+	recordSyncActivity(s.activityRecorder, acc.ID, "Meta", processedDates, failedDates)
+	s.failures.resolve(acc.ID, syncedDates)
+
+	if lastErr == nil {
+		lastErr = ctx.Err()
+	}
+	return accountResult{processed: len(processedDates), failed: len(failedDates), err: lastErr}
+}
+
+// currentAccount retorna a conta com o status e as credenciais atuais e se ela ainda pode ser sincronizada
+func (s *MetaInsightSyncService) currentAccount(acc *domain.AdAccount) (*domain.AdAccount, bool) {
+	current, active := s.accountChanges.refresh(acc)
+	return current, active && current.ExternalID != ""
 }
 
 // processAccountMetaInsights processa os insights do Meta para uma conta e data específicas
-func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccount, date time.Time) {
+func (s *MetaInsightSyncService) processAccountMetaInsights(ctx context.Context, acc *domain.AdAccount, date time.Time) error {
 	// Criar filtros para a data específica
 	filters := &domain.InsigthFilters{
 		StartDate: &date,
@@ -259,7 +471,12 @@ func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccoun
 	}).Info("Obtendo insights do Meta para conta e data")
 
 	// Obter insights do Meta para a conta e data
-	adMetrics, err := s.metaService.GetAdAccountMetrics(acc.ExternalID, filters)
+	if err := s.limiter.wait(ctx); err != nil {
+		return err
+	}
+	adMetrics, err := s.metaService.GetAdAccountMetrics(ctx, acc.ExternalID, filters)
+	s.stats.recordCall(domain.SyncSourceMeta, err)
+	s.limiter.observe(err)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"account_id":  acc.ID,
@@ -267,7 +484,7 @@ func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccoun
 			"date":        date.Format(time.DateOnly),
 			"error":       err.Error(),
 		}).Error("Erro ao obter insights do Meta para conta e data")
-		return
+		return err
 	}
 
 	if adMetrics == nil {
@@ -276,7 +493,7 @@ func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccoun
 			"external_id": acc.ExternalID,
 			"date":        date.Format(time.DateOnly),
 		}).Warn("Nenhum insight do Meta obtido para conta e data")
-		return
+		return nil
 	}
 
 	// Criar a entrada de insights de anúncios
@@ -296,8 +513,9 @@ func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccoun
 			"date":        date.Format(time.DateOnly),
 			"error":       err.Error(),
 		}).Error("Erro ao salvar insights do Meta no banco de dados")
-		return
+		return err
 	}
+	s.stats.recordRowsWritten(domain.SyncSourceMeta, 1)
 
 	logrus.WithFields(logrus.Fields{
 		"account_id":  acc.ID,
@@ -305,34 +523,145 @@ func (s *MetaInsightSyncService) processAccountMetaInsights(acc *domain.AdAccoun
 		"date":        date.Format(time.DateOnly),
 	}).Info("Insights do Meta salvos com sucesso para conta e data")
 
-	// Aguardar antes da próxima requisição para evitar sobrecarga na API
-	time.Sleep(time.Duration(s.config.RequestDelaySeconds) * time.Second)
+	return nil
 }
 
 // TriggerManualSync inicia manualmente uma sincronização de insights do Meta
 func (s *MetaInsightSyncService) TriggerManualSync() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+	if s.jobs.isRunning() {
 		logrus.Info("Sincronização de insights do Meta já em andamento, ignorando solicitação manual")
 		return
 	}
-	s.syncMutex.Unlock()
 
 	logrus.Info("Iniciando sincronização manual de insights do Meta")
-	go s.syncAllMetaInsights()
+	go s.syncAllMetaInsights(s.runCtx, domain.JobTriggerManual)
+}
+
+// TriggerAccountSync inicia em background a sincronização de insights do Meta de uma única conta na janela de
+// LookbackDays, sem sincronizar as demais contas. Não depende da sincronização de todas as contas estar parada.
+func (s *MetaInsightSyncService) TriggerAccountSync(accountID string) error {
+	_, err := s.startAccountSync(accountID, func(acc *domain.AdAccount) ([]time.Time, error) {
+		return s.getDatesToProcess(time.Now().In(acc.TimeLocation())), nil
+	})
+	return err
+}
+
+// RequeueFailures inicia em background uma nova sincronização das datas da conta que falharam em todas as
+// tentativas e retorna essas datas. As datas sincronizadas saem da fila de falhas.
+func (s *MetaInsightSyncService) RequeueFailures(accountID string) ([]time.Time, error) {
+	return s.startAccountSync(accountID, func(acc *domain.AdAccount) ([]time.Time, error) {
+		return s.failures.requeue(acc.ID)
+	})
+}
+
+// startAccountSync reserva a conta e sincroniza em background as datas retornadas por datesFor
+func (s *MetaInsightSyncService) startAccountSync(accountID string, datesFor func(acc *domain.AdAccount) ([]time.Time, error)) ([]time.Time, error) {
+	if s.jobs.isPaused() {
+		return nil, ErrSchedulerPaused
+	}
+
+	acc, err := s.accountRepo.GetAccountByID(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar conta para sincronização: %w", err)
+	}
+	// Contas sem external_id não podem ser consultadas no Meta
+	if acc == nil || acc.Status != domain.AdAccountStatusActive || acc.ExternalID == "" {
+		return nil, ErrAccountSyncNotFound
+	}
+	if !s.accountSyncs.start(acc.ID) {
+		return nil, ErrAccountSyncRunning
+	}
+
+	dates, err := datesFor(acc)
+	if err != nil {
+		s.accountSyncs.finish(acc.ID)
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"account_id": acc.ID,
+		"days":       len(dates),
+	}).Info("Iniciando sincronização manual de insights do Meta da conta")
+
+	done := s.jobs.runs.track("account_sync:" + domain.SyncSourceMeta + ":" + acc.ID)
+	go func() {
+		defer done()
+		defer s.accountSyncs.finish(acc.ID)
+
+		if s.processAccountForAllDates(s.runCtx, acc, dates) {
+			logrus.WithField("account_id", acc.ID).Info("Sincronização manual de insights do Meta da conta concluída")
+		} else {
+			logrus.WithField("account_id", acc.ID).Warn("Sincronização manual de insights do Meta da conta concluída com falhas")
+		}
+	}()
+	return dates, nil
+}
+
+// TriggerBackfill inicia em background a sincronização de insights do Meta no período, processando um mês
+// por vez. Sem accountIDs, sincroniza todas as contas ativas e os blocos concluídos em um backfill anterior do
+// mesmo período não são refeitos; com accountIDs, apenas as contas informadas, refazendo todo o período.
+func (s *MetaInsightSyncService) TriggerBackfill(startDate, endDate time.Time, accountIDs []string) error {
+	if s.jobs.isPaused() {
+		return ErrSchedulerPaused
+	}
+
+	activeAccounts, err := s.getActiveAccounts()
+	if err != nil {
+		return fmt.Errorf("erro ao buscar contas para o backfill: %w", err)
+	}
+
+	// Contas sem external_id não podem ser consultadas no Meta
+	accounts := make([]*domain.AdAccount, 0, len(activeAccounts))
+	for _, acc := range activeAccounts {
+		if acc.ExternalID != "" {
+			accounts = append(accounts, acc)
+		}
+	}
+	accounts, err = backfillAccounts(accounts, accountIDs)
+	if err != nil {
+		return err
+	}
+
+	chunks, err := s.backfill.start(startDate, endDate, time.Now(), accountIDs)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"start_date": startDate.Format(time.DateOnly),
+		"end_date":   endDate.Format(time.DateOnly),
+		"chunks":     len(chunks),
+		"accounts":   len(accounts),
+	}).Info("Iniciando backfill de insights do Meta")
+
+	done := s.jobs.runs.track("backfill:meta")
+	go func() {
+		defer done()
+		s.backfill.run(s.runCtx, chunks, accounts, s.config.MaxConcurrentJobs, s.processAccountForAllDates)
+	}()
+	return nil
+}
+
+// Paused informa se o agendador foi pausado pelas rotas de administração
+func (s *MetaInsightSyncService) Paused() bool {
+	return s.jobs.isPaused()
 }
 
 // GetStatus retorna o status atual do agendador
 func (s *MetaInsightSyncService) GetStatus() map[string]any {
-	return map[string]any{
-		"sync_enabled":           s.config.SyncEnabled,
-		"sync_cron":              s.config.CronSchedule,
-		"sync_lookback_days":     s.config.LookbackDays,
-		"sync_max_concurrent":    s.config.MaxConcurrentJobs,
-		"sync_request_delay_s":   s.config.RequestDelaySeconds,
-		"retention_policy":       "dados mantidos permanentemente",
-		"last_sync_started_at":   s.lastSyncStartedAt,
-		"last_sync_completed_at": s.lastSyncCompletedAt,
-	}
+	status := s.jobs.status()
+	status["sync_enabled"] = s.config.SyncEnabled
+	status["sync_cron"] = s.config.CronSchedule
+	status["next_run_at"] = nextRunAt(s.config.SyncEnabled, s.config.CronSchedule, time.Now())
+	status["sync_lookback_days"] = s.config.LookbackDays
+	status["sync_delta_enabled"] = s.config.DeltaEnabled
+	status["sync_settle_days"] = s.config.SettleDays
+	status["sync_max_concurrent"] = s.config.MaxConcurrentJobs
+	status["rate_limit"] = s.limiter.status()
+	status["sync_max_duration"] = s.config.MaxDuration.String()
+	status["sync_max_retries"] = s.config.MaxRetries
+	status["retention_policy"] = "dados mantidos permanentemente"
+	status["backfill"] = s.backfill.status()
+	status["account_syncs"] = s.accountSyncs.status()
+	return status
 }
@@ -6,26 +6,24 @@ import (
 	"sync"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
 )
 
 // MonthlyInsightsSyncConfig representa a configuração do agendador de insights mensais
 type MonthlyInsightsSyncConfig struct {
-	CronSchedule        string
-	RequestDelaySeconds int
-	MaxConcurrentJobs   int
-	SyncEnabled         bool
-	MonthLookBack       int
+	CronSchedule      string
+	MaxConcurrentJobs int
+	SyncEnabled       bool
+	MonthLookBack     int
 }
 
 // MonthlyInsightsSyncService gerencia o agendamento e execução da sincronização mensal de insights
 type MonthlyInsightsSyncService struct {
-	scheduler               *gocron.Scheduler
 	config                  MonthlyInsightsSyncConfig
 	appConfig               *config.Config
 	accountRepo             repository.AccountRepository
@@ -33,10 +31,12 @@ type MonthlyInsightsSyncService struct {
 	monthlySalesInsightRepo repository.MonthlySalesInsightRepository
 	metaService             insighting.MetaInsighter
 	ssoticaService          insighting.SSOticaInsighter
-	syncRunning             bool
-	syncMutex               sync.Mutex
-	lastSyncStartedAt       time.Time
-	lastSyncCompletedAt     time.Time
+	accountChanges          *AccountChangeTracker
+	stats                   *runStats
+	jobs                    *jobRunner
+	metaLimiter             *RateLimiter
+	ssoticaLimiter          *RateLimiter
+	runCtx                  context.Context
 }
 
 // NewMonthlyInsightsSyncService cria uma nova instância do serviço de sincronização mensal de insights
@@ -47,28 +47,24 @@ func NewMonthlyInsightsSyncService(
 	metaService insighting.MetaInsighter,
 	ssoticaService insighting.SSOticaInsighter,
 	appConfig *config.Config,
+	opts ...JobOption,
 ) *MonthlyInsightsSyncService {
 	// Criar a configuração com base na config global
 	insightConfig := MonthlyInsightsSyncConfig{
-		CronSchedule:        appConfig.MonthlyInsightsSync.CronSchedule,
-		RequestDelaySeconds: appConfig.MonthlyInsightsSync.RequestDelaySeconds,
-		MaxConcurrentJobs:   appConfig.MonthlyInsightsSync.MaxConcurrentJobs,
-		SyncEnabled:         appConfig.MonthlyInsightsSync.Enabled,
-		MonthLookBack:       appConfig.MonthlyInsightsSync.MonthLookBack,
+		CronSchedule:      appConfig.MonthlyInsightsSync.CronSchedule,
+		MaxConcurrentJobs: appConfig.MonthlyInsightsSync.MaxConcurrentJobs,
+		SyncEnabled:       appConfig.MonthlyInsightsSync.Enabled,
+		MonthLookBack:     appConfig.MonthlyInsightsSync.MonthLookBack,
 	}
 
-	// Criar o agendador
-	scheduler := gocron.NewScheduler(time.Local)
-
 	logrus.WithFields(logrus.Fields{
-		"cron_schedule":         insightConfig.CronSchedule,
-		"request_delay_seconds": insightConfig.RequestDelaySeconds,
-		"max_concurrent_jobs":   insightConfig.MaxConcurrentJobs,
-		"sync_enabled":          insightConfig.SyncEnabled,
+		"cron_schedule":       insightConfig.CronSchedule,
+		"max_concurrent_jobs": insightConfig.MaxConcurrentJobs,
+		"sync_enabled":        insightConfig.SyncEnabled,
 	}).Info("Configuração do agendador de insights mensais carregada")
 
+	stats := newRunStats()
 	return &MonthlyInsightsSyncService{
-		scheduler:               scheduler,
 		config:                  insightConfig,
 		appConfig:               appConfig,
 		accountRepo:             accountRepo,
@@ -76,12 +72,38 @@ func NewMonthlyInsightsSyncService(
 		monthlySalesInsightRepo: monthlySalesInsightRepo,
 		metaService:             metaService,
 		ssoticaService:          ssoticaService,
-		syncRunning:             false,
+		stats:                   stats,
+		jobs:                    newJobRunner(domain.SchedulerMonthlyInsightsSync, stats, opts...),
+		runCtx:                  context.Background(),
 	}
 }
 
-// Start inicia o agendador
-func (s *MonthlyInsightsSyncService) Start(ctx context.Context) error {
+// WithAccountChanges faz a execução usar o status e as credenciais atuais das contas alteradas durante a sincronização
+func (s *MonthlyInsightsSyncService) WithAccountChanges(tracker *AccountChangeTracker) *MonthlyInsightsSyncService {
+	s.accountChanges = tracker
+	return s
+}
+
+// WithDispatcher habilita a publicação do evento sync.finished para os webhooks assinados ao fim de cada execução
+func (s *MonthlyInsightsSyncService) WithDispatcher(dispatcher notifying.Dispatcher) *MonthlyInsightsSyncService {
+	s.jobs.dispatcher = dispatcher
+	return s
+}
+
+// WithRateLimiters limita as requisições ao Meta e ao SSOtica com os limites compartilhados entre os agendadores
+func (s *MonthlyInsightsSyncService) WithRateLimiters(meta, ssotica *RateLimiter) *MonthlyInsightsSyncService {
+	s.metaLimiter = meta
+	s.ssoticaLimiter = ssotica
+	return s
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *MonthlyInsightsSyncService) Start(ctx context.Context, scheduler *Scheduler) error {
+	// Sincronizações (inclusive as manuais) são interrompidas quando o contexto da aplicação é cancelado
+	s.runCtx = ctx
+	// O encerramento da aplicação aguarda as execuções em andamento
+	s.jobs.runs = scheduler.runs
+
 	if !s.config.SyncEnabled {
 		logrus.Info("Sincronização mensal de insights desabilitada por configuração")
 		return nil
@@ -89,62 +111,55 @@ func (s *MonthlyInsightsSyncService) Start(ctx context.Context) error {
 
 	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de sincronização mensal de insights")
 
+	s.jobs.interruptAbandoned()
+
 	// Agendar a sincronização de insights
-	_, err := s.scheduler.Cron(s.config.CronSchedule).Do(func() {
-		s.syncMonthlyInsights()
+	err := scheduler.Cron(domain.SchedulerMonthlyInsightsSync, s.config.CronSchedule, func() {
+		s.syncMonthlyInsights(ctx, domain.JobTriggerCron)
 	})
 	if err != nil {
 		return fmt.Errorf("erro ao agendar sincronização mensal de insights: %w", err)
 	}
 
-	// Executar o agendador em uma goroutine separada
-	s.scheduler.StartAsync()
-
-	// Configurar o cancelamento do agendador quando o contexto for cancelado
-	go func() {
-		<-ctx.Done()
-		logrus.Info("Parando agendador de sincronização mensal de insights")
-		s.scheduler.Stop()
-	}()
+	// Executar a sincronização perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() {
+		s.syncMonthlyInsights(ctx, domain.JobTriggerCatchUp)
+	})
 
 	return nil
 }
 
-// syncMonthlyInsights sincroniza os insights mensais de todas as contas ativas
-func (s *MonthlyInsightsSyncService) syncMonthlyInsights() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+// syncMonthlyInsights sincroniza os insights mensais de todas as contas ativas, se não houver outra
+// sincronização em andamento
+func (s *MonthlyInsightsSyncService) syncMonthlyInsights(ctx context.Context, trigger string) {
+	params := map[string]any{"month_look_back": s.config.MonthLookBack}
+	started := s.jobs.run(trigger, params, func(startTime time.Time) error {
+		return s.runMonthlyInsightsSync(ctx, startTime)
+	})
+	if !started {
 		logrus.Info("Sincronização mensal de insights já em andamento, ignorando")
-		return
 	}
-	s.syncRunning = true
-	s.syncMutex.Unlock()
-
-	startTime := time.Now()
-	s.lastSyncStartedAt = startTime
-
-	defer func() {
-		s.syncMutex.Lock()
-		s.syncRunning = false
-		s.syncMutex.Unlock()
-	}()
+}
 
+func (s *MonthlyInsightsSyncService) runMonthlyInsightsSync(ctx context.Context, startTime time.Time) error {
 	logrus.Info("Iniciando sincronização mensal de insights para todas as contas ativas")
 
 	// Buscar todas as contas ativas
 	activeAccounts, err := s.getActiveAccounts()
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao buscar lista de contas para sincronização mensal de insights")
-		return
+		return fmt.Errorf("erro ao buscar contas: %w", err)
 	}
 
 	if len(activeAccounts) == 0 {
 		logrus.Info("Nenhuma conta ativa encontrada para sincronização mensal de insights")
-		return
+		return nil
 	}
 
-	for i := 1; i <= s.config.MonthLookBack; i++ {
+	// Contas VIP são sincronizadas primeiro
+	activeAccounts = prioritizeAccounts(nil, activeAccounts)
+
+	for i := 1; i <= s.config.MonthLookBack && ctx.Err() == nil; i++ {
 		now := time.Now()
 		month := now.AddDate(0, -i, 0)
 		firstDayOfMonth := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
@@ -155,16 +170,21 @@ func (s *MonthlyInsightsSyncService) syncMonthlyInsights() {
 			"end_date":   lastDayOfMonth.Format(time.DateOnly),
 		}).Info("Período para sincronização mensal de insights")
 
-		s.processMonthlyInsights(activeAccounts, firstDayOfMonth, lastDayOfMonth)
+		s.processMonthlyInsights(ctx, activeAccounts, firstDayOfMonth, lastDayOfMonth)
 	}
 
 	duration := time.Since(startTime)
+	if ctx.Err() != nil {
+		logrus.WithField("duration", duration.String()).Warn("Sincronização mensal de insights interrompida pelo encerramento da aplicação")
+		return ctx.Err()
+	}
+
 	logrus.WithFields(logrus.Fields{
 		"duration": duration.String(),
 		"accounts": len(activeAccounts),
 	}).Info("Sincronização mensal de insights concluída")
 
-	s.lastSyncCompletedAt = time.Now()
+	return nil
 }
 
 // getActiveAccounts busca e filtra contas ativas
@@ -187,17 +207,22 @@ func (s *MonthlyInsightsSyncService) getActiveAccounts() ([]*domain.AdAccount, e
 }
 
 // processMonthlyInsights processa os insights mensais para todas as contas
-func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.AdAccount, startDate, endDate time.Time) {
+func (s *MonthlyInsightsSyncService) processMonthlyInsights(ctx context.Context, accounts []*domain.AdAccount, startDate, endDate time.Time) {
+	s.jobs.setTotal(len(accounts))
+
 	// Criar um canal para controlar o número de workers concorrentes
 	semaphore := make(chan struct{}, s.config.MaxConcurrentJobs)
 	var wg sync.WaitGroup
 
 	// Para cada conta, processar as métricas mensais
 	for _, account := range accounts {
+		// Adquirir semáforo, parando de distribuir contas se a sincronização for cancelada
+		if !acquireSlot(ctx, semaphore) {
+			break
+		}
 
 		// Adicionar uma tarefa ao grupo de espera
 		wg.Add(1)
-		semaphore <- struct{}{} // Adquirir semáforo
 
 		go func(acc *domain.AdAccount) {
 			defer func() {
@@ -205,6 +230,16 @@ func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.A
 				wg.Done()
 			}()
 
+			s.jobs.startAccount(acc.ID)
+
+			// A conta pode ter sido inativada ou ter as credenciais alteradas durante a execução
+			acc, active := s.accountChanges.refresh(acc)
+			if !active {
+				logrus.WithField("account_id", acc.ID).Info("Conta inativada durante a sincronização mensal, ignorando")
+				s.jobs.recordAccount(acc.ID, accountResult{skipped: "conta inativada durante a sincronização"})
+				return
+			}
+
 			logrus.WithFields(logrus.Fields{
 				"account_id":   acc.ID,
 				"external_id":  acc.ExternalID,
@@ -219,9 +254,13 @@ func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.A
 				EndDate:   &endDate,
 			}
 
+			// Um mês por conta; falha nos anúncios ou nas vendas conta como falha do mês
+			var monthErr error
+
 			// Processar métricas de anúncios do mês anterior
-			err := s.processMonthlyAdMetrics(acc, filters)
+			err := s.processMonthlyAdMetrics(ctx, acc, filters)
 			if err != nil {
+				monthErr = err
 				logrus.WithError(err).WithFields(logrus.Fields{
 					"account_id":  acc.ID,
 					"external_id": acc.ExternalID,
@@ -232,8 +271,9 @@ func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.A
 
 			// Processar métricas de vendas do mês anterior se a conta tiver os dados necessários
 			if acc.CNPJ != nil && *acc.CNPJ != "" && acc.SecretName != nil && *acc.SecretName != "" {
-				err = s.processMonthlySalesMetrics(acc, filters)
+				err = s.processMonthlySalesMetrics(ctx, acc, filters)
 				if err != nil {
+					monthErr = err
 					logrus.WithError(err).WithFields(logrus.Fields{
 						"account_id":  acc.ID,
 						"cnpj":        *acc.CNPJ,
@@ -244,8 +284,11 @@ func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.A
 				}
 			}
 
-			// Aguardar antes da próxima conta para evitar excesso de requisições
-			time.Sleep(time.Duration(s.config.RequestDelaySeconds) * time.Second)
+			result := accountResult{processed: 1, err: monthErr}
+			if monthErr != nil {
+				result.failed = 1
+			}
+			s.jobs.recordAccount(acc.ID, result)
 		}(account)
 	}
 
@@ -254,13 +297,18 @@ func (s *MonthlyInsightsSyncService) processMonthlyInsights(accounts []*domain.A
 }
 
 // processMonthlyAdMetrics processa as métricas mensais de anúncios para uma conta
-func (s *MonthlyInsightsSyncService) processMonthlyAdMetrics(acc *domain.AdAccount, filters *domain.InsigthFilters) error {
+func (s *MonthlyInsightsSyncService) processMonthlyAdMetrics(ctx context.Context, acc *domain.AdAccount, filters *domain.InsigthFilters) error {
 	if acc.ExternalID == "" {
 		return fmt.Errorf("conta sem ID externo")
 	}
 
 	// Buscar métricas de anúncios diretamente via API
-	adMetrics, err := s.metaService.GetAdAccountMetrics(acc.ExternalID, filters)
+	if err := s.metaLimiter.wait(ctx); err != nil {
+		return err
+	}
+	adMetrics, err := s.metaService.GetAdAccountMetrics(ctx, acc.ExternalID, filters)
+	s.stats.recordCall(domain.SyncSourceMeta, err)
+	s.metaLimiter.observe(err)
 	if err != nil {
 		return fmt.Errorf("erro ao obter métricas de anúncios: %w", err)
 	}
@@ -286,6 +334,7 @@ func (s *MonthlyInsightsSyncService) processMonthlyAdMetrics(acc *domain.AdAccou
 	if err != nil {
 		return fmt.Errorf("erro ao salvar métricas mensais de anúncios: %w", err)
 	}
+	s.stats.recordRowsWritten(domain.SyncSourceMeta, 1)
 
 	logrus.WithFields(logrus.Fields{
 		"account_id":  acc.ID,
@@ -297,13 +346,18 @@ func (s *MonthlyInsightsSyncService) processMonthlyAdMetrics(acc *domain.AdAccou
 }
 
 // processMonthlySalesMetrics processa as métricas mensais de vendas para uma conta
-func (s *MonthlyInsightsSyncService) processMonthlySalesMetrics(acc *domain.AdAccount, filters *domain.InsigthFilters) error {
+func (s *MonthlyInsightsSyncService) processMonthlySalesMetrics(ctx context.Context, acc *domain.AdAccount, filters *domain.InsigthFilters) error {
 	if acc.CNPJ == nil || *acc.CNPJ == "" || acc.SecretName == nil || *acc.SecretName == "" {
 		return fmt.Errorf("conta sem CNPJ ou SecretName")
 	}
 
 	// Buscar métricas de vendas diretamente via API
-	salesMetrics, err := s.ssoticaService.GetSalesMetrics(*acc.CNPJ, *acc.SecretName, filters)
+	if err := s.ssoticaLimiter.wait(ctx); err != nil {
+		return err
+	}
+	salesMetrics, err := s.ssoticaService.GetSalesMetrics(ctx, *acc.CNPJ, *acc.SecretName, filters)
+	s.stats.recordCall(domain.SyncSourceSSOtica, err)
+	s.ssoticaLimiter.observe(err)
 	if err != nil {
 		return fmt.Errorf("erro ao obter métricas de vendas: %w", err)
 	}
@@ -328,6 +382,7 @@ func (s *MonthlyInsightsSyncService) processMonthlySalesMetrics(acc *domain.AdAc
 	if err != nil {
 		return fmt.Errorf("erro ao salvar métricas mensais de vendas: %w", err)
 	}
+	s.stats.recordRowsWritten(domain.SyncSourceSSOtica, 1)
 
 	logrus.WithFields(logrus.Fields{
 		"account_id":  acc.ID,
@@ -341,28 +396,29 @@ func (s *MonthlyInsightsSyncService) processMonthlySalesMetrics(acc *domain.AdAc
 
 // TriggerManualSync inicia manualmente uma sincronização de insights mensais
 func (s *MonthlyInsightsSyncService) TriggerManualSync() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+	if s.jobs.isRunning() {
 		logrus.Info("Sincronização mensal de insights já em andamento, ignorando solicitação manual")
 		return
 	}
-	s.syncMutex.Unlock()
 
 	logrus.Info("Iniciando sincronização manual de insights mensais")
-	go s.syncMonthlyInsights()
+	go s.syncMonthlyInsights(s.runCtx, domain.JobTriggerManual)
+}
+
+// Paused informa se o agendador foi pausado pelas rotas de administração
+func (s *MonthlyInsightsSyncService) Paused() bool {
+	return s.jobs.isPaused()
 }
 
 // GetStatus retorna o status atual da sincronização
 func (s *MonthlyInsightsSyncService) GetStatus() map[string]any {
-	s.syncMutex.Lock()
-	defer s.syncMutex.Unlock()
-
-	return map[string]any{
-		"sync_running":           s.syncRunning,
-		"sync_cron":              s.config.CronSchedule,
-		"sync_enabled":           s.config.SyncEnabled,
-		"last_sync_started_at":   s.lastSyncStartedAt,
-		"last_sync_completed_at": s.lastSyncCompletedAt,
+	status := s.jobs.status()
+	status["sync_cron"] = s.config.CronSchedule
+	status["sync_enabled"] = s.config.SyncEnabled
+	status["next_run_at"] = nextRunAt(s.config.SyncEnabled, s.config.CronSchedule, time.Now())
+	status["rate_limit"] = map[string]any{
+		"meta":    s.metaLimiter.status(),
+		"ssotica": s.ssoticaLimiter.status(),
 	}
+	return status
 }
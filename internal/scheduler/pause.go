@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+)
+
+// ErrSchedulerPaused indica que a origem está pausada e não aceita sincronizações, nem manuais
+var ErrSchedulerPaused = errors.New("sincronização pausada para a origem")
+
+// pauseChecker consulta as pausas gravadas pelas rotas de administração. A pausa é lida a cada execução,
+// então vale para todas as instâncias da API sem reiniciar. Um pauseChecker nil nunca pausa.
+type pauseChecker struct {
+	repo repository.SchedulerPauseRepository
+}
+
+func newPauseChecker(repo repository.SchedulerPauseRepository) *pauseChecker {
+	if repo == nil {
+		return nil
+	}
+	return &pauseChecker{repo: repo}
+}
+
+// isPaused informa se o agendador está pausado. Se a pausa não puder ser consultada, o agendador segue
+// executando, como antes da pausa em tempo de execução existir.
+func (p *pauseChecker) isPaused(scheduler string) bool {
+	if p == nil {
+		return false
+	}
+
+	pause, err := p.repo.GetPause(scheduler)
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", scheduler).Error("Erro ao consultar pausa do agendador")
+		return false
+	}
+	return pause != nil
+}
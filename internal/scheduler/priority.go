@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
+)
+
+// prioritizeAccounts ordena as contas para que as lojas mais acompanhadas recebam dados atualizados
+// primeiro: as contas VIP vêm antes das demais e, em cada grupo, a ordem segue o score de atividade
+// (visualizações e investimento recentes). Sem prioritizer, ou se a consulta falhar, apenas as contas
+// VIP são antecipadas, mantendo a ordem original no restante.
+func prioritizeAccounts(prioritizer activity.Prioritizer, accounts []*domain.AdAccount) []*domain.AdAccount {
+	if len(accounts) < 2 {
+		return accounts
+	}
+
+	var priorities map[string]float64
+	if prioritizer != nil {
+		var err error
+		priorities, err = prioritizer.SyncPriorities()
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao consultar score de atividade das contas, mantendo ordem padrão")
+		}
+	}
+
+	ordered := make([]*domain.AdAccount, len(accounts))
+	copy(ordered, accounts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].VIP != ordered[j].VIP {
+			return ordered[i].VIP
+		}
+		return priorities[ordered[i].ID] > priorities[ordered[j].ID]
+	})
+
+	return ordered
+}
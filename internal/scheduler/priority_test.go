@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type fakePrioritizer struct {
+	priorities map[string]float64
+	err        error
+}
+
+func (f *fakePrioritizer) SyncPriorities() (map[string]float64, error) {
+	return f.priorities, f.err
+}
+
+func TestPrioritizeAccounts(t *testing.T) {
+	accounts := []*domain.AdAccount{{ID: "aaa111"}, {ID: "bbb222"}, {ID: "ccc333"}, {ID: "ddd444"}}
+
+	ids := func(accounts []*domain.AdAccount) []string {
+		result := make([]string, 0, len(accounts))
+		for _, account := range accounts {
+			result = append(result, account.ID)
+		}
+		return result
+	}
+
+	t.Run("ordena pelo score mantendo a ordem original nos empates", func(t *testing.T) {
+		prioritizer := &fakePrioritizer{priorities: map[string]float64{
+			"ccc333": domain.AccountActivityScore{Views: 12}.Score(),
+			"ddd444": domain.AccountActivityScore{Spend: 5000}.Score(),
+		}}
+
+		ordered := prioritizeAccounts(prioritizer, accounts)
+		assert.Equal(t, []string{"ddd444", "ccc333", "aaa111", "bbb222"}, ids(ordered))
+		assert.Equal(t, []string{"aaa111", "bbb222", "ccc333", "ddd444"}, ids(accounts))
+	})
+
+	t.Run("mantém a ordem quando a consulta falha", func(t *testing.T) {
+		ordered := prioritizeAccounts(&fakePrioritizer{err: errors.New("falha")}, accounts)
+		assert.Equal(t, ids(accounts), ids(ordered))
+	})
+
+	t.Run("sem prioritizer", func(t *testing.T) {
+		assert.Equal(t, ids(accounts), ids(prioritizeAccounts(nil, accounts)))
+	})
+
+	t.Run("contas VIP antes do score de atividade", func(t *testing.T) {
+		withVIP := []*domain.AdAccount{{ID: "aaa111"}, {ID: "bbb222", VIP: true}, {ID: "ccc333"}, {ID: "ddd444", VIP: true}}
+		prioritizer := &fakePrioritizer{priorities: map[string]float64{
+			"ccc333": domain.AccountActivityScore{Views: 12}.Score(),
+			"ddd444": domain.AccountActivityScore{Spend: 5000}.Score(),
+		}}
+
+		assert.Equal(t, []string{"ddd444", "bbb222", "ccc333", "aaa111"}, ids(prioritizeAccounts(prioritizer, withVIP)))
+		assert.Equal(t, []string{"bbb222", "ddd444", "aaa111", "ccc333"}, ids(prioritizeAccounts(nil, withVIP)))
+	})
+}
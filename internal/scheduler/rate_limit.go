@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/pkg/ratelimit"
+)
+
+// RateLimiter limita as requisições dos agendadores a um integrador. Uma única instância por integrador é
+// compartilhada por todos os agendadores e goroutines que o consultam, então MaxConcurrentJobs define apenas
+// quantas contas ficam em andamento, e não a vazão. Quando o integrador recusa uma requisição por limite,
+// as requisições seguintes são suspensas por cooldown. Um RateLimiter nil não limita nada.
+type RateLimiter struct {
+	provider string
+	bucket   *ratelimit.TokenBucket
+	burst    int
+	cooldown time.Duration
+}
+
+// NewRateLimiter cria o limite do integrador provider (domain.SyncSourceMeta, domain.SyncSourceSSOtica).
+// Com requestsPerSecond <= 0, as requisições não são limitadas.
+func NewRateLimiter(provider string, requestsPerSecond float64, burst int, cooldown time.Duration) *RateLimiter {
+	return &RateLimiter{
+		provider: provider,
+		bucket:   ratelimit.NewTokenBucket(requestsPerSecond, burst),
+		burst:    burst,
+		cooldown: cooldown,
+	}
+}
+
+// wait aguarda a vez da próxima requisição ao integrador ou o cancelamento do contexto
+func (l *RateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	return l.bucket.Wait(ctx)
+}
+
+// observe suspende as requisições ao integrador por cooldown quando ele recusou a requisição por limite
+func (l *RateLimiter) observe(err error) {
+	if l == nil || err == nil || l.cooldown <= 0 || classifyError(err) != errorClassRateLimit {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"provider": l.provider,
+		"cooldown": l.cooldown.String(),
+	}).Warn("Limite de requisições do integrador atingido, suspendendo as requisições")
+	l.bucket.Drain(l.cooldown)
+}
+
+// status retorna a configuração do limite, para o GetStatus
+func (l *RateLimiter) status() map[string]any {
+	if l == nil {
+		return nil
+	}
+	return map[string]any{
+		"requests_per_second": l.bucket.Rate(),
+		"burst":               l.burst,
+		"cooldown":            l.cooldown.String(),
+	}
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestRateLimiter_CooldownOnRateLimit(t *testing.T) {
+	limiter := NewRateLimiter(domain.SyncSourceMeta, 1000, 1, time.Hour)
+
+	assert.NoError(t, limiter.wait(context.Background()))
+
+	// Erros que não são de limite não suspendem as requisições
+	limiter.observe(errors.New("erro ao obter insights: status 500"))
+	limiter.observe(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, limiter.wait(ctx))
+
+	limiter.observe(errors.New(`erro ao obter insights: {"error":{"message":"User request limit reached","code":17}}`))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var limiter *RateLimiter
+	assert.NoError(t, limiter.wait(context.Background()))
+	limiter.observe(errors.New("too many requests"))
+	assert.Nil(t, limiter.status())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.wait(ctx), context.Canceled)
+}
@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authorizing"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/masking"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/reporting"
+)
+
+const (
+	// reportScheduleBatchSize limita os envios processados em cada execução do agendador
+	reportScheduleBatchSize = 50
+	// reportScheduleLockName identifica o agendador no lock entre as instâncias da API e no agendador da aplicação
+	reportScheduleLockName = "report_schedules"
+)
+
+type ReportScheduleConfig struct {
+	CronSchedule string
+	Enabled      bool
+}
+
+// ReportScheduleService envia os relatórios agendados pelos usuários cujo horário já venceu
+type ReportScheduleService struct {
+	scheduleRepo  repository.ReportScheduleRepository
+	reportRepo    repository.SavedReportRepository
+	userRepo      repository.UserRepository
+	reportService reporting.SavedReportService
+	fieldMasker   masking.FieldMasker
+	policy        authorizing.Policy
+	mailer        notifying.Mailer
+	deliverer     notifying.Deliverer
+	config        ReportScheduleConfig
+	runMutex      sync.Mutex
+	jobs          *jobRunner
+	now           func() time.Time
+
+	lastRunStartedAt   time.Time
+	lastRunCompletedAt time.Time
+}
+
+// NewReportScheduleService cria o agendador. mailer pode ser nil quando o SMTP não estiver configurado;
+// nesse caso os envios por e-mail falham e o erro fica registrado no agendamento.
+func NewReportScheduleService(
+	scheduleRepo repository.ReportScheduleRepository,
+	reportRepo repository.SavedReportRepository,
+	userRepo repository.UserRepository,
+	reportService reporting.SavedReportService,
+	fieldMasker masking.FieldMasker,
+	policy authorizing.Policy,
+	mailer notifying.Mailer,
+	deliverer notifying.Deliverer,
+	cfg *config.Config,
+	opts ...JobOption,
+) *ReportScheduleService {
+	scheduleConfig := ReportScheduleConfig{
+		CronSchedule: cfg.ReportSchedule.CronSchedule,
+		Enabled:      cfg.ReportSchedule.Enabled,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cron_schedule": scheduleConfig.CronSchedule,
+		"enabled":       scheduleConfig.Enabled,
+	}).Info("Configuração do agendador de relatórios carregada")
+
+	return &ReportScheduleService{
+		jobs:          newJobRunner(reportScheduleLockName, nil, opts...),
+		scheduleRepo:  scheduleRepo,
+		reportRepo:    reportRepo,
+		userRepo:      userRepo,
+		reportService: reportService,
+		fieldMasker:   fieldMasker,
+		policy:        policy,
+		mailer:        mailer,
+		deliverer:     deliverer,
+		config:        scheduleConfig,
+		now:           time.Now,
+	}
+}
+
+func (s *ReportScheduleService) Start(ctx context.Context, scheduler *Scheduler) error {
+	if !s.config.Enabled {
+		logrus.Info("Envio de relatórios agendados desabilitado por configuração")
+		return nil
+	}
+
+	err := scheduler.Cron(reportScheduleLockName, s.config.CronSchedule, func() {
+		if err := s.RunDueSchedules(); err != nil {
+			logrus.WithError(err).Error("Erro no envio de relatórios agendados")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao agendar envio de relatórios: %w", err)
+	}
+
+	return nil
+}
+
+// RunDueSchedules envia os relatórios vencidos. Execuções simultâneas, nesta ou em outra instância, são ignoradas.
+func (s *ReportScheduleService) RunDueSchedules() error {
+	if !s.runMutex.TryLock() {
+		logrus.Warn("Envio de relatórios agendados já está em execução")
+		return nil
+	}
+	defer s.runMutex.Unlock()
+
+	// Com várias instâncias da API, apenas uma envia os relatórios, para que ninguém os receba em dobro
+	unlock, acquired := s.jobs.lock.acquire(reportScheduleLockName)
+	if !acquired {
+		return nil
+	}
+	defer unlock()
+
+	s.lastRunStartedAt = s.now()
+	defer func() { s.lastRunCompletedAt = s.now() }()
+
+	schedules, err := s.scheduleRepo.ListDueSchedules(s.now(), reportScheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		s.runSchedule(schedule)
+	}
+
+	if len(schedules) > 0 {
+		logrus.WithField("schedules", len(schedules)).Info("Relatórios agendados processados")
+	}
+
+	return nil
+}
+
+// TriggerManualSync processa os relatórios vencidos imediatamente, em segundo plano
+func (s *ReportScheduleService) TriggerManualSync() {
+	logrus.Info("Iniciando envio manual de relatórios agendados")
+	go func() {
+		if err := s.RunDueSchedules(); err != nil {
+			logrus.WithError(err).Error("Erro no envio manual de relatórios agendados")
+		}
+	}()
+}
+
+// GetStatus retorna o status atual do agendador
+func (s *ReportScheduleService) GetStatus() map[string]any {
+	return map[string]any{
+		"sync_enabled":           s.config.Enabled,
+		"sync_cron":              s.config.CronSchedule,
+		"next_run_at":            nextRunAt(s.config.Enabled, s.config.CronSchedule, time.Now()),
+		"last_sync_started_at":   s.lastRunStartedAt,
+		"last_sync_completed_at": s.lastRunCompletedAt,
+	}
+}
+
+func (s *ReportScheduleService) runSchedule(schedule *domain.ReportSchedule) {
+	logger := logrus.WithFields(logrus.Fields{
+		"schedule_id": schedule.ID,
+		"report_id":   schedule.ReportID,
+		"channel":     schedule.Channel,
+	})
+
+	runAt := s.now()
+	nextRun, err := schedule.NextRun(runAt)
+	if err != nil {
+		logger.WithError(err).Error("Agendamento de relatório inválido, desativando")
+		s.deactivate(schedule, err.Error())
+		return
+	}
+
+	if err := s.deliver(schedule); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, reporting.ErrReportNotFound) {
+			logger.WithError(err).Warn("Usuário ou relatório removido, desativando agendamento")
+			s.deactivate(schedule, "usuário ou relatório removido")
+			return
+		}
+
+		logger.WithError(err).Error("Erro ao enviar relatório agendado")
+		message := err.Error()
+		if markErr := s.scheduleRepo.MarkScheduleRun(schedule.ID, runAt, nextRun, &message); markErr != nil {
+			logger.WithError(markErr).Error("Erro ao registrar falha do agendamento")
+		}
+		return
+	}
+
+	if err := s.scheduleRepo.MarkScheduleRun(schedule.ID, runAt, nextRun, nil); err != nil {
+		logger.WithError(err).Error("Erro ao registrar execução do agendamento")
+		return
+	}
+
+	logger.WithField("next_run_at", nextRun.Format(time.RFC3339)).Info("Relatório agendado enviado")
+}
+
+// deliver executa o relatório com as permissões atuais do usuário, remove as métricas ocultas
+// para o perfil dele e envia pelo canal do agendamento
+func (s *ReportScheduleService) deliver(schedule *domain.ReportSchedule) error {
+	user, err := s.userRepo.GetUserByID(schedule.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.Active {
+		return fmt.Errorf("usuário inativo")
+	}
+
+	report, err := s.reportRepo.GetSavedReport(schedule.UserID, schedule.ReportID)
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		return reporting.ErrReportNotFound
+	}
+
+	unrestricted := s.policy.HasPermission(user.RoleID, domain.PermissionAccountsAll)
+	run, err := s.reportService.ExecuteReport(report, unrestricted)
+	if err != nil {
+		return err
+	}
+	hidden, err := s.fieldMasker.MaskedFields(user.RoleID)
+	if err != nil {
+		return err
+	}
+	run.RemoveMetrics(hidden)
+
+	switch schedule.Channel {
+	case domain.DeliveryEmail:
+		if s.mailer == nil {
+			return notifying.ErrEmailNotConfigured
+		}
+		body, err := reporting.RenderReportHTML(run)
+		if err != nil {
+			return err
+		}
+		return s.mailer.SendEmail(schedule.EmailRecipients(), reporting.ReportSubject(run), body)
+
+	case domain.DeliveryWebhook:
+		return s.deliverer.Deliver(schedule.Destination, schedule.Secret, domain.ReportDeliveredEvent, run)
+
+	default:
+		return fmt.Errorf("canal de entrega desconhecido: %s", schedule.Channel)
+	}
+}
+
+func (s *ReportScheduleService) deactivate(schedule *domain.ReportSchedule, reason string) {
+	if err := s.scheduleRepo.DeactivateSchedule(schedule.ID, reason); err != nil {
+		logrus.WithError(err).WithField("schedule_id", schedule.ID).Error("Erro ao desativar agendamento de relatório")
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// maxRetryBackoff limita a espera entre as tentativas, por maior que seja o número de tentativas configurado
+const maxRetryBackoff = 5 * time.Minute
+
+// retryPolicy define as novas tentativas de uma conta/data cuja sincronização falhou
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+	// jitter sorteia a espera efetiva a partir do backoff da tentativa; substituído nos testes
+	jitter func(time.Duration) time.Duration
+}
+
+func newRetryPolicy(maxRetries int, backoff time.Duration) retryPolicy {
+	return retryPolicy{
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		jitter:     equalJitter,
+	}
+}
+
+// do executa fn até o sucesso ou o fim das tentativas, com backoff exponencial e jitter entre elas.
+// Erros de autenticação e o cancelamento do contexto não são repetidos. O erro final informa o
+// número de tentativas quando houve mais de uma.
+func (p retryPolicy) do(ctx context.Context, fn func() error) error {
+	backoff := p.backoff
+	attempt := 1
+	for {
+		err := fn()
+		if err == nil || attempt > p.maxRetries || !retryable(err) {
+			return attemptsError(attempt, err)
+		}
+
+		wait := backoff
+		if p.jitter != nil {
+			wait = p.jitter(backoff)
+		}
+		if !sleepWithContext(ctx, wait) {
+			return attemptsError(attempt, err)
+		}
+
+		backoff = min(backoff*2, maxRetryBackoff)
+		attempt++
+	}
+}
+
+func attemptsError(attempts int, err error) error {
+	if err == nil || attempts == 1 {
+		return err
+	}
+	return fmt.Errorf("%d tentativas: %w", attempts, err)
+}
+
+// retryable informa se uma nova tentativa pode ter resultado diferente. Credenciais inválidas
+// continuam inválidas até alguém corrigi-las.
+func retryable(err error) bool {
+	switch classifyError(err) {
+	case errorClassCanceled, errorClassAuth:
+		return false
+	default:
+		return true
+	}
+}
+
+// equalJitter espera entre metade e o total do backoff, para que as contas que falharam juntas
+// não repitam as requisições ao mesmo tempo
+func equalJitter(backoff time.Duration) time.Duration {
+	if backoff <= 1 {
+		return backoff
+	}
+	half := backoff / 2
+	return half + rand.N(backoff-half)
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Do(t *testing.T) {
+	var waits []time.Duration
+	policy := newRetryPolicy(3, time.Millisecond)
+	policy.jitter = func(backoff time.Duration) time.Duration {
+		waits = append(waits, backoff)
+		return 0
+	}
+
+	t.Run("sucesso depois de falhas transitórias", func(t *testing.T) {
+		waits = nil
+		calls := 0
+		err := policy.do(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("request timeout")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, waits)
+	})
+
+	t.Run("erro final depois de esgotar as tentativas", func(t *testing.T) {
+		calls := 0
+		cause := errors.New("status 500")
+		err := policy.do(context.Background(), func() error {
+			calls++
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+		assert.EqualError(t, err, "4 tentativas: status 500")
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("erro de autenticação não é repetido", func(t *testing.T) {
+		calls := 0
+		err := policy.do(context.Background(), func() error {
+			calls++
+			return errors.New("invalid access token")
+		})
+		assert.EqualError(t, err, "invalid access token")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("contexto cancelado durante a espera", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := newRetryPolicy(3, time.Hour).do(ctx, func() error {
+			calls++
+			cancel()
+			return errors.New("status 502")
+		})
+		assert.EqualError(t, err, "status 502")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestEqualJitter(t *testing.T) {
+	for range 100 {
+		wait := equalJitter(10 * time.Second)
+		assert.GreaterOrEqual(t, wait, 5*time.Second)
+		assert.Less(t, wait, 10*time.Second)
+	}
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+)
+
+// RollupInsightsSyncConfig representa a configuração do agendador de consolidação trimestral e anual
+type RollupInsightsSyncConfig struct {
+	CronSchedule string
+	SyncEnabled  bool
+}
+
+// RollupInsightsSyncService recalcula os insights trimestrais e anuais a partir dos insights mensais
+type RollupInsightsSyncService struct {
+	config        RollupInsightsSyncConfig
+	accountRepo   repository.AccountRepository
+	rollupService insighting.RollupInsighter
+	jobs          *jobRunner
+}
+
+// NewRollupInsightsSyncService cria uma nova instância do serviço de consolidação trimestral e anual
+func NewRollupInsightsSyncService(
+	accountRepo repository.AccountRepository,
+	rollupService insighting.RollupInsighter,
+	appConfig *config.Config,
+	opts ...JobOption,
+) *RollupInsightsSyncService {
+	rollupConfig := RollupInsightsSyncConfig{
+		CronSchedule: appConfig.RollupInsightsSync.CronSchedule,
+		SyncEnabled:  appConfig.RollupInsightsSync.Enabled,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"cron_schedule": rollupConfig.CronSchedule,
+		"sync_enabled":  rollupConfig.SyncEnabled,
+	}).Info("Configuração do agendador de insights trimestrais e anuais carregada")
+
+	return &RollupInsightsSyncService{
+		jobs:          newJobRunner(domain.SchedulerRollupInsightsSync, nil, opts...),
+		config:        rollupConfig,
+		accountRepo:   accountRepo,
+		rollupService: rollupService,
+	}
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *RollupInsightsSyncService) Start(ctx context.Context, scheduler *Scheduler) error {
+	if !s.config.SyncEnabled {
+		logrus.Info("Consolidação de insights trimestrais e anuais desabilitada por configuração")
+		return nil
+	}
+
+	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de insights trimestrais e anuais")
+
+	// O encerramento da aplicação aguarda as execuções em andamento, inclusive a de recuperação
+	s.jobs.runs = scheduler.runs
+
+	err := scheduler.Cron(domain.SchedulerRollupInsightsSync, s.config.CronSchedule, func() {
+		s.syncRollupInsights(ctx, domain.JobTriggerCron)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao agendar consolidação de insights trimestrais e anuais: %w", err)
+	}
+
+	// Executar a consolidação perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() { s.syncRollupInsights(ctx, domain.JobTriggerCatchUp) })
+
+	return nil
+}
+
+// syncRollupInsights executa a consolidação, se não houver outra em andamento nesta ou em outra instância da API
+func (s *RollupInsightsSyncService) syncRollupInsights(ctx context.Context, trigger string) {
+	started := s.jobs.run(trigger, nil, func(startTime time.Time) error {
+		return s.rebuildRollups(ctx, startTime)
+	})
+	if !started {
+		logrus.Info("Consolidação de insights trimestrais e anuais já em andamento, ignorando")
+	}
+}
+
+// rebuildRollups recalcula o trimestre e o ano do mês anterior para todas as contas ativas, que é o mês
+// consolidado pela sincronização mensal. Interrompida pelo encerramento da aplicação, não é registrada como
+// concluída e é refeita pela recuperação de execuções perdidas.
+func (s *RollupInsightsSyncService) rebuildRollups(ctx context.Context, startTime time.Time) error {
+	accounts, err := s.accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao buscar lista de contas para consolidação de insights trimestrais e anuais")
+		return err
+	}
+
+	now := time.Now()
+	previousMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+
+	failed := 0
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			logrus.Warn("Consolidação de insights trimestrais e anuais interrompida pelo encerramento da aplicação")
+			return err
+		}
+		if err := s.rollupService.RebuildAccountRollups(account, previousMonth); err != nil {
+			failed++
+			logrus.WithError(err).WithField("account_id", account.ID).Error("Erro ao consolidar insights trimestrais e anuais da conta")
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"duration": time.Since(startTime).String(),
+		"accounts": len(accounts),
+		"failed":   failed,
+		"month":    previousMonth.Format("01-2006"),
+	}).Info("Consolidação de insights trimestrais e anuais concluída")
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// runHistory persiste as execuções de um agendador para que, na inicialização, seja possível
+// identificar uma execução perdida (serviço reiniciado ou hibernado no horário do cron) e executá-la.
+// Um runHistory nil não registra nada e nunca dispara recuperação.
+type runHistory struct {
+	repo           repository.SchedulerRunRepository
+	name           string
+	catchUpEnabled bool
+	catchUpDelay   time.Duration
+	now            func() time.Time
+}
+
+func newRunHistory(repo repository.SchedulerRunRepository, name string, cfg config.SchedulerCatchUp) *runHistory {
+	return &runHistory{
+		repo:           repo,
+		name:           name,
+		catchUpEnabled: cfg.Enabled,
+		catchUpDelay:   cfg.Delay,
+		now:            time.Now,
+	}
+}
+
+func (h *runHistory) markStarted(at time.Time) {
+	if h == nil {
+		return
+	}
+	if err := h.repo.MarkStarted(h.name, at); err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao registrar início da execução do agendador")
+	}
+}
+
+func (h *runHistory) markSucceeded(startedAt time.Time) {
+	if h == nil {
+		return
+	}
+	if err := h.repo.MarkSucceeded(h.name, startedAt); err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao registrar execução concluída do agendador")
+	}
+}
+
+// loadCheckpoint retorna o progresso da última execução interrompida, se houver
+func (h *runHistory) loadCheckpoint() *domain.SyncCheckpoint {
+	if h == nil {
+		return nil
+	}
+
+	run, err := h.repo.GetRun(h.name)
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao consultar checkpoint do agendador")
+		return nil
+	}
+	if run == nil {
+		return nil
+	}
+	return run.Checkpoint
+}
+
+// saveCheckpoint grava o progresso da execução interrompida; nil remove o checkpoint anterior
+func (h *runHistory) saveCheckpoint(checkpoint *domain.SyncCheckpoint) {
+	if h == nil {
+		return
+	}
+	if err := h.repo.SaveCheckpoint(h.name, checkpoint); err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao registrar checkpoint do agendador")
+	}
+}
+
+// saveStats grava as estatísticas por provedor da execução
+func (h *runHistory) saveStats(stats map[string]*domain.ProviderStats) {
+	if h == nil {
+		return
+	}
+	if err := h.repo.SaveStats(h.name, stats); err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao registrar estatísticas do agendador")
+	}
+}
+
+// catchUp executa run em background, após o atraso configurado, se alguma ocorrência do cron
+// ficou sem execução desde o último sucesso. Sem histórico (primeira implantação) nada é executado.
+func (h *runHistory) catchUp(cronSpec string, run func()) {
+	if h == nil || !h.catchUpEnabled {
+		return
+	}
+
+	schedule, err := cron.ParseStandard(cronSpec)
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Warn("Cron inválido, recuperação de execução perdida ignorada")
+		return
+	}
+
+	lastRun, err := h.repo.GetRun(h.name)
+	if err != nil {
+		logrus.WithError(err).WithField("scheduler", h.name).Error("Erro ao consultar última execução do agendador")
+		return
+	}
+	if lastRun == nil || lastRun.LastSuccessAt == nil {
+		logrus.WithField("scheduler", h.name).Info("Agendador sem execução registrada, nenhuma recuperação necessária")
+		return
+	}
+
+	missed, ok := missedRun(schedule, *lastRun.LastSuccessAt, h.now())
+	if !ok {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scheduler":       h.name,
+		"last_success_at": lastRun.LastSuccessAt.Format(time.RFC3339),
+		"missed_at":       missed.Format(time.RFC3339),
+	}).Warn("Execução agendada perdida, executando recuperação")
+
+	go func() {
+		time.Sleep(h.catchUpDelay)
+		run()
+	}()
+}
+
+// nextRunAt retorna a próxima ocorrência do cron depois de now, exibida em GetStatus, ou nil com o agendador
+// desabilitado. A expressão já foi validada na carga da configuração.
+func nextRunAt(enabled bool, cronSpec string, now time.Time) *time.Time {
+	if !enabled {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(cronSpec)
+	if err != nil {
+		return nil
+	}
+	next := schedule.Next(now)
+	return &next
+}
+
+// missedRun retorna a primeira ocorrência do cron entre o último sucesso e agora, se houver.
+// Várias ocorrências perdidas resultam em uma única execução, já que cada sincronização cobre sua janela completa.
+func missedRun(schedule cron.Schedule, lastSuccess, now time.Time) (time.Time, bool) {
+	next := schedule.Next(lastSuccess)
+	if next.IsZero() || next.After(now) {
+		return time.Time{}, false
+	}
+	return next, true
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissedRun(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 3 * * *")
+	assert.NoError(t, err)
+
+	lastSuccess := time.Date(2025, 3, 10, 3, 0, 5, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantMissed bool
+		wantAt     time.Time
+	}{
+		{
+			name:       "antes do próximo horário",
+			now:        time.Date(2025, 3, 11, 2, 59, 0, 0, time.Local),
+			wantMissed: false,
+		},
+		{
+			name:       "serviço parado às 3h",
+			now:        time.Date(2025, 3, 11, 8, 0, 0, 0, time.Local),
+			wantMissed: true,
+			wantAt:     time.Date(2025, 3, 11, 3, 0, 0, 0, time.Local),
+		},
+		{
+			name:       "várias noites perdidas retornam a primeira",
+			now:        time.Date(2025, 3, 14, 8, 0, 0, 0, time.Local),
+			wantMissed: true,
+			wantAt:     time.Date(2025, 3, 11, 3, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, missed := missedRun(schedule, lastSuccess, tt.now)
+			assert.Equal(t, tt.wantMissed, missed)
+			if tt.wantMissed {
+				assert.True(t, tt.wantAt.Equal(at))
+			}
+		})
+	}
+}
+
+func TestNextRunAt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 3, 0, 5, 0, time.Local)
+
+	next := nextRunAt(true, "0 3 * * *", now)
+	assert.Equal(t, time.Date(2025, 3, 11, 3, 0, 0, 0, time.Local), *next)
+
+	// Agendador desabilitado não tem próxima execução
+	assert.Nil(t, nextRunAt(false, "0 3 * * *", now))
+}
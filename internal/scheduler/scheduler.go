@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// Scheduler é o agendador único da aplicação, criado no main.go. Cada serviço registra as suas rotinas em
+// Start, com um nome único, e o main.go inicia o agendador depois de todos os registros. As rotinas rodam em
+// goroutines próprias, então uma sincronização longa não atrasa as demais.
+//
+// As execuções dos serviços usam Context, que só é cancelado no encerramento da aplicação (Shutdown), depois
+// da espera pelas execuções em andamento.
+type Scheduler struct {
+	cron   *gocron.Scheduler
+	ctx    context.Context
+	cancel context.CancelFunc
+	runs   *inFlight
+}
+
+func NewScheduler(ctx context.Context) *Scheduler {
+	cron := gocron.NewScheduler(time.Local)
+	cron.TagsUnique()
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scheduler{
+		cron:   cron,
+		ctx:    ctx,
+		cancel: cancel,
+		runs:   newInFlight(),
+	}
+}
+
+// Context é o contexto das execuções dos serviços, cancelado no encerramento
+func (s *Scheduler) Context() context.Context {
+	return s.ctx
+}
+
+// Cron registra fn na expressão cronSpec
+func (s *Scheduler) Cron(name, cronSpec string, fn func()) error {
+	_, err := s.cron.Cron(cronSpec).Tag(name).Do(s.tracked(name, fn))
+	return err
+}
+
+// Every registra fn a cada interval, a partir do início do agendador
+func (s *Scheduler) Every(name string, interval time.Duration, fn func()) error {
+	_, err := s.cron.Every(interval).Tag(name).Do(s.tracked(name, fn))
+	return err
+}
+
+// tracked faz o encerramento aguardar a execução de fn e ignora as ocorrências durante o encerramento
+func (s *Scheduler) tracked(name string, fn func()) func() {
+	return func() {
+		done, ok := s.runs.begin(name)
+		if !ok {
+			return
+		}
+		defer done()
+		fn()
+	}
+}
+
+// Start inicia as rotinas registradas
+func (s *Scheduler) Start() {
+	logrus.WithField("jobs", len(s.cron.Jobs())).Info("Iniciando agendador")
+	s.cron.StartAsync()
+}
+
+// Shutdown para o agendador e aguarda as execuções em andamento por até grace. As que continuarem em
+// andamento são canceladas e gravam o checkpoint, retomado na próxima execução; Shutdown aguarda o fim
+// delas até o prazo de ctx.
+func (s *Scheduler) Shutdown(ctx context.Context, grace time.Duration) error {
+	logrus.WithFields(logrus.Fields{
+		"running": s.runs.names(),
+		"grace":   grace.String(),
+	}).Info("Parando agendador e aguardando as execuções em andamento")
+
+	s.runs.close()
+	// O gocron aguarda as rotinas em andamento ao parar, então a espera fica com s.runs
+	go s.cron.Stop()
+
+	graceCtx, cancelGrace := context.WithTimeout(ctx, grace)
+	err := s.runs.wait(graceCtx)
+	cancelGrace()
+	if err == nil {
+		s.cancel()
+		logrus.Info("Execuções em andamento concluídas")
+		return nil
+	}
+
+	running := s.runs.names()
+	logrus.WithField("running", running).Warn("Execuções ainda em andamento, interrompendo para gravar o checkpoint")
+	s.cancel()
+
+	if err := s.runs.wait(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("execuções não encerradas a tempo: %s", strings.Join(s.runs.names(), ", "))
+		}
+		return err
+	}
+
+	logrus.WithField("interrupted", running).Info("Execuções em andamento interrompidas")
+	return nil
+}
+
+// Status lista as rotinas registradas com a próxima e a última execução, exibidas em GetCronStatus
+func (s *Scheduler) Status() []*domain.ScheduledJob {
+	jobs := s.cron.Jobs()
+	status := make([]*domain.ScheduledJob, 0, len(jobs))
+	for _, job := range jobs {
+		scheduled := &domain.ScheduledJob{
+			Running:  job.IsRunning(),
+			RunCount: job.RunCount(),
+		}
+		if tags := job.Tags(); len(tags) > 0 {
+			scheduled.Name = tags[0]
+		}
+		if next := job.NextRun(); !next.IsZero() {
+			scheduled.NextRunAt = &next
+		}
+		if last := job.LastRun(); !last.IsZero() {
+			scheduled.LastRunAt = &last
+		}
+		status = append(status, scheduled)
+	}
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Name < status[j].Name
+	})
+	return status
+}
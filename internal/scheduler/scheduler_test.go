@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestScheduler_Register(t *testing.T) {
+	scheduler := NewScheduler(context.Background())
+
+	require.NoError(t, scheduler.Cron(domain.SchedulerMetaInsightSync, "0 3 * * *", func() {}))
+	require.NoError(t, scheduler.Every("account_schedules:meta", time.Hour, func() {}))
+
+	// Nomes repetidos e expressões inválidas são recusados
+	assert.Error(t, scheduler.Cron(domain.SchedulerMetaInsightSync, "0 4 * * *", func() {}))
+	assert.Error(t, scheduler.Cron(domain.SchedulerBudgetPacing, "0 3 * *", func() {}))
+
+	scheduler.Start()
+	defer scheduler.Shutdown(context.Background(), 0)
+
+	status := scheduler.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "account_schedules:meta", status[0].Name)
+	assert.Equal(t, domain.SchedulerMetaInsightSync, status[1].Name)
+	require.NotNil(t, status[1].NextRunAt)
+	assert.Equal(t, 3, status[1].NextRunAt.Hour())
+	assert.Zero(t, status[1].RunCount)
+}
+
+func TestScheduler_Shutdown(t *testing.T) {
+	t.Run("aguarda as execuções em andamento", func(t *testing.T) {
+		scheduler := NewScheduler(context.Background())
+		done := scheduler.runs.track("backfill:meta")
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			done()
+		}()
+
+		assert.NoError(t, scheduler.Shutdown(context.Background(), time.Second))
+		// O contexto das execuções só é cancelado depois da espera
+		assert.Error(t, scheduler.Context().Err())
+
+		// Depois do encerramento, novas execuções não começam
+		ran := false
+		scheduler.tracked(domain.SchedulerMetaInsightSync, func() { ran = true })()
+		assert.False(t, ran)
+	})
+
+	t.Run("interrompe as execuções depois da espera", func(t *testing.T) {
+		scheduler := NewScheduler(context.Background())
+		done := scheduler.runs.track(domain.SchedulerMetaInsightSync)
+		go func() {
+			<-scheduler.Context().Done()
+			done()
+		}()
+
+		assert.NoError(t, scheduler.Shutdown(context.Background(), 10*time.Millisecond))
+	})
+
+	t.Run("informa as execuções que não terminaram no prazo", func(t *testing.T) {
+		scheduler := NewScheduler(context.Background())
+		scheduler.runs.track("account_sync:meta:ABC123")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		err := scheduler.Shutdown(ctx, 10*time.Millisecond)
+		assert.EqualError(t, err, "execuções não encerradas a tempo: account_sync:meta:ABC123")
+	})
+}
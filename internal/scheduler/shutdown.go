@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// inFlight acompanha as execuções em andamento (sincronizações pelo cron, manuais, de recuperação,
+// backfills e sincronizações de uma conta) para que o encerramento da aplicação as aguarde. Um inFlight nil
+// não acompanha nada, como nos testes.
+type inFlight struct {
+	mu      sync.Mutex
+	running map[string]int
+	closing bool
+	// idle é fechado quando a última execução em andamento termina
+	idle chan struct{}
+}
+
+func newInFlight() *inFlight {
+	return &inFlight{running: make(map[string]int)}
+}
+
+// begin registra o início de uma execução e retorna a função que registra o fim. Retorna false durante o
+// encerramento: novas execuções não começam.
+func (f *inFlight) begin(name string) (func(), bool) {
+	if f == nil {
+		return func() {}, true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closing {
+		return nil, false
+	}
+	return f.add(name), true
+}
+
+// track registra uma execução já aceita (ex: backfill iniciado pela API) mesmo durante o encerramento
+func (f *inFlight) track(name string) func() {
+	if f == nil {
+		return func() {}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.add(name)
+}
+
+// add deve ser chamado com f.mu travado
+func (f *inFlight) add(name string) func() {
+	if len(f.running) == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.running[name]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.running[name]--
+			if f.running[name] == 0 {
+				delete(f.running, name)
+			}
+			if len(f.running) == 0 {
+				close(f.idle)
+			}
+		})
+	}
+}
+
+// close impede o início de novas execuções
+func (f *inFlight) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closing = true
+}
+
+// wait aguarda o fim das execuções em andamento ou o cancelamento de ctx
+func (f *inFlight) wait(ctx context.Context) error {
+	f.mu.Lock()
+	if len(f.running) == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// names lista as execuções em andamento
+func (f *inFlight) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(f.running))
+	for name := range f.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
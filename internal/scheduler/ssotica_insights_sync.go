@@ -7,35 +7,47 @@ import (
 	"sync"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/activity"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/insighting"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
 )
 
 // SSOticaInsightSyncConfig representa a configuração do agendador de insights do SSOtica
 type SSOticaInsightSyncConfig struct {
-	CronSchedule        string
-	LookbackDays        int
-	RequestDelaySeconds int
-	MaxConcurrentJobs   int
-	SyncEnabled         bool
+	CronSchedule      string
+	LookbackDays      int
+	MaxConcurrentJobs int
+	SyncEnabled       bool
+	DeltaEnabled      bool
+	SettleDays        int
+	MaxDuration       time.Duration
+	MaxRetries        int
+	RetryBackoff      time.Duration
 }
 
 // SSOticaInsightSyncService gerencia o agendamento e execução da sincronização de insights do SSOtica
 type SSOticaInsightSyncService struct {
-	scheduler           *gocron.Scheduler
-	config              SSOticaInsightSyncConfig
-	appConfig           *config.Config
-	accountRepo         repository.AccountRepository
-	salesInsightRepo    repository.SalesInsightRepository
-	ssoticaService      insighting.SSOticaInsighter
-	syncRunning         bool
-	syncMutex           sync.Mutex
-	lastSyncStartedAt   time.Time
-	lastSyncCompletedAt time.Time
+	config           SSOticaInsightSyncConfig
+	appConfig        *config.Config
+	accountRepo      repository.AccountRepository
+	salesInsightRepo repository.SalesInsightRepository
+	ssoticaService   insighting.SSOticaInsighter
+	activityRecorder activity.Recorder
+	accountSchedules *accountSchedules
+	prioritizer      activity.Prioritizer
+	accountChanges   *AccountChangeTracker
+	stats            *runStats
+	jobs             *jobRunner
+	accountSyncs     *accountSyncs
+	retry            retryPolicy
+	backfill         *backfillRunner
+	failures         *failureQueue
+	limiter          *RateLimiter
+	runCtx           context.Context
 }
 
 // NewSSOticaInsightSyncService cria uma nova instância do serviço de sincronização de insights do SSOtica
@@ -44,40 +56,103 @@ func NewSSOticaInsightSyncService(
 	salesInsightRepo repository.SalesInsightRepository,
 	ssoticaService insighting.SSOticaInsighter,
 	appConfig *config.Config,
+	opts ...JobOption,
 ) *SSOticaInsightSyncService {
 	// Criar a configuração com base na config global
 	insightConfig := SSOticaInsightSyncConfig{
-		CronSchedule:        appConfig.SSOticaInsightSync.CronSchedule,
-		LookbackDays:        appConfig.SSOticaInsightSync.LookbackDays,
-		RequestDelaySeconds: appConfig.SSOticaInsightSync.RequestDelaySeconds,
-		MaxConcurrentJobs:   appConfig.SSOticaInsightSync.MaxConcurrentJobs,
-		SyncEnabled:         appConfig.SSOticaInsightSync.Enabled,
+		CronSchedule:      appConfig.SSOticaInsightSync.CronSchedule,
+		LookbackDays:      appConfig.SSOticaInsightSync.LookbackDays,
+		MaxConcurrentJobs: appConfig.SSOticaInsightSync.MaxConcurrentJobs,
+		SyncEnabled:       appConfig.SSOticaInsightSync.Enabled,
+		DeltaEnabled:      appConfig.SSOticaInsightSync.DeltaEnabled,
+		SettleDays:        appConfig.SSOticaInsightSync.SettleDays,
+		MaxDuration:       appConfig.SSOticaInsightSync.MaxDuration,
+		MaxRetries:        appConfig.SSOticaInsightSync.MaxRetries,
+		RetryBackoff:      appConfig.SSOticaInsightSync.RetryBackoff,
 	}
 
-	// Criar o agendador
-	scheduler := gocron.NewScheduler(time.Local)
-
 	logrus.WithFields(logrus.Fields{
-		"cron_schedule":         insightConfig.CronSchedule,
-		"lookback_days":         insightConfig.LookbackDays,
-		"request_delay_seconds": insightConfig.RequestDelaySeconds,
-		"max_concurrent_jobs":   insightConfig.MaxConcurrentJobs,
-		"sync_enabled":          insightConfig.SyncEnabled,
+		"cron_schedule":       insightConfig.CronSchedule,
+		"lookback_days":       insightConfig.LookbackDays,
+		"max_concurrent_jobs": insightConfig.MaxConcurrentJobs,
+		"sync_enabled":        insightConfig.SyncEnabled,
+		"delta_enabled":       insightConfig.DeltaEnabled,
+		"settle_days":         insightConfig.SettleDays,
+		"max_duration":        insightConfig.MaxDuration.String(),
+		"max_retries":         insightConfig.MaxRetries,
+		"retry_backoff":       insightConfig.RetryBackoff.String(),
 	}).Info("Configuração do agendador de insights do SSOtica carregada")
 
+	stats := newRunStats()
 	return &SSOticaInsightSyncService{
-		scheduler:        scheduler,
 		config:           insightConfig,
 		appConfig:        appConfig,
 		accountRepo:      accountRepo,
 		salesInsightRepo: salesInsightRepo,
 		ssoticaService:   ssoticaService,
-		syncRunning:      false,
+		stats:            stats,
+		jobs:             newJobRunner(domain.SchedulerSSOticaInsightSync, stats, opts...),
+		accountSyncs:     newAccountSyncs(),
+		retry:            newRetryPolicy(insightConfig.MaxRetries, insightConfig.RetryBackoff),
+		runCtx:           context.Background(),
 	}
 }
 
-// Start inicia o agendador
-func (s *SSOticaInsightSyncService) Start(ctx context.Context) error {
+// WithActivityRecorder habilita o registro de cada sincronização na linha do tempo das contas
+func (s *SSOticaInsightSyncService) WithActivityRecorder(recorder activity.Recorder) *SSOticaInsightSyncService {
+	s.activityRecorder = recorder
+	return s
+}
+
+// WithPrioritizer ordena a fila de sincronização pelo score de atividade das contas
+func (s *SSOticaInsightSyncService) WithPrioritizer(prioritizer activity.Prioritizer) *SSOticaInsightSyncService {
+	s.prioritizer = prioritizer
+	return s
+}
+
+// WithAccountChanges faz a execução usar o status e as credenciais atuais das contas alteradas durante a sincronização
+func (s *SSOticaInsightSyncService) WithAccountChanges(tracker *AccountChangeTracker) *SSOticaInsightSyncService {
+	s.accountChanges = tracker
+	return s
+}
+
+// WithDispatcher habilita a publicação do evento sync.finished para os webhooks assinados ao fim de cada execução
+func (s *SSOticaInsightSyncService) WithDispatcher(dispatcher notifying.Dispatcher) *SSOticaInsightSyncService {
+	s.jobs.dispatcher = dispatcher
+	return s
+}
+
+// WithAccountSchedules habilita as agendas próprias por conta, verificadas a cada checkInterval
+func (s *SSOticaInsightSyncService) WithAccountSchedules(repo repository.SyncScheduleRepository, checkInterval time.Duration) *SSOticaInsightSyncService {
+	s.accountSchedules = newAccountSchedules(repo, domain.SyncSourceSSOtica, checkInterval)
+	return s
+}
+
+// WithBackfill habilita o backfill de períodos longos em blocos mensais, com a situação de cada bloco persistida
+func (s *SSOticaInsightSyncService) WithBackfill(repo repository.BackfillChunkRepository) *SSOticaInsightSyncService {
+	s.backfill = newBackfillRunner(repo, domain.SyncSourceSSOtica)
+	return s
+}
+
+// WithSyncFailures habilita o registro das datas que falharam em todas as tentativas, para consulta e nova sincronização
+func (s *SSOticaInsightSyncService) WithSyncFailures(repo repository.SyncFailureRepository) *SSOticaInsightSyncService {
+	s.failures = newFailureQueue(repo, domain.SyncSourceSSOtica)
+	return s
+}
+
+// WithRateLimiter limita as requisições ao SSOtica com o limite compartilhado entre os agendadores
+func (s *SSOticaInsightSyncService) WithRateLimiter(limiter *RateLimiter) *SSOticaInsightSyncService {
+	s.limiter = limiter
+	return s
+}
+
+// Start registra as rotinas do serviço no agendador da aplicação
+func (s *SSOticaInsightSyncService) Start(ctx context.Context, scheduler *Scheduler) error {
+	// Sincronizações (inclusive as manuais) são interrompidas quando o contexto da aplicação é cancelado
+	s.runCtx = ctx
+	// O encerramento da aplicação aguarda as execuções em andamento
+	s.jobs.runs = scheduler.runs
+
 	if !s.config.SyncEnabled {
 		logrus.Info("Sincronização de insights do SSOtica desabilitada por configuração")
 		return nil
@@ -85,80 +160,118 @@ func (s *SSOticaInsightSyncService) Start(ctx context.Context) error {
 
 	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando agendador de sincronização de insights do SSOtica")
 
+	s.jobs.interruptAbandoned()
+
 	// Agendar a sincronização de insights
-	_, err := s.scheduler.Cron(s.config.CronSchedule).Do(func() {
-		s.syncAllSSOticaInsights()
+	err := scheduler.Cron(domain.SchedulerSSOticaInsightSync, s.config.CronSchedule, func() {
+		s.syncAllSSOticaInsights(ctx, domain.JobTriggerCron)
 	})
 	if err != nil {
 		return fmt.Errorf("erro ao agendar sincronização de insights do SSOtica: %w", err)
 	}
 
-	// Executar o agendador em uma goroutine separada
-	s.scheduler.StartAsync()
+	// Verificar periodicamente as contas com agenda própria
+	if s.accountSchedules != nil {
+		err = scheduler.Every(s.accountSchedules.lockName(), s.accountSchedules.checkInterval, func() {
+			// A pausa da origem também suspende as agendas por conta
+			if s.jobs.isPaused() {
+				return
+			}
+			s.jobs.lock.tryRun(s.accountSchedules.lockName(), func() {
+				s.accountSchedules.runDue(s.getActiveAccounts, func(acc *domain.AdAccount, dates []time.Time) {
+					s.processAccountForAllDates(ctx, acc, dates)
+				})
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao agendar verificação das agendas por conta do SSOtica: %w", err)
+		}
+	}
 
-	// Configurar o cancelamento do agendador quando o contexto for cancelado
-	go func() {
-		<-ctx.Done()
-		logrus.Info("Parando agendador de sincronização de insights do SSOtica")
-		s.scheduler.Stop()
-	}()
+	// Executar a sincronização perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() {
+		s.syncAllSSOticaInsights(ctx, domain.JobTriggerCatchUp)
+	})
 
 	return nil
 }
 
-// syncAllSSOticaInsights sincroniza os insights do SSOtica de todas as contas ativas
-func (s *SSOticaInsightSyncService) syncAllSSOticaInsights() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+// syncAllSSOticaInsights sincroniza os insights do SSOtica de todas as contas ativas, se não houver outra
+// sincronização em andamento
+func (s *SSOticaInsightSyncService) syncAllSSOticaInsights(ctx context.Context, trigger string) {
+	params := map[string]any{"lookback_days": s.config.LookbackDays}
+	started := s.jobs.run(trigger, params, func(startTime time.Time) error {
+		return s.runSSOticaInsightSync(ctx, startTime)
+	})
+	if !started {
 		logrus.Info("Sincronização de insights do SSOtica já em andamento, ignorando")
-		return
 	}
-	s.syncRunning = true
-	s.syncMutex.Unlock()
-
-	startTime := time.Now()
-	s.lastSyncStartedAt = startTime
-
-	defer func() {
-		s.syncMutex.Lock()
-		s.syncRunning = false
-		s.syncMutex.Unlock()
-	}()
+}
 
+func (s *SSOticaInsightSyncService) runSSOticaInsightSync(ctx context.Context, startTime time.Time) error {
 	logrus.Info("Iniciando sincronização de insights do SSOtica para todas as contas ativas")
 
+	// Limitar a duração para não sobrepor a execução do cron seguinte
+	runCtx, cancel := withTimeBox(ctx, s.config.MaxDuration)
+	defer cancel()
+
 	// Buscar todas as contas ativas
 	activeAccounts, err := s.getActiveAccounts()
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao buscar lista de contas para sincronização de insights do SSOtica")
-		return
+		return fmt.Errorf("erro ao buscar contas: %w", err)
 	}
 
+	// Contas com agenda própria são sincronizadas fora da execução global
+	activeAccounts = s.accountSchedules.withoutOverrides(activeAccounts)
+
+	// Contas VIP e as mais acompanhadas são sincronizadas primeiro
+	activeAccounts = prioritizeAccounts(s.prioritizer, activeAccounts)
+
 	if len(activeAccounts) == 0 {
 		logrus.Info("Nenhuma conta ativa encontrada para sincronização de insights do SSOtica")
-		return
+		return nil
 	}
 
 	// Criar datas para processamento
-	dates := s.getDatesToProcess()
+	dates := s.getDatesToProcess(startTime)
 	logrus.WithFields(logrus.Fields{
 		"days":       s.config.LookbackDays,
 		"start_date": dates[len(dates)-1].Format(time.DateOnly),
 		"end_date":   dates[0].Format(time.DateOnly),
 	}).Info("Período para sincronização de insights do SSOtica")
 
+	// Retomar a execução interrompida: contas pendentes primeiro, incluindo as datas que ficaram sem sincronizar
+	progress := newSyncProgress(dates, s.jobs.history.loadCheckpoint(), startTime)
+	activeAccounts = progress.pendingFirst(activeAccounts)
+
 	// Processar insights
-	s.processSSOticaInsightsForDates(activeAccounts, dates)
+	s.processSSOticaInsightsForDates(runCtx, activeAccounts, progress)
 
 	duration := time.Since(startTime)
+	if runCtx.Err() != nil {
+		checkpoint := progress.checkpoint(time.Now())
+		s.jobs.history.saveCheckpoint(checkpoint)
+		logrus.WithFields(logrus.Fields{
+			"duration":           duration.String(),
+			"completed_accounts": len(checkpoint.CompletedAccountIDs),
+			"accounts":           len(activeAccounts),
+			"time_limit_reached": ctx.Err() == nil,
+		}).Warn("Sincronização de insights do SSOtica interrompida, progresso salvo para a próxima execução")
+		return runCtx.Err()
+	}
+
+	if progress.resumed != nil {
+		s.jobs.history.saveCheckpoint(nil)
+	}
+
 	logrus.WithFields(logrus.Fields{
 		"duration": duration.String(),
 		"accounts": len(activeAccounts),
 		"days":     s.config.LookbackDays,
 	}).Info("Sincronização de insights do SSOtica concluída")
 
-	s.lastSyncCompletedAt = time.Now()
+	return nil
 }
 
 // getActiveAccounts busca e filtra contas ativas
@@ -188,17 +301,19 @@ func (s *SSOticaInsightSyncService) getActiveAccounts() ([]*domain.AdAccount, er
 	return activeAccounts, nil
 }
 
-// getDatesToProcess cria um conjunto de datas para processar
-func (s *SSOticaInsightSyncService) getDatesToProcess() []time.Time {
+// getDatesToProcess cria um conjunto de datas para processar, contadas no fuso de now
+func (s *SSOticaInsightSyncService) getDatesToProcess(now time.Time) []time.Time {
 	dates := make([]time.Time, s.config.LookbackDays)
 	for i := 0; i < s.config.LookbackDays; i++ {
-		dates[i] = time.Now().AddDate(0, 0, -i-1) // Começar de ontem e ir para trás
+		dates[i] = truncateToDay(now).AddDate(0, 0, -i-1) // Começar de ontem e ir para trás
 	}
 	return dates
 }
 
 // processSSOticaInsightsForDates processa insights do SSOtica para cada conta e todas as suas datas
-func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(accounts []*domain.AdAccount, dates []time.Time) {
+func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(ctx context.Context, accounts []*domain.AdAccount, progress *syncProgress) {
+	s.jobs.setTotal(len(accounts))
+
 	// Criar um canal para controlar o número de workers concorrentes
 	semaphore := make(chan struct{}, s.config.MaxConcurrentJobs)
 	var wg sync.WaitGroup
@@ -208,12 +323,17 @@ func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(accounts []*d
 		// Verificação adicional de CNPJ e SecretName
 		if account.CNPJ == nil || *account.CNPJ == "" || account.SecretName == nil || *account.SecretName == "" {
 			logrus.WithField("account_id", account.ID).Warn("Conta sem CNPJ ou Token. Pulando.")
+			s.jobs.recordAccount(account.ID, accountResult{skipped: "conta sem CNPJ ou token do SSOtica"})
 			continue
 		}
 
+		// Adquirir semáforo, parando de distribuir contas se a sincronização for cancelada
+		if !acquireSlot(ctx, semaphore) {
+			break
+		}
+
 		// Adicionar uma tarefa ao grupo de espera
 		wg.Add(1)
-		semaphore <- struct{}{} // Adquirir semáforo
 
 		go func(acc *domain.AdAccount) {
 			defer func() {
@@ -221,6 +341,19 @@ func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(accounts []*d
 				wg.Done()
 			}()
 
+			s.jobs.startAccount(acc.ID)
+
+			// "Ontem" é o ontem da loja: a janela é contada no fuso da conta
+			dates := progress.datesFor(acc.ID, s.getDatesToProcess(progress.startedAt.In(acc.TimeLocation())))
+
+			// Buscar apenas as datas sem dados armazenados ou ainda não consolidadas
+			dates = s.deltaDates(acc, dates)
+			if len(dates) == 0 {
+				s.jobs.recordAccount(acc.ID, accountResult{skipped: "datas já sincronizadas"})
+				progress.markCompleted(acc.ID)
+				return
+			}
+
 			logrus.WithFields(logrus.Fields{
 				"account_id":   acc.ID,
 				"account_name": acc.Name,
@@ -230,7 +363,10 @@ func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(accounts []*d
 			}).Info("Processando insights do SSOtica para conta")
 
 			// Processar todas as datas para esta conta
-			s.processAccountForAllDates(acc, dates)
+			s.jobs.recordAccount(acc.ID, s.syncAccountDates(ctx, acc, dates))
+			if ctx.Err() == nil {
+				progress.markCompleted(acc.ID)
+			}
 		}(account)
 	}
 
@@ -239,22 +375,81 @@ func (s *SSOticaInsightSyncService) processSSOticaInsightsForDates(accounts []*d
 }
 
 // processAccountForAllDates processa os insights do SSOtica para uma conta em todas as datas
-func (s *SSOticaInsightSyncService) processAccountForAllDates(acc *domain.AdAccount, dates []time.Time) {
+// Retorna true se todas as datas foram sincronizadas sem falhas.
+func (s *SSOticaInsightSyncService) processAccountForAllDates(ctx context.Context, acc *domain.AdAccount, dates []time.Time) bool {
+	result := s.syncAccountDates(ctx, acc, dates)
+	return result.failed == 0 && ctx.Err() == nil
+}
+
+// syncAccountDates processa os insights do SSOtica para uma conta em todas as datas e retorna o resultado
+func (s *SSOticaInsightSyncService) syncAccountDates(ctx context.Context, acc *domain.AdAccount, dates []time.Time) accountResult {
 	sort.Slice(dates, func(i, j int) bool {
 		return dates[i].Before(dates[j])
 	})
 
 	// Processa uma data por vez, para APIs que não suportam ranges
+	failedDates := make([]time.Time, 0)
+	processedDates := make([]time.Time, 0, len(dates))
+	syncedDates := make([]time.Time, 0, len(dates))
+	var lastErr error
 	for _, date := range dates {
-		s.processAccountSSOticaInsights(acc, date)
+		if ctx.Err() != nil {
+			break
+		}
+
+		// A conta pode ter sido inativada ou ter as credenciais alteradas durante a execução
+		current, ok := s.currentAccount(acc)
+		if !ok {
+			logrus.WithField("account_id", acc.ID).Info("Conta inativada ou sem credenciais, interrompendo a sincronização do SSOtica")
+			break
+		}
+		acc = current
+
+		processedDates = append(processedDates, date)
+		// Falhas transitórias são repetidas com backoff; o erro final fica no resultado da conta na execução
+		// e a data, na fila de falhas
+		attempts := 0
+		err := s.retry.do(ctx, func() error {
+			attempts++
+			return s.processAccountSSOticaInsights(ctx, acc, date)
+		})
+		if err != nil {
+			failedDates = append(failedDates, date)
+			lastErr = err
+			s.failures.record(acc.ID, date, attempts, err)
+		} else {
+			syncedDates = append(syncedDates, date)
+		}
+	}
+
+	if ctx.Err() != nil {
+		logrus.WithFields(logrus.Fields{
+			"account_id":      acc.ID,
+			"processed_dates": len(processedDates),
+			"total_dates":     len(dates),
+		}).Warn("Sincronização de insights do SSOtica da conta interrompida")
+	}
+
+	recordSyncActivity(s.activityRecorder, acc.ID, "SSOtica", processedDates, failedDates)
+	s.failures.resolve(acc.ID, syncedDates)
 
-		// Aguardar antes da próxima requisição para evitar sobrecarga na API
-		time.Sleep(time.Duration(s.config.RequestDelaySeconds) * time.Second)
+	if lastErr == nil {
+		lastErr = ctx.Err()
 	}
+	if len(dates) > 0 && len(processedDates) == 0 && lastErr == nil {
+		return accountResult{skipped: "conta inativada ou sem credenciais do SSOtica"}
+	}
+	return accountResult{processed: len(processedDates), failed: len(failedDates), err: lastErr}
+}
+
+// currentAccount retorna a conta com o status e as credenciais atuais e se ela ainda pode ser sincronizada
+func (s *SSOticaInsightSyncService) currentAccount(acc *domain.AdAccount) (*domain.AdAccount, bool) {
+	current, active := s.accountChanges.refresh(acc)
+	return current, active && current.CNPJ != nil && *current.CNPJ != "" && current.SecretName != nil && *current.SecretName != ""
 }
 
 // processAccountSSOticaInsights processa os insights do SSOtica para uma conta e data específicas
-func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(acc *domain.AdAccount, date time.Time) {
+func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(ctx context.Context, acc *domain.AdAccount, date time.Time) error {
 	// Criar filtros para a data específica
 	filters := &domain.InsigthFilters{
 		StartDate: &date,
@@ -270,14 +465,19 @@ func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(acc *domain.Ad
 	}).Info("Obtendo insights do SSOtica para conta e data")
 
 	// Obter insights do SSOtica para a conta e data
-	salesMetrics, err := s.ssoticaService.GetSalesMetrics(*acc.CNPJ, *acc.SecretName, filters)
+	if err := s.limiter.wait(ctx); err != nil {
+		return err
+	}
+	salesMetrics, err := s.ssoticaService.GetSalesMetrics(ctx, *acc.CNPJ, *acc.SecretName, filters)
+	s.stats.recordCall(domain.SyncSourceSSOtica, err)
+	s.limiter.observe(err)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"account_id": acc.ID,
 			"date":       date.Format(time.DateOnly),
 			"error":      err.Error(),
 		}).Error("Erro ao obter insights do SSOtica para conta e data")
-		return
+		return err
 	}
 
 	if salesMetrics == nil || len(salesMetrics) == 0 {
@@ -285,7 +485,7 @@ func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(acc *domain.Ad
 			"account_id": acc.ID,
 			"date":       date.Format(time.DateOnly),
 		}).Warn("Nenhum insight do SSOtica obtido para conta e data")
-		return
+		return nil
 	}
 
 	// Criar a entrada de insights de vendas
@@ -303,7 +503,7 @@ func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(acc *domain.Ad
 			"date":       date.Format(time.DateOnly),
 			"error":      err.Error(),
 		}).Error("Erro ao salvar insights do SSOtica no banco de dados")
-		return
+		return err
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -311,34 +511,145 @@ func (s *SSOticaInsightSyncService) processAccountSSOticaInsights(acc *domain.Ad
 		"date":       date.Format(time.DateOnly),
 	}).Info("Insights do SSOtica salvos com sucesso para conta e data")
 
-	// Aguardar antes da próxima requisição para evitar sobrecarga na API
-	time.Sleep(time.Duration(s.config.RequestDelaySeconds) * time.Second)
+	return nil
 }
 
 // TriggerManualSync inicia manualmente uma sincronização de insights do SSOtica
 func (s *SSOticaInsightSyncService) TriggerManualSync() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+	if s.jobs.isRunning() {
 		logrus.Info("Sincronização de insights do SSOtica já em andamento, ignorando solicitação manual")
 		return
 	}
-	s.syncMutex.Unlock()
 
 	logrus.Info("Iniciando sincronização manual de insights do SSOtica")
-	go s.syncAllSSOticaInsights()
+	go s.syncAllSSOticaInsights(s.runCtx, domain.JobTriggerManual)
+}
+
+// TriggerAccountSync inicia em background a sincronização de insights do SSOtica de uma única conta na janela de
+// LookbackDays, sem sincronizar as demais contas. Não depende da sincronização de todas as contas estar parada.
+func (s *SSOticaInsightSyncService) TriggerAccountSync(accountID string) error {
+	_, err := s.startAccountSync(accountID, func(acc *domain.AdAccount) ([]time.Time, error) {
+		return s.getDatesToProcess(time.Now().In(acc.TimeLocation())), nil
+	})
+	return err
+}
+
+// RequeueFailures inicia em background uma nova sincronização das datas da conta que falharam em todas as
+// tentativas e retorna essas datas. As datas sincronizadas saem da fila de falhas.
+func (s *SSOticaInsightSyncService) RequeueFailures(accountID string) ([]time.Time, error) {
+	return s.startAccountSync(accountID, func(acc *domain.AdAccount) ([]time.Time, error) {
+		return s.failures.requeue(acc.ID)
+	})
+}
+
+// startAccountSync reserva a conta e sincroniza em background as datas retornadas por datesFor
+func (s *SSOticaInsightSyncService) startAccountSync(accountID string, datesFor func(acc *domain.AdAccount) ([]time.Time, error)) ([]time.Time, error) {
+	if s.jobs.isPaused() {
+		return nil, ErrSchedulerPaused
+	}
+
+	acc, err := s.accountRepo.GetAccountByID(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar conta para sincronização: %w", err)
+	}
+	// Sem CNPJ e token, a conta não pode ser consultada no SSOtica
+	if acc == nil || acc.Status != domain.AdAccountStatusActive || acc.CNPJ == nil || *acc.CNPJ == "" || acc.SecretName == nil || *acc.SecretName == "" {
+		return nil, ErrAccountSyncNotFound
+	}
+	if !s.accountSyncs.start(acc.ID) {
+		return nil, ErrAccountSyncRunning
+	}
+
+	dates, err := datesFor(acc)
+	if err != nil {
+		s.accountSyncs.finish(acc.ID)
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"account_id": acc.ID,
+		"days":       len(dates),
+	}).Info("Iniciando sincronização manual de insights do SSOtica da conta")
+
+	done := s.jobs.runs.track("account_sync:" + domain.SyncSourceSSOtica + ":" + acc.ID)
+	go func() {
+		defer done()
+		defer s.accountSyncs.finish(acc.ID)
+
+		if s.processAccountForAllDates(s.runCtx, acc, dates) {
+			logrus.WithField("account_id", acc.ID).Info("Sincronização manual de insights do SSOtica da conta concluída")
+		} else {
+			logrus.WithField("account_id", acc.ID).Warn("Sincronização manual de insights do SSOtica da conta concluída com falhas")
+		}
+	}()
+	return dates, nil
+}
+
+// TriggerBackfill inicia em background a sincronização de insights do SSOtica no período, processando um mês
+// por vez. Sem accountIDs, sincroniza todas as contas ativas e os blocos concluídos em um backfill anterior do
+// mesmo período não são refeitos; com accountIDs, apenas as contas informadas, refazendo todo o período.
+func (s *SSOticaInsightSyncService) TriggerBackfill(startDate, endDate time.Time, accountIDs []string) error {
+	if s.jobs.isPaused() {
+		return ErrSchedulerPaused
+	}
+
+	activeAccounts, err := s.getActiveAccounts()
+	if err != nil {
+		return fmt.Errorf("erro ao buscar contas para o backfill: %w", err)
+	}
+
+	// Sem CNPJ e token, a conta não pode ser consultada no SSOtica
+	accounts := make([]*domain.AdAccount, 0, len(activeAccounts))
+	for _, acc := range activeAccounts {
+		if acc.CNPJ != nil && *acc.CNPJ != "" && acc.SecretName != nil && *acc.SecretName != "" {
+			accounts = append(accounts, acc)
+		}
+	}
+	accounts, err = backfillAccounts(accounts, accountIDs)
+	if err != nil {
+		return err
+	}
+
+	chunks, err := s.backfill.start(startDate, endDate, time.Now(), accountIDs)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"start_date": startDate.Format(time.DateOnly),
+		"end_date":   endDate.Format(time.DateOnly),
+		"chunks":     len(chunks),
+		"accounts":   len(accounts),
+	}).Info("Iniciando backfill de insights do SSOtica")
+
+	done := s.jobs.runs.track("backfill:ssotica")
+	go func() {
+		defer done()
+		s.backfill.run(s.runCtx, chunks, accounts, s.config.MaxConcurrentJobs, s.processAccountForAllDates)
+	}()
+	return nil
+}
+
+// Paused informa se o agendador foi pausado pelas rotas de administração
+func (s *SSOticaInsightSyncService) Paused() bool {
+	return s.jobs.isPaused()
 }
 
 // GetStatus retorna o status atual do agendador
 func (s *SSOticaInsightSyncService) GetStatus() map[string]any {
-	return map[string]any{
-		"sync_enabled":           s.config.SyncEnabled,
-		"sync_cron":              s.config.CronSchedule,
-		"sync_lookback_days":     s.config.LookbackDays,
-		"sync_max_concurrent":    s.config.MaxConcurrentJobs,
-		"sync_request_delay_s":   s.config.RequestDelaySeconds,
-		"retention_policy":       "dados mantidos permanentemente",
-		"last_sync_started_at":   s.lastSyncStartedAt,
-		"last_sync_completed_at": s.lastSyncCompletedAt,
-	}
+	status := s.jobs.status()
+	status["sync_enabled"] = s.config.SyncEnabled
+	status["sync_cron"] = s.config.CronSchedule
+	status["next_run_at"] = nextRunAt(s.config.SyncEnabled, s.config.CronSchedule, time.Now())
+	status["sync_lookback_days"] = s.config.LookbackDays
+	status["sync_delta_enabled"] = s.config.DeltaEnabled
+	status["sync_settle_days"] = s.config.SettleDays
+	status["sync_max_concurrent"] = s.config.MaxConcurrentJobs
+	status["rate_limit"] = s.limiter.status()
+	status["sync_max_duration"] = s.config.MaxDuration.String()
+	status["sync_max_retries"] = s.config.MaxRetries
+	status["retention_policy"] = "dados mantidos permanentemente"
+	status["backfill"] = s.backfill.status()
+	status["account_syncs"] = s.accountSyncs.status()
+	return status
 }
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// Classes de erro contabilizadas nas estatísticas das execuções
+const (
+	errorClassCanceled  = "canceled"
+	errorClassTimeout   = "timeout"
+	errorClassRateLimit = "rate_limit"
+	errorClassAuth      = "auth"
+	errorClassHTTP      = "http_status"
+	errorClassOther     = "other"
+)
+
+// runStats contabiliza, por provedor, as chamadas feitas por um agendador na execução corrente,
+// para que os ajustes de concorrência e intervalo entre requisições se baseiem em dados.
+// Um runStats nil não contabiliza nada.
+type runStats struct {
+	mu        sync.Mutex
+	providers map[string]*domain.ProviderStats
+}
+
+func newRunStats() *runStats {
+	return &runStats{providers: make(map[string]*domain.ProviderStats)}
+}
+
+// reset zera as estatísticas no início de uma nova execução
+func (s *runStats) reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providers = make(map[string]*domain.ProviderStats)
+}
+
+// provider deve ser chamado com o mutex adquirido
+func (s *runStats) provider(name string) *domain.ProviderStats {
+	stats, ok := s.providers[name]
+	if !ok {
+		stats = &domain.ProviderStats{Failures: make(map[string]int64)}
+		s.providers[name] = stats
+	}
+	return stats
+}
+
+// recordCall registra uma chamada ao provedor e, se houver erro, a classe da falha
+func (s *runStats) recordCall(provider string, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.provider(provider)
+	stats.APICalls++
+	if err != nil {
+		stats.Failures[classifyError(err)]++
+	}
+}
+
+// recordRowsWritten registra as linhas gravadas no banco com dados do provedor
+func (s *runStats) recordRowsWritten(provider string, rows int) {
+	if s == nil || rows <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provider(provider).RowsWritten += int64(rows)
+}
+
+// recordCacheHits registra os dados reaproveitados do banco sem nova consulta ao provedor
+func (s *runStats) recordCacheHits(provider string, hits int) {
+	if s == nil || hits <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provider(provider).CacheHits += int64(hits)
+}
+
+// snapshot retorna uma cópia das estatísticas, segura para serialização
+func (s *runStats) snapshot() map[string]*domain.ProviderStats {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[string]*domain.ProviderStats, len(s.providers))
+	for name, stats := range s.providers {
+		failures := make(map[string]int64, len(stats.Failures))
+		for class, count := range stats.Failures {
+			failures[class] = count
+		}
+		snapshot[name] = &domain.ProviderStats{
+			APICalls:    stats.APICalls,
+			Failures:    failures,
+			RowsWritten: stats.RowsWritten,
+			CacheHits:   stats.CacheHits,
+		}
+	}
+	return snapshot
+}
+
+// classifyError agrupa os erros dos integradores. Os clientes do Meta e do SSOtica
+// não expõem erros tipados, por isso a classificação também considera a mensagem.
+func classifyError(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return errorClassCanceled
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errorClassTimeout
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "timeout"):
+		return errorClassTimeout
+	case strings.Contains(message, "request limit"), strings.Contains(message, "too many"),
+		strings.Contains(message, "429"), strings.Contains(message, "\"code\":17"), strings.Contains(message, "\"code\":4,"):
+		return errorClassRateLimit
+	case strings.Contains(message, "token"), strings.Contains(message, "401"), strings.Contains(message, "403"):
+		return errorClassAuth
+	case strings.Contains(message, "status"):
+		return errorClassHTTP
+	default:
+		return errorClassOther
+	}
+}
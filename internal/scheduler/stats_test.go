@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("erro ao executar a requisição: %w", context.Canceled), errorClassCanceled},
+		{fmt.Errorf("erro ao executar a requisição: %w", context.DeadlineExceeded), errorClassTimeout},
+		{errors.New(`erro na resposta da API. Status: 400, Corpo: {"error":{"message":"User request limit reached","code":17}}`), errorClassRateLimit},
+		{errors.New("token expirou permanentemente e requer reautorização manual"), errorClassAuth},
+		{errors.New("requisição falhou com status: 500 Internal Server Error"), errorClassHTTP},
+		{errors.New("erro ao decodificar a resposta"), errorClassOther},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, classifyError(tt.err), tt.err.Error())
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	stats := newRunStats()
+	stats.recordCall(domain.SyncSourceMeta, nil)
+	stats.recordCall(domain.SyncSourceMeta, context.DeadlineExceeded)
+	stats.recordRowsWritten(domain.SyncSourceMeta, 1)
+	stats.recordCacheHits(domain.SyncSourceMeta, 3)
+	stats.recordCall(domain.SyncSourceSSOtica, nil)
+
+	snapshot := stats.snapshot()
+	assert.Equal(t, &domain.ProviderStats{
+		APICalls:    2,
+		Failures:    map[string]int64{errorClassTimeout: 1},
+		RowsWritten: 1,
+		CacheHits:   3,
+	}, snapshot[domain.SyncSourceMeta])
+	assert.Equal(t, int64(1), snapshot[domain.SyncSourceSSOtica].APICalls)
+
+	// O snapshot não é afetado pela execução seguinte
+	stats.reset()
+	assert.Empty(t, stats.snapshot())
+	assert.Equal(t, int64(2), snapshot[domain.SyncSourceMeta].APICalls)
+
+	var disabled *runStats
+	disabled.recordCall(domain.SyncSourceMeta, nil)
+	assert.Nil(t, disabled.snapshot())
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// ErrNoSyncFailures indica que a conta não tem datas com falha para sincronizar novamente
+var ErrNoSyncFailures = errors.New("nenhuma falha de sincronização pendente para a conta na origem")
+
+// failureQueue guarda as datas que falharam em todas as tentativas, para que as lacunas fiquem visíveis e
+// possam ser sincronizadas de novo. Uma data sai da fila quando é sincronizada, em qualquer execução.
+// Um failureQueue nil não registra nada.
+type failureQueue struct {
+	repo   repository.SyncFailureRepository
+	source string
+	now    func() time.Time
+}
+
+func newFailureQueue(repo repository.SyncFailureRepository, source string) *failureQueue {
+	if repo == nil {
+		return nil
+	}
+	return &failureQueue{repo: repo, source: source, now: time.Now}
+}
+
+// record registra a falha da data depois das tentativas. Datas interrompidas pelo cancelamento da execução
+// não são falhas: a próxima execução as retoma pelo checkpoint.
+func (q *failureQueue) record(accountID string, date time.Time, attempts int, err error) {
+	if q == nil || classifyError(err) == errorClassCanceled {
+		return
+	}
+
+	failure := &domain.SyncFailure{
+		Source:       q.source,
+		AccountID:    accountID,
+		Date:         date,
+		Attempts:     attempts,
+		Error:        err.Error(),
+		LastFailedAt: q.now(),
+	}
+	if err := q.repo.SaveFailure(failure); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"source":     q.source,
+			"account_id": accountID,
+			"date":       date.Format(time.DateOnly),
+		}).Error("Erro ao registrar falha de sincronização")
+	}
+}
+
+// resolve remove da fila as datas da conta que foram sincronizadas
+func (q *failureQueue) resolve(accountID string, dates []time.Time) {
+	if q == nil || len(dates) == 0 {
+		return
+	}
+
+	if err := q.repo.DeleteFailures(q.source, accountID, dates); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"source":     q.source,
+			"account_id": accountID,
+		}).Error("Erro ao remover falhas de sincronização resolvidas")
+	}
+}
+
+// requeue retorna as datas com falha da conta e registra a nova sincronização delas
+func (q *failureQueue) requeue(accountID string) ([]time.Time, error) {
+	if q == nil {
+		return nil, ErrNoSyncFailures
+	}
+
+	dates, err := q.repo.ListAccountFailureDates(q.source, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, ErrNoSyncFailures
+	}
+
+	if err := q.repo.MarkRequeued(q.source, accountID, q.now()); err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
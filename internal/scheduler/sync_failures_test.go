@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFailureQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	date := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	repo := mocks.NewMockSyncFailureRepository(ctrl)
+	queue := newFailureQueue(repo, domain.SyncSourceMeta)
+	queue.now = func() time.Time { return now }
+
+	t.Run("registra a falha depois das tentativas", func(t *testing.T) {
+		repo.EXPECT().SaveFailure(&domain.SyncFailure{
+			Source:       domain.SyncSourceMeta,
+			AccountID:    "ABC123",
+			Date:         date,
+			Attempts:     3,
+			Error:        "3 tentativas: timeout",
+			LastFailedAt: now,
+		}).Return(nil)
+
+		queue.record("ABC123", date, 3, errors.New("3 tentativas: timeout"))
+	})
+
+	t.Run("execução cancelada não é falha", func(t *testing.T) {
+		queue.record("ABC123", date, 1, fmt.Errorf("sincronização: %w", context.Canceled))
+	})
+
+	t.Run("remove as datas sincronizadas", func(t *testing.T) {
+		repo.EXPECT().DeleteFailures(domain.SyncSourceMeta, "ABC123", []time.Time{date}).Return(nil)
+
+		queue.resolve("ABC123", []time.Time{date})
+		queue.resolve("ABC123", nil)
+	})
+
+	t.Run("nova sincronização das datas com falha", func(t *testing.T) {
+		repo.EXPECT().ListAccountFailureDates(domain.SyncSourceMeta, "ABC123").Return([]time.Time{date}, nil)
+		repo.EXPECT().MarkRequeued(domain.SyncSourceMeta, "ABC123", now).Return(nil)
+
+		dates, err := queue.requeue("ABC123")
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date}, dates)
+	})
+
+	t.Run("conta sem falhas", func(t *testing.T) {
+		repo.EXPECT().ListAccountFailureDates(domain.SyncSourceMeta, "DEF456").Return(nil, nil)
+
+		_, err := queue.requeue("DEF456")
+		assert.ErrorIs(t, err, ErrNoSyncFailures)
+	})
+}
+
+func TestFailureQueue_Disabled(t *testing.T) {
+	queue := newFailureQueue(nil, domain.SyncSourceSSOtica)
+	assert.Nil(t, queue)
+
+	// Sem repositório, as falhas só ficam no resultado da execução
+	queue.record("ABC123", time.Now(), 3, errors.New("timeout"))
+	queue.resolve("ABC123", []time.Time{time.Now()})
+
+	_, err := queue.requeue("ABC123")
+	assert.ErrorIs(t, err, ErrNoSyncFailures)
+}
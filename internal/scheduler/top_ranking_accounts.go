@@ -3,18 +3,19 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica"
 	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
 )
 
 type TopRankingAccountsConfig struct {
@@ -23,16 +24,16 @@ type TopRankingAccountsConfig struct {
 }
 
 type TopRankingAccountsService struct {
-	scheduler           *gocron.Scheduler
-	accountRepo         repository.AccountRepository
-	rankingRepo         repository.StoreRankingRepository
-	config              TopRankingAccountsConfig
-	salesInsightRepo    repository.SalesInsightRepository
-	ssoticaService      ssotica.SSOticaIntegrator
-	syncRunning         bool
-	syncMutex           sync.Mutex
-	lastSyncStartedAt   time.Time
-	lastSyncCompletedAt time.Time
+	accountRepo      repository.AccountRepository
+	rankingRepo      repository.StoreRankingRepository
+	config           TopRankingAccountsConfig
+	salesInsightRepo repository.SalesInsightRepository
+	ssoticaService   ssotica.SSOticaIntegrator
+	dispatcher       notifying.Dispatcher
+	stats            *runStats
+	jobs             *jobRunner
+	limiter          *RateLimiter
+	runCtx           context.Context
 }
 
 func NewTopRankingAccountsService(
@@ -41,29 +42,49 @@ func NewTopRankingAccountsService(
 	salesInsightRepo repository.SalesInsightRepository,
 	ssoticaService ssotica.SSOticaIntegrator,
 	cfg *config.Config,
+	opts ...JobOption,
 ) *TopRankingAccountsService {
 	rankingConfig := TopRankingAccountsConfig{
 		CronSchedule: cfg.TopRankingAccounts.CronSchedule, // Default: 6h da manhã todos os dias
 		SyncEnabled:  cfg.TopRankingAccounts.SyncEnabled,  // Default: desabilitado
 	}
 
-	scheduler := gocron.NewScheduler(time.Local)
-
 	logrus.WithFields(logrus.Fields{
 		"cron_schedule": rankingConfig.CronSchedule,
 	}).Info("Configuração do agendador do top ranking de contas carregada")
 
+	stats := newRunStats()
 	return &TopRankingAccountsService{
-		scheduler:        scheduler,
 		accountRepo:      accountRepo,
 		rankingRepo:      rankingRepo,
 		salesInsightRepo: salesInsightRepo,
 		ssoticaService:   ssoticaService,
 		config:           rankingConfig,
+		stats:            stats,
+		jobs:             newJobRunner(domain.SchedulerTopRankingAccounts, stats, opts...),
+		runCtx:           context.Background(),
 	}
 }
 
-func (s *TopRankingAccountsService) Start(ctx context.Context) error {
+// WithDispatcher habilita a publicação dos eventos ranking.updated e sync.finished para os webhooks assinados
+func (s *TopRankingAccountsService) WithDispatcher(dispatcher notifying.Dispatcher) *TopRankingAccountsService {
+	s.dispatcher = dispatcher
+	s.jobs.dispatcher = dispatcher
+	return s
+}
+
+// WithRateLimiter limita as requisições ao SSOtica com o limite compartilhado entre os agendadores
+func (s *TopRankingAccountsService) WithRateLimiter(limiter *RateLimiter) *TopRankingAccountsService {
+	s.limiter = limiter
+	return s
+}
+
+func (s *TopRankingAccountsService) Start(ctx context.Context, scheduler *Scheduler) error {
+	// Atualizações (inclusive as manuais) são interrompidas quando o contexto da aplicação é cancelado
+	s.runCtx = ctx
+	// O encerramento da aplicação aguarda as execuções em andamento
+	s.jobs.runs = scheduler.runs
+
 	if !s.config.SyncEnabled {
 		logrus.Info("Cron de atualização de top ranking de contas desabilitada por configuração")
 		return nil
@@ -71,45 +92,50 @@ func (s *TopRankingAccountsService) Start(ctx context.Context) error {
 
 	logrus.WithField("cron", s.config.CronSchedule).Info("Iniciando cron de atualização do top ranking de contas")
 
+	s.jobs.interruptAbandoned()
+
 	// Agendar a sincronização de top ranking de contas
-	_, err := s.scheduler.Cron(s.config.CronSchedule).Do(func() {
-		if err := s.UpdateTopRankingAccounts(); err != nil {
+	update := func(trigger string) {
+		if err := s.UpdateTopRankingAccounts(ctx, trigger); err != nil {
 			logrus.WithError(err).Error("Erro na atualização do top ranking de contas")
 		}
+	}
+
+	err := scheduler.Cron(domain.SchedulerTopRankingAccounts, s.config.CronSchedule, func() {
+		update(domain.JobTriggerCron)
 	})
 	if err != nil {
 		return fmt.Errorf("erro ao agendar sincronização de top ranking de contas: %w", err)
 	}
 
-	// Executar o cron em uma goroutine separada
-	s.scheduler.StartAsync()
-
-	// Configurar o cancelamento do cron quando o contexto for cancelado
-	go func() {
-		<-ctx.Done()
-		logrus.Info("Parando cron do top ranking de contas")
-		s.scheduler.Stop()
-	}()
+	// Executar a atualização perdida enquanto o serviço estava parado
+	s.jobs.history.catchUp(s.config.CronSchedule, func() {
+		update(domain.JobTriggerCatchUp)
+	})
 
 	return nil
 }
 
-func (s *TopRankingAccountsService) UpdateTopRankingAccounts() error {
-	s.syncMutex.Lock()
-	defer s.syncMutex.Unlock()
-
-	if s.syncRunning {
+// UpdateTopRankingAccounts atualiza o top ranking de contas, se não houver outra atualização em andamento.
+// Interrupções pelo encerramento da aplicação não são tratadas como erro.
+func (s *TopRankingAccountsService) UpdateTopRankingAccounts(ctx context.Context, trigger string) error {
+	var err error
+	started := s.jobs.run(trigger, nil, func(time.Time) error {
+		err = s.updateTopRankingAccounts(ctx)
+		return err
+	})
+	if !started {
 		logrus.Warn("Sincronização de top ranking de contas já está em execução")
 		return nil
 	}
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return nil
+	}
 
-	s.syncRunning = true
-	s.lastSyncStartedAt = time.Now()
-	defer func() {
-		s.syncRunning = false
-		s.lastSyncCompletedAt = time.Now()
-	}()
+	return err
+}
 
+func (s *TopRankingAccountsService) updateTopRankingAccounts(ctx context.Context) error {
 	logrus.Info("Iniciando atualização do top ranking de contas")
 
 	// TODO: Implementar lógica de atualização do ranking
@@ -119,7 +145,11 @@ func (s *TopRankingAccountsService) UpdateTopRankingAccounts() error {
 		return err
 	}
 
-	s.processTopRankingAccounts(activeAccounts)
+	s.processTopRankingAccounts(ctx, activeAccounts)
+	if err := ctx.Err(); err != nil {
+		logrus.Warn("Atualização do top ranking de contas interrompida pelo encerramento da aplicação")
+		return err
+	}
 
 	logrus.Info("Atualização do top ranking de contas concluída")
 
@@ -159,8 +189,8 @@ func (s *TopRankingAccountsService) getActiveAccounts() ([]*domain.AdAccount, er
 }
 
 // processTopRankingAccounts processa o top ranking de contas
-func (s *TopRankingAccountsService) processTopRankingAccounts(accounts []*domain.AdAccount) {
-	s.processTopRankingAccountsWithDate(accounts, time.Now())
+func (s *TopRankingAccountsService) processTopRankingAccounts(ctx context.Context, accounts []*domain.AdAccount) {
+	s.processTopRankingAccountsWithDate(ctx, accounts, time.Now())
 }
 
 // // processTopRankingAccountsWithDate processa o top ranking de contas com uma data específica
@@ -322,7 +352,8 @@ func (s *TopRankingAccountsService) processTopRankingAccounts(accounts []*domain
 // }
 
 // processTopRankingAccountsWithDate processa o top ranking de contas com uma data específica
-func (s *TopRankingAccountsService) processTopRankingAccountsWithDate(accounts []*domain.AdAccount, processingDate time.Time) []*domain.StoreRankingItem {
+func (s *TopRankingAccountsService) processTopRankingAccountsWithDate(ctx context.Context, accounts []*domain.AdAccount, processingDate time.Time) []*domain.StoreRankingItem {
+	s.jobs.setTotal(len(accounts))
 	wg := sync.WaitGroup{}
 
 	yesterday := processingDate.AddDate(0, 0, -1)
@@ -352,11 +383,14 @@ func (s *TopRankingAccountsService) processTopRankingAccountsWithDate(accounts [
 		go func(account domain.AdAccount) {
 			defer wg.Done()
 
-			sales, err := s.getSalesByAccount(&account, firstDayOfMonth, yesterday)
+			s.jobs.startAccount(account.ID)
+			sales, err := s.getSalesByAccount(ctx, &account, firstDayOfMonth, yesterday)
 			if err != nil {
 				logrus.WithError(err).Error("TopRankingAccountsService: Erro ao buscar vendas do SSOtica")
+				s.jobs.recordAccount(account.ID, accountResult{processed: 1, failed: 1, err: err})
 				return
 			}
+			s.jobs.recordAccount(account.ID, accountResult{processed: 1})
 
 			socialNetworkRevenue := ssoticadomain.GetSumNetAmountSocialNetwork(sales)
 
@@ -380,6 +414,12 @@ func (s *TopRankingAccountsService) processTopRankingAccountsWithDate(accounts [
 	close(rankings)
 	close(rankingBeforeUpdate)
 
+	// Um ranking com as vendas de apenas parte das lojas não deve substituir o anterior
+	if ctx.Err() != nil {
+		logrus.Warn("Top ranking de contas não salvo: atualização cancelada")
+		return nil
+	}
+
 	rankingsBeforeUpdate := make(map[string]*domain.StoreRankingItem, 0)
 	for ranking := range rankingBeforeUpdate {
 		if ranking.AccountID == "" {
@@ -400,13 +440,21 @@ func (s *TopRankingAccountsService) processTopRankingAccountsWithDate(accounts [
 		logrus.WithError(err).Error("Erro ao salvar top ranking de contas atualizado")
 		return updatedRankings // Retorna mesmo com erro para não quebrar os testes
 	}
+	s.stats.recordRowsWritten(domain.SyncSourceSSOtica, len(updatedRankings))
 
 	logrus.Info("Top ranking de contas atualizado")
 
+	if s.dispatcher != nil {
+		s.dispatcher.Dispatch(domain.WebhookEventRankingUpdated, map[string]any{
+			"month":    month,
+			"rankings": updatedRankings,
+		})
+	}
+
 	return updatedRankings
 }
 
-func (s *TopRankingAccountsService) getSalesByAccount(account *domain.AdAccount, startDate time.Time, endDate time.Time) ([]ssoticadomain.Order, error) {
+func (s *TopRankingAccountsService) getSalesByAccount(ctx context.Context, account *domain.AdAccount, startDate time.Time, endDate time.Time) ([]ssoticadomain.Order, error) {
 	params := &ssoticadomain.GetSalesParams{
 		CNPJ:       *account.CNPJ,
 		SecretName: *account.SecretName,
@@ -424,7 +472,12 @@ func (s *TopRankingAccountsService) getSalesByAccount(account *domain.AdAccount,
 		"end_date":   filters.EndDate.Format(time.DateOnly),
 	}).Info("TopRankingAccountsService: buscando vendas do SSOtica")
 
-	sales, err := s.ssoticaService.GetSalesByAccount(*params, filters)
+	if err := s.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	sales, err := s.ssoticaService.GetSalesByAccount(ctx, *params, filters)
+	s.stats.recordCall(domain.SyncSourceSSOtica, err)
+	s.limiter.observe(err)
 	if err != nil {
 		logrus.WithError(err).Error("TopRankingAccountsService: Erro ao buscar vendas do SSOtica")
 		return nil, err
@@ -465,26 +518,28 @@ func (*TopRankingAccountsService) SumSocialNetworkRevenue(salesInsights []*domai
 
 // TriggerManualSync inicia manualmente uma sincronização de top ranking de contas
 func (s *TopRankingAccountsService) TriggerManualSync() {
-	s.syncMutex.Lock()
-	if s.syncRunning {
-		s.syncMutex.Unlock()
+	if s.jobs.isRunning() {
 		logrus.Info("Sincronização de top ranking de contas já em andamento, ignorando solicitação manual")
 		return
 	}
-	s.syncMutex.Unlock()
 
 	logrus.Info("Iniciando sincronização manual de top ranking de contas")
-	go s.UpdateTopRankingAccounts()
+	go s.UpdateTopRankingAccounts(s.runCtx, domain.JobTriggerManual)
+}
+
+// Paused informa se o agendador foi pausado pelas rotas de administração
+func (s *TopRankingAccountsService) Paused() bool {
+	return s.jobs.isPaused()
 }
 
 // GetStatus retorna o status atual do agendador
 func (s *TopRankingAccountsService) GetStatus() map[string]any {
-	return map[string]any{
-		"sync_enabled":           s.config.SyncEnabled,
-		"sync_cron":              s.config.CronSchedule,
-		"last_sync_started_at":   s.lastSyncStartedAt,
-		"last_sync_completed_at": s.lastSyncCompletedAt,
-	}
+	status := s.jobs.status()
+	status["sync_enabled"] = s.config.SyncEnabled
+	status["sync_cron"] = s.config.CronSchedule
+	status["next_run_at"] = nextRunAt(s.config.SyncEnabled, s.config.CronSchedule, time.Now())
+	status["rate_limit"] = s.limiter.status()
+	return status
 }
 
 func EqualDate(date1, date2 time.Time) bool {
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -76,8 +77,8 @@ func TestTopRankingAccountsService_CronjobScenarios(t *testing.T) {
 				// Mock para vendas do SSOtica (receita total do mês até ontem)
 				ssoticaService.
 					EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
 						orders := []ssoticadomain.Order{}
 
 						if params.CNPJ == *accountsMock[0].CNPJ && params.SecretName == *accountsMock[0].SecretName {
@@ -143,7 +144,7 @@ func TestTopRankingAccountsService_CronjobScenarios(t *testing.T) {
 				}, nil)
 
 				// Mock para vendas do SSOtica (receita total do mês até ontem - 30 de janeiro)
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 					{NetAmount: 20000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 				}, nil)
 
@@ -177,7 +178,7 @@ func TestTopRankingAccountsService_CronjobScenarios(t *testing.T) {
 				}, nil)
 
 				// Mock para vendas do SSOtica (receita total de janeiro até 31 de janeiro)
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 					{NetAmount: 30000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 				}, nil)
 
@@ -206,8 +207,8 @@ func TestTopRankingAccountsService_CronjobScenarios(t *testing.T) {
 				// Mock para vendas do SSOtica (receita total de janeiro até 1 de fevereiro)
 				ssoticaService.
 					EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params ssoticadomain.GetSalesParams, filters *domain.InsigthFilters) ([]ssoticadomain.Order, error) {
 						orders := []ssoticadomain.Order{}
 
 						if params.CNPJ == *accountsMock[0].CNPJ && params.SecretName == *accountsMock[0].SecretName {
@@ -275,7 +276,7 @@ func TestTopRankingAccountsService_CronjobScenarios(t *testing.T) {
 			tt.setup(mockAccountRepo, mockRankingRepo, mockSSOticaService)
 
 			// Executar o método com a data específica
-			result := service.processTopRankingAccountsWithDate(tt.accounts, tt.executionDate)
+			result := service.processTopRankingAccountsWithDate(context.Background(), tt.accounts, tt.executionDate)
 
 			// Validações específicas
 			if tt.validate != nil {
@@ -343,7 +344,7 @@ func TestTopRankingAccountsService_PositionAccuracy(t *testing.T) {
 
 				// Mock para vendas do SSOtica
 				mockSSOticaService.
-					EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+					EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 					{NetAmount: execution.salesData[account.ID], CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 				}, nil)
 			}
@@ -351,7 +352,7 @@ func TestTopRankingAccountsService_PositionAccuracy(t *testing.T) {
 			mockRankingRepo.EXPECT().SaveOrUpdateStoreRanking(gomock.Any()).Return(nil)
 
 			// Executar
-			result := service.processTopRankingAccountsWithDate(accounts, executionDate)
+			result := service.processTopRankingAccountsWithDate(context.Background(), accounts, executionDate)
 
 			// Validar posições
 			assert.Len(t, result, 3)
@@ -389,7 +390,7 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 			name: "Conta sem vendas - deve ter receita zero",
 			setup: func(accountRepo *mocks.MockAccountRepository, rankingRepo *mocks.MockStoreRankingRepository, ssoticaService *ssoticamocks.MockSSOticaIntegrator) {
 				rankingRepo.EXPECT().GetByAccountID("ACC001", "01-2024").Return(nil, nil)
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{}, nil)
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{}, nil)
 				rankingRepo.EXPECT().SaveOrUpdateStoreRanking(gomock.Any()).Return(nil)
 			},
 			accounts: []*domain.AdAccount{
@@ -407,11 +408,11 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 			setup: func(accountRepo *mocks.MockAccountRepository, rankingRepo *mocks.MockStoreRankingRepository, ssoticaService *ssoticamocks.MockSSOticaIntegrator) {
 				// ACC001 falha
 				rankingRepo.EXPECT().GetByAccountID("ACC001", "01-2024").Return(nil, nil)
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
 				// ACC002 funciona
 				rankingRepo.EXPECT().GetByAccountID("ACC002", "01-2024").Return(nil, nil)
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 					{NetAmount: 1000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 				}, nil)
 
@@ -442,7 +443,7 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 					UpdatedAt:            time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
 				}, nil)
 
-				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+				ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 					{NetAmount: 1000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 				}, nil)
 
@@ -465,7 +466,7 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 				for i := 1; i <= 10; i++ {
 					accountID := fmt.Sprintf("ACC%03d", i)
 					rankingRepo.EXPECT().GetByAccountID(accountID, "01-2024").Return(nil, nil)
-					ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+					ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 						{NetAmount: float64(i * 1000), CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil)
 				}
@@ -503,7 +504,7 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 				// Todas as contas com a mesma receita
 				for _, account := range []string{"ACC001", "ACC002", "ACC003"} {
 					rankingRepo.EXPECT().GetByAccountID(account, "01-2024").Return(nil, nil)
-					ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
+					ssoticaService.EXPECT().GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ssoticadomain.Order{
 						{NetAmount: 1000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil)
 				}
@@ -548,7 +549,7 @@ func TestTopRankingAccountsService_EdgeCases(t *testing.T) {
 
 			tt.setup(mockAccountRepo, mockRankingRepo, mockSSOticaService)
 
-			result := service.processTopRankingAccountsWithDate(tt.accounts, tt.date)
+			result := service.processTopRankingAccountsWithDate(context.Background(), tt.accounts, tt.date)
 
 			if tt.validate != nil {
 				tt.validate(t, result)
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -56,7 +57,7 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 
 				// Mock: SSOtica retorna vendas do mês inteiro
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{
 							NetAmount:       1000.0,
@@ -112,7 +113,7 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 
 				// Mock: SSOtica retorna vendas do mês inteiro
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{
 							NetAmount:       800.0,
@@ -163,7 +164,7 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 
 				// Mock: SSOtica retorna vendas do mês inteiro
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{
 							NetAmount:       600.0,
@@ -209,19 +210,19 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 
 				// Mock: SSOtica retorna vendas diferentes para cada conta
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{NetAmount: 2500.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil).Times(1) // ACC001
 
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{NetAmount: 3000.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil).Times(1) // ACC002
 
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{NetAmount: 1500.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil).Times(1) // ACC003
@@ -283,14 +284,14 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 				// Mock: SSOtica retorna vendas diferentes para cada conta
 				// ACC001: receita total do mês até ontem (15 de janeiro) = 1500
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{NetAmount: 1500.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil).Times(1)
 
 				// ACC002: receita total do mês até ontem (15 de janeiro) = 200
 				mockSSOticaService.EXPECT().
-					GetSalesByAccount(gomock.Any(), gomock.Any()).
+					GetSalesByAccount(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]ssoticadomain.Order{
 						{NetAmount: 200.0, CustomerOrigins: []ssoticadomain.Origin{ssoticadomain.SocialNetworkOrigin}},
 					}, nil).Times(1)
@@ -335,7 +336,7 @@ func TestTopRankingAccountsService_processTopRankingAccounts(t *testing.T) {
 
 			// Executar o método com data específica (16 de janeiro)
 			referenceDate := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
-			result := service.processTopRankingAccountsWithDate(tt.accounts, referenceDate)
+			result := service.processTopRankingAccountsWithDate(context.Background(), tt.accounts, referenceDate)
 
 			// Validações específicas
 			if tt.validate != nil {
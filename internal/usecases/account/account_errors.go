@@ -12,16 +12,25 @@ var (
 	ErrAccountNotFound       = errors.New("account not found")
 	ErrInvalidToken          = errors.New("invalid token")
 	ErrTokenValidationFailed = errors.New("token validation failed")
+	ErrInvalidFormatting     = errors.New("invalid currency or locale")
+	ErrInvalidMerge          = errors.New("invalid account merge")
+	ErrAccountAlreadyMerged  = errors.New("account already merged")
+	ErrInvalidLocation       = errors.New("invalid account location")
+	ErrInvalidPeriod         = errors.New("invalid period")
+	ErrInvalidSearch         = errors.New("invalid search term")
+	ErrInvalidPriority       = errors.New("invalid account priority")
 
 	// Erros de serviços externos
 	ErrSSOticaConnection  = errors.New("error connecting to SSOtica")
 	ErrRenderSecretUpdate = errors.New("error updating secret on render")
 	ErrMetaIntegration    = errors.New("error fetching accounts from Meta")
+	ErrGeocoding          = errors.New("error geocoding store address")
 
 	// Erros de banco de dados
 	ErrDatabaseOperation = errors.New("database operation error")
 	ErrUpdateAccount     = errors.New("error updating account")
 	ErrFetchAccounts     = errors.New("error fetching accounts from database")
+	ErrMergeAccounts     = errors.New("error merging accounts")
 
 	// Erros de sincronização
 	ErrGenerateID = errors.New("error generating UUID")
@@ -0,0 +1,155 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/geocoding"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+const geocodingTimeout = 15 * time.Second
+
+// GeocodeAccount define a cidade, a UF e as coordenadas da loja da conta. Sem coordenadas na requisição,
+// o endereço é geocodificado; se o endereço não for encontrado, tenta apenas a cidade.
+func (s *Service) GeocodeAccount(accountID string, request *domain.GeocodeAccountRequest) (*domain.AccountLocation, error) {
+	account, err := s.accountRepository.GetAccountByID(accountID)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao buscar conta para geocodificação")
+		return nil, NewAccountError(ErrDatabaseOperation, apiErrors.ErrDatabaseOperation, "Erro ao buscar conta no banco de dados")
+	}
+	if account == nil {
+		return nil, NewAccountErrorWithID(ErrAccountNotFound, apiErrors.ErrInvalidRequest, accountID, "Conta não encontrada")
+	}
+
+	state, ok := domain.NormalizeState(request.State)
+	city := strings.TrimSpace(request.City)
+	if !ok || city == "" {
+		return nil, NewAccountErrorWithID(ErrInvalidLocation, apiErrors.ErrInvalidRequest, accountID, "Informe a cidade e uma UF válida (ex: SP)")
+	}
+
+	now := time.Now()
+	location := &domain.AccountLocation{City: city, State: state, GeocodedAt: &now}
+
+	switch {
+	case request.Latitude != nil && request.Longitude != nil:
+		if *request.Latitude < -90 || *request.Latitude > 90 || *request.Longitude < -180 || *request.Longitude > 180 {
+			return nil, NewAccountErrorWithID(ErrInvalidLocation, apiErrors.ErrInvalidRequest, accountID, "Coordenadas fora do intervalo válido")
+		}
+		location.Latitude = request.Latitude
+		location.Longitude = request.Longitude
+	case s.geocoder != nil:
+		latitude, longitude, err := s.geocode(request.Address, city, state)
+		if err != nil {
+			logrus.WithError(err).WithField("account_id", accountID).Warn("Erro ao geocodificar endereço da loja")
+			if errors.Is(err, geocoding.ErrAddressNotFound) {
+				return nil, NewAccountErrorWithID(ErrGeocoding, apiErrors.ErrInvalidRequest, accountID, "Endereço não encontrado; informe as coordenadas manualmente")
+			}
+			return nil, NewAccountErrorWithID(ErrGeocoding, apiErrors.ErrExternalService, accountID, "Falha ao geocodificar o endereço da loja")
+		}
+		location.Latitude = &latitude
+		location.Longitude = &longitude
+	}
+
+	if err := s.accountRepository.UpdateAccountLocation(accountID, location); err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao salvar localização da conta")
+		return nil, NewAccountErrorWithID(ErrUpdateAccount, apiErrors.ErrDatabaseOperation, accountID, "Falha ao salvar localização da conta")
+	}
+
+	return location, nil
+}
+
+func (s *Service) geocode(address, city, state string) (float64, float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), geocodingTimeout)
+	defer cancel()
+
+	cityQuery := fmt.Sprintf("%s, %s, Brasil", city, state)
+	if address = strings.TrimSpace(address); address != "" {
+		latitude, longitude, err := s.geocoder.Geocode(ctx, address+", "+cityQuery)
+		if !errors.Is(err, geocoding.ErrAddressNotFound) {
+			return latitude, longitude, err
+		}
+	}
+
+	return s.geocoder.Geocode(ctx, cityQuery)
+}
+
+// GetInsightsMap agrega o investimento e o faturamento em redes sociais das lojas ativas por UF e cidade
+// no período padrão informado (caches de insights)
+func (s *Service) GetInsightsMap(period string) (*domain.InsightsMapResponse, error) {
+	start, end, err := domain.ResolvePeriodPreset(period, time.Now())
+	if err != nil {
+		return nil, NewAccountError(ErrInvalidPeriod, apiErrors.ErrInvalidRequest, err.Error())
+	}
+
+	cities, err := s.accountRepository.ListMapInsights(start, end)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao agregar insights por localização")
+		return nil, NewAccountError(ErrFetchAccounts, apiErrors.ErrDatabaseOperation, "Falha ao agregar insights por localização")
+	}
+
+	response := &domain.InsightsMapResponse{
+		Period:    period,
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+		States:    make([]*domain.MapStateInsight, 0),
+	}
+
+	states := make(map[string]*domain.MapStateInsight)
+	for _, city := range cities {
+		response.Spend += city.Spend
+		response.SocialNetworkRevenue += city.SocialNetworkRevenue
+
+		if city.State == "" {
+			response.UnlocatedAccounts += city.Accounts
+			continue
+		}
+
+		state, ok := states[city.State]
+		if !ok {
+			state = &domain.MapStateInsight{State: city.State, Cities: make([]*domain.MapCityInsight, 0)}
+			states[city.State] = state
+			response.States = append(response.States, state)
+		}
+		state.Accounts += city.Accounts
+		state.Spend += city.Spend
+		state.SocialNetworkRevenue += city.SocialNetworkRevenue
+
+		city.ROI = mapROI(city.SocialNetworkRevenue, city.Spend)
+		city.Spend = utils.RoundWithTwoDecimalPlace(city.Spend)
+		city.SocialNetworkRevenue = utils.RoundWithTwoDecimalPlace(city.SocialNetworkRevenue)
+		state.Cities = append(state.Cities, city)
+	}
+
+	for _, state := range response.States {
+		state.ROI = mapROI(state.SocialNetworkRevenue, state.Spend)
+		state.Spend = utils.RoundWithTwoDecimalPlace(state.Spend)
+		state.SocialNetworkRevenue = utils.RoundWithTwoDecimalPlace(state.SocialNetworkRevenue)
+		sort.Slice(state.Cities, func(i, j int) bool {
+			return state.Cities[i].Spend > state.Cities[j].Spend
+		})
+	}
+	sort.Slice(response.States, func(i, j int) bool {
+		return response.States[i].Spend > response.States[j].Spend
+	})
+
+	response.Spend = utils.RoundWithTwoDecimalPlace(response.Spend)
+	response.SocialNetworkRevenue = utils.RoundWithTwoDecimalPlace(response.SocialNetworkRevenue)
+
+	return response, nil
+}
+
+// mapROI segue o cálculo de ROI dos insights: faturamento em redes sociais dividido pelo investimento
+func mapROI(revenue, spend float64) float64 {
+	if spend == 0 {
+		return 0
+	}
+	return utils.RoundWithTwoDecimalPlace(revenue / spend)
+}
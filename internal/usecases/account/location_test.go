@@ -0,0 +1,91 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/geocoding"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeGeocoder struct {
+	queries []string
+}
+
+func (f *fakeGeocoder) Geocode(ctx context.Context, address string) (float64, float64, error) {
+	f.queries = append(f.queries, address)
+	if address == "Campinas, SP, Brasil" {
+		return -22.9056, -47.0608, nil
+	}
+	return 0, 0, geocoding.ErrAddressNotFound
+}
+
+func TestService_GeocodeAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	geocoder := &fakeGeocoder{}
+	service := &Service{accountRepository: accountRepo, geocoder: geocoder}
+
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(&domain.AdAccount{ID: "abc123"}, nil).AnyTimes()
+
+	_, err := service.GeocodeAccount("abc123", &domain.GeocodeAccountRequest{City: "Campinas", State: "XX"})
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+
+	// Endereço não encontrado cai para a busca pela cidade
+	accountRepo.EXPECT().UpdateAccountLocation("abc123", gomock.Any()).Return(nil)
+	location, err := service.GeocodeAccount("abc123", &domain.GeocodeAccountRequest{Address: "Rua Inexistente, 1", City: " Campinas ", State: "sp"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SP", location.State)
+	assert.Equal(t, "Campinas", location.City)
+	assert.Equal(t, -22.9056, *location.Latitude)
+	assert.Equal(t, []string{"Rua Inexistente, 1, Campinas, SP, Brasil", "Campinas, SP, Brasil"}, geocoder.queries)
+
+	// Coordenadas informadas não passam pela geocodificação
+	latitude, longitude := -23.55, -46.63
+	accountRepo.EXPECT().UpdateAccountLocation("abc123", gomock.Any()).Return(nil)
+	location, err = service.GeocodeAccount("abc123", &domain.GeocodeAccountRequest{City: "São Paulo", State: "SP", Latitude: &latitude, Longitude: &longitude})
+	assert.NoError(t, err)
+	assert.Equal(t, -46.63, *location.Longitude)
+	assert.Len(t, geocoder.queries, 2)
+
+	_, err = service.GeocodeAccount("abc123", &domain.GeocodeAccountRequest{City: "Recife", State: "PE"})
+	assert.ErrorIs(t, err, ErrGeocoding)
+}
+
+func TestService_GetInsightsMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+
+	accountRepo.EXPECT().ListMapInsights(gomock.Any(), gomock.Any()).Return([]*domain.MapCityInsight{
+		{State: "", Accounts: 2, Spend: 100},
+		{State: "MG", City: "Belo Horizonte", Accounts: 1, Spend: 300, SocialNetworkRevenue: 900},
+		{State: "SP", City: "Campinas", Accounts: 1, Spend: 200, SocialNetworkRevenue: 1000},
+		{State: "SP", City: "São Paulo", Accounts: 3, Spend: 600.456, SocialNetworkRevenue: 1200},
+	}, nil)
+
+	response, err := service.GetInsightsMap(domain.PeriodLast7Days)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.UnlocatedAccounts)
+	assert.Equal(t, 1200.46, response.Spend)
+	assert.Equal(t, 3100.0, response.SocialNetworkRevenue)
+
+	assert.Len(t, response.States, 2)
+	sp := response.States[0]
+	assert.Equal(t, "SP", sp.State)
+	assert.Equal(t, 4, sp.Accounts)
+	assert.Equal(t, 800.46, sp.Spend)
+	assert.Equal(t, 2.75, sp.ROI)
+	assert.Equal(t, "São Paulo", sp.Cities[0].City)
+	assert.Equal(t, 5.0, sp.Cities[1].ROI)
+
+	_, err = service.GetInsightsMap("yesterday")
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+}
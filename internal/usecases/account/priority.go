@@ -0,0 +1,34 @@
+package account
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+// SetAccountVIP marca ou desmarca a conta como prioritária. As contas VIP são sincronizadas antes das
+// demais nas execuções agendadas, à frente do score de atividade.
+func (s *Service) SetAccountVIP(accountID string, request *domain.AccountPriorityRequest) (*domain.AdAccountResponse, error) {
+	if request.VIP == nil {
+		return nil, NewAccountErrorWithID(ErrInvalidPriority, apiErrors.ErrInvalidRequest, accountID, "Informe o campo vip")
+	}
+
+	account, err := s.accountRepository.GetAccountByID(accountID)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao buscar conta para definir prioridade")
+		return nil, NewAccountError(ErrDatabaseOperation, apiErrors.ErrDatabaseOperation, "Erro ao buscar conta no banco de dados")
+	}
+	if account == nil {
+		return nil, NewAccountErrorWithID(ErrAccountNotFound, apiErrors.ErrInvalidRequest, accountID, "Conta não encontrada")
+	}
+
+	if account.VIP != *request.VIP {
+		if err := s.accountRepository.UpdateAccountVIP(accountID, *request.VIP); err != nil {
+			logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao salvar prioridade da conta")
+			return nil, NewAccountErrorWithID(ErrUpdateAccount, apiErrors.ErrDatabaseOperation, accountID, "Falha ao salvar prioridade da conta")
+		}
+		account.VIP = *request.VIP
+	}
+
+	return newAdAccountResponse(account), nil
+}
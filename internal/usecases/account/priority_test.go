@@ -0,0 +1,38 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_SetAccountVIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+	vip, regular := true, false
+
+	_, err := service.SetAccountVIP("abc123", &domain.AccountPriorityRequest{})
+	assert.ErrorIs(t, err, ErrInvalidPriority)
+
+	accountRepo.EXPECT().GetAccountByID("xyz999").Return(nil, nil)
+	_, err = service.SetAccountVIP("xyz999", &domain.AccountPriorityRequest{VIP: &vip})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(&domain.AdAccount{ID: "abc123"}, nil)
+	accountRepo.EXPECT().UpdateAccountVIP("abc123", true).Return(nil)
+	response, err := service.SetAccountVIP("abc123", &domain.AccountPriorityRequest{VIP: &vip})
+	assert.NoError(t, err)
+	assert.True(t, response.VIP)
+
+	// Sem mudança, nada é gravado
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(&domain.AdAccount{ID: "abc123"}, nil)
+	response, err = service.SetAccountVIP("abc123", &domain.AccountPriorityRequest{VIP: &regular})
+	assert.NoError(t, err)
+	assert.False(t, response.VIP)
+}
@@ -6,70 +6,171 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/geocoding"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/meta"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica"
 	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
 	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
 	"github.com/vfg2006/traffic-manager-api/pkg/utils"
 )
 
 type AccountService interface {
-	UpdateAccount(request *domain.UpdateAdAccountRequest) (*domain.UpdateAdAccountResponse, error)
-	ListAdAccounts(availableStatus []domain.AdAccountStatus) ([]*domain.AdAccountResponse, error)
-	SyncAccounts() (*domain.SyncAccountsResponse, error)
+	UpdateAccount(request *domain.UpdateAdAccountRequest, userID int) (*domain.UpdateAdAccountResponse, error)
+	ListAdAccounts(filters *domain.AccountListFilters) (*domain.AdAccountListResponse, error)
+	SearchAdAccounts(term string, scope domain.SearchScope) (*domain.AdAccountSearchResponse, error)
+	SyncAccounts(userID int) (*domain.SyncAccountsResponse, error)
+	MergeAccounts(request *domain.MergeAccountsRequest, userID int) (*domain.AccountMerge, error)
+	ListAccountMerges(accountID string) ([]*domain.AccountMerge, error)
+	ListBusinessManagers() (*domain.BusinessManagerDirectoryResponse, error)
+	GeocodeAccount(accountID string, request *domain.GeocodeAccountRequest) (*domain.AccountLocation, error)
+	GetInsightsMap(period string) (*domain.InsightsMapResponse, error)
+	SetAccountVIP(accountID string, request *domain.AccountPriorityRequest) (*domain.AdAccountResponse, error)
 }
 
+const (
+	// directoryDays é o período das métricas do diretório de business managers
+	directoryDays = 30
+	// staleAfterDays é a idade máxima dos últimos insights de uma conta ativa considerada em dia
+	staleAfterDays = 2
+
+	// Limites da busca de contas por apelido, nome e CNPJ
+	minSearchLength    = 2
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
 type Service struct {
 	accountRepository repository.AccountRepository
 	metaService       *meta.MetaIntegrator
 	renderClient      *config.RenderClient
 	ssoticaService    ssotica.SSOticaIntegrator
+	geocoder          geocoding.Geocoder
+	auditor           auditing.Recorder
 	cfg               *config.Config
 }
 
+// Option configura dependências opcionais do Service na construção
+type Option func(*Service)
+
+// WithAuditRecorder registra no log de auditoria as edições e mesclagens de contas e as sincronizações manuais
+func WithAuditRecorder(recorder auditing.Recorder) Option {
+	return func(s *Service) {
+		s.auditor = recorder
+	}
+}
+
+// recordAudit registra a operação no log de auditoria, quando configurado
+func (s *Service) recordAudit(actorID int, action, entityType, entityID string, details any) {
+	if s.auditor != nil {
+		s.auditor.Record(actorID, action, entityType, entityID, details)
+	}
+}
+
 func NewService(
 	accountRepository repository.AccountRepository,
 	metaService *meta.MetaIntegrator,
 	renderClient *config.RenderClient,
 	ssoticaService ssotica.SSOticaIntegrator,
+	geocoder geocoding.Geocoder,
 	cfg *config.Config,
+	opts ...Option,
 ) AccountService {
-	return &Service{
+	s := &Service{
 		accountRepository: accountRepository,
 		metaService:       metaService,
 		renderClient:      renderClient,
 		ssoticaService:    ssoticaService,
+		geocoder:          geocoder,
 		cfg:               cfg,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *Service) ListAdAccounts(availableStatus []domain.AdAccountStatus) ([]*domain.AdAccountResponse, error) {
-	accounts, err := s.accountRepository.ListAccounts(availableStatus)
+// ListAdAccounts retorna uma página das contas que atendem aos filtros. Limit fora do intervalo
+// aceito usa o padrão ou o máximo da listagem.
+func (s *Service) ListAdAccounts(filters *domain.AccountListFilters) (*domain.AdAccountListResponse, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = domain.DefaultAccountListLimit
+	}
+	filters.Limit = min(filters.Limit, domain.MaxAccountListLimit)
+	filters.Offset = max(filters.Offset, 0)
+	filters.Search = strings.TrimSpace(filters.Search)
+
+	accounts, total, err := s.accountRepository.ListAccountsPage(filters)
 	if err != nil {
+		logrus.WithError(err).Error("Erro ao listar contas")
 		return nil, NewAccountError(ErrFetchAccounts, apiErrors.ErrDatabaseOperation, "Falha ao listar contas no banco de dados")
 	}
 
 	// Transforma os accounts para o formato de resposta da API
 	adAccountsResponse := make([]*domain.AdAccountResponse, 0, len(accounts))
 	for _, account := range accounts {
-		adAccountsResponse = append(adAccountsResponse, &domain.AdAccountResponse{
-			ID:         account.ID,
-			ExternalID: account.ExternalID,
-			Name:       account.Name,
-			Nickname:   account.Nickname,
-			Status:     account.Status,
-			CNPJ:       account.CNPJ,
-			HasToken:   account.SecretName != nil,
-		})
+		adAccountsResponse = append(adAccountsResponse, newAdAccountResponse(account))
+	}
+
+	return &domain.AdAccountListResponse{
+		Accounts: adAccountsResponse,
+		Total:    total,
+		Limit:    filters.Limit,
+		Offset:   filters.Offset,
+	}, nil
+}
+
+// SearchAdAccounts busca contas pelo apelido, nome ou CNPJ, limitadas às contas do escopo do usuário
+func (s *Service) SearchAdAccounts(term string, scope domain.SearchScope) (*domain.AdAccountSearchResponse, error) {
+	term = strings.TrimSpace(term)
+	if len([]rune(term)) < minSearchLength {
+		return nil, NewAccountError(ErrInvalidSearch, apiErrors.ErrInvalidFormat, fmt.Sprintf("O termo de busca deve ter ao menos %d caracteres", minSearchLength))
+	}
+	if scope.Limit <= 0 {
+		scope.Limit = defaultSearchLimit
+	}
+	scope.Limit = min(scope.Limit, maxSearchLimit)
+
+	accounts, err := s.accountRepository.SearchAccounts(term, scope)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao buscar contas")
+		return nil, NewAccountError(ErrFetchAccounts, apiErrors.ErrDatabaseOperation, "Falha ao buscar contas no banco de dados")
+	}
+
+	response := &domain.AdAccountSearchResponse{
+		Query:    term,
+		Accounts: make([]*domain.AdAccountResponse, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, newAdAccountResponse(account))
 	}
 
-	return adAccountsResponse, nil
+	return response, nil
 }
 
-func (s *Service) SyncAccounts() (*domain.SyncAccountsResponse, error) {
+// newAdAccountResponse monta a representação da conta usada nas listagens
+func newAdAccountResponse(account *domain.AdAccount) *domain.AdAccountResponse {
+	return &domain.AdAccountResponse{
+		ID:         account.ID,
+		ExternalID: account.ExternalID,
+		Name:       account.Name,
+		Nickname:   account.Nickname,
+		Status:     account.Status,
+		CNPJ:       account.CNPJ,
+		HasToken:   account.SecretName != nil,
+		Format:     account.FormatMetadata(),
+		Timezone:   account.TimeLocation().String(),
+		MergedInto: account.MergedInto,
+		VIP:        account.VIP,
+		Location:   account.Location,
+	}
+}
+
+// SyncAccounts cadastra as contas novas do Meta. userID é quem disparou a sincronização, registrado na auditoria.
+func (s *Service) SyncAccounts(userID int) (*domain.SyncAccountsResponse, error) {
 	response := &domain.SyncAccountsResponse{
 		Quantity: 0,
 		Message:  "Erro ao sincronizar contas",
@@ -90,11 +191,16 @@ func (s *Service) SyncAccounts() (*domain.SyncAccountsResponse, error) {
 
 	bms := make([]*domain.BusinessManager, 0)
 	accountsToCreate := make([]*domain.AdAccount, 0)
+	currencies := make(map[string]string)
 	for _, acc := range accounts {
 		externalID := strings.Split(acc.ExternalID, "_")[1]
 		compositeKey := fmt.Sprintf("%s:%s", acc.Origin, externalID)
 
 		if _, exists := existingAccounts[compositeKey]; exists {
+			// Contas já cadastradas acompanham a moeda de cobrança informada pelo Meta
+			if domain.IsValidCurrency(acc.Currency) {
+				currencies[externalID] = acc.Currency
+			}
 			continue
 		}
 
@@ -127,6 +233,10 @@ func (s *Service) SyncAccounts() (*domain.SyncAccountsResponse, error) {
 		return response, NewAccountError(ErrDatabaseOperation, apiErrors.ErrDatabaseOperation, "Falha ao salvar business managers")
 	}
 
+	if err := s.accountRepository.UpdateAccountCurrencies("meta", currencies); err != nil {
+		logrus.WithError(err).Error("Error updating ad account currencies")
+	}
+
 	// Agora tenta salvar as contas com os business managers resolvidos
 	if len(accountsToCreate) > 0 {
 		err = s.accountRepository.SaveOrUpdate(accountsToCreate, businessManagerIDs)
@@ -143,10 +253,14 @@ func (s *Service) SyncAccounts() (*domain.SyncAccountsResponse, error) {
 	response.Message = fmt.Sprintf("%d contas foram sincronizadas com sucesso", quantity)
 	response.Error = false
 
+	s.recordAudit(userID, domain.AuditActionSyncTrigger, domain.AuditEntityJob, "accounts", map[string]any{
+		"created_accounts": quantity,
+	})
+
 	return response, nil
 }
 
-func (s *Service) UpdateAccount(request *domain.UpdateAdAccountRequest) (*domain.UpdateAdAccountResponse, error) {
+func (s *Service) UpdateAccount(request *domain.UpdateAdAccountRequest, userID int) (*domain.UpdateAdAccountResponse, error) {
 	if request.ID == "" {
 		return nil, ErrAccountIDRequired
 	}
@@ -162,6 +276,22 @@ func (s *Service) UpdateAccount(request *domain.UpdateAdAccountRequest) (*domain
 		return nil, NewAccountErrorWithID(ErrAccountNotFound, apiErrors.ErrInvalidRequest, request.ID, "Conta não encontrada")
 	}
 
+	if account.MergedInto != nil && request.Status != nil && domain.AdAccountStatus(*request.Status) == domain.AdAccountStatusActive {
+		return nil, NewAccountErrorWithID(ErrAccountAlreadyMerged, apiErrors.ErrInvalidRequest, request.ID, "Conta mesclada em "+*account.MergedInto+" não pode ser reativada")
+	}
+
+	if request.Currency != nil && !domain.IsValidCurrency(*request.Currency) {
+		return nil, NewAccountErrorWithID(ErrInvalidFormatting, apiErrors.ErrInvalidFormat, request.ID, "Moeda deve ser um código ISO 4217 (ex: BRL)")
+	}
+
+	if request.Locale != nil && !domain.IsValidLocale(*request.Locale) {
+		return nil, NewAccountErrorWithID(ErrInvalidFormatting, apiErrors.ErrInvalidFormat, request.ID, "Locale deve estar no formato idioma-REGIÃO (ex: pt-BR)")
+	}
+
+	if request.Timezone != nil && !domain.IsValidTimezone(*request.Timezone) {
+		return nil, NewAccountErrorWithID(ErrInvalidFormatting, apiErrors.ErrInvalidFormat, request.ID, "Fuso horário deve ser um nome IANA (ex: America/Sao_Paulo)")
+	}
+
 	if request.Token != nil && *request.Token != "" {
 		key := fmt.Sprintf("ssotica_bm-%s-act-%s", account.BusinessManagerID, account.ID)
 
@@ -202,6 +332,7 @@ func (s *Service) UpdateAccount(request *domain.UpdateAdAccountRequest) (*domain
 		logrus.Error("Error updating account on the repository:", err)
 		return nil, NewAccountErrorWithID(ErrUpdateAccount, apiErrors.ErrDatabaseOperation, request.ID, "Falha ao atualizar conta no banco de dados")
 	}
+	s.recordAudit(userID, domain.AuditActionAccountUpdate, domain.AuditEntityAccount, request.ID, accountUpdateAuditDetails(request))
 
 	return &domain.UpdateAdAccountResponse{
 		ID:         request.ID,
@@ -209,5 +340,131 @@ func (s *Service) UpdateAccount(request *domain.UpdateAdAccountRequest) (*domain
 		CNPJ:       request.CNPJ,
 		SecretName: request.SecretName,
 		Status:     request.Status,
+		Currency:   request.Currency,
+		Locale:     request.Locale,
+		Timezone:   request.Timezone,
+	}, nil
+}
+
+// accountUpdateAuditDetails lista os campos alterados. CNPJ, token e secret_name aparecem apenas como
+// alterados, sem o valor.
+func accountUpdateAuditDetails(request *domain.UpdateAdAccountRequest) map[string]any {
+	fields := make([]string, 0, 8)
+	details := map[string]any{}
+	for _, field := range []struct {
+		name  string
+		value *string
+		show  bool
+	}{
+		{"nickname", request.Nickname, true},
+		{"cnpj", request.CNPJ, false},
+		{"secret_name", request.SecretName, false},
+		{"token", request.Token, false},
+		{"status", request.Status, true},
+		{"currency", request.Currency, true},
+		{"locale", request.Locale, true},
+		{"timezone", request.Timezone, true},
+	} {
+		if field.value == nil {
+			continue
+		}
+		fields = append(fields, field.name)
+		if field.show {
+			details[field.name] = *field.value
+		}
+	}
+	details["fields"] = fields
+	return details
+}
+
+// MergeAccounts mescla uma conta duplicada (ex: conta de anúncios recriada na Meta) na conta que permanece.
+// Insights, vendas, ranking e vínculos de usuários são transferidos; a origem fica inativa com o histórico preservado.
+func (s *Service) MergeAccounts(request *domain.MergeAccountsRequest, userID int) (*domain.AccountMerge, error) {
+	if request.SourceID == "" || request.TargetID == "" {
+		return nil, NewAccountError(ErrInvalidMerge, apiErrors.ErrMissingRequiredData, "Contas de origem e destino são obrigatórias")
+	}
+	if request.SourceID == request.TargetID {
+		return nil, NewAccountError(ErrInvalidMerge, apiErrors.ErrInvalidRequest, "Contas de origem e destino devem ser diferentes")
+	}
+
+	source, err := s.accountRepository.GetAccountByID(request.SourceID)
+	if err != nil {
+		return nil, NewAccountError(ErrDatabaseOperation, apiErrors.ErrDatabaseOperation, "Erro ao buscar conta no banco de dados")
+	}
+	if source == nil {
+		return nil, NewAccountErrorWithID(ErrAccountNotFound, apiErrors.ErrResourceNotFound, request.SourceID, "Conta de origem não encontrada")
+	}
+	if source.MergedInto != nil {
+		return nil, NewAccountErrorWithID(ErrAccountAlreadyMerged, apiErrors.ErrInvalidRequest, request.SourceID, "Conta de origem já foi mesclada em "+*source.MergedInto)
+	}
+
+	target, err := s.accountRepository.GetAccountByID(request.TargetID)
+	if err != nil {
+		return nil, NewAccountError(ErrDatabaseOperation, apiErrors.ErrDatabaseOperation, "Erro ao buscar conta no banco de dados")
+	}
+	if target == nil {
+		return nil, NewAccountErrorWithID(ErrAccountNotFound, apiErrors.ErrResourceNotFound, request.TargetID, "Conta de destino não encontrada")
+	}
+	if target.MergedInto != nil {
+		return nil, NewAccountErrorWithID(ErrAccountAlreadyMerged, apiErrors.ErrInvalidRequest, request.TargetID, "Conta de destino já foi mesclada em "+*target.MergedInto)
+	}
+
+	merge := &domain.AccountMerge{
+		SourceID: source.ID,
+		TargetID: target.ID,
+		MergedBy: &userID,
+		Reason:   strings.TrimSpace(request.Reason),
+	}
+	if err := s.accountRepository.MergeAccounts(merge); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"source_id": source.ID,
+			"target_id": target.ID,
+		}).Error("Erro ao mesclar contas")
+		return nil, NewAccountErrorWithID(ErrMergeAccounts, apiErrors.ErrDatabaseOperation, source.ID, "Falha ao mesclar contas no banco de dados")
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"source_id":  source.ID,
+		"target_id":  target.ID,
+		"moved_rows": merge.MovedRows,
+		"kept_rows":  merge.KeptRows,
+	}).Info("Contas mescladas")
+	s.recordAudit(userID, domain.AuditActionAccountMerge, domain.AuditEntityAccount, source.ID, merge)
+
+	return merge, nil
+}
+
+func (s *Service) ListAccountMerges(accountID string) ([]*domain.AccountMerge, error) {
+	merges, err := s.accountRepository.ListAccountMerges(accountID)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao listar mesclagens de contas")
+		return nil, NewAccountError(ErrFetchAccounts, apiErrors.ErrDatabaseOperation, "Falha ao listar mesclagens de contas")
+	}
+	return merges, nil
+}
+
+// ListBusinessManagers lista os business managers com contas, investimento e faturamento em redes sociais
+// dos últimos 30 dias (caches de insights) e a situação das sincronizações
+func (s *Service) ListBusinessManagers() (*domain.BusinessManagerDirectoryResponse, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	startDate := today.AddDate(0, 0, -directoryDays)
+	endDate := today.AddDate(0, 0, -1)
+
+	summaries, err := s.accountRepository.ListBusinessManagerSummaries(startDate, endDate, today.AddDate(0, 0, -staleAfterDays))
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao listar business managers")
+		return nil, NewAccountError(ErrFetchAccounts, apiErrors.ErrDatabaseOperation, "Falha ao listar business managers")
+	}
+
+	for _, summary := range summaries {
+		summary.Spend = utils.RoundWithTwoDecimalPlace(summary.Spend)
+		summary.SocialNetworkRevenue = utils.RoundWithTwoDecimalPlace(summary.SocialNetworkRevenue)
+		summary.CalculateSyncHealth()
+	}
+
+	return &domain.BusinessManagerDirectoryResponse{
+		StartDate:        startDate.Format(time.DateOnly),
+		EndDate:          endDate.Format(time.DateOnly),
+		BusinessManagers: summaries,
 	}, nil
 }
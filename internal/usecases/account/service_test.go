@@ -0,0 +1,126 @@
+package account
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_MergeAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+
+	_, err := service.MergeAccounts(&domain.MergeAccountsRequest{SourceID: "abc123", TargetID: "abc123"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidMerge)
+
+	// Conta de origem já mesclada não pode ser mesclada novamente
+	mergedInto := "zzz999"
+	accountRepo.EXPECT().GetAccountByID("old001").Return(&domain.AdAccount{ID: "old001", MergedInto: &mergedInto}, nil)
+	_, err = service.MergeAccounts(&domain.MergeAccountsRequest{SourceID: "old001", TargetID: "new001"}, 1)
+	assert.ErrorIs(t, err, ErrAccountAlreadyMerged)
+
+	accountRepo.EXPECT().GetAccountByID("old002").Return(&domain.AdAccount{ID: "old002"}, nil)
+	accountRepo.EXPECT().GetAccountByID("new002").Return(&domain.AdAccount{ID: "new002"}, nil)
+	accountRepo.EXPECT().MergeAccounts(gomock.Any()).DoAndReturn(func(merge *domain.AccountMerge) error {
+		assert.Equal(t, "old002", merge.SourceID)
+		assert.Equal(t, "new002", merge.TargetID)
+		assert.Equal(t, 7, *merge.MergedBy)
+		assert.Equal(t, "conta recriada na Meta", merge.Reason)
+		merge.MovedRows = map[string]int{"ad_insights": 30}
+		return nil
+	})
+
+	merge, err := service.MergeAccounts(&domain.MergeAccountsRequest{SourceID: "old002", TargetID: "new002", Reason: " conta recriada na Meta "}, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, merge.MovedRows["ad_insights"])
+
+	accountRepo.EXPECT().GetAccountByID("old003").Return(&domain.AdAccount{ID: "old003"}, nil)
+	accountRepo.EXPECT().GetAccountByID("new003").Return(&domain.AdAccount{ID: "new003"}, nil)
+	accountRepo.EXPECT().MergeAccounts(gomock.Any()).Return(errors.New("deadlock"))
+
+	_, err = service.MergeAccounts(&domain.MergeAccountsRequest{SourceID: "old003", TargetID: "new003"}, 7)
+	assert.ErrorIs(t, err, ErrMergeAccounts)
+}
+
+func TestService_ListBusinessManagers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+
+	accountRepo.EXPECT().ListBusinessManagerSummaries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*domain.BusinessManagerSummary{
+		{ID: "bm0001", ActiveAccounts: 3, StaleAccounts: 0, Spend: 1200.456},
+		{ID: "bm0002", ActiveAccounts: 3, StaleAccounts: 1},
+		{ID: "bm0003", ActiveAccounts: 2, StaleAccounts: 2},
+		{ID: "bm0004", Accounts: 1},
+	}, nil)
+
+	directory, err := service.ListBusinessManagers()
+	assert.NoError(t, err)
+	assert.Len(t, directory.BusinessManagers, 4)
+	assert.Equal(t, 1200.46, directory.BusinessManagers[0].Spend)
+	assert.Equal(t, domain.SyncHealthHealthy, directory.BusinessManagers[0].SyncHealth)
+	assert.Equal(t, domain.SyncHealthDegraded, directory.BusinessManagers[1].SyncHealth)
+	assert.Equal(t, domain.SyncHealthFailing, directory.BusinessManagers[2].SyncHealth)
+	assert.Equal(t, domain.SyncHealthInactive, directory.BusinessManagers[3].SyncHealth)
+}
+
+func TestService_ListAdAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+
+	nickname := "Loja Centro"
+	accountRepo.EXPECT().ListAccountsPage(&domain.AccountListFilters{
+		Status: []domain.AdAccountStatus{domain.AdAccountStatusActive},
+		Search: "centro",
+		Limit:  domain.MaxAccountListLimit,
+	}).Return([]*domain.AdAccount{{ID: "abc123", Nickname: &nickname, Status: domain.AdAccountStatusActive}}, 742, nil)
+
+	// Limite acima do máximo é reduzido e a busca ignora espaços nas pontas
+	page, err := service.ListAdAccounts(&domain.AccountListFilters{
+		Status: []domain.AdAccountStatus{domain.AdAccountStatusActive},
+		Search: " centro ",
+		Limit:  5000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 742, page.Total)
+	assert.Equal(t, domain.MaxAccountListLimit, page.Limit)
+	assert.Len(t, page.Accounts, 1)
+	assert.Equal(t, "abc123", page.Accounts[0].ID)
+
+	accountRepo.EXPECT().ListAccountsPage(gomock.Any()).Return(nil, 0, errors.New("conexão recusada"))
+	_, err = service.ListAdAccounts(&domain.AccountListFilters{})
+	assert.ErrorIs(t, err, ErrFetchAccounts)
+}
+
+func TestService_SearchAdAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := &Service{accountRepository: accountRepo}
+
+	nickname := "Ótica Centro"
+	accountRepo.EXPECT().SearchAccounts("centro", domain.SearchScope{UserID: 7, Limit: maxSearchLimit}).
+		Return([]*domain.AdAccount{{ID: "abc123", Nickname: &nickname}}, nil)
+
+	response, err := service.SearchAdAccounts(" centro ", domain.SearchScope{UserID: 7, Limit: 500})
+	assert.NoError(t, err)
+	assert.Equal(t, "centro", response.Query)
+	assert.Len(t, response.Accounts, 1)
+	assert.Equal(t, "abc123", response.Accounts[0].ID)
+
+	_, err = service.SearchAdAccounts("c", domain.SearchScope{})
+	assert.ErrorIs(t, err, ErrInvalidSearch)
+}
@@ -0,0 +1,160 @@
+package activity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+	maxNoteLength    = 2000
+	// scoreWindow é o período considerado no score de atividade que prioriza as sincronizações
+	scoreWindow = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidNote     = errors.New("nota inválida")
+	ErrInvalidFilter   = errors.New("filtro de atividades inválido")
+	ErrAccountNotFound = errors.New("conta não encontrada")
+)
+
+// Recorder registra eventos na linha do tempo das contas.
+// Falhas são registradas em log e não afetam quem publicou o evento.
+type Recorder interface {
+	Record(accountID, activityType, summary string, details any, userID *int)
+}
+
+// ViewRecorder registra as visualizações do dashboard de uma conta
+type ViewRecorder interface {
+	RecordView(accountID string)
+}
+
+// Prioritizer informa o score de atividade de cada conta, usado para ordenar a fila de sincronização
+type Prioritizer interface {
+	SyncPriorities() (map[string]float64, error)
+}
+
+type ActivityService interface {
+	Recorder
+	ViewRecorder
+	Prioritizer
+	ListActivities(accountID string, filters domain.AccountActivityFilters) ([]*domain.AccountActivity, error)
+	AddNote(accountID string, userID int, req *domain.CreateAccountNoteRequest) (*domain.AccountActivity, error)
+}
+
+type Service struct {
+	activityRepo repository.AccountActivityRepository
+}
+
+func NewService(activityRepo repository.AccountActivityRepository) ActivityService {
+	return &Service{
+		activityRepo: activityRepo,
+	}
+}
+
+func (s *Service) Record(accountID, activityType, summary string, details any, userID *int) {
+	activity := &domain.AccountActivity{
+		AccountID: accountID,
+		Type:      activityType,
+		Summary:   summary,
+		UserID:    userID,
+	}
+
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			logrus.WithError(err).WithField("account_id", accountID).Error("activity: erro ao serializar detalhes")
+			return
+		}
+		activity.Details = encoded
+	}
+
+	if _, err := s.activityRepo.CreateActivity(activity); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"account_id": accountID,
+			"type":       activityType,
+		}).Error("activity: erro ao registrar atividade")
+	}
+}
+
+// RecordView registra a visualização em background para não atrasar a resposta do dashboard
+func (s *Service) RecordView(accountID string) {
+	viewedAt := time.Now()
+	go func() {
+		if err := s.activityRepo.RecordView(accountID, viewedAt); err != nil {
+			logrus.WithError(err).WithField("account_id", accountID).Error("activity: erro ao registrar visualização")
+		}
+	}()
+}
+
+// SyncPriorities retorna o score de atividade dos últimos 7 dias por ID interno da conta.
+// Contas sem visualizações nem investimento no período não aparecem no mapa.
+func (s *Service) SyncPriorities() (map[string]float64, error) {
+	scores, err := s.activityRepo.ListActivityScores(time.Now().Add(-scoreWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := make(map[string]float64, len(scores))
+	for _, score := range scores {
+		priorities[score.AccountID] = score.Score()
+	}
+	return priorities, nil
+}
+
+// ListActivities retorna a linha do tempo da conta, da atividade mais recente para a mais antiga
+func (s *Service) ListActivities(accountID string, filters domain.AccountActivityFilters) ([]*domain.AccountActivity, error) {
+	for _, activityType := range filters.Types {
+		if !slices.Contains(domain.AccountActivityTypes, activityType) {
+			return nil, fmt.Errorf("%w: tipo %q desconhecido", ErrInvalidFilter, activityType)
+		}
+	}
+
+	switch {
+	case filters.Limit < 0:
+		return nil, fmt.Errorf("%w: limite deve ser positivo", ErrInvalidFilter)
+	case filters.Limit == 0:
+		filters.Limit = defaultListLimit
+	case filters.Limit > maxListLimit:
+		filters.Limit = maxListLimit
+	}
+
+	return s.activityRepo.ListActivities(accountID, filters)
+}
+
+// AddNote registra uma nota manual do usuário na linha do tempo da conta
+func (s *Service) AddNote(accountID string, userID int, req *domain.CreateAccountNoteRequest) (*domain.AccountActivity, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: texto é obrigatório", ErrInvalidNote)
+	}
+	if utf8.RuneCountInString(text) > maxNoteLength {
+		return nil, fmt.Errorf("%w: texto deve ter no máximo %d caracteres", ErrInvalidNote, maxNoteLength)
+	}
+
+	activity, err := s.activityRepo.CreateActivity(&domain.AccountActivity{
+		AccountID: accountID,
+		Type:      domain.AccountActivityNote,
+		Summary:   text,
+		UserID:    &userID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,70 @@
+package activity
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	activityRepo := mocks.NewMockAccountActivityRepository(ctrl)
+	service := NewService(activityRepo)
+
+	t.Run("salva nota sem espaços extras", func(t *testing.T) {
+		activityRepo.EXPECT().CreateActivity(gomock.Any()).DoAndReturn(func(activity *domain.AccountActivity) (*domain.AccountActivity, error) {
+			assert.Equal(t, domain.AccountActivityNote, activity.Type)
+			assert.Equal(t, "Loja fechada para reforma", activity.Summary)
+			assert.Equal(t, 7, *activity.UserID)
+			return activity, nil
+		})
+
+		_, err := service.AddNote("abc123", 7, &domain.CreateAccountNoteRequest{Text: "  Loja fechada para reforma "})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejeita nota vazia ou longa demais", func(t *testing.T) {
+		_, err := service.AddNote("abc123", 7, &domain.CreateAccountNoteRequest{Text: "   "})
+		assert.ErrorIs(t, err, ErrInvalidNote)
+
+		_, err = service.AddNote("abc123", 7, &domain.CreateAccountNoteRequest{Text: strings.Repeat("a", maxNoteLength+1)})
+		assert.ErrorIs(t, err, ErrInvalidNote)
+	})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		activityRepo.EXPECT().CreateActivity(gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		_, err := service.AddNote("zzz999", 7, &domain.CreateAccountNoteRequest{Text: "nota"})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
+
+func TestListActivities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	activityRepo := mocks.NewMockAccountActivityRepository(ctrl)
+	service := NewService(activityRepo)
+
+	t.Run("aplica limite padrão e máximo", func(t *testing.T) {
+		activityRepo.EXPECT().ListActivities("abc123", domain.AccountActivityFilters{Limit: defaultListLimit}).Return(nil, nil)
+		activityRepo.EXPECT().ListActivities("abc123", domain.AccountActivityFilters{Limit: maxListLimit}).Return(nil, nil)
+
+		_, err := service.ListActivities("abc123", domain.AccountActivityFilters{})
+		assert.NoError(t, err)
+		_, err = service.ListActivities("abc123", domain.AccountActivityFilters{Limit: 10_000})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejeita tipo desconhecido", func(t *testing.T) {
+		_, err := service.ListActivities("abc123", domain.AccountActivityFilters{Types: []string{"login"}})
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+}
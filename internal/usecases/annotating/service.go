@@ -0,0 +1,130 @@
+package annotating
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	maxAnnotationLength = 500
+	// maxListRange limita o intervalo consultado de uma vez, no mesmo espírito dos filtros de insights
+	maxListRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidAnnotation  = errors.New("anotação inválida")
+	ErrAnnotationNotFound = errors.New("anotação não encontrada")
+	ErrAccountNotFound    = errors.New("conta não encontrada")
+)
+
+type AnnotationService interface {
+	CreateAnnotation(accountID string, userID int, req *domain.AnnotationRequest) (*domain.Annotation, error)
+	ListAnnotations(accountID string, startDate, endDate time.Time) ([]*domain.Annotation, error)
+	UpdateAnnotation(accountID string, annotationID int, req *domain.AnnotationRequest) (*domain.Annotation, error)
+	DeleteAnnotation(accountID string, annotationID int) error
+}
+
+type Service struct {
+	annotationRepo repository.AnnotationRepository
+}
+
+func NewService(annotationRepo repository.AnnotationRepository) AnnotationService {
+	return &Service{
+		annotationRepo: annotationRepo,
+	}
+}
+
+func (s *Service) CreateAnnotation(accountID string, userID int, req *domain.AnnotationRequest) (*domain.Annotation, error) {
+	annotation, err := buildAnnotation(req)
+	if err != nil {
+		return nil, err
+	}
+	annotation.AccountID = accountID
+	annotation.UserID = &userID
+
+	created, err := s.annotationRepo.CreateAnnotation(annotation)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *Service) ListAnnotations(accountID string, startDate, endDate time.Time) ([]*domain.Annotation, error) {
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("%w: a data de início não pode ser posterior à data de fim", ErrInvalidAnnotation)
+	}
+	if endDate.Sub(startDate) > maxListRange {
+		return nil, fmt.Errorf("%w: o intervalo máximo é de um ano", ErrInvalidAnnotation)
+	}
+
+	return s.annotationRepo.ListAnnotations(accountID, startDate, endDate)
+}
+
+func (s *Service) UpdateAnnotation(accountID string, annotationID int, req *domain.AnnotationRequest) (*domain.Annotation, error) {
+	annotation, err := buildAnnotation(req)
+	if err != nil {
+		return nil, err
+	}
+	annotation.ID = annotationID
+	annotation.AccountID = accountID
+
+	updated, err := s.annotationRepo.UpdateAnnotation(annotation)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAnnotationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *Service) DeleteAnnotation(accountID string, annotationID int) error {
+	err := s.annotationRepo.DeleteAnnotation(accountID, annotationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAnnotationNotFound
+	}
+	return err
+}
+
+// buildAnnotation valida a requisição. Sem tipo informado, a anotação é registrada como "outro".
+func buildAnnotation(req *domain.AnnotationRequest) (*domain.Annotation, error) {
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(req.Date))
+	if err != nil {
+		return nil, fmt.Errorf("%w: data deve estar no formato YYYY-MM-DD", ErrInvalidAnnotation)
+	}
+
+	annotationType := strings.TrimSpace(req.Type)
+	if annotationType == "" {
+		annotationType = domain.AnnotationTypeOther
+	}
+	if !slices.Contains(domain.AnnotationTypes, annotationType) {
+		return nil, fmt.Errorf("%w: tipo %q desconhecido", ErrInvalidAnnotation, annotationType)
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: texto é obrigatório", ErrInvalidAnnotation)
+	}
+	if utf8.RuneCountInString(text) > maxAnnotationLength {
+		return nil, fmt.Errorf("%w: texto deve ter no máximo %d caracteres", ErrInvalidAnnotation, maxAnnotationLength)
+	}
+
+	return &domain.Annotation{
+		Date: date.Format(time.DateOnly),
+		Type: annotationType,
+		Text: text,
+	}, nil
+}
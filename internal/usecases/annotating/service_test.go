@@ -0,0 +1,70 @@
+package annotating
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAnnotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	annotationRepo := mocks.NewMockAnnotationRepository(ctrl)
+	service := NewService(annotationRepo)
+
+	t.Run("salva anotação com tipo padrão", func(t *testing.T) {
+		annotationRepo.EXPECT().CreateAnnotation(gomock.Any()).DoAndReturn(func(annotation *domain.Annotation) (*domain.Annotation, error) {
+			assert.Equal(t, "act_123", annotation.AccountID)
+			assert.Equal(t, "2025-06-12", annotation.Date)
+			assert.Equal(t, domain.AnnotationTypeOther, annotation.Type)
+			assert.Equal(t, "Dia dos Namorados", annotation.Text)
+			return annotation, nil
+		})
+
+		_, err := service.CreateAnnotation("act_123", 1, &domain.AnnotationRequest{Date: "2025-06-12", Text: " Dia dos Namorados "})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejeita data, tipo ou texto inválidos", func(t *testing.T) {
+		_, err := service.CreateAnnotation("act_123", 1, &domain.AnnotationRequest{Date: "12/06/2025", Text: "x"})
+		assert.ErrorIs(t, err, ErrInvalidAnnotation)
+
+		_, err = service.CreateAnnotation("act_123", 1, &domain.AnnotationRequest{Date: "2025-06-12", Type: "promoção", Text: "x"})
+		assert.ErrorIs(t, err, ErrInvalidAnnotation)
+
+		_, err = service.CreateAnnotation("act_123", 1, &domain.AnnotationRequest{Date: "2025-06-12", Type: domain.AnnotationTypeHoliday})
+		assert.ErrorIs(t, err, ErrInvalidAnnotation)
+	})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		annotationRepo.EXPECT().CreateAnnotation(gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		_, err := service.CreateAnnotation("act_999", 1, &domain.AnnotationRequest{Date: "2025-06-12", Text: "x"})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
+
+func TestListAnnotations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	annotationRepo := mocks.NewMockAnnotationRepository(ctrl)
+	service := NewService(annotationRepo)
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.ListAnnotations("act_123", start, start.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, ErrInvalidAnnotation)
+
+	_, err = service.ListAnnotations("act_123", start, start.AddDate(2, 0, 0))
+	assert.ErrorIs(t, err, ErrInvalidAnnotation)
+
+	annotationRepo.EXPECT().DeleteAnnotation("act_123", 9).Return(sql.ErrNoRows)
+	assert.ErrorIs(t, service.DeleteAnnotation("act_123", 9), ErrAnnotationNotFound)
+}
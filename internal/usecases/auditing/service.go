@@ -0,0 +1,90 @@
+package auditing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var ErrInvalidFilter = errors.New("filtro de auditoria inválido")
+
+// Recorder registra operações sensíveis no log de auditoria. actorID 0 indica operação sem usuário
+// (ex: rotina agendada). Falhas são registradas em log e não afetam a operação auditada.
+type Recorder interface {
+	Record(actorID int, action, entityType, entityID string, details any)
+}
+
+type AuditService interface {
+	Recorder
+	ListEntries(filters domain.AuditLogFilters) (*domain.AuditLogPage, error)
+}
+
+type Service struct {
+	auditRepo repository.AuditLogRepository
+}
+
+func NewService(auditRepo repository.AuditLogRepository) AuditService {
+	return &Service{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *Service) Record(actorID int, action, entityType, entityID string, details any) {
+	entry := &domain.AuditEntry{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if actorID != 0 {
+		entry.ActorID = &actorID
+	}
+
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			logrus.WithError(err).WithField("action", action).Error("audit: erro ao serializar detalhes")
+			return
+		}
+		entry.Details = encoded
+	}
+
+	if err := s.auditRepo.CreateEntry(entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":      action,
+			"entity_type": entityType,
+			"entity_id":   entityID,
+			"actor_id":    actorID,
+		}).Error("audit: erro ao registrar operação")
+	}
+}
+
+// ListEntries retorna uma página do log de auditoria, do registro mais recente para o mais antigo
+func (s *Service) ListEntries(filters domain.AuditLogFilters) (*domain.AuditLogPage, error) {
+	switch {
+	case filters.Limit < 0 || filters.Offset < 0:
+		return nil, fmt.Errorf("%w: limit e offset devem ser positivos", ErrInvalidFilter)
+	case filters.Limit == 0:
+		filters.Limit = domain.DefaultAuditLogLimit
+	case filters.Limit > domain.MaxAuditLogLimit:
+		filters.Limit = domain.MaxAuditLogLimit
+	}
+	if filters.From != nil && filters.To != nil && !filters.From.Before(*filters.To) {
+		return nil, fmt.Errorf("%w: from deve ser anterior a to", ErrInvalidFilter)
+	}
+
+	entries, total, err := s.auditRepo.ListEntries(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuditLogPage{
+		Entries: entries,
+		Total:   total,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+	}, nil
+}
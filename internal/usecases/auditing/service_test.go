@@ -0,0 +1,83 @@
+package auditing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := mocks.NewMockAuditLogRepository(ctrl)
+	service := NewService(auditRepo)
+
+	t.Run("registra autor, entidade e detalhes", func(t *testing.T) {
+		auditRepo.EXPECT().CreateEntry(gomock.Any()).DoAndReturn(func(entry *domain.AuditEntry) error {
+			assert.Equal(t, 7, *entry.ActorID)
+			assert.Equal(t, domain.AuditActionAccountLink, entry.Action)
+			assert.Equal(t, domain.AuditEntityUser, entry.EntityType)
+			assert.Equal(t, "12", entry.EntityID)
+			assert.JSONEq(t, `{"account_id":"abc123"}`, string(entry.Details))
+			return nil
+		})
+
+		service.Record(7, domain.AuditActionAccountLink, domain.AuditEntityUser, "12", map[string]any{"account_id": "abc123"})
+	})
+
+	t.Run("operação sem usuário fica sem autor", func(t *testing.T) {
+		auditRepo.EXPECT().CreateEntry(gomock.Any()).DoAndReturn(func(entry *domain.AuditEntry) error {
+			assert.Nil(t, entry.ActorID)
+			assert.Empty(t, entry.Details)
+			return nil
+		})
+
+		service.Record(0, domain.AuditActionSyncTrigger, domain.AuditEntityJob, "meta", nil)
+	})
+
+	t.Run("falha ao gravar não interrompe a operação", func(t *testing.T) {
+		auditRepo.EXPECT().CreateEntry(gomock.Any()).Return(errors.New("conexão perdida"))
+
+		assert.NotPanics(t, func() {
+			service.Record(7, domain.AuditActionUserUpdate, domain.AuditEntityUser, "12", nil)
+		})
+	})
+}
+
+func TestListEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := mocks.NewMockAuditLogRepository(ctrl)
+	service := NewService(auditRepo)
+
+	t.Run("aplica o limite padrão e o máximo", func(t *testing.T) {
+		auditRepo.EXPECT().ListEntries(gomock.Any()).DoAndReturn(func(filters domain.AuditLogFilters) ([]*domain.AuditEntry, int, error) {
+			assert.Equal(t, domain.DefaultAuditLogLimit, filters.Limit)
+			return []*domain.AuditEntry{}, 0, nil
+		})
+		_, err := service.ListEntries(domain.AuditLogFilters{})
+		assert.NoError(t, err)
+
+		auditRepo.EXPECT().ListEntries(gomock.Any()).Return([]*domain.AuditEntry{{ID: 1}}, 301, nil)
+		page, err := service.ListEntries(domain.AuditLogFilters{Limit: 1000, Offset: 200})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.MaxAuditLogLimit, page.Limit)
+		assert.Equal(t, 200, page.Offset)
+		assert.Equal(t, 301, page.Total)
+	})
+
+	t.Run("rejeita período invertido", func(t *testing.T) {
+		from := time.Now()
+		to := from.Add(-time.Hour)
+
+		_, err := service.ListEntries(domain.AuditLogFilters{From: &from, To: &to})
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+}
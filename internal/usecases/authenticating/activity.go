@@ -0,0 +1,55 @@
+package authenticating
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// lastSeenTouchInterval evita gravar a última atividade do usuário a cada requisição
+const lastSeenTouchInterval = 5 * time.Minute
+
+// lastSeenTracker guarda, por instância, quando a última atividade de cada usuário foi gravada
+type lastSeenTracker struct {
+	mu      sync.Mutex
+	touched map[int]time.Time
+}
+
+func newLastSeenTracker() *lastSeenTracker {
+	return &lastSeenTracker{touched: make(map[int]time.Time)}
+}
+
+// due informa se a atividade deve ser gravada e, nesse caso, reserva o intervalo para o usuário
+func (t *lastSeenTracker) due(userID int, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.touched[userID]; ok && now.Sub(last) < lastSeenTouchInterval {
+		return false
+	}
+	t.touched[userID] = now
+	return true
+}
+
+// recordLogin grava o login bem-sucedido, que também conta como atividade. Falhas só são registradas no
+// log: o login não depende delas.
+func (s *Service) recordLogin(userID int) {
+	now := time.Now()
+	if err := s.userRepo.RecordLogin(userID, now); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("Erro ao registrar último login")
+		return
+	}
+	s.lastSeen.due(userID, now)
+}
+
+// touchLastSeen grava a última atividade do usuário autenticado, no máximo uma vez por lastSeenTouchInterval
+func (s *Service) touchLastSeen(userID int) {
+	now := time.Now()
+	if !s.lastSeen.due(userID, now) {
+		return
+	}
+	if err := s.userRepo.TouchLastSeen(userID, now); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("Erro ao registrar última atividade")
+	}
+}
@@ -0,0 +1,33 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTouchLastSeen_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewService(userRepo, nil, nil, nil, nil, nil, nil, &config.Config{SecretKey: "secret"}).(*Service)
+
+	// Várias requisições seguidas gravam a atividade uma única vez por usuário
+	userRepo.EXPECT().TouchLastSeen(1, gomock.Any()).Return(nil).Times(1)
+	userRepo.EXPECT().TouchLastSeen(2, gomock.Any()).Return(nil).Times(1)
+	service.touchLastSeen(1)
+	service.touchLastSeen(1)
+	service.touchLastSeen(2)
+
+	// Passado o intervalo, grava novamente
+	service.lastSeen.touched[1] = time.Now().Add(-lastSeenTouchInterval)
+	userRepo.EXPECT().TouchLastSeen(1, gomock.Any()).Return(nil).Times(1)
+	service.touchLastSeen(1)
+
+	assert.Len(t, service.lastSeen.touched, 2)
+}
@@ -0,0 +1,165 @@
+package authenticating
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// APIKeyPrefix identifica as chaves de API emitidas por esta aplicação
+const APIKeyPrefix = "tm_"
+
+// apiKeyDisplayLength é o tamanho do início da chave guardado para identificá-la na listagem
+const apiKeyDisplayLength = 11
+
+// apiKeyTouchInterval evita gravar o último uso a cada requisição de integrações muito ativas
+const apiKeyTouchInterval = time.Minute
+
+// defaultAPIKeyMaxTTL é usado quando a validade máxima das chaves não é configurada
+const defaultAPIKeyMaxTTL = 365 * 24 * time.Hour
+
+var (
+	ErrAPIKeyNotFound    = errors.New("chave de API não encontrada")
+	ErrAPIKeyUnavailable = errors.New("chaves de API não configuradas")
+)
+
+// CreateAPIKey emite uma chave de API para o usuário. A chave só é retornada aqui; depois fica
+// armazenado apenas o hash. Os escopos devem estar em domain.APIKeyScopes.
+func (s *Service) CreateAPIKey(userID int, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error) {
+	if s.apiKeyRepo == nil {
+		return nil, NewAuthError(ErrAPIKeyUnavailable, errorcodes.ErrInternalServer, "")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(req.Scopes) == 0 {
+		return nil, NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "name e scopes são obrigatórios")
+	}
+	if len(name) > 100 {
+		return nil, NewAuthError(ErrInvalidRequest, errorcodes.ErrInvalidRequest, "name deve ter no máximo 100 caracteres")
+	}
+
+	scopes := make([]domain.Permission, 0, len(req.Scopes))
+	seen := make(domain.PermissionSet, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !domain.IsValidAPIKeyScope(scope) {
+			return nil, NewAuthError(ErrInvalidRequest, errorcodes.ErrInvalidRequest, "escopo inválido para chave de API: "+string(scope))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	now := time.Now()
+	maxExpiresAt := now.Add(s.apiKeyMaxTTL())
+	expiresAt := maxExpiresAt
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, NewAuthError(ErrInvalidRequest, errorcodes.ErrInvalidRequest, "expires_at deve ser uma data futura")
+		}
+		if req.ExpiresAt.After(maxExpiresAt) {
+			return nil, NewAuthError(ErrInvalidRequest, errorcodes.ErrInvalidRequest, "expires_at ultrapassa a validade máxima das chaves de API")
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar chave de API")
+	}
+	plainKey := APIKeyPrefix + token
+
+	key := &domain.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plainKey[:apiKeyDisplayLength],
+		KeyHash:   hashOpaqueToken(plainKey),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.apiKeyRepo.CreateAPIKey(key); err != nil {
+		return nil, NewUserAuthError(err, errorcodes.ErrDatabaseOperation, userID, "Erro ao salvar chave de API")
+	}
+
+	return &domain.CreateAPIKeyResponse{APIKey: key, Key: plainKey}, nil
+}
+
+func (s *Service) ListAPIKeys(userID int) ([]*domain.APIKey, error) {
+	if s.apiKeyRepo == nil {
+		return []*domain.APIKey{}, nil
+	}
+	return s.apiKeyRepo.ListUserAPIKeys(userID)
+}
+
+// RevokeAPIKey revoga a chave do usuário. Chaves de outros usuários ou já revogadas retornam ErrAPIKeyNotFound.
+func (s *Service) RevokeAPIKey(userID, keyID int) error {
+	if s.apiKeyRepo == nil {
+		return ErrAPIKeyNotFound
+	}
+
+	revoked, err := s.apiKeyRepo.RevokeAPIKey(userID, keyID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey valida a chave e retorna as claims do usuário dono, com os escopos da chave. Falhas
+// ao registrar o último uso não impedem a requisição.
+func (s *Service) AuthenticateAPIKey(plainKey string) (*domain.Claims, error) {
+	if s.apiKeyRepo == nil || !strings.HasPrefix(plainKey, APIKeyPrefix) {
+		return nil, NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "chave de API inválida")
+	}
+
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(hashOpaqueToken(plainKey))
+	if err != nil {
+		return nil, NewAuthError(ErrDatabaseOperation, errorcodes.ErrDatabaseOperation, err.Error())
+	}
+	if key == nil || key.RevokedAt != nil {
+		return nil, NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "chave de API inválida")
+	}
+
+	now := time.Now()
+	if !key.Usable(now) {
+		return nil, NewUserAuthError(ErrExpiredToken, errorcodes.ErrExpiredToken, key.UserID, "chave de API expirada")
+	}
+
+	user, err := s.userRepo.GetUserByID(key.UserID)
+	if err != nil {
+		return nil, NewAuthError(ErrDatabaseOperation, errorcodes.ErrDatabaseOperation, err.Error())
+	}
+	if user == nil || !user.Active || user.Deleted {
+		return nil, NewUserAuthError(ErrUserDisabled, errorcodes.ErrUserDisabled, key.UserID, "usuário da chave de API desativado")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchAPIKey(key.ID, now); err != nil {
+			logrus.WithError(err).WithField("api_key_id", key.ID).Warn("Erro ao registrar uso da chave de API")
+		}
+	}
+
+	s.touchLastSeen(user.ID)
+
+	return &domain.Claims{
+		UserID:        user.ID,
+		UserRoleID:    user.RoleID,
+		ClaimsVersion: domain.CurrentClaimsVersion,
+		APIKeyID:      key.ID,
+		APIKeyScopes:  key.Scopes,
+	}, nil
+}
+
+func (s *Service) apiKeyMaxTTL() time.Duration {
+	if s.cfg.Auth.APIKeyMaxTTL > 0 {
+		return s.cfg.Auth.APIKeyMaxTTL
+	}
+	return defaultAPIKeyMaxTTL
+}
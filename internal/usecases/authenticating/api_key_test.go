@@ -0,0 +1,122 @@
+package authenticating
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret"}
+	cfg.Auth.APIKeyMaxTTL = 24 * time.Hour
+	service := NewService(nil, nil, nil, nil, nil, apiKeyRepo, nil, cfg)
+
+	t.Run("armazena apenas o hash e aplica a validade máxima", func(t *testing.T) {
+		var stored *domain.APIKey
+		apiKeyRepo.EXPECT().CreateAPIKey(gomock.Any()).DoAndReturn(func(key *domain.APIKey) error {
+			stored = key
+			return nil
+		})
+
+		created, err := service.CreateAPIKey(7, &domain.CreateAPIKeyRequest{
+			Name:   "Power BI",
+			Scopes: []domain.Permission{domain.PermissionInsightsRead, domain.PermissionInsightsRead},
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, APIKeyPrefix))
+		assert.Equal(t, hashOpaqueToken(created.Key), stored.KeyHash)
+		assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+		assert.Equal(t, []domain.Permission{domain.PermissionInsightsRead}, stored.Scopes)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("rejeita escopo de escrita", func(t *testing.T) {
+		_, err := service.CreateAPIKey(7, &domain.CreateAPIKeyRequest{
+			Name:   "Script",
+			Scopes: []domain.Permission{domain.PermissionAccountsWrite},
+		})
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+
+	t.Run("rejeita validade acima do máximo", func(t *testing.T) {
+		expiresAt := time.Now().Add(48 * time.Hour)
+		_, err := service.CreateAPIKey(7, &domain.CreateAPIKeyRequest{
+			Name:      "Script",
+			Scopes:    []domain.Permission{domain.PermissionInsightsRead},
+			ExpiresAt: &expiresAt,
+		})
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewService(userRepo, nil, nil, nil, nil, apiKeyRepo, nil, &config.Config{SecretKey: "secret"})
+	userRepo.EXPECT().TouchLastSeen(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	plainKey := APIKeyPrefix + "chave-de-teste"
+	scopes := []domain.Permission{domain.PermissionInsightsRead}
+
+	t.Run("retorna as claims do dono com os escopos da chave", func(t *testing.T) {
+		apiKeyRepo.EXPECT().GetAPIKeyByHash(hashOpaqueToken(plainKey)).Return(&domain.APIKey{
+			ID: 3, UserID: 7, Scopes: scopes, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, RoleID: 2, Active: true}, nil)
+		apiKeyRepo.EXPECT().TouchAPIKey(3, gomock.Any()).Return(nil)
+
+		claims, err := service.AuthenticateAPIKey(plainKey)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, claims.UserID)
+		assert.Equal(t, 2, claims.UserRoleID)
+		assert.Equal(t, 3, claims.APIKeyID)
+		assert.Equal(t, scopes, claims.APIKeyScopes)
+	})
+
+	t.Run("rejeita chave expirada", func(t *testing.T) {
+		apiKeyRepo.EXPECT().GetAPIKeyByHash(hashOpaqueToken(plainKey)).Return(&domain.APIKey{
+			ID: 3, UserID: 7, Scopes: scopes, ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil)
+
+		_, err := service.AuthenticateAPIKey(plainKey)
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("rejeita chave revogada", func(t *testing.T) {
+		revokedAt := time.Now()
+		apiKeyRepo.EXPECT().GetAPIKeyByHash(hashOpaqueToken(plainKey)).Return(&domain.APIKey{
+			ID: 3, UserID: 7, Scopes: scopes, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+		}, nil)
+
+		_, err := service.AuthenticateAPIKey(plainKey)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rejeita chave de usuário desativado", func(t *testing.T) {
+		apiKeyRepo.EXPECT().GetAPIKeyByHash(hashOpaqueToken(plainKey)).Return(&domain.APIKey{
+			ID: 3, UserID: 7, Scopes: scopes, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, RoleID: 2, Active: false}, nil)
+
+		_, err := service.AuthenticateAPIKey(plainKey)
+		assert.ErrorIs(t, err, ErrUserDisabled)
+	})
+
+	t.Run("rejeita chave sem o prefixo sem consultar o banco", func(t *testing.T) {
+		_, err := service.AuthenticateAPIKey("chave-de-teste")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
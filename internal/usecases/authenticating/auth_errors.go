@@ -15,6 +15,7 @@ var (
 	ErrPasswordExpired       = errors.New("senha expirada")
 	ErrInvalidToken          = errors.New("token inválido")
 	ErrExpiredToken          = errors.New("token expirado")
+	ErrRevokedToken          = errors.New("token revogado")
 	ErrInsufficientPrivilege = errors.New("privilégios insuficientes")
 	ErrUserAlreadyExists     = errors.New("usuário já existe")
 
@@ -27,6 +28,7 @@ var (
 	ErrWeakPassword      = errors.New("senha fraca")
 	ErrPasswordMismatch  = errors.New("senhas não conferem")
 	ErrSamePassword      = errors.New("nova senha deve ser diferente da atual")
+	ErrPasswordReused    = errors.New("a nova senha não pode ser igual às senhas utilizadas recentemente")
 	ErrNoAdminPrivileges = errors.New("apenas administradores podem realizar esta ação")
 
 	// Erros de banco de dados
@@ -67,6 +69,7 @@ func IsAuthorizationError(err error) bool {
 	return errors.Is(err, ErrInsufficientPrivilege) ||
 		errors.Is(err, ErrInvalidToken) ||
 		errors.Is(err, ErrExpiredToken) ||
+		errors.Is(err, ErrRevokedToken) ||
 		errors.Is(err, ErrNoAdminPrivileges)
 }
 
@@ -0,0 +1,50 @@
+package authenticating
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// deactivateUser remove o acesso do usuário que acabou de ser desativado ou excluído: encerra as sessões,
+// revoga as chaves de API e descarta os links de redefinição de senha e de confirmação de e-mail.
+// Na exclusão, os vínculos com contas também são removidos; na desativação eles são mantidos, para que o
+// acesso volte com a reativação. Falhas são registradas sem interromper as demais etapas, já que a
+// alteração do usuário já foi gravada.
+func (s *Service) deactivateUser(userID int, deleted bool, actorID int) {
+	logger := logrus.WithField("user_id", userID)
+	details := map[string]any{"deleted": deleted}
+
+	s.revokeUserSessions(userID)
+
+	if s.apiKeyRepo != nil {
+		revoked, err := s.apiKeyRepo.RevokeUserAPIKeys(userID)
+		if err != nil {
+			logger.WithError(err).Error("Erro ao revogar chaves de API do usuário")
+		}
+		details["api_keys_revoked"] = revoked
+	}
+
+	if s.passwordResetRepo != nil {
+		if err := s.passwordResetRepo.InvalidateUserPasswordResetTokens(userID); err != nil {
+			logger.WithError(err).Error("Erro ao invalidar links de redefinição de senha do usuário")
+		}
+	}
+
+	if s.emailVerificationRepo != nil {
+		if err := s.emailVerificationRepo.InvalidateUserEmailVerificationTokens(userID); err != nil {
+			logger.WithError(err).Error("Erro ao invalidar links de verificação de e-mail do usuário")
+		}
+	}
+
+	if deleted {
+		accountIDs, err := s.userRepo.UnlinkAllUserAccounts(userID)
+		if err != nil {
+			logger.WithError(err).Error("Erro ao desvincular contas do usuário")
+		}
+		details["unlinked_accounts"] = accountIDs
+	}
+
+	s.recordAudit(actorID, domain.AuditActionUserDeactivate, domain.AuditEntityUser, strconv.Itoa(userID), details)
+}
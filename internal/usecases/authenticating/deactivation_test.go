@@ -0,0 +1,90 @@
+package authenticating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type auditCall struct {
+	action  string
+	details any
+}
+
+type auditRecorderStub struct {
+	calls []auditCall
+}
+
+func (r *auditRecorderStub) Record(_ int, action, _, _ string, details any) {
+	r.calls = append(r.calls, auditCall{action: action, details: details})
+}
+
+func TestUpdateUser_DeletionCleansUpAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	revokedRepo := mocks.NewMockRevokedTokenRepository(ctrl)
+	resetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	recorder := &auditRecorderStub{}
+	service := NewService(userRepo, nil, refreshRepo, revokedRepo, resetRepo, apiKeyRepo, nil,
+		&config.Config{SecretKey: "secret"}, WithAuditRecorder(recorder))
+
+	userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, Active: true}, nil)
+	userRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+	refreshRepo.EXPECT().RevokeUserRefreshTokens(7).Return(nil)
+	revokedRepo.EXPECT().RevokeUserTokens(7, gomock.Any()).Return(nil)
+	apiKeyRepo.EXPECT().RevokeUserAPIKeys(7).Return(2, nil)
+	resetRepo.EXPECT().InvalidateUserPasswordResetTokens(7).Return(nil)
+	userRepo.EXPECT().UnlinkAllUserAccounts(7).Return([]string{"ABC123", "DEF456"}, nil)
+
+	deleted := true
+	assert.NoError(t, service.UpdateUser(&domain.UpdateUserRequest{ID: 7, Deleted: &deleted}, 1))
+
+	assert.Len(t, recorder.calls, 2)
+	assert.Equal(t, domain.AuditActionUserDeactivate, recorder.calls[1].action)
+	assert.Equal(t, map[string]any{
+		"deleted":           true,
+		"api_keys_revoked":  2,
+		"unlinked_accounts": []string{"ABC123", "DEF456"},
+	}, recorder.calls[1].details)
+}
+
+func TestUpdateUser_DeactivationKeepsAccountLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewService(userRepo, nil, refreshRepo, nil, nil, apiKeyRepo, nil, &config.Config{SecretKey: "secret"})
+
+	userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, Active: true}, nil)
+	userRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+	refreshRepo.EXPECT().RevokeUserRefreshTokens(7).Return(nil)
+	apiKeyRepo.EXPECT().RevokeUserAPIKeys(7).Return(0, nil)
+
+	active := false
+	assert.NoError(t, service.UpdateUser(&domain.UpdateUserRequest{ID: 7, Active: &active}, 1))
+}
+
+func TestUpdateUser_AlreadyInactiveSkipsCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	service := NewService(userRepo, nil, refreshRepo, nil, nil, nil, nil, &config.Config{SecretKey: "secret"})
+
+	userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, Active: false}, nil)
+	userRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+
+	name := "Ana"
+	assert.NoError(t, service.UpdateUser(&domain.UpdateUserRequest{ID: 7, Name: &name}, 1))
+}
@@ -0,0 +1,156 @@
+package authenticating
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	// ErrEmailVerificationUnavailable indica que a verificação de e-mail não está configurada
+	ErrEmailVerificationUnavailable = errors.New("verificação de e-mail não configurada")
+	ErrEmailNotVerified             = errors.New("e-mail não verificado")
+)
+
+// defaultEmailVerificationTTL é usado quando a validade do link não é configurada
+const defaultEmailVerificationTTL = 48 * time.Hour
+
+var emailVerificationTemplate = template.Must(template.New("email_verification").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<body style="font-family: Arial, sans-serif; color: #222;">
+<h2 style="margin-bottom: 4px;">Confirme o seu e-mail</h2>
+<p>Olá, {{.Name}}.</p>
+<p>Recebemos o seu cadastro. Use o link abaixo para confirmar o seu endereço de e-mail:</p>
+<p><a href="{{.Link}}">Confirmar e-mail</a></p>
+<p style="color: #666;">O link expira em {{.ExpiresIn}} e só pode ser usado uma vez. Se você não fez o cadastro, ignore este e-mail.</p>
+</body>
+</html>`))
+
+// WithEmailVerification habilita a confirmação de e-mail no cadastro. Com AUTH_EMAIL_VERIFICATION_URL e o
+// envio de e-mails configurados, o usuário cadastrado recebe um link de confirmação e só faz login depois
+// de confirmar o endereço.
+func WithEmailVerification(repo repository.EmailVerificationRepository) Option {
+	return func(s *Service) {
+		s.emailVerificationRepo = repo
+	}
+}
+
+// emailVerificationEnabled indica se a confirmação de e-mail está configurada
+func (s *Service) emailVerificationEnabled() bool {
+	return s.emailVerificationRepo != nil && s.mailer != nil && s.cfg.Auth.EmailVerificationURL != ""
+}
+
+// sendEmailVerification descarta os links anteriores ainda não usados e envia um novo link de confirmação.
+// Falhas no envio do e-mail são apenas registradas: o usuário pode pedir um novo link.
+func (s *Service) sendEmailVerification(user *domain.User) error {
+	if err := s.emailVerificationRepo.InvalidateUserEmailVerificationTokens(user.ID); err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao invalidar links de verificação de e-mail")
+	}
+
+	value, err := generateOpaqueToken()
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrInternalServer, user.ID, "Erro ao gerar link de verificação de e-mail")
+	}
+
+	ttl := s.emailVerificationTTL()
+	if err := s.emailVerificationRepo.CreateEmailVerificationToken(&domain.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashOpaqueToken(value),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao salvar link de verificação de e-mail")
+	}
+
+	body, err := s.renderEmailVerification(user, value, ttl)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrInternalServer, user.ID, "Erro ao gerar e-mail de verificação")
+	}
+
+	if err := s.mailer.SendEmail([]string{user.Email}, "Confirme o seu e-mail", body); err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).Error("Erro ao enviar e-mail de verificação")
+	}
+
+	return nil
+}
+
+// ResendEmailVerification envia um novo link de confirmação. E-mails desconhecidos ou já verificados não
+// são informados ao solicitante, para não revelar quais e-mails estão cadastrados.
+func (s *Service) ResendEmailVerification(email string) error {
+	if !s.emailVerificationEnabled() {
+		return NewAuthError(ErrEmailVerificationUnavailable, errorcodes.ErrInternalServer, "")
+	}
+	if email == "" {
+		return NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "email é obrigatório")
+	}
+
+	user, err := s.userRepo.GetUserByEmail(handleEmail(email))
+	if err != nil {
+		return NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+	}
+	if user == nil || user.Deleted || user.EmailVerified {
+		return nil
+	}
+
+	return s.sendEmailVerification(user)
+}
+
+// VerifyEmail confirma o e-mail do usuário a partir do token do link enviado no cadastro
+func (s *Service) VerifyEmail(token string) error {
+	if token == "" {
+		return NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "token é obrigatório")
+	}
+	if s.emailVerificationRepo == nil {
+		return NewAuthError(ErrEmailVerificationUnavailable, errorcodes.ErrInternalServer, "")
+	}
+
+	stored, err := s.emailVerificationRepo.GetEmailVerificationTokenByHash(hashOpaqueToken(token))
+	if err != nil {
+		return NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar link de verificação de e-mail")
+	}
+	if stored == nil || !stored.Usable(time.Now()) {
+		return NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "link de verificação de e-mail inválido ou expirado")
+	}
+
+	confirmed, err := s.emailVerificationRepo.ConfirmEmailVerification(stored.ID, stored.UserID)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, stored.UserID, "Erro ao confirmar e-mail")
+	}
+	if !confirmed {
+		return NewUserAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, stored.UserID, "link de verificação de e-mail inválido ou expirado")
+	}
+
+	return nil
+}
+
+func (s *Service) renderEmailVerification(user *domain.User, token string, ttl time.Duration) (string, error) {
+	link, err := url.Parse(s.cfg.Auth.EmailVerificationURL)
+	if err != nil {
+		return "", fmt.Errorf("AUTH_EMAIL_VERIFICATION_URL inválida: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	var body bytes.Buffer
+	err = emailVerificationTemplate.Execute(&body, map[string]string{
+		"Name":      user.Name,
+		"Link":      link.String(),
+		"ExpiresIn": formatResetTTL(ttl),
+	})
+	return body.String(), err
+}
+
+func (s *Service) emailVerificationTTL() time.Duration {
+	if s.cfg.Auth.EmailVerificationTTL > 0 {
+		return s.cfg.Auth.EmailVerificationTTL
+	}
+	return defaultEmailVerificationTTL
+}
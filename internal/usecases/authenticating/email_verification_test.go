@@ -0,0 +1,120 @@
+package authenticating
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func emailVerificationConfig() *config.Config {
+	return &config.Config{SecretKey: "secret", Auth: config.Auth{
+		EmailVerificationURL: "https://app.example.com/verify-email",
+	}}
+}
+
+func TestCreateUser_SendsEmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	verificationRepo := mocks.NewMockEmailVerificationRepository(ctrl)
+	mailer := &fakeMailer{}
+	service := NewService(userRepo, nil, nil, nil, nil, nil, mailer, emailVerificationConfig(), WithEmailVerification(verificationRepo))
+
+	userRepo.EXPECT().GetUserByEmail("ana@example.com").Return(nil, nil)
+	userRepo.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user *domain.User) (*domain.User, error) {
+		user.ID = 7
+		return user, nil
+	})
+	verificationRepo.EXPECT().InvalidateUserEmailVerificationTokens(7).Return(nil)
+
+	var stored *domain.EmailVerificationToken
+	verificationRepo.EXPECT().CreateEmailVerificationToken(gomock.Any()).DoAndReturn(func(token *domain.EmailVerificationToken) error {
+		stored = token
+		return nil
+	})
+
+	_, err := service.CreateUser(&domain.User{Name: "Ana", Lastname: "Silva", Email: "ana@example.com", PasswordHash: "Senha@123"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ana@example.com"}, mailer.to)
+	assert.Contains(t, mailer.body, "https://app.example.com/verify-email?token=")
+	assert.WithinDuration(t, time.Now().Add(defaultEmailVerificationTTL), stored.ExpiresAt, time.Second)
+
+	// O e-mail traz o token; só o hash é armazenado
+	token := mailer.body[strings.Index(mailer.body, "token=")+len("token="):]
+	token = token[:strings.Index(token, `"`)]
+	assert.Equal(t, hashOpaqueToken(token), stored.TokenHash)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	verificationRepo := mocks.NewMockEmailVerificationRepository(ctrl)
+	service := NewService(nil, nil, nil, nil, nil, nil, &fakeMailer{}, emailVerificationConfig(), WithEmailVerification(verificationRepo))
+
+	t.Run("confirma o e-mail", func(t *testing.T) {
+		verificationRepo.EXPECT().GetEmailVerificationTokenByHash(hashOpaqueToken("valido")).Return(&domain.EmailVerificationToken{
+			ID: 3, UserID: 7, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		verificationRepo.EXPECT().ConfirmEmailVerification(3, 7).Return(true, nil)
+
+		assert.NoError(t, service.VerifyEmail("valido"))
+	})
+
+	t.Run("link expirado", func(t *testing.T) {
+		verificationRepo.EXPECT().GetEmailVerificationTokenByHash(hashOpaqueToken("expirado")).Return(&domain.EmailVerificationToken{
+			ID: 4, UserID: 7, ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		assert.ErrorIs(t, service.VerifyEmail("expirado"), ErrInvalidToken)
+	})
+
+	t.Run("link já usado em outra requisição", func(t *testing.T) {
+		verificationRepo.EXPECT().GetEmailVerificationTokenByHash(hashOpaqueToken("concorrente")).Return(&domain.EmailVerificationToken{
+			ID: 5, UserID: 7, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		verificationRepo.EXPECT().ConfirmEmailVerification(5, 7).Return(false, nil)
+
+		assert.ErrorIs(t, service.VerifyEmail("concorrente"), ErrInvalidToken)
+	})
+}
+
+func TestLoginUser_RequiresVerifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("Senha@123"), bcrypt.MinCost)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	verificationRepo := mocks.NewMockEmailVerificationRepository(ctrl)
+	service := NewService(userRepo, nil, nil, nil, nil, nil, &fakeMailer{}, emailVerificationConfig(), WithEmailVerification(verificationRepo))
+
+	userRepo.EXPECT().GetUserByEmail("ana@example.com").Return(&domain.User{
+		ID: 7, Email: "ana@example.com", PasswordHash: string(hash), Active: true, RoleID: 3,
+	}, nil)
+
+	_, err := service.LoginUser("ana@example.com", "Senha@123", domain.SessionClient{})
+	assert.ErrorIs(t, err, ErrEmailNotVerified)
+}
+
+func TestResendEmailVerification_IgnoresVerifiedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	verificationRepo := mocks.NewMockEmailVerificationRepository(ctrl)
+	mailer := &fakeMailer{}
+	service := NewService(userRepo, nil, nil, nil, nil, nil, mailer, emailVerificationConfig(), WithEmailVerification(verificationRepo))
+
+	userRepo.EXPECT().GetUserByEmail("ana@example.com").Return(&domain.User{ID: 7, Email: "ana@example.com", Active: true, EmailVerified: true}, nil)
+
+	assert.NoError(t, service.ResendEmailVerification("ana@example.com"))
+	assert.Nil(t, mailer.to)
+}
@@ -0,0 +1,126 @@
+package authenticating
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+// SigningKey representa uma chave HMAC identificada por kid
+type SigningKey struct {
+	ID     string
+	Secret []byte
+	// ValidUntil indica até quando a chave é aceita na validação. Zero = sem expiração.
+	ValidUntil time.Time
+}
+
+// KeyRing mantém a chave de assinatura atual e as chaves anteriores aceitas durante a rotação
+type KeyRing struct {
+	current  SigningKey
+	previous []SigningKey
+	now      func() time.Time
+}
+
+// NewKeyRing monta o conjunto de chaves a partir da configuração.
+// A chave atual vem de AUTH_SECRET (ou SECRET_KEY, para compatibilidade) e a anterior de AUTH_PREVIOUS_SECRET.
+func NewKeyRing(cfg *config.Config) *KeyRing {
+	secret := cfg.Auth.Secret
+	if secret == "" {
+		secret = cfg.SecretKey
+	}
+
+	keyID := cfg.Auth.SigningKeyID
+	if keyID == "" {
+		keyID = "default"
+	}
+
+	ring := &KeyRing{
+		current: SigningKey{ID: keyID, Secret: []byte(secret)},
+		now:     time.Now,
+	}
+
+	if cfg.Auth.PreviousSecret != "" {
+		previousID := cfg.Auth.PreviousSigningKeyID
+		if previousID == "" || previousID == keyID {
+			logrus.Warn("AUTH_PREVIOUS_SIGNING_KEY_ID ausente ou igual à chave atual, chave anterior ignorada")
+			return ring
+		}
+
+		// A configuração já exige AUTH_KEY_ROTATED_AT; sem ele a chave anterior não é aceita
+		rotatedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(cfg.Auth.KeyRotatedAt))
+		if err != nil {
+			logrus.WithError(err).Error("AUTH_KEY_ROTATED_AT ausente ou inválido, chave anterior ignorada")
+			return ring
+		}
+
+		validUntil := rotatedAt.Add(cfg.Auth.PreviousKeyGracePeriod)
+		ring.previous = append(ring.previous, SigningKey{
+			ID:         previousID,
+			Secret:     []byte(cfg.Auth.PreviousSecret),
+			ValidUntil: validUntil,
+		})
+
+		logrus.WithFields(logrus.Fields{
+			"current_kid":  keyID,
+			"previous_kid": previousID,
+			"valid_until":  validUntil.Format(time.RFC3339),
+		}).Info("Rotação de chave JWT ativa")
+	}
+
+	return ring
+}
+
+// CurrentKeyID retorna o kid da chave usada para assinar novos tokens
+func (k *KeyRing) CurrentKeyID() string {
+	return k.current.ID
+}
+
+// Sign assina as claims com a chave atual, incluindo o kid no cabeçalho do token
+func (k *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = k.current.ID
+	return token.SignedString(k.current.Secret)
+}
+
+// Keyfunc resolve a chave de validação a partir do kid do token.
+// Tokens sem kid (emitidos antes da rotação por chave) são validados com todas as chaves aceitas.
+func (k *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	accepted := k.acceptedKeys()
+
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		keySet := jwt.VerificationKeySet{}
+		for _, key := range accepted {
+			keySet.Keys = append(keySet.Keys, key.Secret)
+		}
+		return keySet, nil
+	}
+
+	for _, key := range accepted {
+		if key.ID == kid {
+			return key.Secret, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: chave de assinatura desconhecida ou expirada (kid=%s)", ErrInvalidToken, kid)
+}
+
+// acceptedKeys retorna a chave atual e as chaves anteriores ainda dentro da janela de rotação
+func (k *KeyRing) acceptedKeys() []SigningKey {
+	now := k.now()
+	keys := []SigningKey{k.current}
+	for _, key := range k.previous {
+		if key.ValidUntil.IsZero() || now.Before(key.ValidUntil) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
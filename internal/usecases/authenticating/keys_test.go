@@ -0,0 +1,77 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldRing := NewKeyRing(&config.Config{Auth: config.Auth{Secret: "old-secret", SigningKeyID: "k1"}})
+	oldToken, err := oldRing.Sign(jwt.RegisteredClaims{Subject: "1"})
+	assert.NoError(t, err)
+
+	rotatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ring := NewKeyRing(&config.Config{Auth: config.Auth{
+		Secret:                 "new-secret",
+		SigningKeyID:           "k2",
+		PreviousSecret:         "old-secret",
+		PreviousSigningKeyID:   "k1",
+		PreviousKeyGracePeriod: time.Hour,
+		KeyRotatedAt:           rotatedAt.Format(time.RFC3339),
+	}})
+
+	parse := func(tokenString string) error {
+		_, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, ring.Keyfunc)
+		return err
+	}
+
+	t.Run("aceita token da chave anterior dentro da janela", func(t *testing.T) {
+		ring.now = func() time.Time { return rotatedAt.Add(30 * time.Minute) }
+		assert.NoError(t, parse(oldToken))
+	})
+
+	t.Run("rejeita token da chave anterior após a janela", func(t *testing.T) {
+		ring.now = func() time.Time { return rotatedAt.Add(2 * time.Hour) }
+		assert.Error(t, parse(oldToken))
+	})
+
+	t.Run("novos tokens usam a chave atual", func(t *testing.T) {
+		ring.now = func() time.Time { return rotatedAt.Add(2 * time.Hour) }
+		newToken, err := ring.Sign(jwt.RegisteredClaims{Subject: "1"})
+		assert.NoError(t, err)
+		assert.NoError(t, parse(newToken))
+
+		token, _, err := jwt.NewParser().ParseUnverified(newToken, &jwt.RegisteredClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, "k2", token.Header["kid"])
+	})
+
+	t.Run("aceita token legado sem kid", func(t *testing.T) {
+		ring.now = func() time.Time { return rotatedAt.Add(2 * time.Hour) }
+		legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "1"}).SignedString([]byte("new-secret"))
+		assert.NoError(t, err)
+		assert.NoError(t, parse(legacy))
+	})
+}
+
+func TestKeyRing_PreviousKeyWithoutRotatedAt(t *testing.T) {
+	oldRing := NewKeyRing(&config.Config{Auth: config.Auth{Secret: "old-secret", SigningKeyID: "k1"}})
+	oldToken, err := oldRing.Sign(jwt.RegisteredClaims{Subject: "1"})
+	assert.NoError(t, err)
+
+	// Sem o momento da rotação, a janela não recomeça na inicialização: a chave anterior é ignorada
+	ring := NewKeyRing(&config.Config{Auth: config.Auth{
+		Secret:                 "new-secret",
+		SigningKeyID:           "k2",
+		PreviousSecret:         "old-secret",
+		PreviousSigningKeyID:   "k1",
+		PreviousKeyGracePeriod: time.Hour,
+	}})
+
+	_, err = jwt.ParseWithClaims(oldToken, &jwt.RegisteredClaims{}, ring.Keyfunc)
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package authenticating
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrPasswordResetUnavailable indica que a recuperação de senha por e-mail não está configurada
+var ErrPasswordResetUnavailable = errors.New("recuperação de senha por e-mail não configurada")
+
+// defaultPasswordResetTTL é usado quando a validade do link não é configurada
+const defaultPasswordResetTTL = time.Hour
+
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<body style="font-family: Arial, sans-serif; color: #222;">
+<h2 style="margin-bottom: 4px;">Redefinição de senha</h2>
+<p>Olá, {{.Name}}.</p>
+<p>Recebemos uma solicitação para redefinir a sua senha. Use o link abaixo para escolher uma nova senha:</p>
+<p><a href="{{.Link}}">Redefinir senha</a></p>
+<p style="color: #666;">O link expira em {{.ExpiresIn}} e só pode ser usado uma vez. Se você não fez a solicitação, ignore este e-mail.</p>
+</body>
+</html>`))
+
+// RequestPasswordReset envia ao usuário um link de uso único para redefinir a senha. Os links anteriores
+// ainda não usados são descartados. E-mails desconhecidos, usuários desativados e falhas no envio não
+// são informados ao solicitante, para não revelar quais e-mails estão cadastrados.
+func (s *Service) RequestPasswordReset(email string) error {
+	if s.passwordResetRepo == nil || s.mailer == nil || s.cfg.Auth.PasswordResetURL == "" {
+		return NewAuthError(ErrPasswordResetUnavailable, errorcodes.ErrInternalServer, "")
+	}
+	if email == "" {
+		return NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "email é obrigatório")
+	}
+
+	user, err := s.userRepo.GetUserByEmail(handleEmail(email))
+	if err != nil {
+		return NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+	}
+	if user == nil || !user.Active || user.Deleted {
+		return nil
+	}
+
+	if err := s.passwordResetRepo.InvalidateUserPasswordResetTokens(user.ID); err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao invalidar links de redefinição de senha")
+	}
+
+	value, err := generateOpaqueToken()
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrInternalServer, user.ID, "Erro ao gerar link de redefinição de senha")
+	}
+
+	ttl := s.passwordResetTTL()
+	if err := s.passwordResetRepo.CreatePasswordResetToken(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashOpaqueToken(value),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao salvar link de redefinição de senha")
+	}
+
+	body, err := s.renderPasswordResetEmail(user, value, ttl)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrInternalServer, user.ID, "Erro ao gerar e-mail de redefinição de senha")
+	}
+
+	if err := s.mailer.SendEmail([]string{user.Email}, "Redefinição de senha", body); err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).Error("Erro ao enviar e-mail de redefinição de senha")
+	}
+
+	return nil
+}
+
+// ResetPassword define a nova senha a partir do token do link enviado por e-mail e encerra as sessões
+// abertas. O token só é consumido depois que a nova senha passa pelas regras de força e de histórico.
+func (s *Service) ResetPassword(token, newPassword string) error {
+	if token == "" || newPassword == "" {
+		return NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "token e new_password são obrigatórios")
+	}
+	if s.passwordResetRepo == nil {
+		return NewAuthError(ErrPasswordResetUnavailable, errorcodes.ErrInternalServer, "")
+	}
+
+	if err := s.ValidatePasswordStrength(newPassword); err != nil {
+		return NewAuthError(ErrWeakPassword, errorcodes.ErrInvalidFormat, err.Error())
+	}
+
+	stored, err := s.passwordResetRepo.GetPasswordResetTokenByHash(hashOpaqueToken(token))
+	if err != nil {
+		return NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar link de redefinição de senha")
+	}
+	if stored == nil || !stored.Usable(time.Now()) {
+		return NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "link de redefinição de senha inválido ou expirado")
+	}
+
+	user, err := s.userRepo.GetUserByID(stored.UserID)
+	if err != nil {
+		return NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+	}
+	if user == nil || user.Deleted {
+		return NewAuthError(ErrUserNotFound, errorcodes.ErrUserNotFound, "Usuário não encontrado")
+	}
+	if !user.Active {
+		return NewUserAuthError(ErrUserDisabled, errorcodes.ErrUserDisabled, user.ID, "Conta desativada")
+	}
+
+	reused, err := s.isPasswordReused(user, newPassword)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao consultar histórico de senhas")
+	}
+	if reused {
+		return NewUserAuthError(ErrPasswordReused, errorcodes.ErrInvalidFormat, user.ID, "")
+	}
+
+	used, err := s.passwordResetRepo.MarkPasswordResetTokenUsed(stored.ID)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao consumir link de redefinição de senha")
+	}
+	if !used {
+		return NewUserAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, user.ID, "link de redefinição de senha inválido ou expirado")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return NewUserAuthError(err, errorcodes.ErrInternalServer, user.ID, "Erro ao gerar hash da senha")
+	}
+
+	if err := s.userRepo.UpdatePassword(user.ID, string(hashedPassword), false, s.cfg.PasswordPolicy.HistorySize); err != nil {
+		return NewUserAuthError(err, errorcodes.ErrDatabaseOperation, user.ID, "Erro ao atualizar senha")
+	}
+
+	s.revokeUserSessions(user.ID)
+	return nil
+}
+
+func (s *Service) renderPasswordResetEmail(user *domain.User, token string, ttl time.Duration) (string, error) {
+	link, err := url.Parse(s.cfg.Auth.PasswordResetURL)
+	if err != nil {
+		return "", fmt.Errorf("AUTH_PASSWORD_RESET_URL inválida: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	var body bytes.Buffer
+	err = passwordResetTemplate.Execute(&body, map[string]string{
+		"Name":      user.Name,
+		"Link":      link.String(),
+		"ExpiresIn": formatResetTTL(ttl),
+	})
+	return body.String(), err
+}
+
+func (s *Service) passwordResetTTL() time.Duration {
+	if s.cfg.Auth.PasswordResetTTL > 0 {
+		return s.cfg.Auth.PasswordResetTTL
+	}
+	return defaultPasswordResetTTL
+}
+
+// formatResetTTL descreve a validade do link no e-mail (ex: "1 hora", "30 minutos")
+func formatResetTTL(ttl time.Duration) string {
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		if hours := int(ttl / time.Hour); hours > 1 {
+			return fmt.Sprintf("%d horas", hours)
+		}
+		return "1 hora"
+	}
+	minutes := int(ttl.Round(time.Minute) / time.Minute)
+	if minutes <= 1 {
+		return "1 minuto"
+	}
+	return fmt.Sprintf("%d minutos", minutes)
+}
@@ -0,0 +1,123 @@
+package authenticating
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeMailer struct {
+	to   []string
+	body string
+}
+
+func (m *fakeMailer) SendEmail(to []string, subject, htmlBody string) error {
+	m.to = to
+	m.body = htmlBody
+	return nil
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	resetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	mailer := &fakeMailer{}
+	cfg := &config.Config{SecretKey: "secret", Auth: config.Auth{
+		PasswordResetTTL: 30 * time.Minute,
+		PasswordResetURL: "https://app.example.com/reset-password",
+	}}
+	service := NewService(userRepo, nil, nil, nil, resetRepo, nil, mailer, cfg)
+
+	t.Run("envia link de uso único", func(t *testing.T) {
+		userRepo.EXPECT().GetUserByEmail("user@example.com").Return(&domain.User{ID: 1, Name: "Ana", Email: "user@example.com", Active: true}, nil)
+		resetRepo.EXPECT().InvalidateUserPasswordResetTokens(1).Return(nil)
+
+		var stored *domain.PasswordResetToken
+		resetRepo.EXPECT().CreatePasswordResetToken(gomock.Any()).DoAndReturn(func(token *domain.PasswordResetToken) error {
+			stored = token
+			return nil
+		})
+
+		assert.NoError(t, service.RequestPasswordReset("User@Example.com"))
+		assert.Equal(t, []string{"user@example.com"}, mailer.to)
+		assert.Contains(t, mailer.body, "https://app.example.com/reset-password?token=")
+		assert.Contains(t, mailer.body, "30 minutos")
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), stored.ExpiresAt, time.Second)
+
+		// O e-mail traz o token; só o hash é armazenado
+		token := mailer.body[strings.Index(mailer.body, "token=")+len("token="):]
+		token = token[:strings.Index(token, `"`)]
+		assert.Equal(t, hashOpaqueToken(token), stored.TokenHash)
+	})
+
+	t.Run("não revela e-mail desconhecido", func(t *testing.T) {
+		mailer.to = nil
+		userRepo.EXPECT().GetUserByEmail("nobody@example.com").Return(nil, nil)
+
+		assert.NoError(t, service.RequestPasswordReset("nobody@example.com"))
+		assert.Nil(t, mailer.to)
+	})
+
+	t.Run("indisponível sem URL configurada", func(t *testing.T) {
+		unconfigured := NewService(userRepo, nil, nil, nil, resetRepo, nil, mailer, &config.Config{SecretKey: "secret"})
+		assert.ErrorIs(t, unconfigured.RequestPasswordReset("user@example.com"), ErrPasswordResetUnavailable)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	resetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret", PasswordPolicy: config.PasswordPolicy{HistorySize: 3}}
+	service := NewService(userRepo, nil, refreshRepo, nil, resetRepo, nil, &fakeMailer{}, cfg)
+
+	hash := hashOpaqueToken("token-link")
+	user := &domain.User{ID: 1, Active: true, PasswordHash: hashPassword(t, "Atual@2024x")}
+
+	t.Run("redefine a senha e encerra as sessões", func(t *testing.T) {
+		resetRepo.EXPECT().GetPasswordResetTokenByHash(hash).Return(&domain.PasswordResetToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		userRepo.EXPECT().GetUserByID(1).Return(user, nil)
+		userRepo.EXPECT().GetPasswordHistory(1, 3).Return(nil, nil)
+		resetRepo.EXPECT().MarkPasswordResetTokenUsed(5).Return(true, nil)
+		userRepo.EXPECT().UpdatePassword(1, gomock.Any(), false, gomock.Any()).Return(nil)
+		refreshRepo.EXPECT().RevokeUserRefreshTokens(1).Return(nil)
+
+		assert.NoError(t, service.ResetPassword("token-link", "Nova@2025xyz"))
+	})
+
+	t.Run("rejeita token já usado", func(t *testing.T) {
+		usedAt := time.Now()
+		resetRepo.EXPECT().GetPasswordResetTokenByHash(hash).Return(&domain.PasswordResetToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+
+		assert.ErrorIs(t, service.ResetPassword("token-link", "Nova@2025xyz"), ErrInvalidToken)
+	})
+
+	t.Run("rejeita token expirado", func(t *testing.T) {
+		resetRepo.EXPECT().GetPasswordResetTokenByHash(hash).Return(&domain.PasswordResetToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		assert.ErrorIs(t, service.ResetPassword("token-link", "Nova@2025xyz"), ErrInvalidToken)
+	})
+
+	t.Run("senha fraca não consome o token", func(t *testing.T) {
+		assert.ErrorIs(t, service.ResetPassword("token-link", "fraca"), ErrWeakPassword)
+	})
+
+	t.Run("senha do histórico não consome o token", func(t *testing.T) {
+		resetRepo.EXPECT().GetPasswordResetTokenByHash(hash).Return(&domain.PasswordResetToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		userRepo.EXPECT().GetUserByID(1).Return(user, nil)
+		userRepo.EXPECT().GetPasswordHistory(1, 3).Return(nil, nil)
+
+		assert.ErrorIs(t, service.ResetPassword("token-link", "Atual@2024x"), ErrPasswordReused)
+	})
+}
@@ -0,0 +1,173 @@
+package authenticating
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// defaultRefreshTokenTTL é usado quando a validade do refresh token não é configurada
+const defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshSession troca um refresh token válido por um novo token de acesso e um novo refresh token.
+// O token apresentado é revogado; se ele já tinha sido usado, todas as sessões do usuário são
+// encerradas, pois o token provavelmente vazou.
+func (s *Service) RefreshSession(refreshToken string, client domain.SessionClient) (*domain.LoginResult, error) {
+	if refreshToken == "" {
+		return nil, NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "refresh_token é obrigatório")
+	}
+	if s.refreshTokenRepo == nil {
+		return nil, NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "refresh token inválido")
+	}
+
+	stored, err := s.refreshTokenRepo.GetRefreshTokenByHash(hashOpaqueToken(refreshToken))
+	if err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar refresh token")
+	}
+	if stored == nil {
+		return nil, NewAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, "refresh token inválido")
+	}
+
+	now := time.Now()
+	if !now.Before(stored.ExpiresAt) {
+		return nil, NewUserAuthError(ErrExpiredToken, errorcodes.ErrExpiredToken, stored.UserID, "refresh token expirado, faça login novamente")
+	}
+
+	revoked := false
+	if stored.RevokedAt == nil {
+		revoked, err = s.refreshTokenRepo.RevokeRefreshToken(stored.ID)
+		if err != nil {
+			return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao revogar refresh token")
+		}
+	}
+	if !revoked {
+		logrus.WithField("user_id", stored.UserID).Warn("Refresh token reutilizado, revogando todas as sessões do usuário")
+		s.revokeUserSessions(stored.UserID)
+		return nil, NewUserAuthError(ErrInvalidToken, errorcodes.ErrInvalidToken, stored.UserID, "refresh token já utilizado, faça login novamente")
+	}
+
+	user, err := s.userRepo.GetUserByID(stored.UserID)
+	if err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+	}
+	if user == nil {
+		return nil, NewAuthError(ErrUserNotFound, errorcodes.ErrUserNotFound, "Usuário não encontrado")
+	}
+	if !user.Active {
+		return nil, NewUserAuthError(ErrUserDisabled, errorcodes.ErrUserDisabled, user.ID, "Conta desativada")
+	}
+
+	// A troca de senha pode ter passado a ser obrigatória depois do login
+	passwordExpiresAt := s.passwordExpiresAt(user)
+	if user.MustChangePassword || (passwordExpiresAt != nil && now.After(*passwordExpiresAt)) {
+		return nil, NewUserAuthError(ErrPasswordExpired, errorcodes.ErrPasswordExpired, user.ID, "Troca de senha obrigatória, faça login novamente")
+	}
+
+	token, tokenExpiresAt, err := s.generateJWT(user, false, stored.SessionID, now)
+	if err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar token de autenticação")
+	}
+
+	result := &domain.LoginResult{
+		Token:             token,
+		ExpiresAt:         tokenExpiresAt,
+		PasswordExpiresAt: passwordExpiresAt,
+	}
+	session := &domain.RefreshToken{
+		SessionID:        stored.SessionID,
+		SessionStartedAt: stored.SessionStartedAt,
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+	}
+	if err := s.attachRefreshToken(result, user.ID, session); err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar token de autenticação")
+	}
+
+	return result, nil
+}
+
+// RevokeRefreshToken encerra a sessão do refresh token (logout). Tokens desconhecidos são ignorados.
+func (s *Service) RevokeRefreshToken(refreshToken string) error {
+	if refreshToken == "" || s.refreshTokenRepo == nil {
+		return nil
+	}
+
+	stored, err := s.refreshTokenRepo.GetRefreshTokenByHash(hashOpaqueToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if stored == nil || stored.RevokedAt != nil {
+		return nil
+	}
+
+	_, err = s.refreshTokenRepo.RevokeRefreshToken(stored.ID)
+	return err
+}
+
+// attachRefreshToken emite um refresh token da sessão para o usuário e o inclui no resultado
+func (s *Service) attachRefreshToken(result *domain.LoginResult, userID int, session *domain.RefreshToken) error {
+	if s.refreshTokenRepo == nil {
+		return nil
+	}
+
+	value, err := generateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("erro ao gerar refresh token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.refreshTokenTTL())
+	if err := s.refreshTokenRepo.CreateRefreshToken(&domain.RefreshToken{
+		UserID:           userID,
+		TokenHash:        hashOpaqueToken(value),
+		SessionID:        session.SessionID,
+		SessionStartedAt: session.SessionStartedAt,
+		UserAgent:        session.UserAgent,
+		IPAddress:        session.IPAddress,
+		ExpiresAt:        expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	result.RefreshToken = value
+	result.RefreshTokenExpiresAt = &expiresAt
+	return nil
+}
+
+func (s *Service) revokeUserRefreshTokens(userID int) {
+	if s.refreshTokenRepo == nil {
+		return
+	}
+	if err := s.refreshTokenRepo.RevokeUserRefreshTokens(userID); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Erro ao revogar refresh tokens do usuário")
+	}
+}
+
+func (s *Service) refreshTokenTTL() time.Duration {
+	if s.cfg.Auth.RefreshTokenTTL > 0 {
+		return s.cfg.Auth.RefreshTokenTTL
+	}
+	return defaultRefreshTokenTTL
+}
+
+// generateOpaqueToken gera um token aleatório de 256 bits, usado nos refresh tokens e nos links de
+// redefinição de senha
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashOpaqueToken é o SHA-256 (hex) armazenado no lugar do token
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,105 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoginUser_IssuesRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret", Auth: config.Auth{
+		SessionIdleTimeout: 30 * time.Minute,
+		AccessTokenTTL:     10 * time.Minute,
+		RefreshTokenTTL:    24 * time.Hour,
+	}}
+	service := NewService(userRepo, nil, refreshRepo, nil, nil, nil, nil, cfg)
+
+	userRepo.EXPECT().GetUserByEmail("user@example.com").Return(&domain.User{
+		ID:           1,
+		Active:       true,
+		PasswordHash: hashPassword(t, "Senha@2024x"),
+	}, nil)
+	userRepo.EXPECT().RecordLogin(1, gomock.Any()).Return(nil)
+
+	var stored *domain.RefreshToken
+	refreshRepo.EXPECT().CreateRefreshToken(gomock.Any()).DoAndReturn(func(token *domain.RefreshToken) error {
+		stored = token
+		return nil
+	})
+
+	result, err := service.LoginUser("user@example.com", "Senha@2024x", domain.SessionClient{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), result.ExpiresAt, time.Second)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), *result.RefreshTokenExpiresAt, time.Second)
+
+	// Apenas o hash é armazenado
+	assert.Equal(t, hashOpaqueToken(result.RefreshToken), stored.TokenHash)
+	assert.NotEqual(t, result.RefreshToken, stored.TokenHash)
+}
+
+func TestRefreshSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	service := NewService(userRepo, nil, refreshRepo, nil, nil, nil, nil, &config.Config{SecretKey: "secret"})
+	userRepo.EXPECT().TouchLastSeen(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	hash := hashOpaqueToken("token-atual")
+
+	t.Run("troca o token e emite um novo", func(t *testing.T) {
+		refreshRepo.EXPECT().GetRefreshTokenByHash(hash).Return(&domain.RefreshToken{
+			ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		refreshRepo.EXPECT().RevokeRefreshToken(5).Return(true, nil)
+		userRepo.EXPECT().GetUserByID(1).Return(&domain.User{ID: 1, RoleID: 2, Active: true}, nil)
+		refreshRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
+
+		result, err := service.RefreshSession("token-atual", domain.SessionClient{})
+		assert.NoError(t, err)
+		assert.NotEqual(t, "token-atual", result.RefreshToken)
+
+		claims, err := service.ValidateToken(result.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claims.UserRoleID)
+	})
+
+	t.Run("token reutilizado revoga todas as sessões", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Minute)
+		refreshRepo.EXPECT().GetRefreshTokenByHash(hash).Return(&domain.RefreshToken{
+			ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+		}, nil)
+		refreshRepo.EXPECT().RevokeUserRefreshTokens(1).Return(nil)
+
+		_, err := service.RefreshSession("token-atual", domain.SessionClient{})
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("token expirado", func(t *testing.T) {
+		refreshRepo.EXPECT().GetRefreshTokenByHash(hash).Return(&domain.RefreshToken{
+			ID: 5, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		_, err := service.RefreshSession("token-atual", domain.SessionClient{})
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("token desconhecido", func(t *testing.T) {
+		refreshRepo.EXPECT().GetRefreshTokenByHash(hashOpaqueToken("outro")).Return(nil, nil)
+
+		_, err := service.RefreshSession("outro", domain.SessionClient{})
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
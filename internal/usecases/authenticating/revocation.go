@@ -0,0 +1,56 @@
+package authenticating
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// RevokeAccessToken encerra o token de acesso (logout): seu jti fica revogado até a expiração.
+// Tokens inválidos ou já expirados são ignorados, pois não dão mais acesso.
+func (s *Service) RevokeAccessToken(tokenString string) error {
+	if tokenString == "" || s.revokedTokenRepo == nil {
+		return nil
+	}
+
+	claims, err := s.parseToken(tokenString)
+	if err != nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	return s.revokedTokenRepo.RevokeToken(claims.ID, claims.UserID, claims.ExpiresAt.Time)
+}
+
+// checkRevocation rejeita tokens encerrados no logout, de sessões encerradas remotamente ou emitidos antes
+// da revogação de todas as sessões do usuário (desativação, exclusão ou redefinição de senha pelo administrador)
+func (s *Service) checkRevocation(claims *domain.Claims) error {
+	if s.revokedTokenRepo == nil || claims.IssuedAt == nil {
+		return nil
+	}
+
+	revoked, err := s.revokedTokenRepo.IsTokenRevoked(claims.ID, claims.SessionID, claims.UserID, claims.IssuedAt.Time)
+	if err != nil {
+		return NewUserAuthError(ErrDatabaseOperation, errorcodes.ErrDatabaseOperation, claims.UserID, err.Error())
+	}
+	if revoked {
+		return NewUserAuthError(ErrRevokedToken, errorcodes.ErrInvalidToken, claims.UserID, "sessão encerrada, faça login novamente")
+	}
+
+	return nil
+}
+
+// revokeUserSessions encerra todas as sessões do usuário: os refresh tokens são revogados e os tokens de
+// acesso já emitidos deixam de ser aceitos. O instante é truncado ao segundo, a precisão do iat do JWT,
+// para não rejeitar um login feito logo em seguida.
+func (s *Service) revokeUserSessions(userID int) {
+	s.revokeUserRefreshTokens(userID)
+
+	if s.revokedTokenRepo == nil {
+		return
+	}
+	if err := s.revokedTokenRepo.RevokeUserTokens(userID, time.Now().Truncate(time.Second)); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Erro ao revogar tokens de acesso do usuário")
+	}
+}
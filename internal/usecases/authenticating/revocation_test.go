@@ -0,0 +1,80 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateToken_Revocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	revokedRepo := mocks.NewMockRevokedTokenRepository(ctrl)
+	service := NewService(userRepo, nil, nil, revokedRepo, nil, nil, nil, &config.Config{SecretKey: "secret"}).(*Service)
+	userRepo.EXPECT().TouchLastSeen(7, gomock.Any()).Return(nil).AnyTimes()
+
+	token, _, err := service.generateJWT(&domain.User{ID: 7, RoleID: 2}, false, "sessao-1", time.Now())
+	assert.NoError(t, err)
+
+	t.Run("aceita token não revogado", func(t *testing.T) {
+		revokedRepo.EXPECT().IsTokenRevoked(gomock.Any(), "sessao-1", 7, gomock.Any()).Return(false, nil)
+
+		claims, err := service.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, claims.ID)
+	})
+
+	t.Run("rejeita token revogado", func(t *testing.T) {
+		revokedRepo.EXPECT().IsTokenRevoked(gomock.Any(), "sessao-1", 7, gomock.Any()).Return(true, nil)
+
+		_, err := service.ValidateToken(token)
+		assert.ErrorIs(t, err, ErrRevokedToken)
+	})
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revokedRepo := mocks.NewMockRevokedTokenRepository(ctrl)
+	service := NewService(nil, nil, nil, revokedRepo, nil, nil, nil, &config.Config{SecretKey: "secret"}).(*Service)
+
+	token, expiresAt, err := service.generateJWT(&domain.User{ID: 7, RoleID: 2}, false, "sessao-1", time.Now())
+	assert.NoError(t, err)
+
+	revokedRepo.EXPECT().IsTokenRevoked(gomock.Any(), "sessao-1", 7, gomock.Any()).Return(false, nil)
+	revokedRepo.EXPECT().RevokeToken(gomock.Not(""), 7, expiresAt.Truncate(time.Second)).Return(nil)
+
+	assert.NoError(t, service.RevokeAccessToken(token))
+
+	// Tokens inválidos não têm o que revogar
+	assert.NoError(t, service.RevokeAccessToken("token-invalido"))
+}
+
+func TestUpdateUser_DeactivationRevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	revokedRepo := mocks.NewMockRevokedTokenRepository(ctrl)
+	service := NewService(userRepo, nil, refreshRepo, revokedRepo, nil, nil, nil, &config.Config{SecretKey: "secret"})
+
+	userRepo.EXPECT().GetUserByID(7).Return(&domain.User{ID: 7, Active: true}, nil)
+	userRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+	refreshRepo.EXPECT().RevokeUserRefreshTokens(7).Return(nil)
+	revokedRepo.EXPECT().RevokeUserTokens(7, gomock.Any()).DoAndReturn(func(_ int, revokedBefore time.Time) error {
+		assert.WithinDuration(t, time.Now(), revokedBefore, time.Second)
+		return nil
+	})
+
+	active := false
+	assert.NoError(t, service.UpdateUser(&domain.UpdateUserRequest{ID: 7, Active: &active}, 1))
+}
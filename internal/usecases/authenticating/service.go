@@ -5,51 +5,123 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var secretKey = "seu_segredo_super_secreto"
-
 type Authenticator interface {
 	CreateUser(user *domain.User) (*domain.User, error)
-	UpdateUser(user *domain.UpdateUserRequest) error
+	UpdateUser(user *domain.UpdateUserRequest, actorID int) error
 	ListUser() ([]*domain.User, error)
-	LoginUser(email, password string) (string, error)
+	LoginUser(email, password string, client domain.SessionClient) (*domain.LoginResult, error)
 	GetUserProfile(userID int) (*domain.User, error)
 	ValidateToken(tokenString string) (*domain.Claims, error)
-	GenerateStrongPassword(requestUserID, targetUserID int) (string, error)
+	RenewToken(claims *domain.Claims) (string, error)
+	RefreshSession(refreshToken string, client domain.SessionClient) (*domain.LoginResult, error)
+	RevokeRefreshToken(refreshToken string) error
+	ListSessions(userID int, currentSessionID string) ([]*domain.Session, error)
+	RevokeSession(userID int, sessionID string, actorID int) error
+	RevokeAccessToken(tokenString string) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	VerifyEmail(token string) error
+	ResendEmailVerification(email string) error
+	ImportUsers(rows []domain.UserImportRow, actorID int) (*domain.UserImportReport, error)
+	CreateAPIKey(userID int, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error)
+	ListAPIKeys(userID int) ([]*domain.APIKey, error)
+	RevokeAPIKey(userID, keyID int) error
+	AuthenticateAPIKey(plainKey string) (*domain.Claims, error)
+	GenerateStrongPassword(targetUserID, actorID int) (string, error)
 	ChangePassword(userID int, currentPassword, newPassword string) error
 	ValidatePasswordStrength(password string) error
 	GetUserLinkedAccounts(userID int) ([]*domain.AdAccountResponse, error)
-	LinkUserAccount(userID int, accountID string) error
-	UnlinkUserAccount(userID int, accountID string) error
-	ManageUserAccounts(userID int, accountIDs []string) error
+	LinkUserAccount(userID int, accountID string, permission domain.AccountPermission, actorID int) error
+	UnlinkUserAccount(userID int, accountID string, actorID int) error
+	ManageUserAccounts(userID int, accountIDs []string, permission domain.AccountPermission, actorID int) error
+	GetAccountPermission(userID int, accountID string) (domain.AccountPermission, error)
 }
 
 type Service struct {
-	userRepo    repository.UserRepository
-	accountRepo repository.AccountRepository
-	cfg         *config.Config
+	userRepo              repository.UserRepository
+	accountRepo           repository.AccountRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	revokedTokenRepo      repository.RevokedTokenRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	apiKeyRepo            repository.APIKeyRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	roleRepo              repository.RoleRepository
+	mailer                notifying.Mailer
+	auditor               auditing.Recorder
+	cfg                   *config.Config
+	keys                  *KeyRing
+	lastSeen              *lastSeenTracker
+}
+
+// Option configura dependências opcionais do Service na construção
+type Option func(*Service)
+
+// WithAuditRecorder registra no log de auditoria as edições de usuários, as senhas geradas e os
+// vínculos com contas
+func WithAuditRecorder(recorder auditing.Recorder) Option {
+	return func(s *Service) {
+		s.auditor = recorder
+	}
 }
 
-func NewService(userRepo repository.UserRepository, accountRepo repository.AccountRepository, cfg *config.Config) Authenticator {
-	return &Service{
-		userRepo:    userRepo,
-		accountRepo: accountRepo,
-		cfg:         cfg,
+// recordAudit registra a operação no log de auditoria, quando configurado
+func (s *Service) recordAudit(actorID int, action, entityType, entityID string, details any) {
+	if s.auditor != nil {
+		s.auditor.Record(actorID, action, entityType, entityID, details)
 	}
 }
 
-func (s *Service) UpdateUser(user *domain.UpdateUserRequest) error {
+// NewService cria o serviço de autenticação. Sem refreshTokenRepo, o login não emite refresh tokens;
+// sem revokedTokenRepo, os tokens de acesso valem até expirar; sem passwordResetRepo ou mailer, a
+// recuperação de senha por e-mail fica indisponível; sem apiKeyRepo, as chaves de API.
+func NewService(
+	userRepo repository.UserRepository,
+	accountRepo repository.AccountRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	revokedTokenRepo repository.RevokedTokenRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	mailer notifying.Mailer,
+	cfg *config.Config,
+	opts ...Option,
+) Authenticator {
+	s := &Service{
+		userRepo:          userRepo,
+		accountRepo:       accountRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		revokedTokenRepo:  revokedTokenRepo,
+		passwordResetRepo: passwordResetRepo,
+		apiKeyRepo:        apiKeyRepo,
+		mailer:            mailer,
+		cfg:               cfg,
+		keys:              NewKeyRing(cfg),
+		lastSeen:          newLastSeenTracker(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// UpdateUser aplica os campos informados ao usuário. actorID é quem fez a alteração, registrado na auditoria.
+func (s *Service) UpdateUser(user *domain.UpdateUserRequest, actorID int) error {
 	if user.ID == 0 {
 		return errors.New("ID is required")
 	}
@@ -62,6 +134,8 @@ func (s *Service) UpdateUser(user *domain.UpdateUserRequest) error {
 		return err
 	}
 
+	wasActive, wasDeleted := userDatabase.Active, userDatabase.Deleted
+
 	if user.Name != nil {
 		userDatabase.Name = *user.Name
 	}
@@ -96,10 +170,45 @@ func (s *Service) UpdateUser(user *domain.UpdateUserRequest) error {
 	if err != nil {
 		return err
 	}
+	s.recordAudit(actorID, domain.AuditActionUserUpdate, domain.AuditEntityUser, strconv.Itoa(userDatabase.ID), userUpdateAuditDetails(user))
+
+	// Usuário desativado ou excluído perde o acesso imediatamente
+	deactivated := wasActive && !userDatabase.Active
+	deleted := !wasDeleted && userDatabase.Deleted
+	if deactivated || deleted {
+		s.deactivateUser(userDatabase.ID, deleted, actorID)
+	}
 
 	return nil
 }
 
+// userUpdateAuditDetails lista os campos alterados com os novos valores
+func userUpdateAuditDetails(user *domain.UpdateUserRequest) map[string]any {
+	details := map[string]any{}
+	if user.Name != nil {
+		details["name"] = *user.Name
+	}
+	if user.Lastname != nil {
+		details["lastname"] = *user.Lastname
+	}
+	if user.Email != nil {
+		details["email"] = *user.Email
+	}
+	if user.Active != nil {
+		details["active"] = *user.Active
+	}
+	if user.RoleID != nil {
+		details["role_id"] = *user.RoleID
+	}
+	if user.AvatarURL != nil {
+		details["avatar_url"] = *user.AvatarURL
+	}
+	if user.Deleted != nil {
+		details["deleted"] = *user.Deleted
+	}
+	return details
+}
+
 func (s *Service) CreateUser(user *domain.User) (*domain.User, error) {
 	if user.Email == "" || user.Name == "" || user.Lastname == "" || user.PasswordHash == "" {
 		return nil, NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "Email, nome, sobrenome e senha são obrigatórios")
@@ -129,6 +238,13 @@ func (s *Service) CreateUser(user *domain.User) (*domain.User, error) {
 		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao criar usuário")
 	}
 
+	// O cadastro não depende do envio: o usuário pode pedir um novo link de confirmação
+	if s.emailVerificationEnabled() {
+		if err := s.sendEmailVerification(user); err != nil {
+			logrus.WithError(err).WithField("user_id", user.ID).Error("Erro ao enviar verificação de e-mail do cadastro")
+		}
+	}
+
 	return user, nil
 }
 
@@ -148,41 +264,80 @@ func (s *Service) ListUser() ([]*domain.User, error) {
 	return users, nil
 }
 
-func (s *Service) LoginUser(email, password string) (string, error) {
+func (s *Service) LoginUser(email, password string, client domain.SessionClient) (*domain.LoginResult, error) {
 	// Validação de entrada
 	if email == "" || password == "" {
-		return "", NewAuthError(ErrMissingRequiredData, errorcodes.ErrUserDisabled, "Email e senha são obrigatórios")
+		return nil, NewAuthError(ErrMissingRequiredData, errorcodes.ErrUserDisabled, "Email e senha são obrigatórios")
 	}
 
 	email = handleEmail(email)
 
 	user, err := s.userRepo.GetUserByEmail(email)
 	if err != nil {
-		return "", NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
 	}
 
 	// Verificar se o usuário existe
 	if user == nil {
-		return "", NewAuthError(ErrUserNotFound, errorcodes.ErrUserNotFound, "Usuário não encontrado")
+		return nil, NewAuthError(ErrUserNotFound, errorcodes.ErrUserNotFound, "Usuário não encontrado")
 	}
 
 	// Verificar se o usuário está ativo
 	if !user.Active {
-		return "", NewUserAuthError(ErrUserDisabled, errorcodes.ErrUserDisabled, user.ID, "Conta desativada")
+		return nil, NewUserAuthError(ErrUserDisabled, errorcodes.ErrUserDisabled, user.ID, "Conta desativada")
 	}
 
 	// Verificar senha
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", NewUserAuthError(ErrInvalidCredentials, errorcodes.ErrInvalidCredentials, user.ID, "Senha incorreta")
+		return nil, NewUserAuthError(ErrInvalidCredentials, errorcodes.ErrInvalidCredentials, user.ID, "Senha incorreta")
 	}
 
-	// Gerar token JWT
-	token, err := generateJWT(user, s.cfg.SecretKey)
+	// Com a verificação habilitada, o e-mail precisa estar confirmado
+	if s.emailVerificationEnabled() && !user.EmailVerified {
+		return nil, NewUserAuthError(ErrEmailNotVerified, errorcodes.ErrEmailNotVerified, user.ID, "Confirme o e-mail pelo link enviado no cadastro")
+	}
+
+	// Senha expirada ou temporária: o token emitido só permite a troca de senha
+	expiresAt := s.passwordExpiresAt(user)
+	mustChange := user.MustChangePassword || (expiresAt != nil && time.Now().After(*expiresAt))
+
+	// Gerar token JWT de uma nova sessão
+	session := &domain.RefreshToken{
+		SessionID:        uuid.NewString(),
+		SessionStartedAt: time.Now(),
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+	}
+	token, tokenExpiresAt, err := s.generateJWT(user, mustChange, session.SessionID, session.SessionStartedAt)
 	if err != nil {
-		return "", NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar token de autenticação")
+		return nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar token de autenticação")
+	}
+
+	result := &domain.LoginResult{
+		Token:              token,
+		ExpiresAt:          tokenExpiresAt,
+		MustChangePassword: mustChange,
+		PasswordExpiresAt:  expiresAt,
 	}
 
-	return token, nil
+	if !mustChange {
+		if err := s.attachRefreshToken(result, user.ID, session); err != nil {
+			return nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar token de autenticação")
+		}
+	}
+
+	s.recordLogin(user.ID)
+	return result, nil
+}
+
+// passwordExpiresAt calcula a expiração da senha pela política configurada. Nil = sem expiração.
+func (s *Service) passwordExpiresAt(user *domain.User) *time.Time {
+	if s.cfg.PasswordPolicy.MaxAgeDays <= 0 || user.PasswordChangedAt == nil {
+		return nil
+	}
+
+	expiresAt := user.PasswordChangedAt.AddDate(0, 0, s.cfg.PasswordPolicy.MaxAgeDays)
+	return &expiresAt
 }
 
 func (s *Service) GetUserProfile(userID int) (*domain.User, error) {
@@ -196,58 +351,143 @@ func (s *Service) GetUserProfile(userID int) (*domain.User, error) {
 	return user, nil
 }
 
-func generateJWT(user *domain.User, secretKey string) (string, error) {
+// Valores usados quando a configuração da sessão não é informada
+const (
+	defaultSessionIdleTimeout = 30 * time.Minute
+	defaultSessionMaxLifetime = 24 * time.Hour
+)
+
+// generateJWT emite um token de acesso que expira após o tempo de inatividade ou a validade do token de
+// acesso (o menor), limitado à duração máxima da sessão
+func (s *Service) generateJWT(user *domain.User, passwordChangeRequired bool, sessionID string, sessionStartedAt time.Time) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.accessTokenTTL())
+	if maxExpiresAt := sessionStartedAt.Add(s.sessionMaxLifetime()); expiresAt.After(maxExpiresAt) {
+		expiresAt = maxExpiresAt
+	}
+
 	claims := domain.Claims{
-		UserID:        user.ID,
-		UserName:      user.Name,
-		UserLastname:  user.Lastname,
-		UserEmail:     user.Email,
-		UserActive:    user.Active,
-		UserRoleID:    user.RoleID,
-		UserAvatarURL: user.AvatarURL,
-		UserAccounts:  user.LinkedAccounts,
+		UserID:                 user.ID,
+		UserRoleID:             user.RoleID,
+		ClaimsVersion:          domain.CurrentClaimsVersion,
+		PasswordChangeRequired: passwordChangeRequired,
+		SessionStartedAt:       jwt.NewNumericDate(sessionStartedAt),
+		SessionID:              sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        uuid.NewString(),
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secretKey))
+	token, err := s.keys.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// RenewToken emite um novo token para a sessão ativa (expiração deslizante). Retorna vazio quando o token
+// ainda é recente, a sessão atingiu a duração máxima, a troca de senha é obrigatória ou o usuário foi desativado.
+// O perfil é relido do banco, então mudanças de role valem a partir da renovação.
+func (s *Service) RenewToken(claims *domain.Claims) (string, error) {
+	if claims.PasswordChangeRequired || claims.IssuedAt == nil {
+		return "", nil
+	}
+
+	now := time.Now()
+	if now.Sub(claims.IssuedAt.Time) < s.cfg.Auth.SessionRenewInterval {
+		return "", nil
+	}
+
+	sessionStartedAt := claims.IssuedAt.Time
+	if claims.SessionStartedAt != nil {
+		sessionStartedAt = claims.SessionStartedAt.Time
+	}
+	if !now.Before(sessionStartedAt.Add(s.sessionMaxLifetime())) {
+		return "", nil
+	}
+
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil || !user.Active {
+		return "", nil
+	}
+
+	token, _, err := s.generateJWT(user, false, claims.SessionID, sessionStartedAt)
+	return token, err
+}
+
+func (s *Service) sessionIdleTimeout() time.Duration {
+	if s.cfg.Auth.SessionIdleTimeout > 0 {
+		return s.cfg.Auth.SessionIdleTimeout
+	}
+	return defaultSessionIdleTimeout
+}
+
+// accessTokenTTL é a validade de cada token de acesso: AUTH_ACCESS_TOKEN_TTL, quando menor que o tempo de inatividade
+func (s *Service) accessTokenTTL() time.Duration {
+	idle := s.sessionIdleTimeout()
+	if ttl := s.cfg.Auth.AccessTokenTTL; ttl > 0 && ttl < idle {
+		return ttl
+	}
+	return idle
+}
+
+func (s *Service) sessionMaxLifetime() time.Duration {
+	if s.cfg.Auth.SessionMaxLifetime > 0 {
+		return s.cfg.Auth.SessionMaxLifetime
+	}
+	return defaultSessionMaxLifetime
 }
 
+// ValidateToken valida o token de uma requisição autenticada e registra a atividade do usuário
 func (s *Service) ValidateToken(tokenString string) (*domain.Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.cfg.SecretKey), nil
-	})
+	claims, err := s.parseToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*domain.Claims); ok && token.Valid {
-		return claims, nil
-	} else {
-		return nil, errors.New("invalid token")
-	}
+	s.touchLastSeen(claims.UserID)
+	return claims, nil
 }
 
-// GenerateStrongPassword gera uma senha forte para o usuário alvo.
-// Verifica se o usuário solicitante tem perfil de administrador (role_id = 1) antes de prosseguir.
-func (s *Service) GenerateStrongPassword(requestUserID, targetUserID int) (string, error) {
-	// Verificar se o usuário solicitante é um administrador
-	requestUser, err := s.userRepo.GetUserByID(requestUserID)
+// parseToken valida a assinatura, a versão das claims e a revogação do token
+func (s *Service) parseToken(tokenString string) (*domain.Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &domain.Claims{}, s.keys.Keyfunc)
 	if err != nil {
-		return "", err
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, NewAuthError(ErrExpiredToken, errorcodes.ErrExpiredToken, err.Error())
+		}
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*domain.Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
 	}
-	if requestUser == nil {
-		return "", errors.New("usuário solicitante não encontrado")
+
+	// Tokens emitidos com um formato anterior de claims exigem novo login
+	if claims.ClaimsVersion != domain.CurrentClaimsVersion {
+		return nil, NewUserAuthError(ErrExpiredToken, errorcodes.ErrExpiredToken, claims.UserID,
+			fmt.Sprintf("versão de claims %d desatualizada, faça login novamente", claims.ClaimsVersion))
 	}
-	if requestUser.RoleID != 1 {
-		return "", errors.New("apenas administradores podem gerar novas senhas")
+
+	if err := s.checkRevocation(claims); err != nil {
+		return nil, err
 	}
 
+	return claims, nil
+}
+
+// maxGeneratedPasswordAttempts limita as tentativas de gerar uma senha que não esteja no histórico
+const maxGeneratedPasswordAttempts = 3
+
+// GenerateStrongPassword gera uma senha forte para o usuário alvo. A rota exige a permissão users:manage.
+func (s *Service) GenerateStrongPassword(targetUserID, actorID int) (string, error) {
 	// Verificar se o usuário alvo existe
 	targetUser, err := s.userRepo.GetUserByID(targetUserID)
 	if err != nil {
@@ -257,10 +497,25 @@ func (s *Service) GenerateStrongPassword(requestUserID, targetUserID int) (strin
 		return "", errors.New("usuário alvo não encontrado")
 	}
 
-	// Gerar senha forte
-	newPassword, err := generateStrongPassword(12)
-	if err != nil {
-		return "", err
+	// Gerar senha forte, fora do histórico do usuário
+	var newPassword string
+	for attempt := 0; attempt < maxGeneratedPasswordAttempts; attempt++ {
+		newPassword, err = generateStrongPassword(12)
+		if err != nil {
+			return "", err
+		}
+
+		reused, err := s.isPasswordReused(targetUser, newPassword)
+		if err != nil {
+			return "", err
+		}
+		if !reused {
+			break
+		}
+		newPassword = ""
+	}
+	if newPassword == "" {
+		return "", ErrPasswordReused
 	}
 
 	// Hash da nova senha
@@ -269,12 +524,13 @@ func (s *Service) GenerateStrongPassword(requestUserID, targetUserID int) (strin
 		return "", err
 	}
 
-	// Atualizar senha do usuário alvo
-	targetUser.PasswordHash = string(hashedPassword)
-	err = s.userRepo.UpdateUser(targetUser)
+	// Atualizar senha do usuário alvo, exigindo a troca no próximo login
+	err = s.userRepo.UpdatePassword(targetUser.ID, string(hashedPassword), true, s.cfg.PasswordPolicy.HistorySize)
 	if err != nil {
 		return "", err
 	}
+	s.revokeUserSessions(targetUser.ID)
+	s.recordAudit(actorID, domain.AuditActionPasswordGenerate, domain.AuditEntityUser, strconv.Itoa(targetUser.ID), nil)
 
 	return newPassword, nil
 }
@@ -437,31 +693,66 @@ func (s *Service) ChangePassword(userID int, currentPassword, newPassword string
 		return err
 	}
 
+	// Impedir a reutilização da senha atual e das últimas senhas do histórico
+	reused, err := s.isPasswordReused(user, newPassword)
+	if err != nil {
+		return err
+	}
+	if reused {
+		return ErrPasswordReused
+	}
+
 	// Gerar hash da nova senha
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 
-	// Atualizar a senha do usuário
-	user.PasswordHash = string(hashedPassword)
-	err = s.userRepo.UpdateUser(user)
+	// Atualizar a senha do usuário e registrar no histórico
+	err = s.userRepo.UpdatePassword(user.ID, string(hashedPassword), false, s.cfg.PasswordPolicy.HistorySize)
 	if err != nil {
 		return err
 	}
 
+	// Sessões abertas com a senha anterior não podem mais ser estendidas
+	s.revokeUserRefreshTokens(user.ID)
+
 	return nil
 }
 
+// isPasswordReused verifica se a nova senha é igual à atual ou a uma das últimas PASSWORD_HISTORY_SIZE senhas
+func (s *Service) isPasswordReused(user *domain.User, newPassword string) (bool, error) {
+	historySize := s.cfg.PasswordPolicy.HistorySize
+	if historySize <= 0 {
+		return false, nil
+	}
+
+	hashes := []string{user.PasswordHash}
+
+	history, err := s.userRepo.GetPasswordHistory(user.ID, historySize)
+	if err != nil {
+		return false, err
+	}
+	hashes = append(hashes, history...)
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(newPassword)) == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // GetUserLinkedAccounts retorna as contas vinculadas a um usuário
 func (s *Service) GetUserLinkedAccounts(userID int) ([]*domain.AdAccountResponse, error) {
-	accountIDs, err := s.userRepo.GetUserLinkedAccounts(userID)
+	permissions, err := s.userRepo.GetUserAccountPermissions(userID)
 	if err != nil {
 		return nil, err
 	}
 
 	accounts := make([]*domain.AdAccountResponse, 0)
-	for _, id := range accountIDs {
+	for id, permission := range permissions {
 		account, err := s.accountRepo.GetAccountByID(id)
 		if err != nil {
 			return nil, err
@@ -479,14 +770,20 @@ func (s *Service) GetUserLinkedAccounts(userID int) ([]*domain.AdAccountResponse
 			Status:     account.Status,
 			CNPJ:       account.CNPJ,
 			Nickname:   account.Nickname,
+			Permission: permission,
 		})
 	}
 
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+
 	return accounts, nil
 }
 
-// LinkUserAccount adiciona um vínculo entre usuário e conta
-func (s *Service) LinkUserAccount(userID int, accountID string) error {
+// LinkUserAccount adiciona um vínculo entre usuário e conta com o nível de acesso informado.
+// Se o vínculo já existir, apenas o nível de acesso é atualizado.
+func (s *Service) LinkUserAccount(userID int, accountID string, permission domain.AccountPermission, actorID int) error {
 	// Verificar se o usuário existe
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
@@ -500,11 +797,19 @@ func (s *Service) LinkUserAccount(userID int, accountID string) error {
 	// Aqui precisaria de acesso ao repositório de contas
 	// Por simplicidade, apenas adicionamos o vínculo
 
-	return s.userRepo.LinkUserAccount(userID, accountID)
+	if err := s.userRepo.LinkUserAccount(userID, accountID, permission); err != nil {
+		return err
+	}
+	s.recordAudit(actorID, domain.AuditActionAccountLink, domain.AuditEntityUser, strconv.Itoa(userID), map[string]any{
+		"account_id": accountID,
+		"permission": permission,
+	})
+
+	return nil
 }
 
 // UnlinkUserAccount remove o vínculo entre usuário e conta
-func (s *Service) UnlinkUserAccount(userID int, accountID string) error {
+func (s *Service) UnlinkUserAccount(userID int, accountID string, actorID int) error {
 	// Verificar se o usuário existe
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
@@ -514,12 +819,20 @@ func (s *Service) UnlinkUserAccount(userID int, accountID string) error {
 		return errors.New("usuário não encontrado")
 	}
 
-	return s.userRepo.UnlinkUserAccount(userID, accountID)
+	if err := s.userRepo.UnlinkUserAccount(userID, accountID); err != nil {
+		return err
+	}
+	s.recordAudit(actorID, domain.AuditActionAccountUnlink, domain.AuditEntityUser, strconv.Itoa(userID), map[string]any{
+		"account_id": accountID,
+	})
+
+	return nil
 }
 
 // ManageUserAccounts atualiza todas as contas vinculadas a um usuário
-// Isso remove todas as existentes e adiciona as novas
-func (s *Service) ManageUserAccounts(userID int, accountIDs []string) error {
+// Isso remove todas as existentes e adiciona as novas. Com permission informado,
+// o nível de acesso é aplicado a todas as contas; vazio mantém o dos vínculos existentes.
+func (s *Service) ManageUserAccounts(userID int, accountIDs []string, permission domain.AccountPermission, actorID int) error {
 	// Verificar se o usuário existe
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
@@ -564,14 +877,31 @@ func (s *Service) ManageUserAccounts(userID int, accountIDs []string) error {
 			}
 		}
 
-		if !found {
-			err := s.userRepo.LinkUserAccount(userID, new)
-			if err != nil {
-				logrus.Warnf("Erro ao vincular conta %s ao usuário %d: %v", new, userID, err)
-				// Continuar mesmo com erro
-			}
+		if found && permission == "" {
+			continue
+		}
+
+		linkPermission := permission
+		if linkPermission == "" {
+			linkPermission = domain.AccountPermissionViewer
+		}
+
+		if err := s.userRepo.LinkUserAccount(userID, new, linkPermission); err != nil {
+			logrus.Warnf("Erro ao vincular conta %s ao usuário %d: %v", new, userID, err)
+			// Continuar mesmo com erro
 		}
 	}
 
+	s.recordAudit(actorID, domain.AuditActionAccountLinksReplace, domain.AuditEntityUser, strconv.Itoa(userID), map[string]any{
+		"previous_account_ids": currentAccounts,
+		"account_ids":          accountIDs,
+		"permission":           permission,
+	})
+
 	return nil
 }
+
+// GetAccountPermission retorna o nível de acesso do usuário na conta. Vazio se a conta não estiver vinculada.
+func (s *Service) GetAccountPermission(userID int, accountID string) (domain.AccountPermission, error) {
+	return s.userRepo.GetUserAccountPermission(userID, accountID)
+}
@@ -0,0 +1,137 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashPassword(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
+func TestChangePassword_History(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret", PasswordPolicy: config.PasswordPolicy{HistorySize: 3}}
+	service := NewService(userRepo, nil, nil, nil, nil, nil, nil, cfg)
+
+	user := &domain.User{ID: 1, PasswordHash: hashPassword(t, "Atual@2024x")}
+	userRepo.EXPECT().GetUserByID(1).Return(user, nil).AnyTimes()
+	userRepo.EXPECT().GetPasswordHistory(1, 3).Return([]string{hashPassword(t, "Antiga@2023x")}, nil).AnyTimes()
+
+	t.Run("rejeita senha do histórico", func(t *testing.T) {
+		err := service.ChangePassword(1, "Atual@2024x", "Antiga@2023x")
+		assert.ErrorIs(t, err, ErrPasswordReused)
+	})
+
+	t.Run("rejeita a senha atual", func(t *testing.T) {
+		err := service.ChangePassword(1, "Atual@2024x", "Atual@2024x")
+		assert.ErrorIs(t, err, ErrPasswordReused)
+	})
+
+	t.Run("aceita senha nova", func(t *testing.T) {
+		userRepo.EXPECT().UpdatePassword(1, gomock.Any(), false, 3).Return(nil)
+		assert.NoError(t, service.ChangePassword(1, "Atual@2024x", "Nova@2025xyz"))
+	})
+
+	t.Run("senha gerada também entra no histórico", func(t *testing.T) {
+		userRepo.EXPECT().UpdatePassword(1, gomock.Any(), true, 3).Return(nil)
+		password, err := service.GenerateStrongPassword(1, 2)
+		assert.NoError(t, err)
+		assert.Len(t, password, 12)
+	})
+}
+
+func TestLoginUser_PasswordExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret", PasswordPolicy: config.PasswordPolicy{MaxAgeDays: 90}}
+	service := NewService(userRepo, nil, nil, nil, nil, nil, nil, cfg)
+
+	changedAt := time.Now().AddDate(0, 0, -91)
+	userRepo.EXPECT().GetUserByEmail("user@example.com").Return(&domain.User{
+		ID:                1,
+		Active:            true,
+		PasswordHash:      hashPassword(t, "Senha@2024x"),
+		PasswordChangedAt: &changedAt,
+	}, nil)
+	userRepo.EXPECT().RecordLogin(1, gomock.Any()).Return(nil)
+
+	result, err := service.LoginUser("user@example.com", "Senha@2024x", domain.SessionClient{})
+	assert.NoError(t, err)
+	assert.True(t, result.MustChangePassword)
+
+	claims, err := service.ValidateToken(result.Token)
+	assert.NoError(t, err)
+	assert.True(t, claims.PasswordChangeRequired)
+}
+
+func TestRenewToken_SlidingExpiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	cfg := &config.Config{SecretKey: "secret", Auth: config.Auth{
+		SessionIdleTimeout:   30 * time.Minute,
+		SessionMaxLifetime:   8 * time.Hour,
+		SessionRenewInterval: 5 * time.Minute,
+	}}
+	service := NewService(userRepo, nil, nil, nil, nil, nil, nil, cfg)
+	userRepo.EXPECT().TouchLastSeen(1, gomock.Any()).Return(nil).AnyTimes()
+
+	claimsAt := func(issuedAgo, sessionAgo time.Duration) *domain.Claims {
+		return &domain.Claims{
+			UserID:           1,
+			SessionStartedAt: jwt.NewNumericDate(time.Now().Add(-sessionAgo)),
+			RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now().Add(-issuedAgo))},
+		}
+	}
+
+	// Token recente não é renovado
+	token, err := service.RenewToken(claimsAt(time.Minute, time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	// Sessão ativa: novo token mantém o início da sessão e expira após o tempo de inatividade
+	userRepo.EXPECT().GetUserByID(1).Return(&domain.User{ID: 1, RoleID: 2, Active: true}, nil)
+	token, err = service.RenewToken(claimsAt(10*time.Minute, time.Hour))
+	assert.NoError(t, err)
+
+	renewed, err := service.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, renewed.UserRoleID)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), renewed.SessionStartedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), renewed.ExpiresAt.Time, time.Second)
+
+	// Perto da duração máxima a expiração é limitada ao fim da sessão
+	userRepo.EXPECT().GetUserByID(1).Return(&domain.User{ID: 1, Active: true}, nil)
+	token, err = service.RenewToken(claimsAt(10*time.Minute, 8*time.Hour-10*time.Minute))
+	assert.NoError(t, err)
+	renewed, err = service.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), renewed.ExpiresAt.Time, time.Second)
+
+	// Sessão no limite ou usuário desativado não são renovados
+	token, err = service.RenewToken(claimsAt(10*time.Minute, 8*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	userRepo.EXPECT().GetUserByID(1).Return(&domain.User{ID: 1, Active: false}, nil)
+	token, err = service.RenewToken(claimsAt(10*time.Minute, time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+}
@@ -0,0 +1,72 @@
+package authenticating
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var ErrSessionNotFound = errors.New("sessão não encontrada")
+
+// ListSessions lista as sessões ativas do usuário, da renovação mais recente para a mais antiga.
+// currentSessionID marca a sessão da própria requisição.
+func (s *Service) ListSessions(userID int, currentSessionID string) ([]*domain.Session, error) {
+	sessions := []*domain.Session{}
+	if s.refreshTokenRepo == nil {
+		return sessions, nil
+	}
+
+	tokens, err := s.refreshTokenRepo.ListActiveRefreshTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		sessions = append(sessions, &domain.Session{
+			ID:         token.SessionID,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			StartedAt:  token.SessionStartedAt,
+			LastUsedAt: token.CreatedAt,
+			ExpiresAt:  token.ExpiresAt,
+			Current:    currentSessionID != "" && token.SessionID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession encerra uma sessão do usuário: o refresh token deixa de ser aceito e os tokens de acesso
+// da sessão são revogados pelo session_id até a expiração do último emitido. Sessões de outros usuários ou
+// já encerradas retornam ErrSessionNotFound.
+func (s *Service) RevokeSession(userID int, sessionID string, actorID int) error {
+	if s.refreshTokenRepo == nil {
+		return ErrSessionNotFound
+	}
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return ErrSessionNotFound
+	}
+
+	revoked, err := s.refreshTokenRepo.RevokeSessionRefreshTokens(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+
+	if s.revokedTokenRepo != nil {
+		if err := s.revokedTokenRepo.RevokeToken(sessionID, userID, time.Now().Add(s.accessTokenTTL())); err != nil {
+			return err
+		}
+	}
+
+	s.recordAudit(actorID, domain.AuditActionSessionRevoke, domain.AuditEntityUser, strconv.Itoa(userID), map[string]any{
+		"session_id": sessionID,
+	})
+
+	return nil
+}
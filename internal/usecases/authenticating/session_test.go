@@ -0,0 +1,90 @@
+package authenticating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+const testSessionID = "0b6f3f4e-3c1a-4f6e-9a51-2d7c1c9d8e01"
+
+func TestRefreshSession_KeepsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	service := NewService(userRepo, nil, refreshRepo, nil, nil, nil, nil, &config.Config{SecretKey: "secret"})
+	userRepo.EXPECT().TouchLastSeen(1, gomock.Any()).Return(nil).AnyTimes()
+
+	startedAt := time.Now().Add(-2 * time.Hour)
+	refreshRepo.EXPECT().GetRefreshTokenByHash(hashOpaqueToken("token-atual")).Return(&domain.RefreshToken{
+		ID: 5, UserID: 1, SessionID: testSessionID, SessionStartedAt: startedAt, ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	refreshRepo.EXPECT().RevokeRefreshToken(5).Return(true, nil)
+	userRepo.EXPECT().GetUserByID(1).Return(&domain.User{ID: 1, RoleID: 2, Active: true}, nil)
+	refreshRepo.EXPECT().CreateRefreshToken(gomock.Any()).DoAndReturn(func(token *domain.RefreshToken) error {
+		assert.Equal(t, testSessionID, token.SessionID)
+		assert.Equal(t, startedAt, token.SessionStartedAt)
+		assert.Equal(t, "10.0.0.1", token.IPAddress)
+		assert.Equal(t, "Firefox", token.UserAgent)
+		return nil
+	})
+
+	result, err := service.RefreshSession("token-atual", domain.SessionClient{IPAddress: "10.0.0.1", UserAgent: "Firefox"})
+	assert.NoError(t, err)
+
+	claims, err := service.ValidateToken(result.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, testSessionID, claims.SessionID)
+}
+
+func TestListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	service := NewService(nil, nil, refreshRepo, nil, nil, nil, nil, &config.Config{SecretKey: "secret"})
+
+	refreshRepo.EXPECT().ListActiveRefreshTokens(1).Return([]*domain.RefreshToken{
+		{SessionID: testSessionID, UserAgent: "Firefox"},
+		{SessionID: "outra-sessao", UserAgent: "curl"},
+	}, nil)
+
+	sessions, err := service.ListSessions(1, testSessionID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	revokedRepo := mocks.NewMockRevokedTokenRepository(ctrl)
+	service := NewService(nil, nil, refreshRepo, revokedRepo, nil, nil, nil, &config.Config{SecretKey: "secret"})
+
+	t.Run("encerra a sessão e revoga os tokens de acesso", func(t *testing.T) {
+		refreshRepo.EXPECT().RevokeSessionRefreshTokens(1, testSessionID).Return(true, nil)
+		revokedRepo.EXPECT().RevokeToken(testSessionID, 1, gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.RevokeSession(1, testSessionID, 1))
+	})
+
+	t.Run("sessão inexistente ou de outro usuário", func(t *testing.T) {
+		refreshRepo.EXPECT().RevokeSessionRefreshTokens(2, testSessionID).Return(false, nil)
+
+		assert.ErrorIs(t, service.RevokeSession(2, testSessionID, 2), ErrSessionNotFound)
+	})
+
+	t.Run("id de sessão inválido", func(t *testing.T) {
+		assert.ErrorIs(t, service.RevokeSession(1, "abc", 1), ErrSessionNotFound)
+	})
+}
@@ -0,0 +1,233 @@
+package authenticating
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	errorcodes "github.com/vfg2006/traffic-manager-api/internal/api/errors"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUserImportUnavailable indica que a importação em lote não foi configurada (sem repositório de perfis)
+var ErrUserImportUnavailable = errors.New("importação de usuários não configurada")
+
+// MaxUserImportRows limita o tamanho da importação: cada linha gera um hash bcrypt
+const MaxUserImportRows = 200
+
+// WithRoleRepository habilita a importação de usuários em lote, que valida os perfis informados
+func WithRoleRepository(repo repository.RoleRepository) Option {
+	return func(s *Service) {
+		s.roleRepo = repo
+	}
+}
+
+// ImportUsers cria usuários em lote, já ativos e com o e-mail considerado verificado, pois são cadastrados
+// pelo administrador. Todas as linhas são validadas antes da gravação: com alguma linha inválida, nenhum
+// usuário é criado e o relatório traz os erros de cada linha. Linhas sem senha recebem uma senha temporária,
+// exibida apenas no relatório; em ambos os casos a troca de senha é exigida no primeiro login.
+func (s *Service) ImportUsers(rows []domain.UserImportRow, actorID int) (*domain.UserImportReport, error) {
+	if s.roleRepo == nil || s.accountRepo == nil {
+		return nil, NewAuthError(ErrUserImportUnavailable, errorcodes.ErrInternalServer, "")
+	}
+	if len(rows) == 0 {
+		return nil, NewAuthError(ErrMissingRequiredData, errorcodes.ErrMissingRequiredData, "nenhum usuário informado")
+	}
+	if len(rows) > MaxUserImportRows {
+		return nil, NewAuthError(ErrInvalidRequest, errorcodes.ErrInvalidRequest,
+			fmt.Sprintf("a importação aceita até %d usuários por vez", MaxUserImportRows))
+	}
+
+	roles, err := s.roleRepo.ListRoles()
+	if err != nil {
+		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar perfis")
+	}
+
+	validator := &userImportValidator{
+		service:  s,
+		roles:    roles,
+		emails:   map[string]int{},
+		accounts: map[string]bool{},
+	}
+
+	report := &domain.UserImportReport{Rows: make([]*domain.UserImportResult, 0, len(rows))}
+	imports := make([]*domain.UserImport, 0, len(rows))
+	for i, row := range rows {
+		result := &domain.UserImportResult{Row: i + 1, Email: handleEmail(row.Email)}
+		item, password, rowErrors, err := validator.validate(result.Row, row)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Errors = rowErrors
+		if row.Password == "" {
+			result.Password = password
+		}
+		if len(rowErrors) > 0 {
+			report.Failed++
+		}
+
+		report.Rows = append(report.Rows, result)
+		imports = append(imports, item)
+	}
+
+	// Com alguma linha inválida nada é gravado, e as senhas geradas não são exibidas
+	if report.Failed > 0 {
+		for _, result := range report.Rows {
+			result.Password = ""
+		}
+		return report, nil
+	}
+
+	if err := s.userRepo.ImportUsers(imports); err != nil {
+		if errors.Is(err, repository.ErrUserEmailExists) {
+			return nil, NewAuthError(ErrUserAlreadyExists, errorcodes.ErrUserAlreadyExists, err.Error())
+		}
+		return nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao importar usuários")
+	}
+
+	for i, item := range imports {
+		report.Rows[i].UserID = item.User.ID
+		report.Created++
+
+		s.recordAudit(actorID, domain.AuditActionUserImport, domain.AuditEntityUser, strconv.Itoa(item.User.ID), map[string]any{
+			"email":       item.User.Email,
+			"role_id":     item.User.RoleID,
+			"account_ids": item.User.LinkedAccounts,
+			"permission":  item.AccountPermission,
+		})
+	}
+
+	return report, nil
+}
+
+// userImportValidator valida as linhas da importação, guardando o que já foi consultado
+type userImportValidator struct {
+	service  *Service
+	roles    []*domain.Role
+	emails   map[string]int  // e-mail -> linha em que apareceu
+	accounts map[string]bool // conta -> existe
+}
+
+// validate monta o usuário da linha. Retorna os erros de validação da linha; err é reservado a falhas de consulta.
+func (v *userImportValidator) validate(line int, row domain.UserImportRow) (*domain.UserImport, string, []string, error) {
+	var rowErrors []string
+
+	email := handleEmail(row.Email)
+	name := strings.TrimSpace(row.Name)
+	lastname := strings.TrimSpace(row.Lastname)
+	if name == "" || lastname == "" || email == "" {
+		rowErrors = append(rowErrors, "nome, sobrenome e e-mail são obrigatórios")
+	}
+
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			rowErrors = append(rowErrors, "e-mail inválido")
+		} else if previous, ok := v.emails[email]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("e-mail repetido na linha %d", previous))
+		} else {
+			v.emails[email] = line
+
+			existing, err := v.service.userRepo.GetUserByEmail(email)
+			if err != nil {
+				return nil, "", nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar usuário no banco de dados")
+			}
+			if existing != nil {
+				rowErrors = append(rowErrors, "e-mail já cadastrado")
+			}
+		}
+	}
+
+	roleID, err := v.resolveRole(row)
+	if err != nil {
+		rowErrors = append(rowErrors, err.Error())
+	}
+
+	permission, err := domain.ParseAccountPermission(strings.TrimSpace(row.AccountPermission))
+	if err != nil {
+		rowErrors = append(rowErrors, err.Error())
+	}
+
+	accountIDs := make([]string, 0, len(row.AccountIDs))
+	for _, accountID := range row.AccountIDs {
+		accountID = strings.TrimSpace(accountID)
+		if accountID == "" {
+			continue
+		}
+
+		exists, checked := v.accounts[accountID]
+		if !checked {
+			account, err := v.service.accountRepo.GetAccountByID(accountID)
+			if err != nil {
+				return nil, "", nil, NewAuthError(err, errorcodes.ErrDatabaseOperation, "Erro ao consultar conta")
+			}
+			exists = account != nil
+			v.accounts[accountID] = exists
+		}
+		if !exists {
+			rowErrors = append(rowErrors, fmt.Sprintf("conta não encontrada: %s", accountID))
+			continue
+		}
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	password := row.Password
+	if password == "" {
+		password, err = generateStrongPassword(12)
+		if err != nil {
+			return nil, "", nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar senha")
+		}
+	} else if err := v.service.ValidatePasswordStrength(password); err != nil {
+		rowErrors = append(rowErrors, err.Error())
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, "", rowErrors, nil
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", nil, NewAuthError(err, errorcodes.ErrInternalServer, "Erro ao gerar hash da senha")
+	}
+
+	verifiedAt := time.Now()
+	return &domain.UserImport{
+		User: &domain.User{
+			Name:               name,
+			Lastname:           lastname,
+			Email:              email,
+			PasswordHash:       string(hashedPassword),
+			Active:             true,
+			RoleID:             roleID,
+			MustChangePassword: true,
+			EmailVerified:      true,
+			EmailVerifiedAt:    &verifiedAt,
+			LinkedAccounts:     accountIDs,
+		},
+		AccountPermission: permission,
+	}, password, nil, nil
+}
+
+// resolveRole encontra o perfil pelo ID ou pelo nome, sem diferenciar maiúsculas
+func (v *userImportValidator) resolveRole(row domain.UserImportRow) (int, error) {
+	name := strings.TrimSpace(row.Role)
+	if row.RoleID == 0 && name == "" {
+		return 0, errors.New("perfil é obrigatório")
+	}
+
+	for _, role := range v.roles {
+		if (row.RoleID != 0 && role.ID == row.RoleID) || (row.RoleID == 0 && strings.EqualFold(role.Name, name)) {
+			return role.ID, nil
+		}
+	}
+
+	if row.RoleID != 0 {
+		return 0, fmt.Errorf("perfil não encontrado: %d", row.RoleID)
+	}
+	return 0, fmt.Errorf("perfil não encontrado: %s", name)
+}
@@ -0,0 +1,84 @@
+package authenticating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestImportUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	roleRepo := mocks.NewMockRoleRepository(ctrl)
+	service := NewService(userRepo, accountRepo, nil, nil, nil, nil, nil, &config.Config{SecretKey: "secret"}, WithRoleRepository(roleRepo))
+
+	roleRepo.EXPECT().ListRoles().Return([]*domain.Role{{ID: 3, Name: "customer"}, {ID: 5, Name: "vendedor"}}, nil).AnyTimes()
+
+	t.Run("cria todos os usuários com senha temporária e vínculos", func(t *testing.T) {
+		userRepo.EXPECT().GetUserByEmail("ana@example.com").Return(nil, nil)
+		userRepo.EXPECT().GetUserByEmail("bruno@example.com").Return(nil, nil)
+		accountRepo.EXPECT().GetAccountByID("ABC123").Return(&domain.AdAccount{ID: "ABC123"}, nil)
+
+		userRepo.EXPECT().ImportUsers(gomock.Any()).DoAndReturn(func(imports []*domain.UserImport) error {
+			require.Len(t, imports, 2)
+			for i, item := range imports {
+				item.User.ID = 10 + i
+				assert.True(t, item.User.Active)
+				assert.True(t, item.User.MustChangePassword)
+				assert.NotNil(t, item.User.EmailVerifiedAt)
+			}
+			assert.Equal(t, 5, imports[0].User.RoleID)
+			assert.Equal(t, []string{"ABC123"}, imports[0].User.LinkedAccounts)
+			assert.Equal(t, domain.AccountPermissionAnalyst, imports[0].AccountPermission)
+			assert.Equal(t, 3, imports[1].User.RoleID)
+			assert.Equal(t, domain.AccountPermissionViewer, imports[1].AccountPermission)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(imports[1].User.PasswordHash), []byte("Senha@2024x")))
+			return nil
+		})
+
+		report, err := service.ImportUsers([]domain.UserImportRow{
+			{Name: "Ana", Lastname: "Silva", Email: "Ana@Example.com", Role: "Vendedor", AccountIDs: []string{"ABC123"}, AccountPermission: "analyst"},
+			{Name: "Bruno", Lastname: "Souza", Email: "bruno@example.com", RoleID: 3, Password: "Senha@2024x"},
+		}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Created)
+		assert.Zero(t, report.Failed)
+		assert.Equal(t, 10, report.Rows[0].UserID)
+		assert.NotEmpty(t, report.Rows[0].Password)
+		// A senha informada não volta no relatório
+		assert.Empty(t, report.Rows[1].Password)
+	})
+
+	t.Run("com linha inválida nada é criado", func(t *testing.T) {
+		userRepo.EXPECT().GetUserByEmail("ana@example.com").Return(nil, nil)
+		userRepo.EXPECT().GetUserByEmail("carla@example.com").Return(&domain.User{ID: 2}, nil)
+		accountRepo.EXPECT().GetAccountByID("XYZ999").Return(nil, nil)
+
+		report, err := service.ImportUsers([]domain.UserImportRow{
+			{Name: "Ana", Lastname: "Silva", Email: "ana@example.com", RoleID: 3},
+			{Name: "Ana", Lastname: "Silva", Email: "ana@example.com", RoleID: 3},
+			{Name: "Carla", Lastname: "Lima", Email: "carla@example.com", Role: "gerente", AccountIDs: []string{"XYZ999"}},
+		}, 1)
+		require.NoError(t, err)
+		assert.Zero(t, report.Created)
+		assert.Equal(t, 2, report.Failed)
+		assert.Empty(t, report.Rows[0].Errors)
+		assert.Empty(t, report.Rows[0].Password)
+		assert.Equal(t, []string{"e-mail repetido na linha 1"}, report.Rows[1].Errors)
+		assert.Equal(t, []string{"e-mail já cadastrado", "perfil não encontrado: gerente", "conta não encontrada: XYZ999"}, report.Rows[2].Errors)
+	})
+
+	t.Run("limite de linhas", func(t *testing.T) {
+		_, err := service.ImportUsers(make([]domain.UserImportRow, MaxUserImportRows+1), 1)
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
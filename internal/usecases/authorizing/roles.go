@@ -0,0 +1,158 @@
+package authorizing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
+)
+
+// maxRoleNameLength é o tamanho da coluna roles.name
+const maxRoleNameLength = 255
+
+var (
+	ErrInvalidRole  = errors.New("perfil inválido")
+	ErrRoleNotFound = errors.New("perfil não encontrado")
+	ErrRoleInUse    = errors.New("perfil atribuído a usuários")
+)
+
+// RoleManager mantém o cadastro de perfis e suas permissões
+type RoleManager interface {
+	ListRoles() ([]*domain.Role, error)
+	GetRole(id int) (*domain.Role, error)
+	CreateRole(req *domain.RoleRequest, actorID int) (*domain.Role, error)
+	UpdateRole(id int, req *domain.RoleRequest, actorID int) (*domain.Role, error)
+	DeleteRole(id, actorID int) error
+}
+
+// RoleService grava os perfis e descarta o cache de permissões da Policy a cada alteração
+type RoleService struct {
+	roleRepo repository.RoleRepository
+	policy   Policy
+	auditor  auditing.Recorder
+}
+
+func NewRoleService(roleRepo repository.RoleRepository, policy Policy, auditor auditing.Recorder) RoleManager {
+	return &RoleService{
+		roleRepo: roleRepo,
+		policy:   policy,
+		auditor:  auditor,
+	}
+}
+
+func (s *RoleService) ListRoles() ([]*domain.Role, error) {
+	return s.roleRepo.ListRoles()
+}
+
+func (s *RoleService) GetRole(id int) (*domain.Role, error) {
+	role, err := s.roleRepo.GetRoleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *RoleService) CreateRole(req *domain.RoleRequest, actorID int) (*domain.Role, error) {
+	role, err := roleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.CreateRole(role); err != nil {
+		if errors.Is(err, repository.ErrRoleExists) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRole, err)
+		}
+		return nil, err
+	}
+
+	s.changed(actorID, domain.AuditActionRoleCreate, role)
+	return role, nil
+}
+
+// UpdateRole substitui nome, descrição e permissões do perfil
+func (s *RoleService) UpdateRole(id int, req *domain.RoleRequest, actorID int) (*domain.Role, error) {
+	role, err := roleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	role.ID = id
+
+	updated, err := s.roleRepo.UpdateRole(role)
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleExists) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRole, err)
+		}
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrRoleNotFound
+	}
+
+	s.changed(actorID, domain.AuditActionRoleUpdate, role)
+	return s.GetRole(id)
+}
+
+// DeleteRole exclui o perfil. Perfis atribuídos a usuários não podem ser excluídos.
+func (s *RoleService) DeleteRole(id, actorID int) error {
+	role, err := s.GetRole(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.roleRepo.DeleteRole(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrRoleInUse
+	}
+
+	s.changed(actorID, domain.AuditActionRoleDelete, role)
+	return nil
+}
+
+// changed descarta o cache de permissões e registra a alteração no log de auditoria
+func (s *RoleService) changed(actorID int, action string, role *domain.Role) {
+	if s.policy != nil {
+		s.policy.Invalidate()
+	}
+	if s.auditor != nil {
+		s.auditor.Record(actorID, action, domain.AuditEntityRole, strconv.Itoa(role.ID), map[string]any{
+			"name":        role.Name,
+			"permissions": role.Permissions,
+		})
+	}
+}
+
+// roleFromRequest valida o pedido e remove permissões repetidas
+func roleFromRequest(req *domain.RoleRequest) (*domain.Role, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxRoleNameLength {
+		return nil, fmt.Errorf("%w: o nome é obrigatório e deve ter até %d caracteres", ErrInvalidRole, maxRoleNameLength)
+	}
+
+	permissions := make([]domain.Permission, 0, len(req.Permissions))
+	seen := make(map[domain.Permission]bool, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		if !domain.IsValidPermission(string(permission)) {
+			return nil, fmt.Errorf("%w: permissão desconhecida %q", ErrInvalidRole, permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return &domain.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package authorizing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mocks.NewMockRoleRepository(ctrl)
+	policy := NewService(roleRepo, time.Hour).(*Service)
+	service := NewRoleService(roleRepo, policy, nil)
+
+	t.Run("cria o perfil sem permissões repetidas e descarta o cache", func(t *testing.T) {
+		roleRepo.EXPECT().GetRolePermissions().Return(map[int][]string{}, nil)
+		assert.False(t, policy.HasPermission(6, domain.PermissionInsightsRead))
+
+		roleRepo.EXPECT().CreateRole(gomock.Any()).DoAndReturn(func(role *domain.Role) error {
+			assert.Equal(t, "Analista", role.Name)
+			assert.Equal(t, []domain.Permission{domain.PermissionInsightsRead}, role.Permissions)
+			role.ID = 6
+			return nil
+		})
+
+		role, err := service.CreateRole(&domain.RoleRequest{
+			Name:        " Analista ",
+			Permissions: []domain.Permission{domain.PermissionInsightsRead, domain.PermissionInsightsRead},
+		}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, role.ID)
+
+		roleRepo.EXPECT().GetRolePermissions().Return(map[int][]string{6: {"insights:read"}}, nil)
+		assert.True(t, policy.HasPermission(6, domain.PermissionInsightsRead))
+	})
+
+	t.Run("rejeita permissão desconhecida", func(t *testing.T) {
+		_, err := service.CreateRole(&domain.RoleRequest{Name: "Teste", Permissions: []domain.Permission{"tudo"}}, 1)
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("nome repetido", func(t *testing.T) {
+		roleRepo.EXPECT().CreateRole(gomock.Any()).Return(repository.ErrRoleExists)
+
+		_, err := service.CreateRole(&domain.RoleRequest{Name: "admin"}, 1)
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("perfil inexistente na atualização", func(t *testing.T) {
+		roleRepo.EXPECT().UpdateRole(gomock.Any()).Return(false, nil)
+
+		_, err := service.UpdateRole(99, &domain.RoleRequest{Name: "Teste"}, 1)
+		assert.ErrorIs(t, err, ErrRoleNotFound)
+	})
+
+	t.Run("perfil em uso não é excluído", func(t *testing.T) {
+		roleRepo.EXPECT().GetRoleByID(2).Return(&domain.Role{ID: 2, Name: "manager", UserCount: 3}, nil)
+		roleRepo.EXPECT().DeleteRole(2).Return(false, nil)
+
+		assert.ErrorIs(t, service.DeleteRole(2, 1), ErrRoleInUse)
+	})
+}
@@ -0,0 +1,99 @@
+package authorizing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// Policy resolve as permissões de cada perfil
+type Policy interface {
+	Permissions(roleID int) domain.PermissionSet
+	HasPermission(roleID int, permissions ...domain.Permission) bool
+	// Invalidate descarta o cache, para que mudanças nos perfis valham na próxima requisição
+	Invalidate()
+}
+
+// Service carrega as permissões de role_permissions e as mantém em cache por ttl, para não consultar o
+// banco a cada requisição. Mudanças nas permissões valem em até ttl.
+type Service struct {
+	roleRepo repository.RoleRepository
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu          sync.RWMutex
+	permissions map[int]domain.PermissionSet
+	loadedAt    time.Time
+}
+
+func NewService(roleRepo repository.RoleRepository, ttl time.Duration) Policy {
+	return &Service{
+		roleRepo: roleRepo,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+func (s *Service) Permissions(roleID int) domain.PermissionSet {
+	s.mu.RLock()
+	fresh := s.permissions != nil && s.now().Sub(s.loadedAt) < s.ttl
+	permissions := s.permissions[roleID]
+	s.mu.RUnlock()
+
+	if fresh {
+		return permissions
+	}
+
+	return s.reload()[roleID]
+}
+
+func (s *Service) HasPermission(roleID int, permissions ...domain.Permission) bool {
+	return s.Permissions(roleID).Has(permissions...)
+}
+
+func (s *Service) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loadedAt = time.Time{}
+}
+
+// reload recarrega as permissões. Em caso de erro, mantém as permissões anteriores.
+func (s *Service) reload() map[int]domain.PermissionSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.permissions != nil && s.now().Sub(s.loadedAt) < s.ttl {
+		return s.permissions
+	}
+
+	rows, err := s.roleRepo.GetRolePermissions()
+	if err != nil {
+		logrus.WithError(err).Error("authorizing: erro ao carregar permissões dos perfis, mantendo permissões anteriores")
+		s.loadedAt = s.now()
+		if s.permissions == nil {
+			s.permissions = make(map[int]domain.PermissionSet)
+		}
+		return s.permissions
+	}
+
+	permissions := make(map[int]domain.PermissionSet, len(rows))
+	for roleID, names := range rows {
+		permissions[roleID] = make(domain.PermissionSet, len(names))
+		for _, name := range names {
+			if !domain.IsValidPermission(name) {
+				logrus.WithFields(logrus.Fields{"role_id": roleID, "permission": name}).Warn("authorizing: permissão desconhecida ignorada")
+				continue
+			}
+			permissions[roleID][domain.Permission(name)] = true
+		}
+	}
+
+	s.permissions = permissions
+	s.loadedAt = s.now()
+
+	return s.permissions
+}
@@ -0,0 +1,41 @@
+package authorizing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mocks.NewMockRoleRepository(ctrl)
+	service := NewService(roleRepo, time.Minute).(*Service)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	roleRepo.EXPECT().GetRolePermissions().Return(map[int][]string{
+		1: {"insights:read", "users:manage"},
+		6: {"insights:read", "desconhecida"},
+	}, nil)
+
+	assert.True(t, service.HasPermission(1, domain.PermissionInsightsRead, domain.PermissionUsersManage))
+	assert.True(t, service.HasPermission(6, domain.PermissionInsightsRead))
+	assert.False(t, service.HasPermission(6, domain.PermissionAccountsWrite))
+	assert.False(t, service.HasPermission(9, domain.PermissionInsightsRead))
+	assert.Equal(t, []domain.Permission{domain.PermissionInsightsRead}, service.Permissions(6).List())
+
+	t.Run("mantém as permissões anteriores se o recarregamento falhar", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		roleRepo.EXPECT().GetRolePermissions().Return(nil, errors.New("conexão perdida"))
+
+		assert.True(t, service.HasPermission(1, domain.PermissionUsersManage))
+	})
+}
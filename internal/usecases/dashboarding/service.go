@@ -0,0 +1,147 @@
+package dashboarding
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/ranking"
+)
+
+var ErrAccountNotFound = errors.New("conta não encontrada")
+
+// AccountInsightsProvider fornece os insights da conta pelo external_id
+type AccountInsightsProvider interface {
+	GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error)
+}
+
+type DashboardService interface {
+	// GetAccountDashboard aceita o ID interno ou o external_id da conta
+	GetAccountDashboard(accountID string, filters *domain.InsigthFilters) (*domain.AccountDashboard, error)
+}
+
+// Service compõe o dashboard a partir dos casos de uso existentes. Só os insights são obrigatórios;
+// falhas no ranking ou nas datas de sincronização deixam esses blocos vazios.
+type Service struct {
+	insights         AccountInsightsProvider
+	rankingService   ranking.RankingService
+	accountRepo      repository.AccountRepository
+	adInsightRepo    repository.AdInsightRepository
+	salesInsightRepo repository.SalesInsightRepository
+}
+
+func NewService(
+	insights AccountInsightsProvider,
+	rankingService ranking.RankingService,
+	accountRepo repository.AccountRepository,
+	adInsightRepo repository.AdInsightRepository,
+	salesInsightRepo repository.SalesInsightRepository,
+) DashboardService {
+	return &Service{
+		insights:         insights,
+		rankingService:   rankingService,
+		accountRepo:      accountRepo,
+		adInsightRepo:    adInsightRepo,
+		salesInsightRepo: salesInsightRepo,
+	}
+}
+
+func (s *Service) GetAccountDashboard(accountID string, filters *domain.InsigthFilters) (*domain.AccountDashboard, error) {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain.AccountDashboard{
+		AccountID:  account.ID,
+		ExternalID: account.ExternalID,
+		Name:       account.Name,
+		LastSync:   &domain.DashboardSyncStatus{},
+		Format:     account.FormatMetadata(),
+	}
+	if account.Nickname != nil && *account.Nickname != "" {
+		dashboard.Name = *account.Nickname
+	}
+
+	logger := logrus.WithField("account_id", account.ID)
+
+	var insightsErr error
+	wg := sync.WaitGroup{}
+	wg.Add(4)
+
+	go func() {
+		defer wg.Done()
+		dashboard.Insights, insightsErr = s.insights.GetAdAccountsByID(account.ExternalID, filters)
+	}()
+
+	go func() {
+		defer wg.Done()
+		dashboard.Ranking = s.rankingPosition(account.ID)
+	}()
+
+	go func() {
+		defer wg.Done()
+		lastSync, err := s.adInsightRepo.GetLastSyncedAt(account.ID)
+		if err != nil {
+			logger.WithError(err).Warn("dashboard: erro ao buscar última sincronização de anúncios")
+			return
+		}
+		dashboard.LastSync.AdInsightsAt = lastSync
+	}()
+
+	go func() {
+		defer wg.Done()
+		lastSync, err := s.salesInsightRepo.GetLastSyncedAt(account.ID)
+		if err != nil {
+			logger.WithError(err).Warn("dashboard: erro ao buscar última sincronização de vendas")
+			return
+		}
+		dashboard.LastSync.SalesInsightsAt = lastSync
+	}()
+
+	wg.Wait()
+
+	if insightsErr != nil {
+		return nil, insightsErr
+	}
+	if dashboard.Insights != nil {
+		dashboard.Goals = dashboard.Insights.Goals
+	}
+
+	return dashboard, nil
+}
+
+// rankingPosition retorna a posição da loja no ranking do mês, ou nil se ela não estiver classificada
+func (s *Service) rankingPosition(accountID string) *domain.StoreRankingItem {
+	storeRanking, err := s.rankingService.GetStoreRanking()
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Warn("dashboard: erro ao buscar ranking de lojas")
+		return nil
+	}
+	if storeRanking == nil {
+		return nil
+	}
+
+	for i := range storeRanking.Ranking {
+		if storeRanking.Ranking[i].AccountID == accountID {
+			return &storeRanking.Ranking[i]
+		}
+	}
+	return nil
+}
+
+func (s *Service) findAccount(accountID string) (*domain.AdAccount, error) {
+	account, err := s.accountRepo.GetAccountByID(accountID)
+	if err == nil && account == nil {
+		account, err = s.accountRepo.GetAccountByExternalID(accountID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
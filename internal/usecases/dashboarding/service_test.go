@@ -0,0 +1,93 @@
+package dashboarding
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeInsights struct {
+	response *domain.AdAccountInsightsResponse
+	err      error
+	calledID string
+}
+
+func (f *fakeInsights) GetAdAccountsByID(accountID string, _ *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+	f.calledID = accountID
+	return f.response, f.err
+}
+
+type fakeRanking struct {
+	response *domain.StoreRankingResponse
+	err      error
+}
+
+func (f *fakeRanking) GetStoreRanking() (*domain.StoreRankingResponse, error) {
+	return f.response, f.err
+}
+
+func TestGetAccountDashboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	salesInsightRepo := mocks.NewMockSalesInsightRepository(ctrl)
+
+	nickname := "Loja Centro"
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "act_1", Name: "Conta 1", Nickname: &nickname}
+	start, end := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	filters := &domain.InsigthFilters{StartDate: &start, EndDate: &end}
+	synced := time.Date(2025, 6, 11, 3, 0, 0, 0, time.UTC)
+
+	t.Run("compõe insights, ranking, metas e sincronização", func(t *testing.T) {
+		revenue := 1000.0
+		insights := &fakeInsights{response: &domain.AdAccountInsightsResponse{Goals: &domain.GoalAttainment{Period: "06-2025", RevenueTarget: &revenue}}}
+		rankingService := &fakeRanking{response: &domain.StoreRankingResponse{Ranking: []domain.StoreRankingItem{
+			{AccountID: "zzz999", Position: 1},
+			{AccountID: "abc123", Position: 2},
+		}}}
+
+		accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil)
+		adInsightRepo.EXPECT().GetLastSyncedAt("abc123").Return(&synced, nil)
+		salesInsightRepo.EXPECT().GetLastSyncedAt("abc123").Return(nil, errors.New("falha"))
+
+		service := NewService(insights, rankingService, accountRepo, adInsightRepo, salesInsightRepo)
+		dashboard, err := service.GetAccountDashboard("abc123", filters)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "act_1", insights.calledID)
+		assert.Equal(t, "Loja Centro", dashboard.Name)
+		assert.Equal(t, 2, dashboard.Ranking.Position)
+		assert.Equal(t, "06-2025", dashboard.Goals.Period)
+		assert.Equal(t, &synced, dashboard.LastSync.AdInsightsAt)
+		assert.Nil(t, dashboard.LastSync.SalesInsightsAt)
+	})
+
+	t.Run("falha nos insights interrompe o dashboard", func(t *testing.T) {
+		insights := &fakeInsights{err: errors.New("meta indisponível")}
+		rankingService := &fakeRanking{err: errors.New("falha")}
+
+		accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil)
+		adInsightRepo.EXPECT().GetLastSyncedAt("abc123").Return(nil, nil)
+		salesInsightRepo.EXPECT().GetLastSyncedAt("abc123").Return(nil, nil)
+
+		service := NewService(insights, rankingService, accountRepo, adInsightRepo, salesInsightRepo)
+		_, err := service.GetAccountDashboard("abc123", filters)
+		assert.Error(t, err)
+	})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("nope").Return(nil, nil)
+		accountRepo.EXPECT().GetAccountByExternalID("nope").Return(nil, nil)
+
+		service := NewService(&fakeInsights{}, &fakeRanking{}, accountRepo, adInsightRepo, salesInsightRepo)
+		_, err := service.GetAccountDashboard("nope", filters)
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,120 @@
+package goaling
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	ErrInvalidGoal     = errors.New("meta inválida")
+	ErrGoalNotFound    = errors.New("meta não encontrada")
+	ErrAccountNotFound = errors.New("conta não encontrada")
+)
+
+type GoalService interface {
+	ListGoals(accountID string) ([]*domain.AccountGoal, error)
+	GetGoal(accountID, period string) (*domain.AccountGoal, error)
+	// SaveGoal cria ou substitui as metas do mês (mm-yyyy)
+	SaveGoal(accountID, period string, userID int, req *domain.AccountGoalRequest) (*domain.AccountGoal, error)
+	DeleteGoal(accountID, period string) error
+}
+
+type Service struct {
+	goalRepo repository.GoalRepository
+}
+
+func NewService(goalRepo repository.GoalRepository) GoalService {
+	return &Service{
+		goalRepo: goalRepo,
+	}
+}
+
+func (s *Service) ListGoals(accountID string) ([]*domain.AccountGoal, error) {
+	return s.goalRepo.ListGoals(accountID)
+}
+
+func (s *Service) GetGoal(accountID, period string) (*domain.AccountGoal, error) {
+	month, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.goalRepo.GetGoal(accountID, month)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, ErrGoalNotFound
+	}
+
+	return goal, nil
+}
+
+func (s *Service) SaveGoal(accountID, period string, userID int, req *domain.AccountGoalRequest) (*domain.AccountGoal, error) {
+	month, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RevenueTarget == nil && req.MaxCPA == nil && req.SpendBudget == nil {
+		return nil, fmt.Errorf("%w: informe ao menos uma meta (revenue_target, max_cpa ou spend_budget)", ErrInvalidGoal)
+	}
+	for _, field := range []struct {
+		name  string
+		value *float64
+	}{
+		{"revenue_target", req.RevenueTarget},
+		{"max_cpa", req.MaxCPA},
+		{"spend_budget", req.SpendBudget},
+	} {
+		if field.value != nil && *field.value <= 0 {
+			return nil, fmt.Errorf("%w: %s deve ser maior que zero", ErrInvalidGoal, field.name)
+		}
+	}
+
+	goal := &domain.AccountGoal{
+		AccountID:     accountID,
+		RevenueTarget: req.RevenueTarget,
+		MaxCPA:        req.MaxCPA,
+		SpendBudget:   req.SpendBudget,
+		UserID:        &userID,
+	}
+
+	saved, err := s.goalRepo.UpsertGoal(goal, month)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *Service) DeleteGoal(accountID, period string) error {
+	month, err := parsePeriod(period)
+	if err != nil {
+		return err
+	}
+
+	err = s.goalRepo.DeleteGoal(accountID, month)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGoalNotFound
+	}
+	return err
+}
+
+// parsePeriod converte o mês no formato mm-yyyy para o primeiro dia do mês
+func parsePeriod(period string) (time.Time, error) {
+	month, err := time.Parse(domain.GoalPeriodFormat, strings.TrimSpace(period))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: período deve estar no formato mm-yyyy", ErrInvalidGoal)
+	}
+	return month, nil
+}
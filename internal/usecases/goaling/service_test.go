@@ -0,0 +1,69 @@
+package goaling
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalRepo := mocks.NewMockGoalRepository(ctrl)
+	service := NewService(goalRepo)
+
+	revenue := 5000.0
+
+	t.Run("salva metas do mês", func(t *testing.T) {
+		goalRepo.EXPECT().UpsertGoal(gomock.Any(), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).DoAndReturn(func(goal *domain.AccountGoal, _ time.Time) (*domain.AccountGoal, error) {
+			assert.Equal(t, "act_123", goal.AccountID)
+			assert.Equal(t, 5000.0, *goal.RevenueTarget)
+			assert.Nil(t, goal.MaxCPA)
+			assert.Equal(t, 7, *goal.UserID)
+			return goal, nil
+		})
+
+		_, err := service.SaveGoal("act_123", "06-2025", 7, &domain.AccountGoalRequest{RevenueTarget: &revenue})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejeita período ou valores inválidos", func(t *testing.T) {
+		_, err := service.SaveGoal("act_123", "2025-06", 7, &domain.AccountGoalRequest{RevenueTarget: &revenue})
+		assert.ErrorIs(t, err, ErrInvalidGoal)
+
+		_, err = service.SaveGoal("act_123", "06-2025", 7, &domain.AccountGoalRequest{})
+		assert.ErrorIs(t, err, ErrInvalidGoal)
+
+		negative := -1.0
+		_, err = service.SaveGoal("act_123", "06-2025", 7, &domain.AccountGoalRequest{MaxCPA: &negative})
+		assert.ErrorIs(t, err, ErrInvalidGoal)
+	})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		goalRepo.EXPECT().UpsertGoal(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		_, err := service.SaveGoal("act_999", "06-2025", 7, &domain.AccountGoalRequest{RevenueTarget: &revenue})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
+
+func TestGetAndDeleteGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalRepo := mocks.NewMockGoalRepository(ctrl)
+	service := NewService(goalRepo)
+
+	goalRepo.EXPECT().GetGoal("act_123", gomock.Any()).Return(nil, nil)
+	_, err := service.GetGoal("act_123", "06-2025")
+	assert.ErrorIs(t, err, ErrGoalNotFound)
+
+	goalRepo.EXPECT().DeleteGoal("act_123", gomock.Any()).Return(sql.ErrNoRows)
+	assert.ErrorIs(t, service.DeleteGoal("act_123", "06-2025"), ErrGoalNotFound)
+}
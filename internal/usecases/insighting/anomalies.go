@@ -0,0 +1,72 @@
+package insighting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	// DefaultAnomalyThreshold é o número de desvios-padrão a partir do qual um dia é considerado anômalo
+	DefaultAnomalyThreshold = 3.0
+	// maxAnomalyListRange limita o intervalo consultado de uma vez
+	maxAnomalyListRange = 366 * 24 * time.Hour
+)
+
+var ErrInvalidAnomalyRange = errors.New("intervalo de anomalias inválido")
+
+type AnomalyDetector interface {
+	// DetectAccountAnomalies reavalia os dias de from a to da conta com base nos insights diários armazenados
+	// e grava as anomalias encontradas, substituindo as anteriores do intervalo
+	DetectAccountAnomalies(account *domain.AdAccount, from, to time.Time) ([]*domain.InsightAnomaly, error)
+	// ListAnomalies aceita o ID interno ou o external_id da conta
+	ListAnomalies(accountID string, startDate, endDate time.Time) ([]*domain.InsightAnomaly, error)
+}
+
+// AnomalyService detecta dias com investimento ou custo por resultado fora do padrão da conta
+type AnomalyService struct {
+	adInsightRepo repository.AdInsightRepository
+	anomalyRepo   repository.AnomalyRepository
+	threshold     float64
+}
+
+func NewAnomalyService(adInsightRepo repository.AdInsightRepository, anomalyRepo repository.AnomalyRepository, threshold float64) AnomalyDetector {
+	if threshold <= 0 {
+		threshold = DefaultAnomalyThreshold
+	}
+
+	return &AnomalyService{
+		adInsightRepo: adInsightRepo,
+		anomalyRepo:   anomalyRepo,
+		threshold:     threshold,
+	}
+}
+
+func (s *AnomalyService) DetectAccountAnomalies(account *domain.AdAccount, from, to time.Time) ([]*domain.InsightAnomaly, error) {
+	entries, err := s.adInsightRepo.GetByDateRange(account.ID, from.AddDate(0, 0, -domain.AnomalyTrailingDays), to)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights diários: %w", err)
+	}
+
+	anomalies := domain.DetectInsightAnomalies(account.ID, entries, from, to, s.threshold)
+
+	if err := s.anomalyRepo.ReplaceAnomalies(account.ID, from, to, anomalies); err != nil {
+		return nil, err
+	}
+
+	return anomalies, nil
+}
+
+func (s *AnomalyService) ListAnomalies(accountID string, startDate, endDate time.Time) ([]*domain.InsightAnomaly, error) {
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("%w: a data de início não pode ser posterior à data de fim", ErrInvalidAnomalyRange)
+	}
+	if endDate.Sub(startDate) > maxAnomalyListRange {
+		return nil, fmt.Errorf("%w: o intervalo máximo é de um ano", ErrInvalidAnomalyRange)
+	}
+
+	return s.anomalyRepo.ListAnomalies(accountID, startDate, endDate)
+}
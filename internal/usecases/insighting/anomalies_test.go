@@ -0,0 +1,36 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAnomalyService_DetectAccountAnomalies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	anomalyRepo := mocks.NewMockAnomalyRepository(ctrl)
+	service := NewAnomalyService(adInsightRepo, anomalyRepo, 0)
+
+	from := time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	// A janela de referência anterior a from também é carregada
+	adInsightRepo.EXPECT().GetByDateRange("abc123", from.AddDate(0, 0, -domain.AnomalyTrailingDays), to).Return(nil, nil)
+	anomalyRepo.EXPECT().ReplaceAnomalies("abc123", from, to, []*domain.InsightAnomaly{}).Return(nil)
+
+	anomalies, err := service.DetectAccountAnomalies(&domain.AdAccount{ID: "abc123"}, from, to)
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+
+	t.Run("intervalo invertido na listagem", func(t *testing.T) {
+		_, err := service.ListAnomalies("abc123", to, from)
+		assert.ErrorIs(t, err, ErrInvalidAnomalyRange)
+	})
+}
@@ -0,0 +1,104 @@
+package insighting
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// BreakdownProvider busca na origem (Meta) as quebras por idade, gênero e plataforma da conta
+type BreakdownProvider interface {
+	GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdowns, error)
+}
+
+type BreakdownInsighter interface {
+	// GetAdAccountBreakdowns aceita o ID interno ou o external_id da conta
+	GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdownsResponse, error)
+}
+
+// BreakdownService armazena as quebras consultadas no Meta por conta e período. Períodos já
+// encerrados quando foram consultados não mudam e são servidos do banco; os demais são renovados
+// depois de ttl.
+type BreakdownService struct {
+	provider      BreakdownProvider
+	accountRepo   repository.AccountRepository
+	breakdownRepo repository.AdInsightBreakdownRepository
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+func NewBreakdownService(provider BreakdownProvider, accountRepo repository.AccountRepository, breakdownRepo repository.AdInsightBreakdownRepository, ttl time.Duration) BreakdownInsighter {
+	return &BreakdownService{
+		provider:      provider,
+		accountRepo:   accountRepo,
+		breakdownRepo: breakdownRepo,
+		ttl:           ttl,
+		now:           time.Now,
+	}
+}
+
+func (s *BreakdownService) GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdownsResponse, error) {
+	account, err := findAccount(s.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.breakdownRepo.Get(account.ID, *filters.StartDate, *filters.EndDate)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", account.ID).Warn("breakdowns: erro ao buscar quebras armazenadas")
+		stored = nil
+	}
+
+	if stored != nil && s.isFresh(stored) {
+		return breakdownsResponse(account, stored), nil
+	}
+
+	breakdowns, err := s.provider.GetAdAccountBreakdowns(ctx, account.ExternalID, filters)
+	if err != nil {
+		// Com o Meta indisponível, a versão armazenada (mesmo vencida) é melhor que nenhuma
+		if stored != nil {
+			logrus.WithError(err).WithField("account_id", account.ID).Warn("breakdowns: usando quebras armazenadas após falha no Meta")
+			return breakdownsResponse(account, stored), nil
+		}
+		return nil, err
+	}
+
+	entry := &domain.AdInsightBreakdownEntry{
+		AccountID:  account.ID,
+		StartDate:  *filters.StartDate,
+		EndDate:    *filters.EndDate,
+		Breakdowns: breakdowns,
+		FetchedAt:  s.now(),
+	}
+	if err := s.breakdownRepo.SaveOrUpdate(entry); err != nil {
+		logrus.WithError(err).WithField("account_id", account.ID).Error("breakdowns: erro ao salvar quebras")
+	}
+
+	return breakdownsResponse(account, entry), nil
+}
+
+// isFresh indica se as quebras armazenadas podem ser servidas sem consultar o Meta
+func (s *BreakdownService) isFresh(entry *domain.AdInsightBreakdownEntry) bool {
+	periodEnd := time.Date(entry.EndDate.Year(), entry.EndDate.Month(), entry.EndDate.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	if entry.FetchedAt.After(periodEnd) {
+		return true
+	}
+	return s.now().Sub(entry.FetchedAt) < s.ttl
+}
+
+func breakdownsResponse(account *domain.AdAccount, entry *domain.AdInsightBreakdownEntry) *domain.AdAccountBreakdownsResponse {
+	response := &domain.AdAccountBreakdownsResponse{
+		AccountID: account.ID,
+		StartDate: entry.StartDate.Format(time.DateOnly),
+		EndDate:   entry.EndDate.Format(time.DateOnly),
+		FetchedAt: entry.FetchedAt,
+		Format:    account.FormatMetadata(),
+	}
+	if entry.Breakdowns != nil {
+		response.AdAccountBreakdowns = *entry.Breakdowns
+	}
+	return response
+}
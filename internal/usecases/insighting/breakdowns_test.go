@@ -0,0 +1,102 @@
+package insighting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeBreakdownProvider struct {
+	calls int
+	err   error
+}
+
+func (f *fakeBreakdownProvider) GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdowns, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.AdAccountBreakdowns{
+		Gender: []*domain.BreakdownSegment{{Key: "female", Label: "Feminino", Spend: 100, Result: 10}},
+	}, nil
+}
+
+func TestBreakdownService_GetAdAccountBreakdowns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	breakdownRepo := mocks.NewMockAdInsightBreakdownRepository(ctrl)
+	provider := &fakeBreakdownProvider{}
+
+	service := NewBreakdownService(provider, accountRepo, breakdownRepo, time.Hour).(*BreakdownService)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	service.now = func() time.Time { return now }
+
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "act_1"}
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil).AnyTimes()
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	filters := &domain.InsigthFilters{StartDate: &start, EndDate: &end}
+
+	t.Run("consulta o Meta e armazena quando não há quebras salvas", func(t *testing.T) {
+		breakdownRepo.EXPECT().Get("abc123", start, end).Return(nil, nil)
+		breakdownRepo.EXPECT().SaveOrUpdate(gomock.Any()).DoAndReturn(func(entry *domain.AdInsightBreakdownEntry) error {
+			assert.Equal(t, "abc123", entry.AccountID)
+			assert.Equal(t, now, entry.FetchedAt)
+			return nil
+		})
+
+		response, err := service.GetAdAccountBreakdowns(context.Background(), "abc123", filters)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, provider.calls)
+		assert.Equal(t, "2025-03-10", response.EndDate)
+		assert.Equal(t, "Feminino", response.Gender[0].Label)
+	})
+
+	t.Run("serve do banco dentro da validade", func(t *testing.T) {
+		provider.calls = 0
+		breakdownRepo.EXPECT().Get("abc123", start, end).Return(&domain.AdInsightBreakdownEntry{
+			AccountID: "abc123", StartDate: start, EndDate: end,
+			Breakdowns: &domain.AdAccountBreakdowns{}, FetchedAt: now.Add(-30 * time.Minute),
+		}, nil)
+
+		_, err := service.GetAdAccountBreakdowns(context.Background(), "abc123", filters)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, provider.calls)
+	})
+
+	t.Run("período encerrado na consulta não expira", func(t *testing.T) {
+		provider.calls = 0
+		closedEnd := time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local)
+		breakdownRepo.EXPECT().Get("abc123", start, closedEnd).Return(&domain.AdInsightBreakdownEntry{
+			AccountID: "abc123", StartDate: start, EndDate: closedEnd,
+			Breakdowns: &domain.AdAccountBreakdowns{}, FetchedAt: time.Date(2025, 3, 2, 8, 0, 0, 0, time.Local),
+		}, nil)
+
+		_, err := service.GetAdAccountBreakdowns(context.Background(), "abc123", &domain.InsigthFilters{StartDate: &start, EndDate: &closedEnd})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, provider.calls)
+	})
+
+	t.Run("usa a versão vencida quando o Meta falha", func(t *testing.T) {
+		provider.err = errors.New("meta indisponível")
+		defer func() { provider.err = nil }()
+
+		breakdownRepo.EXPECT().Get("abc123", start, end).Return(&domain.AdInsightBreakdownEntry{
+			AccountID: "abc123", StartDate: start, EndDate: end,
+			Breakdowns: &domain.AdAccountBreakdowns{}, FetchedAt: now.Add(-2 * time.Hour),
+		}, nil)
+
+		response, err := service.GetAdAccountBreakdowns(context.Background(), "abc123", filters)
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(-2*time.Hour), response.FetchedAt)
+	})
+}
@@ -0,0 +1,211 @@
+package insighting
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+const defaultBusinessManagerConcurrency = 5
+
+var ErrBusinessManagerNotFound = errors.New("business manager não encontrado ou sem contas ativas")
+
+// AccountInsightsProvider obtém os insights combinados (anúncios e vendas) de uma conta
+type AccountInsightsProvider interface {
+	GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error)
+}
+
+type BusinessManagerInsighter interface {
+	// GetBusinessManagerInsights consolida as contas ativas do business manager. canView, quando informado,
+	// restringe o consolidado às contas que o usuário pode visualizar.
+	GetBusinessManagerInsights(businessManagerID string, filters *domain.InsigthFilters, canView func(accountID string) bool) (*domain.BusinessManagerInsightsResponse, error)
+}
+
+// BusinessManagerInsightService soma os insights de cada conta do business manager, obtidos pelo mesmo
+// serviço da visão da conta, para que o consolidado bata com a soma das contas.
+type BusinessManagerInsightService struct {
+	provider          AccountInsightsProvider
+	accountRepo       repository.AccountRepository
+	currencyConverter CurrencyConverter
+	grossMargin       float64
+	concurrency       int
+}
+
+func NewBusinessManagerInsightService(provider AccountInsightsProvider, accountRepo repository.AccountRepository, currencyConverter CurrencyConverter, grossMargin float64) BusinessManagerInsighter {
+	return &BusinessManagerInsightService{
+		provider:          provider,
+		accountRepo:       accountRepo,
+		currencyConverter: currencyConverter,
+		grossMargin:       grossMargin,
+		concurrency:       defaultBusinessManagerConcurrency,
+	}
+}
+
+func (s *BusinessManagerInsightService) GetBusinessManagerInsights(businessManagerID string, filters *domain.InsigthFilters, canView func(accountID string) bool) (*domain.BusinessManagerInsightsResponse, error) {
+	accounts, _, err := s.accountRepo.ListAccountsPage(&domain.AccountListFilters{
+		Status:            []domain.AdAccountStatus{domain.AdAccountStatusActive},
+		BusinessManagerID: businessManagerID,
+		Limit:             domain.MaxAccountListLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.AdAccount, 0, len(accounts))
+	for _, account := range accounts {
+		if canView == nil || canView(account.ID) {
+			visible = append(visible, account)
+		}
+	}
+	if len(visible) == 0 {
+		return nil, ErrBusinessManagerNotFound
+	}
+
+	response := &domain.BusinessManagerInsightsResponse{
+		BusinessManagerID:   businessManagerID,
+		BusinessManagerName: visible[0].BusinessManagerName,
+		StartDate:           filters.StartDate.Format(time.DateOnly),
+		EndDate:             filters.EndDate.Format(time.DateOnly),
+		Accounts:            make([]*domain.BusinessManagerAccountInsight, 0, len(visible)),
+		Format:              domain.NewFormatMetadata(domain.SalesCurrency, domain.DefaultLocale),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, s.concurrency)
+
+	adMetrics := make([]*domain.AdAccountMetrics, 0, len(visible))
+	salesInsights := make([]*domain.SalesInsightEntry, 0, len(visible))
+
+	for _, account := range visible {
+		wg.Add(1)
+		go func(account *domain.AdAccount) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Cada conta recebe seus próprios filtros, já que o serviço da conta pode alterá-los
+			accountFilters := &domain.InsigthFilters{StartDate: filters.StartDate, EndDate: filters.EndDate}
+			insights, err := s.provider.GetAdAccountsByID(account.ID, accountFilters)
+
+			var rate float64
+			if err == nil {
+				if rate = spendExchangeRate(s.currencyConverter, account); rate <= 0 {
+					err = errors.New("cotação indisponível para a moeda da conta")
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			name := account.Name
+			if account.Nickname != nil && *account.Nickname != "" {
+				name = *account.Nickname
+			}
+
+			if err != nil {
+				logrus.WithError(err).WithField("account_id", account.ID).Warn("Erro ao obter insights da conta para o consolidado do business manager")
+				response.Failures = append(response.Failures, &domain.BusinessManagerInsightFailure{
+					AccountID:   account.ID,
+					AccountName: name,
+					Error:       err.Error(),
+				})
+				return
+			}
+
+			item := &domain.BusinessManagerAccountInsight{AccountID: account.ID, AccountName: name}
+			if insights != nil && insights.AdAccountMetrics != nil {
+				converted := convertAdMetrics(insights.AdAccountMetrics, rate)
+				adMetrics = append(adMetrics, converted)
+				item.Spend = converted.Spend
+				item.Result = converted.Result
+			}
+			if insights != nil && insights.SalesMetrics != nil {
+				salesInsights = append(salesInsights, &domain.SalesInsightEntry{AccountID: account.ID, SalesMetrics: insights.SalesMetrics})
+				if social := insights.SalesMetrics[domain.SocialNetwork]; social != nil {
+					item.SocialNetworkRevenue = social.TotalRevenue
+				}
+			}
+			response.Accounts = append(response.Accounts, item)
+		}(account)
+	}
+
+	wg.Wait()
+
+	response.AdMetrics = sumAdMetrics(adMetrics, response.BusinessManagerName)
+	response.SalesMetrics = combineSalesMetrics(salesInsights)
+	if response.AdMetrics != nil && response.SalesMetrics != nil {
+		response.ResultMetrics = domain.CalculateResultMetricsWithRate(response.AdMetrics, response.SalesMetrics, 1, s.grossMargin)
+	}
+
+	sort.Slice(response.Accounts, func(i, j int) bool {
+		return response.Accounts[i].Spend > response.Accounts[j].Spend
+	})
+	sort.Slice(response.Failures, func(i, j int) bool {
+		return response.Failures[i].AccountID < response.Failures[j].AccountID
+	})
+
+	return response, nil
+}
+
+// convertAdMetrics retorna uma cópia das métricas com os valores monetários multiplicados pela cotação
+func convertAdMetrics(metrics *domain.AdAccountMetrics, rate float64) *domain.AdAccountMetrics {
+	converted := *metrics
+	if rate == 1 {
+		return &converted
+	}
+
+	converted.Spend = utils.RoundWithTwoDecimalPlace(metrics.Spend * rate)
+	converted.CostPerResult = utils.RoundWithTwoDecimalPlace(metrics.CostPerResult * rate)
+	converted.CPC = utils.RoundWithTwoDecimalPlace(metrics.CPC * rate)
+	converted.CPM = utils.RoundWithTwoDecimalPlace(metrics.CPM * rate)
+
+	converted.Campaigns = make([]*domain.CampaignInsight, 0, len(metrics.Campaigns))
+	for _, campaign := range metrics.Campaigns {
+		campaignCopy := *campaign
+		campaignCopy.Spend = utils.RoundWithTwoDecimalPlace(campaign.Spend * rate)
+		campaignCopy.CostPerResult = utils.RoundWithTwoDecimalPlace(campaign.CostPerResult * rate)
+		campaignCopy.CPC = utils.RoundWithTwoDecimalPlace(campaign.CPC * rate)
+		campaignCopy.CPM = utils.RoundWithTwoDecimalPlace(campaign.CPM * rate)
+		converted.Campaigns = append(converted.Campaigns, &campaignCopy)
+	}
+
+	return &converted
+}
+
+// sumAdMetrics soma as métricas das contas. O alcance é a soma dos alcances de cada conta, sem
+// desduplicar pessoas alcançadas por mais de uma conta.
+func sumAdMetrics(metrics []*domain.AdAccountMetrics, name string) *domain.AdAccountMetrics {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	combined := &domain.AdAccountMetrics{
+		AdAccountInsight: domain.AdAccountInsight{
+			Name:      name,
+			Campaigns: make([]*domain.CampaignInsight, 0),
+		},
+	}
+
+	totalImpression, totalReach, totalResults, totalClicks, totalLinkClicks := 0, 0, 0, 0, 0
+	totalSpend := 0.0
+	for _, m := range metrics {
+		totalImpression += m.Impressions
+		totalReach += m.Reach
+		totalResults += m.Result
+		totalClicks += m.Clicks
+		totalLinkClicks += m.LinkClicks
+		totalSpend += m.Spend
+		combined.Campaigns = append(combined.Campaigns, m.Campaigns...)
+	}
+
+	calculateDerivedMetrics(combined, totalImpression, totalReach, totalResults, totalClicks, totalLinkClicks, totalSpend)
+
+	return combined
+}
@@ -0,0 +1,79 @@
+package insighting
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeAccountInsights map[string]*domain.AdAccountInsightsResponse
+
+func (f fakeAccountInsights) GetAdAccountsByID(accountID string, _ *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+	insights, ok := f[accountID]
+	if !ok {
+		return nil, errors.New("falha no Meta")
+	}
+	return insights, nil
+}
+
+func accountInsights(spend float64, impressions, result int, revenue float64) *domain.AdAccountInsightsResponse {
+	return &domain.AdAccountInsightsResponse{
+		AdAccountMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: spend, Impressions: impressions, Result: result}},
+		SalesMetrics:     map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: revenue, SalesQuantity: 1}},
+	}
+}
+
+func TestBusinessManagerInsightService_GetBusinessManagerInsights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	accountRepo.EXPECT().ListAccountsPage(gomock.Any()).Return([]*domain.AdAccount{
+		{ID: "a1", Name: "Loja 1", BusinessManagerName: "BM"},
+		{ID: "a2", Name: "Loja 2", Currency: "USD", BusinessManagerName: "BM"},
+		{ID: "a3", Name: "Loja 3", BusinessManagerName: "BM"},
+	}, 3, nil).AnyTimes()
+
+	provider := fakeAccountInsights{
+		"a1": accountInsights(100, 1000, 10, 500),
+		"a2": accountInsights(20, 1000, 10, 300),
+	}
+	service := NewBusinessManagerInsightService(provider, accountRepo, &fakeConverter{rate: 5}, 0.25)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	filters := &domain.InsigthFilters{StartDate: &start, EndDate: &end}
+
+	t.Run("soma as contas na moeda do faturamento", func(t *testing.T) {
+		response, err := service.GetBusinessManagerInsights("bm1", filters, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "BM", response.BusinessManagerName)
+		assert.Equal(t, 200.0, response.AdMetrics.Spend)
+		assert.Equal(t, 2000, response.AdMetrics.Impressions)
+		assert.Equal(t, 10.0, response.AdMetrics.CostPerResult)
+		assert.Equal(t, 800.0, response.SalesMetrics[domain.SocialNetwork].TotalRevenue)
+		assert.Equal(t, 4.0, response.ResultMetrics.ROAS)
+		assert.Equal(t, 4.0, response.ResultMetrics.BreakEvenROAS)
+		assert.Len(t, response.Accounts, 2)
+		assert.Len(t, response.Failures, 1)
+		assert.Equal(t, "a3", response.Failures[0].AccountID)
+	})
+
+	t.Run("considera apenas as contas visíveis ao usuário", func(t *testing.T) {
+		response, err := service.GetBusinessManagerInsights("bm1", filters, func(accountID string) bool { return accountID == "a1" })
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, response.AdMetrics.Spend)
+		assert.Empty(t, response.Failures)
+	})
+
+	t.Run("sem contas visíveis o business manager não é encontrado", func(t *testing.T) {
+		_, err := service.GetBusinessManagerInsights("bm1", filters, func(string) bool { return false })
+		assert.ErrorIs(t, err, ErrBusinessManagerNotFound)
+	})
+}
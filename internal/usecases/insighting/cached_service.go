@@ -0,0 +1,439 @@
+package insighting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// CachedService decora o Service usando os insights diários armazenados como cache.
+// As consultas de métricas por conta leem primeiro do banco e só buscam no Meta e no SSOtica as datas
+// ausentes, salvando o resultado. Os demais métodos são delegados ao Service.
+type CachedService struct {
+	*Service
+	adInsightRepository    repository.AdInsightRepository
+	salesInsightRepository repository.SalesInsightRepository
+	intradayRepository     repository.IntradayInsightRepository
+	intradayMaxAge         time.Duration
+	now                    func() time.Time
+}
+
+// CachedOption configura dependências opcionais do CachedService na construção
+type CachedOption func(*CachedService)
+
+// NewCachedService cria o decorador de cache sobre um Service já configurado
+func NewCachedService(
+	service *Service,
+	adInsightRepo repository.AdInsightRepository,
+	salesInsightRepo repository.SalesInsightRepository,
+	opts ...CachedOption,
+) *CachedService {
+	cached := &CachedService{
+		Service:                service,
+		adInsightRepository:    adInsightRepo,
+		salesInsightRepository: salesInsightRepo,
+		now:                    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(cached)
+	}
+
+	return cached
+}
+
+// GetAdAccountsByID obtém todas as métricas (anúncios e vendas) para uma conta específica, usando o cache
+func (s *CachedService) GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+	return s.accountInsights(accountID, filters, s.getAdAccountsByIDWithCache)
+}
+
+// GetAdAccountInsightsByDimension agrupa as campanhas da conta por uma dimensão, usando o cache
+func (s *CachedService) GetAdAccountInsightsByDimension(accountID, dimension string, filters *domain.InsigthFilters) (*domain.DimensionInsightsResponse, error) {
+	return s.insightsByDimension(accountID, dimension, filters, s.GetAdAccountsByID)
+}
+
+// CompareAdAccountInsights obtém os insights do período e do período de comparação equivalente, usando o cache
+func (s *CachedService) CompareAdAccountInsights(accountID, comparison string, filters *domain.InsigthFilters) (*domain.InsightsComparisonResponse, error) {
+	return s.compareInsights(accountID, comparison, filters, s.GetAdAccountsByID)
+}
+
+// getAdAccountsByIDWithCache obtém as métricas da conta dos insights armazenados, buscando nas APIs apenas as datas ausentes
+func (s *CachedService) getAdAccountsByIDWithCache(insights *domain.AdAccountInsightsResponse,
+	account *domain.AdAccount,
+	accountExternalID string,
+	filters *domain.InsigthFilters,
+) (*domain.AdAccountInsightsResponse, error) {
+	// Gerar lista de todas as datas do período solicitado para controle
+	allDates := generateDateRange(filters.StartDate, filters.EndDate, account.TimeLocation())
+	if len(allDates) == 0 {
+		return nil, fmt.Errorf("período de datas inválido")
+	}
+
+	// Variáveis para armazenar os resultados
+	var (
+		adInsights     []*domain.AdInsightEntry
+		salesInsights  []*domain.SalesInsightEntry
+		adInsightError error
+		salesError     error
+	)
+
+	// Usar WaitGroup para esperar as goroutines terminarem
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	// Goroutine para buscar e processar métricas de anúncios
+	go func() {
+		defer wg.Done()
+		adInsights, adInsightError = s.getAdMetricsWithCache(account, accountExternalID, filters, allDates)
+	}()
+
+	// Goroutine para buscar e processar métricas de vendas (apenas se a conta tiver os dados necessários)
+	go func() {
+		defer wg.Done()
+		if account.CNPJ != nil && *account.CNPJ != "" && account.SecretName != nil && *account.SecretName != "" {
+			salesInsights, salesError = s.getSalesMetricsWithCache(account, filters, allDates)
+		}
+	}()
+
+	// Aguardar as goroutines terminarem
+	wg.Wait()
+
+	// Verificar se houve erro nas goroutines
+	if adInsightError != nil {
+		logrus.WithError(adInsightError).Error("Erro ao buscar métricas de anúncios com cache")
+	}
+
+	if salesError != nil {
+		logrus.WithError(salesError).Error("Erro ao buscar métricas de vendas com cache")
+	}
+
+	// Combinar todos os insights de anúncios
+	if len(adInsights) > 0 {
+		// Agregar todas as métricas de anúncios
+		combinedAdMetrics := combineAdMetrics(adInsights)
+		insights.AdAccountMetrics = combinedAdMetrics
+	}
+
+	// Combinar todos os insights de vendas
+	if len(salesInsights) > 0 {
+		// Agregar todas as métricas de vendas
+		combinedSalesMetrics := combineSalesMetrics(salesInsights)
+		insights.SalesMetrics = combinedSalesMetrics
+	}
+
+	// Se conseguimos dados tanto de anúncios quanto de vendas, calcular métricas de resultado
+	exchangeRate := spendExchangeRate(s.currencyConverter, account)
+	if insights.AdAccountMetrics != nil && insights.SalesMetrics != nil && insights.SalesMetrics[domain.SocialNetwork] != nil {
+		insights.ResultMetrics = domain.CalculateResultMetricsWithRate(
+			insights.AdAccountMetrics,
+			insights.SalesMetrics,
+			exchangeRate,
+			s.grossMargin(),
+		)
+	}
+
+	if filters.Aggregation != "" {
+		insights.Series = buildInsightSeries(filters.Aggregation, allDates, adInsights, salesInsights, exchangeRate, s.grossMargin())
+	}
+
+	// Se encontramos dados suficientes, retornar
+	if insights.AdAccountMetrics != nil || insights.SalesMetrics != nil {
+		return insights, nil
+	}
+
+	return insights, nil
+}
+
+// getAdMetricsWithCache busca métricas de anúncios do cache e preenche dados faltantes via API
+func (s *CachedService) getAdMetricsWithCache(
+	account *domain.AdAccount,
+	accountExternalID string,
+	filters *domain.InsigthFilters,
+	allDates []time.Time,
+) ([]*domain.AdInsightEntry, error) {
+	// Mapa para armazenar as datas que já temos no banco
+	existingAdDates := make(map[string]bool)
+
+	// Armazenar os insights encontrados
+	adInsights := make([]*domain.AdInsightEntry, 0)
+
+	// 1. Buscar todos os insights de anúncios para o período completo
+	periodAdInsights, err := s.adInsightRepository.GetByDateRange(
+		account.ID,
+		*filters.StartDate,
+		*filters.EndDate,
+	)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"account_id": account.ID,
+			"start_date": filters.StartDate.Format(time.DateOnly),
+			"end_date":   filters.EndDate.Format(time.DateOnly),
+		}).Warn("Erro ao buscar insights de anúncios do banco de dados para o período")
+	} else {
+		// Adicionar aos insights encontrados e marcar as datas que já temos
+		for _, insight := range periodAdInsights {
+			adInsights = append(adInsights, insight)
+			dateStr := insight.Date.Format(time.DateOnly)
+			existingAdDates[dateStr] = true
+		}
+	}
+
+	// O dia em andamento vem da sincronização intradiária, quando ela tem dados recentes
+	if intraday := s.intradayAdInsight(account, allDates); intraday != nil && !existingAdDates[intraday.Date.Format(time.DateOnly)] {
+		adInsights = append(adInsights, intraday)
+		existingAdDates[intraday.Date.Format(time.DateOnly)] = true
+	}
+
+	// 2. Determinar quais datas estão faltando para buscar das APIs
+	var missingAdDates []time.Time
+
+	for _, date := range allDates {
+		dateStr := date.Format(time.DateOnly)
+
+		// Verificar se temos dados de anúncios para esta data
+		if !existingAdDates[dateStr] {
+			missingAdDates = append(missingAdDates, date)
+		}
+	}
+
+	// 3. Se temos datas faltantes de anúncios, buscá-las da API do Meta
+	if len(missingAdDates) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"account_id":    account.ID,
+			"external_id":   accountExternalID,
+			"missing_dates": len(missingAdDates),
+			"total_dates":   len(allDates),
+			"first_missing": missingAdDates[0].Format(time.DateOnly),
+			"last_missing":  missingAdDates[len(missingAdDates)-1].Format(time.DateOnly),
+		}).Info("Buscando insights de anúncios da API para datas faltantes")
+
+		// Definir o número máximo de goroutines simultâneas
+		const maxConcurrent = 5
+		semaphore := make(chan struct{}, maxConcurrent)
+
+		// Usar WaitGroup para esperar todas as chamadas à API terminarem
+		var fetchWg sync.WaitGroup
+
+		// Mutex para proteger o slice de salesInsights durante atualizações concorrentes
+		var mutex sync.Mutex
+
+		for _, date := range missingAdDates {
+			fetchWg.Add(1)
+
+			// Função para buscar dados para uma data específica
+			go func(date time.Time) {
+				defer fetchWg.Done()
+
+				// Adquirir uma vaga no semáforo
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				dailyFilter := &domain.InsigthFilters{
+					StartDate: &date,
+					EndDate:   &date,
+				}
+
+				logrus.WithFields(logrus.Fields{
+					"account_id":  account.ID,
+					"external_id": accountExternalID,
+					"start_date":  dailyFilter.StartDate.Format(time.DateOnly),
+					"end_date":    dailyFilter.EndDate.Format(time.DateOnly),
+				}).Info("Buscando insights de anúncios da API para datas faltantes")
+
+				// Buscar da API do Meta
+				adMetrics, err := s.metaService.GetAdAccountsInsights(context.Background(), accountExternalID, dailyFilter)
+				if err != nil {
+					logrus.WithError(err).WithFields(logrus.Fields{
+						"account_id":  account.ID,
+						"external_id": accountExternalID,
+						"start_date":  dailyFilter.StartDate.Format(time.DateOnly),
+						"end_date":    dailyFilter.EndDate.Format(time.DateOnly),
+					}).Warn("Erro ao obter insights de anúncios do Meta")
+					return
+				}
+
+				logrus.WithFields(logrus.Fields{
+					"ad_metrics": adMetrics,
+				}).Info("Insights de anúncios obtidos da API do Meta")
+
+				// Criar entrada para o cache
+				adInsight := &domain.AdInsightEntry{
+					AccountID:  account.ID,
+					ExternalID: accountExternalID,
+					Date:       *dailyFilter.StartDate,
+					AdMetrics:  adMetrics,
+				}
+
+				// Só dias já encerrados no fuso da conta são armazenados; o dia atual ainda está parcial
+				if date.Before(account.Today(time.Now())) {
+					err = s.adInsightRepository.SaveOrUpdate(adInsight)
+					if err != nil {
+						logrus.WithError(err).WithFields(logrus.Fields{
+							"account_id": account.ID,
+						}).Warn("Erro ao salvar insights de anúncios no banco de dados")
+					}
+				}
+
+				// Adicionar aos insights encontrados - protegido por mutex
+				mutex.Lock()
+				adInsights = append(adInsights, adInsight)
+				mutex.Unlock()
+			}(date)
+		}
+
+		// Aguardar todas as goroutines terminarem
+		fetchWg.Wait()
+	}
+
+	return adInsights, nil
+}
+
+// getSalesMetricsWithCache busca métricas de vendas do cache e preenche dados faltantes via API
+func (s *CachedService) getSalesMetricsWithCache(
+	account *domain.AdAccount,
+	filters *domain.InsigthFilters,
+	allDates []time.Time,
+) ([]*domain.SalesInsightEntry, error) {
+	// Mapa para armazenar as datas que já temos no banco
+	existingSalesDates := make(map[string]bool)
+
+	// Armazenar os insights encontrados
+	salesInsights := make([]*domain.SalesInsightEntry, 0)
+
+	// 1. Buscar todos os insights de vendas para o período completo
+	periodSalesInsights, err := s.salesInsightRepository.GetByDateRange(
+		account.ID,
+		*filters.StartDate,
+		*filters.EndDate,
+	)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"account_id": account.ID,
+			"start_date": filters.StartDate.Format(time.DateOnly),
+			"end_date":   filters.EndDate.Format(time.DateOnly),
+		}).Warn("Erro ao buscar insights de vendas do banco de dados para o período")
+	} else {
+		// Adicionar aos insights encontrados e marcar as datas que já temos
+		for _, insight := range periodSalesInsights {
+			salesInsights = append(salesInsights, insight)
+			dateStr := insight.Date.Format(time.DateOnly)
+			existingSalesDates[dateStr] = true
+		}
+	}
+
+	// O dia em andamento vem da sincronização intradiária, quando ela tem dados recentes
+	if intraday := s.intradaySalesInsight(account, allDates); intraday != nil && !existingSalesDates[intraday.Date.Format(time.DateOnly)] {
+		salesInsights = append(salesInsights, intraday)
+		existingSalesDates[intraday.Date.Format(time.DateOnly)] = true
+	}
+
+	// 2. Determinar quais datas estão faltando para buscar das APIs
+	var missingSalesDates []time.Time
+
+	for _, date := range allDates {
+		dateStr := date.Format(time.DateOnly)
+
+		// Verificar se temos dados de vendas para esta data
+		if !existingSalesDates[dateStr] {
+			missingSalesDates = append(missingSalesDates, date)
+		}
+	}
+
+	// 3. Se temos datas faltantes de vendas, buscá-las da API do SSOtica
+	if len(missingSalesDates) > 0 && account.CNPJ != nil && *account.CNPJ != "" && account.SecretName != nil && *account.SecretName != "" {
+		logrus.WithFields(logrus.Fields{
+			"account_id":    account.ID,
+			"missing_dates": len(missingSalesDates),
+			"total_dates":   len(allDates),
+			"first_missing": missingSalesDates[0].Format(time.DateOnly),
+			"last_missing":  missingSalesDates[len(missingSalesDates)-1].Format(time.DateOnly),
+		}).Info("Buscando insights de vendas da API para datas faltantes")
+
+		// Definir o número máximo de goroutines simultâneas
+		const maxConcurrent = 5
+		semaphore := make(chan struct{}, maxConcurrent)
+
+		// Usar WaitGroup para esperar todas as chamadas à API terminarem
+		var fetchWg sync.WaitGroup
+
+		// Mutex para proteger o slice de salesInsights durante atualizações concorrentes
+		var mutex sync.Mutex
+
+		// Configurar os parâmetros base para a chamada ao SSOtica
+		params := &ssoticadomain.GetSalesParams{
+			CNPJ:       *account.CNPJ,
+			SecretName: *account.SecretName,
+		}
+
+		// Buscar cada data faltante da API em paralelo
+		for _, date := range missingSalesDates {
+			fetchWg.Add(1)
+
+			// Função para buscar dados para uma data específica
+			go func(date time.Time, baseParams ssoticadomain.GetSalesParams) {
+				defer fetchWg.Done()
+
+				// Adquirir uma vaga no semáforo
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				dailyFilter := &domain.InsigthFilters{
+					StartDate: &date,
+					EndDate:   &date,
+				}
+
+				// Buscar da API do SSOtica
+				salesMetrics, err := s.GetSalesMetrics(context.Background(), *account.CNPJ, *account.SecretName, dailyFilter)
+				if err != nil {
+					logrus.Warn("Erro ao obter dados de vendas do SSOtica", map[string]any{
+						"accountID": account.ID,
+						"error":     err,
+					})
+					return
+				}
+
+				if salesMetrics == nil || len(salesMetrics) == 0 {
+					logrus.Warn("Erro ao obter dados de vendas do SSOtica", map[string]any{
+						"accountID": account.ID,
+						"error":     err,
+					})
+					return
+				}
+
+				// Criar entrada para o cache
+				salesInsight := &domain.SalesInsightEntry{
+					AccountID:    account.ID,
+					Date:         date,
+					SalesMetrics: salesMetrics,
+				}
+
+				// Salvar no cache apenas dias já encerrados no fuso da conta
+				if date.Before(account.Today(time.Now())) {
+					err = s.salesInsightRepository.SaveOrUpdate(salesInsight)
+					if err != nil {
+						logrus.WithError(err).WithFields(logrus.Fields{
+							"account_id": account.ID,
+							"date":       date.Format(time.DateOnly),
+						}).Warn("Erro ao salvar insights de vendas no banco de dados")
+					}
+				}
+
+				// Adicionar aos insights encontrados - protegido por mutex
+				mutex.Lock()
+				salesInsights = append(salesInsights, salesInsight)
+				mutex.Unlock()
+			}(date, *params)
+		}
+
+		// Aguardar todas as goroutines terminarem
+		fetchWg.Wait()
+	}
+
+	return salesInsights, nil
+}
@@ -0,0 +1,55 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedService_GetAdAccountInsightsByDimension(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	salesInsightRepo := mocks.NewMockSalesInsightRepository(ctrl)
+	annotationRepo := mocks.NewMockAnnotationRepository(ctrl)
+
+	cfg := &config.Config{CampaignNaming: config.CampaignNaming{Separator: "|", Dimensions: []string{"loja", "produto"}}}
+
+	// Sem integradores: qualquer consulta fora do cache causaria pânico
+	service := NewService(cfg, nil, nil, accountRepo, WithAnnotations(annotationRepo))
+	cached := NewCachedService(service, adInsightRepo, salesInsightRepo)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	filters := &domain.InsigthFilters{StartDate: &start, EndDate: &end}
+
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "999"}
+	accountRepo.EXPECT().GetAccountByExternalID("999").Return(account, nil)
+	annotationRepo.EXPECT().ListAnnotations("abc123", start, end).Return(nil, nil)
+
+	entry := func(date time.Time, campaign string, spend float64) *domain.AdInsightEntry {
+		return &domain.AdInsightEntry{AccountID: "abc123", Date: date, AdMetrics: &domain.AdAccountMetrics{
+			AdAccountInsight: domain.AdAccountInsight{
+				Spend:     spend,
+				Campaigns: []*domain.CampaignInsight{{CampaignName: campaign, Spend: spend, Impressions: "10", Reach: "5"}},
+			},
+		}}
+	}
+	adInsightRepo.EXPECT().GetByDateRange("abc123", start, end).Return([]*domain.AdInsightEntry{
+		entry(start, "CENTRO | LENTES", 10),
+		entry(end, "NORTE | LENTES", 20),
+	}, nil)
+
+	response, err := cached.GetAdAccountInsightsByDimension("999", "produto", filters)
+	assert.NoError(t, err)
+	assert.Len(t, response.Groups, 1)
+	assert.Equal(t, "LENTES", response.Groups[0].Value)
+	assert.Equal(t, 30.0, response.Groups[0].Spend)
+}
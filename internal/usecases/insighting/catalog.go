@@ -0,0 +1,110 @@
+package insighting
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var ErrAccountNotFound = errors.New("conta não encontrada")
+
+// CampaignCatalogProvider busca as campanhas da conta na origem (Meta)
+type CampaignCatalogProvider interface {
+	GetCampaignCatalog(accountID string) ([]*domain.CampaignCatalogItem, error)
+}
+
+type CampaignCataloger interface {
+	// GetCampaignCatalog aceita o ID interno ou o external_id da conta
+	GetCampaignCatalog(accountID string) (*domain.CampaignCatalogResponse, error)
+}
+
+// CatalogService mantém o catálogo de campanhas de cada conta em memória por ttl,
+// para que os seletores do dashboard não disparem uma consulta ao Meta a cada abertura.
+type CatalogService struct {
+	provider    CampaignCatalogProvider
+	accountRepo repository.AccountRepository
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*catalogEntry
+}
+
+type catalogEntry struct {
+	campaigns []*domain.CampaignCatalogItem
+	cachedAt  time.Time
+}
+
+func NewCatalogService(provider CampaignCatalogProvider, accountRepo repository.AccountRepository, ttl time.Duration) CampaignCataloger {
+	return &CatalogService{
+		provider:    provider,
+		accountRepo: accountRepo,
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[string]*catalogEntry),
+	}
+}
+
+func (s *CatalogService) GetCampaignCatalog(accountID string) (*domain.CampaignCatalogResponse, error) {
+	account, err := findAccount(s.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.load(account.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CampaignCatalogResponse{
+		AccountID: account.ID,
+		Campaigns: entry.campaigns,
+		CachedAt:  entry.cachedAt,
+		Format:    account.FormatMetadata(),
+	}, nil
+}
+
+func (s *CatalogService) load(externalID string) (*catalogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if entry, ok := s.entries[externalID]; ok && now.Sub(entry.cachedAt) < s.ttl {
+		return entry, nil
+	}
+
+	campaigns, err := s.provider.GetCampaignCatalog(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &catalogEntry{campaigns: campaigns, cachedAt: now}
+	s.entries[externalID] = entry
+
+	// Remove entradas expiradas de contas que não foram mais consultadas
+	for key, cached := range s.entries {
+		if now.Sub(cached.cachedAt) >= s.ttl {
+			delete(s.entries, key)
+		}
+	}
+
+	return entry, nil
+}
+
+// findAccount busca a conta pelo ID interno ou pelo external_id
+func findAccount(accountRepo repository.AccountRepository, accountID string) (*domain.AdAccount, error) {
+	account, err := accountRepo.GetAccountByID(accountID)
+	if err == nil && account == nil {
+		account, err = accountRepo.GetAccountByExternalID(accountID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
@@ -0,0 +1,66 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeCatalogProvider struct {
+	calls int
+}
+
+func (f *fakeCatalogProvider) GetCampaignCatalog(accountID string) ([]*domain.CampaignCatalogItem, error) {
+	f.calls++
+	return []*domain.CampaignCatalogItem{{ID: "c1", Name: "CENTRO | LENTES | VENDAS", Status: "ACTIVE"}}, nil
+}
+
+func TestCatalogService_GetCampaignCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	provider := &fakeCatalogProvider{}
+
+	service := NewCatalogService(provider, accountRepo, 5*time.Minute).(*CatalogService)
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "act_1"}
+	accountRepo.EXPECT().GetAccountByID("act_1").Return(nil, nil).AnyTimes()
+	accountRepo.EXPECT().GetAccountByExternalID("act_1").Return(account, nil).AnyTimes()
+	accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil).AnyTimes()
+
+	t.Run("aceita o external_id e usa o cache dentro do ttl", func(t *testing.T) {
+		catalog, err := service.GetCampaignCatalog("act_1")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", catalog.AccountID)
+		assert.Len(t, catalog.Campaigns, 1)
+
+		now = now.Add(time.Minute)
+		catalog, err = service.GetCampaignCatalog("abc123")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, provider.calls)
+		assert.Equal(t, now.Add(-time.Minute), catalog.CachedAt)
+	})
+
+	t.Run("busca novamente após o ttl", func(t *testing.T) {
+		now = now.Add(5 * time.Minute)
+		catalog, err := service.GetCampaignCatalog("abc123")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.calls)
+		assert.Equal(t, now, catalog.CachedAt)
+	})
+
+	t.Run("conta inexistente", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("nope").Return(nil, nil)
+		accountRepo.EXPECT().GetAccountByExternalID("nope").Return(nil, nil)
+
+		_, err := service.GetCampaignCatalog("nope")
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,82 @@
+package insighting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// CompareAdAccountInsights obtém os insights do período e do período de comparação equivalente
+func (s *Service) CompareAdAccountInsights(accountID, comparison string, filters *domain.InsigthFilters) (*domain.InsightsComparisonResponse, error) {
+	return s.compareInsights(accountID, comparison, filters, s.GetAdAccountsByID)
+}
+
+// compareInsights compara os dois períodos com os insights obtidos por getInsights, permitindo que o
+// CachedService reaproveite a comparação com a sua própria consulta
+func (s *Service) compareInsights(
+	accountID, comparison string,
+	filters *domain.InsigthFilters,
+	getInsights func(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error),
+) (*domain.InsightsComparisonResponse, error) {
+	if filters == nil || filters.StartDate == nil || filters.EndDate == nil {
+		return nil, fmt.Errorf("é necessário informar as datas de início e fim")
+	}
+	if comparison == "" {
+		comparison = domain.ComparisonPreviousPeriod
+	}
+
+	previousStart, previousEnd, ok := domain.ComparisonRange(comparison, *filters.StartDate, *filters.EndDate)
+	if !ok {
+		return nil, fmt.Errorf("comparação inválida: %s", comparison)
+	}
+
+	current, err := getInsights(accountID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := getInsights(accountID, &domain.InsigthFilters{
+		StartDate:   &previousStart,
+		EndDate:     &previousEnd,
+		Aggregation: filters.Aggregation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.InsightsComparisonResponse{
+		Comparison: comparison,
+		CurrentPeriod: domain.ReportPeriod{
+			StartDate: filters.StartDate.Format(time.DateOnly),
+			EndDate:   filters.EndDate.Format(time.DateOnly),
+		},
+		PreviousPeriod: domain.ReportPeriod{
+			StartDate: previousStart.Format(time.DateOnly),
+			EndDate:   previousEnd.Format(time.DateOnly),
+		},
+		Current:        current,
+		Previous:       previous,
+		Metrics:        current.MetricValues(),
+		PreviousValues: previous.MetricValues(),
+		Deltas:         make(map[string]*float64, len(domain.ComparisonMetrics)),
+	}
+
+	for _, key := range domain.ComparisonMetrics {
+		response.Metrics[key] = utils.RoundWithTwoDecimalPlace(response.Metrics[key])
+		response.PreviousValues[key] = utils.RoundWithTwoDecimalPlace(response.PreviousValues[key])
+		response.Deltas[key] = percentageDelta(response.Metrics[key], response.PreviousValues[key])
+	}
+
+	return response, nil
+}
+
+// percentageDelta retorna a variação percentual de previous para current; nil quando previous é zero
+func percentageDelta(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	delta := utils.RoundWithTwoDecimalPlace((current - previous) / previous * 100)
+	return &delta
+}
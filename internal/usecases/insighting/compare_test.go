@@ -0,0 +1,50 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestService_CompareInsights(t *testing.T) {
+	service := &Service{}
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	requested := make([]string, 0)
+	getInsights := func(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+		requested = append(requested, filters.StartDate.Format(time.DateOnly)+"/"+filters.EndDate.Format(time.DateOnly))
+
+		spend, revenue := 100.0, 400.0
+		if filters.StartDate.Equal(start) {
+			spend, revenue = 150, 300
+		}
+		return &domain.AdAccountInsightsResponse{
+			AdAccountMetrics: &domain.AdAccountMetrics{
+				AdAccountInsight: domain.AdAccountInsight{Spend: spend, Result: 10},
+			},
+			SalesMetrics: map[string]*domain.SalesMetrics{
+				domain.SocialNetwork: {TotalRevenue: revenue, SalesQuantity: 2},
+			},
+		}, nil
+	}
+
+	response, err := service.compareInsights("act_1", "", &domain.InsigthFilters{StartDate: &start, EndDate: &end}, getInsights)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ComparisonPreviousPeriod, response.Comparison)
+	assert.Equal(t, []string{"2025-03-01/2025-03-10", "2025-02-19/2025-02-28"}, requested)
+	assert.Equal(t, domain.ReportPeriod{StartDate: "2025-02-19", EndDate: "2025-02-28"}, response.PreviousPeriod)
+
+	assert.Equal(t, 50.0, *response.Deltas["spend"])
+	assert.Equal(t, -25.0, *response.Deltas["social_network_revenue"])
+	assert.Equal(t, 2.0, response.Metrics["roi"])
+	assert.Equal(t, -50.0, *response.Deltas["roi"])
+	assert.Equal(t, 0.0, *response.Deltas["result"])
+	// Sem vendas na loja em nenhum dos períodos não há variação
+	assert.Nil(t, response.Deltas["store_revenue"])
+
+	_, err = service.compareInsights("act_1", "next_month", &domain.InsigthFilters{StartDate: &start, EndDate: &end}, getInsights)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package insighting
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// CurrencyConverter fornece a cotação entre duas moedas (ISO 4217)
+type CurrencyConverter interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// WithCurrencyConverter converte o investimento de contas cobradas em outra moeda para a moeda do
+// faturamento antes de calcular as métricas de resultado
+func WithCurrencyConverter(converter CurrencyConverter) Option {
+	return func(s *Service) {
+		s.currencyConverter = converter
+	}
+}
+
+// spendExchangeRate retorna a cotação do investimento da conta para a moeda do faturamento. Sem cotação
+// disponível retorna 0, e as métricas de resultado são omitidas em vez de misturar moedas.
+func spendExchangeRate(converter CurrencyConverter, account *domain.AdAccount) float64 {
+	currency := account.FormatMetadata().Currency
+	if currency == domain.SalesCurrency {
+		return 1
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"account_id": account.ID,
+		"currency":   currency,
+	})
+	if converter == nil {
+		logger.Warn("insights: conta em outra moeda sem conversor de cotações configurado")
+		return 0
+	}
+
+	rate, err := converter.Rate(context.Background(), currency, domain.SalesCurrency)
+	if err != nil {
+		logger.WithError(err).Error("insights: erro ao obter cotação da moeda da conta")
+		return 0
+	}
+	return rate
+}
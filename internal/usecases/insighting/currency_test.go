@@ -0,0 +1,29 @@
+package insighting
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type fakeConverter struct {
+	rate float64
+	err  error
+}
+
+func (f *fakeConverter) Rate(_ context.Context, _, _ string) (float64, error) {
+	return f.rate, f.err
+}
+
+func TestSpendExchangeRate(t *testing.T) {
+	brl := &domain.AdAccount{ID: "a1"}
+	usd := &domain.AdAccount{ID: "a2", Currency: "USD"}
+
+	assert.Equal(t, 1.0, spendExchangeRate(nil, brl))
+	assert.Equal(t, 5.4, spendExchangeRate(&fakeConverter{rate: 5.4}, usd))
+	assert.Equal(t, 0.0, spendExchangeRate(nil, usd))
+	assert.Equal(t, 0.0, spendExchangeRate(&fakeConverter{err: errors.New("indisponível")}, usd))
+}
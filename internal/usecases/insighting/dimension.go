@@ -0,0 +1,111 @@
+package insighting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/naming"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+// UngroupedDimensionValue agrupa as campanhas cujo nome não informa a dimensão pedida
+const UngroupedDimensionValue = "(sem valor)"
+
+var ErrUnknownDimension = errors.New("dimensão não configurada na convenção de nomes das campanhas")
+
+// GetAdAccountInsightsByDimension agrupa as campanhas da conta no período pelo valor de uma dimensão do nome
+func (s *Service) GetAdAccountInsightsByDimension(accountID, dimension string, filters *domain.InsigthFilters) (*domain.DimensionInsightsResponse, error) {
+	return s.insightsByDimension(accountID, dimension, filters, s.GetAdAccountsByID)
+}
+
+// insightsByDimension agrupa as campanhas das métricas obtidas por getInsights, permitindo que o
+// CachedService reaproveite o agrupamento com a sua própria consulta
+func (s *Service) insightsByDimension(
+	accountID, dimension string,
+	filters *domain.InsigthFilters,
+	getInsights func(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error),
+) (*domain.DimensionInsightsResponse, error) {
+	if !s.namingParser.HasDimension(dimension) {
+		return nil, fmt.Errorf("%w: %q (disponíveis: %v)", ErrUnknownDimension, dimension, s.namingParser.Dimensions())
+	}
+
+	insights, err := getInsights(accountID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaigns []*domain.CampaignInsight
+	if insights.AdAccountMetrics != nil {
+		campaigns = insights.AdAccountMetrics.Campaigns
+	}
+
+	return &domain.DimensionInsightsResponse{
+		AccountID: accountID,
+		Dimension: dimension,
+		StartDate: filters.StartDate.Format(time.DateOnly),
+		EndDate:   filters.EndDate.Format(time.DateOnly),
+		Groups:    groupCampaignsByDimension(s.namingParser, campaigns, dimension),
+		Format:    insights.Format,
+	}, nil
+}
+
+// groupCampaignsByDimension soma as métricas por valor da dimensão, do maior para o menor investimento.
+// Campanhas salvas antes da convenção ser configurada têm o nome interpretado no momento da consulta.
+func groupCampaignsByDimension(parser *naming.Parser, campaigns []*domain.CampaignInsight, dimension string) []*domain.DimensionGroup {
+	groupsByValue := make(map[string]*domain.DimensionGroup)
+
+	for _, campaign := range campaigns {
+		dimensions := campaign.Dimensions
+		if dimensions == nil {
+			dimensions = parser.Parse(campaign.CampaignName)
+		}
+
+		value, ok := dimensions[dimension]
+		if !ok {
+			value = UngroupedDimensionValue
+		}
+
+		group, exists := groupsByValue[value]
+		if !exists {
+			group = &domain.DimensionGroup{Value: value}
+			groupsByValue[value] = group
+		}
+
+		group.Campaigns++
+		group.Spend += campaign.Spend
+		group.Result += campaign.Result
+		group.Impressions += atoiOrZero(campaign.Impressions)
+		group.Reach += atoiOrZero(campaign.Reach)
+		group.Clicks += atoiOrZero(campaign.Clicks)
+	}
+
+	groups := make([]*domain.DimensionGroup, 0, len(groupsByValue))
+	for _, group := range groupsByValue {
+		group.Spend = utils.RoundWithTwoDecimalPlace(group.Spend)
+		if group.Result > 0 {
+			group.CostPerResult = utils.RoundWithTwoDecimalPlace(group.Spend / float64(group.Result))
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Spend != groups[j].Spend {
+			return groups[i].Spend > groups[j].Spend
+		}
+		return groups[i].Value < groups[j].Value
+	})
+
+	return groups
+}
+
+func atoiOrZero(value string) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
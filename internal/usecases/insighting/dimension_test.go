@@ -0,0 +1,33 @@
+package insighting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/naming"
+)
+
+func TestGroupCampaignsByDimension(t *testing.T) {
+	parser := naming.NewParser("|", []string{"loja", "produto", "objetivo"})
+
+	campaigns := []*domain.CampaignInsight{
+		{
+			CampaignName: "CENTRO | LENTES | VENDAS",
+			Dimensions:   map[string]string{"loja": "CENTRO", "produto": "LENTES", "objetivo": "VENDAS"},
+			Spend:        100, Result: 10, Impressions: "1000", Reach: "500", Clicks: "20",
+		},
+		// Salva antes da convenção: o nome é interpretado na consulta
+		{CampaignName: "NORTE | LENTES | MENSAGENS", Spend: 50.5, Result: 0, Impressions: "300", Reach: "200", Clicks: "5"},
+		{CampaignName: "NORTE | ARMACOES | VENDAS", Spend: 200, Result: 4, Impressions: "2000", Reach: "900", Clicks: "40"},
+		{CampaignName: "Campanha antiga", Spend: 10, Result: 1, Impressions: "x"},
+	}
+
+	groups := groupCampaignsByDimension(parser, campaigns, "produto")
+
+	assert.Equal(t, []*domain.DimensionGroup{
+		{Value: "ARMACOES", Campaigns: 1, Spend: 200, Impressions: 2000, Reach: 900, Clicks: 40, Result: 4, CostPerResult: 50},
+		{Value: "LENTES", Campaigns: 2, Spend: 150.5, Impressions: 1300, Reach: 700, Clicks: 25, Result: 10, CostPerResult: 15.05},
+		{Value: UngroupedDimensionValue, Campaigns: 1, Spend: 10, Result: 1, CostPerResult: 10},
+	}, groups)
+}
@@ -0,0 +1,144 @@
+package insighting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	ErrInvalidForecastMethod = errors.New("método de projeção inválido")
+	ErrForecastUnavailable   = errors.New("ainda não há dias completos no mês para projetar")
+)
+
+type Forecaster interface {
+	// ForecastMonth aceita o ID interno ou o external_id da conta
+	ForecastMonth(accountID, method string) (*domain.MonthForecast, error)
+}
+
+// ForecastService projeta o fechamento do mês a partir dos insights diários armazenados
+type ForecastService struct {
+	accountRepo      repository.AccountRepository
+	adInsightRepo    repository.AdInsightRepository
+	salesInsightRepo repository.SalesInsightRepository
+	goalRepo         repository.GoalRepository
+	storeRankingRepo repository.StoreRankingRepository
+	now              func() time.Time
+}
+
+func NewForecastService(
+	accountRepo repository.AccountRepository,
+	adInsightRepo repository.AdInsightRepository,
+	salesInsightRepo repository.SalesInsightRepository,
+	goalRepo repository.GoalRepository,
+	storeRankingRepo repository.StoreRankingRepository,
+) Forecaster {
+	return &ForecastService{
+		accountRepo:      accountRepo,
+		adInsightRepo:    adInsightRepo,
+		salesInsightRepo: salesInsightRepo,
+		goalRepo:         goalRepo,
+		storeRankingRepo: storeRankingRepo,
+		now:              time.Now,
+	}
+}
+
+// ForecastMonth usa os dias completos do mês no fuso da conta (do dia 1 até ontem). Dias sem insight
+// armazenado contam como zero.
+func (s *ForecastService) ForecastMonth(accountID, method string) (*domain.MonthForecast, error) {
+	if method == "" {
+		method = domain.ForecastMethodLinear
+	}
+	if !domain.IsValidForecastMethod(method) {
+		return nil, fmt.Errorf("%w: use %s ou %s", ErrInvalidForecastMethod, domain.ForecastMethodLinear, domain.ForecastMethodMovingAverage)
+	}
+
+	account, err := findAccount(s.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := account.Today(s.now())
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	daysElapsed := today.Day() - 1
+	if daysElapsed == 0 {
+		return nil, ErrForecastUnavailable
+	}
+	lastDay := today.AddDate(0, 0, -1)
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+	adEntries, err := s.adInsightRepo.GetByDateRange(account.ID, monthStart, lastDay)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights diários de anúncios: %w", err)
+	}
+	salesEntries, err := s.salesInsightRepo.GetByDateRange(account.ID, monthStart, lastDay)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights diários de vendas: %w", err)
+	}
+
+	spendDaily := make([]float64, daysElapsed)
+	for _, entry := range adEntries {
+		if entry == nil || entry.AdMetrics == nil || !sameMonth(entry.Date, monthStart) || entry.Date.Day() > daysElapsed {
+			continue
+		}
+		spendDaily[entry.Date.Day()-1] = entry.AdMetrics.Spend
+	}
+
+	revenueDaily := make([]float64, daysElapsed)
+	for _, entry := range salesEntries {
+		if entry == nil || !sameMonth(entry.Date, monthStart) || entry.Date.Day() > daysElapsed {
+			continue
+		}
+		if social := entry.SalesMetrics[domain.SocialNetwork]; social != nil {
+			revenueDaily[entry.Date.Day()-1] = social.TotalRevenue
+		}
+	}
+
+	period := monthStart.Format(domain.GoalPeriodFormat)
+	forecast := &domain.MonthForecast{
+		AccountID:            account.ID,
+		Period:               period,
+		Method:               method,
+		DaysElapsed:          daysElapsed,
+		DaysInMonth:          daysInMonth,
+		SocialNetworkRevenue: domain.ForecastMetric(revenueDaily, daysInMonth, method),
+		Spend:                domain.ForecastMetric(spendDaily, daysInMonth, method),
+		Format:               account.FormatMetadata(),
+	}
+
+	logger := logrus.WithField("account_id", account.ID)
+
+	if s.goalRepo != nil {
+		goal, err := s.goalRepo.GetGoal(account.ID, monthStart)
+		if err != nil {
+			logger.WithError(err).Warn("forecast: erro ao buscar meta do mês")
+		} else if goal != nil {
+			forecast.ApplyRevenueTarget(goal.RevenueTarget)
+		}
+	}
+
+	if s.storeRankingRepo != nil {
+		storeRanking, err := s.storeRankingRepo.GetStoreRanking()
+		if err != nil {
+			logger.WithError(err).Warn("forecast: erro ao buscar ranking de lojas")
+		} else if storeRanking != nil {
+			monthRanking := make([]domain.StoreRankingItem, 0, len(storeRanking.Ranking))
+			for _, item := range storeRanking.Ranking {
+				if item.Month == period {
+					monthRanking = append(monthRanking, item)
+				}
+			}
+			forecast.Ranking = domain.ForecastRankingPosition(monthRanking, account.ID, forecast.SocialNetworkRevenue.Projected, daysElapsed, daysInMonth)
+		}
+	}
+
+	return forecast, nil
+}
+
+func sameMonth(date, monthStart time.Time) bool {
+	return date.Year() == monthStart.Year() && date.Month() == monthStart.Month()
+}
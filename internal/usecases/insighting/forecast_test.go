@@ -0,0 +1,75 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestForecastService_ForecastMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	salesInsightRepo := mocks.NewMockSalesInsightRepository(ctrl)
+	goalRepo := mocks.NewMockGoalRepository(ctrl)
+	storeRankingRepo := mocks.NewMockStoreRankingRepository(ctrl)
+
+	service := NewForecastService(accountRepo, adInsightRepo, salesInsightRepo, goalRepo, storeRankingRepo).(*ForecastService)
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "act_1", Timezone: "UTC"}
+
+	t.Run("método inválido", func(t *testing.T) {
+		_, err := service.ForecastMonth("abc123", "exponential")
+		assert.ErrorIs(t, err, ErrInvalidForecastMethod)
+	})
+
+	t.Run("primeiro dia do mês sem dias completos", func(t *testing.T) {
+		service.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+		accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil)
+
+		_, err := service.ForecastMonth("abc123", "")
+		assert.ErrorIs(t, err, ErrForecastUnavailable)
+	})
+
+	t.Run("projeta faturamento, investimento e meta", func(t *testing.T) {
+		service.now = func() time.Time { return time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC) }
+		accountRepo.EXPECT().GetAccountByID("abc123").Return(account, nil)
+
+		adEntries := make([]*domain.AdInsightEntry, 0, 3)
+		salesEntries := make([]*domain.SalesInsightEntry, 0, 3)
+		for day := 1; day <= 3; day++ {
+			date := time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC)
+			adEntries = append(adEntries, &domain.AdInsightEntry{
+				Date:      date,
+				AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 10}},
+			})
+			salesEntries = append(salesEntries, &domain.SalesInsightEntry{
+				Date:         date,
+				SalesMetrics: map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: 100}},
+			})
+		}
+		adInsightRepo.EXPECT().GetByDateRange("abc123", gomock.Any(), gomock.Any()).Return(adEntries, nil)
+		salesInsightRepo.EXPECT().GetByDateRange("abc123", gomock.Any(), gomock.Any()).Return(salesEntries, nil)
+
+		target := 6000.0
+		goalRepo.EXPECT().GetGoal("abc123", gomock.Any()).Return(&domain.AccountGoal{RevenueTarget: &target}, nil)
+		storeRankingRepo.EXPECT().GetStoreRanking().Return(&domain.StoreRankingResponse{Ranking: []domain.StoreRankingItem{
+			{AccountID: "abc123", Month: "06-2025", Position: 1, SocialNetworkRevenue: 300},
+		}}, nil)
+
+		forecast, err := service.ForecastMonth("abc123", domain.ForecastMethodMovingAverage)
+		assert.NoError(t, err)
+		assert.Equal(t, "06-2025", forecast.Period)
+		assert.Equal(t, 3, forecast.DaysElapsed)
+		assert.Equal(t, 30, forecast.DaysInMonth)
+		assert.Equal(t, 3000.0, forecast.SocialNetworkRevenue.Projected)
+		assert.Equal(t, 300.0, forecast.Spend.Projected)
+		assert.Equal(t, 50.0, *forecast.ProjectedRevenueAttainment)
+		assert.Equal(t, 1, forecast.Ranking.Position)
+	})
+}
@@ -1,29 +1,41 @@
 package insighting
 
 import (
+	"context"
+
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
 )
 
 // MetaInsighter define a interface para obter métricas de anúncios do Meta
 type MetaInsighter interface {
 	// GetAdAccountMetrics obtém as métricas de anúncios para uma conta específica
-	GetAdAccountMetrics(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error)
+	GetAdAccountMetrics(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error)
+
+	// GetAdAccountDailySnapshots obtém o resumo diário da conta no período, usado pela sincronização incremental
+	GetAdAccountDailySnapshots(ctx context.Context, accountID string, filters *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error)
 }
 
 // SSOticaInsighter define a interface para obter métricas de vendas do SSOtica
 type SSOticaInsighter interface {
 	// GetSalesMetrics obtém as métricas de vendas para uma conta específica
-	GetSalesMetrics(cnpj string, secretName string, filters *domain.InsigthFilters) (map[string]*domain.SalesMetrics, error)
+	GetSalesMetrics(ctx context.Context, cnpj string, secretName string, filters *domain.InsigthFilters) (map[string]*domain.SalesMetrics, error)
 }
 
 // CombinedInsighter é a interface completa que combina as funcionalidades do Meta e SSOtica
 type CombinedInsighter interface {
 	MetaInsighter
 	SSOticaInsighter
+	BudgetPacer
 
 	// GetAdAccountsByID obtém todas as métricas (anúncios e vendas) para uma conta específica
 	GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error)
 
+	// GetAdAccountInsightsByDimension agrupa as campanhas da conta por uma dimensão da convenção de nomes
+	GetAdAccountInsightsByDimension(accountID, dimension string, filters *domain.InsigthFilters) (*domain.DimensionInsightsResponse, error)
+
+	// CompareAdAccountInsights compara os insights do período com o período anterior (previous_period) ou o ano anterior (previous_year)
+	CompareAdAccountInsights(accountID, comparison string, filters *domain.InsigthFilters) (*domain.InsightsComparisonResponse, error)
+
 	// GetAdAccountReachImpressions obtém apenas Reach e Impressions de uma conta específica
 	GetAdAccountReachImpressions(accountID string, filters *domain.InsigthFilters) (*domain.ReachImpressionsResponse, error)
 
@@ -0,0 +1,73 @@
+package insighting
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// WithIntradayInsights faz as consultas usarem, para o dia em andamento, as métricas parciais gravadas pela
+// sincronização intradiária. Dados mais antigos que maxAge são ignorados e o dia é buscado nas APIs.
+func WithIntradayInsights(repo repository.IntradayInsightRepository, maxAge time.Duration) CachedOption {
+	return func(s *CachedService) {
+		s.intradayRepository = repo
+		s.intradayMaxAge = maxAge
+	}
+}
+
+// intradayDate retorna o dia em andamento da conta, se ele fizer parte do período consultado
+func (s *CachedService) intradayDate(account *domain.AdAccount, allDates []time.Time) (time.Time, bool) {
+	if s.intradayRepository == nil || len(allDates) == 0 {
+		return time.Time{}, false
+	}
+
+	today := account.Today(s.now())
+	last := allDates[len(allDates)-1]
+	if today.Before(allDates[0]) || today.After(last) {
+		return time.Time{}, false
+	}
+	return today, true
+}
+
+// intradayFresh informa se os dados parciais ainda podem ser usados
+func (s *CachedService) intradayFresh(syncedAt time.Time) bool {
+	return s.intradayMaxAge <= 0 || s.now().Sub(syncedAt) <= s.intradayMaxAge
+}
+
+// intradayAdInsight retorna as métricas do Meta do dia em andamento, quando recentes
+func (s *CachedService) intradayAdInsight(account *domain.AdAccount, allDates []time.Time) *domain.AdInsightEntry {
+	today, ok := s.intradayDate(account, allDates)
+	if !ok {
+		return nil
+	}
+
+	insight, err := s.intradayRepository.GetAdInsight(account.ID, today)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", account.ID).Warn("Erro ao buscar insights intradiários de anúncios")
+		return nil
+	}
+	if insight == nil || !s.intradayFresh(insight.UpdatedAt) {
+		return nil
+	}
+	return insight
+}
+
+// intradaySalesInsight retorna as métricas do SSOtica do dia em andamento, quando recentes
+func (s *CachedService) intradaySalesInsight(account *domain.AdAccount, allDates []time.Time) *domain.SalesInsightEntry {
+	today, ok := s.intradayDate(account, allDates)
+	if !ok {
+		return nil
+	}
+
+	insight, err := s.intradayRepository.GetSalesInsight(account.ID, today)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", account.ID).Warn("Erro ao buscar insights intradiários de vendas")
+		return nil
+	}
+	if insight == nil || !s.intradayFresh(insight.UpdatedAt) {
+		return nil
+	}
+	return insight
+}
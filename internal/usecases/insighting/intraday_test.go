@@ -0,0 +1,64 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedService_Intraday(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	salesInsightRepo := mocks.NewMockSalesInsightRepository(ctrl)
+	intradayRepo := mocks.NewMockIntradayInsightRepository(ctrl)
+
+	// Sem integradores: qualquer consulta fora do cache causaria pânico
+	service := NewService(&config.Config{}, nil, nil, nil)
+	cached := NewCachedService(service, adInsightRepo, salesInsightRepo, WithIntradayInsights(intradayRepo, 2*time.Hour))
+	now := time.Date(2025, 3, 2, 15, 0, 0, 0, time.UTC)
+	cached.now = func() time.Time { return now }
+
+	account := &domain.AdAccount{ID: "abc123", ExternalID: "999"}
+	yesterday := time.Date(2025, 3, 1, 0, 0, 0, 0, account.TimeLocation())
+	today := yesterday.AddDate(0, 0, 1)
+	filters := &domain.InsigthFilters{StartDate: &yesterday, EndDate: &today}
+	allDates := []time.Time{yesterday, today}
+
+	t.Run("combina o dia em andamento com os insights diários", func(t *testing.T) {
+		adInsightRepo.EXPECT().GetByDateRange("abc123", yesterday, today).Return([]*domain.AdInsightEntry{
+			{AccountID: "abc123", Date: yesterday, AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 100}}},
+		}, nil)
+		intradayRepo.EXPECT().GetAdInsight("abc123", today).Return(&domain.AdInsightEntry{
+			AccountID: "abc123",
+			Date:      today,
+			AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 40}},
+			UpdatedAt: now.Add(-30 * time.Minute),
+		}, nil)
+
+		insights, err := cached.getAdMetricsWithCache(account, "999", filters, allDates)
+		assert.NoError(t, err)
+		assert.Len(t, insights, 2)
+		assert.Equal(t, 140.0, combineAdMetrics(insights).Spend)
+	})
+
+	t.Run("ignora dados antigos", func(t *testing.T) {
+		intradayRepo.EXPECT().GetSalesInsight("abc123", today).Return(&domain.SalesInsightEntry{
+			AccountID: "abc123",
+			Date:      today,
+			UpdatedAt: now.Add(-3 * time.Hour),
+		}, nil)
+
+		assert.Nil(t, cached.intradaySalesInsight(account, allDates))
+	})
+
+	t.Run("período sem o dia em andamento", func(t *testing.T) {
+		assert.Nil(t, cached.intradayAdInsight(account, allDates[:1]))
+	})
+}
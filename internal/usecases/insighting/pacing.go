@@ -0,0 +1,74 @@
+package insighting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	// ErrNoSpendBudget indica que a conta não tem orçamento definido nas metas do mês corrente
+	ErrNoSpendBudget = errors.New("conta sem orçamento definido para o mês")
+	// ErrPacingUnavailable indica que o serviço foi criado sem o repositório de metas
+	ErrPacingUnavailable = errors.New("acompanhamento de orçamento não está disponível")
+)
+
+type BudgetPacer interface {
+	// GetBudgetPacing calcula o ritmo do investimento do mês corrente da conta, no fuso da conta.
+	// Aceita o ID interno ou o external_id.
+	GetBudgetPacing(ctx context.Context, accountID string) (*domain.BudgetPacing, error)
+}
+
+// GetBudgetPacing compara o investimento do mês até hoje, obtido no Meta, com o orçamento das metas
+func (s *Service) GetBudgetPacing(ctx context.Context, accountID string) (*domain.BudgetPacing, error) {
+	if s.goalRepository == nil {
+		return nil, ErrPacingUnavailable
+	}
+
+	account, err := findAccount(s.accountRepository, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := account.Today(time.Now())
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+
+	goal, err := s.goalRepository.GetGoal(account.ID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil || goal.SpendBudget == nil {
+		return nil, ErrNoSpendBudget
+	}
+
+	exchangeRate := spendExchangeRate(s.currencyConverter, account)
+	if exchangeRate <= 0 {
+		return nil, fmt.Errorf("cotação de %s indisponível para comparar o investimento com o orçamento", account.FormatMetadata().Currency)
+	}
+
+	metrics, err := s.metaService.GetAdAccountsInsights(ctx, account.ExternalID, &domain.InsigthFilters{
+		StartDate: &monthStart,
+		EndDate:   &today,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var spend float64
+	if metrics != nil {
+		spend = metrics.Spend * exchangeRate
+	}
+
+	return domain.CalculateBudgetPacing(*goal.SpendBudget, spend, today, s.pacingTolerance()), nil
+}
+
+// pacingTolerance retorna a variação aceita em relação ao investimento esperado
+func (s *Service) pacingTolerance() float64 {
+	if s.cfg == nil || s.cfg.BudgetPacing.Tolerance <= 0 {
+		return domain.DefaultPacingTolerance
+	}
+	return s.cfg.BudgetPacing.Tolerance
+}
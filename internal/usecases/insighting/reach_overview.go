@@ -0,0 +1,154 @@
+package insighting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const defaultReachOverviewConcurrency = 5
+
+// ReachImpressionsProvider busca Reach e Impressions de uma conta na origem (Meta) pelo external_id
+type ReachImpressionsProvider interface {
+	GetAdAccountReachImpressions(accountID string, filters *domain.InsigthFilters) (*domain.ReachImpressionsResponse, error)
+}
+
+type ReachOverviewer interface {
+	// GetReachImpressionsOverview consulta as contas informadas em paralelo para um período padrão (ex: last_30_days)
+	GetReachImpressionsOverview(accounts []*domain.AdAccountResponse, period string) (*domain.ReachImpressionsOverview, error)
+}
+
+// ReachOverviewService mantém o Reach e as Impressions de cada conta e período em memória por ttl,
+// para que a visão geral não dispare uma consulta ao Meta por conta a cada abertura.
+type ReachOverviewService struct {
+	provider    ReachImpressionsProvider
+	ttl         time.Duration
+	concurrency int
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*reachEntry
+}
+
+type reachEntry struct {
+	metrics  *domain.ReachImpressionsResponse
+	cachedAt time.Time
+}
+
+func NewReachOverviewService(provider ReachImpressionsProvider, ttl time.Duration, concurrency int) ReachOverviewer {
+	if concurrency <= 0 {
+		concurrency = defaultReachOverviewConcurrency
+	}
+
+	return &ReachOverviewService{
+		provider:    provider,
+		ttl:         ttl,
+		concurrency: concurrency,
+		now:         time.Now,
+		entries:     make(map[string]*reachEntry),
+	}
+}
+
+func (s *ReachOverviewService) GetReachImpressionsOverview(accounts []*domain.AdAccountResponse, period string) (*domain.ReachImpressionsOverview, error) {
+	now := s.now()
+	start, end, err := domain.ResolvePeriodPreset(period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &domain.ReachImpressionsOverview{
+		Period:      period,
+		StartDate:   start.Format(time.DateOnly),
+		EndDate:     end.Format(time.DateOnly),
+		Accounts:    make([]*domain.ReachImpressionsResponse, 0, len(accounts)),
+		GeneratedAt: now,
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, s.concurrency)
+
+	for _, account := range accounts {
+		if account == nil || account.ExternalID == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(account *domain.AdAccountResponse) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			metrics, err := s.load(account.ExternalID, start, end)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				logrus.WithError(err).WithField("account_id", account.ID).Warn("Erro ao obter Reach e Impressions da conta para a visão geral")
+				overview.Failures = append(overview.Failures, &domain.ReachImpressionsFailure{
+					AccountID:   account.ID,
+					AccountName: account.Name,
+					Error:       err.Error(),
+				})
+				return
+			}
+
+			// A resposta da Meta traz o external_id; a visão geral identifica as contas pelo ID interno
+			item := *metrics
+			item.AccountID = account.ID
+			if item.AccountName == "" {
+				item.AccountName = account.Name
+			}
+			overview.Accounts = append(overview.Accounts, &item)
+			overview.TotalImpressions += item.Impressions
+		}(account)
+	}
+
+	wg.Wait()
+
+	sort.Slice(overview.Accounts, func(i, j int) bool {
+		return overview.Accounts[i].Impressions > overview.Accounts[j].Impressions
+	})
+	sort.Slice(overview.Failures, func(i, j int) bool {
+		return overview.Failures[i].AccountID < overview.Failures[j].AccountID
+	})
+
+	return overview, nil
+}
+
+func (s *ReachOverviewService) load(externalID string, start, end time.Time) (*domain.ReachImpressionsResponse, error) {
+	key := fmt.Sprintf("%s:%s:%s", externalID, start.Format(time.DateOnly), end.Format(time.DateOnly))
+
+	s.mu.Lock()
+	if entry, ok := s.entries[key]; ok && s.now().Sub(entry.cachedAt) < s.ttl {
+		s.mu.Unlock()
+		return entry.metrics, nil
+	}
+	s.mu.Unlock()
+
+	// A consulta ao Meta fica fora do lock para que as contas sejam buscadas em paralelo
+	metrics, err := s.provider.GetAdAccountReachImpressions(externalID, &domain.InsigthFilters{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.entries[key] = &reachEntry{metrics: metrics, cachedAt: now}
+
+	// Remove entradas expiradas de contas e períodos que não foram mais consultados
+	for key, cached := range s.entries {
+		if now.Sub(cached.cachedAt) >= s.ttl {
+			delete(s.entries, key)
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,83 @@
+package insighting
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type fakeReachProvider struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *fakeReachProvider) GetAdAccountReachImpressions(accountID string, filters *domain.InsigthFilters) (*domain.ReachImpressionsResponse, error) {
+	f.mu.Lock()
+	f.calls[accountID]++
+	f.mu.Unlock()
+
+	if accountID == "act_erro" {
+		return nil, errors.New("token expirado")
+	}
+
+	impressions := map[string]int{"act_1": 1000, "act_2": 3000}[accountID]
+	return &domain.ReachImpressionsResponse{
+		AccountID:   accountID,
+		Reach:       impressions / 2,
+		Impressions: impressions,
+		StartDate:   filters.StartDate.Format(time.DateOnly),
+		EndDate:     filters.EndDate.Format(time.DateOnly),
+	}, nil
+}
+
+func TestReachOverviewService_GetReachImpressionsOverview(t *testing.T) {
+	provider := &fakeReachProvider{calls: map[string]int{}}
+	service := NewReachOverviewService(provider, 15*time.Minute, 2).(*ReachOverviewService)
+	now := time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	accounts := []*domain.AdAccountResponse{
+		{ID: "a1", Name: "Loja Centro", ExternalID: "act_1"},
+		{ID: "a2", Name: "Loja Norte", ExternalID: "act_2"},
+		{ID: "a3", Name: "Loja Sul", ExternalID: "act_erro"},
+		{ID: "a4", Name: "Sem Meta"},
+	}
+
+	t.Run("consulta todas as contas e separa as falhas", func(t *testing.T) {
+		overview, err := service.GetReachImpressionsOverview(accounts, domain.PeriodLast7Days)
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-03-25", overview.StartDate)
+		assert.Equal(t, "2025-03-31", overview.EndDate)
+
+		assert.Len(t, overview.Accounts, 2)
+		assert.Equal(t, "a2", overview.Accounts[0].AccountID)
+		assert.Equal(t, "Loja Norte", overview.Accounts[0].AccountName)
+		assert.Equal(t, 4000, overview.TotalImpressions)
+
+		assert.Len(t, overview.Failures, 1)
+		assert.Equal(t, "a3", overview.Failures[0].AccountID)
+	})
+
+	t.Run("usa o cache dentro do ttl e busca novamente após", func(t *testing.T) {
+		now = now.Add(5 * time.Minute)
+		_, err := service.GetReachImpressionsOverview(accounts, domain.PeriodLast7Days)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, provider.calls["act_1"])
+		// Falhas não são armazenadas no cache
+		assert.Equal(t, 2, provider.calls["act_erro"])
+
+		now = now.Add(15 * time.Minute)
+		_, err = service.GetReachImpressionsOverview(accounts, domain.PeriodLast7Days)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.calls["act_1"])
+	})
+
+	t.Run("período desconhecido", func(t *testing.T) {
+		_, err := service.GetReachImpressionsOverview(accounts, "yesterday")
+		assert.Error(t, err)
+	})
+}
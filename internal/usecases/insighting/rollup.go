@@ -0,0 +1,180 @@
+package insighting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// maxRollupYears limita o intervalo consultado de uma vez nos endpoints trimestral e anual
+const maxRollupYears = 10
+
+var ErrInvalidRollupRange = errors.New("intervalo de anos inválido")
+
+type RollupInsighter interface {
+	// GetRollupInsights retorna os trimestres ou anos da conta entre startYear e endYear
+	GetRollupInsights(accountID, granularity string, startYear, endYear int) ([]*domain.RollupInsightReport, error)
+	// RebuildAccountRollups recalcula, a partir dos insights mensais, o trimestre e o ano que contêm a data
+	RebuildAccountRollups(account *domain.AdAccount, date time.Time) error
+}
+
+// RollupService consolida os insights mensais em trimestres e anos, para que relatórios de longo prazo
+// não precisem agregar centenas de linhas diárias a cada requisição.
+type RollupService struct {
+	rollupRepo              repository.RollupInsightRepository
+	monthlyAdInsightRepo    repository.MonthlyAdInsightRepository
+	monthlySalesInsightRepo repository.MonthlySalesInsightRepository
+	accountRepo             repository.AccountRepository
+	currencyConverter       CurrencyConverter
+	grossMargin             float64
+}
+
+func NewRollupService(
+	rollupRepo repository.RollupInsightRepository,
+	monthlyAdInsightRepo repository.MonthlyAdInsightRepository,
+	monthlySalesInsightRepo repository.MonthlySalesInsightRepository,
+	accountRepo repository.AccountRepository,
+	currencyConverter CurrencyConverter,
+	grossMargin float64,
+) RollupInsighter {
+	return &RollupService{
+		rollupRepo:              rollupRepo,
+		monthlyAdInsightRepo:    monthlyAdInsightRepo,
+		monthlySalesInsightRepo: monthlySalesInsightRepo,
+		accountRepo:             accountRepo,
+		currencyConverter:       currencyConverter,
+		grossMargin:             grossMargin,
+	}
+}
+
+func (s *RollupService) GetRollupInsights(accountID, granularity string, startYear, endYear int) ([]*domain.RollupInsightReport, error) {
+	if startYear > endYear {
+		return nil, fmt.Errorf("%w: o ano inicial não pode ser posterior ao ano final", ErrInvalidRollupRange)
+	}
+	if endYear-startYear+1 > maxRollupYears {
+		return nil, fmt.Errorf("%w: o intervalo máximo é de %d anos", ErrInvalidRollupRange, maxRollupYears)
+	}
+
+	account, err := findAccount(s.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Date(startYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(endYear, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	entries, err := s.rollupRepo.GetByRange(account.ID, granularity, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate := spendExchangeRate(s.currencyConverter, account)
+	reports := make([]*domain.RollupInsightReport, 0, len(entries))
+	for _, entry := range entries {
+		report := &domain.RollupInsightReport{
+			AccountID:    entry.AccountID,
+			Granularity:  entry.Granularity,
+			Period:       entry.Period,
+			StartDate:    entry.StartDate.Format(time.DateOnly),
+			EndDate:      entry.EndDate.Format(time.DateOnly),
+			Months:       entry.Months,
+			AdMetrics:    entry.AdMetrics,
+			SalesMetrics: entry.SalesMetrics,
+			Format:       account.FormatMetadata(),
+		}
+		if report.AdMetrics != nil {
+			report.AdMetrics.TranslateObjectives()
+		}
+		if report.AdMetrics != nil && report.SalesMetrics != nil {
+			report.ResultMetrics = domain.CalculateResultMetricsWithRate(report.AdMetrics, report.SalesMetrics, exchangeRate, s.grossMargin)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (s *RollupService) RebuildAccountRollups(account *domain.AdAccount, date time.Time) error {
+	_, yearStart, yearEnd := domain.YearPeriod(date)
+
+	adEntries, err := s.monthlyAdInsightRepo.GetByPeriodRange(account.ID, yearStart, yearEnd)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar insights mensais de anúncios: %w", err)
+	}
+	salesEntries, err := s.monthlySalesInsightRepo.GetByPeriodRange(account.ID, yearStart, yearEnd)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar insights mensais de vendas: %w", err)
+	}
+
+	quarter, quarterStart, quarterEnd := domain.QuarterPeriod(date)
+	year, _, _ := domain.YearPeriod(date)
+
+	rollups := []*domain.RollupInsightEntry{
+		buildRollup(account.ID, domain.RollupQuarter, quarter, quarterStart, quarterEnd, adEntries, salesEntries),
+		buildRollup(account.ID, domain.RollupYear, year, yearStart, yearEnd, adEntries, salesEntries),
+	}
+
+	for _, rollup := range rollups {
+		if rollup == nil {
+			continue
+		}
+		if err := s.rollupRepo.SaveOrUpdate(rollup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildRollup consolida os meses (período mm-yyyy) que caem entre start e end.
+// Retorna nil quando não há nenhum mês com dados no período.
+func buildRollup(
+	accountID, granularity, period string,
+	start, end time.Time,
+	adEntries []*domain.MonthlyAdInsightEntry,
+	salesEntries []*domain.MonthlySalesInsightEntry,
+) *domain.RollupInsightEntry {
+	months := make(map[string]bool)
+
+	adMetrics := make([]*domain.AdAccountMetrics, 0)
+	for _, entry := range adEntries {
+		if monthInRange(entry.Period, start, end) {
+			adMetrics = append(adMetrics, entry.AdMetrics)
+			months[entry.Period] = true
+		}
+	}
+
+	salesMetrics := make([]map[string]*domain.SalesMetrics, 0)
+	for _, entry := range salesEntries {
+		if monthInRange(entry.Period, start, end) {
+			salesMetrics = append(salesMetrics, entry.SalesMetrics)
+			months[entry.Period] = true
+		}
+	}
+
+	if len(months) == 0 {
+		return nil
+	}
+
+	return &domain.RollupInsightEntry{
+		AccountID:    accountID,
+		Granularity:  granularity,
+		Period:       period,
+		StartDate:    start,
+		EndDate:      end,
+		Months:       len(months),
+		AdMetrics:    domain.RollupAdMetrics(adMetrics),
+		SalesMetrics: domain.RollupSalesMetrics(salesMetrics),
+	}
+}
+
+func monthInRange(period string, start, end time.Time) bool {
+	month, err := time.Parse("01-2006", period)
+	if err != nil {
+		return false
+	}
+	return !month.Before(start) && !month.After(end)
+}
@@ -0,0 +1,51 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestBuildRollup(t *testing.T) {
+	adEntries := []*domain.MonthlyAdInsightEntry{
+		{Period: "01-2025", AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 100, Result: 10, Impressions: 1000, Reach: 400}}},
+		{Period: "02-2025", AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 50.5, Result: 5, Impressions: 600, Reach: 200}}},
+		{Period: "04-2025", AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 80, Result: 0, Impressions: 500, Reach: 250}}},
+	}
+	salesEntries := []*domain.MonthlySalesInsightEntry{
+		{Period: "01-2025", SalesMetrics: map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: 1000, SalesQuantity: 4}}},
+		{Period: "03-2025", SalesMetrics: map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: 500, SalesQuantity: 1}}},
+	}
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("trimestre", func(t *testing.T) {
+		period, start, end := domain.QuarterPeriod(date)
+		assert.Equal(t, "Q1-2025", period)
+		assert.Equal(t, "2025-03-31", end.Format(time.DateOnly))
+
+		rollup := buildRollup("abc123", domain.RollupQuarter, period, start, end, adEntries, salesEntries)
+		assert.Equal(t, 3, rollup.Months)
+		assert.Equal(t, 150.5, rollup.AdMetrics.Spend)
+		assert.Equal(t, 15, rollup.AdMetrics.Result)
+		assert.Equal(t, 10.03, rollup.AdMetrics.CostPerResult)
+		assert.Equal(t, 2.67, rollup.AdMetrics.Frequency)
+		assert.Equal(t, 1500.0, rollup.SalesMetrics[domain.SocialNetwork].TotalRevenue)
+		assert.Equal(t, 300.0, rollup.SalesMetrics[domain.SocialNetwork].AverageTicket)
+	})
+
+	t.Run("ano", func(t *testing.T) {
+		period, start, end := domain.YearPeriod(date)
+		rollup := buildRollup("abc123", domain.RollupYear, period, start, end, adEntries, salesEntries)
+		assert.Equal(t, "2025", rollup.Period)
+		assert.Equal(t, 4, rollup.Months)
+		assert.Equal(t, 230.5, rollup.AdMetrics.Spend)
+	})
+
+	t.Run("sem meses no período", func(t *testing.T) {
+		period, start, end := domain.QuarterPeriod(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, buildRollup("abc123", domain.RollupQuarter, period, start, end, adEntries, salesEntries))
+	})
+}
@@ -0,0 +1,68 @@
+package insighting
+
+import (
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// buildInsightSeries agrupa os insights diários nos períodos da agregação, na ordem do intervalo consultado.
+// Períodos sem dados aparecem sem métricas para que a série não tenha lacunas. exchangeRate converte o
+// investimento para a moeda do faturamento nas métricas de resultado e grossMargin define o ROAS de equilíbrio.
+func buildInsightSeries(
+	aggregation string,
+	dates []time.Time,
+	adInsights []*domain.AdInsightEntry,
+	salesInsights []*domain.SalesInsightEntry,
+	exchangeRate, grossMargin float64,
+) []*domain.InsightSeriesPoint {
+	if len(dates) == 0 {
+		return nil
+	}
+
+	adByPeriod := make(map[string][]*domain.AdInsightEntry)
+	for _, entry := range adInsights {
+		if entry == nil || entry.AdMetrics == nil {
+			continue
+		}
+		period, _, _ := domain.AggregationPeriod(aggregation, entry.Date)
+		adByPeriod[period] = append(adByPeriod[period], entry)
+	}
+
+	salesByPeriod := make(map[string][]*domain.SalesInsightEntry)
+	for _, entry := range salesInsights {
+		if entry == nil {
+			continue
+		}
+		period, _, _ := domain.AggregationPeriod(aggregation, entry.Date)
+		salesByPeriod[period] = append(salesByPeriod[period], entry)
+	}
+
+	series := make([]*domain.InsightSeriesPoint, 0)
+	points := make(map[string]*domain.InsightSeriesPoint)
+	for _, date := range dates {
+		period, _, _ := domain.AggregationPeriod(aggregation, date)
+
+		// As datas estão em ordem, então a última data vista de cada período é o fim do período dentro do intervalo
+		if point, ok := points[period]; ok {
+			point.EndDate = date.Format(time.DateOnly)
+			continue
+		}
+
+		point := &domain.InsightSeriesPoint{
+			Period:    period,
+			StartDate: date.Format(time.DateOnly),
+			EndDate:   date.Format(time.DateOnly),
+		}
+		points[period] = point
+		series = append(series, point)
+	}
+
+	for _, point := range series {
+		point.AdAccountMetrics = combineAdMetrics(adByPeriod[point.Period])
+		point.SalesMetrics = combineSalesMetrics(salesByPeriod[point.Period])
+		point.ResultMetrics = domain.CalculateResultMetricsWithRate(point.AdAccountMetrics, point.SalesMetrics, exchangeRate, grossMargin)
+	}
+
+	return series
+}
@@ -0,0 +1,69 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestAggregationPeriod(t *testing.T) {
+	date := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC) // domingo
+
+	period, start, end := domain.AggregationPeriod(domain.AggregationWeekly, date)
+	assert.Equal(t, "2025-W09", period)
+	assert.Equal(t, "2025-02-24", start.Format(time.DateOnly))
+	assert.Equal(t, "2025-03-02", end.Format(time.DateOnly))
+
+	period, _, end = domain.AggregationPeriod(domain.AggregationMonthly, date)
+	assert.Equal(t, "03-2025", period)
+	assert.Equal(t, "2025-03-31", end.Format(time.DateOnly))
+
+	period, _, _ = domain.AggregationPeriod(domain.AggregationQuarterly, date)
+	assert.Equal(t, "Q1-2025", period)
+}
+
+func TestBuildInsightSeries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	start, end := day(1), day(12)
+
+	adEntry := func(d int, spend float64, result int) *domain.AdInsightEntry {
+		return &domain.AdInsightEntry{
+			ExternalID: "act_1",
+			Date:       day(d),
+			AdMetrics: &domain.AdAccountMetrics{
+				AdAccountInsight: domain.AdAccountInsight{Spend: spend, Impressions: 1000, Reach: 500, Result: result},
+			},
+		}
+	}
+	salesEntry := func(d int, revenue float64) *domain.SalesInsightEntry {
+		return &domain.SalesInsightEntry{
+			Date:         day(d),
+			SalesMetrics: map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: revenue, SalesQuantity: 1}},
+		}
+	}
+
+	series := buildInsightSeries(domain.AggregationWeekly, generateDateRange(&start, &end, time.UTC),
+		[]*domain.AdInsightEntry{adEntry(1, 10, 2), adEntry(2, 20, 3), adEntry(4, 50, 5)},
+		[]*domain.SalesInsightEntry{salesEntry(2, 300)},
+		1, domain.DefaultGrossMargin,
+	)
+
+	// 01/03 e 02/03 fecham a semana 9; a semana 11 começa em 10/03 e fica parcial
+	assert.Len(t, series, 3)
+	assert.Equal(t, "2025-W09", series[0].Period)
+	assert.Equal(t, "2025-03-01", series[0].StartDate)
+	assert.Equal(t, "2025-03-02", series[0].EndDate)
+	assert.Equal(t, 30.0, series[0].AdAccountMetrics.Spend)
+	assert.Equal(t, 5, series[0].AdAccountMetrics.Result)
+	assert.Equal(t, 300.0, series[0].SalesMetrics[domain.SocialNetwork].TotalRevenue)
+	assert.Equal(t, "10x", series[0].ResultMetrics.ROI)
+
+	assert.Equal(t, 50.0, series[1].AdAccountMetrics.Spend)
+	assert.Nil(t, series[1].SalesMetrics)
+
+	assert.Equal(t, "2025-W11", series[2].Period)
+	assert.Equal(t, "2025-03-12", series[2].EndDate)
+	assert.Nil(t, series[2].AdAccountMetrics)
+}
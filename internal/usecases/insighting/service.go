@@ -1,6 +1,7 @@
 package insighting
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"sort"
@@ -15,6 +16,7 @@ import (
 	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
 	"github.com/vfg2006/traffic-manager-api/internal/config"
 	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/naming"
 	"github.com/vfg2006/traffic-manager-api/pkg/utils"
 )
 
@@ -25,17 +27,44 @@ type originAggregator struct {
 	sales         []*domain.Sale
 }
 
-// Service implementa tanto a interface Insighter quanto MetaInsighter e SSOticaInsighter
+// Service implementa tanto a interface Insighter quanto MetaInsighter e SSOticaInsighter.
+// É imutável após a construção; o uso dos insights armazenados como cache fica no decorador CachedService.
 type Service struct {
 	cfg                           *config.Config
 	metaService                   *meta.MetaIntegrator
 	ssoticaService                ssotica.SSOticaIntegrator
 	accountRepository             repository.AccountRepository
-	adInsightRepository           repository.AdInsightRepository
-	salesInsightRepository        repository.SalesInsightRepository
 	monthlyAdInsightRepository    repository.MonthlyAdInsightRepository
 	monthlySalesInsightRepository repository.MonthlySalesInsightRepository
-	useCache                      bool
+	annotationRepository          repository.AnnotationRepository
+	goalRepository                repository.GoalRepository
+	namingParser                  *naming.Parser
+	currencyConverter             CurrencyConverter
+}
+
+// Option configura dependências opcionais do Service na construção
+type Option func(*Service)
+
+// WithMonthlyInsights habilita as consultas de insights mensais consolidados
+func WithMonthlyInsights(monthlyAdInsightRepo repository.MonthlyAdInsightRepository, monthlySalesInsightRepo repository.MonthlySalesInsightRepository) Option {
+	return func(s *Service) {
+		s.monthlyAdInsightRepository = monthlyAdInsightRepo
+		s.monthlySalesInsightRepository = monthlySalesInsightRepo
+	}
+}
+
+// WithAnnotations inclui as anotações de datas do período na resposta de insights da conta
+func WithAnnotations(annotationRepo repository.AnnotationRepository) Option {
+	return func(s *Service) {
+		s.annotationRepository = annotationRepo
+	}
+}
+
+// WithGoals inclui o progresso em relação às metas mensais da conta nos insights e no relatório mensal
+func WithGoals(goalRepo repository.GoalRepository) Option {
+	return func(s *Service) {
+		s.goalRepository = goalRepo
+	}
 }
 
 // NewService cria uma nova instância do serviço de insights
@@ -44,35 +73,38 @@ func NewService(
 	metaService *meta.MetaIntegrator,
 	ssoticaService ssotica.SSOticaIntegrator,
 	accountRepo repository.AccountRepository,
-) CombinedInsighter {
-	return &Service{
-		cfg:                    cfg,
-		metaService:            metaService,
-		ssoticaService:         ssoticaService,
-		accountRepository:      accountRepo,
-		adInsightRepository:    nil,   // Inicialmente null
-		salesInsightRepository: nil,   // Inicialmente null
-		useCache:               false, // Inicialmente não usa cache
+	opts ...Option,
+) *Service {
+	service := &Service{
+		cfg:               cfg,
+		metaService:       metaService,
+		ssoticaService:    ssoticaService,
+		accountRepository: accountRepo,
+		namingParser:      naming.NewParser(cfg.CampaignNaming.Separator, cfg.CampaignNaming.Dimensions),
 	}
-}
 
-// WithCache habilita o uso de cache de insights
-func (s *Service) WithCache(
-	adInsightRepo repository.AdInsightRepository,
-	salesInsightRepo repository.SalesInsightRepository,
-	monthlyAdInsightRepo repository.MonthlyAdInsightRepository,
-	monthlySalesInsightRepo repository.MonthlySalesInsightRepository,
-) *Service {
-	s.adInsightRepository = adInsightRepo
-	s.salesInsightRepository = salesInsightRepo
-	s.monthlyAdInsightRepository = monthlyAdInsightRepo
-	s.monthlySalesInsightRepository = monthlySalesInsightRepo
-	s.useCache = (s.adInsightRepository != nil && s.salesInsightRepository != nil)
-	return s
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
 }
 
+// accountMetricsFetcher busca as métricas de anúncios e vendas de uma conta já resolvida
+type accountMetricsFetcher func(
+	insights *domain.AdAccountInsightsResponse,
+	account *domain.AdAccount,
+	accountExternalID string,
+	filters *domain.InsigthFilters,
+) (*domain.AdAccountInsightsResponse, error)
+
 // GetAdAccountsByID obtém todas as métricas (anúncios e vendas) para uma conta específica
 func (s *Service) GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+	return s.accountInsights(accountID, filters, s.GetAdAccountsByIDWithoutCache)
+}
+
+// accountInsights valida os filtros, resolve a conta e monta a resposta com as métricas obtidas por fetch
+func (s *Service) accountInsights(accountID string, filters *domain.InsigthFilters, fetch accountMetricsFetcher) (*domain.AdAccountInsightsResponse, error) {
 	// Verificar se os filtros têm datas válidas
 	if filters == nil || filters.StartDate == nil || filters.EndDate == nil {
 		return nil, fmt.Errorf("é necessário informar as datas de início e fim")
@@ -83,6 +115,10 @@ func (s *Service) GetAdAccountsByID(accountID string, filters *domain.InsigthFil
 		return nil, fmt.Errorf("a data de início não pode ser posterior à data de fim")
 	}
 
+	if filters.Aggregation != "" && !domain.IsValidAggregation(filters.Aggregation) {
+		return nil, fmt.Errorf("agregação inválida: %s", filters.Aggregation)
+	}
+
 	// Buscar a conta do repositório para obter o ID interno, CNPJ e SecretName
 	account, err := s.accountRepository.GetAccountByExternalID(accountID)
 	if err != nil {
@@ -100,369 +136,74 @@ func (s *Service) GetAdAccountsByID(accountID string, filters *domain.InsigthFil
 	// Criar a resposta final
 	insights := &domain.AdAccountInsightsResponse{
 		Filters: filters,
+		Format:  account.FormatMetadata(),
 	}
 
-	// Se o cache estiver habilitado, tentar buscar as métricas do banco primeiro
-	if s.useCache {
-		return s.GetAdAccountsByIDWithCache(insights, account, accountID, filters)
+	insights, err = fetch(insights, account, accountID, filters)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.GetAdAccountsByIDWithoutCache(insights, account, accountID, filters)
-}
+	s.attachAnnotations(insights, account.ID, filters)
+	s.attachGoals(insights, account, filters)
 
-// GetAdAccountsByID obtém todas as métricas (anúncios e vendas) para uma conta específica
-func (s *Service) GetAdAccountsByIDWithCache(insights *domain.AdAccountInsightsResponse,
-	account *domain.AdAccount,
-	accountExternalID string,
-	filters *domain.InsigthFilters,
-) (*domain.AdAccountInsightsResponse, error) {
-	// Gerar lista de todas as datas do período solicitado para controle
-	allDates := generateDateRange(filters.StartDate, filters.EndDate)
-	if len(allDates) == 0 {
-		return nil, fmt.Errorf("período de datas inválido")
+	if insights != nil && insights.AdAccountMetrics != nil {
+		insights.AdAccountMetrics.TranslateObjectives()
 	}
-
-	// Variáveis para armazenar os resultados
-	var (
-		adInsights     []*domain.AdInsightEntry
-		salesInsights  []*domain.SalesInsightEntry
-		adInsightError error
-		salesError     error
-	)
-
-	// Usar WaitGroup para esperar as goroutines terminarem
-	wg := sync.WaitGroup{}
-	wg.Add(2)
-
-	// Goroutine para buscar e processar métricas de anúncios
-	go func() {
-		defer wg.Done()
-		adInsights, adInsightError = s.getAdMetricsWithCache(account, accountExternalID, filters, allDates)
-	}()
-
-	// Goroutine para buscar e processar métricas de vendas (apenas se a conta tiver os dados necessários)
-	go func() {
-		defer wg.Done()
-		if account.CNPJ != nil && *account.CNPJ != "" && account.SecretName != nil && *account.SecretName != "" {
-			salesInsights, salesError = s.getSalesMetricsWithCache(account, filters, allDates)
+	if insights != nil {
+		for _, point := range insights.Series {
+			if point.AdAccountMetrics != nil {
+				point.AdAccountMetrics.TranslateObjectives()
+			}
 		}
-	}()
-
-	// Aguardar as goroutines terminarem
-	wg.Wait()
-
-	// Verificar se houve erro nas goroutines
-	if adInsightError != nil {
-		logrus.WithError(adInsightError).Error("Erro ao buscar métricas de anúncios com cache")
-	}
-
-	if salesError != nil {
-		logrus.WithError(salesError).Error("Erro ao buscar métricas de vendas com cache")
-	}
-
-	// Combinar todos os insights de anúncios
-	if len(adInsights) > 0 {
-		// Agregar todas as métricas de anúncios
-		combinedAdMetrics := combineAdMetrics(adInsights)
-		insights.AdAccountMetrics = combinedAdMetrics
-	}
-
-	// Combinar todos os insights de vendas
-	if len(salesInsights) > 0 {
-		// Agregar todas as métricas de vendas
-		combinedSalesMetrics := combineSalesMetrics(salesInsights)
-		insights.SalesMetrics = combinedSalesMetrics
-	}
-
-	// Se conseguimos dados tanto de anúncios quanto de vendas, calcular métricas de resultado
-	if insights.AdAccountMetrics != nil && insights.SalesMetrics != nil && insights.SalesMetrics[domain.SocialNetwork] != nil {
-		insights.ResultMetrics = domain.CalculateResultMetrics(
-			insights.AdAccountMetrics,
-			insights.SalesMetrics,
-		)
-	}
-
-	// Se encontramos dados suficientes, retornar
-	if insights.AdAccountMetrics != nil || insights.SalesMetrics != nil {
-		return insights, nil
 	}
 
 	return insights, nil
 }
 
-// getAdMetricsWithCache busca métricas de anúncios do cache e preenche dados faltantes via API
-func (s *Service) getAdMetricsWithCache(
-	account *domain.AdAccount,
-	accountExternalID string,
-	filters *domain.InsigthFilters,
-	allDates []time.Time,
-) ([]*domain.AdInsightEntry, error) {
-	// Mapa para armazenar as datas que já temos no banco
-	existingAdDates := make(map[string]bool)
-
-	// Armazenar os insights encontrados
-	adInsights := make([]*domain.AdInsightEntry, 0)
-
-	// 1. Buscar todos os insights de anúncios para o período completo
-	periodAdInsights, err := s.adInsightRepository.GetByDateRange(
-		account.ID,
-		*filters.StartDate,
-		*filters.EndDate,
-	)
-	if err != nil {
-		logrus.WithError(err).WithFields(logrus.Fields{
-			"account_id": account.ID,
-			"start_date": filters.StartDate.Format(time.DateOnly),
-			"end_date":   filters.EndDate.Format(time.DateOnly),
-		}).Warn("Erro ao buscar insights de anúncios do banco de dados para o período")
-	} else {
-		// Adicionar aos insights encontrados e marcar as datas que já temos
-		for _, insight := range periodAdInsights {
-			adInsights = append(adInsights, insight)
-			dateStr := insight.Date.Format(time.DateOnly)
-			existingAdDates[dateStr] = true
-		}
-	}
-
-	// 2. Determinar quais datas estão faltando para buscar das APIs
-	var missingAdDates []time.Time
-
-	for _, date := range allDates {
-		dateStr := date.Format(time.DateOnly)
-
-		// Verificar se temos dados de anúncios para esta data
-		if !existingAdDates[dateStr] {
-			missingAdDates = append(missingAdDates, date)
-		}
+// attachAnnotations adiciona as anotações do período. Falhas não impedem o retorno das métricas.
+func (s *Service) attachAnnotations(insights *domain.AdAccountInsightsResponse, accountID string, filters *domain.InsigthFilters) {
+	if s.annotationRepository == nil || insights == nil {
+		return
 	}
 
-	// 3. Se temos datas faltantes de anúncios, buscá-las da API do Meta
-	if len(missingAdDates) > 0 {
-		logrus.WithFields(logrus.Fields{
-			"account_id":    account.ID,
-			"external_id":   accountExternalID,
-			"missing_dates": len(missingAdDates),
-			"total_dates":   len(allDates),
-			"first_missing": missingAdDates[0].Format(time.DateOnly),
-			"last_missing":  missingAdDates[len(missingAdDates)-1].Format(time.DateOnly),
-		}).Info("Buscando insights de anúncios da API para datas faltantes")
-
-		// Definir o número máximo de goroutines simultâneas
-		const maxConcurrent = 5
-		semaphore := make(chan struct{}, maxConcurrent)
-
-		// Usar WaitGroup para esperar todas as chamadas à API terminarem
-		var fetchWg sync.WaitGroup
-
-		// Mutex para proteger o slice de salesInsights durante atualizações concorrentes
-		var mutex sync.Mutex
-
-		for _, date := range missingAdDates {
-			fetchWg.Add(1)
-
-			// Função para buscar dados para uma data específica
-			go func(date time.Time) {
-				defer fetchWg.Done()
-
-				// Adquirir uma vaga no semáforo
-				semaphore <- struct{}{}
-				defer func() { <-semaphore }()
-
-				dailyFilter := &domain.InsigthFilters{
-					StartDate: &date,
-					EndDate:   &date,
-				}
-
-				logrus.WithFields(logrus.Fields{
-					"account_id":  account.ID,
-					"external_id": accountExternalID,
-					"start_date":  dailyFilter.StartDate.Format(time.DateOnly),
-					"end_date":    dailyFilter.EndDate.Format(time.DateOnly),
-				}).Info("Buscando insights de anúncios da API para datas faltantes")
-
-				// Buscar da API do Meta
-				adMetrics, err := s.metaService.GetAdAccountsInsights(accountExternalID, dailyFilter)
-				if err != nil {
-					logrus.WithError(err).WithFields(logrus.Fields{
-						"account_id":  account.ID,
-						"external_id": accountExternalID,
-						"start_date":  dailyFilter.StartDate.Format(time.DateOnly),
-						"end_date":    dailyFilter.EndDate.Format(time.DateOnly),
-					}).Warn("Erro ao obter insights de anúncios do Meta")
-					return
-				}
-
-				logrus.WithFields(logrus.Fields{
-					"ad_metrics": adMetrics,
-				}).Info("Insights de anúncios obtidos da API do Meta")
-
-				// Criar entrada para o cache
-				adInsight := &domain.AdInsightEntry{
-					AccountID:  account.ID,
-					ExternalID: accountExternalID,
-					Date:       *dailyFilter.StartDate,
-					AdMetrics:  adMetrics,
-				}
-
-				if date.Format(time.DateOnly) != time.Now().Format(time.DateOnly) {
-					err = s.adInsightRepository.SaveOrUpdate(adInsight)
-					if err != nil {
-						logrus.WithError(err).WithFields(logrus.Fields{
-							"account_id": account.ID,
-						}).Warn("Erro ao salvar insights de anúncios no banco de dados")
-					}
-				}
-
-				// Adicionar aos insights encontrados - protegido por mutex
-				mutex.Lock()
-				adInsights = append(adInsights, adInsight)
-				mutex.Unlock()
-			}(date)
-		}
-
-		// Aguardar todas as goroutines terminarem
-		fetchWg.Wait()
+	annotations, err := s.annotationRepository.ListAnnotations(accountID, *filters.StartDate, *filters.EndDate)
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Error("Erro ao buscar anotações da conta")
+		return
 	}
 
-	return adInsights, nil
+	insights.Annotations = annotations
 }
 
-// getSalesMetricsWithCache busca métricas de vendas do cache e preenche dados faltantes via API
-func (s *Service) getSalesMetricsWithCache(
-	account *domain.AdAccount,
-	filters *domain.InsigthFilters,
-	allDates []time.Time,
-) ([]*domain.SalesInsightEntry, error) {
-	// Mapa para armazenar as datas que já temos no banco
-	existingSalesDates := make(map[string]bool)
-
-	// Armazenar os insights encontrados
-	salesInsights := make([]*domain.SalesInsightEntry, 0)
-
-	// 1. Buscar todos os insights de vendas para o período completo
-	periodSalesInsights, err := s.salesInsightRepository.GetByDateRange(
-		account.ID,
-		*filters.StartDate,
-		*filters.EndDate,
-	)
-	if err != nil {
-		logrus.WithError(err).WithFields(logrus.Fields{
-			"account_id": account.ID,
-			"start_date": filters.StartDate.Format(time.DateOnly),
-			"end_date":   filters.EndDate.Format(time.DateOnly),
-		}).Warn("Erro ao buscar insights de vendas do banco de dados para o período")
-	} else {
-		// Adicionar aos insights encontrados e marcar as datas que já temos
-		for _, insight := range periodSalesInsights {
-			salesInsights = append(salesInsights, insight)
-			dateStr := insight.Date.Format(time.DateOnly)
-			existingSalesDates[dateStr] = true
-		}
+// attachGoals adiciona o progresso em relação às metas quando o período está contido em um único mês.
+// Falhas não impedem o retorno das métricas.
+func (s *Service) attachGoals(insights *domain.AdAccountInsightsResponse, account *domain.AdAccount, filters *domain.InsigthFilters) {
+	if s.goalRepository == nil || insights == nil {
+		return
 	}
 
-	// 2. Determinar quais datas estão faltando para buscar das APIs
-	var missingSalesDates []time.Time
-
-	for _, date := range allDates {
-		dateStr := date.Format(time.DateOnly)
-
-		// Verificar se temos dados de vendas para esta data
-		if !existingSalesDates[dateStr] {
-			missingSalesDates = append(missingSalesDates, date)
-		}
+	period, ok := domain.GoalPeriodForRange(*filters.StartDate, *filters.EndDate)
+	if !ok {
+		return
 	}
 
-	// 3. Se temos datas faltantes de vendas, buscá-las da API do SSOtica
-	if len(missingSalesDates) > 0 && account.CNPJ != nil && *account.CNPJ != "" && account.SecretName != nil && *account.SecretName != "" {
-		logrus.WithFields(logrus.Fields{
-			"account_id":    account.ID,
-			"missing_dates": len(missingSalesDates),
-			"total_dates":   len(allDates),
-			"first_missing": missingSalesDates[0].Format(time.DateOnly),
-			"last_missing":  missingSalesDates[len(missingSalesDates)-1].Format(time.DateOnly),
-		}).Info("Buscando insights de vendas da API para datas faltantes")
-
-		// Definir o número máximo de goroutines simultâneas
-		const maxConcurrent = 5
-		semaphore := make(chan struct{}, maxConcurrent)
-
-		// Usar WaitGroup para esperar todas as chamadas à API terminarem
-		var fetchWg sync.WaitGroup
-
-		// Mutex para proteger o slice de salesInsights durante atualizações concorrentes
-		var mutex sync.Mutex
-
-		// Configurar os parâmetros base para a chamada ao SSOtica
-		params := &ssoticadomain.GetSalesParams{
-			CNPJ:       *account.CNPJ,
-			SecretName: *account.SecretName,
-		}
-
-		// Buscar cada data faltante da API em paralelo
-		for _, date := range missingSalesDates {
-			fetchWg.Add(1)
-
-			// Função para buscar dados para uma data específica
-			go func(date time.Time, baseParams ssoticadomain.GetSalesParams) {
-				defer fetchWg.Done()
-
-				// Adquirir uma vaga no semáforo
-				semaphore <- struct{}{}
-				defer func() { <-semaphore }()
-
-				dailyFilter := &domain.InsigthFilters{
-					StartDate: &date,
-					EndDate:   &date,
-				}
-
-				// Buscar da API do SSOtica
-				salesMetrics, err := s.GetSalesMetrics(*account.CNPJ, *account.SecretName, dailyFilter)
-				if err != nil {
-					logrus.Warn("Erro ao obter dados de vendas do SSOtica", map[string]any{
-						"accountID": account.ID,
-						"error":     err,
-					})
-					return
-				}
-
-				if salesMetrics == nil || len(salesMetrics) == 0 {
-					logrus.Warn("Erro ao obter dados de vendas do SSOtica", map[string]any{
-						"accountID": account.ID,
-						"error":     err,
-					})
-					return
-				}
+	goal, err := s.goalRepository.GetGoal(account.ID, parseMonthYearToPeriod(period))
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", account.ID).Error("Erro ao buscar metas da conta")
+		return
+	}
 
-				// Criar entrada para o cache
-				salesInsight := &domain.SalesInsightEntry{
-					AccountID:    account.ID,
-					Date:         date,
-					SalesMetrics: salesMetrics,
-				}
+	exchangeRate := spendExchangeRate(s.currencyConverter, account)
+	insights.Goals = domain.CalculateGoalAttainment(goal, insights.AdAccountMetrics, insights.SalesMetrics, exchangeRate)
 
-				// Salvar no cache
-				if date.Format(time.DateOnly) != time.Now().Format(time.DateOnly) {
-					err = s.salesInsightRepository.SaveOrUpdate(salesInsight)
-					if err != nil {
-						logrus.WithError(err).WithFields(logrus.Fields{
-							"account_id": account.ID,
-							"date":       date.Format(time.DateOnly),
-						}).Warn("Erro ao salvar insights de vendas no banco de dados")
-					}
-				}
-
-				// Adicionar aos insights encontrados - protegido por mutex
-				mutex.Lock()
-				salesInsights = append(salesInsights, salesInsight)
-				mutex.Unlock()
-			}(date, *params)
+	// Do início do mês até hoje, o investimento do período é o do mês e o ritmo pode ser avaliado
+	if insights.Goals != nil && goal.SpendBudget != nil && insights.AdAccountMetrics != nil && exchangeRate > 0 {
+		today := account.Today(time.Now())
+		if domain.IsMonthToDate(*filters.StartDate, *filters.EndDate, today) {
+			insights.Goals.Pacing = domain.CalculateBudgetPacing(*goal.SpendBudget, insights.AdAccountMetrics.Spend*exchangeRate, today, s.pacingTolerance())
 		}
-
-		// Aguardar todas as goroutines terminarem
-		fetchWg.Wait()
 	}
-
-	return salesInsights, nil
 }
 
 func (s *Service) GetAdAccountsByIDWithoutCache(
@@ -479,7 +220,7 @@ func (s *Service) GetAdAccountsByIDWithoutCache(
 	go func() {
 		defer wg.Done()
 
-		adAccountMetrics, err := s.metaService.GetAdAccountsInsights(accountExternalID, filters)
+		adAccountMetrics, err := s.metaService.GetAdAccountsInsights(context.Background(), accountExternalID, filters)
 		if err != nil {
 			logrus.Warn("Erro ao obter insights de anúncios do Meta", map[string]any{
 				"accountID": accountExternalID,
@@ -499,7 +240,7 @@ func (s *Service) GetAdAccountsByIDWithoutCache(
 		go func(params ssoticadomain.GetSalesParams) {
 			defer wg.Done()
 
-			salesMetrics, err := s.GetSalesMetrics(*account.CNPJ, *account.SecretName, filters)
+			salesMetrics, err := s.GetSalesMetrics(context.Background(), *account.CNPJ, *account.SecretName, filters)
 			if err != nil {
 				logrus.Warn("Erro ao obter dados de vendas do SSOtica", map[string]any{
 					"accountID": accountExternalID,
@@ -527,27 +268,29 @@ func (s *Service) GetAdAccountsByIDWithoutCache(
 	// Calcular métricas de resultado se temos dados suficientes
 	if insights.SalesMetrics != nil && insights.SalesMetrics[domain.SocialNetwork] != nil &&
 		insights.AdAccountMetrics != nil {
-		insights.ResultMetrics = domain.CalculateResultMetrics(
+		insights.ResultMetrics = domain.CalculateResultMetricsWithRate(
 			insights.AdAccountMetrics,
 			insights.SalesMetrics,
+			spendExchangeRate(s.currencyConverter, account),
+			s.grossMargin(),
 		)
 	}
 
 	return insights, nil
 }
 
-// generateDateRange gera um slice de datas entre startDate e endDate (inclusive)
-func generateDateRange(startDate, endDate *time.Time) []time.Time {
+// generateDateRange gera um slice de datas entre startDate e endDate (inclusive), à meia-noite no fuso
+// da conta. Os dias de calendário dos filtros são mantidos; apenas o fuso em que são contados muda.
+func generateDateRange(startDate, endDate *time.Time, loc *time.Location) []time.Time {
 	if startDate == nil || endDate == nil || startDate.After(*endDate) {
 		return []time.Time{}
 	}
 
 	var dates []time.Time
-	currentDate := *startDate
 
-	// Normalizando as datas para meia-noite
-	currentDate = time.Date(currentDate.Year(), currentDate.Month(), currentDate.Day(), 0, 0, 0, 0, currentDate.Location())
-	endDateTime := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, endDate.Location())
+	// Normalizando as datas para meia-noite no fuso da conta
+	currentDate := domain.DayIn(*startDate, loc)
+	endDateTime := domain.DayIn(*endDate, loc)
 
 	for !currentDate.After(endDateTime) {
 		dates = append(dates, currentDate)
@@ -590,17 +333,21 @@ func updateCampaignMetrics(existing, adding *domain.CampaignInsight) {
 	existing.Clicks = sumStringInts(existing.Clicks, adding.Clicks)
 
 	// Atualizar campos que são numéricos
+	existing.LinkClicks += adding.LinkClicks
 	existing.Result += adding.Result
 	existing.Spend += adding.Spend
 }
 
-// calculateDerivedMetrics calcula métricas derivadas como CostPerResult e Frequency
-func calculateDerivedMetrics(metrics *domain.AdAccountMetrics, totalImpression, totalReach, totalResults int, totalSpend float64) {
+// calculateDerivedMetrics calcula métricas derivadas como CostPerResult, Frequency, CTR, CPM e CPC
+func calculateDerivedMetrics(metrics *domain.AdAccountMetrics, totalImpression, totalReach, totalResults, totalClicks, totalLinkClicks int, totalSpend float64) {
 	// Definir os totais calculados nos métodos
 	metrics.Impressions = totalImpression
 	metrics.Reach = totalReach
 	metrics.Result = totalResults
+	metrics.Clicks = totalClicks
+	metrics.LinkClicks = totalLinkClicks
 	metrics.Spend = utils.RoundWithTwoDecimalPlace(totalSpend)
+	metrics.CalculateDeliveryRates()
 
 	// Calcular CostPerResult
 	if totalResults > 0 {
@@ -639,6 +386,8 @@ func combineAdMetrics(adInsights []*domain.AdInsightEntry) *domain.AdAccountMetr
 	totalImpression := 0
 	totalReach := 0
 	totalResults := 0
+	totalClicks := 0
+	totalLinkClicks := 0
 	totalSpend := 0.0
 
 	// Mapear campanhas pelo ID para combinar adequadamente
@@ -654,6 +403,8 @@ func combineAdMetrics(adInsights []*domain.AdInsightEntry) *domain.AdAccountMetr
 		totalImpression += insight.AdMetrics.Impressions
 		totalReach += insight.AdMetrics.Reach
 		totalResults += insight.AdMetrics.Result
+		totalClicks += insight.AdMetrics.Clicks
+		totalLinkClicks += insight.AdMetrics.LinkClicks
 		totalSpend += insight.AdMetrics.Spend
 
 		date := insight.Date.Format(time.DateOnly)
@@ -701,12 +452,13 @@ func combineAdMetrics(adInsights []*domain.AdInsightEntry) *domain.AdAccountMetr
 		}
 
 		campaign.Spend = utils.RoundWithTwoDecimalPlace(campaign.Spend)
+		campaign.CalculateDeliveryRates()
 
 		combined.Campaigns = append(combined.Campaigns, campaign)
 	}
 
 	// Calcular métricas derivadas
-	calculateDerivedMetrics(combined, totalImpression, totalReach, totalResults, totalSpend)
+	calculateDerivedMetrics(combined, totalImpression, totalReach, totalResults, totalClicks, totalLinkClicks, totalSpend)
 
 	return combined
 }
@@ -834,14 +586,14 @@ func getSalesMetricsByOrigin(origin ssoticadomain.Origin, sales []ssoticadomain.
 // Métodos para a interface MetaInsighter
 
 // GetAdAccountMetrics obtém métricas de anúncios do Meta
-func (s *Service) GetAdAccountMetrics(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
+func (s *Service) GetAdAccountMetrics(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountMetrics, error) {
 	logrus.WithFields(logrus.Fields{
 		"account_id": accountID,
 		"start_date": filters.StartDate.Format(time.DateOnly),
 		"end_date":   filters.EndDate.Format(time.DateOnly),
 	}).Info("Obtendo métricas de anúncios do Meta")
 
-	adAccountMetrics, err := s.metaService.GetAdAccountsInsights(accountID, filters)
+	adAccountMetrics, err := s.metaService.GetAdAccountsInsights(ctx, accountID, filters)
 	if err != nil {
 		logrus.WithError(err).Warn("Erro ao obter métricas de anúncios do Meta")
 		return nil, err
@@ -850,10 +602,15 @@ func (s *Service) GetAdAccountMetrics(accountID string, filters *domain.InsigthF
 	return adAccountMetrics, nil
 }
 
+// GetAdAccountDailySnapshots obtém o resumo diário de anúncios do Meta
+func (s *Service) GetAdAccountDailySnapshots(ctx context.Context, accountID string, filters *domain.InsigthFilters) ([]*domain.AdDailySnapshot, error) {
+	return s.metaService.GetAdAccountDailySnapshots(ctx, accountID, filters)
+}
+
 // Métodos para a interface SSOticaInsighter
 
 // GetSalesMetrics obtém métricas de vendas do SSOtica
-func (s *Service) GetSalesMetrics(cnpj string, secretName string, filters *domain.InsigthFilters) (map[string]*domain.SalesMetrics, error) {
+func (s *Service) GetSalesMetrics(ctx context.Context, cnpj string, secretName string, filters *domain.InsigthFilters) (map[string]*domain.SalesMetrics, error) {
 	logrus.WithFields(logrus.Fields{
 		"cnpj":        cnpj,
 		"secret_name": secretName,
@@ -868,7 +625,7 @@ func (s *Service) GetSalesMetrics(cnpj string, secretName string, filters *domai
 	}
 
 	// Obter as vendas do SSOtica
-	sales, err := s.ssoticaService.GetSalesByAccount(*params, filters)
+	sales, err := s.ssoticaService.GetSalesByAccount(ctx, *params, filters)
 	if err != nil {
 		logrus.WithError(err).Warn("Erro ao obter vendas do SSOtica")
 		return nil, err
@@ -910,6 +667,15 @@ func (s *Service) GetMonthlyInsightsByPeriod(period string) ([]*domain.MonthlyIn
 	// Buscar relatórios mensais de anúncios para o período
 	reports := make([]*domain.MonthlyInsightReport, 0, len(activeAccounts))
 
+	// Metas do mês de todas as contas, buscadas de uma vez; sem elas o relatório sai sem o progresso
+	var goals map[string]*domain.AccountGoal
+	if s.goalRepository != nil {
+		goals, err = s.goalRepository.ListGoalsByMonth(parseMonthYearToPeriod(period))
+		if err != nil {
+			logrus.WithError(err).WithField("period", period).Error("erro ao buscar metas do mês")
+		}
+	}
+
 	// Para cada conta, buscar os insights do mês especificado
 	for _, acc := range activeAccounts {
 		// Conversão de período para time.Time para uso nos repositórios
@@ -944,11 +710,15 @@ func (s *Service) GetMonthlyInsightsByPeriod(period string) ([]*domain.MonthlyIn
 			AccountID:   acc.ID,
 			AccountName: *acc.Nickname,
 			Period:      period,
+			Format:      acc.FormatMetadata(),
 		}
 
 		// Adicionar métricas de anúncios se disponíveis
 		if adInsight != nil {
 			report.AdMetrics = adInsight.AdMetrics
+			if report.AdMetrics != nil {
+				report.AdMetrics.TranslateObjectives()
+			}
 		}
 
 		// Adicionar métricas de vendas se disponíveis
@@ -956,11 +726,15 @@ func (s *Service) GetMonthlyInsightsByPeriod(period string) ([]*domain.MonthlyIn
 			report.SalesMetrics = salesInsight.SalesMetrics
 		}
 
+		exchangeRate := spendExchangeRate(s.currencyConverter, acc)
+
 		// Calcular métricas de resultado se tiver ambos os dados
 		if report.AdMetrics != nil && report.SalesMetrics != nil {
-			report.ResultMetrics = domain.CalculateResultMetrics(report.AdMetrics, report.SalesMetrics)
+			report.ResultMetrics = domain.CalculateResultMetricsWithRate(report.AdMetrics, report.SalesMetrics, exchangeRate, s.grossMargin())
 		}
 
+		report.Goals = domain.CalculateGoalAttainment(goals[acc.ID], report.AdMetrics, report.SalesMetrics, exchangeRate)
+
 		reports = append(reports, report)
 	}
 
@@ -1079,7 +853,7 @@ func (s *Service) GetAdAccountReachImpressions(accountID string, filters *domain
 	}).Info("Obtendo Reach e Impressions da conta do Meta")
 
 	// Buscar diretamente da API do Meta
-	metrics, err := s.metaService.GetAdAccountReachImpressions(accountID, filters)
+	metrics, err := s.metaService.GetAdAccountReachImpressions(context.Background(), accountID, filters)
 	if err != nil {
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"account_id": accountID,
@@ -1091,3 +865,11 @@ func (s *Service) GetAdAccountReachImpressions(accountID string, filters *domain
 
 	return metrics, nil
 }
+
+// grossMargin retorna a margem bruta usada no ROAS de equilíbrio
+func (s *Service) grossMargin() float64 {
+	if s.cfg == nil || s.cfg.ResultMetrics.GrossMargin <= 0 {
+		return domain.DefaultGrossMargin
+	}
+	return s.cfg.ResultMetrics.GrossMargin
+}
@@ -0,0 +1,41 @@
+package insighting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestCombineAdMetrics_DeliveryRates(t *testing.T) {
+	entry := func(d int, spend float64, impressions, clicks, linkClicks int) *domain.AdInsightEntry {
+		return &domain.AdInsightEntry{
+			ExternalID: "act_1",
+			Date:       time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC),
+			AdMetrics: &domain.AdAccountMetrics{
+				AdAccountInsight: domain.AdAccountInsight{
+					Spend: spend, Impressions: impressions, Reach: impressions / 2, Clicks: clicks, LinkClicks: linkClicks,
+					Campaigns: []*domain.CampaignInsight{{
+						CampaignID: "c1", Spend: spend, Impressions: "1000", Reach: "500", Clicks: "10", LinkClicks: linkClicks,
+					}},
+				},
+			},
+		}
+	}
+
+	combined := combineAdMetrics([]*domain.AdInsightEntry{entry(1, 30, 1000, 10, 6), entry(2, 50, 3000, 30, 20)})
+
+	// As taxas vêm dos totais do período, não da média das taxas diárias
+	assert.Equal(t, 40, combined.Clicks)
+	assert.Equal(t, 26, combined.LinkClicks)
+	assert.Equal(t, 1.0, combined.CTR)
+	assert.Equal(t, 20.0, combined.CPM)
+	assert.Equal(t, 2.0, combined.CPC)
+
+	campaign := combined.Campaigns[0]
+	assert.Equal(t, 26, campaign.LinkClicks)
+	assert.Equal(t, 1.0, campaign.CTR)
+	assert.Equal(t, 40.0, campaign.CPM)
+	assert.Equal(t, 4.0, campaign.CPC)
+}
@@ -0,0 +1,90 @@
+package masking
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+)
+
+// ErrRulesUnavailable indica que as regras de mascaramento ainda não puderam ser carregadas. Sem saber
+// quais campos ocultar, a resposta não deve ser enviada.
+var ErrRulesUnavailable = errors.New("regras de mascaramento indisponíveis")
+
+// FieldMasker informa quais campos das respostas devem ser ocultados para cada perfil
+type FieldMasker interface {
+	MaskedFields(roleID int) (map[string]bool, error)
+}
+
+// Service carrega as regras de role_masked_fields e as mantém em cache por ttl,
+// para não consultar o banco a cada requisição
+type Service struct {
+	roleRepo repository.RoleRepository
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	fields   map[int]map[string]bool
+	loadedAt time.Time
+}
+
+func NewService(roleRepo repository.RoleRepository, ttl time.Duration) FieldMasker {
+	return &Service{
+		roleRepo: roleRepo,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+func (s *Service) MaskedFields(roleID int) (map[string]bool, error) {
+	s.mu.RLock()
+	fresh := s.fields != nil && s.now().Sub(s.loadedAt) < s.ttl
+	fields := s.fields[roleID]
+	s.mu.RUnlock()
+
+	if fresh {
+		return fields, nil
+	}
+
+	rules, err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return rules[roleID], nil
+}
+
+// reload recarrega as regras. Em caso de erro, mantém as regras anteriores sem renovar o cache, para que a
+// próxima requisição tente de novo; se nenhuma regra foi carregada ainda, retorna ErrRulesUnavailable.
+func (s *Service) reload() (map[int]map[string]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fields != nil && s.now().Sub(s.loadedAt) < s.ttl {
+		return s.fields, nil
+	}
+
+	rows, err := s.roleRepo.GetMaskedFields()
+	if err != nil {
+		if s.fields == nil {
+			logrus.WithError(err).Error("masking: erro ao carregar campos mascarados, nenhuma regra disponível")
+			return nil, ErrRulesUnavailable
+		}
+		logrus.WithError(err).Error("masking: erro ao carregar campos mascarados, mantendo regras anteriores")
+		return s.fields, nil
+	}
+
+	fields := make(map[int]map[string]bool, len(rows))
+	for roleID, names := range rows {
+		fields[roleID] = make(map[string]bool, len(names))
+		for _, name := range names {
+			fields[roleID][name] = true
+		}
+	}
+
+	s.fields = fields
+	s.loadedAt = s.now()
+
+	return s.fields, nil
+}
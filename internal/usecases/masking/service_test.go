@@ -0,0 +1,51 @@
+package masking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_MaskedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRoleRepository(ctrl)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := &Service{roleRepo: repo, ttl: time.Minute, now: func() time.Time { return now }}
+
+	t.Run("sem regras carregadas, a falha não é guardada em cache", func(t *testing.T) {
+		repo.EXPECT().GetMaskedFields().Return(nil, errors.New("conexão recusada")).Times(2)
+
+		for range 2 {
+			fields, err := service.MaskedFields(3)
+			assert.ErrorIs(t, err, ErrRulesUnavailable)
+			assert.Nil(t, fields)
+		}
+	})
+
+	t.Run("carrega as regras e as mantém em cache", func(t *testing.T) {
+		repo.EXPECT().GetMaskedFields().Return(map[int][]string{3: {"spend", "cnpj"}}, nil)
+
+		for range 2 {
+			fields, err := service.MaskedFields(3)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]bool{"spend": true, "cnpj": true}, fields)
+		}
+	})
+
+	t.Run("falha depois de carregar mantém as regras anteriores e tenta de novo", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		repo.EXPECT().GetMaskedFields().Return(nil, errors.New("conexão recusada")).Times(2)
+
+		for range 2 {
+			fields, err := service.MaskedFields(3)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]bool{"spend": true, "cnpj": true}, fields)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// Nomes das dependências verificadas
+const (
+	DependencyDatabase = "database"
+	DependencyMeta     = "meta"
+	DependencySSOtica  = "ssotica"
+)
+
+// Probe verifica uma dependência externa. Check deve ser barato: é executado a cada intervalo.
+type Probe struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+type DependencyMonitor interface {
+	// Statuses retorna o resultado da última verificação de cada dependência
+	Statuses() []domain.DependencyStatus
+	// Ready indica se todas as dependências críticas responderam na última verificação
+	Ready() bool
+}
+
+// Monitor executa as verificações das dependências periodicamente e guarda o último resultado,
+// para que /readyz e o status administrativo respondam sem chamar as dependências a cada requisição.
+type Monitor struct {
+	probes   []Probe
+	interval time.Duration
+	timeout  time.Duration
+	mu       sync.RWMutex
+	statuses map[string]*domain.DependencyStatus
+	now      func() time.Time
+}
+
+func NewMonitor(interval, timeout time.Duration, probes ...Probe) *Monitor {
+	statuses := make(map[string]*domain.DependencyStatus, len(probes))
+	for _, probe := range probes {
+		statuses[probe.Name] = &domain.DependencyStatus{Name: probe.Name, Critical: probe.Critical}
+	}
+
+	return &Monitor{
+		probes:   probes,
+		interval: interval,
+		timeout:  timeout,
+		statuses: statuses,
+		now:      time.Now,
+	}
+}
+
+// Start executa a primeira verificação imediatamente e as seguintes a cada intervalo, até o contexto ser cancelado
+func (m *Monitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			m.CheckAll(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// CheckAll verifica todas as dependências em paralelo, cada uma limitada ao timeout configurado
+func (m *Monitor) CheckAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, probe := range m.probes {
+		wg.Add(1)
+		go func(probe Probe) {
+			defer wg.Done()
+			m.check(ctx, probe)
+		}(probe)
+	}
+	wg.Wait()
+}
+
+func (m *Monitor) check(ctx context.Context, probe Probe) {
+	probeCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	startedAt := m.now()
+	err := probe.Check(probeCtx)
+	latency := m.now().Sub(startedAt)
+
+	// O encerramento da aplicação não deve ser registrado como falha da dependência
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := m.statuses[probe.Name]
+	status.LastCheckAt = &startedAt
+	status.LatencyMs = latency.Milliseconds()
+	status.Healthy = err == nil
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"dependency": probe.Name,
+			"latency_ms": status.LatencyMs,
+		}).Warn("Dependência externa indisponível")
+		return
+	}
+	status.LastSuccessAt = &startedAt
+}
+
+func (m *Monitor) Statuses() []domain.DependencyStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]domain.DependencyStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func (m *Monitor) Ready() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, status := range m.statuses {
+		if status.Critical && !status.Healthy {
+			return false
+		}
+	}
+	return true
+}
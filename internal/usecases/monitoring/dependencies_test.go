@@ -0,0 +1,62 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitor_CheckAll(t *testing.T) {
+	metaErr := errors.New("requisição falhou com status: 500")
+	metaHealthy := true
+
+	monitor := NewMonitor(time.Minute, time.Second,
+		Probe{Name: DependencyDatabase, Critical: true, Check: func(context.Context) error { return nil }},
+		Probe{Name: DependencyMeta, Check: func(context.Context) error {
+			if metaHealthy {
+				return nil
+			}
+			return metaErr
+		}},
+	)
+
+	// Antes da primeira verificação a aplicação não está pronta
+	assert.False(t, monitor.Ready())
+
+	monitor.CheckAll(context.Background())
+	assert.True(t, monitor.Ready())
+
+	metaHealthy = false
+	monitor.CheckAll(context.Background())
+
+	statuses := monitor.Statuses()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, DependencyDatabase, statuses[0].Name)
+	assert.True(t, statuses[0].Healthy)
+
+	meta := statuses[1]
+	assert.False(t, meta.Healthy)
+	assert.Equal(t, metaErr.Error(), meta.Error)
+	assert.NotNil(t, meta.LastSuccessAt)
+	assert.True(t, meta.LastCheckAt.After(*meta.LastSuccessAt) || meta.LastCheckAt.Equal(*meta.LastSuccessAt))
+
+	// Dependências não críticas indisponíveis não tiram a aplicação do ar
+	assert.True(t, monitor.Ready())
+}
+
+func TestMonitor_Timeout(t *testing.T) {
+	monitor := NewMonitor(time.Minute, 10*time.Millisecond,
+		Probe{Name: DependencyDatabase, Critical: true, Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+
+	monitor.CheckAll(context.Background())
+
+	assert.False(t, monitor.Ready())
+	assert.Equal(t, context.DeadlineExceeded.Error(), monitor.Statuses()[0].Error)
+}
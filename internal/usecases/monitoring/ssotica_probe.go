@@ -0,0 +1,41 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica"
+	ssoticadomain "github.com/vfg2006/traffic-manager-api/infrastructure/integrator/ssotica/domain"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// SSOticaProbe consulta as vendas do dia anterior com a credencial da primeira conta ativa integrada ao SSOtica.
+// O SSOtica não tem endpoint de verificação e as credenciais são por conta. Sem contas integradas não há o que verificar.
+func SSOticaProbe(accountRepo repository.AccountRepository, integrator ssotica.SSOticaIntegrator) Probe {
+	return Probe{
+		Name: DependencySSOtica,
+		Check: func(ctx context.Context) error {
+			accounts, err := accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+			if err != nil {
+				return fmt.Errorf("erro ao buscar conta para verificação: %w", err)
+			}
+
+			for _, account := range accounts {
+				if account.CNPJ == nil || *account.CNPJ == "" || account.SecretName == nil || *account.SecretName == "" {
+					continue
+				}
+
+				yesterday := time.Now().AddDate(0, 0, -1)
+				_, err := integrator.GetSalesByAccount(ctx, ssoticadomain.GetSalesParams{
+					CNPJ:       *account.CNPJ,
+					SecretName: *account.SecretName,
+				}, &domain.InsigthFilters{StartDate: &yesterday, EndDate: &yesterday})
+				return err
+			}
+
+			return nil
+		},
+	}
+}
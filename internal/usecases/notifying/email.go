@@ -0,0 +1,81 @@
+package notifying
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+)
+
+var ErrEmailNotConfigured = errors.New("envio de e-mail não configurado (SMTP_HOST/EMAIL_FROM)")
+
+// Mailer envia e-mails em HTML
+type Mailer interface {
+	SendEmail(to []string, subject, htmlBody string) error
+}
+
+// SMTPMailer envia e-mails pelo servidor SMTP configurado, com STARTTLS quando disponível
+type SMTPMailer struct {
+	addr     string
+	host     string
+	auth     smtp.Auth
+	from     *mail.Address
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// NewSMTPMailer cria o cliente SMTP. Retorna ErrEmailNotConfigured sem SMTP_HOST ou EMAIL_FROM.
+func NewSMTPMailer(cfg config.Email) (*SMTPMailer, error) {
+	if cfg.SMTPHost == "" || cfg.From == "" {
+		return nil, ErrEmailNotConfigured
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("EMAIL_FROM inválido: %w", err)
+	}
+
+	mailer := &SMTPMailer{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		host:     cfg.SMTPHost,
+		from:     from,
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}
+	if cfg.SMTPUser != "" {
+		mailer.auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return mailer, nil
+}
+
+func (m *SMTPMailer) SendEmail(to []string, subject, htmlBody string) error {
+	if len(to) == 0 {
+		return errors.New("nenhum destinatário informado")
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("From: " + m.from.String() + "\r\n")
+	msg.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + m.now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(htmlBody)
+
+	if err := m.sendMail(m.addr, m.auth, m.from.Address, to, msg.Bytes()); err != nil {
+		return fmt.Errorf("erro ao enviar e-mail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package notifying
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	ErrInvalidWebhookURL   = errors.New("URL do webhook inválida")
+	ErrInvalidWebhookEvent = errors.New("tipo de evento de webhook inválido")
+)
+
+// Dispatcher publica eventos para as assinaturas de webhook ativas
+type Dispatcher interface {
+	Dispatch(eventType string, data any)
+}
+
+// Deliverer envia um evento para um destino específico, fora das assinaturas cadastradas
+// (ex: webhook informado em um relatório agendado), aguardando o resultado da entrega
+type Deliverer interface {
+	Deliver(url, secret, eventType string, data any) error
+}
+
+type WebhookService interface {
+	Dispatcher
+	Deliverer
+	CreateSubscription(req *domain.CreateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error)
+	ListSubscriptions() ([]*domain.WebhookSubscription, error)
+	DeleteSubscription(id int) error
+}
+
+type Service struct {
+	webhookRepo repository.WebhookRepository
+	httpClient  *http.Client
+	maxRetries  int
+	now         func() time.Time
+}
+
+func NewService(webhookRepo repository.WebhookRepository, cfg *config.Config) WebhookService {
+	return &Service{
+		webhookRepo: webhookRepo,
+		httpClient: &http.Client{
+			Timeout: cfg.Webhook.Timeout,
+		},
+		maxRetries: cfg.Webhook.MaxRetries,
+		now:        time.Now,
+	}
+}
+
+// CreateSubscription cadastra um destino de webhook com um segredo gerado para assinatura dos payloads.
+// O segredo é retornado apenas nesta resposta.
+func (s *Service) CreateSubscription(req *domain.CreateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	if len(req.Events) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um evento", ErrInvalidWebhookEvent)
+	}
+	for _, event := range req.Events {
+		if !slices.Contains(domain.WebhookEventTypes, event) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, event)
+		}
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo do webhook: %w", err)
+	}
+
+	return s.webhookRepo.CreateSubscription(&domain.WebhookSubscription{
+		URL:    req.URL,
+		Secret: secret,
+		Events: req.Events,
+		Active: true,
+	})
+}
+
+func (s *Service) ListSubscriptions() ([]*domain.WebhookSubscription, error) {
+	return s.webhookRepo.ListSubscriptions()
+}
+
+func (s *Service) DeleteSubscription(id int) error {
+	return s.webhookRepo.DeleteSubscription(id)
+}
+
+// Dispatch envia o evento para todas as assinaturas ativas em segundo plano.
+// Falhas de entrega são registradas em log e não afetam quem publicou o evento.
+func (s *Service) Dispatch(eventType string, data any) {
+	subscriptions, err := s.webhookRepo.ListActiveSubscriptionsByEvent(eventType)
+	if err != nil {
+		logrus.WithError(err).WithField("event", eventType).Error("webhook: erro ao buscar assinaturas")
+		return
+	}
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	eventID, err := gonanoid.New()
+	if err != nil {
+		logrus.WithError(err).Error("webhook: erro ao gerar ID do evento")
+		return
+	}
+
+	body, err := json.Marshal(domain.WebhookEvent{
+		ID:        "evt_" + eventID,
+		Type:      eventType,
+		CreatedAt: s.now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("event", eventType).Error("webhook: erro ao serializar evento")
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		go s.deliver(subscription, "evt_"+eventID, eventType, body)
+	}
+}
+
+// Deliver envia o evento para a URL informada com as mesmas regras de assinatura e novas tentativas
+// das assinaturas cadastradas. Retorna o erro da última tentativa.
+func (s *Service) Deliver(url, secret, eventType string, data any) error {
+	eventID, err := gonanoid.New()
+	if err != nil {
+		return fmt.Errorf("erro ao gerar ID do evento: %w", err)
+	}
+
+	body, err := json.Marshal(domain.WebhookEvent{
+		ID:        "evt_" + eventID,
+		Type:      eventType,
+		CreatedAt: s.now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar evento: %w", err)
+	}
+
+	return s.deliver(&domain.WebhookSubscription{URL: url, Secret: secret}, "evt_"+eventID, eventType, body)
+}
+
+// deliver tenta a entrega com backoff exponencial. Cada tentativa é assinada novamente com o horário atual,
+// mantendo o mesmo ID de evento para que o receptor descarte duplicidades.
+func (s *Service) deliver(subscription *domain.WebhookSubscription, eventID, eventType string, body []byte) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+		"event_id":        eventID,
+		"event":           eventType,
+	})
+
+	var err error
+	backoff := time.Second
+	for attempt := 1; attempt <= s.maxRetries+1; attempt++ {
+		err = s.send(subscription, eventID, eventType, body)
+		if err == nil {
+			logger.WithField("attempt", attempt).Info("webhook: evento entregue")
+			return nil
+		}
+
+		logger.WithError(err).WithField("attempt", attempt).Warn("webhook: falha na entrega")
+		if attempt <= s.maxRetries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	logger.Error("webhook: entrega descartada após esgotar as tentativas")
+	return err
+}
+
+func (s *Service) send(subscription *domain.WebhookSubscription, eventID, eventType string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, eventID)
+	req.Header.Set(HeaderWebhookEvent, eventType)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("destino retornou status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GenerateSecret gera um segredo de assinatura de webhook (whsec_...)
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
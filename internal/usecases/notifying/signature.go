@@ -0,0 +1,59 @@
+package notifying
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cabeçalhos enviados em cada entrega de webhook
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+
+	signatureVersion = "v1"
+)
+
+var (
+	ErrInvalidSignature = errors.New("assinatura do webhook inválida")
+	ErrInvalidTimestamp = errors.New("timestamp do webhook inválido ou fora da tolerância")
+)
+
+// Sign calcula a assinatura de uma entrega: HMAC-SHA256 do segredo sobre "<timestamp>.<corpo>",
+// no formato "v1=<hex>". O timestamp faz parte do conteúdo assinado para impedir replay com outro horário.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature valida a assinatura e rejeita timestamps fora da tolerância.
+// Receptores devem também descartar IDs de evento (X-Webhook-Id) já processados.
+func VerifySignature(secret, timestamp string, body []byte, signature string, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	sentAt := time.Unix(ts, 0)
+	if now.Sub(sentAt) > tolerance || sentAt.Sub(now) > tolerance {
+		return ErrInvalidTimestamp
+	}
+
+	expected := Sign(secret, ts, body)
+	for _, candidate := range strings.Split(signature, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(candidate)), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
@@ -0,0 +1,36 @@
+package notifying
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"id":"evt_1","type":"ranking.updated"}`)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign(secret, now.Unix(), body)
+
+	t.Run("aceita assinatura válida", func(t *testing.T) {
+		assert.NoError(t, VerifySignature(secret, timestamp, body, signature, 5*time.Minute, now.Add(time.Minute)))
+	})
+
+	t.Run("rejeita corpo alterado", func(t *testing.T) {
+		err := VerifySignature(secret, timestamp, []byte(`{"id":"evt_2"}`), signature, 5*time.Minute, now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejeita segredo diferente", func(t *testing.T) {
+		err := VerifySignature("outro", timestamp, body, signature, 5*time.Minute, now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejeita replay fora da tolerância", func(t *testing.T) {
+		err := VerifySignature(secret, timestamp, body, signature, 5*time.Minute, now.Add(10*time.Minute))
+		assert.ErrorIs(t, err, ErrInvalidTimestamp)
+	})
+}
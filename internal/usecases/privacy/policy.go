@@ -0,0 +1,113 @@
+package privacy
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var ErrPolicyVersionMismatch = errors.New("a versão aceita não é a versão vigente da política")
+
+type PolicyService interface {
+	// Status retorna a versão vigente e se o usuário já a aceitou
+	Status(userID int) (*domain.PolicyStatus, error)
+	// Accept registra o aceite da versão vigente, que precisa ser a informada pelo cliente
+	Accept(userID int, version string, client domain.SessionClient) (*domain.PolicyStatus, error)
+	// HasAcceptedCurrent informa se o usuário aceitou a versão vigente. Sem versão configurada, sempre aceita.
+	HasAcceptedCurrent(userID int) (bool, error)
+}
+
+type policyService struct {
+	policyRepo repository.PolicyRepository
+	version    string
+	url        string
+	now        func() time.Time
+
+	// accepted guarda os usuários que já aceitaram a versão vigente. Como o aceite não é desfeito, evita
+	// uma consulta ao banco em cada requisição; a versão só muda com o reinício da aplicação.
+	mu       sync.RWMutex
+	accepted map[int]bool
+}
+
+// NewPolicyService cria o serviço de aceite dos termos de uso e da política de privacidade na versão
+// POLICY_VERSION. Sem versão configurada, o aceite não é exigido.
+func NewPolicyService(policyRepo repository.PolicyRepository, cfg *config.Config) PolicyService {
+	return &policyService{
+		policyRepo: policyRepo,
+		version:    strings.TrimSpace(cfg.Policy.Version),
+		url:        cfg.Policy.URL,
+		now:        time.Now,
+		accepted:   make(map[int]bool),
+	}
+}
+
+func (s *policyService) Status(userID int) (*domain.PolicyStatus, error) {
+	status := &domain.PolicyStatus{Version: s.version, URL: s.url}
+	if s.version == "" {
+		status.Accepted = true
+		return status, nil
+	}
+
+	acceptance, err := s.policyRepo.GetPolicyAcceptance(userID, s.version)
+	if err != nil {
+		return nil, err
+	}
+	if acceptance != nil {
+		status.Accepted = true
+		status.AcceptedAt = &acceptance.AcceptedAt
+		s.markAccepted(userID)
+	}
+
+	return status, nil
+}
+
+func (s *policyService) Accept(userID int, version string, client domain.SessionClient) (*domain.PolicyStatus, error) {
+	if s.version == "" {
+		return s.Status(userID)
+	}
+	if strings.TrimSpace(version) != s.version {
+		return nil, ErrPolicyVersionMismatch
+	}
+
+	if err := s.policyRepo.AcceptPolicy(&domain.PolicyAcceptance{
+		UserID:     userID,
+		Version:    s.version,
+		AcceptedAt: s.now(),
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.Status(userID)
+}
+
+func (s *policyService) HasAcceptedCurrent(userID int) (bool, error) {
+	if s.version == "" {
+		return true, nil
+	}
+
+	s.mu.RLock()
+	accepted := s.accepted[userID]
+	s.mu.RUnlock()
+	if accepted {
+		return true, nil
+	}
+
+	status, err := s.Status(userID)
+	if err != nil {
+		return false, err
+	}
+	return status.Accepted, nil
+}
+
+func (s *policyService) markAccepted(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accepted[userID] = true
+}
@@ -0,0 +1,65 @@
+package privacy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/config"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPolicyService_WithoutVersion(t *testing.T) {
+	service := NewPolicyService(nil, &config.Config{})
+
+	accepted, err := service.HasAcceptedCurrent(1)
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+}
+
+func TestPolicyService_Accept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockPolicyRepository(ctrl)
+	service := NewPolicyService(repo, &config.Config{Policy: config.Policy{Version: "2026-10", URL: "https://example.com/termos"}})
+
+	t.Run("versão diferente da vigente", func(t *testing.T) {
+		_, err := service.Accept(1, "2025-01", domain.SessionClient{})
+		assert.ErrorIs(t, err, ErrPolicyVersionMismatch)
+	})
+
+	t.Run("bloqueia até o aceite", func(t *testing.T) {
+		repo.EXPECT().GetPolicyAcceptance(1, "2026-10").Return(nil, nil)
+
+		accepted, err := service.HasAcceptedCurrent(1)
+		assert.NoError(t, err)
+		assert.False(t, accepted)
+	})
+
+	t.Run("registra o aceite com o IP e o User-Agent", func(t *testing.T) {
+		acceptedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		repo.EXPECT().AcceptPolicy(gomock.Any()).DoAndReturn(func(acceptance *domain.PolicyAcceptance) error {
+			assert.Equal(t, 1, acceptance.UserID)
+			assert.Equal(t, "2026-10", acceptance.Version)
+			assert.Equal(t, "10.0.0.1", acceptance.IPAddress)
+			assert.Equal(t, "Firefox", acceptance.UserAgent)
+			return nil
+		})
+		repo.EXPECT().GetPolicyAcceptance(1, "2026-10").Return(&domain.PolicyAcceptance{AcceptedAt: acceptedAt}, nil)
+
+		status, err := service.Accept(1, "2026-10", domain.SessionClient{IPAddress: "10.0.0.1", UserAgent: "Firefox"})
+		assert.NoError(t, err)
+		assert.True(t, status.Accepted)
+		assert.Equal(t, &acceptedAt, status.AcceptedAt)
+		assert.Equal(t, "https://example.com/termos", status.URL)
+	})
+
+	t.Run("aceite fica em cache", func(t *testing.T) {
+		accepted, err := service.HasAcceptedCurrent(1)
+		assert.NoError(t, err)
+		assert.True(t, accepted)
+	})
+}
@@ -0,0 +1,170 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	ErrMissingSubject = errors.New("informe o e-mail ou o CPF do titular")
+	ErrInvalidEmail   = errors.New("e-mail do titular inválido")
+	ErrInvalidCPF     = errors.New("CPF do titular inválido")
+	// ErrCPFNotSearchable é retornado para pedidos só com CPF: nenhuma origem guarda o CPF do titular,
+	// então o pedido não localizaria dado algum
+	ErrCPFNotSearchable = errors.New("nenhuma origem de dados é pesquisável por CPF; informe o e-mail do titular")
+)
+
+type ErasureService interface {
+	Erase(requestedBy int, req *domain.ErasureRequest) (*domain.ErasureRecord, error)
+	ListErasures(limit int) ([]*domain.ErasureRecord, error)
+}
+
+// subject é o titular normalizado
+type subject struct {
+	email string
+	cpf   string
+}
+
+// anonymizer anonimiza os dados do titular em uma origem e retorna a quantidade de registros afetados
+type anonymizer struct {
+	name string
+	run  func(s subject) (int, error)
+}
+
+type Service struct {
+	privacyRepo repository.PrivacyRepository
+	anonymizers []anonymizer
+}
+
+// NewService cria o serviço de eliminação de dados pessoais.
+// Vendas e leads são armazenados apenas de forma agregada por conta e dia (sales_insights e
+// monthly_sales_insights), sem identificação de clientes, então os agregados não precisam ser alterados.
+// Novas origens que guardem dados de clientes devem registrar aqui o seu anonimizador.
+func NewService(privacyRepo repository.PrivacyRepository) ErasureService {
+	s := &Service{privacyRepo: privacyRepo}
+
+	s.anonymizers = []anonymizer{
+		{
+			name: "users",
+			run: func(sub subject) (int, error) {
+				if sub.email == "" {
+					return 0, nil
+				}
+				return privacyRepo.AnonymizeUsersByEmail(sub.email)
+			},
+		},
+	}
+
+	return s
+}
+
+// Erase registra o pedido e anonimiza os dados do titular em todas as origens conhecidas. Quando nenhuma
+// origem tem dados do titular, o pedido é registrado como not_found em vez de completed.
+func (s *Service) Erase(requestedBy int, req *domain.ErasureRequest) (*domain.ErasureRecord, error) {
+	sub, err := normalizeSubject(req)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.privacyRepo.CreateErasureRecord(&domain.ErasureRecord{
+		SubjectHash: hashSubject(sub),
+		RequestedBy: &requestedBy,
+		Reason:      req.Reason,
+		Status:      domain.ErasureStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record.Details = make(map[string]int, len(s.anonymizers))
+	record.Status = domain.ErasureStatusCompleted
+
+	var runErr error
+	for _, a := range s.anonymizers {
+		count, err := a.run(sub)
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"erasure_id": record.ID,
+				"source":     a.name,
+			}).Error("privacy: erro ao anonimizar dados do titular")
+			record.Status = domain.ErasureStatusFailed
+			runErr = fmt.Errorf("erro ao anonimizar %s: %w", a.name, err)
+			break
+		}
+
+		record.Details[a.name] = count
+		record.RecordsAnonymized += count
+	}
+
+	if record.Status == domain.ErasureStatusCompleted && record.RecordsAnonymized == 0 {
+		record.Status = domain.ErasureStatusNotFound
+	}
+
+	if err := s.privacyRepo.CompleteErasureRecord(record); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"erasure_id":         record.ID,
+		"status":             record.Status,
+		"records_anonymized": record.RecordsAnonymized,
+	}).Info("privacy: pedido de eliminação processado")
+
+	if runErr != nil {
+		return record, runErr
+	}
+
+	return record, nil
+}
+
+func (s *Service) ListErasures(limit int) ([]*domain.ErasureRecord, error) {
+	return s.privacyRepo.ListErasureRecords(limit)
+}
+
+func normalizeSubject(req *domain.ErasureRequest) (subject, error) {
+	var sub subject
+
+	if email := strings.ToLower(strings.TrimSpace(req.Email)); email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return sub, ErrInvalidEmail
+		}
+		sub.email = email
+	}
+
+	if req.CPF != "" {
+		cpf := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, req.CPF)
+		if len(cpf) != 11 {
+			return sub, ErrInvalidCPF
+		}
+		sub.cpf = cpf
+	}
+
+	if sub.email == "" && sub.cpf == "" {
+		return sub, ErrMissingSubject
+	}
+	if sub.email == "" {
+		return sub, ErrCPFNotSearchable
+	}
+
+	return sub, nil
+}
+
+// hashSubject gera o identificador do titular guardado no registro do pedido
+func hashSubject(sub subject) string {
+	sum := sha256.Sum256([]byte("email:" + sub.email + "|cpf:" + sub.cpf))
+	return hex.EncodeToString(sum[:])
+}
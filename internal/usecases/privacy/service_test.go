@@ -0,0 +1,78 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Erase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockPrivacyRepository(ctrl)
+	service := NewService(repo)
+
+	created := func(record *domain.ErasureRecord) (*domain.ErasureRecord, error) {
+		assert.Equal(t, domain.ErasureStatusPending, record.Status)
+		assert.Len(t, record.SubjectHash, 64)
+		record.ID = 7
+		return record, nil
+	}
+
+	t.Run("anonimiza o usuário pelo e-mail", func(t *testing.T) {
+		repo.EXPECT().CreateErasureRecord(gomock.Any()).DoAndReturn(created)
+		repo.EXPECT().AnonymizeUsersByEmail("titular@example.com").Return(1, nil)
+		repo.EXPECT().CompleteErasureRecord(gomock.Any()).Return(nil)
+
+		record, err := service.Erase(1, &domain.ErasureRequest{Email: " Titular@Example.com "})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ErasureStatusCompleted, record.Status)
+		assert.Equal(t, 1, record.RecordsAnonymized)
+		assert.Equal(t, map[string]int{"users": 1}, record.Details)
+	})
+
+	t.Run("titular sem dados é registrado como não encontrado", func(t *testing.T) {
+		repo.EXPECT().CreateErasureRecord(gomock.Any()).DoAndReturn(created)
+		repo.EXPECT().AnonymizeUsersByEmail("ausente@example.com").Return(0, nil)
+		repo.EXPECT().CompleteErasureRecord(gomock.Any()).DoAndReturn(func(record *domain.ErasureRecord) error {
+			assert.Equal(t, domain.ErasureStatusNotFound, record.Status)
+			return nil
+		})
+
+		record, err := service.Erase(1, &domain.ErasureRequest{Email: "ausente@example.com", CPF: "123.456.789-09"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ErasureStatusNotFound, record.Status)
+		assert.Equal(t, 0, record.RecordsAnonymized)
+	})
+
+	t.Run("pedido só com CPF é recusado sem registro", func(t *testing.T) {
+		_, err := service.Erase(1, &domain.ErasureRequest{CPF: "123.456.789-09"})
+		assert.ErrorIs(t, err, ErrCPFNotSearchable)
+	})
+
+	t.Run("dados do titular inválidos", func(t *testing.T) {
+		_, err := service.Erase(1, &domain.ErasureRequest{})
+		assert.ErrorIs(t, err, ErrMissingSubject)
+
+		_, err = service.Erase(1, &domain.ErasureRequest{Email: "titular"})
+		assert.ErrorIs(t, err, ErrInvalidEmail)
+
+		_, err = service.Erase(1, &domain.ErasureRequest{Email: "titular@example.com", CPF: "123"})
+		assert.ErrorIs(t, err, ErrInvalidCPF)
+	})
+
+	t.Run("falha na anonimização fica registrada", func(t *testing.T) {
+		repo.EXPECT().CreateErasureRecord(gomock.Any()).DoAndReturn(created)
+		repo.EXPECT().AnonymizeUsersByEmail("titular@example.com").Return(0, errors.New("conexão perdida"))
+		repo.EXPECT().CompleteErasureRecord(gomock.Any()).Return(nil)
+
+		record, err := service.Erase(1, &domain.ErasureRequest{Email: "titular@example.com"})
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErasureStatusFailed, record.Status)
+	})
+}
@@ -0,0 +1,105 @@
+package profile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/storage"
+	"github.com/vfg2006/traffic-manager-api/pkg/imaging"
+)
+
+const defaultAvatarSize = 256
+
+var (
+	ErrStorageUnavailable = errors.New("upload de arquivos não configurado")
+	ErrUserNotFound       = errors.New("usuário não encontrado")
+)
+
+type AvatarService interface {
+	UploadAvatar(ctx context.Context, userID int, image []byte) (string, error)
+}
+
+type avatarService struct {
+	userRepo repository.UserRepository
+	storage  storage.Storage
+	size     int
+}
+
+// NewAvatarService cria o serviço de avatar. Com storage nil o upload retorna ErrStorageUnavailable.
+func NewAvatarService(userRepo repository.UserRepository, store storage.Storage, size int) AvatarService {
+	if size <= 0 {
+		size = defaultAvatarSize
+	}
+
+	return &avatarService{
+		userRepo: userRepo,
+		storage:  store,
+		size:     size,
+	}
+}
+
+// UploadAvatar redimensiona a imagem para o tamanho padrão, grava no storage com uma chave nova
+// (evitando cache de CDN da imagem anterior), atualiza o usuário e remove o arquivo antigo
+func (s *avatarService) UploadAvatar(ctx context.Context, userID int, image []byte) (string, error) {
+	if s.storage == nil {
+		return "", ErrStorageUnavailable
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar usuário: %w", err)
+	}
+
+	resized, err := imaging.SquareJPEG(image, s.size)
+	if err != nil {
+		return "", err
+	}
+
+	suffix, err := gonanoid.New(12)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar nome do arquivo: %w", err)
+	}
+
+	key := "avatars/" + strconv.Itoa(userID) + "/" + suffix + ".jpg"
+	if err := s.storage.Put(ctx, key, resized, "image/jpeg"); err != nil {
+		return "", fmt.Errorf("erro ao enviar avatar para o storage: %w", err)
+	}
+
+	avatarURL := s.storage.URL(key)
+	if err := s.userRepo.UpdateAvatarURL(userID, avatarURL); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+
+	if user.AvatarURL != nil {
+		s.removePrevious(ctx, userID, *user.AvatarURL)
+	}
+
+	return avatarURL, nil
+}
+
+// removePrevious apaga o avatar anterior quando ele foi gerado por este serviço
+func (s *avatarService) removePrevious(ctx context.Context, userID int, previousURL string) {
+	keyPrefix := "avatars/" + strconv.Itoa(userID) + "/"
+	urlPrefix := s.storage.URL(keyPrefix)
+	if !strings.HasPrefix(previousURL, urlPrefix) {
+		return
+	}
+
+	key := keyPrefix + strings.TrimPrefix(previousURL, urlPrefix)
+	if err := s.storage.Delete(ctx, key); err != nil {
+		logrus.WithError(err).WithField("key", key).Warn("Não foi possível remover o avatar anterior")
+	}
+}
@@ -0,0 +1,108 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	maxFavoriteAccounts    = 50
+	maxDashboardLayoutSize = 64 * 1024
+)
+
+var ErrInvalidPreferences = errors.New("preferências inválidas")
+
+type PreferencesService interface {
+	GetPreferences(userID int) (*domain.UserPreferences, error)
+	UpdatePreferences(userID int, unrestricted bool, preferences *domain.UserPreferences) (*domain.UserPreferences, error)
+}
+
+type preferencesService struct {
+	preferencesRepo repository.PreferencesRepository
+	userRepo        repository.UserRepository
+}
+
+func NewPreferencesService(preferencesRepo repository.PreferencesRepository, userRepo repository.UserRepository) PreferencesService {
+	return &preferencesService{
+		preferencesRepo: preferencesRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// GetPreferences retorna as preferências salvas ou os valores padrão
+func (s *preferencesService) GetPreferences(userID int) (*domain.UserPreferences, error) {
+	preferences, err := s.preferencesRepo.GetUserPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	if preferences == nil {
+		return domain.DefaultUserPreferences(), nil
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences valida e substitui as preferências do usuário. Campos vazios assumem o valor padrão.
+// Sem unrestricted (admin/supervisor), as contas favoritas precisam estar vinculadas ao usuário.
+func (s *preferencesService) UpdatePreferences(userID int, unrestricted bool, preferences *domain.UserPreferences) (*domain.UserPreferences, error) {
+	defaults := domain.DefaultUserPreferences()
+	if preferences.DefaultPeriod == "" {
+		preferences.DefaultPeriod = defaults.DefaultPeriod
+	}
+	if preferences.CurrencyFormat == "" {
+		preferences.CurrencyFormat = defaults.CurrencyFormat
+	}
+	if len(preferences.DashboardLayout) == 0 {
+		preferences.DashboardLayout = defaults.DashboardLayout
+	}
+
+	if !slices.Contains(domain.PeriodPresets, preferences.DefaultPeriod) {
+		return nil, fmt.Errorf("%w: default_period deve ser um de %s", ErrInvalidPreferences, strings.Join(domain.PeriodPresets, ", "))
+	}
+
+	if !slices.Contains(domain.CurrencyFormats, preferences.CurrencyFormat) {
+		return nil, fmt.Errorf("%w: currency_format deve ser um de %s", ErrInvalidPreferences, strings.Join(domain.CurrencyFormats, ", "))
+	}
+
+	if len(preferences.DashboardLayout) > maxDashboardLayoutSize {
+		return nil, fmt.Errorf("%w: dashboard_layout excede %d bytes", ErrInvalidPreferences, maxDashboardLayoutSize)
+	}
+	if !json.Valid(preferences.DashboardLayout) {
+		return nil, fmt.Errorf("%w: dashboard_layout deve ser um JSON válido", ErrInvalidPreferences)
+	}
+
+	favorites := make([]string, 0, len(preferences.FavoriteAccounts))
+	for _, accountID := range preferences.FavoriteAccounts {
+		accountID = strings.TrimSpace(accountID)
+		if accountID != "" && !slices.Contains(favorites, accountID) {
+			favorites = append(favorites, accountID)
+		}
+	}
+	if len(favorites) > maxFavoriteAccounts {
+		return nil, fmt.Errorf("%w: no máximo %d contas favoritas", ErrInvalidPreferences, maxFavoriteAccounts)
+	}
+	preferences.FavoriteAccounts = favorites
+
+	if !unrestricted && len(favorites) > 0 {
+		linked, err := s.userRepo.GetUserLinkedAccounts(userID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar contas vinculadas: %w", err)
+		}
+		for _, accountID := range favorites {
+			if !slices.Contains(linked, accountID) {
+				return nil, fmt.Errorf("%w: conta %s não está vinculada ao usuário", ErrInvalidPreferences, accountID)
+			}
+		}
+	}
+
+	if err := s.preferencesRepo.SaveUserPreferences(userID, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
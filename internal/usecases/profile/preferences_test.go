@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preferencesRepo := mocks.NewMockPreferencesRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewPreferencesService(preferencesRepo, userRepo)
+
+	userRepo.EXPECT().GetUserLinkedAccounts(1).Return([]string{"abc123", "def456"}, nil).AnyTimes()
+
+	t.Run("aplica padrões e remove favoritas duplicadas", func(t *testing.T) {
+		preferencesRepo.EXPECT().SaveUserPreferences(1, gomock.Any()).Return(nil)
+
+		saved, err := service.UpdatePreferences(1, false, &domain.UserPreferences{
+			FavoriteAccounts: []string{"abc123", " abc123", ""},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PeriodLast30Days, saved.DefaultPeriod)
+		assert.Equal(t, domain.CurrencyFormatFull, saved.CurrencyFormat)
+		assert.Equal(t, []string{"abc123"}, saved.FavoriteAccounts)
+	})
+
+	t.Run("rejeita período desconhecido", func(t *testing.T) {
+		_, err := service.UpdatePreferences(1, false, &domain.UserPreferences{DefaultPeriod: "last_year"})
+		assert.ErrorIs(t, err, ErrInvalidPreferences)
+	})
+
+	t.Run("rejeita layout que não é JSON", func(t *testing.T) {
+		_, err := service.UpdatePreferences(1, false, &domain.UserPreferences{DashboardLayout: json.RawMessage("{")})
+		assert.ErrorIs(t, err, ErrInvalidPreferences)
+	})
+
+	t.Run("rejeita favorita não vinculada ao usuário", func(t *testing.T) {
+		_, err := service.UpdatePreferences(1, false, &domain.UserPreferences{FavoriteAccounts: []string{"zzz999"}})
+		assert.ErrorIs(t, err, ErrInvalidPreferences)
+	})
+
+	t.Run("admin pode favoritar qualquer conta", func(t *testing.T) {
+		preferencesRepo.EXPECT().SaveUserPreferences(1, gomock.Any()).Return(nil)
+
+		_, err := service.UpdatePreferences(1, true, &domain.UserPreferences{FavoriteAccounts: []string{"zzz999"}})
+		assert.NoError(t, err)
+	})
+}
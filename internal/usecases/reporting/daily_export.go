@@ -0,0 +1,199 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// maxExportDays limita o intervalo exportado para que um pedido não leia anos de insights de uma vez
+const maxExportDays = 366
+
+var (
+	ErrInvalidExport   = errors.New("exportação inválida")
+	ErrAccountNotFound = errors.New("conta não encontrada")
+)
+
+// dailyExportColumn associa a coluna do CSV ao campo JSON equivalente, usado para aplicar as regras de
+// role_masked_fields do perfil também ao arquivo exportado
+type dailyExportColumn struct {
+	header string
+	field  string
+}
+
+var dailyExportColumns = []dailyExportColumn{
+	{header: "data"},
+	{header: "investimento", field: "spend"},
+	{header: "impressoes", field: "impressions"},
+	{header: "alcance", field: "reach"},
+	{header: "frequencia", field: "frequency"},
+	{header: "resultados", field: "result"},
+	{header: "custo_por_resultado", field: "cost_per_result"},
+	{header: "faturamento_redes_sociais"},
+	{header: "vendas_redes_sociais"},
+	{header: "faturamento_loja"},
+	{header: "vendas_loja"},
+}
+
+// DailyInsightExporter exporta os insights diários armazenados de uma conta
+type DailyInsightExporter interface {
+	// PrepareDailyExport valida o pedido e carrega os insights do intervalo; accountID aceita o ID interno ou o external_id
+	PrepareDailyExport(accountID string, start, end time.Time) (*DailyExport, error)
+}
+
+// DailyExport são os insights diários carregados para exportação. Separar a carga da escrita permite
+// responder com erro antes de começar a enviar o arquivo.
+type DailyExport struct {
+	Account *domain.AdAccount
+	Start   time.Time
+	End     time.Time
+
+	adByDate    map[string]*domain.AdAccountMetrics
+	salesByDate map[string]map[string]*domain.SalesMetrics
+}
+
+type dailyExportService struct {
+	accountRepo      repository.AccountRepository
+	adInsightRepo    repository.AdInsightRepository
+	salesInsightRepo repository.SalesInsightRepository
+}
+
+func NewDailyExportService(
+	accountRepo repository.AccountRepository,
+	adInsightRepo repository.AdInsightRepository,
+	salesInsightRepo repository.SalesInsightRepository,
+) DailyInsightExporter {
+	return &dailyExportService{
+		accountRepo:      accountRepo,
+		adInsightRepo:    adInsightRepo,
+		salesInsightRepo: salesInsightRepo,
+	}
+}
+
+func (s *dailyExportService) PrepareDailyExport(accountID string, start, end time.Time) (*DailyExport, error) {
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: a data de início não pode ser posterior à data de fim", ErrInvalidExport)
+	}
+	if end.Sub(start) >= maxExportDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: o intervalo máximo é de %d dias", ErrInvalidExport, maxExportDays)
+	}
+
+	account, err := s.accountRepo.GetAccountByID(accountID)
+	if err == nil && account == nil {
+		account, err = s.accountRepo.GetAccountByExternalID(accountID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar conta: %w", err)
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	adInsights, err := s.adInsightRepo.GetByDateRange(account.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights de anúncios: %w", err)
+	}
+	salesInsights, err := s.salesInsightRepo.GetByDateRange(account.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights de vendas: %w", err)
+	}
+
+	export := &DailyExport{
+		Account:     account,
+		Start:       start,
+		End:         end,
+		adByDate:    make(map[string]*domain.AdAccountMetrics, len(adInsights)),
+		salesByDate: make(map[string]map[string]*domain.SalesMetrics, len(salesInsights)),
+	}
+	for _, entry := range adInsights {
+		export.adByDate[entry.Date.Format(time.DateOnly)] = entry.AdMetrics
+	}
+	for _, entry := range salesInsights {
+		export.salesByDate[entry.Date.Format(time.DateOnly)] = entry.SalesMetrics
+	}
+
+	return export, nil
+}
+
+// Filename é o nome sugerido para o arquivo exportado
+func (e *DailyExport) Filename() string {
+	return fmt.Sprintf("insights-diarios-%s-%s-%s.csv", e.Account.ID, e.Start.Format(time.DateOnly), e.End.Format(time.DateOnly))
+}
+
+// WriteCSV escreve uma linha por dia do intervalo. Dias sem insights armazenados ficam com as colunas vazias,
+// para diferenciar dado ausente de dia sem veiculação. Contas em pt-* usam ; como separador e vírgula
+// decimal, o formato que as planilhas em português abrem sem conversão. As colunas cujo campo está em
+// hidden (os campos ocultados do perfil) são omitidas do arquivo.
+func (e *DailyExport) WriteCSV(w io.Writer, hidden map[string]bool) error {
+	writer := csv.NewWriter(w)
+
+	decimalComma := strings.HasPrefix(strings.ToLower(e.Account.FormatMetadata().Locale), "pt")
+	if decimalComma {
+		writer.Comma = ';'
+	}
+	number := func(value float64) string {
+		formatted := strconv.FormatFloat(value, 'f', 2, 64)
+		if decimalComma {
+			formatted = strings.Replace(formatted, ".", ",", 1)
+		}
+		return formatted
+	}
+
+	visible := make([]int, 0, len(dailyExportColumns))
+	header := make([]string, 0, len(dailyExportColumns))
+	for i, column := range dailyExportColumns {
+		if column.field != "" && hidden[column.field] {
+			continue
+		}
+		visible = append(visible, i)
+		header = append(header, column.header)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(dailyExportColumns))
+	out := make([]string, len(visible))
+	for date := e.Start; !date.After(e.End); date = date.AddDate(0, 0, 1) {
+		key := date.Format(time.DateOnly)
+		clear(row)
+		row[0] = key
+
+		if ad := e.adByDate[key]; ad != nil {
+			row[1] = number(ad.Spend)
+			row[2] = strconv.Itoa(ad.Impressions)
+			row[3] = strconv.Itoa(ad.Reach)
+			row[4] = number(ad.Frequency)
+			row[5] = strconv.Itoa(ad.Result)
+			row[6] = number(ad.CostPerResult)
+		}
+		if sales := e.salesByDate[key]; sales != nil {
+			if social := sales[domain.SocialNetwork]; social != nil {
+				row[7] = number(social.TotalRevenue)
+				row[8] = strconv.Itoa(social.SalesQuantity)
+			}
+			if store := sales[domain.Store]; store != nil {
+				row[9] = number(store.TotalRevenue)
+				row[10] = strconv.Itoa(store.SalesQuantity)
+			}
+		}
+
+		for i, index := range visible {
+			out[i] = row[index]
+		}
+		if err := writer.Write(out); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,68 @@
+package reporting
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDailyExportService_WriteCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	adInsightRepo := mocks.NewMockAdInsightRepository(ctrl)
+	salesInsightRepo := mocks.NewMockSalesInsightRepository(ctrl)
+	service := NewDailyExportService(accountRepo, adInsightRepo, salesInsightRepo)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.PrepareDailyExport("abc123", end, start)
+	assert.ErrorIs(t, err, ErrInvalidExport)
+
+	_, err = service.PrepareDailyExport("abc123", start, start.AddDate(2, 0, 0))
+	assert.ErrorIs(t, err, ErrInvalidExport)
+
+	accountRepo.EXPECT().GetAccountByID("act_1").Return(nil, nil)
+	accountRepo.EXPECT().GetAccountByExternalID("act_1").Return(&domain.AdAccount{ID: "abc123", ExternalID: "act_1", Locale: "pt-BR"}, nil)
+	adInsightRepo.EXPECT().GetByDateRange("abc123", start, end).Return([]*domain.AdInsightEntry{
+		{Date: start, AdMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 120.5, Impressions: 3000, Reach: 1500, Frequency: 2, Result: 12, CostPerResult: 10.04}}},
+	}, nil)
+	salesInsightRepo.EXPECT().GetByDateRange("abc123", start, end).Return([]*domain.SalesInsightEntry{
+		{Date: start, SalesMetrics: map[string]*domain.SalesMetrics{domain.SocialNetwork: {TotalRevenue: 899.9, SalesQuantity: 2}}},
+		{Date: end, SalesMetrics: map[string]*domain.SalesMetrics{domain.Store: {TotalRevenue: 450, SalesQuantity: 1}}},
+	}, nil)
+
+	export, err := service.PrepareDailyExport("act_1", start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, "insights-diarios-abc123-2025-03-01-2025-03-03.csv", export.Filename())
+
+	var buf bytes.Buffer
+	assert.NoError(t, export.WriteCSV(&buf, nil))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "data;investimento;impressoes"))
+	assert.Equal(t, "2025-03-01;120,50;3000;1500;2,00;12;10,04;899,90;2;;", lines[1])
+	// Dia sem insights armazenados fica com as colunas vazias
+	assert.Equal(t, "2025-03-02;;;;;;;;;;", lines[2])
+	assert.Equal(t, "2025-03-03;;;;;;;;;450,00;1", lines[3])
+
+	// Perfis com investimento e custos ocultos recebem o arquivo sem essas colunas
+	buf.Reset()
+	assert.NoError(t, export.WriteCSV(&buf, map[string]bool{"spend": true, "cost_per_result": true}))
+
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "data;impressoes;alcance;frequencia;resultados;faturamento_redes_sociais;vendas_redes_sociais;faturamento_loja;vendas_loja", lines[0])
+	assert.Equal(t, "2025-03-01;3000;1500;2,00;12;899,90;2;;", lines[1])
+	assert.Equal(t, "2025-03-03;;;;;;;450,00;1", lines[3])
+	assert.NotContains(t, buf.String(), "120,50")
+}
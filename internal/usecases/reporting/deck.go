@@ -0,0 +1,324 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/pptx"
+)
+
+// trendMonths é a quantidade de meses exibidos nos gráficos de tendência (incluindo o mês do relatório)
+const trendMonths = 6
+
+// DeckGenerator gera apresentações para a reunião mensal das franquias
+type DeckGenerator interface {
+	GenerateMonthlyDeck(period string, businessManagerID string) ([]byte, error)
+}
+
+// DeckService monta a apresentação mensal a partir das tabelas de insights mensais
+type DeckService struct {
+	accountRepo             repository.AccountRepository
+	monthlyAdInsightRepo    repository.MonthlyAdInsightRepository
+	monthlySalesInsightRepo repository.MonthlySalesInsightRepository
+}
+
+// NewDeckService cria uma nova instância do gerador de apresentações
+func NewDeckService(
+	accountRepo repository.AccountRepository,
+	monthlyAdInsightRepo repository.MonthlyAdInsightRepository,
+	monthlySalesInsightRepo repository.MonthlySalesInsightRepository,
+) DeckGenerator {
+	return &DeckService{
+		accountRepo:             accountRepo,
+		monthlyAdInsightRepo:    monthlyAdInsightRepo,
+		monthlySalesInsightRepo: monthlySalesInsightRepo,
+	}
+}
+
+// accountMonth agrega as métricas de uma conta em um mês
+type accountMonth struct {
+	Spend                float64
+	Result               int
+	SocialNetworkRevenue float64
+	SocialNetworkSales   int
+	StoreRevenue         float64
+}
+
+// accountSummary representa uma conta com as métricas dos meses do relatório
+type accountSummary struct {
+	Account *domain.AdAccount
+	Months  map[string]*accountMonth
+}
+
+// businessManagerGroup agrupa as contas de um business manager
+type businessManagerGroup struct {
+	ID       string
+	Name     string
+	Accounts []*accountSummary
+}
+
+// GenerateMonthlyDeck gera o .pptx do período (mm-yyyy) com KPIs, tendências e ranking por business manager.
+// Se businessManagerID for vazio, todos os business managers com contas ativas são incluídos.
+func (s *DeckService) GenerateMonthlyDeck(period string, businessManagerID string) ([]byte, error) {
+	reference, err := time.Parse("01-2006", period)
+	if err != nil {
+		return nil, fmt.Errorf("período inválido %q: %w", period, err)
+	}
+
+	accounts, err := s.accountRepo.ListAccounts([]domain.AdAccountStatus{domain.AdAccountStatusActive})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar contas: %w", err)
+	}
+
+	periods := trendPeriods(reference)
+	startDate := reference.AddDate(0, -(trendMonths - 1), 0)
+
+	groups := make(map[string]*businessManagerGroup)
+	for _, acc := range accounts {
+		if businessManagerID != "" && acc.BusinessManagerID != businessManagerID {
+			continue
+		}
+
+		summary, err := s.loadAccountSummary(acc, startDate, reference)
+		if err != nil {
+			logrus.WithError(err).WithField("account_id", acc.ID).Error("Erro ao carregar insights mensais da conta para apresentação")
+			continue
+		}
+
+		group, ok := groups[acc.BusinessManagerID]
+		if !ok {
+			group = &businessManagerGroup{ID: acc.BusinessManagerID, Name: acc.BusinessManagerName}
+			groups[acc.BusinessManagerID] = group
+		}
+		group.Accounts = append(group.Accounts, summary)
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("nenhuma conta ativa encontrada para o business manager informado")
+	}
+
+	ordered := make([]*businessManagerGroup, 0, len(groups))
+	for _, g := range groups {
+		ordered = append(ordered, g)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	deck := pptx.New()
+	addCoverSlide(deck, period, ordered)
+
+	for _, group := range ordered {
+		addKPISlide(deck, group, period)
+		addTrendSlide(deck, group, periods)
+		addRankingSlide(deck, group, period)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"period":            period,
+		"business_managers": len(ordered),
+		"slides":            deck.SlideCount(),
+	}).Info("Apresentação mensal gerada com sucesso")
+
+	return deck.Bytes()
+}
+
+// loadAccountSummary carrega os insights mensais de anúncios e vendas de uma conta
+func (s *DeckService) loadAccountSummary(acc *domain.AdAccount, startDate, endDate time.Time) (*accountSummary, error) {
+	summary := &accountSummary{
+		Account: acc,
+		Months:  make(map[string]*accountMonth),
+	}
+
+	month := func(period string) *accountMonth {
+		m, ok := summary.Months[period]
+		if !ok {
+			m = &accountMonth{}
+			summary.Months[period] = m
+		}
+		return m
+	}
+
+	adInsights, err := s.monthlyAdInsightRepo.GetByPeriodRange(acc.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights mensais de anúncios: %w", err)
+	}
+
+	for _, insight := range adInsights {
+		if insight.AdMetrics == nil {
+			continue
+		}
+		m := month(insight.Period)
+		m.Spend = insight.AdMetrics.Spend
+		m.Result = insight.AdMetrics.Result
+	}
+
+	salesInsights, err := s.monthlySalesInsightRepo.GetByPeriodRange(acc.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights mensais de vendas: %w", err)
+	}
+
+	for _, insight := range salesInsights {
+		m := month(insight.Period)
+		if sn := insight.SalesMetrics[domain.SocialNetwork]; sn != nil {
+			m.SocialNetworkRevenue = sn.TotalRevenue
+			m.SocialNetworkSales = sn.SalesQuantity
+		}
+		if store := insight.SalesMetrics[domain.Store]; store != nil {
+			m.StoreRevenue = store.TotalRevenue
+		}
+	}
+
+	return summary, nil
+}
+
+// total soma as métricas de todas as contas do grupo em um período
+func (g *businessManagerGroup) total(period string) accountMonth {
+	var total accountMonth
+	for _, acc := range g.Accounts {
+		m, ok := acc.Months[period]
+		if !ok {
+			continue
+		}
+		total.Spend += m.Spend
+		total.Result += m.Result
+		total.SocialNetworkRevenue += m.SocialNetworkRevenue
+		total.SocialNetworkSales += m.SocialNetworkSales
+		total.StoreRevenue += m.StoreRevenue
+	}
+	return total
+}
+
+func addCoverSlide(deck *pptx.Presentation, period string, groups []*businessManagerGroup) {
+	slide := deck.AddSlide("")
+	slide.AddRect(0, 0, pptx.SlideWidth, pptx.SlideHeight, "1F4E79")
+	slide.AddText(pptx.EMUPerCm*2, pptx.EMUPerCm*6, pptx.SlideWidth-pptx.EMUPerCm*4, pptx.EMUPerCm*3,
+		"Reunião mensal de franquias", pptx.TextOptions{Size: 40, Bold: true, Color: "FFFFFF"})
+	slide.AddText(pptx.EMUPerCm*2, pptx.EMUPerCm*9, pptx.SlideWidth-pptx.EMUPerCm*4, pptx.EMUPerCm*2,
+		fmt.Sprintf("Período %s • %d business manager(s)", period, len(groups)), pptx.TextOptions{Size: 20, Color: "FFFFFF"})
+}
+
+func addKPISlide(deck *pptx.Presentation, group *businessManagerGroup, period string) {
+	slide := deck.AddSlide(fmt.Sprintf("%s • Indicadores de %s", group.Name, period))
+	total := group.total(period)
+
+	costPerResult := 0.0
+	if total.Result > 0 {
+		costPerResult = total.Spend / float64(total.Result)
+	}
+	roi := 0.0
+	if total.Spend > 0 {
+		roi = total.SocialNetworkRevenue / total.Spend
+	}
+
+	kpis := []struct {
+		label string
+		value string
+	}{
+		{"Investimento", formatCurrency(total.Spend)},
+		{"Resultados", fmt.Sprintf("%d", total.Result)},
+		{"Custo por resultado", formatCurrency(costPerResult)},
+		{"Faturamento redes sociais", formatCurrency(total.SocialNetworkRevenue)},
+		{"Vendas redes sociais", fmt.Sprintf("%d", total.SocialNetworkSales)},
+		{"ROI", fmt.Sprintf("%.1fx", roi)},
+	}
+
+	const cols = 3
+	cardWidth := (pptx.SlideWidth - pptx.EMUPerCm*4) / cols
+	cardHeight := pptx.EMUPerCm * 5
+
+	for i, kpi := range kpis {
+		x := pptx.EMUPerCm + int64(i%cols)*(cardWidth+pptx.EMUPerCm)
+		y := pptx.EMUPerCm*3 + int64(i/cols)*(cardHeight+pptx.EMUPerCm)
+
+		slide.AddRect(x, y, cardWidth, cardHeight, "EAF1F8")
+		slide.AddText(x, y+pptx.EMUPerCm/2, cardWidth, pptx.EMUPerCm*2, kpi.value, pptx.TextOptions{Size: 28, Bold: true, Color: "1F4E79", Align: "ctr"})
+		slide.AddText(x, y+pptx.EMUPerCm*3, cardWidth, pptx.EMUPerCm*1, kpi.label, pptx.TextOptions{Size: 14, Color: "595959", Align: "ctr"})
+	}
+}
+
+func addTrendSlide(deck *pptx.Presentation, group *businessManagerGroup, periods []string) {
+	slide := deck.AddSlide(fmt.Sprintf("%s • Tendência dos últimos %d meses", group.Name, len(periods)))
+
+	spend := make([]pptx.Bar, 0, len(periods))
+	revenue := make([]pptx.Bar, 0, len(periods))
+	for _, p := range periods {
+		total := group.total(p)
+		spend = append(spend, pptx.Bar{Label: p, Value: total.Spend})
+		revenue = append(revenue, pptx.Bar{Label: p, Value: total.SocialNetworkRevenue})
+	}
+
+	chartWidth := (pptx.SlideWidth - pptx.EMUPerCm*3) / 2
+	chartHeight := pptx.SlideHeight - pptx.EMUPerCm*4
+
+	slide.AddBarChart(pptx.EMUPerCm, pptx.EMUPerCm*3, chartWidth, chartHeight, "Investimento", spend, "2E75B6", formatCompact)
+	slide.AddBarChart(pptx.EMUPerCm*2+chartWidth, pptx.EMUPerCm*3, chartWidth, chartHeight, "Faturamento redes sociais", revenue, "70AD47", formatCompact)
+}
+
+func addRankingSlide(deck *pptx.Presentation, group *businessManagerGroup, period string) {
+	slide := deck.AddSlide(fmt.Sprintf("%s • Ranking de lojas %s", group.Name, period))
+
+	accounts := make([]*accountSummary, len(group.Accounts))
+	copy(accounts, group.Accounts)
+
+	revenue := func(a *accountSummary) float64 {
+		if m, ok := a.Months[period]; ok {
+			return m.SocialNetworkRevenue
+		}
+		return 0
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return revenue(accounts[i]) > revenue(accounts[j])
+	})
+
+	// Limitar a quantidade de linhas para caber no slide
+	const maxRows = 12
+	if len(accounts) > maxRows {
+		accounts = accounts[:maxRows]
+	}
+
+	rows := [][]string{{"#", "Loja", "Faturamento redes sociais", "Investimento", "ROI"}}
+	for i, acc := range accounts {
+		m, ok := acc.Months[period]
+		if !ok {
+			m = &accountMonth{}
+		}
+
+		roi := "-"
+		if m.Spend > 0 {
+			roi = fmt.Sprintf("%.1fx", m.SocialNetworkRevenue/m.Spend)
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			accountName(acc.Account),
+			formatCurrency(m.SocialNetworkRevenue),
+			formatCurrency(m.Spend),
+			roi,
+		})
+	}
+
+	rowHeight := pptx.EMUPerCm * 9 / 10
+	slide.AddTable(pptx.EMUPerCm, pptx.EMUPerCm*3, pptx.SlideWidth-pptx.EMUPerCm*2, rowHeight*int64(len(rows)), rows)
+}
+
+// trendPeriods retorna os períodos (mm-yyyy) do gráfico de tendência, do mais antigo ao mais recente
+func trendPeriods(reference time.Time) []string {
+	periods := make([]string, 0, trendMonths)
+	for i := trendMonths - 1; i >= 0; i-- {
+		month := reference.AddDate(0, -i, 0)
+		periods = append(periods, fmt.Sprintf("%02d-%04d", int(month.Month()), month.Year()))
+	}
+	return periods
+}
+
+func accountName(acc *domain.AdAccount) string {
+	if acc.Nickname != nil && *acc.Nickname != "" {
+		return *acc.Nickname
+	}
+	return acc.Name
+}
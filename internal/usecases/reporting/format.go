@@ -0,0 +1,53 @@
+package reporting
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// formatCurrency formata um valor em reais no padrão brasileiro (ex: R$ 1.234,56)
+func formatCurrency(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	cents := int64(math.Round(value * 100))
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, groupThousands(cents/100), cents%100)
+}
+
+// formatInteger formata inteiros com separador de milhar brasileiro (ex: 12.345)
+func formatInteger(value int64) string {
+	if value < 0 {
+		return "-" + groupThousands(-value)
+	}
+	return groupThousands(value)
+}
+
+func groupThousands(value int64) string {
+	integer := fmt.Sprintf("%d", value)
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return grouped.String()
+}
+
+// formatCompact formata valores grandes de forma abreviada para rótulos de gráficos (ex: 12,3 mil)
+func formatCompact(value float64) string {
+	switch {
+	case value >= 1_000_000:
+		return strings.Replace(fmt.Sprintf("%.1f mi", value/1_000_000), ".", ",", 1)
+	case value >= 1_000:
+		return strings.Replace(fmt.Sprintf("%.1f mil", value/1_000), ".", ",", 1)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
@@ -0,0 +1,119 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/xlsx"
+)
+
+// MonthlyInsightsProvider fornece os relatórios mensais de todas as contas ativas
+type MonthlyInsightsProvider interface {
+	GetMonthlyInsightsByPeriod(period string) ([]*domain.MonthlyInsightReport, error)
+}
+
+// WorkbookGenerator gera planilhas com os relatórios mensais
+type WorkbookGenerator interface {
+	GenerateMonthlyWorkbook(period string) ([]byte, error)
+}
+
+// WorkbookService monta a planilha mensal a partir dos relatórios de GetMonthlyInsightsByPeriod
+type WorkbookService struct {
+	insights MonthlyInsightsProvider
+}
+
+// NewWorkbookService cria uma nova instância do gerador de planilhas
+func NewWorkbookService(insights MonthlyInsightsProvider) WorkbookGenerator {
+	return &WorkbookService{insights: insights}
+}
+
+// GenerateMonthlyWorkbook gera o .xlsx do período (mm-yyyy) com as abas Anúncios, Vendas e Resultados,
+// uma linha por conta. Contas sem dados em uma aba ficam de fora apenas dela.
+func (s *WorkbookService) GenerateMonthlyWorkbook(period string) ([]byte, error) {
+	if _, err := time.Parse("01-2006", period); err != nil {
+		return nil, fmt.Errorf("período inválido %q: %w", period, err)
+	}
+
+	reports, err := s.insights.GetMonthlyInsightsByPeriod(period)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar insights mensais: %w", err)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].AccountName < reports[j].AccountName
+	})
+
+	workbook := xlsx.New()
+	addAdsSheet(workbook, reports)
+	addSalesSheet(workbook, reports)
+	addResultsSheet(workbook, reports)
+
+	logrus.WithFields(logrus.Fields{
+		"period":   period,
+		"accounts": len(reports),
+	}).Info("Planilha mensal gerada com sucesso")
+
+	return workbook.Bytes()
+}
+
+func addAdsSheet(workbook *xlsx.Workbook, reports []*domain.MonthlyInsightReport) {
+	sheet := workbook.AddSheet("Anúncios")
+	sheet.AddHeader("Conta", "ID da conta", "Período", "Objetivo", "Investimento", "Impressões", "Alcance", "Frequência", "Resultados", "Custo por resultado")
+
+	for _, report := range reports {
+		ad := report.AdMetrics
+		if ad == nil {
+			continue
+		}
+
+		objective := ad.Objective
+		if ad.ObjectiveInfo != nil {
+			objective = ad.ObjectiveInfo.Label
+		}
+
+		sheet.AddRow(report.AccountName, report.AccountID, report.Period, objective,
+			ad.Spend, ad.Impressions, ad.Reach, ad.Frequency, ad.Result, ad.CostPerResult)
+	}
+}
+
+func addSalesSheet(workbook *xlsx.Workbook, reports []*domain.MonthlyInsightReport) {
+	sheet := workbook.AddSheet("Vendas")
+	sheet.AddHeader("Conta", "ID da conta", "Período", "Origem", "Faturamento", "Quantidade de vendas", "Ticket médio")
+
+	origins := []struct {
+		key   string
+		label string
+	}{
+		{domain.SocialNetwork, "Redes sociais"},
+		{domain.Store, "Loja"},
+	}
+
+	for _, report := range reports {
+		for _, origin := range origins {
+			sales := report.SalesMetrics[origin.key]
+			if sales == nil {
+				continue
+			}
+
+			sheet.AddRow(report.AccountName, report.AccountID, report.Period, origin.label,
+				sales.TotalRevenue, sales.SalesQuantity, sales.AverageTicket)
+		}
+	}
+}
+
+func addResultsSheet(workbook *xlsx.Workbook, reports []*domain.MonthlyInsightReport) {
+	sheet := workbook.AddSheet("Resultados")
+	sheet.AddHeader("Conta", "ID da conta", "Período", "Conversão (%)", "ROI", "ROAS", "ROAS de equilíbrio")
+
+	for _, report := range reports {
+		result := report.ResultMetrics
+		if result == nil {
+			continue
+		}
+
+		sheet.AddRow(report.AccountName, report.AccountID, report.Period, result.Conversion, result.ROI, result.ROAS, result.BreakEvenROAS)
+	}
+}
@@ -0,0 +1,73 @@
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type fakeMonthlyInsights struct {
+	reports []*domain.MonthlyInsightReport
+}
+
+func (f *fakeMonthlyInsights) GetMonthlyInsightsByPeriod(string) ([]*domain.MonthlyInsightReport, error) {
+	return f.reports, nil
+}
+
+func TestWorkbookService_GenerateMonthlyWorkbook(t *testing.T) {
+	service := NewWorkbookService(&fakeMonthlyInsights{reports: []*domain.MonthlyInsightReport{
+		{
+			AccountID:   "b",
+			AccountName: "Loja Centro",
+			Period:      "03-2025",
+			AdMetrics:   &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 120.5, Result: 12}},
+			SalesMetrics: map[string]*domain.SalesMetrics{
+				domain.SocialNetwork: {TotalRevenue: 899.9, SalesQuantity: 2},
+				domain.Store:         {TotalRevenue: 450, SalesQuantity: 1},
+			},
+			ResultMetrics: &domain.ResultMetrics{Conversion: 16.67, ROI: "7x"},
+		},
+		{
+			AccountID:    "a",
+			AccountName:  "Loja <Bairro>",
+			Period:       "03-2025",
+			SalesMetrics: map[string]*domain.SalesMetrics{domain.Store: {TotalRevenue: 100, SalesQuantity: 1}},
+		},
+	}})
+
+	_, err := service.GenerateMonthlyWorkbook("2025-03")
+	assert.Error(t, err)
+
+	content, err := service.GenerateMonthlyWorkbook("03-2025")
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Anúncios" sheetId="1"`)
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Vendas" sheetId="2"`)
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Resultados" sheetId="3"`)
+
+	// Conta sem anúncios fica fora da aba de anúncios, mas aparece nas vendas antes da outra (ordem alfabética)
+	assert.Equal(t, 2, strings.Count(files["xl/worksheets/sheet1.xml"], "<row "))
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="E2"><v>120.5</v></c>`)
+	assert.Equal(t, 4, strings.Count(files["xl/worksheets/sheet2.xml"], "<row "))
+	assert.Less(t, strings.Index(files["xl/worksheets/sheet2.xml"], "Loja &lt;Bairro&gt;"), strings.Index(files["xl/worksheets/sheet2.xml"], "Loja Centro"))
+	assert.Contains(t, files["xl/worksheets/sheet3.xml"], "7x")
+}
@@ -0,0 +1,243 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/pdf"
+)
+
+// Layout das páginas do relatório em PDF, em pontos
+const (
+	pdfMargin       = 40.0
+	pdfHeaderHeight = 60.0
+	pdfRowHeight    = 18.0
+	pdfFooterY      = pdf.PageHeight - 25
+	pdfTableBottom  = pdf.PageHeight - 50
+)
+
+// pdfColumn é uma coluna da tabela de contas; x é a borda esquerda (Conta) ou direita (demais colunas)
+type pdfColumn struct {
+	title string
+	x     float64
+	value func(m accountMonth) string
+}
+
+var pdfAccountColumns = []pdfColumn{
+	{"Investimento", 235, func(m accountMonth) string { return formatCurrency(m.Spend) }},
+	{"Resultados", 290, func(m accountMonth) string { return formatInteger(int64(m.Result)) }},
+	{"Custo/result.", 355, func(m accountMonth) string { return formatCurrency(costPerResult(m)) }},
+	{"Fat. redes", 430, func(m accountMonth) string { return formatCurrency(m.SocialNetworkRevenue) }},
+	{"Vendas redes", 480, func(m accountMonth) string { return formatInteger(int64(m.SocialNetworkSales)) }},
+	{"Fat. loja", 555, func(m accountMonth) string { return formatCurrency(m.StoreRevenue) }},
+}
+
+// RenderMonthlyPDF gera o PDF do relatório mensal com a identidade visual informada. Com uma única conta
+// o relatório detalha anúncios e vendas; com várias, consolida os indicadores e lista as contas.
+func RenderMonthlyPDF(brand PDFBrand, period string, reports []*domain.MonthlyInsightReport, generatedAt time.Time) ([]byte, error) {
+	if brand.Color == "" {
+		brand.Color = "1F4E79"
+	}
+
+	sorted := make([]*domain.MonthlyInsightReport, len(reports))
+	copy(sorted, reports)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].AccountName < sorted[j].AccountName
+	})
+
+	title := fmt.Sprintf("Consolidado • %d contas", len(sorted))
+	if len(sorted) == 1 {
+		title = sorted[0].AccountName
+	}
+
+	doc := pdf.New(fmt.Sprintf("Relatório mensal %s - %s", period, title))
+	pages := []*pdf.Page{newPDFPage(doc, brand, period)}
+	page := pages[0]
+
+	page.Text(pdfMargin, pdfHeaderHeight+40, title, pdf.TextOptions{Size: 16, Bold: true, Color: brand.Color})
+
+	var total accountMonth
+	for _, report := range sorted {
+		m := reportMonth(report)
+		total.Spend += m.Spend
+		total.Result += m.Result
+		total.SocialNetworkRevenue += m.SocialNetworkRevenue
+		total.SocialNetworkSales += m.SocialNetworkSales
+		total.StoreRevenue += m.StoreRevenue
+	}
+
+	y := addPDFKPIs(page, total, pdfHeaderHeight+60)
+
+	if len(sorted) == 1 {
+		addPDFAccountDetails(page, sorted[0], y+30)
+	} else {
+		pages = addPDFAccountTable(doc, pages, brand, period, sorted, y+30)
+	}
+
+	footer := fmt.Sprintf("Gerado em %s", generatedAt.Format("02/01/2006 15:04"))
+	for i, p := range pages {
+		p.Line(pdfMargin, pdfFooterY-12, pdf.PageWidth-pdfMargin, pdfFooterY-12, "D9D9D9")
+		p.Text(pdfMargin, pdfFooterY, footer, pdf.TextOptions{Size: 8, Color: "7F7F7F"})
+		p.Text(pdf.PageWidth-pdfMargin, pdfFooterY, fmt.Sprintf("Página %d de %d", i+1, len(pages)), pdf.TextOptions{Size: 8, Color: "7F7F7F", Align: "r"})
+	}
+
+	return doc.Bytes()
+}
+
+// newPDFPage adiciona uma página com a faixa de cabeçalho da marca
+func newPDFPage(doc *pdf.Document, brand PDFBrand, period string) *pdf.Page {
+	page := doc.AddPage()
+	page.Rect(0, 0, pdf.PageWidth, pdfHeaderHeight, brand.Color)
+	page.Text(pdfMargin, 37, brand.Name, pdf.TextOptions{Size: 18, Bold: true, Color: "FFFFFF"})
+	page.Text(pdf.PageWidth-pdfMargin, 37, fmt.Sprintf("Relatório mensal • %s", period), pdf.TextOptions{Size: 11, Color: "FFFFFF", Align: "r"})
+	return page
+}
+
+// addPDFKPIs desenha os cartões de indicadores a partir de y e retorna a posição abaixo deles
+func addPDFKPIs(page *pdf.Page, total accountMonth, y float64) float64 {
+	roi := 0.0
+	if total.Spend > 0 {
+		roi = total.SocialNetworkRevenue / total.Spend
+	}
+
+	kpis := []struct {
+		label string
+		value string
+	}{
+		{"Investimento", formatCurrency(total.Spend)},
+		{"Resultados", formatInteger(int64(total.Result))},
+		{"Custo por resultado", formatCurrency(costPerResult(total))},
+		{"Faturamento redes sociais", formatCurrency(total.SocialNetworkRevenue)},
+		{"Vendas redes sociais", formatInteger(int64(total.SocialNetworkSales))},
+		{"ROI", strings.Replace(fmt.Sprintf("%.1fx", roi), ".", ",", 1)},
+	}
+
+	const (
+		cols       = 3
+		gap        = 10.0
+		cardHeight = 60.0
+	)
+	cardWidth := (pdf.PageWidth - 2*pdfMargin - gap*(cols-1)) / cols
+
+	for i, kpi := range kpis {
+		x := pdfMargin + float64(i%cols)*(cardWidth+gap)
+		top := y + float64(i/cols)*(cardHeight+gap)
+
+		page.Rect(x, top, cardWidth, cardHeight, "EAF1F8")
+		page.Text(x+cardWidth/2, top+28, kpi.value, pdf.TextOptions{Size: 15, Bold: true, Color: "1F4E79", Align: "ctr"})
+		page.Text(x+cardWidth/2, top+46, kpi.label, pdf.TextOptions{Size: 9, Color: "595959", Align: "ctr"})
+	}
+
+	rows := (len(kpis) + cols - 1) / cols
+	return y + float64(rows)*(cardHeight+gap)
+}
+
+// addPDFAccountDetails lista as métricas de anúncios e vendas de uma conta
+func addPDFAccountDetails(page *pdf.Page, report *domain.MonthlyInsightReport, y float64) {
+	details := make([][2]string, 0, 10)
+
+	if ad := report.AdMetrics; ad != nil {
+		objective := ad.Objective
+		if ad.ObjectiveInfo != nil {
+			objective = ad.ObjectiveInfo.Label
+		}
+		details = append(details,
+			[2]string{"Objetivo", objective},
+			[2]string{"Impressões", formatInteger(int64(ad.Impressions))},
+			[2]string{"Alcance", formatInteger(int64(ad.Reach))},
+			[2]string{"Frequência", strings.Replace(fmt.Sprintf("%.2f", ad.Frequency), ".", ",", 1)},
+		)
+	}
+
+	if sales := report.SalesMetrics[domain.SocialNetwork]; sales != nil {
+		details = append(details, [2]string{"Ticket médio redes sociais", formatCurrency(sales.AverageTicket)})
+	}
+	if sales := report.SalesMetrics[domain.Store]; sales != nil {
+		details = append(details,
+			[2]string{"Vendas loja", formatInteger(int64(sales.SalesQuantity))},
+			[2]string{"Ticket médio loja", formatCurrency(sales.AverageTicket)},
+		)
+	}
+	if result := report.ResultMetrics; result != nil {
+		details = append(details, [2]string{"Conversão", strings.Replace(fmt.Sprintf("%.2f%%", result.Conversion), ".", ",", 1)})
+	}
+
+	page.Text(pdfMargin, y, "Detalhes", pdf.TextOptions{Size: 12, Bold: true})
+	y += pdfRowHeight + 4
+
+	for _, detail := range details {
+		page.Text(pdfMargin, y, detail[0], pdf.TextOptions{Color: "595959"})
+		page.Text(pdf.PageWidth-pdfMargin, y, detail[1], pdf.TextOptions{Bold: true, Align: "r"})
+		page.Line(pdfMargin, y+6, pdf.PageWidth-pdfMargin, y+6, "E7E6E6")
+		y += pdfRowHeight
+	}
+}
+
+// addPDFAccountTable lista as contas, quebrando em novas páginas quando necessário
+func addPDFAccountTable(doc *pdf.Document, pages []*pdf.Page, brand PDFBrand, period string, reports []*domain.MonthlyInsightReport, y float64) []*pdf.Page {
+	page := pages[len(pages)-1]
+
+	header := func() {
+		page.Rect(pdfMargin, y-12, pdf.PageWidth-2*pdfMargin, pdfRowHeight, "EAF1F8")
+		page.Text(pdfMargin+4, y, "Conta", pdf.TextOptions{Size: 8, Bold: true})
+		for _, col := range pdfAccountColumns {
+			page.Text(col.x, y, col.title, pdf.TextOptions{Size: 8, Bold: true, Align: "r"})
+		}
+		y += pdfRowHeight
+	}
+	header()
+
+	for _, report := range reports {
+		if y > pdfTableBottom {
+			page = newPDFPage(doc, brand, period)
+			pages = append(pages, page)
+			y = pdfHeaderHeight + 40
+			header()
+		}
+
+		m := reportMonth(report)
+		page.Text(pdfMargin+4, y, truncate(report.AccountName, 28), pdf.TextOptions{Size: 8})
+		for _, col := range pdfAccountColumns {
+			page.Text(col.x, y, col.value(m), pdf.TextOptions{Size: 8, Align: "r"})
+		}
+		page.Line(pdfMargin, y+6, pdf.PageWidth-pdfMargin, y+6, "E7E6E6")
+		y += pdfRowHeight
+	}
+
+	return pages
+}
+
+// reportMonth resume o relatório mensal de uma conta nas métricas exibidas
+func reportMonth(report *domain.MonthlyInsightReport) accountMonth {
+	var m accountMonth
+	if report.AdMetrics != nil {
+		m.Spend = report.AdMetrics.Spend
+		m.Result = report.AdMetrics.Result
+	}
+	if sn := report.SalesMetrics[domain.SocialNetwork]; sn != nil {
+		m.SocialNetworkRevenue = sn.TotalRevenue
+		m.SocialNetworkSales = sn.SalesQuantity
+	}
+	if store := report.SalesMetrics[domain.Store]; store != nil {
+		m.StoreRevenue = store.TotalRevenue
+	}
+	return m
+}
+
+func costPerResult(m accountMonth) float64 {
+	if m.Result == 0 {
+		return 0
+	}
+	return m.Spend / float64(m.Result)
+}
+
+func truncate(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+	return string(runes[:size-1]) + "…"
+}
@@ -0,0 +1,226 @@
+package reporting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+var (
+	ErrInvalidPDFReport  = errors.New("relatório em PDF inválido")
+	ErrPDFReportNotFound = errors.New("relatório em PDF não encontrado")
+	ErrPDFReportNotReady = errors.New("relatório em PDF ainda não concluído")
+)
+
+// PDFBrand é a identidade visual aplicada no cabeçalho dos relatórios em PDF
+type PDFBrand struct {
+	Name  string
+	Color string // Cor em hexadecimal (ex: "1F4E79")
+}
+
+// PDFReportGenerator gera os relatórios mensais em PDF em background. Os arquivos ficam em memória
+// até expirarem, então um reinício da API descarta as gerações em andamento.
+type PDFReportGenerator interface {
+	// RequestMonthlyPDF valida o pedido e agenda a geração, retornando o job pendente
+	RequestMonthlyPDF(req *domain.PDFReportRequest, userID int) (*domain.PDFReportJob, error)
+	// GetPDFReport retorna a situação de um job do usuário
+	GetPDFReport(id string, userID int) (*domain.PDFReportJob, error)
+	// DownloadPDFReport retorna o arquivo de um job concluído do usuário
+	DownloadPDFReport(id string, userID int) (*domain.PDFReportJob, []byte, error)
+}
+
+type pdfReport struct {
+	job     domain.PDFReportJob
+	content []byte
+}
+
+type pdfReportService struct {
+	insights MonthlyInsightsProvider
+	brand    PDFBrand
+	ttl      time.Duration
+	slots    chan struct{}
+	now      func() time.Time
+
+	mu      sync.Mutex
+	reports map[string]*pdfReport
+}
+
+// NewPDFReportService cria o gerador de relatórios em PDF; maxConcurrent limita as gerações simultâneas
+// e ttl define por quanto tempo um arquivo concluído fica disponível
+func NewPDFReportService(insights MonthlyInsightsProvider, brand PDFBrand, ttl time.Duration, maxConcurrent int) PDFReportGenerator {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &pdfReportService{
+		insights: insights,
+		brand:    brand,
+		ttl:      ttl,
+		slots:    make(chan struct{}, maxConcurrent),
+		now:      time.Now,
+		reports:  make(map[string]*pdfReport),
+	}
+}
+
+func (s *pdfReportService) RequestMonthlyPDF(req *domain.PDFReportRequest, userID int) (*domain.PDFReportJob, error) {
+	req.Period = strings.TrimSpace(req.Period)
+	req.AccountID = strings.TrimSpace(req.AccountID)
+
+	if _, err := time.Parse("01-2006", req.Period); err != nil {
+		return nil, fmt.Errorf("%w: período deve estar no formato mm-aaaa", ErrInvalidPDFReport)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeExpired()
+
+	var id string
+	for {
+		generated, err := utils.GenerateID()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar identificador do relatório: %w", err)
+		}
+		if _, exists := s.reports[generated]; !exists {
+			id = generated
+			break
+		}
+	}
+
+	report := &pdfReport{job: domain.PDFReportJob{
+		ID:          id,
+		Period:      req.Period,
+		AccountID:   req.AccountID,
+		Status:      domain.PDFReportStatusPending,
+		RequestedBy: userID,
+		CreatedAt:   s.now(),
+	}}
+	s.reports[id] = report
+
+	go s.generate(id, *req)
+
+	job := report.job
+	return &job, nil
+}
+
+func (s *pdfReportService) GetPDFReport(id string, userID int) (*domain.PDFReportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeExpired()
+
+	report, ok := s.reports[id]
+	if !ok || report.job.RequestedBy != userID {
+		return nil, ErrPDFReportNotFound
+	}
+
+	job := report.job
+	return &job, nil
+}
+
+func (s *pdfReportService) DownloadPDFReport(id string, userID int) (*domain.PDFReportJob, []byte, error) {
+	job, err := s.GetPDFReport(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != domain.PDFReportStatusDone {
+		return nil, nil, ErrPDFReportNotReady
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return job, s.reports[id].content, nil
+}
+
+// generate monta o PDF aguardando uma vaga entre as gerações simultâneas
+func (s *pdfReportService) generate(id string, req domain.PDFReportRequest) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	s.update(id, func(r *pdfReport) { r.job.Status = domain.PDFReportStatusRunning })
+
+	content, filename, err := s.render(req)
+
+	s.update(id, func(r *pdfReport) {
+		finishedAt := s.now()
+		expiresAt := finishedAt.Add(s.ttl)
+		r.job.FinishedAt = &finishedAt
+		r.job.ExpiresAt = &expiresAt
+
+		if err != nil {
+			r.job.Status = domain.PDFReportStatusFailed
+			r.job.Error = err.Error()
+			return
+		}
+
+		r.job.Status = domain.PDFReportStatusDone
+		r.job.Filename = filename
+		r.job.Size = len(content)
+		r.content = content
+	})
+
+	logger := logrus.WithFields(logrus.Fields{
+		"job_id":     id,
+		"period":     req.Period,
+		"account_id": req.AccountID,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Erro ao gerar relatório mensal em PDF")
+		return
+	}
+	logger.Info("Relatório mensal em PDF gerado com sucesso")
+}
+
+func (s *pdfReportService) render(req domain.PDFReportRequest) ([]byte, string, error) {
+	reports, err := s.insights.GetMonthlyInsightsByPeriod(req.Period)
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao buscar insights mensais: %w", err)
+	}
+
+	filename := fmt.Sprintf("relatorio-mensal-%s.pdf", req.Period)
+	if req.AccountID != "" {
+		filtered := make([]*domain.MonthlyInsightReport, 0, 1)
+		for _, report := range reports {
+			if report.AccountID == req.AccountID || report.ExternalID == req.AccountID {
+				filtered = append(filtered, report)
+			}
+		}
+		reports = filtered
+		filename = fmt.Sprintf("relatorio-mensal-%s-%s.pdf", req.AccountID, req.Period)
+	}
+
+	if len(reports) == 0 {
+		return nil, "", fmt.Errorf("nenhum insight mensal encontrado para o período %s", req.Period)
+	}
+
+	content, err := RenderMonthlyPDF(s.brand, req.Period, reports, s.now())
+	if err != nil {
+		return nil, "", err
+	}
+	return content, filename, nil
+}
+
+func (s *pdfReportService) update(id string, apply func(*pdfReport)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if report, ok := s.reports[id]; ok {
+		apply(report)
+	}
+}
+
+// purgeExpired descarta os jobs finalizados há mais de ttl; deve ser chamado com o lock adquirido
+func (s *pdfReportService) purgeExpired() {
+	now := s.now()
+	for id, report := range s.reports {
+		if report.job.ExpiresAt != nil && now.After(*report.job.ExpiresAt) {
+			delete(s.reports, id)
+		}
+	}
+}
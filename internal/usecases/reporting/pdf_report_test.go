@@ -0,0 +1,86 @@
+package reporting
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestPDFReportService_RequestMonthlyPDF(t *testing.T) {
+	reports := []*domain.MonthlyInsightReport{
+		{
+			AccountID:   "abc123",
+			AccountName: "Loja Centro (SP)",
+			ExternalID:  "act_1",
+			Period:      "03-2025",
+			AdMetrics:   &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: 120.5, Result: 12}},
+			SalesMetrics: map[string]*domain.SalesMetrics{
+				domain.SocialNetwork: {TotalRevenue: 899.9, SalesQuantity: 2},
+			},
+		},
+	}
+	for i := 0; i < 60; i++ {
+		reports = append(reports, &domain.MonthlyInsightReport{AccountID: fmt.Sprintf("acc%d", i), AccountName: fmt.Sprintf("Loja %02d", i), Period: "03-2025"})
+	}
+
+	now := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	service := NewPDFReportService(&fakeMonthlyInsights{reports: reports}, PDFBrand{Name: "Óticas"}, time.Hour, 1).(*pdfReportService)
+	service.now = func() time.Time { return now }
+
+	_, err := service.RequestMonthlyPDF(&domain.PDFReportRequest{Period: "2025-03"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidPDFReport)
+
+	waitDone := func(id string) *domain.PDFReportJob {
+		var job *domain.PDFReportJob
+		require.Eventually(t, func() bool {
+			job, err = service.GetPDFReport(id, 1)
+			require.NoError(t, err)
+			return job.Status == domain.PDFReportStatusDone || job.Status == domain.PDFReportStatusFailed
+		}, time.Second, 5*time.Millisecond)
+		return job
+	}
+
+	// Relatório de uma conta, identificada pelo external_id
+	job, err := service.RequestMonthlyPDF(&domain.PDFReportRequest{Period: "03-2025", AccountID: "act_1"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PDFReportStatusPending, job.Status)
+
+	job = waitDone(job.ID)
+	assert.Equal(t, domain.PDFReportStatusDone, job.Status)
+	assert.Equal(t, "relatorio-mensal-act_1-03-2025.pdf", job.Filename)
+
+	_, _, err = service.DownloadPDFReport(job.ID, 2)
+	assert.ErrorIs(t, err, ErrPDFReportNotFound)
+
+	_, content, err := service.DownloadPDFReport(job.ID, 1)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4")))
+	assert.Contains(t, string(content), `(Loja Centro \(SP\)) Tj`)
+	assert.Contains(t, string(content), "/Count 1")
+
+	// Consolidado com mais contas do que cabem em uma página
+	job, err = service.RequestMonthlyPDF(&domain.PDFReportRequest{Period: "03-2025"}, 1)
+	require.NoError(t, err)
+	job = waitDone(job.ID)
+	_, content, err = service.DownloadPDFReport(job.ID, 1)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "/Count 2")
+
+	// Conta sem dados no período
+	job, err = service.RequestMonthlyPDF(&domain.PDFReportRequest{Period: "03-2025", AccountID: "inexistente"}, 1)
+	require.NoError(t, err)
+	job = waitDone(job.ID)
+	assert.Equal(t, domain.PDFReportStatusFailed, job.Status)
+	_, _, err = service.DownloadPDFReport(job.ID, 1)
+	assert.ErrorIs(t, err, ErrPDFReportNotReady)
+
+	// Após o ttl os arquivos são descartados
+	now = now.Add(2 * time.Hour)
+	_, err = service.GetPDFReport(job.ID, 1)
+	assert.ErrorIs(t, err, ErrPDFReportNotFound)
+}
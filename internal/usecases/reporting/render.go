@@ -0,0 +1,147 @@
+package reporting
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// metricLabels são os nomes exibidos das métricas nos relatórios enviados
+var metricLabels = map[string]string{
+	"spend":                  "Investimento",
+	"impressions":            "Impressões",
+	"reach":                  "Alcance",
+	"frequency":              "Frequência",
+	"result":                 "Resultados",
+	"cost_per_result":        "Custo por resultado",
+	"social_network_revenue": "Faturamento redes sociais",
+	"social_network_sales":   "Vendas redes sociais",
+	"store_revenue":          "Faturamento loja",
+	"store_sales":            "Vendas loja",
+	"conversion":             "Conversão",
+	"roi":                    "ROI",
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<body style="font-family: Arial, sans-serif; color: #222;">
+<h2 style="margin-bottom: 4px;">{{.Name}}</h2>
+<p style="margin-top: 0; color: #666;">Período: {{.Period}}{{if .ComparisonPeriod}} &middot; Comparação: {{.ComparisonPeriod}}{{end}}</p>
+<table cellpadding="6" cellspacing="0" style="border-collapse: collapse; font-size: 13px;">
+<thead>
+<tr style="background: #f0f0f0;">
+<th align="left">Conta</th>{{range .Headers}}<th align="right">{{.}}</th>{{end}}
+</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr style="border-top: 1px solid #ddd;{{if .Total}} font-weight: bold;{{end}}">
+<td>{{.Name}}{{if .Error}} <span style="color: #b00;">({{.Error}})</span>{{end}}</td>{{range .Cells}}<td align="right">{{.Value}}{{if .Change}}<br><span style="font-size: 11px; color: {{.ChangeColor}};">{{.Change}}</span>{{end}}</td>{{end}}
+</tr>
+{{end}}</tbody>
+</table>
+<p style="color: #999; font-size: 11px;">Gerado em {{.GeneratedAt}}</p>
+</body>
+</html>`))
+
+type renderCell struct {
+	Value       string
+	Change      string
+	ChangeColor string
+}
+
+type renderRow struct {
+	Name  string
+	Error string
+	Total bool
+	Cells []renderCell
+}
+
+// ReportSubject é o assunto do e-mail de um relatório
+func ReportSubject(run *domain.ReportRun) string {
+	return fmt.Sprintf("Relatório %s (%s a %s)", run.Name, run.Period.StartDate, run.Period.EndDate)
+}
+
+// RenderReportHTML gera o corpo HTML do relatório, com uma linha por conta e o total consolidado
+func RenderReportHTML(run *domain.ReportRun) (string, error) {
+	headers := make([]string, 0, len(run.Metrics))
+	for _, key := range run.Metrics {
+		headers = append(headers, metricLabels[key])
+	}
+
+	rows := make([]renderRow, 0, len(run.Accounts)+1)
+	for _, account := range run.Accounts {
+		name := account.AccountName
+		if name == "" {
+			name = account.AccountID
+		}
+		rows = append(rows, renderRow{
+			Name:  name,
+			Error: account.Error,
+			Cells: renderCells(run.Metrics, account.Metrics, account.Comparison),
+		})
+	}
+	rows = append(rows, renderRow{
+		Name:  "Total",
+		Total: true,
+		Cells: renderCells(run.Metrics, run.Totals, run.ComparisonTotals),
+	})
+
+	data := map[string]any{
+		"Name":        run.Name,
+		"Period":      run.Period.StartDate + " a " + run.Period.EndDate,
+		"Headers":     headers,
+		"Rows":        rows,
+		"GeneratedAt": run.GeneratedAt.Format("02/01/2006 15:04"),
+	}
+	if run.ComparisonPeriod != nil {
+		data["ComparisonPeriod"] = run.ComparisonPeriod.StartDate + " a " + run.ComparisonPeriod.EndDate
+	}
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("erro ao renderizar relatório: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func renderCells(keys []string, values, comparison map[string]float64) []renderCell {
+	cells := make([]renderCell, 0, len(keys))
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			cells = append(cells, renderCell{Value: "-"})
+			continue
+		}
+
+		cell := renderCell{Value: formatMetric(key, value)}
+		if previous, ok := comparison[key]; ok && previous != 0 {
+			change := (value - previous) / math.Abs(previous) * 100
+			cell.Change = fmt.Sprintf("%+.1f%%", change)
+			cell.ChangeColor = "#2a7a2a"
+			if change < 0 {
+				cell.ChangeColor = "#b00"
+			}
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+func formatMetric(key string, value float64) string {
+	switch key {
+	case "spend", "cost_per_result", "social_network_revenue", "store_revenue":
+		return formatCurrency(value)
+	case "conversion":
+		return fmt.Sprintf("%.2f%%", value)
+	case "roi":
+		return fmt.Sprintf("%.2fx", value)
+	case "frequency":
+		return fmt.Sprintf("%.2f", value)
+	default:
+		return formatInteger(int64(math.Round(value)))
+	}
+}
@@ -0,0 +1,407 @@
+package reporting
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/utils"
+)
+
+const maxReportAccounts = 50
+
+var (
+	ErrInvalidReport  = errors.New("relatório inválido")
+	ErrReportNotFound = errors.New("relatório não encontrado")
+	ErrAccountDenied  = errors.New("conta não vinculada ao usuário")
+)
+
+// AccountInsighter busca as métricas combinadas (anúncios e vendas) de uma conta pelo ID externo
+type AccountInsighter interface {
+	GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error)
+}
+
+// AccountBreakdowner busca as quebras por idade, gênero e plataforma de uma conta pelo ID interno
+type AccountBreakdowner interface {
+	GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdownsResponse, error)
+}
+
+// SavedReportService gerencia e executa as definições de relatório salvas pelos usuários.
+// Sem unrestricted (admin/supervisor), o usuário só pode usar contas vinculadas a ele.
+type SavedReportService interface {
+	CreateReport(userID int, unrestricted bool, req *domain.CreateSavedReportRequest) (*domain.SavedReport, error)
+	ListReports(userID int) ([]*domain.SavedReport, error)
+	GetReport(userID, reportID int) (*domain.SavedReport, error)
+	UpdateReport(userID int, unrestricted bool, reportID int, req *domain.CreateSavedReportRequest) (*domain.SavedReport, error)
+	DeleteReport(userID, reportID int) error
+	RunReport(userID int, unrestricted bool, reportID int) (*domain.ReportRun, error)
+	ExecuteReport(report *domain.SavedReport, unrestricted bool) (*domain.ReportRun, error)
+}
+
+type savedReportService struct {
+	reportRepo  repository.SavedReportRepository
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	insighter   AccountInsighter
+	breakdowns  AccountBreakdowner
+	now         func() time.Time
+}
+
+func NewSavedReportService(
+	reportRepo repository.SavedReportRepository,
+	accountRepo repository.AccountRepository,
+	userRepo repository.UserRepository,
+	insighter AccountInsighter,
+	breakdowns AccountBreakdowner,
+) SavedReportService {
+	return &savedReportService{
+		reportRepo:  reportRepo,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		insighter:   insighter,
+		breakdowns:  breakdowns,
+		now:         time.Now,
+	}
+}
+
+func (s *savedReportService) CreateReport(userID int, unrestricted bool, req *domain.CreateSavedReportRequest) (*domain.SavedReport, error) {
+	report, err := s.buildReport(userID, unrestricted, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.CreateSavedReport(report)
+}
+
+// UpdateReport substitui a definição de um relatório do usuário; os agendamentos passam a usar a nova definição
+func (s *savedReportService) UpdateReport(userID int, unrestricted bool, reportID int, req *domain.CreateSavedReportRequest) (*domain.SavedReport, error) {
+	report, err := s.buildReport(userID, unrestricted, req)
+	if err != nil {
+		return nil, err
+	}
+	report.ID = reportID
+
+	updated, err := s.reportRepo.UpdateSavedReport(report)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReportNotFound
+	}
+	return updated, err
+}
+
+// buildReport normaliza e valida a definição, verificando se as contas existem e se o usuário pode usá-las
+func (s *savedReportService) buildReport(userID int, unrestricted bool, req *domain.CreateSavedReportRequest) (*domain.SavedReport, error) {
+	report := &domain.SavedReport{
+		UserID:       userID,
+		Name:         strings.TrimSpace(req.Name),
+		AccountIDs:   uniqueNonEmpty(req.AccountIDs),
+		PeriodPreset: req.PeriodPreset,
+		Metrics:      uniqueNonEmpty(req.Metrics),
+		Breakdowns:   uniqueNonEmpty(req.Breakdowns),
+		Comparison:   req.Comparison,
+	}
+	if report.Comparison == "" {
+		report.Comparison = domain.ComparisonNone
+	}
+	if len(report.Metrics) == 0 {
+		report.Metrics = domain.ReportMetrics
+	}
+
+	if err := validateReport(report); err != nil {
+		return nil, err
+	}
+
+	for _, accountID := range report.AccountIDs {
+		account, err := s.accountRepo.GetAccountByID(accountID)
+		if err != nil || account == nil {
+			return nil, fmt.Errorf("%w: conta %s não encontrada", ErrInvalidReport, accountID)
+		}
+		if err := s.checkAccess(userID, unrestricted, accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func (s *savedReportService) ListReports(userID int) ([]*domain.SavedReport, error) {
+	return s.reportRepo.ListSavedReports(userID)
+}
+
+// GetReport retorna o relatório do usuário ou ErrReportNotFound
+func (s *savedReportService) GetReport(userID, reportID int) (*domain.SavedReport, error) {
+	report, err := s.reportRepo.GetSavedReport(userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+	return report, nil
+}
+
+func (s *savedReportService) DeleteReport(userID, reportID int) error {
+	err := s.reportRepo.DeleteSavedReport(userID, reportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReportNotFound
+	}
+	return err
+}
+
+// RunReport executa o relatório com as datas resolvidas no momento da execução.
+// Falhas em uma conta não interrompem o relatório: a conta volta com o erro e fica fora dos totais.
+func (s *savedReportService) RunReport(userID int, unrestricted bool, reportID int) (*domain.ReportRun, error) {
+	report, err := s.GetReport(userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ExecuteReport(report, unrestricted)
+}
+
+// ExecuteReport roda uma definição de relatório já carregada, verificando novamente o acesso às contas
+// (o vínculo pode ter sido removido depois que o relatório foi salvo)
+func (s *savedReportService) ExecuteReport(report *domain.SavedReport, unrestricted bool) (*domain.ReportRun, error) {
+	start, end, err := domain.ResolvePeriodPreset(report.PeriodPreset, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReport, err)
+	}
+	compareStart, compareEnd, hasComparison := domain.ComparisonRange(report.Comparison, start, end)
+
+	run := &domain.ReportRun{
+		ReportID:    report.ID,
+		Name:        report.Name,
+		Period:      reportPeriod(start, end),
+		Metrics:     report.Metrics,
+		Accounts:    make([]*domain.ReportAccountResult, 0, len(report.AccountIDs)),
+		GeneratedAt: s.now(),
+	}
+	if hasComparison {
+		period := reportPeriod(compareStart, compareEnd)
+		run.ComparisonPeriod = &period
+	}
+
+	var total, compareTotal reportTotals
+	for _, accountID := range report.AccountIDs {
+		result := &domain.ReportAccountResult{AccountID: accountID}
+		run.Accounts = append(run.Accounts, result)
+
+		if err := s.checkAccess(report.UserID, unrestricted, accountID); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+
+		account, err := s.accountRepo.GetAccountByID(accountID)
+		if err != nil || account == nil {
+			result.Error = "conta não encontrada"
+			continue
+		}
+		result.AccountName = accountName(account)
+		result.Format = account.FormatMetadata()
+
+		current, err := s.loadTotals(account.ExternalID, start, end)
+		if err != nil {
+			logrus.WithError(err).WithField("account_id", accountID).Warn("Erro ao buscar métricas do relatório salvo")
+			result.Error = "erro ao buscar métricas da conta"
+			continue
+		}
+		result.Metrics = current.metrics(report.Metrics)
+		result.Breakdowns = s.loadBreakdowns(account.ID, report.Breakdowns, start, end)
+		total.add(current)
+
+		if hasComparison {
+			previous, err := s.loadTotals(account.ExternalID, compareStart, compareEnd)
+			if err != nil {
+				logrus.WithError(err).WithField("account_id", accountID).Warn("Erro ao buscar métricas de comparação do relatório salvo")
+				continue
+			}
+			result.Comparison = previous.metrics(report.Metrics)
+			compareTotal.add(previous)
+		}
+	}
+
+	run.Totals = total.metrics(report.Metrics)
+	if hasComparison {
+		run.ComparisonTotals = compareTotal.metrics(report.Metrics)
+	}
+
+	return run, nil
+}
+
+func (s *savedReportService) checkAccess(userID int, unrestricted bool, accountID string) error {
+	if unrestricted {
+		return nil
+	}
+
+	permission, err := s.userRepo.GetUserAccountPermission(userID, accountID)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar permissão da conta: %w", err)
+	}
+	if !permission.Allows(domain.AccountPermissionViewer) {
+		return fmt.Errorf("%w: %s", ErrAccountDenied, accountID)
+	}
+	return nil
+}
+
+func (s *savedReportService) loadTotals(externalID string, start, end time.Time) (reportTotals, error) {
+	insights, err := s.insighter.GetAdAccountsByID(externalID, &domain.InsigthFilters{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return reportTotals{}, err
+	}
+
+	var totals reportTotals
+	if insights == nil {
+		return totals, nil
+	}
+
+	if ad := insights.AdAccountMetrics; ad != nil {
+		totals.Spend = ad.Spend
+		totals.Impressions = ad.Impressions
+		totals.Reach = ad.Reach
+		totals.Result = ad.Result
+	}
+	if sales := insights.SalesMetrics[domain.SocialNetwork]; sales != nil {
+		totals.SocialNetworkRevenue = sales.TotalRevenue
+		totals.SocialNetworkSales = sales.SalesQuantity
+	}
+	if sales := insights.SalesMetrics[domain.Store]; sales != nil {
+		totals.StoreRevenue = sales.TotalRevenue
+		totals.StoreSales = sales.SalesQuantity
+	}
+
+	return totals, nil
+}
+
+// loadBreakdowns retorna as quebras pedidas no relatório. Falhas só deixam as quebras de fora, sem
+// afetar as métricas da conta.
+func (s *savedReportService) loadBreakdowns(accountID string, dimensions []string, start, end time.Time) *domain.AdAccountBreakdowns {
+	if len(dimensions) == 0 || s.breakdowns == nil {
+		return nil
+	}
+
+	response, err := s.breakdowns.GetAdAccountBreakdowns(context.Background(), accountID, &domain.InsigthFilters{StartDate: &start, EndDate: &end})
+	if err != nil {
+		logrus.WithError(err).WithField("account_id", accountID).Warn("Erro ao buscar quebras do relatório salvo")
+		return nil
+	}
+	if response == nil {
+		return nil
+	}
+
+	return response.AdAccountBreakdowns.Select(dimensions)
+}
+
+// reportTotals acumula as métricas aditivas; as derivadas são calculadas em metrics
+type reportTotals struct {
+	Spend                float64
+	Impressions          int
+	Reach                int
+	Result               int
+	SocialNetworkRevenue float64
+	SocialNetworkSales   int
+	StoreRevenue         float64
+	StoreSales           int
+}
+
+func (t *reportTotals) add(other reportTotals) {
+	t.Spend += other.Spend
+	t.Impressions += other.Impressions
+	t.Reach += other.Reach
+	t.Result += other.Result
+	t.SocialNetworkRevenue += other.SocialNetworkRevenue
+	t.SocialNetworkSales += other.SocialNetworkSales
+	t.StoreRevenue += other.StoreRevenue
+	t.StoreSales += other.StoreSales
+}
+
+func (t reportTotals) metrics(keys []string) map[string]float64 {
+	values := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		var value float64
+		switch key {
+		case "spend":
+			value = t.Spend
+		case "impressions":
+			value = float64(t.Impressions)
+		case "reach":
+			value = float64(t.Reach)
+		case "frequency":
+			value = ratio(float64(t.Impressions), float64(t.Reach))
+		case "result":
+			value = float64(t.Result)
+		case "cost_per_result":
+			value = ratio(t.Spend, float64(t.Result))
+		case "social_network_revenue":
+			value = t.SocialNetworkRevenue
+		case "social_network_sales":
+			value = float64(t.SocialNetworkSales)
+		case "store_revenue":
+			value = t.StoreRevenue
+		case "store_sales":
+			value = float64(t.StoreSales)
+		case "conversion":
+			value = ratio(float64(t.SocialNetworkSales), float64(t.Result)) * 100
+		case "roi":
+			value = ratio(t.SocialNetworkRevenue, t.Spend)
+		}
+		values[key] = utils.RoundWithTwoDecimalPlace(value)
+	}
+	return values
+}
+
+func validateReport(report *domain.SavedReport) error {
+	if report.Name == "" || len(report.Name) > 100 {
+		return fmt.Errorf("%w: o nome é obrigatório e deve ter até 100 caracteres", ErrInvalidReport)
+	}
+	if len(report.AccountIDs) == 0 || len(report.AccountIDs) > maxReportAccounts {
+		return fmt.Errorf("%w: informe entre 1 e %d contas", ErrInvalidReport, maxReportAccounts)
+	}
+	if !slices.Contains(domain.PeriodPresets, report.PeriodPreset) {
+		return fmt.Errorf("%w: period_preset deve ser um de %s", ErrInvalidReport, strings.Join(domain.PeriodPresets, ", "))
+	}
+	if !slices.Contains(domain.ReportComparisons, report.Comparison) {
+		return fmt.Errorf("%w: comparison deve ser um de %s", ErrInvalidReport, strings.Join(domain.ReportComparisons, ", "))
+	}
+	for _, metric := range report.Metrics {
+		if !slices.Contains(domain.ReportMetrics, metric) {
+			return fmt.Errorf("%w: métrica desconhecida %s", ErrInvalidReport, metric)
+		}
+	}
+	for _, breakdown := range report.Breakdowns {
+		if !slices.Contains(domain.ReportBreakdowns, breakdown) {
+			return fmt.Errorf("%w: quebra desconhecida %s", ErrInvalidReport, breakdown)
+		}
+	}
+	return nil
+}
+
+func reportPeriod(start, end time.Time) domain.ReportPeriod {
+	return domain.ReportPeriod{
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+	}
+}
+
+func uniqueNonEmpty(values []string) []string {
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func ratio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
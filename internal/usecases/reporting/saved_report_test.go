@@ -0,0 +1,186 @@
+package reporting
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeInsighter map[string]*domain.AdAccountInsightsResponse
+
+func (f fakeInsighter) GetAdAccountsByID(accountID string, filters *domain.InsigthFilters) (*domain.AdAccountInsightsResponse, error) {
+	return f[accountID+"|"+filters.StartDate.Format(time.DateOnly)], nil
+}
+
+func insights(spend float64, result int, revenue float64, sales int) *domain.AdAccountInsightsResponse {
+	return &domain.AdAccountInsightsResponse{
+		AdAccountMetrics: &domain.AdAccountMetrics{AdAccountInsight: domain.AdAccountInsight{Spend: spend, Result: result}},
+		SalesMetrics: map[string]*domain.SalesMetrics{
+			domain.SocialNetwork: {TotalRevenue: revenue, SalesQuantity: sales},
+		},
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockSavedReportRepository(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+
+	insighter := fakeInsighter{
+		"act_1|2025-03-01": insights(100, 10, 1000, 2),
+		"act_2|2025-03-01": insights(300, 30, 500, 4),
+		"act_1|2025-01-29": insights(50, 5, 200, 1),
+	}
+
+	service := NewSavedReportService(reportRepo, accountRepo, userRepo, insighter, nil).(*savedReportService)
+	service.now = func() time.Time { return time.Date(2025, 4, 10, 15, 0, 0, 0, time.UTC) }
+
+	reportRepo.EXPECT().GetSavedReport(7, 1).Return(&domain.SavedReport{
+		ID:           1,
+		UserID:       7,
+		Name:         "Mensal",
+		AccountIDs:   []string{"AAA111", "BBB222", "CCC333"},
+		PeriodPreset: domain.PeriodLastMonth,
+		Metrics:      []string{"spend", "cost_per_result", "roi"},
+		Comparison:   domain.ComparisonPreviousPeriod,
+	}, nil)
+
+	userRepo.EXPECT().GetUserAccountPermission(7, "AAA111").Return(domain.AccountPermissionViewer, nil)
+	userRepo.EXPECT().GetUserAccountPermission(7, "BBB222").Return(domain.AccountPermissionManager, nil)
+	userRepo.EXPECT().GetUserAccountPermission(7, "CCC333").Return(domain.AccountPermission(""), nil)
+
+	accountRepo.EXPECT().GetAccountByID("AAA111").Return(&domain.AdAccount{ID: "AAA111", ExternalID: "act_1", Name: "Loja 1"}, nil)
+	accountRepo.EXPECT().GetAccountByID("BBB222").Return(&domain.AdAccount{ID: "BBB222", ExternalID: "act_2", Name: "Loja 2"}, nil)
+
+	run, err := service.RunReport(7, false, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.ReportPeriod{StartDate: "2025-03-01", EndDate: "2025-03-31"}, run.Period)
+	assert.Equal(t, &domain.ReportPeriod{StartDate: "2025-01-29", EndDate: "2025-02-28"}, run.ComparisonPeriod)
+
+	assert.Len(t, run.Accounts, 3)
+	assert.NotEmpty(t, run.Accounts[2].Error, "conta sem vínculo não deve ser executada")
+
+	// Derivadas recalculadas a partir dos totais: 400/40 e 1500/400
+	assert.Equal(t, map[string]float64{"spend": 400, "cost_per_result": 10, "roi": 3.75}, run.Totals)
+	assert.Equal(t, 100.0, run.Accounts[0].Metrics["spend"])
+	assert.Equal(t, 50.0, run.ComparisonTotals["spend"])
+}
+
+type fakeBreakdowner struct{}
+
+func (fakeBreakdowner) GetAdAccountBreakdowns(ctx context.Context, accountID string, filters *domain.InsigthFilters) (*domain.AdAccountBreakdownsResponse, error) {
+	return &domain.AdAccountBreakdownsResponse{
+		AccountID: accountID,
+		AdAccountBreakdowns: domain.AdAccountBreakdowns{
+			Age:      []*domain.BreakdownSegment{{Key: "25-34", Spend: 60}},
+			Gender:   []*domain.BreakdownSegment{{Key: "female", Spend: 70}},
+			Platform: []*domain.BreakdownSegment{{Key: "instagram", Spend: 80}},
+		},
+	}, nil
+}
+
+func TestRunReportWithBreakdowns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockSavedReportRepository(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+
+	insighter := fakeInsighter{"act_1|2025-03-01": insights(100, 10, 1000, 2)}
+	service := NewSavedReportService(reportRepo, accountRepo, userRepo, insighter, fakeBreakdowner{}).(*savedReportService)
+	service.now = func() time.Time { return time.Date(2025, 4, 10, 15, 0, 0, 0, time.UTC) }
+
+	accountRepo.EXPECT().GetAccountByID("AAA111").Return(&domain.AdAccount{ID: "AAA111", ExternalID: "act_1"}, nil)
+
+	run, err := service.ExecuteReport(&domain.SavedReport{
+		ID:           1,
+		UserID:       7,
+		AccountIDs:   []string{"AAA111"},
+		PeriodPreset: domain.PeriodLastMonth,
+		Metrics:      []string{"spend"},
+		Breakdowns:   []string{domain.BreakdownAge, domain.BreakdownPlatform},
+		Comparison:   domain.ComparisonNone,
+	}, true)
+	assert.NoError(t, err)
+
+	breakdowns := run.Accounts[0].Breakdowns
+	if assert.NotNil(t, breakdowns) {
+		assert.Len(t, breakdowns.Age, 1)
+		assert.Len(t, breakdowns.Platform, 1)
+		assert.Nil(t, breakdowns.Gender, "quebra não pedida não deve ser incluída")
+	}
+}
+
+func TestUpdateReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockSavedReportRepository(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	service := NewSavedReportService(reportRepo, accountRepo, nil, nil, nil)
+
+	req := &domain.CreateSavedReportRequest{
+		Name:         "Semanal",
+		AccountIDs:   []string{"AAA111"},
+		PeriodPreset: domain.PeriodLast7Days,
+		Breakdowns:   []string{domain.BreakdownGender},
+	}
+
+	t.Run("quebra desconhecida", func(t *testing.T) {
+		_, err := service.UpdateReport(7, true, 1, &domain.CreateSavedReportRequest{
+			Name:         "Semanal",
+			AccountIDs:   []string{"AAA111"},
+			PeriodPreset: domain.PeriodLast7Days,
+			Breakdowns:   []string{"country"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidReport)
+	})
+
+	t.Run("relatório de outro usuário", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("AAA111").Return(&domain.AdAccount{ID: "AAA111"}, nil)
+		reportRepo.EXPECT().UpdateSavedReport(gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		_, err := service.UpdateReport(7, true, 1, req)
+		assert.ErrorIs(t, err, ErrReportNotFound)
+	})
+
+	t.Run("substitui a definição", func(t *testing.T) {
+		accountRepo.EXPECT().GetAccountByID("AAA111").Return(&domain.AdAccount{ID: "AAA111"}, nil)
+		reportRepo.EXPECT().UpdateSavedReport(gomock.Any()).DoAndReturn(func(report *domain.SavedReport) (*domain.SavedReport, error) {
+			return report, nil
+		})
+
+		report, err := service.UpdateReport(7, true, 1, req)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ID)
+		assert.Equal(t, []string{domain.BreakdownGender}, report.Breakdowns)
+		assert.Equal(t, domain.ReportMetrics, report.Metrics)
+	})
+}
+
+func TestResolvePeriodPreset(t *testing.T) {
+	now := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	start, end, err := domain.ResolvePeriodPreset(domain.PeriodLast7Days, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-03-09", start.Format(time.DateOnly))
+	assert.Equal(t, "2025-03-15", end.Format(time.DateOnly))
+
+	start, end, _ = domain.ComparisonRange(domain.ComparisonPreviousYear, start, end)
+	assert.Equal(t, "2024-03-09", start.Format(time.DateOnly))
+	assert.Equal(t, "2024-03-15", end.Format(time.DateOnly))
+
+	_, _, err = domain.ResolvePeriodPreset("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package reporting
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/notifying"
+)
+
+const (
+	defaultScheduleHour = 8
+	// minScheduleInterval evita expressões cron que disparem o relatório várias vezes por hora
+	minScheduleInterval = time.Hour
+)
+
+var (
+	ErrInvalidSchedule  = errors.New("agendamento inválido")
+	ErrScheduleNotFound = errors.New("agendamento não encontrado")
+)
+
+// ReportScheduleService gerencia o envio recorrente dos relatórios salvos.
+// O envio em si é feito pelo agendador (scheduler.ReportScheduleService).
+type ReportScheduleService interface {
+	CreateSchedule(userID, reportID int, req *domain.CreateReportScheduleRequest) (*domain.ReportSchedule, error)
+	ListSchedules(userID, reportID int) ([]*domain.ReportSchedule, error)
+	DeleteSchedule(userID, reportID, scheduleID int) error
+}
+
+type reportScheduleService struct {
+	scheduleRepo repository.ReportScheduleRepository
+	reportRepo   repository.SavedReportRepository
+	now          func() time.Time
+}
+
+func NewReportScheduleService(scheduleRepo repository.ReportScheduleRepository, reportRepo repository.SavedReportRepository) ReportScheduleService {
+	return &reportScheduleService{
+		scheduleRepo: scheduleRepo,
+		reportRepo:   reportRepo,
+		now:          time.Now,
+	}
+}
+
+// CreateSchedule agenda o relatório. Para o canal webhook, gera o segredo de assinatura,
+// retornado apenas nesta resposta.
+func (s *reportScheduleService) CreateSchedule(userID, reportID int, req *domain.CreateReportScheduleRequest) (*domain.ReportSchedule, error) {
+	report, err := s.reportRepo.GetSavedReport(userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+
+	schedule := &domain.ReportSchedule{
+		ReportID:    reportID,
+		UserID:      userID,
+		Frequency:   req.Frequency,
+		Hour:        defaultScheduleHour,
+		Channel:     req.Channel,
+		Destination: strings.TrimSpace(req.Destination),
+		Active:      true,
+	}
+	if req.Hour != nil {
+		schedule.Hour = *req.Hour
+	}
+
+	switch req.Frequency {
+	case domain.ScheduleWeekly:
+		schedule.Weekday = req.Weekday
+	case domain.ScheduleMonthly:
+		schedule.DayOfMonth = req.DayOfMonth
+	case domain.ScheduleCron:
+		if req.CronSchedule == nil {
+			return nil, fmt.Errorf("%w: cron_schedule é obrigatório para a frequência cron", ErrInvalidSchedule)
+		}
+		cronSpec := strings.TrimSpace(*req.CronSchedule)
+		parsed, err := cron.ParseStandard(cronSpec)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expressão cron inválida", ErrInvalidSchedule)
+		}
+		if interval := domain.CronShortestInterval(parsed); interval < minScheduleInterval {
+			return nil, fmt.Errorf("%w: o intervalo mínimo entre envios é de %s", ErrInvalidSchedule, minScheduleInterval)
+		}
+		schedule.CronSchedule = &cronSpec
+	case domain.ScheduleDaily:
+	default:
+		return nil, fmt.Errorf("%w: frequency deve ser um de %s", ErrInvalidSchedule, strings.Join(domain.ScheduleFrequencies, ", "))
+	}
+
+	nextRun, err := schedule.NextRun(s.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	schedule.NextRunAt = nextRun
+
+	switch schedule.Channel {
+	case domain.DeliveryEmail:
+		addresses, err := mail.ParseAddressList(schedule.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("%w: e-mail de destino inválido", ErrInvalidSchedule)
+		}
+		if len(addresses) > domain.MaxReportRecipients {
+			return nil, fmt.Errorf("%w: informe até %d destinatários", ErrInvalidSchedule, domain.MaxReportRecipients)
+		}
+		recipients := make([]string, 0, len(addresses))
+		for _, address := range addresses {
+			recipients = append(recipients, address.Address)
+		}
+		schedule.Destination = strings.Join(recipients, ",")
+
+	case domain.DeliveryWebhook:
+		parsed, err := url.Parse(schedule.Destination)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: URL de destino inválida", ErrInvalidSchedule)
+		}
+		secret, err := notifying.GenerateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar segredo do webhook: %w", err)
+		}
+		schedule.Secret = secret
+
+	default:
+		return nil, fmt.Errorf("%w: channel deve ser um de %s", ErrInvalidSchedule, strings.Join(domain.DeliveryChannels, ", "))
+	}
+
+	return s.scheduleRepo.CreateSchedule(schedule)
+}
+
+func (s *reportScheduleService) ListSchedules(userID, reportID int) ([]*domain.ReportSchedule, error) {
+	report, err := s.reportRepo.GetSavedReport(userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+
+	return s.scheduleRepo.ListSchedulesByReport(userID, reportID)
+}
+
+func (s *reportScheduleService) DeleteSchedule(userID, reportID, scheduleID int) error {
+	err := s.scheduleRepo.DeleteSchedule(userID, reportID, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	return err
+}
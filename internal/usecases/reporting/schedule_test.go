@@ -0,0 +1,115 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func intPtr(v int) *int { return &v }
+
+func stringPtr(v string) *string { return &v }
+
+func TestReportScheduleNextRun(t *testing.T) {
+	// Quarta-feira, 10h30
+	now := time.Date(2025, 4, 16, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule domain.ReportSchedule
+		expected string
+	}{
+		{"diário ainda hoje", domain.ReportSchedule{Frequency: domain.ScheduleDaily, Hour: 18}, "2025-04-16T18:00"},
+		{"diário amanhã", domain.ReportSchedule{Frequency: domain.ScheduleDaily, Hour: 8}, "2025-04-17T08:00"},
+		{"semanal na segunda", domain.ReportSchedule{Frequency: domain.ScheduleWeekly, Weekday: intPtr(1), Hour: 8}, "2025-04-21T08:00"},
+		{"semanal hoje já passou", domain.ReportSchedule{Frequency: domain.ScheduleWeekly, Weekday: intPtr(3), Hour: 9}, "2025-04-23T09:00"},
+		{"mensal próximo mês", domain.ReportSchedule{Frequency: domain.ScheduleMonthly, DayOfMonth: intPtr(1), Hour: 8}, "2025-05-01T08:00"},
+		{"mensal ainda neste mês", domain.ReportSchedule{Frequency: domain.ScheduleMonthly, DayOfMonth: intPtr(20), Hour: 8}, "2025-04-20T08:00"},
+		{"cron em dias úteis", domain.ReportSchedule{Frequency: domain.ScheduleCron, CronSchedule: stringPtr("30 7 * * 1-5")}, "2025-04-17T07:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := tt.schedule.NextRun(now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, next.Format("2006-01-02T15:04"))
+		})
+	}
+
+	_, err := (&domain.ReportSchedule{Frequency: domain.ScheduleMonthly, DayOfMonth: intPtr(31)}).NextRun(now)
+	assert.Error(t, err)
+}
+
+func TestCreateSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduleRepo := mocks.NewMockReportScheduleRepository(ctrl)
+	reportRepo := mocks.NewMockSavedReportRepository(ctrl)
+	service := NewReportScheduleService(scheduleRepo, reportRepo)
+
+	reportRepo.EXPECT().GetSavedReport(7, 1).Return(&domain.SavedReport{ID: 1, UserID: 7}, nil).AnyTimes()
+
+	t.Run("webhook recebe segredo", func(t *testing.T) {
+		scheduleRepo.EXPECT().CreateSchedule(gomock.Any()).DoAndReturn(func(s *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+			return s, nil
+		})
+
+		schedule, err := service.CreateSchedule(7, 1, &domain.CreateReportScheduleRequest{
+			Frequency:   domain.ScheduleDaily,
+			Channel:     domain.DeliveryWebhook,
+			Destination: "https://example.com/hook",
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(schedule.Secret, "whsec_"))
+		assert.Equal(t, 8, schedule.Hour)
+		assert.False(t, schedule.NextRunAt.IsZero())
+	})
+
+	t.Run("rejeita e-mail inválido", func(t *testing.T) {
+		_, err := service.CreateSchedule(7, 1, &domain.CreateReportScheduleRequest{
+			Frequency:   domain.ScheduleDaily,
+			Channel:     domain.DeliveryEmail,
+			Destination: "not-an-email",
+		})
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+	})
+
+	t.Run("semanal exige dia da semana", func(t *testing.T) {
+		_, err := service.CreateSchedule(7, 1, &domain.CreateReportScheduleRequest{
+			Frequency:   domain.ScheduleWeekly,
+			Channel:     domain.DeliveryEmail,
+			Destination: "gerente@example.com",
+		})
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+	})
+
+	t.Run("vários destinatários por e-mail", func(t *testing.T) {
+		scheduleRepo.EXPECT().CreateSchedule(gomock.Any()).DoAndReturn(func(s *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+			return s, nil
+		})
+
+		schedule, err := service.CreateSchedule(7, 1, &domain.CreateReportScheduleRequest{
+			Frequency:   domain.ScheduleDaily,
+			Channel:     domain.DeliveryEmail,
+			Destination: "Gerente <gerente@example.com>, dono@example.com",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gerente@example.com", "dono@example.com"}, schedule.EmailRecipients())
+	})
+
+	t.Run("cron com intervalo curto", func(t *testing.T) {
+		_, err := service.CreateSchedule(7, 1, &domain.CreateReportScheduleRequest{
+			Frequency:    domain.ScheduleCron,
+			CronSchedule: stringPtr("*/10 * * * *"),
+			Channel:      domain.DeliveryEmail,
+			Destination:  "gerente@example.com",
+		})
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+	})
+}
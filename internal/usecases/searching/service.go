@@ -0,0 +1,112 @@
+package searching
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	minQueryLength     = 2
+	maxQueryLength     = 100
+	defaultLimitByType = 5
+	maxLimitByType     = 20
+)
+
+var ErrInvalidQuery = errors.New("busca inválida")
+
+// SearchRequest é a busca global feita pela paleta de comandos do dashboard
+type SearchRequest struct {
+	Query string
+	// Types restringe os tipos pesquisados. Vazio pesquisa todos os permitidos.
+	Types []string
+	// Limit é o máximo de resultados por tipo
+	Limit        int
+	UserID       int
+	Unrestricted bool
+	// IncludeUsers habilita a busca de usuários, restrita a administradores
+	IncludeUsers bool
+}
+
+type Searcher interface {
+	Search(req *SearchRequest) (*domain.SearchResponse, error)
+}
+
+type Service struct {
+	searchRepo repository.SearchRepository
+}
+
+func NewService(searchRepo repository.SearchRepository) Searcher {
+	return &Service{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search pesquisa contas, campanhas, business managers e usuários, nessa ordem.
+// Os resultados de cada tipo são limitados individualmente para que um tipo não esconda os demais.
+func (s *Service) Search(req *SearchRequest) (*domain.SearchResponse, error) {
+	term := strings.TrimSpace(req.Query)
+	length := utf8.RuneCountInString(term)
+	if length < minQueryLength || length > maxQueryLength {
+		return nil, fmt.Errorf("%w: informe entre %d e %d caracteres", ErrInvalidQuery, minQueryLength, maxQueryLength)
+	}
+
+	for _, searchType := range req.Types {
+		if !slices.Contains(domain.SearchTypes, searchType) {
+			return nil, fmt.Errorf("%w: tipo %q desconhecido", ErrInvalidQuery, searchType)
+		}
+	}
+
+	limit := req.Limit
+	switch {
+	case limit <= 0:
+		limit = defaultLimitByType
+	case limit > maxLimitByType:
+		limit = maxLimitByType
+	}
+
+	scope := domain.SearchScope{
+		UserID:       req.UserID,
+		Unrestricted: req.Unrestricted,
+		Limit:        limit,
+	}
+
+	wants := func(searchType string) bool {
+		return len(req.Types) == 0 || slices.Contains(req.Types, searchType)
+	}
+
+	response := &domain.SearchResponse{
+		Query:   term,
+		Results: make([]*domain.SearchResult, 0),
+	}
+
+	searches := []struct {
+		searchType string
+		allowed    bool
+		search     func() ([]*domain.SearchResult, error)
+	}{
+		{domain.SearchTypeAccount, true, func() ([]*domain.SearchResult, error) { return s.searchRepo.SearchAccounts(term, scope) }},
+		{domain.SearchTypeCampaign, true, func() ([]*domain.SearchResult, error) { return s.searchRepo.SearchCampaigns(term, scope) }},
+		{domain.SearchTypeBusinessManager, true, func() ([]*domain.SearchResult, error) { return s.searchRepo.SearchBusinessManagers(term, scope) }},
+		{domain.SearchTypeUser, req.IncludeUsers, func() ([]*domain.SearchResult, error) { return s.searchRepo.SearchUsers(term, limit) }},
+	}
+
+	for _, search := range searches {
+		if !search.allowed || !wants(search.searchType) {
+			continue
+		}
+
+		results, err := search.search()
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, results...)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,55 @@
+package searching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	searchRepo := mocks.NewMockSearchRepository(ctrl)
+	service := NewService(searchRepo)
+
+	account := &domain.SearchResult{Type: domain.SearchTypeAccount, ID: "abc123", Title: "Ótica Centro"}
+	campaign := &domain.SearchResult{Type: domain.SearchTypeCampaign, ID: "987", Title: "CENTRO | LENTES | VENDAS", AccountID: "abc123"}
+
+	t.Run("cliente não pesquisa usuários", func(t *testing.T) {
+		scope := domain.SearchScope{UserID: 3, Limit: defaultLimitByType}
+		searchRepo.EXPECT().SearchAccounts("centro", scope).Return([]*domain.SearchResult{account}, nil)
+		searchRepo.EXPECT().SearchCampaigns("centro", scope).Return([]*domain.SearchResult{campaign}, nil)
+		searchRepo.EXPECT().SearchBusinessManagers("centro", scope).Return(nil, nil)
+
+		response, err := service.Search(&SearchRequest{Query: " centro ", UserID: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, "centro", response.Query)
+		assert.Equal(t, []*domain.SearchResult{account, campaign}, response.Results)
+	})
+
+	t.Run("admin filtra por tipo e limita resultados", func(t *testing.T) {
+		searchRepo.EXPECT().SearchUsers("maria", maxLimitByType).Return(nil, nil)
+
+		response, err := service.Search(&SearchRequest{
+			Query:        "maria",
+			Types:        []string{domain.SearchTypeUser},
+			Limit:        500,
+			Unrestricted: true,
+			IncludeUsers: true,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, response.Results)
+	})
+
+	t.Run("rejeita busca curta ou tipo desconhecido", func(t *testing.T) {
+		_, err := service.Search(&SearchRequest{Query: "a"})
+		assert.ErrorIs(t, err, ErrInvalidQuery)
+
+		_, err = service.Search(&SearchRequest{Query: "centro", Types: []string{"order"}})
+		assert.ErrorIs(t, err, ErrInvalidQuery)
+	})
+}
@@ -0,0 +1,107 @@
+package syncing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
+)
+
+var (
+	ErrInvalidFailureFilter = errors.New("filtro de falhas de sincronização inválido")
+	ErrSyncFailureNotFound  = errors.New("falha de sincronização não encontrada")
+)
+
+// FailureRequeuer sincroniza novamente, em background, as datas com falha de uma conta e retorna essas datas.
+// Implementado pelos agendadores de sincronização do Meta e do SSOtica.
+type FailureRequeuer interface {
+	RequeueFailures(accountID string) ([]time.Time, error)
+}
+
+// SyncFailureService consulta as datas que falharam em todas as tentativas da sincronização e as sincroniza novamente
+type SyncFailureService interface {
+	ListFailures(filters domain.SyncFailureFilters) (*domain.SyncFailurePage, error)
+	RequeueFailure(id int64, actorID int) (*domain.SyncFailureRequeue, error)
+}
+
+type FailureService struct {
+	failureRepo repository.SyncFailureRepository
+	requeuers   map[string]FailureRequeuer
+	auditor     auditing.Recorder
+}
+
+// NewFailureService cria o serviço com o agendador de cada origem (domain.SyncSourceMeta, domain.SyncSourceSSOtica)
+func NewFailureService(failureRepo repository.SyncFailureRepository, requeuers map[string]FailureRequeuer, auditor auditing.Recorder) SyncFailureService {
+	return &FailureService{
+		failureRepo: failureRepo,
+		requeuers:   requeuers,
+		auditor:     auditor,
+	}
+}
+
+// ListFailures retorna uma página das falhas, das datas mais recentes para as mais antigas
+func (s *FailureService) ListFailures(filters domain.SyncFailureFilters) (*domain.SyncFailurePage, error) {
+	switch {
+	case filters.Limit < 0 || filters.Offset < 0:
+		return nil, fmt.Errorf("%w: limit e offset devem ser positivos", ErrInvalidFailureFilter)
+	case filters.Limit == 0:
+		filters.Limit = domain.DefaultSyncFailureListLimit
+	case filters.Limit > domain.MaxSyncFailureListLimit:
+		filters.Limit = domain.MaxSyncFailureListLimit
+	}
+	if filters.Source != "" && !slices.Contains(domain.SyncSources, filters.Source) {
+		return nil, fmt.Errorf("%w: source deve ser um de %v", ErrInvalidFailureFilter, domain.SyncSources)
+	}
+
+	failures, total, err := s.failureRepo.ListFailures(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SyncFailurePage{
+		Failures: failures,
+		Total:    total,
+		Limit:    filters.Limit,
+		Offset:   filters.Offset,
+	}, nil
+}
+
+// RequeueFailure sincroniza novamente a data da falha junto com as demais datas com falha da mesma conta e
+// origem, em uma única sincronização da conta. As falhas saem da lista quando as datas são sincronizadas.
+func (s *FailureService) RequeueFailure(id int64, actorID int) (*domain.SyncFailureRequeue, error) {
+	failure, err := s.failureRepo.GetFailure(id)
+	if err != nil {
+		return nil, err
+	}
+	if failure == nil {
+		return nil, ErrSyncFailureNotFound
+	}
+
+	requeuer, ok := s.requeuers[failure.Source]
+	if !ok {
+		return nil, fmt.Errorf("%w: origem %s sem agendador", ErrSyncFailureNotFound, failure.Source)
+	}
+
+	dates, err := requeuer.RequeueFailures(failure.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	requeue := &domain.SyncFailureRequeue{
+		Source:    failure.Source,
+		AccountID: failure.AccountID,
+		Dates:     make([]string, len(dates)),
+	}
+	for i, date := range dates {
+		requeue.Dates[i] = date.Format(time.DateOnly)
+	}
+
+	if s.auditor != nil {
+		s.auditor.Record(actorID, domain.AuditActionSyncRequeue, domain.AuditEntitySyncFailure, fmt.Sprint(id), requeue)
+	}
+	return requeue, nil
+}
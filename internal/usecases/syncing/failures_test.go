@@ -0,0 +1,84 @@
+package syncing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+type requeuerFunc func(accountID string) ([]time.Time, error)
+
+func (f requeuerFunc) RequeueFailures(accountID string) ([]time.Time, error) {
+	return f(accountID)
+}
+
+func TestListFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failureRepo := mocks.NewMockSyncFailureRepository(ctrl)
+	service := NewFailureService(failureRepo, nil, nil)
+
+	t.Run("aplica o limite padrão", func(t *testing.T) {
+		failureRepo.EXPECT().ListFailures(domain.SyncFailureFilters{Source: domain.SyncSourceMeta, Limit: domain.DefaultSyncFailureListLimit}).
+			Return([]*domain.SyncFailure{{ID: 1}}, 1, nil)
+
+		page, err := service.ListFailures(domain.SyncFailureFilters{Source: domain.SyncSourceMeta})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, domain.DefaultSyncFailureListLimit, page.Limit)
+	})
+
+	t.Run("origem desconhecida", func(t *testing.T) {
+		_, err := service.ListFailures(domain.SyncFailureFilters{Source: "google"})
+		assert.ErrorIs(t, err, ErrInvalidFailureFilter)
+	})
+}
+
+func TestRequeueFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failureRepo := mocks.NewMockSyncFailureRepository(ctrl)
+	requeued := ""
+	service := NewFailureService(failureRepo, map[string]FailureRequeuer{
+		domain.SyncSourceSSOtica: requeuerFunc(func(accountID string) ([]time.Time, error) {
+			requeued = accountID
+			return []time.Time{
+				time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+			}, nil
+		}),
+		domain.SyncSourceMeta: requeuerFunc(func(string) ([]time.Time, error) {
+			return nil, errors.New("sincronização pausada para a origem")
+		}),
+	}, nil)
+
+	t.Run("sincroniza as datas com falha da conta", func(t *testing.T) {
+		failureRepo.EXPECT().GetFailure(int64(4)).Return(&domain.SyncFailure{ID: 4, Source: domain.SyncSourceSSOtica, AccountID: "ABC123"}, nil)
+
+		requeue, err := service.RequeueFailure(4, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "ABC123", requeued)
+		assert.Equal(t, []string{"2026-10-12", "2026-10-14"}, requeue.Dates)
+	})
+
+	t.Run("erro do agendador", func(t *testing.T) {
+		failureRepo.EXPECT().GetFailure(int64(5)).Return(&domain.SyncFailure{ID: 5, Source: domain.SyncSourceMeta, AccountID: "ABC123"}, nil)
+
+		_, err := service.RequeueFailure(5, 1)
+		assert.EqualError(t, err, "sincronização pausada para a origem")
+	})
+
+	t.Run("falha inexistente", func(t *testing.T) {
+		failureRepo.EXPECT().GetFailure(int64(9)).Return(nil, nil)
+
+		_, err := service.RequeueFailure(9, 1)
+		assert.ErrorIs(t, err, ErrSyncFailureNotFound)
+	})
+}
@@ -0,0 +1,113 @@
+package syncing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/auditing"
+)
+
+// maxPauseReasonLength limita o motivo informado na pausa
+const maxPauseReasonLength = 500
+
+var ErrInvalidScheduler = errors.New("agendador inválido")
+
+// SchedulerPauseService pausa e retoma os agendadores de sincronização em tempo de execução, sem alterar a
+// configuração nem reiniciar a API. Os agendadores consultam a pausa a cada execução.
+type SchedulerPauseService interface {
+	ListSchedulers() ([]*domain.SchedulerState, error)
+	PauseScheduler(scheduler, reason string, actorID int) (*domain.SchedulerState, error)
+	ResumeScheduler(scheduler string, actorID int) (*domain.SchedulerState, error)
+}
+
+type PauseService struct {
+	pauseRepo repository.SchedulerPauseRepository
+	auditor   auditing.Recorder
+	now       func() time.Time
+}
+
+func NewPauseService(pauseRepo repository.SchedulerPauseRepository, auditor auditing.Recorder) SchedulerPauseService {
+	return &PauseService{
+		pauseRepo: pauseRepo,
+		auditor:   auditor,
+		now:       time.Now,
+	}
+}
+
+// ListSchedulers retorna a situação de todos os agendadores que podem ser pausados
+func (s *PauseService) ListSchedulers() ([]*domain.SchedulerState, error) {
+	pauses, err := s.pauseRepo.ListPauses()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*domain.SchedulerState, 0, len(runTypes))
+	for _, scheduler := range runTypes {
+		state := &domain.SchedulerState{Scheduler: scheduler}
+		for _, pause := range pauses {
+			if pause.Scheduler == scheduler {
+				state = pause
+				break
+			}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// PauseScheduler impede novas execuções do agendador, inclusive manuais; a execução em andamento não é interrompida
+func (s *PauseService) PauseScheduler(scheduler, reason string, actorID int) (*domain.SchedulerState, error) {
+	if !slices.Contains(runTypes, scheduler) {
+		return nil, fmt.Errorf("%w: deve ser um de %v", ErrInvalidScheduler, runTypes)
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxPauseReasonLength {
+		return nil, fmt.Errorf("%w: motivo deve ter no máximo %d caracteres", ErrInvalidScheduler, maxPauseReasonLength)
+	}
+
+	pausedAt := s.now()
+	state := &domain.SchedulerState{
+		Scheduler: scheduler,
+		Paused:    true,
+		Reason:    reason,
+		PausedAt:  &pausedAt,
+	}
+	if actorID > 0 {
+		state.PausedBy = &actorID
+	}
+
+	if err := s.pauseRepo.PauseScheduler(state); err != nil {
+		return nil, err
+	}
+
+	s.record(actorID, domain.AuditActionSyncPause, scheduler, map[string]any{"reason": reason})
+	return state, nil
+}
+
+// ResumeScheduler libera as execuções do agendador a partir da próxima ocorrência do cron
+func (s *PauseService) ResumeScheduler(scheduler string, actorID int) (*domain.SchedulerState, error) {
+	if !slices.Contains(runTypes, scheduler) {
+		return nil, fmt.Errorf("%w: deve ser um de %v", ErrInvalidScheduler, runTypes)
+	}
+
+	resumed, err := s.pauseRepo.ResumeScheduler(scheduler)
+	if err != nil {
+		return nil, err
+	}
+
+	if resumed {
+		s.record(actorID, domain.AuditActionSyncResume, scheduler, nil)
+	}
+	return &domain.SchedulerState{Scheduler: scheduler}, nil
+}
+
+func (s *PauseService) record(actorID int, action, scheduler string, details any) {
+	if s.auditor != nil {
+		s.auditor.Record(actorID, action, domain.AuditEntityJob, scheduler, details)
+	}
+}
@@ -0,0 +1,64 @@
+package syncing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSchedulers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pauseRepo := mocks.NewMockSchedulerPauseRepository(ctrl)
+	service := NewPauseService(pauseRepo, nil)
+
+	pauseRepo.EXPECT().ListPauses().Return([]*domain.SchedulerState{
+		{Scheduler: domain.SchedulerMetaInsightSync, Paused: true, Reason: "limite de requisições"},
+	}, nil)
+
+	states, err := service.ListSchedulers()
+	assert.NoError(t, err)
+	assert.Len(t, states, len(runTypes))
+	assert.True(t, states[0].Paused)
+	assert.Equal(t, "limite de requisições", states[0].Reason)
+	assert.False(t, states[1].Paused)
+}
+
+func TestPauseScheduler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pauseRepo := mocks.NewMockSchedulerPauseRepository(ctrl)
+	service := NewPauseService(pauseRepo, nil)
+
+	t.Run("pausa com autor e motivo", func(t *testing.T) {
+		pauseRepo.EXPECT().PauseScheduler(gomock.Any()).DoAndReturn(func(state *domain.SchedulerState) error {
+			assert.Equal(t, domain.SchedulerMetaInsightSync, state.Scheduler)
+			assert.Equal(t, 7, *state.PausedBy)
+			assert.Equal(t, "limite de requisições", state.Reason)
+			assert.NotNil(t, state.PausedAt)
+			return nil
+		})
+
+		state, err := service.PauseScheduler(domain.SchedulerMetaInsightSync, " limite de requisições ", 7)
+		assert.NoError(t, err)
+		assert.True(t, state.Paused)
+	})
+
+	t.Run("agendador desconhecido", func(t *testing.T) {
+		_, err := service.PauseScheduler("backup", "", 7)
+		assert.ErrorIs(t, err, ErrInvalidScheduler)
+	})
+
+	t.Run("retomada", func(t *testing.T) {
+		pauseRepo.EXPECT().ResumeScheduler(domain.SchedulerSSOticaInsightSync).Return(true, nil)
+
+		state, err := service.ResumeScheduler(domain.SchedulerSSOticaInsightSync, 7)
+		assert.NoError(t, err)
+		assert.False(t, state.Paused)
+	})
+}
@@ -0,0 +1,97 @@
+package syncing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+var (
+	ErrInvalidRunFilter = errors.New("filtro de execuções inválido")
+	ErrRunNotFound      = errors.New("execução não encontrada")
+)
+
+// runTypes são os agendadores com execuções registradas na tabela jobs
+var runTypes = []string{
+	domain.SchedulerMetaInsightSync,
+	domain.SchedulerSSOticaInsightSync,
+	domain.SchedulerMonthlyInsightsSync,
+	domain.SchedulerTopRankingAccounts,
+}
+
+var runStatuses = []string{
+	domain.JobStatusRunning,
+	domain.JobStatusSucceeded,
+	domain.JobStatusFailed,
+	domain.JobStatusInterrupted,
+}
+
+// SyncRunService consulta o histórico das execuções dos agendadores de sincronização
+type SyncRunService interface {
+	ListRuns(filters domain.JobFilters) (*domain.JobPage, error)
+	GetRun(id int64) (*domain.JobDetail, error)
+}
+
+type RunService struct {
+	jobRepo repository.JobRepository
+}
+
+func NewRunService(jobRepo repository.JobRepository) SyncRunService {
+	return &RunService{
+		jobRepo: jobRepo,
+	}
+}
+
+// ListRuns retorna uma página das execuções, da mais recente para a mais antiga, com o resumo das contas
+func (s *RunService) ListRuns(filters domain.JobFilters) (*domain.JobPage, error) {
+	switch {
+	case filters.Limit < 0 || filters.Offset < 0:
+		return nil, fmt.Errorf("%w: limit e offset devem ser positivos", ErrInvalidRunFilter)
+	case filters.Limit == 0:
+		filters.Limit = domain.DefaultJobListLimit
+	case filters.Limit > domain.MaxJobListLimit:
+		filters.Limit = domain.MaxJobListLimit
+	}
+	if filters.Type != "" && !slices.Contains(runTypes, filters.Type) {
+		return nil, fmt.Errorf("%w: type deve ser um de %v", ErrInvalidRunFilter, runTypes)
+	}
+	if filters.Status != "" && !slices.Contains(runStatuses, filters.Status) {
+		return nil, fmt.Errorf("%w: status deve ser um de %v", ErrInvalidRunFilter, runStatuses)
+	}
+	if filters.From != nil && filters.To != nil && !filters.From.Before(*filters.To) {
+		return nil, fmt.Errorf("%w: from deve ser anterior a to", ErrInvalidRunFilter)
+	}
+
+	jobs, total, err := s.jobRepo.ListJobs(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobPage{
+		Jobs:   jobs,
+		Total:  total,
+		Limit:  filters.Limit,
+		Offset: filters.Offset,
+	}, nil
+}
+
+// GetRun retorna a execução com o resultado de cada conta, as falhas primeiro
+func (s *RunService) GetRun(id int64) (*domain.JobDetail, error) {
+	job, err := s.jobRepo.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrRunNotFound
+	}
+
+	accounts, err := s.jobRepo.ListJobAccounts(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobDetail{Job: job, AccountResults: accounts}, nil
+}
@@ -0,0 +1,65 @@
+package syncing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository/mocks"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockJobRepository(ctrl)
+	service := NewRunService(jobRepo)
+
+	t.Run("aplica o limite padrão", func(t *testing.T) {
+		jobRepo.EXPECT().ListJobs(domain.JobFilters{Type: domain.SchedulerMetaInsightSync, Limit: domain.DefaultJobListLimit}).
+			Return([]*domain.Job{{ID: 1}}, 1, nil)
+
+		page, err := service.ListRuns(domain.JobFilters{Type: domain.SchedulerMetaInsightSync})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, domain.DefaultJobListLimit, page.Limit)
+	})
+
+	t.Run("agendador desconhecido", func(t *testing.T) {
+		_, err := service.ListRuns(domain.JobFilters{Type: "backup"})
+		assert.ErrorIs(t, err, ErrInvalidRunFilter)
+	})
+
+	t.Run("status desconhecido", func(t *testing.T) {
+		_, err := service.ListRuns(domain.JobFilters{Status: "done"})
+		assert.ErrorIs(t, err, ErrInvalidRunFilter)
+	})
+}
+
+func TestGetRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockJobRepository(ctrl)
+	service := NewRunService(jobRepo)
+
+	t.Run("execução com o resultado das contas", func(t *testing.T) {
+		jobRepo.EXPECT().GetJob(int64(5)).Return(&domain.Job{ID: 5, Status: domain.JobStatusFailed}, nil)
+		jobRepo.EXPECT().ListJobAccounts(int64(5)).Return([]*domain.JobAccount{
+			{AccountID: "ABC123", Status: domain.JobAccountFailed, Reason: "token expirado"},
+		}, nil)
+
+		run, err := service.GetRun(5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), run.ID)
+		assert.Len(t, run.AccountResults, 1)
+	})
+
+	t.Run("execução inexistente", func(t *testing.T) {
+		jobRepo.EXPECT().GetJob(int64(9)).Return(nil, nil)
+
+		_, err := service.GetRun(9)
+		assert.ErrorIs(t, err, ErrRunNotFound)
+	})
+}
@@ -0,0 +1,114 @@
+package syncing
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/vfg2006/traffic-manager-api/infrastructure/repository"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+const (
+	defaultLookbackDays = 1
+	maxLookbackDays     = 31
+	// minScheduleInterval protege as APIs de origem de agendas mais frequentes que de hora em hora
+	minScheduleInterval = time.Hour
+)
+
+var (
+	ErrInvalidSchedule  = errors.New("agenda de sincronização inválida")
+	ErrScheduleNotFound = errors.New("agenda de sincronização não encontrada")
+	ErrAccountNotFound  = errors.New("conta não encontrada")
+)
+
+type SyncScheduleService interface {
+	ListSchedules(accountID string) ([]*domain.AccountSyncSchedule, error)
+	SaveSchedule(accountID, source string, req *domain.AccountSyncScheduleRequest) (*domain.AccountSyncSchedule, error)
+	DeleteSchedule(accountID, source string) error
+}
+
+type ScheduleService struct {
+	scheduleRepo repository.SyncScheduleRepository
+}
+
+func NewScheduleService(scheduleRepo repository.SyncScheduleRepository) SyncScheduleService {
+	return &ScheduleService{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+func (s *ScheduleService) ListSchedules(accountID string) ([]*domain.AccountSyncSchedule, error) {
+	return s.scheduleRepo.ListAccountSchedules(accountID)
+}
+
+func (s *ScheduleService) SaveSchedule(accountID, source string, req *domain.AccountSyncScheduleRequest) (*domain.AccountSyncSchedule, error) {
+	schedule, err := buildSchedule(source, req)
+	if err != nil {
+		return nil, err
+	}
+	schedule.AccountID = accountID
+
+	saved, err := s.scheduleRepo.UpsertSchedule(schedule)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *ScheduleService) DeleteSchedule(accountID, source string) error {
+	if !slices.Contains(domain.SyncSources, source) {
+		return fmt.Errorf("%w: origem %q desconhecida", ErrInvalidSchedule, source)
+	}
+
+	err := s.scheduleRepo.DeleteSchedule(accountID, source)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	return err
+}
+
+// buildSchedule valida a requisição. Sem lookback_days informado sincroniza apenas o dia atual,
+// e sem enabled a agenda é criada habilitada.
+func buildSchedule(source string, req *domain.AccountSyncScheduleRequest) (*domain.AccountSyncSchedule, error) {
+	if !slices.Contains(domain.SyncSources, source) {
+		return nil, fmt.Errorf("%w: origem %q desconhecida", ErrInvalidSchedule, source)
+	}
+
+	cronSpec := strings.TrimSpace(req.CronSchedule)
+	schedule, err := cron.ParseStandard(cronSpec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: expressão cron inválida", ErrInvalidSchedule)
+	}
+	if interval := domain.CronShortestInterval(schedule); interval < minScheduleInterval {
+		return nil, fmt.Errorf("%w: o intervalo mínimo entre execuções é de %s", ErrInvalidSchedule, minScheduleInterval)
+	}
+
+	lookbackDays := req.LookbackDays
+	if lookbackDays == 0 {
+		lookbackDays = defaultLookbackDays
+	}
+	if lookbackDays < 1 || lookbackDays > maxLookbackDays {
+		return nil, fmt.Errorf("%w: lookback_days deve estar entre 1 e %d", ErrInvalidSchedule, maxLookbackDays)
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return &domain.AccountSyncSchedule{
+		Source:       source,
+		CronSchedule: cronSpec,
+		LookbackDays: lookbackDays,
+		Enabled:      enabled,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package syncing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestBuildSchedule(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name    string
+		source  string
+		req     domain.AccountSyncScheduleRequest
+		want    *domain.AccountSyncSchedule
+		wantErr bool
+	}{
+		{
+			name:   "de hora em hora com valores padrão",
+			source: domain.SyncSourceSSOtica,
+			req:    domain.AccountSyncScheduleRequest{CronSchedule: " 0 * * * * "},
+			want:   &domain.AccountSyncSchedule{Source: "ssotica", CronSchedule: "0 * * * *", LookbackDays: 1, Enabled: true},
+		},
+		{
+			name:   "diária desabilitada",
+			source: domain.SyncSourceMeta,
+			req:    domain.AccountSyncScheduleRequest{CronSchedule: "30 2 * * *", LookbackDays: 3, Enabled: &disabled},
+			want:   &domain.AccountSyncSchedule{Source: "meta", CronSchedule: "30 2 * * *", LookbackDays: 3, Enabled: false},
+		},
+		{
+			name:    "mais frequente que de hora em hora",
+			source:  domain.SyncSourceSSOtica,
+			req:     domain.AccountSyncScheduleRequest{CronSchedule: "*/15 * * * *"},
+			wantErr: true,
+		},
+		{
+			name:    "origem desconhecida",
+			source:  "google",
+			req:     domain.AccountSyncScheduleRequest{CronSchedule: "0 * * * *"},
+			wantErr: true,
+		},
+		{
+			name:    "cron inválido",
+			source:  domain.SyncSourceMeta,
+			req:     domain.AccountSyncScheduleRequest{CronSchedule: "todo dia"},
+			wantErr: true,
+		},
+		{
+			name:    "lookback acima do limite",
+			source:  domain.SyncSourceMeta,
+			req:     domain.AccountSyncScheduleRequest{CronSchedule: "0 3 * * *", LookbackDays: 60},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSchedule(tt.source, &tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,11 +18,20 @@ const (
 	ErrInsufficientPrivilege = "AUTH_008" // Privilégios insuficientes
 	ErrUserAlreadyExists     = "AUTH_009" // Usuário já existe
 	ErrInvalidTokenSSOtica   = "AUTH_010" // Token inválido para a integração SSOtica
+	ErrCaptchaRequired       = "AUTH_011" // Captcha obrigatório após falhas de login
+	ErrInvalidCaptcha        = "AUTH_012" // Captcha inválido
+	ErrEmailNotVerified      = "AUTH_013" // E-mail ainda não confirmado
+	ErrPolicyNotAccepted     = "AUTH_014" // Versão vigente dos termos de uso ainda não aceita
 
 	// Erros de validação (2000-2999)
 	ErrInvalidRequest      = "VAL_001" // Requisição inválida
 	ErrMissingRequiredData = "VAL_002" // Dados obrigatórios ausentes
 	ErrInvalidFormat       = "VAL_003" // Formato de dados inválido
+	ErrResourceNotFound    = "VAL_004" // Recurso não encontrado
+
+	// Erros de limite de uso (4000-4999)
+	ErrQuotaExceeded     = "LIM_001" // Cota diária de uso excedida
+	ErrRateLimitExceeded = "LIM_002" // Muitas requisições em pouco tempo
 
 	// Erros do servidor (5000-5999)
 	ErrInternalServer    = "SRV_001" // Erro interno do servidor
@@ -41,9 +50,16 @@ var httpStatusMap = map[string]int{
 	ErrInvalidToken:          http.StatusUnauthorized,
 	ErrExpiredToken:          http.StatusUnauthorized,
 	ErrInsufficientPrivilege: http.StatusForbidden,
+	ErrEmailNotVerified:      http.StatusForbidden,
+	ErrPolicyNotAccepted:     http.StatusForbidden,
+	ErrCaptchaRequired:       http.StatusPreconditionRequired,
+	ErrInvalidCaptcha:        http.StatusForbidden,
 	ErrInvalidRequest:        http.StatusBadRequest,
 	ErrMissingRequiredData:   http.StatusBadRequest,
 	ErrInvalidFormat:         http.StatusBadRequest,
+	ErrResourceNotFound:      http.StatusNotFound,
+	ErrQuotaExceeded:         http.StatusTooManyRequests,
+	ErrRateLimitExceeded:     http.StatusTooManyRequests,
 	ErrUserAlreadyExists:     http.StatusBadRequest,
 	ErrInternalServer:        http.StatusInternalServerError,
 	ErrDatabaseOperation:     http.StatusInternalServerError,
@@ -0,0 +1,503 @@
+// Package graphql executa consultas GraphQL sobre os casos de uso existentes sem dependências externas.
+//
+// O esquema é derivado do JSON retornado por cada resolvedor: os campos selecionados
+// correspondem às chaves do payload REST equivalente, então o cliente pede apenas o que
+// precisa (ex: { insights(...) { AdAccountMetrics { spend } } }). São suportados aliases,
+// argumentos, variáveis, fragmentos e as diretivas @include e @skip; apenas consultas
+// (query) são aceitas.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Request é o corpo de uma requisição GraphQL
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response é a resposta de uma execução. Data é nulo quando a consulta não pôde ser executada.
+type Response struct {
+	Data   *Object  `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error é um erro de execução, com o caminho do campo que falhou
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// ResolveParams são os dados disponíveis para um resolvedor
+type ResolveParams struct {
+	Context context.Context
+	Args    map[string]any
+}
+
+// FieldResolver resolve um campo raiz da consulta. O valor retornado é serializado em JSON
+// antes da seleção dos campos.
+type FieldResolver func(p ResolveParams) (any, error)
+
+// Schema reúne os campos raiz disponíveis nas consultas
+type Schema struct {
+	Query map[string]FieldResolver
+}
+
+// ExecuteParams são os dados de uma execução. HiddenFields lista chaves removidas em qualquer
+// nível da resposta, mesmo quando pedidas com alias.
+type ExecuteParams struct {
+	Context      context.Context
+	Request      *Request
+	HiddenFields map[string]bool
+}
+
+// Object é um objeto da resposta que preserva a ordem dos campos selecionados
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+func newObject() *Object {
+	return &Object{values: make(map[string]any)}
+}
+
+// Set define o valor de um campo, mantendo a posição do primeiro Set
+func (o *Object) Set(key string, value any) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get retorna o valor de um campo
+func (o *Object) Get(key string) any {
+	return o.values[key]
+}
+
+// MarshalJSON serializa os campos na ordem da seleção
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// execution guarda o estado de uma execução
+type execution struct {
+	doc       *Document
+	variables map[string]any
+	hidden    map[string]bool
+	errors    []*Error
+}
+
+// Execute analisa e executa a consulta, resolvendo os campos raiz na ordem em que aparecem
+func (s *Schema) Execute(params ExecuteParams) *Response {
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if params.Request == nil || params.Request.Query == "" {
+		return requestError("consulta não informada")
+	}
+
+	doc, err := Parse(params.Request.Query)
+	if err != nil {
+		return requestError(err.Error())
+	}
+
+	if err := validateFragments(doc); err != nil {
+		return requestError(err.Error())
+	}
+
+	op, err := selectOperation(doc, params.Request.OperationName)
+	if err != nil {
+		return requestError(err.Error())
+	}
+	if op.Type != "query" {
+		return requestError(fmt.Sprintf("operação %s não suportada: apenas consultas (query) são aceitas", op.Type))
+	}
+
+	exec := &execution{
+		doc:       doc,
+		variables: make(map[string]any, len(op.Variables)),
+		hidden:    params.HiddenFields,
+	}
+	for _, def := range op.Variables {
+		if value, ok := params.Request.Variables[def.Name]; ok {
+			exec.variables[def.Name] = value
+		} else if def.HasDefault {
+			exec.variables[def.Name] = def.Default
+		}
+	}
+
+	fields, err := exec.collectFields(op.Selections, map[string]bool{})
+	if err != nil {
+		return requestError(err.Error())
+	}
+
+	data := newObject()
+	for _, field := range fields {
+		key := field.Key()
+
+		if field.Name == "__typename" {
+			data.Set(key, "Query")
+			continue
+		}
+
+		resolver, ok := s.Query[field.Name]
+		if !ok {
+			exec.errors = append(exec.errors, &Error{Message: fmt.Sprintf("campo %q não existe em Query", field.Name), Path: []any{key}})
+			data.Set(key, nil)
+			continue
+		}
+
+		args := make(map[string]any, len(field.Arguments))
+		for name, value := range field.Arguments {
+			args[name] = exec.resolveValue(value)
+		}
+
+		value, err := resolver(ResolveParams{Context: ctx, Args: args})
+		if err != nil {
+			exec.errors = append(exec.errors, &Error{Message: err.Error(), Path: []any{key}})
+			data.Set(key, nil)
+			continue
+		}
+
+		normalized, err := normalize(value)
+		if err != nil {
+			exec.errors = append(exec.errors, &Error{Message: "erro ao serializar resultado", Path: []any{key}})
+			data.Set(key, nil)
+			continue
+		}
+
+		projected, err := exec.project(normalized, field.Selections, []any{key})
+		if err != nil {
+			exec.errors = append(exec.errors, &Error{Message: err.Error(), Path: []any{key}})
+			data.Set(key, nil)
+			continue
+		}
+		data.Set(key, projected)
+	}
+
+	return &Response{Data: data, Errors: exec.errors}
+}
+
+func requestError(message string) *Response {
+	return &Response{Errors: []*Error{{Message: message}}}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("o documento tem várias operações: informe operationName")
+		}
+		return doc.Operations[0], nil
+	}
+
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("operação %q não encontrada", name)
+}
+
+// validateFragments garante que todo fragmento usado está definido e que não há referências circulares
+func validateFragments(doc *Document) error {
+	var walk func(selections []*Selection, visiting map[string]bool) error
+	walk = func(selections []*Selection, visiting map[string]bool) error {
+		for _, selection := range selections {
+			if selection.FragmentSpread == "" {
+				if err := walk(selection.Selections, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+
+			fragment, ok := doc.Fragments[selection.FragmentSpread]
+			if !ok {
+				return fmt.Errorf("fragmento %q não definido", selection.FragmentSpread)
+			}
+			if visiting[fragment.Name] {
+				return fmt.Errorf("fragmento %q referencia a si mesmo", fragment.Name)
+			}
+			visiting[fragment.Name] = true
+			if err := walk(fragment.Selections, visiting); err != nil {
+				return err
+			}
+			delete(visiting, fragment.Name)
+		}
+		return nil
+	}
+
+	for _, op := range doc.Operations {
+		if err := walk(op.Selections, map[string]bool{}); err != nil {
+			return err
+		}
+	}
+	for _, fragment := range doc.Fragments {
+		if err := walk(fragment.Selections, map[string]bool{fragment.Name: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectFields expande fragmentos e aplica @include/@skip, retornando os campos a resolver.
+// Campos repetidos com a mesma chave têm as seleções combinadas.
+func (e *execution) collectFields(selections []*Selection, visiting map[string]bool) ([]*Selection, error) {
+	fields := make([]*Selection, 0, len(selections))
+	byKey := make(map[string]*Selection)
+
+	add := func(field *Selection) {
+		if existing, ok := byKey[field.Key()]; ok {
+			merged := *existing
+			merged.Selections = append(append([]*Selection{}, existing.Selections...), field.Selections...)
+			*existing = merged
+			return
+		}
+		copied := *field
+		byKey[field.Key()] = &copied
+		fields = append(fields, &copied)
+	}
+
+	for _, selection := range selections {
+		include, err := e.shouldInclude(selection.Directives)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			continue
+		}
+
+		var nested []*Selection
+		switch {
+		case selection.FragmentSpread != "":
+			fragment, ok := e.doc.Fragments[selection.FragmentSpread]
+			if !ok {
+				return nil, fmt.Errorf("fragmento %q não definido", selection.FragmentSpread)
+			}
+			if visiting[fragment.Name] {
+				return nil, fmt.Errorf("fragmento %q referencia a si mesmo", fragment.Name)
+			}
+			visiting[fragment.Name] = true
+			nested, err = e.collectFields(fragment.Selections, visiting)
+			delete(visiting, fragment.Name)
+		case selection.InlineFragment:
+			nested, err = e.collectFields(selection.Selections, visiting)
+		default:
+			add(selection)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range nested {
+			add(field)
+		}
+	}
+
+	return fields, nil
+}
+
+func (e *execution) shouldInclude(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "include" && directive.Name != "skip" {
+			continue
+		}
+
+		condition, ok := e.resolveValue(directive.Arguments["if"]).(bool)
+		if !ok {
+			return false, fmt.Errorf("a diretiva @%s exige o argumento booleano if", directive.Name)
+		}
+		if (directive.Name == "include") != condition {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// resolveValue substitui as variáveis de um argumento pelos valores informados
+func (e *execution) resolveValue(value any) any {
+	switch v := value.(type) {
+	case Variable:
+		return e.variables[v.Name]
+	case Enum:
+		return string(v)
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = e.resolveValue(item)
+		}
+		return resolved
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = e.resolveValue(item)
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+// project mantém apenas os campos selecionados do valor. Sem seleção, o valor é retornado inteiro.
+func (e *execution) project(value any, selections []*Selection, path []any) (any, error) {
+	if len(selections) == 0 {
+		return e.strip(value), nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			projected, err := e.project(item, selections, append(path, i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = projected
+		}
+		return list, nil
+	case map[string]any:
+		fields, err := e.collectFields(selections, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+
+		object := newObject()
+		for _, field := range fields {
+			key := field.Key()
+			if field.Name == "__typename" || e.hidden[field.Name] {
+				object.Set(key, nil)
+				continue
+			}
+
+			projected, err := e.project(v[field.Name], field.Selections, append(path, key))
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key, projected)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("o campo em %v não é um objeto e não aceita seleção de subcampos", path)
+	}
+}
+
+// strip remove os campos ocultos de um valor retornado sem seleção
+func (e *execution) strip(value any) any {
+	if len(e.hidden) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			v[i] = e.strip(item)
+		}
+		return v
+	case map[string]any:
+		for key, item := range v {
+			if e.hidden[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = e.strip(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// normalize converte o resultado do resolvedor na representação JSON genérica (mapas e listas)
+func normalize(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// String retorna o argumento como texto; vazio se ausente ou de outro tipo
+func (p ResolveParams) String(name string) string {
+	value, _ := p.Args[name].(string)
+	return value
+}
+
+// Int retorna o argumento como inteiro, ou fallback se ausente
+func (p ResolveParams) Int(name string, fallback int) (int, error) {
+	switch v := p.Args[name].(type) {
+	case nil:
+		return fallback, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("argumento %s deve ser inteiro", name)
+		}
+		return int(v), nil
+	case json.Number:
+		parsed, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("argumento %s deve ser inteiro", name)
+		}
+		return int(parsed), nil
+	default:
+		return 0, fmt.Errorf("argumento %s deve ser inteiro", name)
+	}
+}
+
+// Strings retorna o argumento como lista de textos; um texto isolado vira uma lista de um item
+func (p ResolveParams) Strings(name string) ([]string, error) {
+	switch v := p.Args[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("argumento %s deve ser uma lista de textos", name)
+			}
+			values = append(values, text)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("argumento %s deve ser uma lista de textos", name)
+	}
+}
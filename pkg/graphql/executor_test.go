@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCampaign struct {
+	Name  string  `json:"name"`
+	Spend float64 `json:"spend"`
+}
+
+type testInsights struct {
+	AccountID string          `json:"account_id"`
+	Spend     float64         `json:"spend"`
+	CNPJ      string          `json:"cnpj"`
+	Campaigns []*testCampaign `json:"campaigns"`
+}
+
+func testSchema() *Schema {
+	return &Schema{Query: map[string]FieldResolver{
+		"insights": func(p ResolveParams) (any, error) {
+			limit, err := p.Int("limit", 10)
+			if err != nil {
+				return nil, err
+			}
+			campaigns := []*testCampaign{{Name: "Óculos", Spend: 10.5}, {Name: "Lentes", Spend: 4}}
+			return &testInsights{
+				AccountID: p.String("accountId"),
+				Spend:     14.5,
+				CNPJ:      "12345678000199",
+				Campaigns: campaigns[:min(limit, len(campaigns))],
+			}, nil
+		},
+		"failing": func(ResolveParams) (any, error) {
+			return nil, errors.New("falhou")
+		},
+	}}
+}
+
+func execute(t *testing.T, req *Request, hidden map[string]bool) (string, *Response) {
+	t.Helper()
+	response := testSchema().Execute(ExecuteParams{Context: context.Background(), Request: req, HiddenFields: hidden})
+	encoded, err := json.Marshal(response)
+	require.NoError(t, err)
+	return string(encoded), response
+}
+
+func TestSchema_Execute(t *testing.T) {
+	encoded, _ := execute(t, &Request{
+		Query: `
+			# apenas campanhas e investimento
+			query Dashboard($id: String!, $limit: Int = 5, $withTotal: Boolean!) {
+				insights(accountId: $id, limit: $limit) {
+					account_id
+					total: spend @include(if: $withTotal)
+					campaigns { ...CampaignFields }
+				}
+				erro: failing
+			}
+			fragment CampaignFields on Campaign { name spend }`,
+		OperationName: "Dashboard",
+		Variables:     map[string]any{"id": "act_1", "limit": json.Number("1"), "withTotal": false},
+	}, nil)
+
+	assert.JSONEq(t, `{
+		"data": {
+			"insights": {"account_id": "act_1", "campaigns": [{"name": "Óculos", "spend": 10.5}]},
+			"erro": null
+		},
+		"errors": [{"message": "falhou", "path": ["erro"]}]
+	}`, encoded)
+
+	// A ordem dos campos segue a seleção
+	encoded, _ = execute(t, &Request{Query: `{ insights { spend account_id } }`}, nil)
+	assert.Equal(t, `{"data":{"insights":{"spend":14.5,"account_id":""}}}`, encoded)
+}
+
+func TestSchema_Execute_HiddenFields(t *testing.T) {
+	hidden := map[string]bool{"spend": true, "cnpj": true}
+
+	encoded, _ := execute(t, &Request{Query: `{ insights { documento: cnpj total: spend campaigns { name } } }`}, hidden)
+	assert.JSONEq(t, `{"data": {"insights": {"documento": null, "total": null, "campaigns": [{"name": "Óculos"}, {"name": "Lentes"}]}}}`, encoded)
+
+	// Sem seleção de subcampos o objeto volta inteiro, ainda sem os campos ocultos
+	encoded, _ = execute(t, &Request{Query: `{ insights { campaigns } }`}, hidden)
+	assert.JSONEq(t, `{"data": {"insights": {"campaigns": [{"name": "Óculos"}, {"name": "Lentes"}]}}}`, encoded)
+}
+
+func TestSchema_Execute_RequestErrors(t *testing.T) {
+	cases := map[string]*Request{
+		"sintaxe":             {Query: `{ insights { spend }`},
+		"mutation":            {Query: `mutation { insights { spend } }`},
+		"fragmento ausente":   {Query: `{ insights { ...Missing } }`},
+		"operação ambígua":    {Query: `query A { insights { spend } } query B { insights { spend } }`},
+		"consulta vazia":      {Query: ""},
+		"fragmento recursivo": {Query: `{ insights { ...A } } fragment A on T { ...A }`},
+	}
+
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, response := execute(t, req, nil)
+			assert.Nil(t, response.Data)
+			assert.NotEmpty(t, response.Errors)
+		})
+	}
+
+	_, response := execute(t, &Request{Query: `{ desconhecido insights { spend { valor } } }`}, nil)
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, `campo "desconhecido" não existe em Query`, response.Errors[0].Message)
+	assert.Equal(t, []any{"insights"}, response.Errors[1].Path)
+}
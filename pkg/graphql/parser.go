@@ -0,0 +1,550 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Document é uma consulta GraphQL analisada
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation é uma operação do documento (query, mutation ou subscription)
+type Operation struct {
+	Type       string
+	Name       string
+	Variables  []*VariableDefinition
+	Selections []*Selection
+}
+
+// VariableDefinition declara uma variável da operação e o seu valor padrão
+type VariableDefinition struct {
+	Name       string
+	Default    any
+	HasDefault bool
+}
+
+// Fragment é um fragmento nomeado reutilizado com ...Nome
+type Fragment struct {
+	Name       string
+	Selections []*Selection
+}
+
+// Selection é um campo selecionado, um fragmento (...Nome) ou um fragmento em linha (... on Tipo { })
+type Selection struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]any
+	Directives []*Directive
+	Selections []*Selection
+
+	FragmentSpread string // preenchido em ...Nome
+	InlineFragment bool   // verdadeiro em ... { } e ... on Tipo { }
+}
+
+// Directive é uma diretiva aplicada a uma seleção (ex: @include(if: $x))
+type Directive struct {
+	Name      string
+	Arguments map[string]any
+}
+
+// Variable referencia uma variável da operação em um argumento
+type Variable struct {
+	Name string
+}
+
+// Enum é um valor de enumeração sem aspas em um argumento
+type Enum string
+
+// Key retorna o nome do campo na resposta: o alias, se informado
+func (s *Selection) Key() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
+	return s.Name
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// Parse analisa o texto de uma consulta GraphQL
+func Parse(query string) (doc *Document, err error) {
+	p := &parser{src: query}
+	defer func() {
+		if r := recover(); r != nil {
+			syntaxErr, ok := r.(*syntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, syntaxErr
+		}
+	}()
+
+	p.next()
+	doc = &Document{Fragments: make(map[string]*Fragment)}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peekPunct("{"):
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", Selections: p.parseSelectionSet()})
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			fragment := p.parseFragment()
+			doc.Fragments[fragment.Name] = fragment
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			doc.Operations = append(doc.Operations, p.parseOperation())
+		default:
+			p.fail("esperado operação ou fragmento, encontrado %q", p.tok.value)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		p.fail("o documento não contém operações")
+	}
+
+	return doc, nil
+}
+
+type syntaxError struct {
+	msg  string
+	line int
+	col  int
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("erro de sintaxe na linha %d, coluna %d: %s", e.line, e.col, e.msg)
+}
+
+func (p *parser) fail(format string, args ...any) {
+	line, col := 1, 1
+	for _, r := range p.src[:min(p.tok.pos, len(p.src))] {
+		if r == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	panic(&syntaxError{msg: fmt.Sprintf(format, args...), line: line, col: col})
+}
+
+func (p *parser) parseOperation() *Operation {
+	op := &Operation{Type: p.tok.value}
+	p.next()
+
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.value
+		p.next()
+	}
+
+	if p.peekPunct("(") {
+		p.next()
+		for !p.peekPunct(")") {
+			p.expectPunct("$")
+			def := &VariableDefinition{Name: p.expectName()}
+			p.expectPunct(":")
+			p.parseType()
+			if p.peekPunct("=") {
+				p.next()
+				def.Default = p.parseValue(true)
+				def.HasDefault = true
+			}
+			p.parseDirectives()
+			op.Variables = append(op.Variables, def)
+		}
+		p.next()
+	}
+
+	p.parseDirectives()
+	op.Selections = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseFragment() *Fragment {
+	p.next()
+	fragment := &Fragment{Name: p.expectName()}
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		p.fail("esperado \"on\" após o nome do fragmento")
+	}
+	p.next()
+	p.expectName()
+	p.parseDirectives()
+	fragment.Selections = p.parseSelectionSet()
+	return fragment
+}
+
+// parseType consome a declaração de tipo de uma variável; os tipos não são validados
+func (p *parser) parseType() {
+	if p.peekPunct("[") {
+		p.next()
+		p.parseType()
+		p.expectPunct("]")
+	} else {
+		p.expectName()
+	}
+	if p.peekPunct("!") {
+		p.next()
+	}
+}
+
+func (p *parser) parseSelectionSet() []*Selection {
+	p.expectPunct("{")
+
+	var selections []*Selection
+	for !p.peekPunct("}") {
+		if p.tok.kind == tokenEOF {
+			p.fail("seleção não fechada")
+		}
+		selections = append(selections, p.parseSelection())
+	}
+	p.next()
+
+	if len(selections) == 0 {
+		p.fail("seleção vazia")
+	}
+	return selections
+}
+
+func (p *parser) parseSelection() *Selection {
+	if p.peekPunct("...") {
+		p.next()
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			spread := &Selection{FragmentSpread: p.tok.value}
+			p.next()
+			spread.Directives = p.parseDirectives()
+			return spread
+		}
+
+		inline := &Selection{InlineFragment: true}
+		if p.tok.kind == tokenName && p.tok.value == "on" {
+			p.next()
+			p.expectName()
+		}
+		inline.Directives = p.parseDirectives()
+		inline.Selections = p.parseSelectionSet()
+		return inline
+	}
+
+	selection := &Selection{Name: p.expectName()}
+	if p.peekPunct(":") {
+		p.next()
+		selection.Alias = selection.Name
+		selection.Name = p.expectName()
+	}
+
+	if p.peekPunct("(") {
+		selection.Arguments = p.parseArguments(false)
+	}
+	selection.Directives = p.parseDirectives()
+	if p.peekPunct("{") {
+		selection.Selections = p.parseSelectionSet()
+	}
+	return selection
+}
+
+func (p *parser) parseArguments(constant bool) map[string]any {
+	p.expectPunct("(")
+	args := make(map[string]any)
+	for !p.peekPunct(")") {
+		name := p.expectName()
+		p.expectPunct(":")
+		args[name] = p.parseValue(constant)
+	}
+	p.next()
+	return args
+}
+
+func (p *parser) parseDirectives() []*Directive {
+	var directives []*Directive
+	for p.peekPunct("@") {
+		p.next()
+		directive := &Directive{Name: p.expectName()}
+		if p.peekPunct("(") {
+			directive.Arguments = p.parseArguments(false)
+		}
+		directives = append(directives, directive)
+	}
+	return directives
+}
+
+func (p *parser) parseValue(constant bool) any {
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				p.fail("variáveis não são permitidas em valores padrão")
+			}
+			p.next()
+			return Variable{Name: p.expectName()}
+		case "[":
+			p.next()
+			list := make([]any, 0)
+			for !p.peekPunct("]") {
+				if p.tok.kind == tokenEOF {
+					p.fail("lista não fechada")
+				}
+				list = append(list, p.parseValue(constant))
+			}
+			p.next()
+			return list
+		case "{":
+			p.next()
+			object := make(map[string]any)
+			for !p.peekPunct("}") {
+				name := p.expectName()
+				p.expectPunct(":")
+				object[name] = p.parseValue(constant)
+			}
+			p.next()
+			return object
+		}
+	case tokenInt:
+		p.next()
+		value, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			p.fail("inteiro inválido %q", tok.value)
+		}
+		return value
+	case tokenFloat:
+		p.next()
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			p.fail("número inválido %q", tok.value)
+		}
+		return value
+	case tokenString:
+		p.next()
+		return tok.value
+	case tokenName:
+		p.next()
+		switch tok.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		default:
+			return Enum(tok.value)
+		}
+	}
+
+	p.fail("valor inesperado %q", tok.value)
+	return nil
+}
+
+func (p *parser) peekPunct(value string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == value
+}
+
+func (p *parser) expectPunct(value string) {
+	if !p.peekPunct(value) {
+		p.fail("esperado %q, encontrado %q", value, p.tok.value)
+	}
+	p.next()
+}
+
+func (p *parser) expectName() string {
+	if p.tok.kind != tokenName {
+		p.fail("esperado nome, encontrado %q", p.tok.value)
+	}
+	name := p.tok.value
+	p.next()
+	return name
+}
+
+// next avança para o próximo token, ignorando espaços, vírgulas e comentários
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case strings.HasPrefix(p.src[p.pos:], "\ufeff"):
+			p.pos += len("\ufeff")
+		default:
+			p.tok = p.scan()
+			return
+		}
+	}
+	p.tok = token{kind: tokenEOF, pos: p.pos}
+}
+
+func (p *parser) scan() token {
+	start := p.pos
+	c := p.src[p.pos]
+
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		return token{kind: tokenPunct, value: "...", pos: start}
+	case strings.ContainsRune("!$():=@[]{}|&", rune(c)):
+		p.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		return token{kind: tokenName, value: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return p.scanNumber()
+	case c == '"':
+		return p.scanString()
+	}
+
+	p.tok = token{pos: start, value: string(c)}
+	p.fail("caractere inesperado %q", string(c))
+	return token{}
+}
+
+func (p *parser) scanNumber() token {
+	start := p.pos
+	kind := tokenInt
+
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+
+	return token{kind: kind, value: p.src[start:p.pos], pos: start}
+}
+
+func (p *parser) scanString() token {
+	start := p.pos
+
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			p.tok = token{pos: start}
+			p.fail("string não fechada")
+		}
+		value := p.src[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		return token{kind: tokenString, value: strings.TrimSpace(value), pos: start}
+	}
+
+	p.pos++
+	var value strings.Builder
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			p.tok = token{pos: start}
+			p.fail("string não fechada")
+		}
+
+		c := p.src[p.pos]
+		if c == '"' {
+			p.pos++
+			return token{kind: tokenString, value: value.String(), pos: start}
+		}
+
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			value.WriteRune(r)
+			p.pos += size
+			continue
+		}
+
+		if p.pos+1 >= len(p.src) {
+			p.tok = token{pos: start}
+			p.fail("string não fechada")
+		}
+		escaped := p.src[p.pos+1]
+		p.pos += 2
+		switch escaped {
+		case '"', '\\', '/':
+			value.WriteByte(escaped)
+		case 'b':
+			value.WriteByte('\b')
+		case 'f':
+			value.WriteByte('\f')
+		case 'n':
+			value.WriteByte('\n')
+		case 'r':
+			value.WriteByte('\r')
+		case 't':
+			value.WriteByte('\t')
+		case 'u':
+			if p.pos+4 > len(p.src) {
+				p.tok = token{pos: start}
+				p.fail("escape unicode inválido")
+			}
+			code, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+			if err != nil {
+				p.tok = token{pos: start}
+				p.fail("escape unicode inválido")
+			}
+			p.pos += 4
+			r := rune(code)
+			// Caracteres fora do plano básico (ex: emoji) chegam como um par de escapes UTF-16
+			if utf16.IsSurrogate(r) && strings.HasPrefix(p.src[p.pos:], `\u`) && p.pos+6 <= len(p.src) {
+				if low, err := strconv.ParseUint(p.src[p.pos+2:p.pos+6], 16, 32); err == nil {
+					if combined := utf16.DecodeRune(r, rune(low)); combined != utf8.RuneError {
+						r = combined
+						p.pos += 6
+					}
+				}
+			}
+			value.WriteRune(r)
+		default:
+			p.tok = token{pos: start}
+			p.fail("escape inválido \\%c", escaped)
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// literal escreve o valor como literal GraphQL, para conferir que Parse devolve o mesmo valor
+func literal(t *testing.T, value any) string {
+	t.Helper()
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = literal(t, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			fields[i] = key + ": " + literal(t, v[key])
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case Enum:
+		return string(v)
+	case nil:
+		return "null"
+	default:
+		encoded, err := json.Marshal(v)
+		require.NoError(t, err)
+		return string(encoded)
+	}
+}
+
+func parseArgument(t *testing.T, value string) any {
+	t.Helper()
+	doc, err := Parse(fmt.Sprintf("{ campo(valor: %s) }", value))
+	require.NoError(t, err)
+	return doc.Operations[0].Selections[0].Arguments["valor"]
+}
+
+func TestParse_ValueRoundTrip(t *testing.T) {
+	values := []any{
+		"",
+		"Óculos de sol",
+		"aspas \" barra \\ e / barra normal",
+		"quebra\nde linha\ttab\r\b\f",
+		"<html> & 'apóstrofo'",
+		"emoji 😎 e ideograma 眼鏡",
+		"  ",
+		int64(0),
+		int64(-42),
+		int64(9007199254740993),
+		1.5,
+		-0.25,
+		1e21,
+		true,
+		false,
+		nil,
+		Enum("ACTIVE"),
+		[]any{},
+		[]any{int64(1), "dois", []any{3.5, nil}},
+		map[string]any{},
+		map[string]any{"nome": "Loja", "filtros": map[string]any{"status": []any{Enum("ACTIVE"), Enum("PAUSED")}}},
+	}
+
+	for _, value := range values {
+		t.Run(fmt.Sprintf("%v", value), func(t *testing.T) {
+			assert.Equal(t, value, parseArgument(t, literal(t, value)))
+		})
+	}
+}
+
+func TestParse_StringEscapes(t *testing.T) {
+	tests := map[string]string{
+		`"\u00e9"`:       "é",
+		`"\u00C9"`:       "É",
+		`"\uD83D\uDE0E"`: "😎",
+		`"""
+		  bloco com "aspas"
+		"""`: `bloco com "aspas"`,
+	}
+
+	for query, expected := range tests {
+		assert.Equal(t, expected, parseArgument(t, query), query)
+	}
+
+	for _, query := range []string{`"sem fim`, `"quebra` + "\n" + `"`, `"\x"`, `"\u12"`, `"\uzzzz"`, `"""sem fim`} {
+		_, err := Parse(fmt.Sprintf("{ campo(valor: %s) }", query))
+		assert.Error(t, err, query)
+	}
+}
+
+func TestParse_Document(t *testing.T) {
+	doc, err := Parse(`
+		query Painel($id: String!, $limite: Int = 10, $filtros: [String!] = ["a", "b"]) {
+			conta: account(id: $id) @include(if: true) {
+				nome
+				...Metricas
+				... on Account { status }
+				... @skip(if: false) { criado_em }
+			}
+		}
+		fragment Metricas on Insights { spend reach }
+		{ ranking }`)
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 2)
+
+	operation := doc.Operations[0]
+	assert.Equal(t, "query", operation.Type)
+	assert.Equal(t, "Painel", operation.Name)
+	require.Len(t, operation.Variables, 3)
+	assert.Equal(t, "id", operation.Variables[0].Name)
+	assert.False(t, operation.Variables[0].HasDefault)
+	assert.Equal(t, int64(10), operation.Variables[1].Default)
+	assert.Equal(t, []any{"a", "b"}, operation.Variables[2].Default)
+
+	require.Len(t, operation.Selections, 1)
+	account := operation.Selections[0]
+	assert.Equal(t, "conta", account.Key())
+	assert.Equal(t, "account", account.Name)
+	assert.Equal(t, map[string]any{"id": Variable{Name: "id"}}, account.Arguments)
+	assert.Equal(t, []*Directive{{Name: "include", Arguments: map[string]any{"if": true}}}, account.Directives)
+
+	require.Len(t, account.Selections, 4)
+	assert.Equal(t, "nome", account.Selections[0].Key())
+	assert.Equal(t, "Metricas", account.Selections[1].FragmentSpread)
+	assert.True(t, account.Selections[2].InlineFragment)
+	assert.Equal(t, "status", account.Selections[2].Selections[0].Name)
+	assert.True(t, account.Selections[3].InlineFragment)
+	assert.Equal(t, "skip", account.Selections[3].Directives[0].Name)
+
+	require.Contains(t, doc.Fragments, "Metricas")
+	assert.Len(t, doc.Fragments["Metricas"].Selections, 2)
+
+	assert.Equal(t, "query", doc.Operations[1].Type)
+	assert.Equal(t, "ranking", doc.Operations[1].Selections[0].Name)
+
+	_, err = Parse(`query ($x: Int = $y) { campo }`)
+	assert.Error(t, err)
+}
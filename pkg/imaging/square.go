@@ -0,0 +1,91 @@
+// Package imaging contém utilitários de processamento de imagens usando apenas a biblioteca padrão
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+// maxSourcePixels limita a resolução aceita para evitar consumo excessivo de memória na decodificação
+const maxSourcePixels = 40_000_000
+
+var (
+	ErrUnsupportedImage = errors.New("formato de imagem não suportado (use JPEG, PNG ou GIF)")
+	ErrImageTooLarge    = errors.New("resolução da imagem acima do limite")
+)
+
+// SquareJPEG decodifica a imagem, recorta o quadrado central e redimensiona para size x size,
+// retornando o resultado codificado em JPEG. Transparências são preenchidas com branco.
+func SquareJPEG(data []byte, size int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	resized := resizeSquare(src, size)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("erro ao codificar imagem: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resizeSquare recorta o quadrado central de src e o reduz (ou amplia) para size x size
+// calculando a média dos pixels de origem que caem em cada pixel de destino
+func resizeSquare(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	offsetX := bounds.Min.X + (bounds.Dx()-side)/2
+	offsetY := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	// Achata a imagem sobre fundo branco para descartar o canal alfa
+	flat := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, image.Pt(offsetX, offsetY), draw.Over)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0 := y * side / size
+		y1 := max((y+1)*side/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := x * side / size
+			x1 := max((x+1)*side/size, x0+1)
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				row := flat.Pix[sy*flat.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					count++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / count)
+			dst.Pix[i+1] = uint8(g / count)
+			dst.Pix[i+2] = uint8(b / count)
+			dst.Pix[i+3] = 255
+		}
+	}
+
+	return dst
+}
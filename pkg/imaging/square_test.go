@@ -0,0 +1,46 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquareJPEG(t *testing.T) {
+	t.Run("recorta o centro e redimensiona", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 300, 100))
+		for y := 0; y < 100; y++ {
+			for x := 0; x < 300; x++ {
+				c := color.RGBA{R: 255, A: 255}
+				if x >= 100 && x < 200 {
+					c = color.RGBA{B: 255, A: 255}
+				}
+				src.Set(x, y, c)
+			}
+		}
+
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, src))
+
+		out, err := SquareJPEG(buf.Bytes(), 32)
+		assert.NoError(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(out))
+		assert.NoError(t, err)
+		assert.Equal(t, 32, img.Bounds().Dx())
+		assert.Equal(t, 32, img.Bounds().Dy())
+
+		r, _, b, _ := img.At(16, 16).RGBA()
+		assert.Greater(t, b, r, "o quadrado central (azul) deve ser mantido")
+	})
+
+	t.Run("rejeita conteúdo que não é imagem", func(t *testing.T) {
+		_, err := SquareJPEG([]byte("not an image"), 32)
+		assert.ErrorIs(t, err, ErrUnsupportedImage)
+	})
+}
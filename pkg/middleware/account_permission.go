@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+const ContextKeyAccountPermission contextKey = "account_permission"
+
+// AccountPermissionChecker resolve o nível de acesso de um usuário em uma conta vinculada
+type AccountPermissionChecker interface {
+	GetAccountPermission(userID int, accountID string) (domain.AccountPermission, error)
+}
+
+// RequireAccountPermission exige que o usuário tenha ao menos o nível informado na conta do parâmetro :id.
+// Perfis com a permissão accounts:all têm acesso total. O nível resolvido fica disponível no contexto
+// para que o handler aplique regras mais finas (ver AccountPermissionFromContext).
+func RequireAccountPermission(checker AccountPermissionChecker, required domain.AccountPermission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok {
+				apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+				return
+			}
+
+			if HasPermission(r.Context(), domain.PermissionAccountsAll) {
+				ctx := context.WithValue(r.Context(), ContextKeyAccountPermission, domain.AccountPermissionManager)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			accountID := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+			permission, err := checker.GetAccountPermission(userClaims.UserID, accountID)
+			if err != nil {
+				logrus.WithError(err).Error("Erro ao consultar permissão da conta")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao verificar permissão da conta", nil)
+				return
+			}
+
+			if !permission.Allows(required) {
+				logrus.Warningf("Acesso negado à conta %s para usuário ID=%d (permissão=%q, exigida=%s)",
+					accountID, userClaims.UserID, permission, required)
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para esta ação nesta conta", nil)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ContextKeyAccountPermission, permission)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// AccountPermissionFromContext retorna o nível de acesso resolvido por RequireAccountPermission
+func AccountPermissionFromContext(ctx context.Context) domain.AccountPermission {
+	permission, _ := ctx.Value(ContextKeyAccountPermission).(domain.AccountPermission)
+	return permission
+}
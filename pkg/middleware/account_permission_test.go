@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+// accountLinks simula o vínculo do repositório, que resolve a conta pelo ID interno ou pelo external_id
+type accountLinks map[string]domain.AccountPermission
+
+func (l accountLinks) GetAccountPermission(userID int, accountID string) (domain.AccountPermission, error) {
+	return l[accountID], nil
+}
+
+func TestRequireAccountPermission(t *testing.T) {
+	links := accountLinks{
+		"AAA111":      domain.AccountPermissionAnalyst,
+		"act_9876543": domain.AccountPermissionAnalyst,
+	}
+
+	tests := []struct {
+		name        string
+		accountID   string
+		required    domain.AccountPermission
+		permissions domain.PermissionSet
+		expected    int
+	}{
+		{name: "ID interno vinculado", accountID: "AAA111", required: domain.AccountPermissionViewer, expected: http.StatusOK},
+		{name: "ID externo vinculado", accountID: "act_9876543", required: domain.AccountPermissionAnalyst, expected: http.StatusOK},
+		{name: "nível insuficiente", accountID: "act_9876543", required: domain.AccountPermissionManager, expected: http.StatusForbidden},
+		{name: "conta não vinculada", accountID: "BBB222", required: domain.AccountPermissionViewer, expected: http.StatusForbidden},
+		{name: "acesso a todas as contas", accountID: "BBB222", required: domain.AccountPermissionManager, permissions: domain.PermissionSet{domain.PermissionAccountsAll: true}, expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireAccountPermission(links, tt.required)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.True(t, AccountPermissionFromContext(r.Context()).Allows(tt.required))
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := context.WithValue(context.Background(), ContextKeyUser, &domain.Claims{UserID: 7})
+			ctx = context.WithValue(ctx, ContextKeyPermissions, tt.permissions)
+			ctx = context.WithValue(ctx, httprouter.ParamsKey, httprouter.Params{{Key: "id", Value: tt.accountID}})
+			r := httptest.NewRequest(http.MethodGet, "/v1/adAccount/"+tt.accountID+"/dashboard", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// AccountViewRecorder registra que o dashboard de uma conta foi aberto
+type AccountViewRecorder interface {
+	RecordView(accountID string)
+}
+
+// TrackAccountView registra uma visualização da conta do parâmetro :id a cada requisição.
+// Deve vir depois de RequireAccountPermission para contar apenas acessos autorizados.
+func TrackAccountView(recorder AccountViewRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if accountID := httprouter.ParamsFromContext(r.Context()).ByName("id"); accountID != "" {
+				recorder.RecordView(accountID)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
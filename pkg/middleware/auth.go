@@ -2,46 +2,149 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
 )
 
 type contextKey string
 
 const (
 	ContextKeyUser contextKey = "user"
+	// APIKeyHeader traz a chave de API das integrações, no lugar do token de acesso
+	APIKeyHeader = "X-API-Key"
+	// RenewedTokenHeader traz o token renovado quando a sessão é estendida; o cliente deve passar a usá-lo
+	RenewedTokenHeader = "X-Renewed-Token"
 )
 
-func AuthMiddleware(authService authenticating.Authenticator) func(http.Handler) http.Handler {
+// AuthMiddleware valida o token e renova a sessão enquanto o usuário estiver ativo. No modo bearer o
+// token renovado vem no cabeçalho X-Renewed-Token; no modo cookie o cookie de sessão é regravado.
+func AuthMiddleware(authService authenticating.Authenticator, cookieAuth CookieAuthConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/v1/login" || r.URL.Path == "/healthcheck" || r.URL.Path == "/v1/register" {
+			// A troca do refresh token e o logout são chamados com o token de acesso já expirado; a
+			// recuperação de senha e a confirmação de e-mail, sem sessão. Os arquivos enviados são públicos, como no S3
+			if strings.HasPrefix(r.URL.Path, "/uploads/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.URL.Path == "/v1/login" || r.URL.Path == "/healthcheck" || r.URL.Path == "/readyz" || r.URL.Path == "/v1/register" ||
+				r.URL.Path == "/v1/auth/refresh" || r.URL.Path == "/v1/auth/logout" ||
+				r.URL.Path == "/v1/auth/forgot-password" || r.URL.Path == "/v1/auth/reset-password" ||
+				r.URL.Path == "/v1/auth/verify-email" || r.URL.Path == "/v1/auth/resend-verification" {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Authorization header is required", http.StatusUnauthorized)
+			// Integrações autenticam com chave de API: sem sessão, portanto sem renovação de token
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+				claims, err := authService.AuthenticateAPIKey(apiKey)
+				if err != nil {
+					writeAPIKeyError(w, err)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), ContextKeyUser, claims)
+				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 
-			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-			if tokenString == authHeader {
-				http.Error(w, "Bearer token is required", http.StatusUnauthorized)
+			var tokenString string
+			fromCookie := false
+			authHeader := r.Header.Get("Authorization")
+			if authHeader != "" {
+				tokenString = strings.TrimPrefix(authHeader, "Bearer ")
+				if tokenString == authHeader {
+					http.Error(w, "Bearer token is required", http.StatusUnauthorized)
+					return
+				}
+			} else if cookie, err := r.Cookie(SessionCookieName); err == nil && cookie.Value != "" {
+				// Modo cookie (dashboard web): requisições que alteram estado exigem o token CSRF
+				if !isSafeMethod(r.Method) && !validCSRF(r) {
+					apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Token CSRF ausente ou inválido", nil)
+					return
+				}
+				tokenString = cookie.Value
+				fromCookie = true
+			} else {
+				http.Error(w, "Authorization header is required", http.StatusUnauthorized)
 				return
 			}
 
 			claims, err := authService.ValidateToken(tokenString)
 			if err != nil {
+				if errors.Is(err, authenticating.ErrExpiredToken) {
+					apiErrors.WriteError(w, apiErrors.ErrExpiredToken, "Sessão expirada, faça login novamente", nil)
+					return
+				}
+				if errors.Is(err, authenticating.ErrRevokedToken) {
+					apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Sessão encerrada, faça login novamente", nil)
+					return
+				}
+				if errors.Is(err, authenticating.ErrDatabaseOperation) {
+					logrus.WithError(err).Error("Erro ao verificar revogação do token")
+					apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao validar sessão", nil)
+					return
+				}
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
+			// Senha expirada ou temporária: apenas a troca de senha e o perfil ficam liberados
+			if claims.PasswordChangeRequired && !allowedWithPasswordChangeRequired(r.URL.Path) {
+				apiErrors.WriteError(w, apiErrors.ErrPasswordExpired, "Troca de senha obrigatória antes de continuar", nil)
+				return
+			}
+
+			renewed, err := authService.RenewToken(claims)
+			if err != nil {
+				logrus.WithError(err).WithField("user_id", claims.UserID).Warn("Erro ao renovar sessão")
+			}
+			if renewed != "" {
+				if fromCookie {
+					RenewSessionCookie(w, cookieAuth, renewed)
+				} else {
+					w.Header().Set(RenewedTokenHeader, renewed)
+				}
+			}
+
 			ctx := context.WithValue(r.Context(), ContextKeyUser, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func writeAPIKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, authenticating.ErrExpiredToken):
+		apiErrors.WriteError(w, apiErrors.ErrExpiredToken, "Chave de API expirada", nil)
+	case errors.Is(err, authenticating.ErrUserDisabled):
+		apiErrors.WriteError(w, apiErrors.ErrUserDisabled, "Usuário da chave de API desativado", nil)
+	case errors.Is(err, authenticating.ErrDatabaseOperation):
+		logrus.WithError(err).Error("Erro ao validar chave de API")
+		apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao validar chave de API", nil)
+	default:
+		apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Chave de API inválida", nil)
+	}
+}
+
+// AccessTokenFromRequest retorna o token de acesso do cabeçalho Authorization ou, com CSRF válido, do
+// cookie de sessão. Usado nas rotas fora do AuthMiddleware, como o logout.
+func AccessTokenFromRequest(r *http.Request) string {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if cookie, err := r.Cookie(SessionCookieName); err == nil && cookie.Value != "" && validCSRF(r) {
+		return cookie.Value
+	}
+	return ""
+}
+
+func allowedWithPasswordChangeRequired(path string) bool {
+	return path == "/v1/me" ||
+		(strings.HasPrefix(path, "/v1/users/") && strings.HasSuffix(path, "/change-password"))
+}
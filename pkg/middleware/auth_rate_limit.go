@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/ratelimit"
+)
+
+// maxRateLimitBodyBytes limita a leitura do corpo para identificar o e-mail da tentativa
+const maxRateLimitBodyBytes = 64 << 10
+
+// rateLimitedAuthPaths são as rotas de autenticação limitadas. As que recebem o e-mail no corpo também
+// são limitadas por usuário.
+var rateLimitedAuthPaths = map[string]bool{
+	"/v1/login":                true,
+	"/v1/auth/forgot-password": true,
+	"/v1/auth/refresh":         false,
+}
+
+// AuthRateLimitConfig configura o limite de requisições às rotas de autenticação
+type AuthRateLimitConfig struct {
+	IPLimit           int                // requisições por IP na janela; 0 desabilita
+	UserLimit         int                // requisições por e-mail na janela; 0 desabilita
+	IPRequests        *ratelimit.Counter // requisições por IP e rota
+	UserRequests      *ratelimit.Counter // requisições por e-mail e rota
+	TrustProxyHeaders bool
+}
+
+// NewAuthRateLimitConfig cria a configuração com contadores próprios na janela informada
+func NewAuthRateLimitConfig(ipLimit, userLimit int, window time.Duration, trustProxyHeaders bool) AuthRateLimitConfig {
+	return AuthRateLimitConfig{
+		IPLimit:           ipLimit,
+		UserLimit:         userLimit,
+		IPRequests:        ratelimit.NewCounter(window),
+		UserRequests:      ratelimit.NewCounter(window),
+		TrustProxyHeaders: trustProxyHeaders,
+	}
+}
+
+// AuthRateLimit limita as requisições de login, recuperação de senha e renovação de token por IP e, no
+// login e na recuperação de senha, pelo e-mail informado, contra tentativas em massa de credenciais.
+// Diferente do LoginCaptcha, conta todas as tentativas, e não só as falhas. Acima do limite responde 429
+// com Retry-After. Deve ser registrado antes do LoginCaptcha.
+func AuthRateLimit(cfg AuthRateLimitConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if (cfg.IPLimit <= 0 || cfg.IPRequests == nil) && (cfg.UserLimit <= 0 || cfg.UserRequests == nil) {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			byUser, limited := rateLimitedAuthPaths[r.URL.Path]
+			if r.Method != http.MethodPost || !limited {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cfg.IPLimit > 0 && cfg.IPRequests != nil {
+				ip := ClientIP(r, cfg.TrustProxyHeaders)
+				if exceeded(w, cfg.IPRequests, r.URL.Path+":"+ip, cfg.IPLimit, logrus.Fields{"ip": ip, "path": r.URL.Path}) {
+					return
+				}
+			}
+
+			if byUser && cfg.UserLimit > 0 && cfg.UserRequests != nil {
+				if email := requestEmail(r); email != "" {
+					if exceeded(w, cfg.UserRequests, r.URL.Path+":"+email, cfg.UserLimit, logrus.Fields{"email": email, "path": r.URL.Path}) {
+						return
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// exceeded registra a requisição e, acima do limite, responde 429. Só a primeira requisição recusada na
+// janela é registrada no log.
+func exceeded(w http.ResponseWriter, requests *ratelimit.Counter, key string, limit int, fields logrus.Fields) bool {
+	used := requests.Increment(key)
+	if used <= limit {
+		return false
+	}
+	if used == limit+1 {
+		logrus.WithFields(fields).Warn("Limite de requisições de autenticação excedido")
+	}
+
+	retryAfter := max(int(time.Until(requests.ResetAt(key)).Seconds()), 1)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	apiErrors.WriteError(w, apiErrors.ErrRateLimitExceeded, "Muitas tentativas, tente novamente mais tarde", map[string]any{
+		"retry_after_seconds": retryAfter,
+	})
+	return true
+}
+
+// requestEmail lê o e-mail do corpo JSON sem consumi-lo, para que o handler ainda possa decodificá-lo
+func requestEmail(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRateLimitBodyBytes))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthRateLimit(t *testing.T) {
+	handler := AuthRateLimit(NewAuthRateLimitConfig(3, 2, time.Minute, true))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// O corpo continua disponível para o handler depois da leitura do e-mail
+		body, _ := io.ReadAll(r.Body)
+		assert.NotEmpty(t, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		ip       string
+		email    string
+		expected int
+	}{
+		{name: "primeira tentativa do e-mail", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.1", email: "ana@example.com", expected: http.StatusOK},
+		{name: "segunda tentativa do e-mail, de outro IP", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.2", email: "ANA@example.com", expected: http.StatusOK},
+		{name: "limite por e-mail excedido", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.3", email: "ana@example.com", expected: http.StatusTooManyRequests},
+		{name: "outro e-mail do mesmo IP", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.1", email: "bia@example.com", expected: http.StatusOK},
+		{name: "terceira tentativa do IP", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.1", email: "caio@example.com", expected: http.StatusOK},
+		{name: "limite por IP excedido", method: http.MethodPost, path: "/v1/login", ip: "198.51.100.1", email: "duda@example.com", expected: http.StatusTooManyRequests},
+		{name: "contagem separada por rota", method: http.MethodPost, path: "/v1/auth/forgot-password", ip: "198.51.100.1", email: "ana@example.com", expected: http.StatusOK},
+		{name: "rota sem limite", method: http.MethodPost, path: "/v1/annotations", ip: "198.51.100.1", email: "ana@example.com", expected: http.StatusOK},
+		{name: "método que não é POST", method: http.MethodGet, path: "/v1/login", ip: "198.51.100.1", email: "ana@example.com", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"email":"`+tt.email+`","password":"x"}`))
+			r.Header.Set("X-Forwarded-For", tt.ip)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusTooManyRequests {
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestAuthRateLimit_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthRateLimit(NewAuthRateLimitConfig(0, 0, time.Minute, false))(next)
+
+	for range 5 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(`{"email":"ana@example.com"}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
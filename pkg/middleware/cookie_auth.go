@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SessionCookieName é o cookie HttpOnly que carrega o JWT no modo cookie
+	SessionCookieName = "tm_session"
+	// CSRFCookieName é o cookie legível pelo dashboard com o token CSRF (double submit)
+	CSRFCookieName = "tm_csrf"
+	// CSRFHeaderName é o cabeçalho em que o dashboard deve reenviar o token CSRF
+	CSRFHeaderName = "X-CSRF-Token"
+	// RefreshCookieName é o cookie HttpOnly com o refresh token, enviado apenas às rotas de RefreshCookiePath
+	RefreshCookieName = "tm_refresh"
+	RefreshCookiePath = "/v1/auth"
+)
+
+// CookieAuthConfig configura o modo de autenticação por cookie usado pelo dashboard web
+type CookieAuthConfig struct {
+	Enabled  bool
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite
+	MaxAge   time.Duration
+}
+
+// ParseSameSite converte o valor de configuração (strict, lax, none) em http.SameSite
+func ParseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	default:
+		return http.SameSiteNoneMode
+	}
+}
+
+// WantsCookieAuth indica se o cliente pediu o modo cookie no login (X-Auth-Mode: cookie ou ?mode=cookie)
+func WantsCookieAuth(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("X-Auth-Mode"), "cookie") || r.URL.Query().Get("mode") == "cookie"
+}
+
+// SetSessionCookies grava o JWT em um cookie HttpOnly e gera um novo token CSRF, que é retornado
+func SetSessionCookies(w http.ResponseWriter, cfg CookieAuthConfig, token string) (string, error) {
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	maxAge := int(cfg.MaxAge.Seconds())
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Domain:   cfg.Domain,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    csrfToken,
+		Path:     "/",
+		Domain:   cfg.Domain,
+		MaxAge:   maxAge,
+		HttpOnly: false,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	})
+
+	return csrfToken, nil
+}
+
+// RenewSessionCookie regrava o cookie de sessão com o token renovado, mantendo o token CSRF atual
+func RenewSessionCookie(w http.ResponseWriter, cfg CookieAuthConfig, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Domain:   cfg.Domain,
+		MaxAge:   int(cfg.MaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	})
+}
+
+// SetRefreshCookie grava o refresh token em um cookie HttpOnly válido até a expiração do token
+func SetRefreshCookie(w http.ResponseWriter, cfg CookieAuthConfig, token string, expiresAt time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    token,
+		Path:     RefreshCookiePath,
+		Domain:   cfg.Domain,
+		MaxAge:   int(time.Until(expiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	})
+}
+
+// RefreshTokenFromCookie retorna o refresh token do cookie. Como nas demais requisições do modo cookie
+// que alteram estado, o token CSRF é exigido; sem ele o cookie é ignorado.
+func RefreshTokenFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie(RefreshCookieName)
+	if err != nil || cookie.Value == "" || !validCSRF(r) {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ClearSessionCookies remove os cookies de sessão, CSRF e do refresh token
+func ClearSessionCookies(w http.ResponseWriter, cfg CookieAuthConfig) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    "",
+		Path:     RefreshCookiePath,
+		Domain:   cfg.Domain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	})
+
+	for _, name := range []string{SessionCookieName, CSRFCookieName} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			Domain:   cfg.Domain,
+			MaxAge:   -1,
+			HttpOnly: name == SessionCookieName,
+			Secure:   cfg.Secure,
+			SameSite: cfg.SameSite,
+		})
+	}
+}
+
+// validCSRF verifica se o cabeçalho CSRF confere com o cookie CSRF
+func validCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	header := r.Header.Get(CSRFHeaderName)
+	if header == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) == 1
+}
+
+// isSafeMethod indica métodos que não alteram estado e dispensam verificação CSRF
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func generateCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/internal/usecases/authenticating"
+)
+
+// fakeAuthenticator aceita apenas o token "valido" e não renova a sessão
+type fakeAuthenticator struct {
+	authenticating.Authenticator
+}
+
+func (fakeAuthenticator) ValidateToken(tokenString string) (*domain.Claims, error) {
+	if tokenString != "valido" {
+		return nil, authenticating.ErrExpiredToken
+	}
+	return &domain.Claims{UserID: 7}, nil
+}
+
+func (fakeAuthenticator) RenewToken(claims *domain.Claims) (string, error) {
+	return "", nil
+}
+
+func TestAuthMiddleware_CookieCSRF(t *testing.T) {
+	handler := AuthMiddleware(fakeAuthenticator{}, CookieAuthConfig{Enabled: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		method   string
+		bearer   bool
+		cookie   string
+		header   string
+		expected int
+	}{
+		{name: "leitura com cookie dispensa o token CSRF", method: http.MethodGet, expected: http.StatusOK},
+		{name: "alteração com token CSRF igual ao cookie", method: http.MethodPost, cookie: "csrf-1", header: "csrf-1", expected: http.StatusOK},
+		{name: "alteração sem cabeçalho CSRF", method: http.MethodPost, cookie: "csrf-1", expected: http.StatusUnauthorized},
+		{name: "alteração sem cookie CSRF", method: http.MethodDelete, header: "csrf-1", expected: http.StatusUnauthorized},
+		{name: "alteração com token CSRF diferente do cookie", method: http.MethodPut, cookie: "csrf-1", header: "csrf-2", expected: http.StatusUnauthorized},
+		{name: "bearer não usa CSRF", method: http.MethodPost, bearer: true, expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/v1/annotations", nil)
+			if tt.bearer {
+				r.Header.Set("Authorization", "Bearer valido")
+			} else {
+				r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "valido"})
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(CSRFHeaderName, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestRefreshTokenFromCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/v1/auth/refresh", nil)
+	r.AddCookie(&http.Cookie{Name: RefreshCookieName, Value: "refresh-1"})
+	r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "csrf-1"})
+	assert.Empty(t, RefreshTokenFromCookie(r))
+
+	r.Header.Set(CSRFHeaderName, "csrf-1")
+	assert.Equal(t, "refresh-1", RefreshTokenFromCookie(r))
+}
@@ -29,8 +29,9 @@ func Cors() func(http.Handler) http.Handler {
 			if isOriginAllowed(origin) {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-				w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-Requested-With")
+				w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-Requested-With, X-CSRF-Token, X-Auth-Mode, X-Captcha-Token")
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
+				w.Header().Set("Access-Control-Expose-Headers", "X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, X-Renewed-Token")
 				w.Header().Set("Content-Type", "application/json")
 				w.Header().Set("Access-Control-Max-Age", "86400") // Cache do CORS por 24 horas
 			}
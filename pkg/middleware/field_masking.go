@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+// MaskedFieldsProvider informa os campos ocultados para o perfil. Um erro indica que as regras não
+// estão disponíveis e a resposta não pode ser enviada.
+type MaskedFieldsProvider interface {
+	MaskedFields(roleID int) (map[string]bool, error)
+}
+
+// FieldMasking remove das respostas JSON os campos configurados para o perfil do usuário
+// (ex: spend, cnpj), em qualquer nível do payload. Respostas que não são JSON passam sem alteração.
+// Sem as regras de mascaramento, a requisição é recusada em vez de expor os campos.
+// Deve ser registrado depois do AuthMiddleware.
+func FieldMasking(provider MaskedFieldsProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			fields, err := provider.MaskedFields(userClaims.UserRoleID)
+			if err != nil {
+				logrus.WithError(err).Error("Campos mascarados indisponíveis, requisição recusada")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao carregar as regras de acesso aos campos", nil)
+				return
+			}
+			if len(fields) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mw := &maskingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(mw, r)
+
+			if mw.passthrough {
+				return
+			}
+
+			body := mw.buf.Bytes()
+			if mw.statusCode >= 200 && mw.statusCode < 300 && len(body) > 0 {
+				var payload any
+				decoder := json.NewDecoder(bytes.NewReader(body))
+				decoder.UseNumber()
+				if err := decoder.Decode(&payload); err == nil {
+					if masked, err := json.Marshal(maskFields(payload, fields)); err == nil {
+						body = append(masked, '\n')
+					}
+				} else {
+					logrus.WithError(err).Warn("Resposta JSON inválida, enviada sem mascaramento")
+				}
+			}
+
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(mw.statusCode)
+			w.Write(body)
+		})
+	}
+}
+
+// maskFields remove recursivamente as chaves configuradas de objetos e listas
+func maskFields(value any, fields map[string]bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if fields[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = maskFields(item, fields)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = maskFields(item, fields)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// maskingResponseWriter acumula respostas JSON para mascaramento e repassa as demais diretamente
+type maskingResponseWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	statusCode  int
+	decided     bool
+	passthrough bool
+}
+
+func (m *maskingResponseWriter) decide() {
+	if m.decided {
+		return
+	}
+	m.decided = true
+	m.passthrough = !strings.HasPrefix(m.Header().Get("Content-Type"), "application/json")
+}
+
+func (m *maskingResponseWriter) WriteHeader(code int) {
+	m.decide()
+	if m.passthrough {
+		m.ResponseWriter.WriteHeader(code)
+		return
+	}
+	m.statusCode = code
+}
+
+func (m *maskingResponseWriter) Write(b []byte) (int, error) {
+	m.decide()
+	if m.passthrough {
+		return m.ResponseWriter.Write(b)
+	}
+	return m.buf.Write(b)
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+type maskedFields map[int]map[string]bool
+
+func (m maskedFields) MaskedFields(roleID int) (map[string]bool, error) {
+	return m[roleID], nil
+}
+
+func TestFieldMasking(t *testing.T) {
+	provider := maskedFields{3: {"spend": true, "cnpj": true}}
+
+	tests := []struct {
+		name        string
+		claims      *domain.Claims
+		contentType string
+		status      int
+		body        string
+		expected    string
+	}{
+		{
+			name:        "remove os campos em qualquer nível",
+			claims:      &domain.Claims{UserRoleID: 3},
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"spend":10.5,"reach":100,"accounts":[{"cnpj":"123","name":"Loja","spend":1}]}`,
+			expected:    `{"accounts":[{"name":"Loja"}],"reach":100}` + "\n",
+		},
+		{
+			name:        "perfil sem campos ocultos recebe a resposta original",
+			claims:      &domain.Claims{UserRoleID: 1},
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"spend":10.5}`,
+			expected:    `{"spend":10.5}`,
+		},
+		{
+			name:        "requisição sem usuário autenticado não é alterada",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"spend":10.5}`,
+			expected:    `{"spend":10.5}`,
+		},
+		{
+			name:        "resposta de erro não é alterada",
+			claims:      &domain.Claims{UserRoleID: 3},
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			body:        `{"spend":"inválido"}`,
+			expected:    `{"spend":"inválido"}`,
+		},
+		{
+			name:        "resposta que não é JSON passa sem alteração",
+			claims:      &domain.Claims{UserRoleID: 3},
+			contentType: "text/csv",
+			status:      http.StatusOK,
+			body:        "spend;cnpj\n10;123\n",
+			expected:    "spend;cnpj\n10;123\n",
+		},
+		{
+			name:        "preserva a precisão dos números",
+			claims:      &domain.Claims{UserRoleID: 3},
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"spend":1,"external_id":12345678901234567890}`,
+			expected:    `{"external_id":12345678901234567890}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := FieldMasking(provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/insights", nil)
+			if tt.claims != nil {
+				r = r.WithContext(context.WithValue(r.Context(), ContextKeyUser, tt.claims))
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.expected, w.Body.String())
+		})
+	}
+}
+
+type unavailableMaskedFields struct{}
+
+func (unavailableMaskedFields) MaskedFields(int) (map[string]bool, error) {
+	return nil, errors.New("regras de mascaramento indisponíveis")
+}
+
+func TestFieldMasking_RulesUnavailable(t *testing.T) {
+	handler := FieldMasking(unavailableMaskedFields{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("a resposta não deveria ser gerada sem as regras de mascaramento")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/insights", nil)
+	r = r.WithContext(context.WithValue(r.Context(), ContextKeyUser, &domain.Claims{UserRoleID: 3}))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "spend")
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+// ipRestrictedPrefixes são os caminhos que disparam uso intensivo de APIs externas
+// e por isso só podem ser acessados a partir das redes permitidas
+var ipRestrictedPrefixes = []string{
+	"/admin/",
+	"/v1/admin/",
+	"/v1/accounts/sync",
+	"/v1/cron/",
+}
+
+// IPAllowlist restringe as rotas administrativas e de sincronização manual às redes (CIDR) informadas.
+// Com a lista vazia o middleware não aplica restrição. Endereços sem máscara são tratados como /32 (ou /128).
+// Uma entrada inválida retorna erro, para que um erro de digitação não desligue a restrição sem aviso.
+func IPAllowlist(cidrs []string, trustProxyHeaders bool) (func(http.Handler) http.Handler, error) {
+	prefixes, err := parsePrefixes(cidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(prefixes) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isIPRestrictedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := ClientIP(r, trustProxyHeaders)
+			addr, err := netip.ParseAddr(ip)
+			if err == nil {
+				addr = addr.Unmap()
+				for _, prefix := range prefixes {
+					if prefix.Contains(addr) {
+						next.ServeHTTP(w, r)
+						return
+					}
+				}
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"ip":   ip,
+				"path": r.URL.Path,
+			}).Warn("Acesso negado: IP fora da lista de permissões")
+
+			apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Acesso não permitido a partir deste endereço IP", nil)
+		})
+	}, nil
+}
+
+// ClientIP retorna o IP do cliente. Com trustProxyHeaders, usa o último endereço de X-Forwarded-For
+// (ou X-Real-IP), que é o adicionado pelo proxy reverso à frente da aplicação; os anteriores vêm do
+// próprio cliente e podem ser forjados. Sem cabeçalho válido, usa o endereço da conexão.
+func ClientIP(r *http.Request, trustProxyHeaders bool) string {
+	if trustProxyHeaders {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if last := strings.TrimSpace(hops[len(hops)-1]); isValidIP(last) {
+				return last
+			}
+		} else if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); isValidIP(realIP) {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func isValidIP(ip string) bool {
+	_, err := netip.ParseAddr(ip)
+	return err == nil
+}
+
+func isIPRestrictedPath(path string) bool {
+	for _, prefix := range ipRestrictedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("endereço inválido na lista de IPs permitidos %q: %w", cidr, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("CIDR inválido na lista de IPs permitidos %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string][]string
+		trust    bool
+		expected string
+	}{
+		{
+			name:     "sem proxy usa o endereço da conexão",
+			headers:  map[string][]string{"X-Forwarded-For": {"10.0.0.1"}},
+			expected: "203.0.113.9",
+		},
+		{
+			name:     "usa o último endereço adicionado pelo proxy",
+			headers:  map[string][]string{"X-Forwarded-For": {"10.0.0.1, 198.51.100.7"}},
+			trust:    true,
+			expected: "198.51.100.7",
+		},
+		{
+			name:     "endereço forjado pelo cliente no início da lista é ignorado",
+			headers:  map[string][]string{"X-Forwarded-For": {"10.0.0.1", "198.51.100.7"}},
+			trust:    true,
+			expected: "198.51.100.7",
+		},
+		{
+			name:     "X-Real-IP quando não há X-Forwarded-For",
+			headers:  map[string][]string{"X-Real-IP": {"198.51.100.8"}},
+			trust:    true,
+			expected: "198.51.100.8",
+		},
+		{
+			name:     "valor inválido volta para o endereço da conexão",
+			headers:  map[string][]string{"X-Forwarded-For": {"10.0.0.1, invalido"}},
+			trust:    true,
+			expected: "203.0.113.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "203.0.113.9:54321"
+			for key, values := range tt.headers {
+				for _, value := range values {
+					r.Header.Add(key, value)
+				}
+			}
+
+			assert.Equal(t, tt.expected, ClientIP(r, tt.trust))
+		})
+	}
+}
+
+func TestIPAllowlist(t *testing.T) {
+	allowlist, err := IPAllowlist([]string{"10.0.0.0/8", " 198.51.100.7 ", ""}, true)
+	assert.NoError(t, err)
+
+	handler := allowlist(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name      string
+		path      string
+		forwarded string
+		expected  int
+	}{
+		{name: "rota sem restrição", path: "/v1/insights", forwarded: "203.0.113.1", expected: http.StatusOK},
+		{name: "rede permitida", path: "/v1/admin/users", forwarded: "10.1.2.3", expected: http.StatusOK},
+		{name: "endereço único permitido", path: "/v1/accounts/sync", forwarded: "198.51.100.7", expected: http.StatusOK},
+		{name: "endereço fora da lista", path: "/v1/admin/users", forwarded: "203.0.113.1", expected: http.StatusForbidden},
+		{name: "endereço permitido forjado antes do proxy", path: "/v1/admin/users", forwarded: "10.1.2.3, 203.0.113.1", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r.Header.Set("X-Forwarded-For", tt.forwarded)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestIPAllowlist_InvalidEntries(t *testing.T) {
+	for _, cidrs := range [][]string{{"10.0.0.0/33"}, {"10.0.0.0/8", "10.0.0.300"}, {"rede-interna"}} {
+		_, err := IPAllowlist(cidrs, false)
+		assert.Error(t, err, cidrs)
+	}
+
+	allowlist, err := IPAllowlist(nil, false)
+	assert.NoError(t, err)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	assert.NotNil(t, allowlist(next))
+}
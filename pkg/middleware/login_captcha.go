@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/ratelimit"
+)
+
+// CaptchaHeaderName é o cabeçalho com o token de captcha resolvido pelo cliente
+const CaptchaHeaderName = "X-Captcha-Token"
+
+// CaptchaVerifier valida tokens de captcha junto ao provedor (hCaptcha, reCAPTCHA ou Turnstile).
+// Outros provedores de proteção contra bots entram implementando esta interface, sem alterar o login.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// LoginCaptchaConfig configura a exigência de captcha no login
+type LoginCaptchaConfig struct {
+	Verifier          CaptchaVerifier
+	Failures          *ratelimit.Counter // falhas de login por IP
+	MaxFailures       int                // falhas permitidas antes de exigir captcha
+	TrustProxyHeaders bool
+}
+
+// LoginCaptcha conta as falhas de login por IP e, após MaxFailures, exige um token de captcha
+// válido no cabeçalho X-Captcha-Token em POST /v1/login. Um login bem-sucedido zera o contador.
+func LoginCaptcha(cfg LoginCaptchaConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg.Verifier == nil || cfg.Failures == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v1/login" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := ClientIP(r, cfg.TrustProxyHeaders)
+
+			if cfg.Failures.Count(ip) >= cfg.MaxFailures {
+				token := r.Header.Get(CaptchaHeaderName)
+				if token == "" {
+					apiErrors.WriteError(w, apiErrors.ErrCaptchaRequired, "Verificação de captcha obrigatória", nil)
+					return
+				}
+
+				ok, err := cfg.Verifier.Verify(r.Context(), token, ip)
+				if err != nil {
+					logrus.WithError(err).WithField("ip", ip).Error("Erro ao verificar captcha")
+					apiErrors.WriteError(w, apiErrors.ErrExternalService, "Não foi possível verificar o captcha", nil)
+					return
+				}
+				if !ok {
+					apiErrors.WriteError(w, apiErrors.ErrInvalidCaptcha, "Captcha inválido", nil)
+					return
+				}
+			}
+
+			lrw := newLoggingResponseWriter(w)
+			next.ServeHTTP(lrw, r)
+
+			switch {
+			case lrw.statusCode == http.StatusOK:
+				cfg.Failures.Reset(ip)
+			case lrw.statusCode == http.StatusUnauthorized || lrw.statusCode == http.StatusNotFound:
+				failures := cfg.Failures.Increment(ip)
+				if failures == cfg.MaxFailures {
+					logrus.WithField("ip", ip).Warn("Limite de falhas de login atingido, captcha passa a ser exigido")
+				}
+			}
+		})
+	}
+}
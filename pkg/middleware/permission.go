@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+const ContextKeyPermissions contextKey = "permissions"
+
+// PermissionsProvider resolve as permissões do perfil
+type PermissionsProvider interface {
+	Permissions(roleID int) domain.PermissionSet
+}
+
+// Permissions coloca no contexto as permissões do perfil do usuário autenticado, para RequirePermission
+// e HasPermission. Nas chaves de API, valem apenas os escopos da chave que o perfil também tem. Deve ser
+// registrado depois do AuthMiddleware.
+func Permissions(provider PermissionsProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			permissions := provider.Permissions(userClaims.UserRoleID)
+			if permissions == nil {
+				permissions = domain.PermissionSet{}
+			}
+			if userClaims.APIKeyID != 0 {
+				permissions = permissions.Intersect(userClaims.APIKeyScopes)
+			}
+
+			ctx := context.WithValue(r.Context(), ContextKeyPermissions, permissions)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequirePermission exige que o perfil do usuário tenha todas as permissões informadas
+func RequirePermission(permissions ...domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok {
+				logrus.Warning("Tentativa de acesso sem autenticação")
+				apiErrors.WriteError(w, apiErrors.ErrInvalidToken, "Usuário não autenticado", nil)
+				return
+			}
+
+			if !HasPermission(r.Context(), permissions...) {
+				logrus.Warningf("Acesso negado para usuário ID=%d, Role=%d (permissões exigidas=%v)",
+					userClaims.UserID, userClaims.UserRoleID, permissions)
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Você não tem permissão para acessar este recurso", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authenticated libera a rota para qualquer usuário autenticado, independentemente do perfil. Usado nas
+// rotas do próprio usuário (perfil, senha, preferências), que não aceitam chaves de API.
+func Authenticated() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return RequirePermission()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims); ok && userClaims.APIKeyID != 0 {
+				apiErrors.WriteError(w, apiErrors.ErrInsufficientPrivilege, "Recurso indisponível para chaves de API", nil)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// HasPermission indica se o perfil do usuário da requisição tem todas as permissões informadas
+func HasPermission(ctx context.Context, permissions ...domain.Permission) bool {
+	set, _ := ctx.Value(ContextKeyPermissions).(domain.PermissionSet)
+	return set.Has(permissions...)
+}
+
+// PermissionsFromContext retorna as permissões resolvidas pelo middleware Permissions
+func PermissionsFromContext(ctx context.Context) domain.PermissionSet {
+	set, _ := ctx.Value(ContextKeyPermissions).(domain.PermissionSet)
+	return set
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+)
+
+// PolicyAcceptanceChecker informa se o usuário aceitou a versão vigente dos termos de uso
+type PolicyAcceptanceChecker interface {
+	HasAcceptedCurrent(userID int) (bool, error)
+}
+
+// PolicyAcceptance bloqueia a API, inclusive por chaves de API, para usuários que ainda não aceitaram a
+// versão vigente dos termos de uso e da política de privacidade (LGPD). Ficam liberados o perfil, a troca
+// de senha e as rotas de consulta e aceite da política. Deve ser registrado depois do AuthMiddleware.
+func PolicyAcceptance(checker PolicyAcceptanceChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if checker == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok || allowedWithoutPolicyAcceptance(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			accepted, err := checker.HasAcceptedCurrent(userClaims.UserID)
+			if err != nil {
+				logrus.WithError(err).WithField("user_id", userClaims.UserID).Error("Erro ao verificar aceite da política")
+				apiErrors.WriteError(w, apiErrors.ErrDatabaseOperation, "Erro ao verificar aceite dos termos de uso", nil)
+				return
+			}
+			if !accepted {
+				apiErrors.WriteError(w, apiErrors.ErrPolicyNotAccepted, "Aceite os termos de uso e a política de privacidade para continuar", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func allowedWithoutPolicyAcceptance(path string) bool {
+	return strings.HasPrefix(path, "/v1/me/policy") || allowedWithPasswordChangeRequired(path)
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+	"github.com/vfg2006/traffic-manager-api/pkg/apiErrors"
+	"github.com/vfg2006/traffic-manager-api/pkg/ratelimit"
+)
+
+// UsageQuotaConfig configura a cota diária de requisições por usuário de um grupo de rotas
+type UsageQuotaConfig struct {
+	Scope      string             // nome do grupo de rotas (ex: live_insights, exports)
+	DailyLimit int                // requisições permitidas por usuário por dia; 0 desabilita a cota
+	Requests   *ratelimit.Counter // contador compartilhado pelas rotas do grupo
+	Now        func() time.Time
+}
+
+// NewUsageQuotaConfig cria a configuração com um contador próprio para o grupo de rotas
+func NewUsageQuotaConfig(scope string, dailyLimit int) UsageQuotaConfig {
+	return UsageQuotaConfig{
+		Scope:      scope,
+		DailyLimit: dailyLimit,
+		Requests:   ratelimit.NewCounter(24 * time.Hour),
+		Now:        time.Now,
+	}
+}
+
+// UsageQuota limita as requisições diárias de cada usuário às rotas caras (insights ao vivo, exportações),
+// para que um único usuário não consuma o limite de chamadas da Meta de todos. A cota reinicia à meia-noite.
+// Informa o consumo nos cabeçalhos X-RateLimit-* e responde 429 com Retry-After quando excedida.
+// Deve ser registrado depois do AuthMiddleware.
+func UsageQuota(cfg UsageQuotaConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg.DailyLimit <= 0 || cfg.Requests == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := r.Context().Value(ContextKeyUser).(*domain.Claims)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			now := cfg.Now()
+			day := now.Format(time.DateOnly)
+			resetAt := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+			// A data na chave reinicia a cota na virada do dia, independente da janela do contador
+			used := cfg.Requests.Increment(fmt.Sprintf("%s:%d:%s", cfg.Scope, userClaims.UserID, day))
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(cfg.DailyLimit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(cfg.DailyLimit-used, 0)))
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+			if used > cfg.DailyLimit {
+				if used == cfg.DailyLimit+1 {
+					logrus.WithFields(logrus.Fields{
+						"user_id": userClaims.UserID,
+						"scope":   cfg.Scope,
+						"limit":   cfg.DailyLimit,
+					}).Warn("Cota diária de uso excedida")
+				}
+
+				w.Header().Set("Retry-After", strconv.Itoa(int(resetAt.Sub(now).Seconds())))
+				apiErrors.WriteError(w, apiErrors.ErrQuotaExceeded, "Cota diária de uso excedida", map[string]any{
+					"scope":    cfg.Scope,
+					"limit":    cfg.DailyLimit,
+					"reset_at": resetAt,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
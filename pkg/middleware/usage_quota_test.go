@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfg2006/traffic-manager-api/internal/domain"
+)
+
+func TestUsageQuota(t *testing.T) {
+	now := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+	cfg := NewUsageQuotaConfig("exports", 2)
+	cfg.Now = func() time.Time { return now }
+
+	handler := UsageQuota(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name      string
+		claims    *domain.Claims
+		advance   time.Duration
+		expected  int
+		remaining string
+	}{
+		{name: "primeira requisição do dia", claims: &domain.Claims{UserID: 1}, expected: http.StatusOK, remaining: "1"},
+		{name: "última requisição da cota", claims: &domain.Claims{UserID: 1}, expected: http.StatusOK, remaining: "0"},
+		{name: "cota excedida", claims: &domain.Claims{UserID: 1}, expected: http.StatusTooManyRequests, remaining: "0"},
+		{name: "cota é individual por usuário", claims: &domain.Claims{UserID: 2}, expected: http.StatusOK, remaining: "1"},
+		{name: "requisição sem usuário não consome cota", expected: http.StatusOK},
+		{name: "cota reinicia na virada do dia", claims: &domain.Claims{UserID: 1}, advance: 2 * time.Hour, expected: http.StatusOK, remaining: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/insights/report/xlsx", nil)
+			if tt.claims != nil {
+				r = r.WithContext(context.WithValue(r.Context(), ContextKeyUser, tt.claims))
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+			assert.Equal(t, tt.remaining, w.Header().Get("X-RateLimit-Remaining"))
+			if tt.expected == http.StatusTooManyRequests {
+				assert.Equal(t, "3600", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestUsageQuota_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/insights/report/xlsx", nil)
+	r = r.WithContext(context.WithValue(r.Context(), ContextKeyUser, &domain.Claims{UserID: 1}))
+	w := httptest.NewRecorder()
+	UsageQuota(NewUsageQuotaConfig("exports", 0))(next).ServeHTTP(w, r)
+	assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+}
@@ -0,0 +1,64 @@
+// Package naming extrai dimensões estruturadas de nomes que seguem uma convenção com separador,
+// como as campanhas nomeadas "LOJA | PRODUTO | OBJETIVO".
+package naming
+
+import (
+	"slices"
+	"strings"
+)
+
+// Parser associa cada parte do nome, na ordem, a uma dimensão configurada
+type Parser struct {
+	separator  string
+	dimensions []string
+}
+
+// NewParser cria um parser. Os nomes das dimensões são normalizados em minúsculas.
+func NewParser(separator string, dimensions []string) *Parser {
+	normalized := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		dimension = strings.ToLower(strings.TrimSpace(dimension))
+		if dimension != "" {
+			normalized = append(normalized, dimension)
+		}
+	}
+
+	return &Parser{
+		separator:  separator,
+		dimensions: normalized,
+	}
+}
+
+// Dimensions retorna as dimensões configuradas, na ordem das partes do nome
+func (p *Parser) Dimensions() []string {
+	return p.dimensions
+}
+
+// HasDimension informa se a dimensão faz parte da convenção
+func (p *Parser) HasDimension(dimension string) bool {
+	return slices.Contains(p.dimensions, dimension)
+}
+
+// Parse extrai as dimensões do nome. Retorna nil quando o nome não segue a convenção (sem separador).
+// Partes vazias são ignoradas e partes além das dimensões configuradas são descartadas.
+func (p *Parser) Parse(name string) map[string]string {
+	if p == nil || p.separator == "" || len(p.dimensions) == 0 || !strings.Contains(name, p.separator) {
+		return nil
+	}
+
+	parts := strings.Split(name, p.separator)
+	values := make(map[string]string, len(p.dimensions))
+	for i, dimension := range p.dimensions {
+		if i >= len(parts) {
+			break
+		}
+		if value := strings.TrimSpace(parts[i]); value != "" {
+			values[dimension] = value
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
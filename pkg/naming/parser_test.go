@@ -0,0 +1,36 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Parse(t *testing.T) {
+	parser := NewParser("|", []string{"Loja", "produto", " objetivo "})
+
+	t.Run("extrai as dimensões na ordem", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"loja":     "CENTRO",
+			"produto":  "LENTES",
+			"objetivo": "VENDAS",
+		}, parser.Parse("CENTRO | LENTES | VENDAS"))
+	})
+
+	t.Run("ignora partes vazias e excedentes", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"loja":     "CENTRO",
+			"objetivo": "VENDAS",
+		}, parser.Parse("CENTRO || VENDAS | TESTE A"))
+	})
+
+	t.Run("nome fora da convenção", func(t *testing.T) {
+		assert.Nil(t, parser.Parse("Campanha de inverno"))
+	})
+
+	t.Run("dimensões configuradas", func(t *testing.T) {
+		assert.True(t, parser.HasDimension("loja"))
+		assert.False(t, parser.HasDimension("Loja"))
+		assert.Equal(t, []string{"loja", "produto", "objetivo"}, parser.Dimensions())
+	})
+}
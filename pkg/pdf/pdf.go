@@ -0,0 +1,222 @@
+// Package pdf gera documentos PDF simples sem dependências externas.
+//
+// O pacote cobre apenas o necessário para relatórios: páginas A4 com textos nas
+// fontes padrão Helvetica, retângulos preenchidos e linhas. As coordenadas são em
+// pontos a partir do canto superior esquerdo da página.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Dimensões da página A4 em pontos
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document representa um documento em construção
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page representa uma página do documento
+type Page struct {
+	content strings.Builder
+}
+
+// TextOptions define a formatação de um texto
+type TextOptions struct {
+	Size  float64 // Tamanho da fonte em pontos
+	Bold  bool    // Negrito
+	Color string  // Cor em hexadecimal (ex: "1F4E79")
+	Align string  // l, ctr ou r em relação ao x informado
+}
+
+// New cria um novo documento vazio com o título informado nas propriedades
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage adiciona uma nova página em branco
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// PageCount retorna o número de páginas do documento
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text escreve uma linha de texto com a linha de base em y
+func (p *Page) Text(x, y float64, text string, opts TextOptions) {
+	if opts.Size == 0 {
+		opts.Size = 10
+	}
+	if opts.Color == "" {
+		opts.Color = "262626"
+	}
+
+	switch opts.Align {
+	case "r":
+		x -= TextWidth(text, opts.Size, opts.Bold)
+	case "ctr":
+		x -= TextWidth(text, opts.Size, opts.Bold) / 2
+	}
+
+	font := "F1"
+	if opts.Bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		font, number(opts.Size), rgb(opts.Color), number(x), number(PageHeight-y), escape(text))
+}
+
+// Rect desenha um retângulo preenchido com o canto superior esquerdo em (x, y)
+func (p *Page) Rect(x, y, width, height float64, fill string) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		rgb(fill), number(x), number(PageHeight-y-height), number(width), number(height))
+}
+
+// Line desenha uma linha de 0,5 ponto entre os pontos informados
+func (p *Page) Line(x1, y1, x2, y2 float64, color string) {
+	fmt.Fprintf(&p.content, "%s RG 0.5 w %s %s m %s %s l S\n",
+		rgb(color), number(x1), number(PageHeight-y1), number(x2), number(PageHeight-y2))
+}
+
+// TextWidth estima a largura do texto em pontos a partir das métricas da Helvetica
+func TextWidth(text string, size float64, bold bool) float64 {
+	var units float64
+	for _, r := range text {
+		w, ok := charWidths[r]
+		if !ok {
+			w = 556
+		}
+		if bold && r >= 'A' && r <= 'z' {
+			w += 30
+		}
+		units += float64(w)
+	}
+	return units * size / 1000
+}
+
+// charWidths são as larguras (em milésimos do tamanho da fonte) dos caracteres mais comuns da Helvetica
+var charWidths = map[rune]int{
+	' ': 278, '.': 278, ',': 278, ':': 278, ';': 278, '!': 278, '|': 260, '/': 278, '-': 333, '(': 333, ')': 333,
+	'%': 889, '$': 556, '0': 556, '1': 556, '2': 556, '3': 556, '4': 556, '5': 556, '6': 556, '7': 556, '8': 556, '9': 556,
+	'i': 222, 'j': 222, 'l': 222, 'f': 278, 't': 278, 'r': 333, 'I': 278, 'J': 500, 'm': 833, 'w': 722, 'W': 944, 'M': 833,
+	'c': 500, 'k': 500, 's': 500, 'v': 500, 'x': 500, 'y': 500, 'z': 500, 'ç': 500,
+	'A': 667, 'B': 667, 'C': 722, 'D': 722, 'E': 667, 'F': 611, 'G': 778, 'H': 722, 'K': 667, 'L': 556, 'N': 722,
+	'O': 778, 'P': 667, 'Q': 778, 'R': 722, 'S': 667, 'T': 611, 'U': 722, 'V': 667, 'X': 667, 'Y': 667, 'Z': 611,
+}
+
+// escape converte o texto para WinAnsiEncoding e escapa os caracteres especiais de strings PDF.
+// Caracteres fora da codificação são trocados por "?".
+func escape(text string) string {
+	var buf bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r == '•':
+			buf.WriteByte(0x95)
+		case r == '–':
+			buf.WriteByte(0x96)
+		case r == '—':
+			buf.WriteByte(0x97)
+		case r == '…':
+			buf.WriteByte(0x85)
+		case r == '€':
+			buf.WriteByte(0x80)
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			buf.WriteByte(byte(r))
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
+
+func rgb(hex string) string {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		value = 0
+	}
+
+	r := float64(value>>16&0xFF) / 255
+	g := float64(value>>8&0xFF) / 255
+	b := float64(value&0xFF) / 255
+	return fmt.Sprintf("%s %s %s", number(r), number(g), number(b))
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 32)
+}
+
+// Bytes gera o arquivo .pdf em memória
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write escreve o arquivo .pdf no writer informado
+func (d *Document) Write(w io.Writer) error {
+	if len(d.pages) == 0 {
+		return fmt.Errorf("o documento precisa ter ao menos uma página")
+	}
+
+	// Objetos fixos: 1 catálogo, 2 árvore de páginas, 3 e 4 fontes, 5 propriedades.
+	// Cada página ocupa dois objetos a partir do 6: a página e o seu conteúdo.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title (%s) /Producer (traffic-manager-api) >>", escape(d.title)),
+	}
+
+	for i, p := range d.pages {
+		content := p.content.String()
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				number(PageWidth), number(PageHeight), 7+i*2),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,134 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	objectPattern = regexp.MustCompile(`(?s)^(\d+) 0 obj\n(.*?)\nendobj\n`)
+	streamPattern = regexp.MustCompile(`(?s)^<< /Length (\d+) >>\nstream\n(.*)endstream$`)
+	textPattern   = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\) Tj`)
+)
+
+// winAnsi são os caracteres que escape grava fora da faixa Latin-1
+var winAnsi = map[byte]rune{0x80: '€', 0x85: '…', 0x95: '•', 0x96: '–', 0x97: '—'}
+
+// parseDocument confere a tabela xref e retorna o corpo de cada objeto pelo número
+func parseDocument(t *testing.T, data []byte) map[int]string {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	trailer := string(data[bytes.LastIndex(data, []byte("trailer\n")):])
+	xref, err := strconv.Atoi(strings.Fields(trailer[strings.Index(trailer, "startxref"):])[1])
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+
+	lines := strings.Split(string(data[xref:]), "\n")
+	count, err := strconv.Atoi(strings.Fields(lines[1])[1])
+	require.NoError(t, err)
+	assert.Contains(t, trailer, "/Size "+strconv.Itoa(count))
+
+	objects := make(map[int]string, count-1)
+	for i := 1; i < count; i++ {
+		offset, err := strconv.Atoi(strings.Fields(lines[2+i])[0])
+		require.NoError(t, err)
+
+		match := objectPattern.FindSubmatch(data[offset:])
+		require.NotNil(t, match, "objeto %d fora do deslocamento da xref", i)
+		assert.Equal(t, strconv.Itoa(i), string(match[1]))
+		objects[i] = string(match[2])
+	}
+	return objects
+}
+
+// pageTexts lê o conteúdo da página, conferindo o /Length, e decodifica os textos escritos com Tj
+func pageTexts(t *testing.T, object string) []string {
+	t.Helper()
+	match := streamPattern.FindStringSubmatch(object)
+	require.NotNil(t, match)
+	length, err := strconv.Atoi(match[1])
+	require.NoError(t, err)
+	require.Len(t, match[2], length)
+
+	var texts []string
+	for _, m := range textPattern.FindAllStringSubmatch(match[2], -1) {
+		var text strings.Builder
+		raw := m[1]
+		for i := 0; i < len(raw); i++ {
+			c := raw[i]
+			if c == '\\' {
+				i++
+				c = raw[i]
+			}
+			if r, ok := winAnsi[c]; ok {
+				text.WriteRune(r)
+			} else {
+				text.WriteRune(rune(c))
+			}
+		}
+		texts = append(texts, text.String())
+	}
+	return texts
+}
+
+func TestDocument_RoundTrip(t *testing.T) {
+	doc := New("Relatório (março) \\ 2025")
+	first := doc.AddPage()
+	first.Text(40, 60, "Investimento: R$ 1.500,00", TextOptions{Size: 18, Bold: true, Color: "1F4E79"})
+	first.Text(PageWidth-40, 80, "Óculos • lentes – 10% (à vista) — 5€…", TextOptions{Align: "r"})
+	first.Rect(40, 100, 200, 20, "EAF1F8")
+	first.Line(40, 130, 240, 130, "A6A6A6")
+	doc.AddPage().Text(PageWidth/2, 60, "emoji 😎 e ideograma 眼鏡", TextOptions{Align: "ctr"})
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	objects := parseDocument(t, data)
+
+	require.Len(t, objects, 9)
+	assert.Contains(t, objects[2], "/Kids [6 0 R 8 0 R] /Count 2")
+	assert.Equal(t, "<< /Title (Relatório \\(março\\) \\\\ 2025) /Producer (traffic-manager-api) >>", decodeLatin1(objects[5]))
+	assert.Contains(t, objects[6], "/Contents 7 0 R")
+	assert.Contains(t, objects[8], "/Contents 9 0 R")
+
+	assert.Equal(t, []string{"Investimento: R$ 1.500,00", "Óculos • lentes – 10% (à vista) — 5€…"}, pageTexts(t, objects[7]))
+	assert.Contains(t, objects[7], "/F2 18 Tf")
+	assert.Contains(t, objects[7], "re f\n")
+	assert.Contains(t, objects[7], " l S\n")
+
+	// Caracteres fora da WinAnsiEncoding são trocados por "?"
+	assert.Equal(t, []string{"emoji ? e ideograma ??"}, pageTexts(t, objects[9]))
+
+	_, err = New("vazio").Bytes()
+	assert.Error(t, err)
+}
+
+func TestText_Align(t *testing.T) {
+	width := TextWidth("Total", 10, false)
+	// T (611) + o (556) + t (278) + a (556) + l (222) milésimos do tamanho da fonte
+	assert.InDelta(t, 22.23, width, 0.001)
+	assert.Greater(t, TextWidth("Total", 10, true), width)
+
+	page := &Page{}
+	page.Text(100, 0, "Total", TextOptions{Align: "r"})
+	page.Text(100, 0, "Total", TextOptions{Align: "ctr"})
+	lines := strings.Split(strings.TrimSpace(page.content.String()), "\n")
+	assert.Contains(t, lines[0], number(100-width)+" "+number(PageHeight)+" Td")
+	assert.Contains(t, lines[1], number(100-width/2)+" "+number(PageHeight)+" Td")
+}
+
+// decodeLatin1 converte bytes WinAnsi da faixa Latin-1 de volta para UTF-8
+func decodeLatin1(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		out.WriteRune(rune(text[i]))
+	}
+	return out.String()
+}
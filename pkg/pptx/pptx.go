@@ -0,0 +1,322 @@
+// Package pptx gera apresentações PowerPoint (OOXML) simples sem dependências externas.
+//
+// O pacote cobre apenas o necessário para relatórios: slides com título, caixas de
+// texto, tabelas e gráficos de barras desenhados com formas.
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Dimensões do slide em EMU (16:9)
+const (
+	SlideWidth  int64 = 12192000
+	SlideHeight int64 = 6858000
+
+	// EMUPerCm é a quantidade de EMUs em um centímetro
+	EMUPerCm int64 = 360000
+)
+
+// Presentation representa uma apresentação em construção
+type Presentation struct {
+	slides []*Slide
+}
+
+// Slide representa um slide da apresentação
+type Slide struct {
+	title  string
+	shapes []string
+	nextID int
+}
+
+// TextOptions define a formatação de uma caixa de texto
+type TextOptions struct {
+	Size  int    // Tamanho da fonte em pontos
+	Bold  bool   // Negrito
+	Color string // Cor em hexadecimal (ex: "1F4E79")
+	Align string // l, ctr ou r
+}
+
+// Bar representa uma barra em um gráfico de barras
+type Bar struct {
+	Label string
+	Value float64
+}
+
+// New cria uma nova apresentação vazia
+func New() *Presentation {
+	return &Presentation{}
+}
+
+// AddSlide adiciona um novo slide com o título informado
+func (p *Presentation) AddSlide(title string) *Slide {
+	s := &Slide{title: title, nextID: 2}
+	if title != "" {
+		s.AddText(EMUPerCm, EMUPerCm/2, SlideWidth-2*EMUPerCm, 2*EMUPerCm, title, TextOptions{Size: 28, Bold: true, Color: "1F4E79"})
+	}
+	p.slides = append(p.slides, s)
+	return s
+}
+
+// SlideCount retorna o número de slides da apresentação
+func (p *Presentation) SlideCount() int {
+	return len(p.slides)
+}
+
+// AddText adiciona uma caixa de texto ao slide. Quebras de linha geram novos parágrafos.
+func (s *Slide) AddText(x, y, cx, cy int64, text string, opts TextOptions) {
+	if opts.Size == 0 {
+		opts.Size = 14
+	}
+	if opts.Color == "" {
+		opts.Color = "262626"
+	}
+	if opts.Align == "" {
+		opts.Align = "l"
+	}
+
+	var paragraphs strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		paragraphs.WriteString(fmt.Sprintf(`<a:p><a:pPr algn="%s"/>%s</a:p>`, opts.Align, run(line, opts)))
+	}
+
+	id := s.id()
+	s.shapes = append(s.shapes, fmt.Sprintf(
+		`<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Texto %d"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>`+
+			`<p:spPr><a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom><a:noFill/></p:spPr>`+
+			`<p:txBody><a:bodyPr wrap="square" rtlCol="0" anchor="ctr"><a:normAutofit/></a:bodyPr><a:lstStyle/>%s</p:txBody></p:sp>`,
+		id, id, x, y, cx, cy, paragraphs.String()))
+}
+
+// AddRect adiciona um retângulo preenchido ao slide
+func (s *Slide) AddRect(x, y, cx, cy int64, fill string) {
+	id := s.id()
+	s.shapes = append(s.shapes, fmt.Sprintf(
+		`<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Forma %d"/><p:cNvSpPr/><p:nvPr/></p:nvSpPr>`+
+			`<p:spPr><a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom>`+
+			`<a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:ln><a:noFill/></a:ln></p:spPr></p:sp>`,
+		id, id, x, y, cx, cy, fill))
+}
+
+// AddTable adiciona uma tabela ao slide. A primeira linha é tratada como cabeçalho.
+func (s *Slide) AddTable(x, y, cx, cy int64, rows [][]string) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return
+	}
+
+	cols := len(rows[0])
+	colWidth := cx / int64(cols)
+	rowHeight := cy / int64(len(rows))
+
+	var grid strings.Builder
+	for range cols {
+		grid.WriteString(fmt.Sprintf(`<a:gridCol w="%d"/>`, colWidth))
+	}
+
+	var body strings.Builder
+	for i, row := range rows {
+		body.WriteString(fmt.Sprintf(`<a:tr h="%d">`, rowHeight))
+		for c := range cols {
+			value := ""
+			if c < len(row) {
+				value = row[c]
+			}
+
+			opts := TextOptions{Size: 12, Color: "262626"}
+			fill := "FFFFFF"
+			if i == 0 {
+				opts = TextOptions{Size: 12, Bold: true, Color: "FFFFFF"}
+				fill = "1F4E79"
+			} else if i%2 == 0 {
+				fill = "EAF1F8"
+			}
+
+			body.WriteString(fmt.Sprintf(
+				`<a:tc><a:txBody><a:bodyPr/><a:lstStyle/><a:p>%s</a:p></a:txBody><a:tcPr><a:solidFill><a:srgbClr val="%s"/></a:solidFill></a:tcPr></a:tc>`,
+				run(value, opts), fill))
+		}
+		body.WriteString(`</a:tr>`)
+	}
+
+	id := s.id()
+	s.shapes = append(s.shapes, fmt.Sprintf(
+		`<p:graphicFrame><p:nvGraphicFramePr><p:cNvPr id="%d" name="Tabela %d"/><p:cNvGraphicFramePr><a:graphicFrameLocks noGrp="1"/></p:cNvGraphicFramePr><p:nvPr/></p:nvGraphicFramePr>`+
+			`<p:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></p:xfrm>`+
+			`<a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/table"><a:tbl><a:tblPr firstRow="1" bandRow="1"/><a:tblGrid>%s</a:tblGrid>%s</a:tbl></a:graphicData></a:graphic></p:graphicFrame>`,
+		id, id, x, y, cx, cy, grid.String(), body.String()))
+}
+
+// AddBarChart desenha um gráfico de barras verticais com formas nativas.
+// Os valores são escalados pelo maior valor da série; format formata o rótulo de cada barra.
+func (s *Slide) AddBarChart(x, y, cx, cy int64, title string, bars []Bar, fill string, format func(float64) string) {
+	const titleHeight = 500000
+	const labelHeight = 350000
+
+	s.AddText(x, y, cx, titleHeight, title, TextOptions{Size: 16, Bold: true})
+
+	if len(bars) == 0 {
+		s.AddText(x, y+titleHeight, cx, cy-titleHeight, "Sem dados para o período", TextOptions{Size: 12, Align: "ctr", Color: "7F7F7F"})
+		return
+	}
+
+	maxValue := 0.0
+	for _, b := range bars {
+		if b.Value > maxValue {
+			maxValue = b.Value
+		}
+	}
+
+	plotTop := y + titleHeight + labelHeight
+	plotHeight := cy - titleHeight - 2*labelHeight
+	slot := cx / int64(len(bars))
+	barWidth := slot * 6 / 10
+
+	// Linha de base
+	s.AddRect(x, plotTop+plotHeight, cx, 12700, "A6A6A6")
+
+	for i, b := range bars {
+		barX := x + int64(i)*slot + (slot-barWidth)/2
+
+		height := int64(0)
+		if maxValue > 0 && b.Value > 0 {
+			height = int64(float64(plotHeight) * b.Value / maxValue)
+		}
+		barY := plotTop + plotHeight - height
+
+		if height > 0 {
+			s.AddRect(barX, barY, barWidth, height, fill)
+		}
+
+		s.AddText(x+int64(i)*slot, barY-labelHeight, slot, labelHeight, format(b.Value), TextOptions{Size: 10, Align: "ctr"})
+		s.AddText(x+int64(i)*slot, plotTop+plotHeight, slot, labelHeight, b.Label, TextOptions{Size: 10, Align: "ctr", Color: "595959"})
+	}
+}
+
+func (s *Slide) id() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func run(text string, opts TextOptions) string {
+	bold := "0"
+	if opts.Bold {
+		bold = "1"
+	}
+
+	return fmt.Sprintf(`<a:r><a:rPr lang="pt-BR" sz="%d" b="%s" dirty="0"><a:solidFill><a:srgbClr val="%s"/></a:solidFill></a:rPr><a:t>%s</a:t></a:r>`,
+		opts.Size*100, bold, opts.Color, escape(text))
+}
+
+func escape(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// part representa um arquivo dentro do pacote .pptx
+type part struct {
+	name    string
+	content string
+}
+
+// Bytes gera o arquivo .pptx em memória
+func (p *Presentation) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write escreve o arquivo .pptx no writer informado
+func (p *Presentation) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []part{
+		{"[Content_Types].xml", p.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"docProps/app.xml", appProps},
+		{"docProps/core.xml", coreProps},
+		{"ppt/presentation.xml", p.presentation()},
+		{"ppt/_rels/presentation.xml.rels", p.presentationRels()},
+		{"ppt/slideMasters/slideMaster1.xml", slideMaster},
+		{"ppt/slideMasters/_rels/slideMaster1.xml.rels", slideMasterRels},
+		{"ppt/slideLayouts/slideLayout1.xml", slideLayout},
+		{"ppt/slideLayouts/_rels/slideLayout1.xml.rels", slideLayoutRels},
+		{"ppt/theme/theme1.xml", theme},
+	}
+
+	for i, s := range p.slides {
+		files = append(files,
+			part{fmt.Sprintf("ppt/slides/slide%d.xml", i+1), s.xml()},
+			part{fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", i+1), slideRels},
+		)
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("erro ao criar %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("erro ao escrever %s: %w", f.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func (p *Presentation) contentTypes() string {
+	var overrides strings.Builder
+	for i := range p.slides {
+		overrides.WriteString(fmt.Sprintf(`<Override PartName="/ppt/slides/slide%d.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slide+xml"/>`, i+1))
+	}
+
+	return xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/ppt/presentation.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"/>` +
+		`<Override PartName="/ppt/slideMasters/slideMaster1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"/>` +
+		`<Override PartName="/ppt/slideLayouts/slideLayout1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"/>` +
+		`<Override PartName="/ppt/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>` +
+		`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+		`<Override PartName="/docProps/app.xml" ContentType="application/vnd.openxmlformats-officedocument.extended-properties+xml"/>` +
+		overrides.String() +
+		`</Types>`
+}
+
+func (p *Presentation) presentation() string {
+	var ids strings.Builder
+	for i := range p.slides {
+		ids.WriteString(fmt.Sprintf(`<p:sldId id="%d" r:id="rId%d"/>`, 256+i, i+2))
+	}
+
+	return xmlHeader + `<p:presentation xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" saveSubsetFonts="1">` +
+		`<p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst>` +
+		`<p:sldIdLst>` + ids.String() + `</p:sldIdLst>` +
+		fmt.Sprintf(`<p:sldSz cx="%d" cy="%d"/><p:notesSz cx="6858000" cy="9144000"/>`, SlideWidth, SlideHeight) +
+		`</p:presentation>`
+}
+
+func (p *Presentation) presentationRels() string {
+	var rels strings.Builder
+	rels.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideMaster" Target="slideMasters/slideMaster1.xml"/>`)
+	for i := range p.slides {
+		rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide%d.xml"/>`, i+2, i+1))
+	}
+	rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/>`, len(p.slides)+2))
+
+	return xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() + `</Relationships>`
+}
+
+func (s *Slide) xml() string {
+	return xmlHeader + `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+		`<p:cSld><p:spTree>` + spTreeHeader + strings.Join(s.shapes, "") + `</p:spTree></p:cSld>` +
+		`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`
+}
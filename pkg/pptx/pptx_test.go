@@ -0,0 +1,137 @@
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readPackage abre o .pptx gerado e retorna o conteúdo de cada parte
+func readPackage(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+// slideTexts decodifica o XML do slide e retorna o texto de cada parágrafo, na ordem
+func slideTexts(t *testing.T, content []byte) []string {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	var texts []string
+	var paragraph strings.Builder
+	inText := false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return texts
+		}
+		require.NoError(t, err)
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "p" && el.Name.Space == "http://schemas.openxmlformats.org/drawingml/2006/main" {
+				paragraph.Reset()
+			}
+			inText = el.Name.Local == "t"
+		case xml.EndElement:
+			if el.Name.Local == "p" && el.Name.Space == "http://schemas.openxmlformats.org/drawingml/2006/main" {
+				texts = append(texts, paragraph.String())
+			}
+			inText = false
+		case xml.CharData:
+			if inText {
+				paragraph.Write(el)
+			}
+		}
+	}
+}
+
+func TestPresentation_RoundTrip(t *testing.T) {
+	p := New()
+	cover := p.AddSlide("Relatório <Março> & \"Abril\"")
+	cover.AddText(0, 0, EMUPerCm, EMUPerCm, "linha 1\nÓculos & lentes 😎", TextOptions{Bold: true, Align: "ctr"})
+	cover.AddTable(0, 0, 10*EMUPerCm, 4*EMUPerCm, [][]string{{"Loja", "Vendas"}, {"Centro", "R$ 1.500,00"}, {"Norte"}})
+
+	chart := p.AddSlide("")
+	chart.AddBarChart(0, 0, 10*EMUPerCm, 5*EMUPerCm, "Investimento", []Bar{{Label: "Jan", Value: 10}, {Label: "Fev", Value: 0}}, "2E75B6", func(v float64) string {
+		return strings.Repeat("|", int(v))
+	})
+	p.AddSlide("Sem dados").AddBarChart(0, 0, EMUPerCm, EMUPerCm, "Vazio", nil, "2E75B6", nil)
+
+	data, err := p.Bytes()
+	require.NoError(t, err)
+	parts := readPackage(t, data)
+
+	t.Run("todas as partes são XML válido", func(t *testing.T) {
+		for name, content := range parts {
+			decoder := xml.NewDecoder(bytes.NewReader(content))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err, name)
+			}
+		}
+	})
+
+	t.Run("tipos de conteúdo e relacionamentos apontam para partes existentes", func(t *testing.T) {
+		var types struct {
+			Overrides []struct {
+				PartName string `xml:"PartName,attr"`
+			} `xml:"Override"`
+		}
+		require.NoError(t, xml.Unmarshal(parts["[Content_Types].xml"], &types))
+		for _, override := range types.Overrides {
+			assert.Contains(t, parts, strings.TrimPrefix(override.PartName, "/"))
+		}
+
+		for name, content := range parts {
+			if !strings.HasSuffix(name, ".rels") {
+				continue
+			}
+			var rels struct {
+				Relationships []struct {
+					Target string `xml:"Target,attr"`
+				} `xml:"Relationship"`
+			}
+			require.NoError(t, xml.Unmarshal(content, &rels), name)
+
+			// As partes de _rels/x.xml.rels são relativas à pasta de x.xml
+			base := path.Dir(path.Dir(name))
+			for _, rel := range rels.Relationships {
+				assert.Contains(t, parts, path.Clean(path.Join(base, rel.Target)), name)
+			}
+		}
+	})
+
+	t.Run("textos voltam iguais aos informados", func(t *testing.T) {
+		assert.Equal(t, 3, p.SlideCount())
+		assert.Equal(t, []string{
+			"Relatório <Março> & \"Abril\"",
+			"linha 1", "Óculos & lentes 😎",
+			"Loja", "Vendas", "Centro", "R$ 1.500,00", "Norte", "",
+		}, slideTexts(t, parts["ppt/slides/slide1.xml"]))
+		assert.Equal(t, []string{"Investimento", "||||||||||", "Jan", "", "Fev"}, slideTexts(t, parts["ppt/slides/slide2.xml"]))
+		assert.Equal(t, []string{"Sem dados", "Vazio", "Sem dados para o período"}, slideTexts(t, parts["ppt/slides/slide3.xml"]))
+	})
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket libera até burst eventos de uma vez e, depois, rate eventos por segundo, dividindo a vazão entre
+// todas as goroutines que o compartilham. Cada Wait reserva um token; quem chega com o balde vazio espera a
+// sua vez, na ordem das reservas. É mantido em memória, então cada instância da API possui o seu.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket cria um balde cheio. Com rate <= 0, os eventos não são limitados.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+		now:    time.Now,
+	}
+}
+
+// Wait aguarda um token ou o cancelamento do contexto, retornando o erro do contexto nesse caso.
+// O token reservado por uma espera cancelada não é devolvido.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve consome um token e retorna a espera até ele estar disponível
+func (b *TokenBucket) reserve() time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Drain esvazia o balde por d: o próximo token só fica disponível depois desse tempo. Reservas já feitas
+// não são alteradas.
+func (b *TokenBucket) Drain(d time.Duration) {
+	if b.rate <= 0 || d <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(b.now())
+	debt := -d.Seconds() * b.rate
+	if b.tokens > debt {
+		b.tokens = debt
+	}
+}
+
+// Rate retorna os eventos por segundo liberados pelo balde
+func (b *TokenBucket) Rate() float64 {
+	return b.rate
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
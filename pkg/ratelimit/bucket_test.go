@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	bucket := NewTokenBucket(2, 2)
+	bucket.now = func() time.Time { return now }
+
+	// O burst é liberado de uma vez; depois, um token a cada meio segundo, na ordem das reservas
+	assert.Zero(t, bucket.reserve())
+	assert.Zero(t, bucket.reserve())
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+	assert.Equal(t, time.Second, bucket.reserve())
+
+	// O balde não acumula além do burst
+	now = now.Add(time.Minute)
+	assert.Zero(t, bucket.reserve())
+	assert.Zero(t, bucket.reserve())
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+}
+
+func TestTokenBucket_Drain(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	bucket := NewTokenBucket(1, 5)
+	bucket.now = func() time.Time { return now }
+
+	bucket.Drain(30 * time.Second)
+	assert.Equal(t, 31*time.Second, bucket.reserve())
+
+	now = now.Add(time.Minute)
+	assert.Zero(t, bucket.reserve())
+}
+
+func TestTokenBucket_Unlimited(t *testing.T) {
+	bucket := NewTokenBucket(0, 1)
+	for range 100 {
+		assert.Zero(t, bucket.reserve())
+	}
+	bucket.Drain(time.Hour)
+	assert.NoError(t, bucket.Wait(context.Background()))
+}
+
+func TestTokenBucket_WaitCanceled(t *testing.T) {
+	bucket := NewTokenBucket(0.001, 1)
+	assert.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bucket.Wait(ctx), context.DeadlineExceeded)
+}
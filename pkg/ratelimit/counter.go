@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Counter conta eventos por chave (IP, usuário, etc.) em janelas fixas de tempo.
+// É mantido em memória, então cada instância da API possui seus próprios contadores.
+type Counter struct {
+	mu        sync.Mutex
+	window    time.Duration
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type entry struct {
+	count   int
+	resetAt time.Time
+}
+
+// NewCounter cria um contador cuja janela reinicia após window
+func NewCounter(window time.Duration) *Counter {
+	return &Counter{
+		window:  window,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// Increment registra um evento para a chave e retorna o total na janela atual
+func (c *Counter) Increment(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.sweep(now)
+
+	e, ok := c.entries[key]
+	if !ok || !now.Before(e.resetAt) {
+		e = &entry{resetAt: now.Add(c.window)}
+		c.entries[key] = e
+	}
+	e.count++
+
+	return e.count
+}
+
+// Count retorna o total de eventos da chave na janela atual
+func (c *Counter) Count(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.resetAt) {
+		return 0
+	}
+	return e.count
+}
+
+// ResetAt retorna quando a janela atual da chave termina. Zero se não houver eventos.
+func (c *Counter) ResetAt(key string) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.resetAt) {
+		return time.Time{}
+	}
+	return e.resetAt
+}
+
+// Reset zera o contador da chave
+func (c *Counter) Reset(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// sweep remove as janelas expiradas no máximo uma vez por janela, evitando crescimento indefinido do mapa
+func (c *Counter) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.window {
+		return
+	}
+	c.lastSweep = now
+
+	for key, e := range c.entries {
+		if !now.Before(e.resetAt) {
+			delete(c.entries, key)
+		}
+	}
+}
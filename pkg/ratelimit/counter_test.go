@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter_Window(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	counter := NewCounter(time.Minute)
+	counter.now = func() time.Time { return now }
+
+	assert.Equal(t, 1, counter.Increment("1.1.1.1"))
+	assert.Equal(t, 2, counter.Increment("1.1.1.1"))
+	assert.Equal(t, 1, counter.Increment("2.2.2.2"))
+	assert.Equal(t, 2, counter.Count("1.1.1.1"))
+	assert.Equal(t, now.Add(time.Minute), counter.ResetAt("1.1.1.1"))
+
+	counter.Reset("2.2.2.2")
+	assert.Equal(t, 0, counter.Count("2.2.2.2"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 0, counter.Count("1.1.1.1"))
+	assert.Equal(t, 1, counter.Increment("1.1.1.1"))
+}
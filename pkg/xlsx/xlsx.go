@@ -0,0 +1,236 @@
+// Package xlsx gera planilhas Excel (OOXML) simples sem dependências externas.
+//
+// O pacote cobre apenas o necessário para exportações: várias abas com linha de
+// cabeçalho em negrito, textos e números.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxSheetName é o tamanho máximo do nome de uma aba aceito pelo Excel
+const maxSheetName = 31
+
+// Workbook representa uma planilha em construção
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// Sheet representa uma aba da planilha
+type Sheet struct {
+	name string
+	rows []string
+}
+
+// New cria uma nova planilha vazia
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet adiciona uma nova aba. Caracteres não aceitos pelo Excel são removidos do nome.
+func (wb *Workbook) AddSheet(name string) *Sheet {
+	s := &Sheet{name: sheetName(name, len(wb.sheets)+1)}
+	wb.sheets = append(wb.sheets, s)
+	return s
+}
+
+// SheetCount retorna o número de abas da planilha
+func (wb *Workbook) SheetCount() int {
+	return len(wb.sheets)
+}
+
+// RowCount retorna o número de linhas da aba, incluindo o cabeçalho
+func (s *Sheet) RowCount() int {
+	return len(s.rows)
+}
+
+// AddHeader adiciona uma linha com os valores em negrito
+func (s *Sheet) AddHeader(values ...string) {
+	cells := make([]any, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+	s.addRow(cells, true)
+}
+
+// AddRow adiciona uma linha. Aceita string, inteiros e float64; nil gera uma célula vazia.
+func (s *Sheet) AddRow(values ...any) {
+	s.addRow(values, false)
+}
+
+func (s *Sheet) addRow(values []any, bold bool) {
+	index := len(s.rows) + 1
+	style := ""
+	if bold {
+		style = ` s="1"`
+	}
+
+	var row strings.Builder
+	row.WriteString(fmt.Sprintf(`<row r="%d">`, index))
+	for i, value := range values {
+		ref := fmt.Sprintf("%s%d", columnName(i), index)
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			row.WriteString(fmt.Sprintf(`<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(v)))
+		case int:
+			row.WriteString(fmt.Sprintf(`<c r="%s"%s><v>%d</v></c>`, ref, style, v))
+		case int64:
+			row.WriteString(fmt.Sprintf(`<c r="%s"%s><v>%d</v></c>`, ref, style, v))
+		case float64:
+			row.WriteString(fmt.Sprintf(`<c r="%s"%s><v>%s</v></c>`, ref, style, strconv.FormatFloat(v, 'f', -1, 64)))
+		default:
+			row.WriteString(fmt.Sprintf(`<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(fmt.Sprint(v))))
+		}
+	}
+	row.WriteString(`</row>`)
+
+	s.rows = append(s.rows, row.String())
+}
+
+// columnName converte o índice da coluna (a partir de 0) para a notação do Excel (A, B, ..., AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func sheetName(name string, position int) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	if runes := []rune(name); len(runes) > maxSheetName {
+		name = string(runes[:maxSheetName])
+	}
+	if name == "" {
+		name = fmt.Sprintf("Planilha%d", position)
+	}
+	return name
+}
+
+func escape(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// part representa um arquivo dentro do pacote .xlsx
+type part struct {
+	name    string
+	content string
+}
+
+// Bytes gera o arquivo .xlsx em memória
+func (wb *Workbook) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := wb.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write escreve o arquivo .xlsx no writer informado
+func (wb *Workbook) Write(w io.Writer) error {
+	if len(wb.sheets) == 0 {
+		return fmt.Errorf("a planilha precisa ter ao menos uma aba")
+	}
+
+	zw := zip.NewWriter(w)
+
+	files := []part{
+		{"[Content_Types].xml", wb.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", wb.workbook()},
+		{"xl/_rels/workbook.xml.rels", wb.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+
+	for i, s := range wb.sheets {
+		files = append(files, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), s.xml()})
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("erro ao criar %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("erro ao escrever %s: %w", f.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func (wb *Workbook) contentTypes() string {
+	var overrides strings.Builder
+	for i := range wb.sheets {
+		overrides.WriteString(fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1))
+	}
+
+	return xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		overrides.String() +
+		`</Types>`
+}
+
+func (wb *Workbook) workbook() string {
+	var sheets strings.Builder
+	for i, s := range wb.sheets {
+		sheets.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(s.name), i+1, i+1))
+	}
+
+	return xmlHeader + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets>` + sheets.String() + `</sheets>` +
+		`</workbook>`
+}
+
+func (wb *Workbook) workbookRels() string {
+	var rels strings.Builder
+	for i := range wb.sheets {
+		rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1))
+	}
+	rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(wb.sheets)+1))
+
+	return xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() + `</Relationships>`
+}
+
+func (s *Sheet) xml() string {
+	return xmlHeader + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheetData>` + strings.Join(s.rows, "") + `</sheetData>` +
+		`</worksheet>`
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const rootRels = xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles define dois formatos de célula: 0 (padrão) e 1 (negrito, usado nos cabeçalhos)
+const styles = xmlHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
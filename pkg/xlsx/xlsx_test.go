@@ -0,0 +1,153 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  string `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readPackage abre o .xlsx gerado e retorna o conteúdo de cada parte
+func readPackage(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+// cellValues lê a aba e retorna o valor de cada célula pela referência (ex: B2)
+func cellValues(t *testing.T, content []byte) (map[string]string, map[string]bool) {
+	t.Helper()
+	var sheet sheetXML
+	require.NoError(t, xml.Unmarshal(content, &sheet))
+
+	values := make(map[string]string)
+	bold := make(map[string]bool)
+	for _, row := range sheet.Rows {
+		for _, cell := range row.Cells {
+			assert.True(t, strings.HasSuffix(cell.Ref, row.Ref), cell.Ref)
+			if cell.Type == "inlineStr" {
+				values[cell.Ref] = cell.Inline
+			} else {
+				values[cell.Ref] = cell.Value
+			}
+			bold[cell.Ref] = cell.Style == "1"
+		}
+	}
+	return values, bold
+}
+
+func TestWorkbook_RoundTrip(t *testing.T) {
+	wb := New()
+	summary := wb.AddSheet("Resumo: março/2025")
+	summary.AddHeader("Conta", "Investimento", "Vendas")
+	summary.AddRow("Loja <Centro> & \"Cia\"", 1500.75, 12)
+	summary.AddRow("  espaços\nquebra  ", nil, int64(-3))
+	summary.AddRow("Óculos 😎", 0.1, struct{ ID int }{ID: 7})
+
+	wb.AddSheet("[]:*?/\\")
+	wb.AddSheet(strings.Repeat("Campanhas ", 5)).AddRow("a")
+
+	data, err := wb.Bytes()
+	require.NoError(t, err)
+	parts := readPackage(t, data)
+
+	t.Run("nomes das abas", func(t *testing.T) {
+		var workbook struct {
+			Sheets []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"sheets>sheet"`
+		}
+		require.NoError(t, xml.Unmarshal(parts["xl/workbook.xml"], &workbook))
+		require.Len(t, workbook.Sheets, 3)
+		assert.Equal(t, "Resumo março2025", workbook.Sheets[0].Name)
+		assert.Equal(t, "Planilha2", workbook.Sheets[1].Name)
+		assert.Equal(t, "Campanhas Campanhas Campanhas C", workbook.Sheets[2].Name)
+	})
+
+	t.Run("relacionamentos apontam para partes existentes", func(t *testing.T) {
+		for name, content := range parts {
+			if !strings.HasSuffix(name, ".rels") {
+				continue
+			}
+			var rels struct {
+				Relationships []struct {
+					Target string `xml:"Target,attr"`
+				} `xml:"Relationship"`
+			}
+			require.NoError(t, xml.Unmarshal(content, &rels), name)
+
+			base := path.Dir(path.Dir(name))
+			for _, rel := range rels.Relationships {
+				assert.Contains(t, parts, path.Clean(path.Join(base, rel.Target)), name)
+			}
+		}
+	})
+
+	t.Run("valores voltam iguais aos informados", func(t *testing.T) {
+		values, bold := cellValues(t, parts["xl/worksheets/sheet1.xml"])
+		assert.Equal(t, map[string]string{
+			"A1": "Conta", "B1": "Investimento", "C1": "Vendas",
+			"A2": "Loja <Centro> & \"Cia\"", "B2": "1500.75", "C2": "12",
+			"A3": "  espaços\nquebra  ", "C3": "-3",
+			"A4": "Óculos 😎", "B4": "0.1", "C4": "{7}",
+		}, values)
+		assert.True(t, bold["A1"])
+		assert.False(t, bold["A2"])
+		assert.Equal(t, 4, summary.RowCount())
+
+		values, _ = cellValues(t, parts["xl/worksheets/sheet2.xml"])
+		assert.Empty(t, values)
+	})
+
+	_, err = New().Bytes()
+	assert.Error(t, err)
+}
+
+func TestColumnName(t *testing.T) {
+	// Converte de volta para o índice, conferindo a sequência A..Z, AA..ZZ, AAA
+	index := func(name string) int {
+		value := 0
+		for _, c := range name {
+			value = value*26 + int(c-'A') + 1
+		}
+		return value - 1
+	}
+
+	for i := range 800 {
+		assert.Equal(t, i, index(columnName(i)))
+	}
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "ZZ", columnName(701))
+	assert.Equal(t, "AAA", columnName(702))
+}